@@ -70,8 +70,13 @@ func provideCleanup(
 	schedulerSnapshot *service.SchedulerSnapshotService,
 	tokenRefresh *service.TokenRefreshService,
 	accountExpiry *service.AccountExpiryService,
+	accountDrain *service.AccountDrainService,
 	subscriptionExpiry *service.SubscriptionExpiryService,
 	usageCleanup *service.UsageCleanupService,
+	webhook *service.WebhookService,
+	messageBatch *service.MessageBatchService,
+	balanceLedger *service.BalanceLedgerService,
+	credentialReencrypt *service.CredentialReencryptService,
 	pricing *service.PricingService,
 	emailQueue *service.EmailQueueService,
 	billingCache *service.BillingCacheService,
@@ -89,6 +94,12 @@ func provideCleanup(
 			name string
 			fn   func() error
 		}{
+			{"MessageBatchService", func() error {
+				if messageBatch != nil {
+					messageBatch.Stop()
+				}
+				return nil
+			}},
 			{"OpsScheduledReportService", func() error {
 				if opsScheduledReport != nil {
 					opsScheduledReport.Stop()
@@ -131,6 +142,24 @@ func provideCleanup(
 				}
 				return nil
 			}},
+			{"WebhookService", func() error {
+				if webhook != nil {
+					webhook.Stop()
+				}
+				return nil
+			}},
+			{"BalanceLedgerService", func() error {
+				if balanceLedger != nil {
+					balanceLedger.Stop()
+				}
+				return nil
+			}},
+			{"CredentialReencryptService", func() error {
+				if credentialReencrypt != nil {
+					credentialReencrypt.Stop()
+				}
+				return nil
+			}},
 			{"TokenRefreshService", func() error {
 				tokenRefresh.Stop()
 				return nil
@@ -139,6 +168,10 @@ func provideCleanup(
 				accountExpiry.Stop()
 				return nil
 			}},
+			{"AccountDrainService", func() error {
+				accountDrain.Stop()
+				return nil
+			}},
 			{"SubscriptionExpiryService", func() error {
 				subscriptionExpiry.Stop()
 				return nil
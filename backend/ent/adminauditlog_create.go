@@ -0,0 +1,1244 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/adminauditlog"
+)
+
+// AdminAuditLogCreate is the builder for creating a AdminAuditLog entity.
+type AdminAuditLogCreate struct {
+	config
+	mutation *AdminAuditLogMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetActorUserID sets the "actor_user_id" field.
+func (_c *AdminAuditLogCreate) SetActorUserID(v int64) *AdminAuditLogCreate {
+	_c.mutation.SetActorUserID(v)
+	return _c
+}
+
+// SetNillableActorUserID sets the "actor_user_id" field if the given value is not nil.
+func (_c *AdminAuditLogCreate) SetNillableActorUserID(v *int64) *AdminAuditLogCreate {
+	if v != nil {
+		_c.SetActorUserID(*v)
+	}
+	return _c
+}
+
+// SetAuthMethod sets the "auth_method" field.
+func (_c *AdminAuditLogCreate) SetAuthMethod(v string) *AdminAuditLogCreate {
+	_c.mutation.SetAuthMethod(v)
+	return _c
+}
+
+// SetNillableAuthMethod sets the "auth_method" field if the given value is not nil.
+func (_c *AdminAuditLogCreate) SetNillableAuthMethod(v *string) *AdminAuditLogCreate {
+	if v != nil {
+		_c.SetAuthMethod(*v)
+	}
+	return _c
+}
+
+// SetMethod sets the "method" field.
+func (_c *AdminAuditLogCreate) SetMethod(v string) *AdminAuditLogCreate {
+	_c.mutation.SetMethod(v)
+	return _c
+}
+
+// SetRoute sets the "route" field.
+func (_c *AdminAuditLogCreate) SetRoute(v string) *AdminAuditLogCreate {
+	_c.mutation.SetRoute(v)
+	return _c
+}
+
+// SetPath sets the "path" field.
+func (_c *AdminAuditLogCreate) SetPath(v string) *AdminAuditLogCreate {
+	_c.mutation.SetPath(v)
+	return _c
+}
+
+// SetStatusCode sets the "status_code" field.
+func (_c *AdminAuditLogCreate) SetStatusCode(v int) *AdminAuditLogCreate {
+	_c.mutation.SetStatusCode(v)
+	return _c
+}
+
+// SetNillableStatusCode sets the "status_code" field if the given value is not nil.
+func (_c *AdminAuditLogCreate) SetNillableStatusCode(v *int) *AdminAuditLogCreate {
+	if v != nil {
+		_c.SetStatusCode(*v)
+	}
+	return _c
+}
+
+// SetTargetType sets the "target_type" field.
+func (_c *AdminAuditLogCreate) SetTargetType(v string) *AdminAuditLogCreate {
+	_c.mutation.SetTargetType(v)
+	return _c
+}
+
+// SetNillableTargetType sets the "target_type" field if the given value is not nil.
+func (_c *AdminAuditLogCreate) SetNillableTargetType(v *string) *AdminAuditLogCreate {
+	if v != nil {
+		_c.SetTargetType(*v)
+	}
+	return _c
+}
+
+// SetTargetID sets the "target_id" field.
+func (_c *AdminAuditLogCreate) SetTargetID(v string) *AdminAuditLogCreate {
+	_c.mutation.SetTargetID(v)
+	return _c
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (_c *AdminAuditLogCreate) SetNillableTargetID(v *string) *AdminAuditLogCreate {
+	if v != nil {
+		_c.SetTargetID(*v)
+	}
+	return _c
+}
+
+// SetChanges sets the "changes" field.
+func (_c *AdminAuditLogCreate) SetChanges(v json.RawMessage) *AdminAuditLogCreate {
+	_c.mutation.SetChanges(v)
+	return _c
+}
+
+// SetIPAddress sets the "ip_address" field.
+func (_c *AdminAuditLogCreate) SetIPAddress(v string) *AdminAuditLogCreate {
+	_c.mutation.SetIPAddress(v)
+	return _c
+}
+
+// SetNillableIPAddress sets the "ip_address" field if the given value is not nil.
+func (_c *AdminAuditLogCreate) SetNillableIPAddress(v *string) *AdminAuditLogCreate {
+	if v != nil {
+		_c.SetIPAddress(*v)
+	}
+	return _c
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (_c *AdminAuditLogCreate) SetUserAgent(v string) *AdminAuditLogCreate {
+	_c.mutation.SetUserAgent(v)
+	return _c
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (_c *AdminAuditLogCreate) SetNillableUserAgent(v *string) *AdminAuditLogCreate {
+	if v != nil {
+		_c.SetUserAgent(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *AdminAuditLogCreate) SetCreatedAt(v time.Time) *AdminAuditLogCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *AdminAuditLogCreate) SetNillableCreatedAt(v *time.Time) *AdminAuditLogCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// Mutation returns the AdminAuditLogMutation object of the builder.
+func (_c *AdminAuditLogCreate) Mutation() *AdminAuditLogMutation {
+	return _c.mutation
+}
+
+// Save creates the AdminAuditLog in the database.
+func (_c *AdminAuditLogCreate) Save(ctx context.Context) (*AdminAuditLog, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *AdminAuditLogCreate) SaveX(ctx context.Context) *AdminAuditLog {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AdminAuditLogCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AdminAuditLogCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *AdminAuditLogCreate) defaults() {
+	if _, ok := _c.mutation.AuthMethod(); !ok {
+		v := adminauditlog.DefaultAuthMethod
+		_c.mutation.SetAuthMethod(v)
+	}
+	if _, ok := _c.mutation.StatusCode(); !ok {
+		v := adminauditlog.DefaultStatusCode
+		_c.mutation.SetStatusCode(v)
+	}
+	if _, ok := _c.mutation.TargetType(); !ok {
+		v := adminauditlog.DefaultTargetType
+		_c.mutation.SetTargetType(v)
+	}
+	if _, ok := _c.mutation.IPAddress(); !ok {
+		v := adminauditlog.DefaultIPAddress
+		_c.mutation.SetIPAddress(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := adminauditlog.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *AdminAuditLogCreate) check() error {
+	if _, ok := _c.mutation.AuthMethod(); !ok {
+		return &ValidationError{Name: "auth_method", err: errors.New(`ent: missing required field "AdminAuditLog.auth_method"`)}
+	}
+	if v, ok := _c.mutation.AuthMethod(); ok {
+		if err := adminauditlog.AuthMethodValidator(v); err != nil {
+			return &ValidationError{Name: "auth_method", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.auth_method": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Method(); !ok {
+		return &ValidationError{Name: "method", err: errors.New(`ent: missing required field "AdminAuditLog.method"`)}
+	}
+	if v, ok := _c.mutation.Method(); ok {
+		if err := adminauditlog.MethodValidator(v); err != nil {
+			return &ValidationError{Name: "method", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.method": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Route(); !ok {
+		return &ValidationError{Name: "route", err: errors.New(`ent: missing required field "AdminAuditLog.route"`)}
+	}
+	if v, ok := _c.mutation.Route(); ok {
+		if err := adminauditlog.RouteValidator(v); err != nil {
+			return &ValidationError{Name: "route", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.route": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Path(); !ok {
+		return &ValidationError{Name: "path", err: errors.New(`ent: missing required field "AdminAuditLog.path"`)}
+	}
+	if _, ok := _c.mutation.StatusCode(); !ok {
+		return &ValidationError{Name: "status_code", err: errors.New(`ent: missing required field "AdminAuditLog.status_code"`)}
+	}
+	if _, ok := _c.mutation.TargetType(); !ok {
+		return &ValidationError{Name: "target_type", err: errors.New(`ent: missing required field "AdminAuditLog.target_type"`)}
+	}
+	if v, ok := _c.mutation.TargetType(); ok {
+		if err := adminauditlog.TargetTypeValidator(v); err != nil {
+			return &ValidationError{Name: "target_type", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.target_type": %w`, err)}
+		}
+	}
+	if v, ok := _c.mutation.TargetID(); ok {
+		if err := adminauditlog.TargetIDValidator(v); err != nil {
+			return &ValidationError{Name: "target_id", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.target_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.IPAddress(); !ok {
+		return &ValidationError{Name: "ip_address", err: errors.New(`ent: missing required field "AdminAuditLog.ip_address"`)}
+	}
+	if v, ok := _c.mutation.IPAddress(); ok {
+		if err := adminauditlog.IPAddressValidator(v); err != nil {
+			return &ValidationError{Name: "ip_address", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.ip_address": %w`, err)}
+		}
+	}
+	if v, ok := _c.mutation.UserAgent(); ok {
+		if err := adminauditlog.UserAgentValidator(v); err != nil {
+			return &ValidationError{Name: "user_agent", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.user_agent": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "AdminAuditLog.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *AdminAuditLogCreate) sqlSave(ctx context.Context) (*AdminAuditLog, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int64(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *AdminAuditLogCreate) createSpec() (*AdminAuditLog, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AdminAuditLog{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(adminauditlog.Table, sqlgraph.NewFieldSpec(adminauditlog.FieldID, field.TypeInt64))
+	)
+	_spec.OnConflict = _c.conflict
+	if value, ok := _c.mutation.ActorUserID(); ok {
+		_spec.SetField(adminauditlog.FieldActorUserID, field.TypeInt64, value)
+		_node.ActorUserID = &value
+	}
+	if value, ok := _c.mutation.AuthMethod(); ok {
+		_spec.SetField(adminauditlog.FieldAuthMethod, field.TypeString, value)
+		_node.AuthMethod = value
+	}
+	if value, ok := _c.mutation.Method(); ok {
+		_spec.SetField(adminauditlog.FieldMethod, field.TypeString, value)
+		_node.Method = value
+	}
+	if value, ok := _c.mutation.Route(); ok {
+		_spec.SetField(adminauditlog.FieldRoute, field.TypeString, value)
+		_node.Route = value
+	}
+	if value, ok := _c.mutation.Path(); ok {
+		_spec.SetField(adminauditlog.FieldPath, field.TypeString, value)
+		_node.Path = value
+	}
+	if value, ok := _c.mutation.StatusCode(); ok {
+		_spec.SetField(adminauditlog.FieldStatusCode, field.TypeInt, value)
+		_node.StatusCode = value
+	}
+	if value, ok := _c.mutation.TargetType(); ok {
+		_spec.SetField(adminauditlog.FieldTargetType, field.TypeString, value)
+		_node.TargetType = value
+	}
+	if value, ok := _c.mutation.TargetID(); ok {
+		_spec.SetField(adminauditlog.FieldTargetID, field.TypeString, value)
+		_node.TargetID = &value
+	}
+	if value, ok := _c.mutation.Changes(); ok {
+		_spec.SetField(adminauditlog.FieldChanges, field.TypeJSON, value)
+		_node.Changes = value
+	}
+	if value, ok := _c.mutation.IPAddress(); ok {
+		_spec.SetField(adminauditlog.FieldIPAddress, field.TypeString, value)
+		_node.IPAddress = value
+	}
+	if value, ok := _c.mutation.UserAgent(); ok {
+		_spec.SetField(adminauditlog.FieldUserAgent, field.TypeString, value)
+		_node.UserAgent = &value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(adminauditlog.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.AdminAuditLog.Create().
+//		SetActorUserID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.AdminAuditLogUpsert) {
+//			SetActorUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *AdminAuditLogCreate) OnConflict(opts ...sql.ConflictOption) *AdminAuditLogUpsertOne {
+	_c.conflict = opts
+	return &AdminAuditLogUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.AdminAuditLog.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *AdminAuditLogCreate) OnConflictColumns(columns ...string) *AdminAuditLogUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &AdminAuditLogUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// AdminAuditLogUpsertOne is the builder for "upsert"-ing
+	//  one AdminAuditLog node.
+	AdminAuditLogUpsertOne struct {
+		create *AdminAuditLogCreate
+	}
+
+	// AdminAuditLogUpsert is the "OnConflict" setter.
+	AdminAuditLogUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetActorUserID sets the "actor_user_id" field.
+func (u *AdminAuditLogUpsert) SetActorUserID(v int64) *AdminAuditLogUpsert {
+	u.Set(adminauditlog.FieldActorUserID, v)
+	return u
+}
+
+// UpdateActorUserID sets the "actor_user_id" field to the value that was provided on create.
+func (u *AdminAuditLogUpsert) UpdateActorUserID() *AdminAuditLogUpsert {
+	u.SetExcluded(adminauditlog.FieldActorUserID)
+	return u
+}
+
+// AddActorUserID adds v to the "actor_user_id" field.
+func (u *AdminAuditLogUpsert) AddActorUserID(v int64) *AdminAuditLogUpsert {
+	u.Add(adminauditlog.FieldActorUserID, v)
+	return u
+}
+
+// ClearActorUserID clears the value of the "actor_user_id" field.
+func (u *AdminAuditLogUpsert) ClearActorUserID() *AdminAuditLogUpsert {
+	u.SetNull(adminauditlog.FieldActorUserID)
+	return u
+}
+
+// SetAuthMethod sets the "auth_method" field.
+func (u *AdminAuditLogUpsert) SetAuthMethod(v string) *AdminAuditLogUpsert {
+	u.Set(adminauditlog.FieldAuthMethod, v)
+	return u
+}
+
+// UpdateAuthMethod sets the "auth_method" field to the value that was provided on create.
+func (u *AdminAuditLogUpsert) UpdateAuthMethod() *AdminAuditLogUpsert {
+	u.SetExcluded(adminauditlog.FieldAuthMethod)
+	return u
+}
+
+// SetMethod sets the "method" field.
+func (u *AdminAuditLogUpsert) SetMethod(v string) *AdminAuditLogUpsert {
+	u.Set(adminauditlog.FieldMethod, v)
+	return u
+}
+
+// UpdateMethod sets the "method" field to the value that was provided on create.
+func (u *AdminAuditLogUpsert) UpdateMethod() *AdminAuditLogUpsert {
+	u.SetExcluded(adminauditlog.FieldMethod)
+	return u
+}
+
+// SetRoute sets the "route" field.
+func (u *AdminAuditLogUpsert) SetRoute(v string) *AdminAuditLogUpsert {
+	u.Set(adminauditlog.FieldRoute, v)
+	return u
+}
+
+// UpdateRoute sets the "route" field to the value that was provided on create.
+func (u *AdminAuditLogUpsert) UpdateRoute() *AdminAuditLogUpsert {
+	u.SetExcluded(adminauditlog.FieldRoute)
+	return u
+}
+
+// SetPath sets the "path" field.
+func (u *AdminAuditLogUpsert) SetPath(v string) *AdminAuditLogUpsert {
+	u.Set(adminauditlog.FieldPath, v)
+	return u
+}
+
+// UpdatePath sets the "path" field to the value that was provided on create.
+func (u *AdminAuditLogUpsert) UpdatePath() *AdminAuditLogUpsert {
+	u.SetExcluded(adminauditlog.FieldPath)
+	return u
+}
+
+// SetStatusCode sets the "status_code" field.
+func (u *AdminAuditLogUpsert) SetStatusCode(v int) *AdminAuditLogUpsert {
+	u.Set(adminauditlog.FieldStatusCode, v)
+	return u
+}
+
+// UpdateStatusCode sets the "status_code" field to the value that was provided on create.
+func (u *AdminAuditLogUpsert) UpdateStatusCode() *AdminAuditLogUpsert {
+	u.SetExcluded(adminauditlog.FieldStatusCode)
+	return u
+}
+
+// AddStatusCode adds v to the "status_code" field.
+func (u *AdminAuditLogUpsert) AddStatusCode(v int) *AdminAuditLogUpsert {
+	u.Add(adminauditlog.FieldStatusCode, v)
+	return u
+}
+
+// SetTargetType sets the "target_type" field.
+func (u *AdminAuditLogUpsert) SetTargetType(v string) *AdminAuditLogUpsert {
+	u.Set(adminauditlog.FieldTargetType, v)
+	return u
+}
+
+// UpdateTargetType sets the "target_type" field to the value that was provided on create.
+func (u *AdminAuditLogUpsert) UpdateTargetType() *AdminAuditLogUpsert {
+	u.SetExcluded(adminauditlog.FieldTargetType)
+	return u
+}
+
+// SetTargetID sets the "target_id" field.
+func (u *AdminAuditLogUpsert) SetTargetID(v string) *AdminAuditLogUpsert {
+	u.Set(adminauditlog.FieldTargetID, v)
+	return u
+}
+
+// UpdateTargetID sets the "target_id" field to the value that was provided on create.
+func (u *AdminAuditLogUpsert) UpdateTargetID() *AdminAuditLogUpsert {
+	u.SetExcluded(adminauditlog.FieldTargetID)
+	return u
+}
+
+// ClearTargetID clears the value of the "target_id" field.
+func (u *AdminAuditLogUpsert) ClearTargetID() *AdminAuditLogUpsert {
+	u.SetNull(adminauditlog.FieldTargetID)
+	return u
+}
+
+// SetChanges sets the "changes" field.
+func (u *AdminAuditLogUpsert) SetChanges(v json.RawMessage) *AdminAuditLogUpsert {
+	u.Set(adminauditlog.FieldChanges, v)
+	return u
+}
+
+// UpdateChanges sets the "changes" field to the value that was provided on create.
+func (u *AdminAuditLogUpsert) UpdateChanges() *AdminAuditLogUpsert {
+	u.SetExcluded(adminauditlog.FieldChanges)
+	return u
+}
+
+// ClearChanges clears the value of the "changes" field.
+func (u *AdminAuditLogUpsert) ClearChanges() *AdminAuditLogUpsert {
+	u.SetNull(adminauditlog.FieldChanges)
+	return u
+}
+
+// SetIPAddress sets the "ip_address" field.
+func (u *AdminAuditLogUpsert) SetIPAddress(v string) *AdminAuditLogUpsert {
+	u.Set(adminauditlog.FieldIPAddress, v)
+	return u
+}
+
+// UpdateIPAddress sets the "ip_address" field to the value that was provided on create.
+func (u *AdminAuditLogUpsert) UpdateIPAddress() *AdminAuditLogUpsert {
+	u.SetExcluded(adminauditlog.FieldIPAddress)
+	return u
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (u *AdminAuditLogUpsert) SetUserAgent(v string) *AdminAuditLogUpsert {
+	u.Set(adminauditlog.FieldUserAgent, v)
+	return u
+}
+
+// UpdateUserAgent sets the "user_agent" field to the value that was provided on create.
+func (u *AdminAuditLogUpsert) UpdateUserAgent() *AdminAuditLogUpsert {
+	u.SetExcluded(adminauditlog.FieldUserAgent)
+	return u
+}
+
+// ClearUserAgent clears the value of the "user_agent" field.
+func (u *AdminAuditLogUpsert) ClearUserAgent() *AdminAuditLogUpsert {
+	u.SetNull(adminauditlog.FieldUserAgent)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.AdminAuditLog.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *AdminAuditLogUpsertOne) UpdateNewValues() *AdminAuditLogUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(adminauditlog.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.AdminAuditLog.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *AdminAuditLogUpsertOne) Ignore() *AdminAuditLogUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *AdminAuditLogUpsertOne) DoNothing() *AdminAuditLogUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the AdminAuditLogCreate.OnConflict
+// documentation for more info.
+func (u *AdminAuditLogUpsertOne) Update(set func(*AdminAuditLogUpsert)) *AdminAuditLogUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&AdminAuditLogUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetActorUserID sets the "actor_user_id" field.
+func (u *AdminAuditLogUpsertOne) SetActorUserID(v int64) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetActorUserID(v)
+	})
+}
+
+// AddActorUserID adds v to the "actor_user_id" field.
+func (u *AdminAuditLogUpsertOne) AddActorUserID(v int64) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.AddActorUserID(v)
+	})
+}
+
+// UpdateActorUserID sets the "actor_user_id" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertOne) UpdateActorUserID() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateActorUserID()
+	})
+}
+
+// ClearActorUserID clears the value of the "actor_user_id" field.
+func (u *AdminAuditLogUpsertOne) ClearActorUserID() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.ClearActorUserID()
+	})
+}
+
+// SetAuthMethod sets the "auth_method" field.
+func (u *AdminAuditLogUpsertOne) SetAuthMethod(v string) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetAuthMethod(v)
+	})
+}
+
+// UpdateAuthMethod sets the "auth_method" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertOne) UpdateAuthMethod() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateAuthMethod()
+	})
+}
+
+// SetMethod sets the "method" field.
+func (u *AdminAuditLogUpsertOne) SetMethod(v string) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetMethod(v)
+	})
+}
+
+// UpdateMethod sets the "method" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertOne) UpdateMethod() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateMethod()
+	})
+}
+
+// SetRoute sets the "route" field.
+func (u *AdminAuditLogUpsertOne) SetRoute(v string) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetRoute(v)
+	})
+}
+
+// UpdateRoute sets the "route" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertOne) UpdateRoute() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateRoute()
+	})
+}
+
+// SetPath sets the "path" field.
+func (u *AdminAuditLogUpsertOne) SetPath(v string) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetPath(v)
+	})
+}
+
+// UpdatePath sets the "path" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertOne) UpdatePath() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdatePath()
+	})
+}
+
+// SetStatusCode sets the "status_code" field.
+func (u *AdminAuditLogUpsertOne) SetStatusCode(v int) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetStatusCode(v)
+	})
+}
+
+// AddStatusCode adds v to the "status_code" field.
+func (u *AdminAuditLogUpsertOne) AddStatusCode(v int) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.AddStatusCode(v)
+	})
+}
+
+// UpdateStatusCode sets the "status_code" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertOne) UpdateStatusCode() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateStatusCode()
+	})
+}
+
+// SetTargetType sets the "target_type" field.
+func (u *AdminAuditLogUpsertOne) SetTargetType(v string) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetTargetType(v)
+	})
+}
+
+// UpdateTargetType sets the "target_type" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertOne) UpdateTargetType() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateTargetType()
+	})
+}
+
+// SetTargetID sets the "target_id" field.
+func (u *AdminAuditLogUpsertOne) SetTargetID(v string) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetTargetID(v)
+	})
+}
+
+// UpdateTargetID sets the "target_id" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertOne) UpdateTargetID() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateTargetID()
+	})
+}
+
+// ClearTargetID clears the value of the "target_id" field.
+func (u *AdminAuditLogUpsertOne) ClearTargetID() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.ClearTargetID()
+	})
+}
+
+// SetChanges sets the "changes" field.
+func (u *AdminAuditLogUpsertOne) SetChanges(v json.RawMessage) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetChanges(v)
+	})
+}
+
+// UpdateChanges sets the "changes" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertOne) UpdateChanges() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateChanges()
+	})
+}
+
+// ClearChanges clears the value of the "changes" field.
+func (u *AdminAuditLogUpsertOne) ClearChanges() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.ClearChanges()
+	})
+}
+
+// SetIPAddress sets the "ip_address" field.
+func (u *AdminAuditLogUpsertOne) SetIPAddress(v string) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetIPAddress(v)
+	})
+}
+
+// UpdateIPAddress sets the "ip_address" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertOne) UpdateIPAddress() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateIPAddress()
+	})
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (u *AdminAuditLogUpsertOne) SetUserAgent(v string) *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetUserAgent(v)
+	})
+}
+
+// UpdateUserAgent sets the "user_agent" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertOne) UpdateUserAgent() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateUserAgent()
+	})
+}
+
+// ClearUserAgent clears the value of the "user_agent" field.
+func (u *AdminAuditLogUpsertOne) ClearUserAgent() *AdminAuditLogUpsertOne {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.ClearUserAgent()
+	})
+}
+
+// Exec executes the query.
+func (u *AdminAuditLogUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for AdminAuditLogCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *AdminAuditLogUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *AdminAuditLogUpsertOne) ID(ctx context.Context) (id int64, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *AdminAuditLogUpsertOne) IDX(ctx context.Context) int64 {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// AdminAuditLogCreateBulk is the builder for creating many AdminAuditLog entities in bulk.
+type AdminAuditLogCreateBulk struct {
+	config
+	err      error
+	builders []*AdminAuditLogCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the AdminAuditLog entities in the database.
+func (_c *AdminAuditLogCreateBulk) Save(ctx context.Context) ([]*AdminAuditLog, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*AdminAuditLog, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AdminAuditLogMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *AdminAuditLogCreateBulk) SaveX(ctx context.Context) []*AdminAuditLog {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *AdminAuditLogCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *AdminAuditLogCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.AdminAuditLog.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.AdminAuditLogUpsert) {
+//			SetActorUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *AdminAuditLogCreateBulk) OnConflict(opts ...sql.ConflictOption) *AdminAuditLogUpsertBulk {
+	_c.conflict = opts
+	return &AdminAuditLogUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.AdminAuditLog.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *AdminAuditLogCreateBulk) OnConflictColumns(columns ...string) *AdminAuditLogUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &AdminAuditLogUpsertBulk{
+		create: _c,
+	}
+}
+
+// AdminAuditLogUpsertBulk is the builder for "upsert"-ing
+// a bulk of AdminAuditLog nodes.
+type AdminAuditLogUpsertBulk struct {
+	create *AdminAuditLogCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.AdminAuditLog.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *AdminAuditLogUpsertBulk) UpdateNewValues() *AdminAuditLogUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(adminauditlog.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.AdminAuditLog.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *AdminAuditLogUpsertBulk) Ignore() *AdminAuditLogUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *AdminAuditLogUpsertBulk) DoNothing() *AdminAuditLogUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the AdminAuditLogCreateBulk.OnConflict
+// documentation for more info.
+func (u *AdminAuditLogUpsertBulk) Update(set func(*AdminAuditLogUpsert)) *AdminAuditLogUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&AdminAuditLogUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetActorUserID sets the "actor_user_id" field.
+func (u *AdminAuditLogUpsertBulk) SetActorUserID(v int64) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetActorUserID(v)
+	})
+}
+
+// AddActorUserID adds v to the "actor_user_id" field.
+func (u *AdminAuditLogUpsertBulk) AddActorUserID(v int64) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.AddActorUserID(v)
+	})
+}
+
+// UpdateActorUserID sets the "actor_user_id" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertBulk) UpdateActorUserID() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateActorUserID()
+	})
+}
+
+// ClearActorUserID clears the value of the "actor_user_id" field.
+func (u *AdminAuditLogUpsertBulk) ClearActorUserID() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.ClearActorUserID()
+	})
+}
+
+// SetAuthMethod sets the "auth_method" field.
+func (u *AdminAuditLogUpsertBulk) SetAuthMethod(v string) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetAuthMethod(v)
+	})
+}
+
+// UpdateAuthMethod sets the "auth_method" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertBulk) UpdateAuthMethod() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateAuthMethod()
+	})
+}
+
+// SetMethod sets the "method" field.
+func (u *AdminAuditLogUpsertBulk) SetMethod(v string) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetMethod(v)
+	})
+}
+
+// UpdateMethod sets the "method" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertBulk) UpdateMethod() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateMethod()
+	})
+}
+
+// SetRoute sets the "route" field.
+func (u *AdminAuditLogUpsertBulk) SetRoute(v string) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetRoute(v)
+	})
+}
+
+// UpdateRoute sets the "route" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertBulk) UpdateRoute() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateRoute()
+	})
+}
+
+// SetPath sets the "path" field.
+func (u *AdminAuditLogUpsertBulk) SetPath(v string) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetPath(v)
+	})
+}
+
+// UpdatePath sets the "path" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertBulk) UpdatePath() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdatePath()
+	})
+}
+
+// SetStatusCode sets the "status_code" field.
+func (u *AdminAuditLogUpsertBulk) SetStatusCode(v int) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetStatusCode(v)
+	})
+}
+
+// AddStatusCode adds v to the "status_code" field.
+func (u *AdminAuditLogUpsertBulk) AddStatusCode(v int) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.AddStatusCode(v)
+	})
+}
+
+// UpdateStatusCode sets the "status_code" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertBulk) UpdateStatusCode() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateStatusCode()
+	})
+}
+
+// SetTargetType sets the "target_type" field.
+func (u *AdminAuditLogUpsertBulk) SetTargetType(v string) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetTargetType(v)
+	})
+}
+
+// UpdateTargetType sets the "target_type" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertBulk) UpdateTargetType() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateTargetType()
+	})
+}
+
+// SetTargetID sets the "target_id" field.
+func (u *AdminAuditLogUpsertBulk) SetTargetID(v string) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetTargetID(v)
+	})
+}
+
+// UpdateTargetID sets the "target_id" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertBulk) UpdateTargetID() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateTargetID()
+	})
+}
+
+// ClearTargetID clears the value of the "target_id" field.
+func (u *AdminAuditLogUpsertBulk) ClearTargetID() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.ClearTargetID()
+	})
+}
+
+// SetChanges sets the "changes" field.
+func (u *AdminAuditLogUpsertBulk) SetChanges(v json.RawMessage) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetChanges(v)
+	})
+}
+
+// UpdateChanges sets the "changes" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertBulk) UpdateChanges() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateChanges()
+	})
+}
+
+// ClearChanges clears the value of the "changes" field.
+func (u *AdminAuditLogUpsertBulk) ClearChanges() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.ClearChanges()
+	})
+}
+
+// SetIPAddress sets the "ip_address" field.
+func (u *AdminAuditLogUpsertBulk) SetIPAddress(v string) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetIPAddress(v)
+	})
+}
+
+// UpdateIPAddress sets the "ip_address" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertBulk) UpdateIPAddress() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateIPAddress()
+	})
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (u *AdminAuditLogUpsertBulk) SetUserAgent(v string) *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.SetUserAgent(v)
+	})
+}
+
+// UpdateUserAgent sets the "user_agent" field to the value that was provided on create.
+func (u *AdminAuditLogUpsertBulk) UpdateUserAgent() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.UpdateUserAgent()
+	})
+}
+
+// ClearUserAgent clears the value of the "user_agent" field.
+func (u *AdminAuditLogUpsertBulk) ClearUserAgent() *AdminAuditLogUpsertBulk {
+	return u.Update(func(s *AdminAuditLogUpsert) {
+		s.ClearUserAgent()
+	})
+}
+
+// Exec executes the query.
+func (u *AdminAuditLogUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the AdminAuditLogCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for AdminAuditLogCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *AdminAuditLogUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
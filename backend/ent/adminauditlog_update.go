@@ -0,0 +1,755 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/adminauditlog"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+)
+
+// AdminAuditLogUpdate is the builder for updating AdminAuditLog entities.
+type AdminAuditLogUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AdminAuditLogMutation
+}
+
+// Where appends a list predicates to the AdminAuditLogUpdate builder.
+func (_u *AdminAuditLogUpdate) Where(ps ...predicate.AdminAuditLog) *AdminAuditLogUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetActorUserID sets the "actor_user_id" field.
+func (_u *AdminAuditLogUpdate) SetActorUserID(v int64) *AdminAuditLogUpdate {
+	_u.mutation.ResetActorUserID()
+	_u.mutation.SetActorUserID(v)
+	return _u
+}
+
+// SetNillableActorUserID sets the "actor_user_id" field if the given value is not nil.
+func (_u *AdminAuditLogUpdate) SetNillableActorUserID(v *int64) *AdminAuditLogUpdate {
+	if v != nil {
+		_u.SetActorUserID(*v)
+	}
+	return _u
+}
+
+// AddActorUserID adds value to the "actor_user_id" field.
+func (_u *AdminAuditLogUpdate) AddActorUserID(v int64) *AdminAuditLogUpdate {
+	_u.mutation.AddActorUserID(v)
+	return _u
+}
+
+// ClearActorUserID clears the value of the "actor_user_id" field.
+func (_u *AdminAuditLogUpdate) ClearActorUserID() *AdminAuditLogUpdate {
+	_u.mutation.ClearActorUserID()
+	return _u
+}
+
+// SetAuthMethod sets the "auth_method" field.
+func (_u *AdminAuditLogUpdate) SetAuthMethod(v string) *AdminAuditLogUpdate {
+	_u.mutation.SetAuthMethod(v)
+	return _u
+}
+
+// SetNillableAuthMethod sets the "auth_method" field if the given value is not nil.
+func (_u *AdminAuditLogUpdate) SetNillableAuthMethod(v *string) *AdminAuditLogUpdate {
+	if v != nil {
+		_u.SetAuthMethod(*v)
+	}
+	return _u
+}
+
+// SetMethod sets the "method" field.
+func (_u *AdminAuditLogUpdate) SetMethod(v string) *AdminAuditLogUpdate {
+	_u.mutation.SetMethod(v)
+	return _u
+}
+
+// SetNillableMethod sets the "method" field if the given value is not nil.
+func (_u *AdminAuditLogUpdate) SetNillableMethod(v *string) *AdminAuditLogUpdate {
+	if v != nil {
+		_u.SetMethod(*v)
+	}
+	return _u
+}
+
+// SetRoute sets the "route" field.
+func (_u *AdminAuditLogUpdate) SetRoute(v string) *AdminAuditLogUpdate {
+	_u.mutation.SetRoute(v)
+	return _u
+}
+
+// SetNillableRoute sets the "route" field if the given value is not nil.
+func (_u *AdminAuditLogUpdate) SetNillableRoute(v *string) *AdminAuditLogUpdate {
+	if v != nil {
+		_u.SetRoute(*v)
+	}
+	return _u
+}
+
+// SetPath sets the "path" field.
+func (_u *AdminAuditLogUpdate) SetPath(v string) *AdminAuditLogUpdate {
+	_u.mutation.SetPath(v)
+	return _u
+}
+
+// SetNillablePath sets the "path" field if the given value is not nil.
+func (_u *AdminAuditLogUpdate) SetNillablePath(v *string) *AdminAuditLogUpdate {
+	if v != nil {
+		_u.SetPath(*v)
+	}
+	return _u
+}
+
+// SetStatusCode sets the "status_code" field.
+func (_u *AdminAuditLogUpdate) SetStatusCode(v int) *AdminAuditLogUpdate {
+	_u.mutation.ResetStatusCode()
+	_u.mutation.SetStatusCode(v)
+	return _u
+}
+
+// SetNillableStatusCode sets the "status_code" field if the given value is not nil.
+func (_u *AdminAuditLogUpdate) SetNillableStatusCode(v *int) *AdminAuditLogUpdate {
+	if v != nil {
+		_u.SetStatusCode(*v)
+	}
+	return _u
+}
+
+// AddStatusCode adds value to the "status_code" field.
+func (_u *AdminAuditLogUpdate) AddStatusCode(v int) *AdminAuditLogUpdate {
+	_u.mutation.AddStatusCode(v)
+	return _u
+}
+
+// SetTargetType sets the "target_type" field.
+func (_u *AdminAuditLogUpdate) SetTargetType(v string) *AdminAuditLogUpdate {
+	_u.mutation.SetTargetType(v)
+	return _u
+}
+
+// SetNillableTargetType sets the "target_type" field if the given value is not nil.
+func (_u *AdminAuditLogUpdate) SetNillableTargetType(v *string) *AdminAuditLogUpdate {
+	if v != nil {
+		_u.SetTargetType(*v)
+	}
+	return _u
+}
+
+// SetTargetID sets the "target_id" field.
+func (_u *AdminAuditLogUpdate) SetTargetID(v string) *AdminAuditLogUpdate {
+	_u.mutation.SetTargetID(v)
+	return _u
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (_u *AdminAuditLogUpdate) SetNillableTargetID(v *string) *AdminAuditLogUpdate {
+	if v != nil {
+		_u.SetTargetID(*v)
+	}
+	return _u
+}
+
+// ClearTargetID clears the value of the "target_id" field.
+func (_u *AdminAuditLogUpdate) ClearTargetID() *AdminAuditLogUpdate {
+	_u.mutation.ClearTargetID()
+	return _u
+}
+
+// SetChanges sets the "changes" field.
+func (_u *AdminAuditLogUpdate) SetChanges(v json.RawMessage) *AdminAuditLogUpdate {
+	_u.mutation.SetChanges(v)
+	return _u
+}
+
+// AppendChanges appends value to the "changes" field.
+func (_u *AdminAuditLogUpdate) AppendChanges(v json.RawMessage) *AdminAuditLogUpdate {
+	_u.mutation.AppendChanges(v)
+	return _u
+}
+
+// ClearChanges clears the value of the "changes" field.
+func (_u *AdminAuditLogUpdate) ClearChanges() *AdminAuditLogUpdate {
+	_u.mutation.ClearChanges()
+	return _u
+}
+
+// SetIPAddress sets the "ip_address" field.
+func (_u *AdminAuditLogUpdate) SetIPAddress(v string) *AdminAuditLogUpdate {
+	_u.mutation.SetIPAddress(v)
+	return _u
+}
+
+// SetNillableIPAddress sets the "ip_address" field if the given value is not nil.
+func (_u *AdminAuditLogUpdate) SetNillableIPAddress(v *string) *AdminAuditLogUpdate {
+	if v != nil {
+		_u.SetIPAddress(*v)
+	}
+	return _u
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (_u *AdminAuditLogUpdate) SetUserAgent(v string) *AdminAuditLogUpdate {
+	_u.mutation.SetUserAgent(v)
+	return _u
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (_u *AdminAuditLogUpdate) SetNillableUserAgent(v *string) *AdminAuditLogUpdate {
+	if v != nil {
+		_u.SetUserAgent(*v)
+	}
+	return _u
+}
+
+// ClearUserAgent clears the value of the "user_agent" field.
+func (_u *AdminAuditLogUpdate) ClearUserAgent() *AdminAuditLogUpdate {
+	_u.mutation.ClearUserAgent()
+	return _u
+}
+
+// Mutation returns the AdminAuditLogMutation object of the builder.
+func (_u *AdminAuditLogUpdate) Mutation() *AdminAuditLogMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *AdminAuditLogUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AdminAuditLogUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *AdminAuditLogUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AdminAuditLogUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *AdminAuditLogUpdate) check() error {
+	if v, ok := _u.mutation.AuthMethod(); ok {
+		if err := adminauditlog.AuthMethodValidator(v); err != nil {
+			return &ValidationError{Name: "auth_method", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.auth_method": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Method(); ok {
+		if err := adminauditlog.MethodValidator(v); err != nil {
+			return &ValidationError{Name: "method", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.method": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Route(); ok {
+		if err := adminauditlog.RouteValidator(v); err != nil {
+			return &ValidationError{Name: "route", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.route": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.TargetType(); ok {
+		if err := adminauditlog.TargetTypeValidator(v); err != nil {
+			return &ValidationError{Name: "target_type", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.target_type": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.TargetID(); ok {
+		if err := adminauditlog.TargetIDValidator(v); err != nil {
+			return &ValidationError{Name: "target_id", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.target_id": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.IPAddress(); ok {
+		if err := adminauditlog.IPAddressValidator(v); err != nil {
+			return &ValidationError{Name: "ip_address", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.ip_address": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.UserAgent(); ok {
+		if err := adminauditlog.UserAgentValidator(v); err != nil {
+			return &ValidationError{Name: "user_agent", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.user_agent": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *AdminAuditLogUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(adminauditlog.Table, adminauditlog.Columns, sqlgraph.NewFieldSpec(adminauditlog.FieldID, field.TypeInt64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.ActorUserID(); ok {
+		_spec.SetField(adminauditlog.FieldActorUserID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedActorUserID(); ok {
+		_spec.AddField(adminauditlog.FieldActorUserID, field.TypeInt64, value)
+	}
+	if _u.mutation.ActorUserIDCleared() {
+		_spec.ClearField(adminauditlog.FieldActorUserID, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.AuthMethod(); ok {
+		_spec.SetField(adminauditlog.FieldAuthMethod, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Method(); ok {
+		_spec.SetField(adminauditlog.FieldMethod, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Route(); ok {
+		_spec.SetField(adminauditlog.FieldRoute, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Path(); ok {
+		_spec.SetField(adminauditlog.FieldPath, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.StatusCode(); ok {
+		_spec.SetField(adminauditlog.FieldStatusCode, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedStatusCode(); ok {
+		_spec.AddField(adminauditlog.FieldStatusCode, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.TargetType(); ok {
+		_spec.SetField(adminauditlog.FieldTargetType, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.TargetID(); ok {
+		_spec.SetField(adminauditlog.FieldTargetID, field.TypeString, value)
+	}
+	if _u.mutation.TargetIDCleared() {
+		_spec.ClearField(adminauditlog.FieldTargetID, field.TypeString)
+	}
+	if value, ok := _u.mutation.Changes(); ok {
+		_spec.SetField(adminauditlog.FieldChanges, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedChanges(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, adminauditlog.FieldChanges, value)
+		})
+	}
+	if _u.mutation.ChangesCleared() {
+		_spec.ClearField(adminauditlog.FieldChanges, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.IPAddress(); ok {
+		_spec.SetField(adminauditlog.FieldIPAddress, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UserAgent(); ok {
+		_spec.SetField(adminauditlog.FieldUserAgent, field.TypeString, value)
+	}
+	if _u.mutation.UserAgentCleared() {
+		_spec.ClearField(adminauditlog.FieldUserAgent, field.TypeString)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{adminauditlog.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// AdminAuditLogUpdateOne is the builder for updating a single AdminAuditLog entity.
+type AdminAuditLogUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AdminAuditLogMutation
+}
+
+// SetActorUserID sets the "actor_user_id" field.
+func (_u *AdminAuditLogUpdateOne) SetActorUserID(v int64) *AdminAuditLogUpdateOne {
+	_u.mutation.ResetActorUserID()
+	_u.mutation.SetActorUserID(v)
+	return _u
+}
+
+// SetNillableActorUserID sets the "actor_user_id" field if the given value is not nil.
+func (_u *AdminAuditLogUpdateOne) SetNillableActorUserID(v *int64) *AdminAuditLogUpdateOne {
+	if v != nil {
+		_u.SetActorUserID(*v)
+	}
+	return _u
+}
+
+// AddActorUserID adds value to the "actor_user_id" field.
+func (_u *AdminAuditLogUpdateOne) AddActorUserID(v int64) *AdminAuditLogUpdateOne {
+	_u.mutation.AddActorUserID(v)
+	return _u
+}
+
+// ClearActorUserID clears the value of the "actor_user_id" field.
+func (_u *AdminAuditLogUpdateOne) ClearActorUserID() *AdminAuditLogUpdateOne {
+	_u.mutation.ClearActorUserID()
+	return _u
+}
+
+// SetAuthMethod sets the "auth_method" field.
+func (_u *AdminAuditLogUpdateOne) SetAuthMethod(v string) *AdminAuditLogUpdateOne {
+	_u.mutation.SetAuthMethod(v)
+	return _u
+}
+
+// SetNillableAuthMethod sets the "auth_method" field if the given value is not nil.
+func (_u *AdminAuditLogUpdateOne) SetNillableAuthMethod(v *string) *AdminAuditLogUpdateOne {
+	if v != nil {
+		_u.SetAuthMethod(*v)
+	}
+	return _u
+}
+
+// SetMethod sets the "method" field.
+func (_u *AdminAuditLogUpdateOne) SetMethod(v string) *AdminAuditLogUpdateOne {
+	_u.mutation.SetMethod(v)
+	return _u
+}
+
+// SetNillableMethod sets the "method" field if the given value is not nil.
+func (_u *AdminAuditLogUpdateOne) SetNillableMethod(v *string) *AdminAuditLogUpdateOne {
+	if v != nil {
+		_u.SetMethod(*v)
+	}
+	return _u
+}
+
+// SetRoute sets the "route" field.
+func (_u *AdminAuditLogUpdateOne) SetRoute(v string) *AdminAuditLogUpdateOne {
+	_u.mutation.SetRoute(v)
+	return _u
+}
+
+// SetNillableRoute sets the "route" field if the given value is not nil.
+func (_u *AdminAuditLogUpdateOne) SetNillableRoute(v *string) *AdminAuditLogUpdateOne {
+	if v != nil {
+		_u.SetRoute(*v)
+	}
+	return _u
+}
+
+// SetPath sets the "path" field.
+func (_u *AdminAuditLogUpdateOne) SetPath(v string) *AdminAuditLogUpdateOne {
+	_u.mutation.SetPath(v)
+	return _u
+}
+
+// SetNillablePath sets the "path" field if the given value is not nil.
+func (_u *AdminAuditLogUpdateOne) SetNillablePath(v *string) *AdminAuditLogUpdateOne {
+	if v != nil {
+		_u.SetPath(*v)
+	}
+	return _u
+}
+
+// SetStatusCode sets the "status_code" field.
+func (_u *AdminAuditLogUpdateOne) SetStatusCode(v int) *AdminAuditLogUpdateOne {
+	_u.mutation.ResetStatusCode()
+	_u.mutation.SetStatusCode(v)
+	return _u
+}
+
+// SetNillableStatusCode sets the "status_code" field if the given value is not nil.
+func (_u *AdminAuditLogUpdateOne) SetNillableStatusCode(v *int) *AdminAuditLogUpdateOne {
+	if v != nil {
+		_u.SetStatusCode(*v)
+	}
+	return _u
+}
+
+// AddStatusCode adds value to the "status_code" field.
+func (_u *AdminAuditLogUpdateOne) AddStatusCode(v int) *AdminAuditLogUpdateOne {
+	_u.mutation.AddStatusCode(v)
+	return _u
+}
+
+// SetTargetType sets the "target_type" field.
+func (_u *AdminAuditLogUpdateOne) SetTargetType(v string) *AdminAuditLogUpdateOne {
+	_u.mutation.SetTargetType(v)
+	return _u
+}
+
+// SetNillableTargetType sets the "target_type" field if the given value is not nil.
+func (_u *AdminAuditLogUpdateOne) SetNillableTargetType(v *string) *AdminAuditLogUpdateOne {
+	if v != nil {
+		_u.SetTargetType(*v)
+	}
+	return _u
+}
+
+// SetTargetID sets the "target_id" field.
+func (_u *AdminAuditLogUpdateOne) SetTargetID(v string) *AdminAuditLogUpdateOne {
+	_u.mutation.SetTargetID(v)
+	return _u
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (_u *AdminAuditLogUpdateOne) SetNillableTargetID(v *string) *AdminAuditLogUpdateOne {
+	if v != nil {
+		_u.SetTargetID(*v)
+	}
+	return _u
+}
+
+// ClearTargetID clears the value of the "target_id" field.
+func (_u *AdminAuditLogUpdateOne) ClearTargetID() *AdminAuditLogUpdateOne {
+	_u.mutation.ClearTargetID()
+	return _u
+}
+
+// SetChanges sets the "changes" field.
+func (_u *AdminAuditLogUpdateOne) SetChanges(v json.RawMessage) *AdminAuditLogUpdateOne {
+	_u.mutation.SetChanges(v)
+	return _u
+}
+
+// AppendChanges appends value to the "changes" field.
+func (_u *AdminAuditLogUpdateOne) AppendChanges(v json.RawMessage) *AdminAuditLogUpdateOne {
+	_u.mutation.AppendChanges(v)
+	return _u
+}
+
+// ClearChanges clears the value of the "changes" field.
+func (_u *AdminAuditLogUpdateOne) ClearChanges() *AdminAuditLogUpdateOne {
+	_u.mutation.ClearChanges()
+	return _u
+}
+
+// SetIPAddress sets the "ip_address" field.
+func (_u *AdminAuditLogUpdateOne) SetIPAddress(v string) *AdminAuditLogUpdateOne {
+	_u.mutation.SetIPAddress(v)
+	return _u
+}
+
+// SetNillableIPAddress sets the "ip_address" field if the given value is not nil.
+func (_u *AdminAuditLogUpdateOne) SetNillableIPAddress(v *string) *AdminAuditLogUpdateOne {
+	if v != nil {
+		_u.SetIPAddress(*v)
+	}
+	return _u
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (_u *AdminAuditLogUpdateOne) SetUserAgent(v string) *AdminAuditLogUpdateOne {
+	_u.mutation.SetUserAgent(v)
+	return _u
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (_u *AdminAuditLogUpdateOne) SetNillableUserAgent(v *string) *AdminAuditLogUpdateOne {
+	if v != nil {
+		_u.SetUserAgent(*v)
+	}
+	return _u
+}
+
+// ClearUserAgent clears the value of the "user_agent" field.
+func (_u *AdminAuditLogUpdateOne) ClearUserAgent() *AdminAuditLogUpdateOne {
+	_u.mutation.ClearUserAgent()
+	return _u
+}
+
+// Mutation returns the AdminAuditLogMutation object of the builder.
+func (_u *AdminAuditLogUpdateOne) Mutation() *AdminAuditLogMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the AdminAuditLogUpdate builder.
+func (_u *AdminAuditLogUpdateOne) Where(ps ...predicate.AdminAuditLog) *AdminAuditLogUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *AdminAuditLogUpdateOne) Select(field string, fields ...string) *AdminAuditLogUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated AdminAuditLog entity.
+func (_u *AdminAuditLogUpdateOne) Save(ctx context.Context) (*AdminAuditLog, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *AdminAuditLogUpdateOne) SaveX(ctx context.Context) *AdminAuditLog {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *AdminAuditLogUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *AdminAuditLogUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *AdminAuditLogUpdateOne) check() error {
+	if v, ok := _u.mutation.AuthMethod(); ok {
+		if err := adminauditlog.AuthMethodValidator(v); err != nil {
+			return &ValidationError{Name: "auth_method", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.auth_method": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Method(); ok {
+		if err := adminauditlog.MethodValidator(v); err != nil {
+			return &ValidationError{Name: "method", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.method": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Route(); ok {
+		if err := adminauditlog.RouteValidator(v); err != nil {
+			return &ValidationError{Name: "route", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.route": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.TargetType(); ok {
+		if err := adminauditlog.TargetTypeValidator(v); err != nil {
+			return &ValidationError{Name: "target_type", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.target_type": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.TargetID(); ok {
+		if err := adminauditlog.TargetIDValidator(v); err != nil {
+			return &ValidationError{Name: "target_id", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.target_id": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.IPAddress(); ok {
+		if err := adminauditlog.IPAddressValidator(v); err != nil {
+			return &ValidationError{Name: "ip_address", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.ip_address": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.UserAgent(); ok {
+		if err := adminauditlog.UserAgentValidator(v); err != nil {
+			return &ValidationError{Name: "user_agent", err: fmt.Errorf(`ent: validator failed for field "AdminAuditLog.user_agent": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *AdminAuditLogUpdateOne) sqlSave(ctx context.Context) (_node *AdminAuditLog, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(adminauditlog.Table, adminauditlog.Columns, sqlgraph.NewFieldSpec(adminauditlog.FieldID, field.TypeInt64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "AdminAuditLog.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, adminauditlog.FieldID)
+		for _, f := range fields {
+			if !adminauditlog.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != adminauditlog.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.ActorUserID(); ok {
+		_spec.SetField(adminauditlog.FieldActorUserID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedActorUserID(); ok {
+		_spec.AddField(adminauditlog.FieldActorUserID, field.TypeInt64, value)
+	}
+	if _u.mutation.ActorUserIDCleared() {
+		_spec.ClearField(adminauditlog.FieldActorUserID, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.AuthMethod(); ok {
+		_spec.SetField(adminauditlog.FieldAuthMethod, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Method(); ok {
+		_spec.SetField(adminauditlog.FieldMethod, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Route(); ok {
+		_spec.SetField(adminauditlog.FieldRoute, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Path(); ok {
+		_spec.SetField(adminauditlog.FieldPath, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.StatusCode(); ok {
+		_spec.SetField(adminauditlog.FieldStatusCode, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedStatusCode(); ok {
+		_spec.AddField(adminauditlog.FieldStatusCode, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.TargetType(); ok {
+		_spec.SetField(adminauditlog.FieldTargetType, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.TargetID(); ok {
+		_spec.SetField(adminauditlog.FieldTargetID, field.TypeString, value)
+	}
+	if _u.mutation.TargetIDCleared() {
+		_spec.ClearField(adminauditlog.FieldTargetID, field.TypeString)
+	}
+	if value, ok := _u.mutation.Changes(); ok {
+		_spec.SetField(adminauditlog.FieldChanges, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedChanges(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, adminauditlog.FieldChanges, value)
+		})
+	}
+	if _u.mutation.ChangesCleared() {
+		_spec.ClearField(adminauditlog.FieldChanges, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.IPAddress(); ok {
+		_spec.SetField(adminauditlog.FieldIPAddress, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.UserAgent(); ok {
+		_spec.SetField(adminauditlog.FieldUserAgent, field.TypeString, value)
+	}
+	if _u.mutation.UserAgentCleared() {
+		_spec.ClearField(adminauditlog.FieldUserAgent, field.TypeString)
+	}
+	_node = &AdminAuditLog{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{adminauditlog.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
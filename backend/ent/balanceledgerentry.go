@@ -0,0 +1,202 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/Wei-Shaw/sub2api/ent/balanceledgerentry"
+)
+
+// BalanceLedgerEntry is the model entity for the BalanceLedgerEntry schema.
+type BalanceLedgerEntry struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int64 `json:"id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID int64 `json:"user_id,omitempty"`
+	// EntryType holds the value of the "entry_type" field.
+	EntryType string `json:"entry_type,omitempty"`
+	// Amount holds the value of the "amount" field.
+	Amount float64 `json:"amount,omitempty"`
+	// BalanceBefore holds the value of the "balance_before" field.
+	BalanceBefore float64 `json:"balance_before,omitempty"`
+	// BalanceAfter holds the value of the "balance_after" field.
+	BalanceAfter float64 `json:"balance_after,omitempty"`
+	// ReferenceID holds the value of the "reference_id" field.
+	ReferenceID *int64 `json:"reference_id,omitempty"`
+	// APIKeyID holds the value of the "api_key_id" field.
+	APIKeyID *int64 `json:"api_key_id,omitempty"`
+	// Note holds the value of the "note" field.
+	Note string `json:"note,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*BalanceLedgerEntry) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case balanceledgerentry.FieldAmount, balanceledgerentry.FieldBalanceBefore, balanceledgerentry.FieldBalanceAfter:
+			values[i] = new(sql.NullFloat64)
+		case balanceledgerentry.FieldID, balanceledgerentry.FieldUserID, balanceledgerentry.FieldReferenceID, balanceledgerentry.FieldAPIKeyID:
+			values[i] = new(sql.NullInt64)
+		case balanceledgerentry.FieldEntryType, balanceledgerentry.FieldNote:
+			values[i] = new(sql.NullString)
+		case balanceledgerentry.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the BalanceLedgerEntry fields.
+func (_m *BalanceLedgerEntry) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case balanceledgerentry.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			_m.ID = int64(value.Int64)
+		case balanceledgerentry.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				_m.UserID = value.Int64
+			}
+		case balanceledgerentry.FieldEntryType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field entry_type", values[i])
+			} else if value.Valid {
+				_m.EntryType = value.String
+			}
+		case balanceledgerentry.FieldAmount:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field amount", values[i])
+			} else if value.Valid {
+				_m.Amount = value.Float64
+			}
+		case balanceledgerentry.FieldBalanceBefore:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field balance_before", values[i])
+			} else if value.Valid {
+				_m.BalanceBefore = value.Float64
+			}
+		case balanceledgerentry.FieldBalanceAfter:
+			if value, ok := values[i].(*sql.NullFloat64); !ok {
+				return fmt.Errorf("unexpected type %T for field balance_after", values[i])
+			} else if value.Valid {
+				_m.BalanceAfter = value.Float64
+			}
+		case balanceledgerentry.FieldReferenceID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field reference_id", values[i])
+			} else if value.Valid {
+				_m.ReferenceID = new(int64)
+				*_m.ReferenceID = value.Int64
+			}
+		case balanceledgerentry.FieldAPIKeyID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field api_key_id", values[i])
+			} else if value.Valid {
+				_m.APIKeyID = new(int64)
+				*_m.APIKeyID = value.Int64
+			}
+		case balanceledgerentry.FieldNote:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field note", values[i])
+			} else if value.Valid {
+				_m.Note = value.String
+			}
+		case balanceledgerentry.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				_m.CreatedAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the BalanceLedgerEntry.
+// This includes values selected through modifiers, order, etc.
+func (_m *BalanceLedgerEntry) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this BalanceLedgerEntry.
+// Note that you need to call BalanceLedgerEntry.Unwrap() before calling this method if this BalanceLedgerEntry
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *BalanceLedgerEntry) Update() *BalanceLedgerEntryUpdateOne {
+	return NewBalanceLedgerEntryClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the BalanceLedgerEntry entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *BalanceLedgerEntry) Unwrap() *BalanceLedgerEntry {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: BalanceLedgerEntry is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *BalanceLedgerEntry) String() string {
+	var builder strings.Builder
+	builder.WriteString("BalanceLedgerEntry(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("entry_type=")
+	builder.WriteString(_m.EntryType)
+	builder.WriteString(", ")
+	builder.WriteString("amount=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Amount))
+	builder.WriteString(", ")
+	builder.WriteString("balance_before=")
+	builder.WriteString(fmt.Sprintf("%v", _m.BalanceBefore))
+	builder.WriteString(", ")
+	builder.WriteString("balance_after=")
+	builder.WriteString(fmt.Sprintf("%v", _m.BalanceAfter))
+	builder.WriteString(", ")
+	if v := _m.ReferenceID; v != nil {
+		builder.WriteString("reference_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := _m.APIKeyID; v != nil {
+		builder.WriteString("api_key_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("note=")
+	builder.WriteString(_m.Note)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(_m.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// BalanceLedgerEntries is a parsable slice of BalanceLedgerEntry.
+type BalanceLedgerEntries []*BalanceLedgerEntry
@@ -0,0 +1,545 @@
+// Code generated by ent, DO NOT EDIT.
+
+package balanceledgerentry
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLTE(FieldID, id))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldUserID, v))
+}
+
+// EntryType applies equality check predicate on the "entry_type" field. It's identical to EntryTypeEQ.
+func EntryType(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldEntryType, v))
+}
+
+// Amount applies equality check predicate on the "amount" field. It's identical to AmountEQ.
+func Amount(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldAmount, v))
+}
+
+// BalanceBefore applies equality check predicate on the "balance_before" field. It's identical to BalanceBeforeEQ.
+func BalanceBefore(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldBalanceBefore, v))
+}
+
+// BalanceAfter applies equality check predicate on the "balance_after" field. It's identical to BalanceAfterEQ.
+func BalanceAfter(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldBalanceAfter, v))
+}
+
+// ReferenceID applies equality check predicate on the "reference_id" field. It's identical to ReferenceIDEQ.
+func ReferenceID(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldReferenceID, v))
+}
+
+// APIKeyID applies equality check predicate on the "api_key_id" field. It's identical to APIKeyIDEQ.
+func APIKeyID(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldAPIKeyID, v))
+}
+
+// Note applies equality check predicate on the "note" field. It's identical to NoteEQ.
+func Note(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldNote, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGT(FieldUserID, v))
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGTE(FieldUserID, v))
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLT(FieldUserID, v))
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLTE(FieldUserID, v))
+}
+
+// EntryTypeEQ applies the EQ predicate on the "entry_type" field.
+func EntryTypeEQ(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldEntryType, v))
+}
+
+// EntryTypeNEQ applies the NEQ predicate on the "entry_type" field.
+func EntryTypeNEQ(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNEQ(FieldEntryType, v))
+}
+
+// EntryTypeIn applies the In predicate on the "entry_type" field.
+func EntryTypeIn(vs ...string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIn(FieldEntryType, vs...))
+}
+
+// EntryTypeNotIn applies the NotIn predicate on the "entry_type" field.
+func EntryTypeNotIn(vs ...string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotIn(FieldEntryType, vs...))
+}
+
+// EntryTypeGT applies the GT predicate on the "entry_type" field.
+func EntryTypeGT(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGT(FieldEntryType, v))
+}
+
+// EntryTypeGTE applies the GTE predicate on the "entry_type" field.
+func EntryTypeGTE(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGTE(FieldEntryType, v))
+}
+
+// EntryTypeLT applies the LT predicate on the "entry_type" field.
+func EntryTypeLT(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLT(FieldEntryType, v))
+}
+
+// EntryTypeLTE applies the LTE predicate on the "entry_type" field.
+func EntryTypeLTE(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLTE(FieldEntryType, v))
+}
+
+// EntryTypeContains applies the Contains predicate on the "entry_type" field.
+func EntryTypeContains(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldContains(FieldEntryType, v))
+}
+
+// EntryTypeHasPrefix applies the HasPrefix predicate on the "entry_type" field.
+func EntryTypeHasPrefix(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldHasPrefix(FieldEntryType, v))
+}
+
+// EntryTypeHasSuffix applies the HasSuffix predicate on the "entry_type" field.
+func EntryTypeHasSuffix(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldHasSuffix(FieldEntryType, v))
+}
+
+// EntryTypeEqualFold applies the EqualFold predicate on the "entry_type" field.
+func EntryTypeEqualFold(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEqualFold(FieldEntryType, v))
+}
+
+// EntryTypeContainsFold applies the ContainsFold predicate on the "entry_type" field.
+func EntryTypeContainsFold(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldContainsFold(FieldEntryType, v))
+}
+
+// AmountEQ applies the EQ predicate on the "amount" field.
+func AmountEQ(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldAmount, v))
+}
+
+// AmountNEQ applies the NEQ predicate on the "amount" field.
+func AmountNEQ(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNEQ(FieldAmount, v))
+}
+
+// AmountIn applies the In predicate on the "amount" field.
+func AmountIn(vs ...float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIn(FieldAmount, vs...))
+}
+
+// AmountNotIn applies the NotIn predicate on the "amount" field.
+func AmountNotIn(vs ...float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotIn(FieldAmount, vs...))
+}
+
+// AmountGT applies the GT predicate on the "amount" field.
+func AmountGT(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGT(FieldAmount, v))
+}
+
+// AmountGTE applies the GTE predicate on the "amount" field.
+func AmountGTE(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGTE(FieldAmount, v))
+}
+
+// AmountLT applies the LT predicate on the "amount" field.
+func AmountLT(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLT(FieldAmount, v))
+}
+
+// AmountLTE applies the LTE predicate on the "amount" field.
+func AmountLTE(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLTE(FieldAmount, v))
+}
+
+// BalanceBeforeEQ applies the EQ predicate on the "balance_before" field.
+func BalanceBeforeEQ(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldBalanceBefore, v))
+}
+
+// BalanceBeforeNEQ applies the NEQ predicate on the "balance_before" field.
+func BalanceBeforeNEQ(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNEQ(FieldBalanceBefore, v))
+}
+
+// BalanceBeforeIn applies the In predicate on the "balance_before" field.
+func BalanceBeforeIn(vs ...float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIn(FieldBalanceBefore, vs...))
+}
+
+// BalanceBeforeNotIn applies the NotIn predicate on the "balance_before" field.
+func BalanceBeforeNotIn(vs ...float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotIn(FieldBalanceBefore, vs...))
+}
+
+// BalanceBeforeGT applies the GT predicate on the "balance_before" field.
+func BalanceBeforeGT(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGT(FieldBalanceBefore, v))
+}
+
+// BalanceBeforeGTE applies the GTE predicate on the "balance_before" field.
+func BalanceBeforeGTE(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGTE(FieldBalanceBefore, v))
+}
+
+// BalanceBeforeLT applies the LT predicate on the "balance_before" field.
+func BalanceBeforeLT(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLT(FieldBalanceBefore, v))
+}
+
+// BalanceBeforeLTE applies the LTE predicate on the "balance_before" field.
+func BalanceBeforeLTE(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLTE(FieldBalanceBefore, v))
+}
+
+// BalanceAfterEQ applies the EQ predicate on the "balance_after" field.
+func BalanceAfterEQ(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldBalanceAfter, v))
+}
+
+// BalanceAfterNEQ applies the NEQ predicate on the "balance_after" field.
+func BalanceAfterNEQ(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNEQ(FieldBalanceAfter, v))
+}
+
+// BalanceAfterIn applies the In predicate on the "balance_after" field.
+func BalanceAfterIn(vs ...float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIn(FieldBalanceAfter, vs...))
+}
+
+// BalanceAfterNotIn applies the NotIn predicate on the "balance_after" field.
+func BalanceAfterNotIn(vs ...float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotIn(FieldBalanceAfter, vs...))
+}
+
+// BalanceAfterGT applies the GT predicate on the "balance_after" field.
+func BalanceAfterGT(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGT(FieldBalanceAfter, v))
+}
+
+// BalanceAfterGTE applies the GTE predicate on the "balance_after" field.
+func BalanceAfterGTE(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGTE(FieldBalanceAfter, v))
+}
+
+// BalanceAfterLT applies the LT predicate on the "balance_after" field.
+func BalanceAfterLT(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLT(FieldBalanceAfter, v))
+}
+
+// BalanceAfterLTE applies the LTE predicate on the "balance_after" field.
+func BalanceAfterLTE(v float64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLTE(FieldBalanceAfter, v))
+}
+
+// ReferenceIDEQ applies the EQ predicate on the "reference_id" field.
+func ReferenceIDEQ(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldReferenceID, v))
+}
+
+// ReferenceIDNEQ applies the NEQ predicate on the "reference_id" field.
+func ReferenceIDNEQ(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNEQ(FieldReferenceID, v))
+}
+
+// ReferenceIDIn applies the In predicate on the "reference_id" field.
+func ReferenceIDIn(vs ...int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIn(FieldReferenceID, vs...))
+}
+
+// ReferenceIDNotIn applies the NotIn predicate on the "reference_id" field.
+func ReferenceIDNotIn(vs ...int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotIn(FieldReferenceID, vs...))
+}
+
+// ReferenceIDGT applies the GT predicate on the "reference_id" field.
+func ReferenceIDGT(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGT(FieldReferenceID, v))
+}
+
+// ReferenceIDGTE applies the GTE predicate on the "reference_id" field.
+func ReferenceIDGTE(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGTE(FieldReferenceID, v))
+}
+
+// ReferenceIDLT applies the LT predicate on the "reference_id" field.
+func ReferenceIDLT(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLT(FieldReferenceID, v))
+}
+
+// ReferenceIDLTE applies the LTE predicate on the "reference_id" field.
+func ReferenceIDLTE(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLTE(FieldReferenceID, v))
+}
+
+// ReferenceIDIsNil applies the IsNil predicate on the "reference_id" field.
+func ReferenceIDIsNil() predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIsNull(FieldReferenceID))
+}
+
+// ReferenceIDNotNil applies the NotNil predicate on the "reference_id" field.
+func ReferenceIDNotNil() predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotNull(FieldReferenceID))
+}
+
+// APIKeyIDEQ applies the EQ predicate on the "api_key_id" field.
+func APIKeyIDEQ(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldAPIKeyID, v))
+}
+
+// APIKeyIDNEQ applies the NEQ predicate on the "api_key_id" field.
+func APIKeyIDNEQ(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNEQ(FieldAPIKeyID, v))
+}
+
+// APIKeyIDIn applies the In predicate on the "api_key_id" field.
+func APIKeyIDIn(vs ...int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIn(FieldAPIKeyID, vs...))
+}
+
+// APIKeyIDNotIn applies the NotIn predicate on the "api_key_id" field.
+func APIKeyIDNotIn(vs ...int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotIn(FieldAPIKeyID, vs...))
+}
+
+// APIKeyIDGT applies the GT predicate on the "api_key_id" field.
+func APIKeyIDGT(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGT(FieldAPIKeyID, v))
+}
+
+// APIKeyIDGTE applies the GTE predicate on the "api_key_id" field.
+func APIKeyIDGTE(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGTE(FieldAPIKeyID, v))
+}
+
+// APIKeyIDLT applies the LT predicate on the "api_key_id" field.
+func APIKeyIDLT(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLT(FieldAPIKeyID, v))
+}
+
+// APIKeyIDLTE applies the LTE predicate on the "api_key_id" field.
+func APIKeyIDLTE(v int64) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLTE(FieldAPIKeyID, v))
+}
+
+// APIKeyIDIsNil applies the IsNil predicate on the "api_key_id" field.
+func APIKeyIDIsNil() predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIsNull(FieldAPIKeyID))
+}
+
+// APIKeyIDNotNil applies the NotNil predicate on the "api_key_id" field.
+func APIKeyIDNotNil() predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotNull(FieldAPIKeyID))
+}
+
+// NoteEQ applies the EQ predicate on the "note" field.
+func NoteEQ(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldNote, v))
+}
+
+// NoteNEQ applies the NEQ predicate on the "note" field.
+func NoteNEQ(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNEQ(FieldNote, v))
+}
+
+// NoteIn applies the In predicate on the "note" field.
+func NoteIn(vs ...string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIn(FieldNote, vs...))
+}
+
+// NoteNotIn applies the NotIn predicate on the "note" field.
+func NoteNotIn(vs ...string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotIn(FieldNote, vs...))
+}
+
+// NoteGT applies the GT predicate on the "note" field.
+func NoteGT(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGT(FieldNote, v))
+}
+
+// NoteGTE applies the GTE predicate on the "note" field.
+func NoteGTE(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGTE(FieldNote, v))
+}
+
+// NoteLT applies the LT predicate on the "note" field.
+func NoteLT(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLT(FieldNote, v))
+}
+
+// NoteLTE applies the LTE predicate on the "note" field.
+func NoteLTE(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLTE(FieldNote, v))
+}
+
+// NoteContains applies the Contains predicate on the "note" field.
+func NoteContains(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldContains(FieldNote, v))
+}
+
+// NoteHasPrefix applies the HasPrefix predicate on the "note" field.
+func NoteHasPrefix(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldHasPrefix(FieldNote, v))
+}
+
+// NoteHasSuffix applies the HasSuffix predicate on the "note" field.
+func NoteHasSuffix(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldHasSuffix(FieldNote, v))
+}
+
+// NoteEqualFold applies the EqualFold predicate on the "note" field.
+func NoteEqualFold(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEqualFold(FieldNote, v))
+}
+
+// NoteContainsFold applies the ContainsFold predicate on the "note" field.
+func NoteContainsFold(v string) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldContainsFold(FieldNote, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.BalanceLedgerEntry) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.BalanceLedgerEntry) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.BalanceLedgerEntry) predicate.BalanceLedgerEntry {
+	return predicate.BalanceLedgerEntry(sql.NotPredicates(p))
+}
@@ -0,0 +1,1056 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/balanceledgerentry"
+)
+
+// BalanceLedgerEntryCreate is the builder for creating a BalanceLedgerEntry entity.
+type BalanceLedgerEntryCreate struct {
+	config
+	mutation *BalanceLedgerEntryMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *BalanceLedgerEntryCreate) SetUserID(v int64) *BalanceLedgerEntryCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetEntryType sets the "entry_type" field.
+func (_c *BalanceLedgerEntryCreate) SetEntryType(v string) *BalanceLedgerEntryCreate {
+	_c.mutation.SetEntryType(v)
+	return _c
+}
+
+// SetAmount sets the "amount" field.
+func (_c *BalanceLedgerEntryCreate) SetAmount(v float64) *BalanceLedgerEntryCreate {
+	_c.mutation.SetAmount(v)
+	return _c
+}
+
+// SetBalanceBefore sets the "balance_before" field.
+func (_c *BalanceLedgerEntryCreate) SetBalanceBefore(v float64) *BalanceLedgerEntryCreate {
+	_c.mutation.SetBalanceBefore(v)
+	return _c
+}
+
+// SetBalanceAfter sets the "balance_after" field.
+func (_c *BalanceLedgerEntryCreate) SetBalanceAfter(v float64) *BalanceLedgerEntryCreate {
+	_c.mutation.SetBalanceAfter(v)
+	return _c
+}
+
+// SetReferenceID sets the "reference_id" field.
+func (_c *BalanceLedgerEntryCreate) SetReferenceID(v int64) *BalanceLedgerEntryCreate {
+	_c.mutation.SetReferenceID(v)
+	return _c
+}
+
+// SetNillableReferenceID sets the "reference_id" field if the given value is not nil.
+func (_c *BalanceLedgerEntryCreate) SetNillableReferenceID(v *int64) *BalanceLedgerEntryCreate {
+	if v != nil {
+		_c.SetReferenceID(*v)
+	}
+	return _c
+}
+
+// SetAPIKeyID sets the "api_key_id" field.
+func (_c *BalanceLedgerEntryCreate) SetAPIKeyID(v int64) *BalanceLedgerEntryCreate {
+	_c.mutation.SetAPIKeyID(v)
+	return _c
+}
+
+// SetNillableAPIKeyID sets the "api_key_id" field if the given value is not nil.
+func (_c *BalanceLedgerEntryCreate) SetNillableAPIKeyID(v *int64) *BalanceLedgerEntryCreate {
+	if v != nil {
+		_c.SetAPIKeyID(*v)
+	}
+	return _c
+}
+
+// SetNote sets the "note" field.
+func (_c *BalanceLedgerEntryCreate) SetNote(v string) *BalanceLedgerEntryCreate {
+	_c.mutation.SetNote(v)
+	return _c
+}
+
+// SetNillableNote sets the "note" field if the given value is not nil.
+func (_c *BalanceLedgerEntryCreate) SetNillableNote(v *string) *BalanceLedgerEntryCreate {
+	if v != nil {
+		_c.SetNote(*v)
+	}
+	return _c
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *BalanceLedgerEntryCreate) SetCreatedAt(v time.Time) *BalanceLedgerEntryCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *BalanceLedgerEntryCreate) SetNillableCreatedAt(v *time.Time) *BalanceLedgerEntryCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// Mutation returns the BalanceLedgerEntryMutation object of the builder.
+func (_c *BalanceLedgerEntryCreate) Mutation() *BalanceLedgerEntryMutation {
+	return _c.mutation
+}
+
+// Save creates the BalanceLedgerEntry in the database.
+func (_c *BalanceLedgerEntryCreate) Save(ctx context.Context) (*BalanceLedgerEntry, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *BalanceLedgerEntryCreate) SaveX(ctx context.Context) *BalanceLedgerEntry {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *BalanceLedgerEntryCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *BalanceLedgerEntryCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *BalanceLedgerEntryCreate) defaults() {
+	if _, ok := _c.mutation.Note(); !ok {
+		v := balanceledgerentry.DefaultNote
+		_c.mutation.SetNote(v)
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := balanceledgerentry.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *BalanceLedgerEntryCreate) check() error {
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "BalanceLedgerEntry.user_id"`)}
+	}
+	if _, ok := _c.mutation.EntryType(); !ok {
+		return &ValidationError{Name: "entry_type", err: errors.New(`ent: missing required field "BalanceLedgerEntry.entry_type"`)}
+	}
+	if v, ok := _c.mutation.EntryType(); ok {
+		if err := balanceledgerentry.EntryTypeValidator(v); err != nil {
+			return &ValidationError{Name: "entry_type", err: fmt.Errorf(`ent: validator failed for field "BalanceLedgerEntry.entry_type": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Amount(); !ok {
+		return &ValidationError{Name: "amount", err: errors.New(`ent: missing required field "BalanceLedgerEntry.amount"`)}
+	}
+	if _, ok := _c.mutation.BalanceBefore(); !ok {
+		return &ValidationError{Name: "balance_before", err: errors.New(`ent: missing required field "BalanceLedgerEntry.balance_before"`)}
+	}
+	if _, ok := _c.mutation.BalanceAfter(); !ok {
+		return &ValidationError{Name: "balance_after", err: errors.New(`ent: missing required field "BalanceLedgerEntry.balance_after"`)}
+	}
+	if _, ok := _c.mutation.Note(); !ok {
+		return &ValidationError{Name: "note", err: errors.New(`ent: missing required field "BalanceLedgerEntry.note"`)}
+	}
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "BalanceLedgerEntry.created_at"`)}
+	}
+	return nil
+}
+
+func (_c *BalanceLedgerEntryCreate) sqlSave(ctx context.Context) (*BalanceLedgerEntry, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int64(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *BalanceLedgerEntryCreate) createSpec() (*BalanceLedgerEntry, *sqlgraph.CreateSpec) {
+	var (
+		_node = &BalanceLedgerEntry{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(balanceledgerentry.Table, sqlgraph.NewFieldSpec(balanceledgerentry.FieldID, field.TypeInt64))
+	)
+	_spec.OnConflict = _c.conflict
+	if value, ok := _c.mutation.UserID(); ok {
+		_spec.SetField(balanceledgerentry.FieldUserID, field.TypeInt64, value)
+		_node.UserID = value
+	}
+	if value, ok := _c.mutation.EntryType(); ok {
+		_spec.SetField(balanceledgerentry.FieldEntryType, field.TypeString, value)
+		_node.EntryType = value
+	}
+	if value, ok := _c.mutation.Amount(); ok {
+		_spec.SetField(balanceledgerentry.FieldAmount, field.TypeFloat64, value)
+		_node.Amount = value
+	}
+	if value, ok := _c.mutation.BalanceBefore(); ok {
+		_spec.SetField(balanceledgerentry.FieldBalanceBefore, field.TypeFloat64, value)
+		_node.BalanceBefore = value
+	}
+	if value, ok := _c.mutation.BalanceAfter(); ok {
+		_spec.SetField(balanceledgerentry.FieldBalanceAfter, field.TypeFloat64, value)
+		_node.BalanceAfter = value
+	}
+	if value, ok := _c.mutation.ReferenceID(); ok {
+		_spec.SetField(balanceledgerentry.FieldReferenceID, field.TypeInt64, value)
+		_node.ReferenceID = &value
+	}
+	if value, ok := _c.mutation.APIKeyID(); ok {
+		_spec.SetField(balanceledgerentry.FieldAPIKeyID, field.TypeInt64, value)
+		_node.APIKeyID = &value
+	}
+	if value, ok := _c.mutation.Note(); ok {
+		_spec.SetField(balanceledgerentry.FieldNote, field.TypeString, value)
+		_node.Note = value
+	}
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(balanceledgerentry.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.BalanceLedgerEntry.Create().
+//		SetUserID(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.BalanceLedgerEntryUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *BalanceLedgerEntryCreate) OnConflict(opts ...sql.ConflictOption) *BalanceLedgerEntryUpsertOne {
+	_c.conflict = opts
+	return &BalanceLedgerEntryUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.BalanceLedgerEntry.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *BalanceLedgerEntryCreate) OnConflictColumns(columns ...string) *BalanceLedgerEntryUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &BalanceLedgerEntryUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// BalanceLedgerEntryUpsertOne is the builder for "upsert"-ing
+	//  one BalanceLedgerEntry node.
+	BalanceLedgerEntryUpsertOne struct {
+		create *BalanceLedgerEntryCreate
+	}
+
+	// BalanceLedgerEntryUpsert is the "OnConflict" setter.
+	BalanceLedgerEntryUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUserID sets the "user_id" field.
+func (u *BalanceLedgerEntryUpsert) SetUserID(v int64) *BalanceLedgerEntryUpsert {
+	u.Set(balanceledgerentry.FieldUserID, v)
+	return u
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsert) UpdateUserID() *BalanceLedgerEntryUpsert {
+	u.SetExcluded(balanceledgerentry.FieldUserID)
+	return u
+}
+
+// AddUserID adds v to the "user_id" field.
+func (u *BalanceLedgerEntryUpsert) AddUserID(v int64) *BalanceLedgerEntryUpsert {
+	u.Add(balanceledgerentry.FieldUserID, v)
+	return u
+}
+
+// SetEntryType sets the "entry_type" field.
+func (u *BalanceLedgerEntryUpsert) SetEntryType(v string) *BalanceLedgerEntryUpsert {
+	u.Set(balanceledgerentry.FieldEntryType, v)
+	return u
+}
+
+// UpdateEntryType sets the "entry_type" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsert) UpdateEntryType() *BalanceLedgerEntryUpsert {
+	u.SetExcluded(balanceledgerentry.FieldEntryType)
+	return u
+}
+
+// SetAmount sets the "amount" field.
+func (u *BalanceLedgerEntryUpsert) SetAmount(v float64) *BalanceLedgerEntryUpsert {
+	u.Set(balanceledgerentry.FieldAmount, v)
+	return u
+}
+
+// UpdateAmount sets the "amount" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsert) UpdateAmount() *BalanceLedgerEntryUpsert {
+	u.SetExcluded(balanceledgerentry.FieldAmount)
+	return u
+}
+
+// AddAmount adds v to the "amount" field.
+func (u *BalanceLedgerEntryUpsert) AddAmount(v float64) *BalanceLedgerEntryUpsert {
+	u.Add(balanceledgerentry.FieldAmount, v)
+	return u
+}
+
+// SetBalanceBefore sets the "balance_before" field.
+func (u *BalanceLedgerEntryUpsert) SetBalanceBefore(v float64) *BalanceLedgerEntryUpsert {
+	u.Set(balanceledgerentry.FieldBalanceBefore, v)
+	return u
+}
+
+// UpdateBalanceBefore sets the "balance_before" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsert) UpdateBalanceBefore() *BalanceLedgerEntryUpsert {
+	u.SetExcluded(balanceledgerentry.FieldBalanceBefore)
+	return u
+}
+
+// AddBalanceBefore adds v to the "balance_before" field.
+func (u *BalanceLedgerEntryUpsert) AddBalanceBefore(v float64) *BalanceLedgerEntryUpsert {
+	u.Add(balanceledgerentry.FieldBalanceBefore, v)
+	return u
+}
+
+// SetBalanceAfter sets the "balance_after" field.
+func (u *BalanceLedgerEntryUpsert) SetBalanceAfter(v float64) *BalanceLedgerEntryUpsert {
+	u.Set(balanceledgerentry.FieldBalanceAfter, v)
+	return u
+}
+
+// UpdateBalanceAfter sets the "balance_after" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsert) UpdateBalanceAfter() *BalanceLedgerEntryUpsert {
+	u.SetExcluded(balanceledgerentry.FieldBalanceAfter)
+	return u
+}
+
+// AddBalanceAfter adds v to the "balance_after" field.
+func (u *BalanceLedgerEntryUpsert) AddBalanceAfter(v float64) *BalanceLedgerEntryUpsert {
+	u.Add(balanceledgerentry.FieldBalanceAfter, v)
+	return u
+}
+
+// SetReferenceID sets the "reference_id" field.
+func (u *BalanceLedgerEntryUpsert) SetReferenceID(v int64) *BalanceLedgerEntryUpsert {
+	u.Set(balanceledgerentry.FieldReferenceID, v)
+	return u
+}
+
+// UpdateReferenceID sets the "reference_id" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsert) UpdateReferenceID() *BalanceLedgerEntryUpsert {
+	u.SetExcluded(balanceledgerentry.FieldReferenceID)
+	return u
+}
+
+// AddReferenceID adds v to the "reference_id" field.
+func (u *BalanceLedgerEntryUpsert) AddReferenceID(v int64) *BalanceLedgerEntryUpsert {
+	u.Add(balanceledgerentry.FieldReferenceID, v)
+	return u
+}
+
+// ClearReferenceID clears the value of the "reference_id" field.
+func (u *BalanceLedgerEntryUpsert) ClearReferenceID() *BalanceLedgerEntryUpsert {
+	u.SetNull(balanceledgerentry.FieldReferenceID)
+	return u
+}
+
+// SetAPIKeyID sets the "api_key_id" field.
+func (u *BalanceLedgerEntryUpsert) SetAPIKeyID(v int64) *BalanceLedgerEntryUpsert {
+	u.Set(balanceledgerentry.FieldAPIKeyID, v)
+	return u
+}
+
+// UpdateAPIKeyID sets the "api_key_id" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsert) UpdateAPIKeyID() *BalanceLedgerEntryUpsert {
+	u.SetExcluded(balanceledgerentry.FieldAPIKeyID)
+	return u
+}
+
+// AddAPIKeyID adds v to the "api_key_id" field.
+func (u *BalanceLedgerEntryUpsert) AddAPIKeyID(v int64) *BalanceLedgerEntryUpsert {
+	u.Add(balanceledgerentry.FieldAPIKeyID, v)
+	return u
+}
+
+// ClearAPIKeyID clears the value of the "api_key_id" field.
+func (u *BalanceLedgerEntryUpsert) ClearAPIKeyID() *BalanceLedgerEntryUpsert {
+	u.SetNull(balanceledgerentry.FieldAPIKeyID)
+	return u
+}
+
+// SetNote sets the "note" field.
+func (u *BalanceLedgerEntryUpsert) SetNote(v string) *BalanceLedgerEntryUpsert {
+	u.Set(balanceledgerentry.FieldNote, v)
+	return u
+}
+
+// UpdateNote sets the "note" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsert) UpdateNote() *BalanceLedgerEntryUpsert {
+	u.SetExcluded(balanceledgerentry.FieldNote)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.BalanceLedgerEntry.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *BalanceLedgerEntryUpsertOne) UpdateNewValues() *BalanceLedgerEntryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(balanceledgerentry.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.BalanceLedgerEntry.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *BalanceLedgerEntryUpsertOne) Ignore() *BalanceLedgerEntryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *BalanceLedgerEntryUpsertOne) DoNothing() *BalanceLedgerEntryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the BalanceLedgerEntryCreate.OnConflict
+// documentation for more info.
+func (u *BalanceLedgerEntryUpsertOne) Update(set func(*BalanceLedgerEntryUpsert)) *BalanceLedgerEntryUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&BalanceLedgerEntryUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *BalanceLedgerEntryUpsertOne) SetUserID(v int64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// AddUserID adds v to the "user_id" field.
+func (u *BalanceLedgerEntryUpsertOne) AddUserID(v int64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertOne) UpdateUserID() *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetEntryType sets the "entry_type" field.
+func (u *BalanceLedgerEntryUpsertOne) SetEntryType(v string) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetEntryType(v)
+	})
+}
+
+// UpdateEntryType sets the "entry_type" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertOne) UpdateEntryType() *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateEntryType()
+	})
+}
+
+// SetAmount sets the "amount" field.
+func (u *BalanceLedgerEntryUpsertOne) SetAmount(v float64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetAmount(v)
+	})
+}
+
+// AddAmount adds v to the "amount" field.
+func (u *BalanceLedgerEntryUpsertOne) AddAmount(v float64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddAmount(v)
+	})
+}
+
+// UpdateAmount sets the "amount" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertOne) UpdateAmount() *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateAmount()
+	})
+}
+
+// SetBalanceBefore sets the "balance_before" field.
+func (u *BalanceLedgerEntryUpsertOne) SetBalanceBefore(v float64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetBalanceBefore(v)
+	})
+}
+
+// AddBalanceBefore adds v to the "balance_before" field.
+func (u *BalanceLedgerEntryUpsertOne) AddBalanceBefore(v float64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddBalanceBefore(v)
+	})
+}
+
+// UpdateBalanceBefore sets the "balance_before" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertOne) UpdateBalanceBefore() *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateBalanceBefore()
+	})
+}
+
+// SetBalanceAfter sets the "balance_after" field.
+func (u *BalanceLedgerEntryUpsertOne) SetBalanceAfter(v float64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetBalanceAfter(v)
+	})
+}
+
+// AddBalanceAfter adds v to the "balance_after" field.
+func (u *BalanceLedgerEntryUpsertOne) AddBalanceAfter(v float64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddBalanceAfter(v)
+	})
+}
+
+// UpdateBalanceAfter sets the "balance_after" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertOne) UpdateBalanceAfter() *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateBalanceAfter()
+	})
+}
+
+// SetReferenceID sets the "reference_id" field.
+func (u *BalanceLedgerEntryUpsertOne) SetReferenceID(v int64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetReferenceID(v)
+	})
+}
+
+// AddReferenceID adds v to the "reference_id" field.
+func (u *BalanceLedgerEntryUpsertOne) AddReferenceID(v int64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddReferenceID(v)
+	})
+}
+
+// UpdateReferenceID sets the "reference_id" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertOne) UpdateReferenceID() *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateReferenceID()
+	})
+}
+
+// ClearReferenceID clears the value of the "reference_id" field.
+func (u *BalanceLedgerEntryUpsertOne) ClearReferenceID() *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.ClearReferenceID()
+	})
+}
+
+// SetAPIKeyID sets the "api_key_id" field.
+func (u *BalanceLedgerEntryUpsertOne) SetAPIKeyID(v int64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetAPIKeyID(v)
+	})
+}
+
+// AddAPIKeyID adds v to the "api_key_id" field.
+func (u *BalanceLedgerEntryUpsertOne) AddAPIKeyID(v int64) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddAPIKeyID(v)
+	})
+}
+
+// UpdateAPIKeyID sets the "api_key_id" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertOne) UpdateAPIKeyID() *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateAPIKeyID()
+	})
+}
+
+// ClearAPIKeyID clears the value of the "api_key_id" field.
+func (u *BalanceLedgerEntryUpsertOne) ClearAPIKeyID() *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.ClearAPIKeyID()
+	})
+}
+
+// SetNote sets the "note" field.
+func (u *BalanceLedgerEntryUpsertOne) SetNote(v string) *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetNote(v)
+	})
+}
+
+// UpdateNote sets the "note" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertOne) UpdateNote() *BalanceLedgerEntryUpsertOne {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateNote()
+	})
+}
+
+// Exec executes the query.
+func (u *BalanceLedgerEntryUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for BalanceLedgerEntryCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *BalanceLedgerEntryUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *BalanceLedgerEntryUpsertOne) ID(ctx context.Context) (id int64, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *BalanceLedgerEntryUpsertOne) IDX(ctx context.Context) int64 {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// BalanceLedgerEntryCreateBulk is the builder for creating many BalanceLedgerEntry entities in bulk.
+type BalanceLedgerEntryCreateBulk struct {
+	config
+	err      error
+	builders []*BalanceLedgerEntryCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the BalanceLedgerEntry entities in the database.
+func (_c *BalanceLedgerEntryCreateBulk) Save(ctx context.Context) ([]*BalanceLedgerEntry, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*BalanceLedgerEntry, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*BalanceLedgerEntryMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *BalanceLedgerEntryCreateBulk) SaveX(ctx context.Context) []*BalanceLedgerEntry {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *BalanceLedgerEntryCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *BalanceLedgerEntryCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.BalanceLedgerEntry.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.BalanceLedgerEntryUpsert) {
+//			SetUserID(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *BalanceLedgerEntryCreateBulk) OnConflict(opts ...sql.ConflictOption) *BalanceLedgerEntryUpsertBulk {
+	_c.conflict = opts
+	return &BalanceLedgerEntryUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.BalanceLedgerEntry.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *BalanceLedgerEntryCreateBulk) OnConflictColumns(columns ...string) *BalanceLedgerEntryUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &BalanceLedgerEntryUpsertBulk{
+		create: _c,
+	}
+}
+
+// BalanceLedgerEntryUpsertBulk is the builder for "upsert"-ing
+// a bulk of BalanceLedgerEntry nodes.
+type BalanceLedgerEntryUpsertBulk struct {
+	create *BalanceLedgerEntryCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.BalanceLedgerEntry.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *BalanceLedgerEntryUpsertBulk) UpdateNewValues() *BalanceLedgerEntryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(balanceledgerentry.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.BalanceLedgerEntry.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *BalanceLedgerEntryUpsertBulk) Ignore() *BalanceLedgerEntryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *BalanceLedgerEntryUpsertBulk) DoNothing() *BalanceLedgerEntryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the BalanceLedgerEntryCreateBulk.OnConflict
+// documentation for more info.
+func (u *BalanceLedgerEntryUpsertBulk) Update(set func(*BalanceLedgerEntryUpsert)) *BalanceLedgerEntryUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&BalanceLedgerEntryUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *BalanceLedgerEntryUpsertBulk) SetUserID(v int64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// AddUserID adds v to the "user_id" field.
+func (u *BalanceLedgerEntryUpsertBulk) AddUserID(v int64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertBulk) UpdateUserID() *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetEntryType sets the "entry_type" field.
+func (u *BalanceLedgerEntryUpsertBulk) SetEntryType(v string) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetEntryType(v)
+	})
+}
+
+// UpdateEntryType sets the "entry_type" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertBulk) UpdateEntryType() *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateEntryType()
+	})
+}
+
+// SetAmount sets the "amount" field.
+func (u *BalanceLedgerEntryUpsertBulk) SetAmount(v float64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetAmount(v)
+	})
+}
+
+// AddAmount adds v to the "amount" field.
+func (u *BalanceLedgerEntryUpsertBulk) AddAmount(v float64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddAmount(v)
+	})
+}
+
+// UpdateAmount sets the "amount" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertBulk) UpdateAmount() *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateAmount()
+	})
+}
+
+// SetBalanceBefore sets the "balance_before" field.
+func (u *BalanceLedgerEntryUpsertBulk) SetBalanceBefore(v float64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetBalanceBefore(v)
+	})
+}
+
+// AddBalanceBefore adds v to the "balance_before" field.
+func (u *BalanceLedgerEntryUpsertBulk) AddBalanceBefore(v float64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddBalanceBefore(v)
+	})
+}
+
+// UpdateBalanceBefore sets the "balance_before" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertBulk) UpdateBalanceBefore() *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateBalanceBefore()
+	})
+}
+
+// SetBalanceAfter sets the "balance_after" field.
+func (u *BalanceLedgerEntryUpsertBulk) SetBalanceAfter(v float64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetBalanceAfter(v)
+	})
+}
+
+// AddBalanceAfter adds v to the "balance_after" field.
+func (u *BalanceLedgerEntryUpsertBulk) AddBalanceAfter(v float64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddBalanceAfter(v)
+	})
+}
+
+// UpdateBalanceAfter sets the "balance_after" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertBulk) UpdateBalanceAfter() *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateBalanceAfter()
+	})
+}
+
+// SetReferenceID sets the "reference_id" field.
+func (u *BalanceLedgerEntryUpsertBulk) SetReferenceID(v int64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetReferenceID(v)
+	})
+}
+
+// AddReferenceID adds v to the "reference_id" field.
+func (u *BalanceLedgerEntryUpsertBulk) AddReferenceID(v int64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddReferenceID(v)
+	})
+}
+
+// UpdateReferenceID sets the "reference_id" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertBulk) UpdateReferenceID() *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateReferenceID()
+	})
+}
+
+// ClearReferenceID clears the value of the "reference_id" field.
+func (u *BalanceLedgerEntryUpsertBulk) ClearReferenceID() *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.ClearReferenceID()
+	})
+}
+
+// SetAPIKeyID sets the "api_key_id" field.
+func (u *BalanceLedgerEntryUpsertBulk) SetAPIKeyID(v int64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetAPIKeyID(v)
+	})
+}
+
+// AddAPIKeyID adds v to the "api_key_id" field.
+func (u *BalanceLedgerEntryUpsertBulk) AddAPIKeyID(v int64) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.AddAPIKeyID(v)
+	})
+}
+
+// UpdateAPIKeyID sets the "api_key_id" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertBulk) UpdateAPIKeyID() *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateAPIKeyID()
+	})
+}
+
+// ClearAPIKeyID clears the value of the "api_key_id" field.
+func (u *BalanceLedgerEntryUpsertBulk) ClearAPIKeyID() *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.ClearAPIKeyID()
+	})
+}
+
+// SetNote sets the "note" field.
+func (u *BalanceLedgerEntryUpsertBulk) SetNote(v string) *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.SetNote(v)
+	})
+}
+
+// UpdateNote sets the "note" field to the value that was provided on create.
+func (u *BalanceLedgerEntryUpsertBulk) UpdateNote() *BalanceLedgerEntryUpsertBulk {
+	return u.Update(func(s *BalanceLedgerEntryUpsert) {
+		s.UpdateNote()
+	})
+}
+
+// Exec executes the query.
+func (u *BalanceLedgerEntryUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the BalanceLedgerEntryCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for BalanceLedgerEntryCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *BalanceLedgerEntryUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
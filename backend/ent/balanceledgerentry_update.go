@@ -0,0 +1,629 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/balanceledgerentry"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+)
+
+// BalanceLedgerEntryUpdate is the builder for updating BalanceLedgerEntry entities.
+type BalanceLedgerEntryUpdate struct {
+	config
+	hooks    []Hook
+	mutation *BalanceLedgerEntryMutation
+}
+
+// Where appends a list predicates to the BalanceLedgerEntryUpdate builder.
+func (_u *BalanceLedgerEntryUpdate) Where(ps ...predicate.BalanceLedgerEntry) *BalanceLedgerEntryUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *BalanceLedgerEntryUpdate) SetUserID(v int64) *BalanceLedgerEntryUpdate {
+	_u.mutation.ResetUserID()
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdate) SetNillableUserID(v *int64) *BalanceLedgerEntryUpdate {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// AddUserID adds value to the "user_id" field.
+func (_u *BalanceLedgerEntryUpdate) AddUserID(v int64) *BalanceLedgerEntryUpdate {
+	_u.mutation.AddUserID(v)
+	return _u
+}
+
+// SetEntryType sets the "entry_type" field.
+func (_u *BalanceLedgerEntryUpdate) SetEntryType(v string) *BalanceLedgerEntryUpdate {
+	_u.mutation.SetEntryType(v)
+	return _u
+}
+
+// SetNillableEntryType sets the "entry_type" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdate) SetNillableEntryType(v *string) *BalanceLedgerEntryUpdate {
+	if v != nil {
+		_u.SetEntryType(*v)
+	}
+	return _u
+}
+
+// SetAmount sets the "amount" field.
+func (_u *BalanceLedgerEntryUpdate) SetAmount(v float64) *BalanceLedgerEntryUpdate {
+	_u.mutation.ResetAmount()
+	_u.mutation.SetAmount(v)
+	return _u
+}
+
+// SetNillableAmount sets the "amount" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdate) SetNillableAmount(v *float64) *BalanceLedgerEntryUpdate {
+	if v != nil {
+		_u.SetAmount(*v)
+	}
+	return _u
+}
+
+// AddAmount adds value to the "amount" field.
+func (_u *BalanceLedgerEntryUpdate) AddAmount(v float64) *BalanceLedgerEntryUpdate {
+	_u.mutation.AddAmount(v)
+	return _u
+}
+
+// SetBalanceBefore sets the "balance_before" field.
+func (_u *BalanceLedgerEntryUpdate) SetBalanceBefore(v float64) *BalanceLedgerEntryUpdate {
+	_u.mutation.ResetBalanceBefore()
+	_u.mutation.SetBalanceBefore(v)
+	return _u
+}
+
+// SetNillableBalanceBefore sets the "balance_before" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdate) SetNillableBalanceBefore(v *float64) *BalanceLedgerEntryUpdate {
+	if v != nil {
+		_u.SetBalanceBefore(*v)
+	}
+	return _u
+}
+
+// AddBalanceBefore adds value to the "balance_before" field.
+func (_u *BalanceLedgerEntryUpdate) AddBalanceBefore(v float64) *BalanceLedgerEntryUpdate {
+	_u.mutation.AddBalanceBefore(v)
+	return _u
+}
+
+// SetBalanceAfter sets the "balance_after" field.
+func (_u *BalanceLedgerEntryUpdate) SetBalanceAfter(v float64) *BalanceLedgerEntryUpdate {
+	_u.mutation.ResetBalanceAfter()
+	_u.mutation.SetBalanceAfter(v)
+	return _u
+}
+
+// SetNillableBalanceAfter sets the "balance_after" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdate) SetNillableBalanceAfter(v *float64) *BalanceLedgerEntryUpdate {
+	if v != nil {
+		_u.SetBalanceAfter(*v)
+	}
+	return _u
+}
+
+// AddBalanceAfter adds value to the "balance_after" field.
+func (_u *BalanceLedgerEntryUpdate) AddBalanceAfter(v float64) *BalanceLedgerEntryUpdate {
+	_u.mutation.AddBalanceAfter(v)
+	return _u
+}
+
+// SetReferenceID sets the "reference_id" field.
+func (_u *BalanceLedgerEntryUpdate) SetReferenceID(v int64) *BalanceLedgerEntryUpdate {
+	_u.mutation.ResetReferenceID()
+	_u.mutation.SetReferenceID(v)
+	return _u
+}
+
+// SetNillableReferenceID sets the "reference_id" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdate) SetNillableReferenceID(v *int64) *BalanceLedgerEntryUpdate {
+	if v != nil {
+		_u.SetReferenceID(*v)
+	}
+	return _u
+}
+
+// AddReferenceID adds value to the "reference_id" field.
+func (_u *BalanceLedgerEntryUpdate) AddReferenceID(v int64) *BalanceLedgerEntryUpdate {
+	_u.mutation.AddReferenceID(v)
+	return _u
+}
+
+// ClearReferenceID clears the value of the "reference_id" field.
+func (_u *BalanceLedgerEntryUpdate) ClearReferenceID() *BalanceLedgerEntryUpdate {
+	_u.mutation.ClearReferenceID()
+	return _u
+}
+
+// SetAPIKeyID sets the "api_key_id" field.
+func (_u *BalanceLedgerEntryUpdate) SetAPIKeyID(v int64) *BalanceLedgerEntryUpdate {
+	_u.mutation.ResetAPIKeyID()
+	_u.mutation.SetAPIKeyID(v)
+	return _u
+}
+
+// SetNillableAPIKeyID sets the "api_key_id" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdate) SetNillableAPIKeyID(v *int64) *BalanceLedgerEntryUpdate {
+	if v != nil {
+		_u.SetAPIKeyID(*v)
+	}
+	return _u
+}
+
+// AddAPIKeyID adds value to the "api_key_id" field.
+func (_u *BalanceLedgerEntryUpdate) AddAPIKeyID(v int64) *BalanceLedgerEntryUpdate {
+	_u.mutation.AddAPIKeyID(v)
+	return _u
+}
+
+// ClearAPIKeyID clears the value of the "api_key_id" field.
+func (_u *BalanceLedgerEntryUpdate) ClearAPIKeyID() *BalanceLedgerEntryUpdate {
+	_u.mutation.ClearAPIKeyID()
+	return _u
+}
+
+// SetNote sets the "note" field.
+func (_u *BalanceLedgerEntryUpdate) SetNote(v string) *BalanceLedgerEntryUpdate {
+	_u.mutation.SetNote(v)
+	return _u
+}
+
+// SetNillableNote sets the "note" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdate) SetNillableNote(v *string) *BalanceLedgerEntryUpdate {
+	if v != nil {
+		_u.SetNote(*v)
+	}
+	return _u
+}
+
+// Mutation returns the BalanceLedgerEntryMutation object of the builder.
+func (_u *BalanceLedgerEntryUpdate) Mutation() *BalanceLedgerEntryMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *BalanceLedgerEntryUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *BalanceLedgerEntryUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *BalanceLedgerEntryUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *BalanceLedgerEntryUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *BalanceLedgerEntryUpdate) check() error {
+	if v, ok := _u.mutation.EntryType(); ok {
+		if err := balanceledgerentry.EntryTypeValidator(v); err != nil {
+			return &ValidationError{Name: "entry_type", err: fmt.Errorf(`ent: validator failed for field "BalanceLedgerEntry.entry_type": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *BalanceLedgerEntryUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(balanceledgerentry.Table, balanceledgerentry.Columns, sqlgraph.NewFieldSpec(balanceledgerentry.FieldID, field.TypeInt64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(balanceledgerentry.FieldUserID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUserID(); ok {
+		_spec.AddField(balanceledgerentry.FieldUserID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.EntryType(); ok {
+		_spec.SetField(balanceledgerentry.FieldEntryType, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Amount(); ok {
+		_spec.SetField(balanceledgerentry.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedAmount(); ok {
+		_spec.AddField(balanceledgerentry.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.BalanceBefore(); ok {
+		_spec.SetField(balanceledgerentry.FieldBalanceBefore, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedBalanceBefore(); ok {
+		_spec.AddField(balanceledgerentry.FieldBalanceBefore, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.BalanceAfter(); ok {
+		_spec.SetField(balanceledgerentry.FieldBalanceAfter, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedBalanceAfter(); ok {
+		_spec.AddField(balanceledgerentry.FieldBalanceAfter, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.ReferenceID(); ok {
+		_spec.SetField(balanceledgerentry.FieldReferenceID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedReferenceID(); ok {
+		_spec.AddField(balanceledgerentry.FieldReferenceID, field.TypeInt64, value)
+	}
+	if _u.mutation.ReferenceIDCleared() {
+		_spec.ClearField(balanceledgerentry.FieldReferenceID, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.APIKeyID(); ok {
+		_spec.SetField(balanceledgerentry.FieldAPIKeyID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedAPIKeyID(); ok {
+		_spec.AddField(balanceledgerentry.FieldAPIKeyID, field.TypeInt64, value)
+	}
+	if _u.mutation.APIKeyIDCleared() {
+		_spec.ClearField(balanceledgerentry.FieldAPIKeyID, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.Note(); ok {
+		_spec.SetField(balanceledgerentry.FieldNote, field.TypeString, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{balanceledgerentry.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// BalanceLedgerEntryUpdateOne is the builder for updating a single BalanceLedgerEntry entity.
+type BalanceLedgerEntryUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *BalanceLedgerEntryMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *BalanceLedgerEntryUpdateOne) SetUserID(v int64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.ResetUserID()
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdateOne) SetNillableUserID(v *int64) *BalanceLedgerEntryUpdateOne {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// AddUserID adds value to the "user_id" field.
+func (_u *BalanceLedgerEntryUpdateOne) AddUserID(v int64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.AddUserID(v)
+	return _u
+}
+
+// SetEntryType sets the "entry_type" field.
+func (_u *BalanceLedgerEntryUpdateOne) SetEntryType(v string) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.SetEntryType(v)
+	return _u
+}
+
+// SetNillableEntryType sets the "entry_type" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdateOne) SetNillableEntryType(v *string) *BalanceLedgerEntryUpdateOne {
+	if v != nil {
+		_u.SetEntryType(*v)
+	}
+	return _u
+}
+
+// SetAmount sets the "amount" field.
+func (_u *BalanceLedgerEntryUpdateOne) SetAmount(v float64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.ResetAmount()
+	_u.mutation.SetAmount(v)
+	return _u
+}
+
+// SetNillableAmount sets the "amount" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdateOne) SetNillableAmount(v *float64) *BalanceLedgerEntryUpdateOne {
+	if v != nil {
+		_u.SetAmount(*v)
+	}
+	return _u
+}
+
+// AddAmount adds value to the "amount" field.
+func (_u *BalanceLedgerEntryUpdateOne) AddAmount(v float64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.AddAmount(v)
+	return _u
+}
+
+// SetBalanceBefore sets the "balance_before" field.
+func (_u *BalanceLedgerEntryUpdateOne) SetBalanceBefore(v float64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.ResetBalanceBefore()
+	_u.mutation.SetBalanceBefore(v)
+	return _u
+}
+
+// SetNillableBalanceBefore sets the "balance_before" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdateOne) SetNillableBalanceBefore(v *float64) *BalanceLedgerEntryUpdateOne {
+	if v != nil {
+		_u.SetBalanceBefore(*v)
+	}
+	return _u
+}
+
+// AddBalanceBefore adds value to the "balance_before" field.
+func (_u *BalanceLedgerEntryUpdateOne) AddBalanceBefore(v float64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.AddBalanceBefore(v)
+	return _u
+}
+
+// SetBalanceAfter sets the "balance_after" field.
+func (_u *BalanceLedgerEntryUpdateOne) SetBalanceAfter(v float64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.ResetBalanceAfter()
+	_u.mutation.SetBalanceAfter(v)
+	return _u
+}
+
+// SetNillableBalanceAfter sets the "balance_after" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdateOne) SetNillableBalanceAfter(v *float64) *BalanceLedgerEntryUpdateOne {
+	if v != nil {
+		_u.SetBalanceAfter(*v)
+	}
+	return _u
+}
+
+// AddBalanceAfter adds value to the "balance_after" field.
+func (_u *BalanceLedgerEntryUpdateOne) AddBalanceAfter(v float64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.AddBalanceAfter(v)
+	return _u
+}
+
+// SetReferenceID sets the "reference_id" field.
+func (_u *BalanceLedgerEntryUpdateOne) SetReferenceID(v int64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.ResetReferenceID()
+	_u.mutation.SetReferenceID(v)
+	return _u
+}
+
+// SetNillableReferenceID sets the "reference_id" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdateOne) SetNillableReferenceID(v *int64) *BalanceLedgerEntryUpdateOne {
+	if v != nil {
+		_u.SetReferenceID(*v)
+	}
+	return _u
+}
+
+// AddReferenceID adds value to the "reference_id" field.
+func (_u *BalanceLedgerEntryUpdateOne) AddReferenceID(v int64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.AddReferenceID(v)
+	return _u
+}
+
+// ClearReferenceID clears the value of the "reference_id" field.
+func (_u *BalanceLedgerEntryUpdateOne) ClearReferenceID() *BalanceLedgerEntryUpdateOne {
+	_u.mutation.ClearReferenceID()
+	return _u
+}
+
+// SetAPIKeyID sets the "api_key_id" field.
+func (_u *BalanceLedgerEntryUpdateOne) SetAPIKeyID(v int64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.ResetAPIKeyID()
+	_u.mutation.SetAPIKeyID(v)
+	return _u
+}
+
+// SetNillableAPIKeyID sets the "api_key_id" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdateOne) SetNillableAPIKeyID(v *int64) *BalanceLedgerEntryUpdateOne {
+	if v != nil {
+		_u.SetAPIKeyID(*v)
+	}
+	return _u
+}
+
+// AddAPIKeyID adds value to the "api_key_id" field.
+func (_u *BalanceLedgerEntryUpdateOne) AddAPIKeyID(v int64) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.AddAPIKeyID(v)
+	return _u
+}
+
+// ClearAPIKeyID clears the value of the "api_key_id" field.
+func (_u *BalanceLedgerEntryUpdateOne) ClearAPIKeyID() *BalanceLedgerEntryUpdateOne {
+	_u.mutation.ClearAPIKeyID()
+	return _u
+}
+
+// SetNote sets the "note" field.
+func (_u *BalanceLedgerEntryUpdateOne) SetNote(v string) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.SetNote(v)
+	return _u
+}
+
+// SetNillableNote sets the "note" field if the given value is not nil.
+func (_u *BalanceLedgerEntryUpdateOne) SetNillableNote(v *string) *BalanceLedgerEntryUpdateOne {
+	if v != nil {
+		_u.SetNote(*v)
+	}
+	return _u
+}
+
+// Mutation returns the BalanceLedgerEntryMutation object of the builder.
+func (_u *BalanceLedgerEntryUpdateOne) Mutation() *BalanceLedgerEntryMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the BalanceLedgerEntryUpdate builder.
+func (_u *BalanceLedgerEntryUpdateOne) Where(ps ...predicate.BalanceLedgerEntry) *BalanceLedgerEntryUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *BalanceLedgerEntryUpdateOne) Select(field string, fields ...string) *BalanceLedgerEntryUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated BalanceLedgerEntry entity.
+func (_u *BalanceLedgerEntryUpdateOne) Save(ctx context.Context) (*BalanceLedgerEntry, error) {
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *BalanceLedgerEntryUpdateOne) SaveX(ctx context.Context) *BalanceLedgerEntry {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *BalanceLedgerEntryUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *BalanceLedgerEntryUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *BalanceLedgerEntryUpdateOne) check() error {
+	if v, ok := _u.mutation.EntryType(); ok {
+		if err := balanceledgerentry.EntryTypeValidator(v); err != nil {
+			return &ValidationError{Name: "entry_type", err: fmt.Errorf(`ent: validator failed for field "BalanceLedgerEntry.entry_type": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *BalanceLedgerEntryUpdateOne) sqlSave(ctx context.Context) (_node *BalanceLedgerEntry, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(balanceledgerentry.Table, balanceledgerentry.Columns, sqlgraph.NewFieldSpec(balanceledgerentry.FieldID, field.TypeInt64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "BalanceLedgerEntry.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, balanceledgerentry.FieldID)
+		for _, f := range fields {
+			if !balanceledgerentry.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != balanceledgerentry.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(balanceledgerentry.FieldUserID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedUserID(); ok {
+		_spec.AddField(balanceledgerentry.FieldUserID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.EntryType(); ok {
+		_spec.SetField(balanceledgerentry.FieldEntryType, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Amount(); ok {
+		_spec.SetField(balanceledgerentry.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedAmount(); ok {
+		_spec.AddField(balanceledgerentry.FieldAmount, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.BalanceBefore(); ok {
+		_spec.SetField(balanceledgerentry.FieldBalanceBefore, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedBalanceBefore(); ok {
+		_spec.AddField(balanceledgerentry.FieldBalanceBefore, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.BalanceAfter(); ok {
+		_spec.SetField(balanceledgerentry.FieldBalanceAfter, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.AddedBalanceAfter(); ok {
+		_spec.AddField(balanceledgerentry.FieldBalanceAfter, field.TypeFloat64, value)
+	}
+	if value, ok := _u.mutation.ReferenceID(); ok {
+		_spec.SetField(balanceledgerentry.FieldReferenceID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedReferenceID(); ok {
+		_spec.AddField(balanceledgerentry.FieldReferenceID, field.TypeInt64, value)
+	}
+	if _u.mutation.ReferenceIDCleared() {
+		_spec.ClearField(balanceledgerentry.FieldReferenceID, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.APIKeyID(); ok {
+		_spec.SetField(balanceledgerentry.FieldAPIKeyID, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedAPIKeyID(); ok {
+		_spec.AddField(balanceledgerentry.FieldAPIKeyID, field.TypeInt64, value)
+	}
+	if _u.mutation.APIKeyIDCleared() {
+		_spec.ClearField(balanceledgerentry.FieldAPIKeyID, field.TypeInt64)
+	}
+	if value, ok := _u.mutation.Note(); ok {
+		_spec.SetField(balanceledgerentry.FieldNote, field.TypeString, value)
+	}
+	_node = &BalanceLedgerEntry{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{balanceledgerentry.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"github.com/Wei-Shaw/sub2api/ent/group"
+	"github.com/Wei-Shaw/sub2api/internal/domain"
 )
 
 // Group is the model entity for the Group schema.
@@ -68,6 +69,20 @@ type Group struct {
 	SupportedModelScopes []string `json:"supported_model_scopes,omitempty"`
 	// 分组显示排序，数值越小越靠前
 	SortOrder int `json:"sort_order,omitempty"`
+	// 分组内 API Key 默认每分钟请求数上限（0 = 不限制）
+	DefaultRpmLimit int `json:"default_rpm_limit,omitempty"`
+	// 分组内 API Key 默认每分钟 token 数上限（0 = 不限制）
+	DefaultTpmLimit int `json:"default_tpm_limit,omitempty"`
+	// 分组内 API Key 默认每日请求数上限（0 = 不限制）
+	DefaultDailyRequestLimit int `json:"default_daily_request_limit,omitempty"`
+	// 是否缓存 temperature=0 的确定性请求响应
+	ResponseCacheEnabled bool `json:"response_cache_enabled,omitempty"`
+	// 响应缓存过期时间（秒，0 = 使用全局默认值）
+	ResponseCacheTTLSeconds int `json:"response_cache_ttl_seconds,omitempty"`
+	// Claude 模型 → OpenAI 模型映射：Claude 账号不可用时转换为 Responses API 请求 OpenAI 账号
+	OpenaiFallbackModelMapping map[string]string `json:"openai_fallback_model_mapping,omitempty"`
+	// 分组请求策略：max_tokens/thinking 预算上限、禁用工具、强制 temperature、合规系统提示词、拦截正则
+	RequestPolicy *domain.RequestPolicy `json:"request_policy,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the GroupQuery when eager-loading is set.
 	Edges        GroupEdges `json:"edges"`
@@ -174,13 +189,13 @@ func (*Group) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case group.FieldModelRouting, group.FieldSupportedModelScopes:
+		case group.FieldModelRouting, group.FieldSupportedModelScopes, group.FieldOpenaiFallbackModelMapping, group.FieldRequestPolicy:
 			values[i] = new([]byte)
-		case group.FieldIsExclusive, group.FieldClaudeCodeOnly, group.FieldModelRoutingEnabled, group.FieldMcpXMLInject:
+		case group.FieldIsExclusive, group.FieldClaudeCodeOnly, group.FieldModelRoutingEnabled, group.FieldMcpXMLInject, group.FieldResponseCacheEnabled:
 			values[i] = new(sql.NullBool)
 		case group.FieldRateMultiplier, group.FieldDailyLimitUsd, group.FieldWeeklyLimitUsd, group.FieldMonthlyLimitUsd, group.FieldImagePrice1k, group.FieldImagePrice2k, group.FieldImagePrice4k:
 			values[i] = new(sql.NullFloat64)
-		case group.FieldID, group.FieldDefaultValidityDays, group.FieldFallbackGroupID, group.FieldFallbackGroupIDOnInvalidRequest, group.FieldSortOrder:
+		case group.FieldID, group.FieldDefaultValidityDays, group.FieldFallbackGroupID, group.FieldFallbackGroupIDOnInvalidRequest, group.FieldSortOrder, group.FieldDefaultRpmLimit, group.FieldDefaultTpmLimit, group.FieldDefaultDailyRequestLimit, group.FieldResponseCacheTTLSeconds:
 			values[i] = new(sql.NullInt64)
 		case group.FieldName, group.FieldDescription, group.FieldStatus, group.FieldPlatform, group.FieldSubscriptionType:
 			values[i] = new(sql.NullString)
@@ -371,6 +386,52 @@ func (_m *Group) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.SortOrder = int(value.Int64)
 			}
+		case group.FieldDefaultRpmLimit:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field default_rpm_limit", values[i])
+			} else if value.Valid {
+				_m.DefaultRpmLimit = int(value.Int64)
+			}
+		case group.FieldDefaultTpmLimit:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field default_tpm_limit", values[i])
+			} else if value.Valid {
+				_m.DefaultTpmLimit = int(value.Int64)
+			}
+		case group.FieldDefaultDailyRequestLimit:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field default_daily_request_limit", values[i])
+			} else if value.Valid {
+				_m.DefaultDailyRequestLimit = int(value.Int64)
+			}
+		case group.FieldResponseCacheEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field response_cache_enabled", values[i])
+			} else if value.Valid {
+				_m.ResponseCacheEnabled = value.Bool
+			}
+		case group.FieldResponseCacheTTLSeconds:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field response_cache_ttl_seconds", values[i])
+			} else if value.Valid {
+				_m.ResponseCacheTTLSeconds = int(value.Int64)
+			}
+		case group.FieldOpenaiFallbackModelMapping:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field openai_fallback_model_mapping", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.OpenaiFallbackModelMapping); err != nil {
+					return fmt.Errorf("unmarshal field openai_fallback_model_mapping: %w", err)
+				}
+			}
+		case group.FieldRequestPolicy:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field request_policy", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.RequestPolicy); err != nil {
+					return fmt.Errorf("unmarshal field request_policy: %w", err)
+				}
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -541,6 +602,27 @@ func (_m *Group) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("sort_order=")
 	builder.WriteString(fmt.Sprintf("%v", _m.SortOrder))
+	builder.WriteString(", ")
+	builder.WriteString("default_rpm_limit=")
+	builder.WriteString(fmt.Sprintf("%v", _m.DefaultRpmLimit))
+	builder.WriteString(", ")
+	builder.WriteString("default_tpm_limit=")
+	builder.WriteString(fmt.Sprintf("%v", _m.DefaultTpmLimit))
+	builder.WriteString(", ")
+	builder.WriteString("default_daily_request_limit=")
+	builder.WriteString(fmt.Sprintf("%v", _m.DefaultDailyRequestLimit))
+	builder.WriteString(", ")
+	builder.WriteString("response_cache_enabled=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ResponseCacheEnabled))
+	builder.WriteString(", ")
+	builder.WriteString("response_cache_ttl_seconds=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ResponseCacheTTLSeconds))
+	builder.WriteString(", ")
+	builder.WriteString("openai_fallback_model_mapping=")
+	builder.WriteString(fmt.Sprintf("%v", _m.OpenaiFallbackModelMapping))
+	builder.WriteString(", ")
+	builder.WriteString("request_policy=")
+	builder.WriteString(fmt.Sprintf("%v", _m.RequestPolicy))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -65,6 +65,20 @@ const (
 	FieldSupportedModelScopes = "supported_model_scopes"
 	// FieldSortOrder holds the string denoting the sort_order field in the database.
 	FieldSortOrder = "sort_order"
+	// FieldDefaultRpmLimit holds the string denoting the default_rpm_limit field in the database.
+	FieldDefaultRpmLimit = "default_rpm_limit"
+	// FieldDefaultTpmLimit holds the string denoting the default_tpm_limit field in the database.
+	FieldDefaultTpmLimit = "default_tpm_limit"
+	// FieldDefaultDailyRequestLimit holds the string denoting the default_daily_request_limit field in the database.
+	FieldDefaultDailyRequestLimit = "default_daily_request_limit"
+	// FieldResponseCacheEnabled holds the string denoting the response_cache_enabled field in the database.
+	FieldResponseCacheEnabled = "response_cache_enabled"
+	// FieldResponseCacheTTLSeconds holds the string denoting the response_cache_ttl_seconds field in the database.
+	FieldResponseCacheTTLSeconds = "response_cache_ttl_seconds"
+	// FieldOpenaiFallbackModelMapping holds the string denoting the openai_fallback_model_mapping field in the database.
+	FieldOpenaiFallbackModelMapping = "openai_fallback_model_mapping"
+	// FieldRequestPolicy holds the string denoting the request_policy field in the database.
+	FieldRequestPolicy = "request_policy"
 	// EdgeAPIKeys holds the string denoting the api_keys edge name in mutations.
 	EdgeAPIKeys = "api_keys"
 	// EdgeRedeemCodes holds the string denoting the redeem_codes edge name in mutations.
@@ -165,6 +179,13 @@ var Columns = []string{
 	FieldMcpXMLInject,
 	FieldSupportedModelScopes,
 	FieldSortOrder,
+	FieldDefaultRpmLimit,
+	FieldDefaultTpmLimit,
+	FieldDefaultDailyRequestLimit,
+	FieldResponseCacheEnabled,
+	FieldResponseCacheTTLSeconds,
+	FieldOpenaiFallbackModelMapping,
+	FieldRequestPolicy,
 }
 
 var (
@@ -230,6 +251,16 @@ var (
 	DefaultSupportedModelScopes []string
 	// DefaultSortOrder holds the default value on creation for the "sort_order" field.
 	DefaultSortOrder int
+	// DefaultDefaultRpmLimit holds the default value on creation for the "default_rpm_limit" field.
+	DefaultDefaultRpmLimit int
+	// DefaultDefaultTpmLimit holds the default value on creation for the "default_tpm_limit" field.
+	DefaultDefaultTpmLimit int
+	// DefaultDefaultDailyRequestLimit holds the default value on creation for the "default_daily_request_limit" field.
+	DefaultDefaultDailyRequestLimit int
+	// DefaultResponseCacheEnabled holds the default value on creation for the "response_cache_enabled" field.
+	DefaultResponseCacheEnabled bool
+	// DefaultResponseCacheTTLSeconds holds the default value on creation for the "response_cache_ttl_seconds" field.
+	DefaultResponseCacheTTLSeconds int
 )
 
 // OrderOption defines the ordering options for the Group queries.
@@ -355,6 +386,31 @@ func BySortOrder(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldSortOrder, opts...).ToFunc()
 }
 
+// ByDefaultRpmLimit orders the results by the default_rpm_limit field.
+func ByDefaultRpmLimit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDefaultRpmLimit, opts...).ToFunc()
+}
+
+// ByDefaultTpmLimit orders the results by the default_tpm_limit field.
+func ByDefaultTpmLimit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDefaultTpmLimit, opts...).ToFunc()
+}
+
+// ByDefaultDailyRequestLimit orders the results by the default_daily_request_limit field.
+func ByDefaultDailyRequestLimit(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDefaultDailyRequestLimit, opts...).ToFunc()
+}
+
+// ByResponseCacheEnabled orders the results by the response_cache_enabled field.
+func ByResponseCacheEnabled(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResponseCacheEnabled, opts...).ToFunc()
+}
+
+// ByResponseCacheTTLSeconds orders the results by the response_cache_ttl_seconds field.
+func ByResponseCacheTTLSeconds(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResponseCacheTTLSeconds, opts...).ToFunc()
+}
+
 // ByAPIKeysCount orders the results by api_keys count.
 func ByAPIKeysCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
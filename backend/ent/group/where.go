@@ -170,6 +170,31 @@ func SortOrder(v int) predicate.Group {
 	return predicate.Group(sql.FieldEQ(FieldSortOrder, v))
 }
 
+// DefaultRpmLimit applies equality check predicate on the "default_rpm_limit" field. It's identical to DefaultRpmLimitEQ.
+func DefaultRpmLimit(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDefaultRpmLimit, v))
+}
+
+// DefaultTpmLimit applies equality check predicate on the "default_tpm_limit" field. It's identical to DefaultTpmLimitEQ.
+func DefaultTpmLimit(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDefaultTpmLimit, v))
+}
+
+// DefaultDailyRequestLimit applies equality check predicate on the "default_daily_request_limit" field. It's identical to DefaultDailyRequestLimitEQ.
+func DefaultDailyRequestLimit(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDefaultDailyRequestLimit, v))
+}
+
+// ResponseCacheEnabled applies equality check predicate on the "response_cache_enabled" field. It's identical to ResponseCacheEnabledEQ.
+func ResponseCacheEnabled(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldResponseCacheEnabled, v))
+}
+
+// ResponseCacheTTLSeconds applies equality check predicate on the "response_cache_ttl_seconds" field. It's identical to ResponseCacheTTLSecondsEQ.
+func ResponseCacheTTLSeconds(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldResponseCacheTTLSeconds, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Group {
 	return predicate.Group(sql.FieldEQ(FieldCreatedAt, v))
@@ -1205,6 +1230,196 @@ func SortOrderLTE(v int) predicate.Group {
 	return predicate.Group(sql.FieldLTE(FieldSortOrder, v))
 }
 
+// DefaultRpmLimitEQ applies the EQ predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDefaultRpmLimit, v))
+}
+
+// DefaultRpmLimitNEQ applies the NEQ predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldDefaultRpmLimit, v))
+}
+
+// DefaultRpmLimitIn applies the In predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldDefaultRpmLimit, vs...))
+}
+
+// DefaultRpmLimitNotIn applies the NotIn predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldDefaultRpmLimit, vs...))
+}
+
+// DefaultRpmLimitGT applies the GT predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldDefaultRpmLimit, v))
+}
+
+// DefaultRpmLimitGTE applies the GTE predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldDefaultRpmLimit, v))
+}
+
+// DefaultRpmLimitLT applies the LT predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldDefaultRpmLimit, v))
+}
+
+// DefaultRpmLimitLTE applies the LTE predicate on the "default_rpm_limit" field.
+func DefaultRpmLimitLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldDefaultRpmLimit, v))
+}
+
+// DefaultTpmLimitEQ applies the EQ predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDefaultTpmLimit, v))
+}
+
+// DefaultTpmLimitNEQ applies the NEQ predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldDefaultTpmLimit, v))
+}
+
+// DefaultTpmLimitIn applies the In predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldDefaultTpmLimit, vs...))
+}
+
+// DefaultTpmLimitNotIn applies the NotIn predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldDefaultTpmLimit, vs...))
+}
+
+// DefaultTpmLimitGT applies the GT predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldDefaultTpmLimit, v))
+}
+
+// DefaultTpmLimitGTE applies the GTE predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldDefaultTpmLimit, v))
+}
+
+// DefaultTpmLimitLT applies the LT predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldDefaultTpmLimit, v))
+}
+
+// DefaultTpmLimitLTE applies the LTE predicate on the "default_tpm_limit" field.
+func DefaultTpmLimitLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldDefaultTpmLimit, v))
+}
+
+// DefaultDailyRequestLimitEQ applies the EQ predicate on the "default_daily_request_limit" field.
+func DefaultDailyRequestLimitEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldDefaultDailyRequestLimit, v))
+}
+
+// DefaultDailyRequestLimitNEQ applies the NEQ predicate on the "default_daily_request_limit" field.
+func DefaultDailyRequestLimitNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldDefaultDailyRequestLimit, v))
+}
+
+// DefaultDailyRequestLimitIn applies the In predicate on the "default_daily_request_limit" field.
+func DefaultDailyRequestLimitIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldDefaultDailyRequestLimit, vs...))
+}
+
+// DefaultDailyRequestLimitNotIn applies the NotIn predicate on the "default_daily_request_limit" field.
+func DefaultDailyRequestLimitNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldDefaultDailyRequestLimit, vs...))
+}
+
+// DefaultDailyRequestLimitGT applies the GT predicate on the "default_daily_request_limit" field.
+func DefaultDailyRequestLimitGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldDefaultDailyRequestLimit, v))
+}
+
+// DefaultDailyRequestLimitGTE applies the GTE predicate on the "default_daily_request_limit" field.
+func DefaultDailyRequestLimitGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldDefaultDailyRequestLimit, v))
+}
+
+// DefaultDailyRequestLimitLT applies the LT predicate on the "default_daily_request_limit" field.
+func DefaultDailyRequestLimitLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldDefaultDailyRequestLimit, v))
+}
+
+// DefaultDailyRequestLimitLTE applies the LTE predicate on the "default_daily_request_limit" field.
+func DefaultDailyRequestLimitLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldDefaultDailyRequestLimit, v))
+}
+
+// ResponseCacheEnabledEQ applies the EQ predicate on the "response_cache_enabled" field.
+func ResponseCacheEnabledEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldResponseCacheEnabled, v))
+}
+
+// ResponseCacheEnabledNEQ applies the NEQ predicate on the "response_cache_enabled" field.
+func ResponseCacheEnabledNEQ(v bool) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldResponseCacheEnabled, v))
+}
+
+// ResponseCacheTTLSecondsEQ applies the EQ predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldEQ(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheTTLSecondsNEQ applies the NEQ predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsNEQ(v int) predicate.Group {
+	return predicate.Group(sql.FieldNEQ(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheTTLSecondsIn applies the In predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldIn(FieldResponseCacheTTLSeconds, vs...))
+}
+
+// ResponseCacheTTLSecondsNotIn applies the NotIn predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsNotIn(vs ...int) predicate.Group {
+	return predicate.Group(sql.FieldNotIn(FieldResponseCacheTTLSeconds, vs...))
+}
+
+// ResponseCacheTTLSecondsGT applies the GT predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsGT(v int) predicate.Group {
+	return predicate.Group(sql.FieldGT(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheTTLSecondsGTE applies the GTE predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsGTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldGTE(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheTTLSecondsLT applies the LT predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsLT(v int) predicate.Group {
+	return predicate.Group(sql.FieldLT(FieldResponseCacheTTLSeconds, v))
+}
+
+// ResponseCacheTTLSecondsLTE applies the LTE predicate on the "response_cache_ttl_seconds" field.
+func ResponseCacheTTLSecondsLTE(v int) predicate.Group {
+	return predicate.Group(sql.FieldLTE(FieldResponseCacheTTLSeconds, v))
+}
+
+// OpenaiFallbackModelMappingIsNil applies the IsNil predicate on the "openai_fallback_model_mapping" field.
+func OpenaiFallbackModelMappingIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldOpenaiFallbackModelMapping))
+}
+
+// OpenaiFallbackModelMappingNotNil applies the NotNil predicate on the "openai_fallback_model_mapping" field.
+func OpenaiFallbackModelMappingNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldOpenaiFallbackModelMapping))
+}
+
+// RequestPolicyIsNil applies the IsNil predicate on the "request_policy" field.
+func RequestPolicyIsNil() predicate.Group {
+	return predicate.Group(sql.FieldIsNull(FieldRequestPolicy))
+}
+
+// RequestPolicyNotNil applies the NotNil predicate on the "request_policy" field.
+func RequestPolicyNotNil() predicate.Group {
+	return predicate.Group(sql.FieldNotNull(FieldRequestPolicy))
+}
+
 // HasAPIKeys applies the HasEdge predicate on the "api_keys" edge.
 func HasAPIKeys() predicate.Group {
 	return predicate.Group(func(s *sql.Selector) {
@@ -18,6 +18,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/usagelog"
 	"github.com/Wei-Shaw/sub2api/ent/user"
 	"github.com/Wei-Shaw/sub2api/ent/usersubscription"
+	"github.com/Wei-Shaw/sub2api/internal/domain"
 )
 
 // GroupCreate is the builder for creating a Group entity.
@@ -354,6 +355,88 @@ func (_c *GroupCreate) SetNillableSortOrder(v *int) *GroupCreate {
 	return _c
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (_c *GroupCreate) SetDefaultRpmLimit(v int) *GroupCreate {
+	_c.mutation.SetDefaultRpmLimit(v)
+	return _c
+}
+
+// SetNillableDefaultRpmLimit sets the "default_rpm_limit" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableDefaultRpmLimit(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetDefaultRpmLimit(*v)
+	}
+	return _c
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (_c *GroupCreate) SetDefaultTpmLimit(v int) *GroupCreate {
+	_c.mutation.SetDefaultTpmLimit(v)
+	return _c
+}
+
+// SetNillableDefaultTpmLimit sets the "default_tpm_limit" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableDefaultTpmLimit(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetDefaultTpmLimit(*v)
+	}
+	return _c
+}
+
+// SetDefaultDailyRequestLimit sets the "default_daily_request_limit" field.
+func (_c *GroupCreate) SetDefaultDailyRequestLimit(v int) *GroupCreate {
+	_c.mutation.SetDefaultDailyRequestLimit(v)
+	return _c
+}
+
+// SetNillableDefaultDailyRequestLimit sets the "default_daily_request_limit" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableDefaultDailyRequestLimit(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetDefaultDailyRequestLimit(*v)
+	}
+	return _c
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (_c *GroupCreate) SetResponseCacheEnabled(v bool) *GroupCreate {
+	_c.mutation.SetResponseCacheEnabled(v)
+	return _c
+}
+
+// SetNillableResponseCacheEnabled sets the "response_cache_enabled" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableResponseCacheEnabled(v *bool) *GroupCreate {
+	if v != nil {
+		_c.SetResponseCacheEnabled(*v)
+	}
+	return _c
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (_c *GroupCreate) SetResponseCacheTTLSeconds(v int) *GroupCreate {
+	_c.mutation.SetResponseCacheTTLSeconds(v)
+	return _c
+}
+
+// SetNillableResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field if the given value is not nil.
+func (_c *GroupCreate) SetNillableResponseCacheTTLSeconds(v *int) *GroupCreate {
+	if v != nil {
+		_c.SetResponseCacheTTLSeconds(*v)
+	}
+	return _c
+}
+
+// SetOpenaiFallbackModelMapping sets the "openai_fallback_model_mapping" field.
+func (_c *GroupCreate) SetOpenaiFallbackModelMapping(v map[string]string) *GroupCreate {
+	_c.mutation.SetOpenaiFallbackModelMapping(v)
+	return _c
+}
+
+// SetRequestPolicy sets the "request_policy" field.
+func (_c *GroupCreate) SetRequestPolicy(v *domain.RequestPolicy) *GroupCreate {
+	_c.mutation.SetRequestPolicy(v)
+	return _c
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_c *GroupCreate) AddAPIKeyIDs(ids ...int64) *GroupCreate {
 	_c.mutation.AddAPIKeyIDs(ids...)
@@ -539,6 +622,26 @@ func (_c *GroupCreate) defaults() error {
 		v := group.DefaultSortOrder
 		_c.mutation.SetSortOrder(v)
 	}
+	if _, ok := _c.mutation.DefaultRpmLimit(); !ok {
+		v := group.DefaultDefaultRpmLimit
+		_c.mutation.SetDefaultRpmLimit(v)
+	}
+	if _, ok := _c.mutation.DefaultTpmLimit(); !ok {
+		v := group.DefaultDefaultTpmLimit
+		_c.mutation.SetDefaultTpmLimit(v)
+	}
+	if _, ok := _c.mutation.DefaultDailyRequestLimit(); !ok {
+		v := group.DefaultDefaultDailyRequestLimit
+		_c.mutation.SetDefaultDailyRequestLimit(v)
+	}
+	if _, ok := _c.mutation.ResponseCacheEnabled(); !ok {
+		v := group.DefaultResponseCacheEnabled
+		_c.mutation.SetResponseCacheEnabled(v)
+	}
+	if _, ok := _c.mutation.ResponseCacheTTLSeconds(); !ok {
+		v := group.DefaultResponseCacheTTLSeconds
+		_c.mutation.SetResponseCacheTTLSeconds(v)
+	}
 	return nil
 }
 
@@ -606,6 +709,21 @@ func (_c *GroupCreate) check() error {
 	if _, ok := _c.mutation.SortOrder(); !ok {
 		return &ValidationError{Name: "sort_order", err: errors.New(`ent: missing required field "Group.sort_order"`)}
 	}
+	if _, ok := _c.mutation.DefaultRpmLimit(); !ok {
+		return &ValidationError{Name: "default_rpm_limit", err: errors.New(`ent: missing required field "Group.default_rpm_limit"`)}
+	}
+	if _, ok := _c.mutation.DefaultTpmLimit(); !ok {
+		return &ValidationError{Name: "default_tpm_limit", err: errors.New(`ent: missing required field "Group.default_tpm_limit"`)}
+	}
+	if _, ok := _c.mutation.DefaultDailyRequestLimit(); !ok {
+		return &ValidationError{Name: "default_daily_request_limit", err: errors.New(`ent: missing required field "Group.default_daily_request_limit"`)}
+	}
+	if _, ok := _c.mutation.ResponseCacheEnabled(); !ok {
+		return &ValidationError{Name: "response_cache_enabled", err: errors.New(`ent: missing required field "Group.response_cache_enabled"`)}
+	}
+	if _, ok := _c.mutation.ResponseCacheTTLSeconds(); !ok {
+		return &ValidationError{Name: "response_cache_ttl_seconds", err: errors.New(`ent: missing required field "Group.response_cache_ttl_seconds"`)}
+	}
 	return nil
 }
 
@@ -733,6 +851,34 @@ func (_c *GroupCreate) createSpec() (*Group, *sqlgraph.CreateSpec) {
 		_spec.SetField(group.FieldSortOrder, field.TypeInt, value)
 		_node.SortOrder = value
 	}
+	if value, ok := _c.mutation.DefaultRpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultRpmLimit, field.TypeInt, value)
+		_node.DefaultRpmLimit = value
+	}
+	if value, ok := _c.mutation.DefaultTpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultTpmLimit, field.TypeInt, value)
+		_node.DefaultTpmLimit = value
+	}
+	if value, ok := _c.mutation.DefaultDailyRequestLimit(); ok {
+		_spec.SetField(group.FieldDefaultDailyRequestLimit, field.TypeInt, value)
+		_node.DefaultDailyRequestLimit = value
+	}
+	if value, ok := _c.mutation.ResponseCacheEnabled(); ok {
+		_spec.SetField(group.FieldResponseCacheEnabled, field.TypeBool, value)
+		_node.ResponseCacheEnabled = value
+	}
+	if value, ok := _c.mutation.ResponseCacheTTLSeconds(); ok {
+		_spec.SetField(group.FieldResponseCacheTTLSeconds, field.TypeInt, value)
+		_node.ResponseCacheTTLSeconds = value
+	}
+	if value, ok := _c.mutation.OpenaiFallbackModelMapping(); ok {
+		_spec.SetField(group.FieldOpenaiFallbackModelMapping, field.TypeJSON, value)
+		_node.OpenaiFallbackModelMapping = value
+	}
+	if value, ok := _c.mutation.RequestPolicy(); ok {
+		_spec.SetField(group.FieldRequestPolicy, field.TypeJSON, value)
+		_node.RequestPolicy = value
+	}
 	if nodes := _c.mutation.APIKeysIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1309,6 +1455,126 @@ func (u *GroupUpsert) AddSortOrder(v int) *GroupUpsert {
 	return u
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (u *GroupUpsert) SetDefaultRpmLimit(v int) *GroupUpsert {
+	u.Set(group.FieldDefaultRpmLimit, v)
+	return u
+}
+
+// UpdateDefaultRpmLimit sets the "default_rpm_limit" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateDefaultRpmLimit() *GroupUpsert {
+	u.SetExcluded(group.FieldDefaultRpmLimit)
+	return u
+}
+
+// AddDefaultRpmLimit adds v to the "default_rpm_limit" field.
+func (u *GroupUpsert) AddDefaultRpmLimit(v int) *GroupUpsert {
+	u.Add(group.FieldDefaultRpmLimit, v)
+	return u
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (u *GroupUpsert) SetDefaultTpmLimit(v int) *GroupUpsert {
+	u.Set(group.FieldDefaultTpmLimit, v)
+	return u
+}
+
+// UpdateDefaultTpmLimit sets the "default_tpm_limit" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateDefaultTpmLimit() *GroupUpsert {
+	u.SetExcluded(group.FieldDefaultTpmLimit)
+	return u
+}
+
+// AddDefaultTpmLimit adds v to the "default_tpm_limit" field.
+func (u *GroupUpsert) AddDefaultTpmLimit(v int) *GroupUpsert {
+	u.Add(group.FieldDefaultTpmLimit, v)
+	return u
+}
+
+// SetDefaultDailyRequestLimit sets the "default_daily_request_limit" field.
+func (u *GroupUpsert) SetDefaultDailyRequestLimit(v int) *GroupUpsert {
+	u.Set(group.FieldDefaultDailyRequestLimit, v)
+	return u
+}
+
+// UpdateDefaultDailyRequestLimit sets the "default_daily_request_limit" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateDefaultDailyRequestLimit() *GroupUpsert {
+	u.SetExcluded(group.FieldDefaultDailyRequestLimit)
+	return u
+}
+
+// AddDefaultDailyRequestLimit adds v to the "default_daily_request_limit" field.
+func (u *GroupUpsert) AddDefaultDailyRequestLimit(v int) *GroupUpsert {
+	u.Add(group.FieldDefaultDailyRequestLimit, v)
+	return u
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (u *GroupUpsert) SetResponseCacheEnabled(v bool) *GroupUpsert {
+	u.Set(group.FieldResponseCacheEnabled, v)
+	return u
+}
+
+// UpdateResponseCacheEnabled sets the "response_cache_enabled" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateResponseCacheEnabled() *GroupUpsert {
+	u.SetExcluded(group.FieldResponseCacheEnabled)
+	return u
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (u *GroupUpsert) SetResponseCacheTTLSeconds(v int) *GroupUpsert {
+	u.Set(group.FieldResponseCacheTTLSeconds, v)
+	return u
+}
+
+// UpdateResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateResponseCacheTTLSeconds() *GroupUpsert {
+	u.SetExcluded(group.FieldResponseCacheTTLSeconds)
+	return u
+}
+
+// AddResponseCacheTTLSeconds adds v to the "response_cache_ttl_seconds" field.
+func (u *GroupUpsert) AddResponseCacheTTLSeconds(v int) *GroupUpsert {
+	u.Add(group.FieldResponseCacheTTLSeconds, v)
+	return u
+}
+
+// SetOpenaiFallbackModelMapping sets the "openai_fallback_model_mapping" field.
+func (u *GroupUpsert) SetOpenaiFallbackModelMapping(v map[string]string) *GroupUpsert {
+	u.Set(group.FieldOpenaiFallbackModelMapping, v)
+	return u
+}
+
+// UpdateOpenaiFallbackModelMapping sets the "openai_fallback_model_mapping" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateOpenaiFallbackModelMapping() *GroupUpsert {
+	u.SetExcluded(group.FieldOpenaiFallbackModelMapping)
+	return u
+}
+
+// ClearOpenaiFallbackModelMapping clears the value of the "openai_fallback_model_mapping" field.
+func (u *GroupUpsert) ClearOpenaiFallbackModelMapping() *GroupUpsert {
+	u.SetNull(group.FieldOpenaiFallbackModelMapping)
+	return u
+}
+
+// SetRequestPolicy sets the "request_policy" field.
+func (u *GroupUpsert) SetRequestPolicy(v *domain.RequestPolicy) *GroupUpsert {
+	u.Set(group.FieldRequestPolicy, v)
+	return u
+}
+
+// UpdateRequestPolicy sets the "request_policy" field to the value that was provided on create.
+func (u *GroupUpsert) UpdateRequestPolicy() *GroupUpsert {
+	u.SetExcluded(group.FieldRequestPolicy)
+	return u
+}
+
+// ClearRequestPolicy clears the value of the "request_policy" field.
+func (u *GroupUpsert) ClearRequestPolicy() *GroupUpsert {
+	u.SetNull(group.FieldRequestPolicy)
+	return u
+}
+
 // UpdateNewValues updates the mutable fields using the new values that were set on create.
 // Using this option is equivalent to using:
 //
@@ -1844,6 +2110,146 @@ func (u *GroupUpsertOne) UpdateSortOrder() *GroupUpsertOne {
 	})
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (u *GroupUpsertOne) SetDefaultRpmLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDefaultRpmLimit(v)
+	})
+}
+
+// AddDefaultRpmLimit adds v to the "default_rpm_limit" field.
+func (u *GroupUpsertOne) AddDefaultRpmLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDefaultRpmLimit(v)
+	})
+}
+
+// UpdateDefaultRpmLimit sets the "default_rpm_limit" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateDefaultRpmLimit() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDefaultRpmLimit()
+	})
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (u *GroupUpsertOne) SetDefaultTpmLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDefaultTpmLimit(v)
+	})
+}
+
+// AddDefaultTpmLimit adds v to the "default_tpm_limit" field.
+func (u *GroupUpsertOne) AddDefaultTpmLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDefaultTpmLimit(v)
+	})
+}
+
+// UpdateDefaultTpmLimit sets the "default_tpm_limit" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateDefaultTpmLimit() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDefaultTpmLimit()
+	})
+}
+
+// SetDefaultDailyRequestLimit sets the "default_daily_request_limit" field.
+func (u *GroupUpsertOne) SetDefaultDailyRequestLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDefaultDailyRequestLimit(v)
+	})
+}
+
+// AddDefaultDailyRequestLimit adds v to the "default_daily_request_limit" field.
+func (u *GroupUpsertOne) AddDefaultDailyRequestLimit(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDefaultDailyRequestLimit(v)
+	})
+}
+
+// UpdateDefaultDailyRequestLimit sets the "default_daily_request_limit" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateDefaultDailyRequestLimit() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDefaultDailyRequestLimit()
+	})
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (u *GroupUpsertOne) SetResponseCacheEnabled(v bool) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetResponseCacheEnabled(v)
+	})
+}
+
+// UpdateResponseCacheEnabled sets the "response_cache_enabled" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateResponseCacheEnabled() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateResponseCacheEnabled()
+	})
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (u *GroupUpsertOne) SetResponseCacheTTLSeconds(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetResponseCacheTTLSeconds(v)
+	})
+}
+
+// AddResponseCacheTTLSeconds adds v to the "response_cache_ttl_seconds" field.
+func (u *GroupUpsertOne) AddResponseCacheTTLSeconds(v int) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddResponseCacheTTLSeconds(v)
+	})
+}
+
+// UpdateResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateResponseCacheTTLSeconds() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateResponseCacheTTLSeconds()
+	})
+}
+
+// SetOpenaiFallbackModelMapping sets the "openai_fallback_model_mapping" field.
+func (u *GroupUpsertOne) SetOpenaiFallbackModelMapping(v map[string]string) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetOpenaiFallbackModelMapping(v)
+	})
+}
+
+// UpdateOpenaiFallbackModelMapping sets the "openai_fallback_model_mapping" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateOpenaiFallbackModelMapping() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateOpenaiFallbackModelMapping()
+	})
+}
+
+// ClearOpenaiFallbackModelMapping clears the value of the "openai_fallback_model_mapping" field.
+func (u *GroupUpsertOne) ClearOpenaiFallbackModelMapping() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearOpenaiFallbackModelMapping()
+	})
+}
+
+// SetRequestPolicy sets the "request_policy" field.
+func (u *GroupUpsertOne) SetRequestPolicy(v *domain.RequestPolicy) *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetRequestPolicy(v)
+	})
+}
+
+// UpdateRequestPolicy sets the "request_policy" field to the value that was provided on create.
+func (u *GroupUpsertOne) UpdateRequestPolicy() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateRequestPolicy()
+	})
+}
+
+// ClearRequestPolicy clears the value of the "request_policy" field.
+func (u *GroupUpsertOne) ClearRequestPolicy() *GroupUpsertOne {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearRequestPolicy()
+	})
+}
+
 // Exec executes the query.
 func (u *GroupUpsertOne) Exec(ctx context.Context) error {
 	if len(u.create.conflict) == 0 {
@@ -2545,6 +2951,146 @@ func (u *GroupUpsertBulk) UpdateSortOrder() *GroupUpsertBulk {
 	})
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (u *GroupUpsertBulk) SetDefaultRpmLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDefaultRpmLimit(v)
+	})
+}
+
+// AddDefaultRpmLimit adds v to the "default_rpm_limit" field.
+func (u *GroupUpsertBulk) AddDefaultRpmLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDefaultRpmLimit(v)
+	})
+}
+
+// UpdateDefaultRpmLimit sets the "default_rpm_limit" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateDefaultRpmLimit() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDefaultRpmLimit()
+	})
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (u *GroupUpsertBulk) SetDefaultTpmLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDefaultTpmLimit(v)
+	})
+}
+
+// AddDefaultTpmLimit adds v to the "default_tpm_limit" field.
+func (u *GroupUpsertBulk) AddDefaultTpmLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDefaultTpmLimit(v)
+	})
+}
+
+// UpdateDefaultTpmLimit sets the "default_tpm_limit" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateDefaultTpmLimit() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDefaultTpmLimit()
+	})
+}
+
+// SetDefaultDailyRequestLimit sets the "default_daily_request_limit" field.
+func (u *GroupUpsertBulk) SetDefaultDailyRequestLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetDefaultDailyRequestLimit(v)
+	})
+}
+
+// AddDefaultDailyRequestLimit adds v to the "default_daily_request_limit" field.
+func (u *GroupUpsertBulk) AddDefaultDailyRequestLimit(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddDefaultDailyRequestLimit(v)
+	})
+}
+
+// UpdateDefaultDailyRequestLimit sets the "default_daily_request_limit" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateDefaultDailyRequestLimit() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateDefaultDailyRequestLimit()
+	})
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (u *GroupUpsertBulk) SetResponseCacheEnabled(v bool) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetResponseCacheEnabled(v)
+	})
+}
+
+// UpdateResponseCacheEnabled sets the "response_cache_enabled" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateResponseCacheEnabled() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateResponseCacheEnabled()
+	})
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (u *GroupUpsertBulk) SetResponseCacheTTLSeconds(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetResponseCacheTTLSeconds(v)
+	})
+}
+
+// AddResponseCacheTTLSeconds adds v to the "response_cache_ttl_seconds" field.
+func (u *GroupUpsertBulk) AddResponseCacheTTLSeconds(v int) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.AddResponseCacheTTLSeconds(v)
+	})
+}
+
+// UpdateResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateResponseCacheTTLSeconds() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateResponseCacheTTLSeconds()
+	})
+}
+
+// SetOpenaiFallbackModelMapping sets the "openai_fallback_model_mapping" field.
+func (u *GroupUpsertBulk) SetOpenaiFallbackModelMapping(v map[string]string) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetOpenaiFallbackModelMapping(v)
+	})
+}
+
+// UpdateOpenaiFallbackModelMapping sets the "openai_fallback_model_mapping" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateOpenaiFallbackModelMapping() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateOpenaiFallbackModelMapping()
+	})
+}
+
+// ClearOpenaiFallbackModelMapping clears the value of the "openai_fallback_model_mapping" field.
+func (u *GroupUpsertBulk) ClearOpenaiFallbackModelMapping() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearOpenaiFallbackModelMapping()
+	})
+}
+
+// SetRequestPolicy sets the "request_policy" field.
+func (u *GroupUpsertBulk) SetRequestPolicy(v *domain.RequestPolicy) *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.SetRequestPolicy(v)
+	})
+}
+
+// UpdateRequestPolicy sets the "request_policy" field to the value that was provided on create.
+func (u *GroupUpsertBulk) UpdateRequestPolicy() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.UpdateRequestPolicy()
+	})
+}
+
+// ClearRequestPolicy clears the value of the "request_policy" field.
+func (u *GroupUpsertBulk) ClearRequestPolicy() *GroupUpsertBulk {
+	return u.Update(func(s *GroupUpsert) {
+		s.ClearRequestPolicy()
+	})
+}
+
 // Exec executes the query.
 func (u *GroupUpsertBulk) Exec(ctx context.Context) error {
 	if u.create.err != nil {
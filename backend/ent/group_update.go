@@ -20,6 +20,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/usagelog"
 	"github.com/Wei-Shaw/sub2api/ent/user"
 	"github.com/Wei-Shaw/sub2api/ent/usersubscription"
+	"github.com/Wei-Shaw/sub2api/internal/domain"
 )
 
 // GroupUpdate is the builder for updating Group entities.
@@ -496,6 +497,128 @@ func (_u *GroupUpdate) AddSortOrder(v int) *GroupUpdate {
 	return _u
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (_u *GroupUpdate) SetDefaultRpmLimit(v int) *GroupUpdate {
+	_u.mutation.ResetDefaultRpmLimit()
+	_u.mutation.SetDefaultRpmLimit(v)
+	return _u
+}
+
+// SetNillableDefaultRpmLimit sets the "default_rpm_limit" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableDefaultRpmLimit(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetDefaultRpmLimit(*v)
+	}
+	return _u
+}
+
+// AddDefaultRpmLimit adds value to the "default_rpm_limit" field.
+func (_u *GroupUpdate) AddDefaultRpmLimit(v int) *GroupUpdate {
+	_u.mutation.AddDefaultRpmLimit(v)
+	return _u
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (_u *GroupUpdate) SetDefaultTpmLimit(v int) *GroupUpdate {
+	_u.mutation.ResetDefaultTpmLimit()
+	_u.mutation.SetDefaultTpmLimit(v)
+	return _u
+}
+
+// SetNillableDefaultTpmLimit sets the "default_tpm_limit" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableDefaultTpmLimit(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetDefaultTpmLimit(*v)
+	}
+	return _u
+}
+
+// AddDefaultTpmLimit adds value to the "default_tpm_limit" field.
+func (_u *GroupUpdate) AddDefaultTpmLimit(v int) *GroupUpdate {
+	_u.mutation.AddDefaultTpmLimit(v)
+	return _u
+}
+
+// SetDefaultDailyRequestLimit sets the "default_daily_request_limit" field.
+func (_u *GroupUpdate) SetDefaultDailyRequestLimit(v int) *GroupUpdate {
+	_u.mutation.ResetDefaultDailyRequestLimit()
+	_u.mutation.SetDefaultDailyRequestLimit(v)
+	return _u
+}
+
+// SetNillableDefaultDailyRequestLimit sets the "default_daily_request_limit" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableDefaultDailyRequestLimit(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetDefaultDailyRequestLimit(*v)
+	}
+	return _u
+}
+
+// AddDefaultDailyRequestLimit adds value to the "default_daily_request_limit" field.
+func (_u *GroupUpdate) AddDefaultDailyRequestLimit(v int) *GroupUpdate {
+	_u.mutation.AddDefaultDailyRequestLimit(v)
+	return _u
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (_u *GroupUpdate) SetResponseCacheEnabled(v bool) *GroupUpdate {
+	_u.mutation.SetResponseCacheEnabled(v)
+	return _u
+}
+
+// SetNillableResponseCacheEnabled sets the "response_cache_enabled" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableResponseCacheEnabled(v *bool) *GroupUpdate {
+	if v != nil {
+		_u.SetResponseCacheEnabled(*v)
+	}
+	return _u
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (_u *GroupUpdate) SetResponseCacheTTLSeconds(v int) *GroupUpdate {
+	_u.mutation.ResetResponseCacheTTLSeconds()
+	_u.mutation.SetResponseCacheTTLSeconds(v)
+	return _u
+}
+
+// SetNillableResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field if the given value is not nil.
+func (_u *GroupUpdate) SetNillableResponseCacheTTLSeconds(v *int) *GroupUpdate {
+	if v != nil {
+		_u.SetResponseCacheTTLSeconds(*v)
+	}
+	return _u
+}
+
+// AddResponseCacheTTLSeconds adds value to the "response_cache_ttl_seconds" field.
+func (_u *GroupUpdate) AddResponseCacheTTLSeconds(v int) *GroupUpdate {
+	_u.mutation.AddResponseCacheTTLSeconds(v)
+	return _u
+}
+
+// SetOpenaiFallbackModelMapping sets the "openai_fallback_model_mapping" field.
+func (_u *GroupUpdate) SetOpenaiFallbackModelMapping(v map[string]string) *GroupUpdate {
+	_u.mutation.SetOpenaiFallbackModelMapping(v)
+	return _u
+}
+
+// ClearOpenaiFallbackModelMapping clears the value of the "openai_fallback_model_mapping" field.
+func (_u *GroupUpdate) ClearOpenaiFallbackModelMapping() *GroupUpdate {
+	_u.mutation.ClearOpenaiFallbackModelMapping()
+	return _u
+}
+
+// SetRequestPolicy sets the "request_policy" field.
+func (_u *GroupUpdate) SetRequestPolicy(v *domain.RequestPolicy) *GroupUpdate {
+	_u.mutation.SetRequestPolicy(v)
+	return _u
+}
+
+// ClearRequestPolicy clears the value of the "request_policy" field.
+func (_u *GroupUpdate) ClearRequestPolicy() *GroupUpdate {
+	_u.mutation.ClearRequestPolicy()
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *GroupUpdate) AddAPIKeyIDs(ids ...int64) *GroupUpdate {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -939,6 +1062,45 @@ func (_u *GroupUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if value, ok := _u.mutation.AddedSortOrder(); ok {
 		_spec.AddField(group.FieldSortOrder, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.DefaultRpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDefaultRpmLimit(); ok {
+		_spec.AddField(group.FieldDefaultRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.DefaultTpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDefaultTpmLimit(); ok {
+		_spec.AddField(group.FieldDefaultTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.DefaultDailyRequestLimit(); ok {
+		_spec.SetField(group.FieldDefaultDailyRequestLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDefaultDailyRequestLimit(); ok {
+		_spec.AddField(group.FieldDefaultDailyRequestLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.ResponseCacheEnabled(); ok {
+		_spec.SetField(group.FieldResponseCacheEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.ResponseCacheTTLSeconds(); ok {
+		_spec.SetField(group.FieldResponseCacheTTLSeconds, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedResponseCacheTTLSeconds(); ok {
+		_spec.AddField(group.FieldResponseCacheTTLSeconds, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.OpenaiFallbackModelMapping(); ok {
+		_spec.SetField(group.FieldOpenaiFallbackModelMapping, field.TypeJSON, value)
+	}
+	if _u.mutation.OpenaiFallbackModelMappingCleared() {
+		_spec.ClearField(group.FieldOpenaiFallbackModelMapping, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.RequestPolicy(); ok {
+		_spec.SetField(group.FieldRequestPolicy, field.TypeJSON, value)
+	}
+	if _u.mutation.RequestPolicyCleared() {
+		_spec.ClearField(group.FieldRequestPolicy, field.TypeJSON)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1714,6 +1876,128 @@ func (_u *GroupUpdateOne) AddSortOrder(v int) *GroupUpdateOne {
 	return _u
 }
 
+// SetDefaultRpmLimit sets the "default_rpm_limit" field.
+func (_u *GroupUpdateOne) SetDefaultRpmLimit(v int) *GroupUpdateOne {
+	_u.mutation.ResetDefaultRpmLimit()
+	_u.mutation.SetDefaultRpmLimit(v)
+	return _u
+}
+
+// SetNillableDefaultRpmLimit sets the "default_rpm_limit" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableDefaultRpmLimit(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetDefaultRpmLimit(*v)
+	}
+	return _u
+}
+
+// AddDefaultRpmLimit adds value to the "default_rpm_limit" field.
+func (_u *GroupUpdateOne) AddDefaultRpmLimit(v int) *GroupUpdateOne {
+	_u.mutation.AddDefaultRpmLimit(v)
+	return _u
+}
+
+// SetDefaultTpmLimit sets the "default_tpm_limit" field.
+func (_u *GroupUpdateOne) SetDefaultTpmLimit(v int) *GroupUpdateOne {
+	_u.mutation.ResetDefaultTpmLimit()
+	_u.mutation.SetDefaultTpmLimit(v)
+	return _u
+}
+
+// SetNillableDefaultTpmLimit sets the "default_tpm_limit" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableDefaultTpmLimit(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetDefaultTpmLimit(*v)
+	}
+	return _u
+}
+
+// AddDefaultTpmLimit adds value to the "default_tpm_limit" field.
+func (_u *GroupUpdateOne) AddDefaultTpmLimit(v int) *GroupUpdateOne {
+	_u.mutation.AddDefaultTpmLimit(v)
+	return _u
+}
+
+// SetDefaultDailyRequestLimit sets the "default_daily_request_limit" field.
+func (_u *GroupUpdateOne) SetDefaultDailyRequestLimit(v int) *GroupUpdateOne {
+	_u.mutation.ResetDefaultDailyRequestLimit()
+	_u.mutation.SetDefaultDailyRequestLimit(v)
+	return _u
+}
+
+// SetNillableDefaultDailyRequestLimit sets the "default_daily_request_limit" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableDefaultDailyRequestLimit(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetDefaultDailyRequestLimit(*v)
+	}
+	return _u
+}
+
+// AddDefaultDailyRequestLimit adds value to the "default_daily_request_limit" field.
+func (_u *GroupUpdateOne) AddDefaultDailyRequestLimit(v int) *GroupUpdateOne {
+	_u.mutation.AddDefaultDailyRequestLimit(v)
+	return _u
+}
+
+// SetResponseCacheEnabled sets the "response_cache_enabled" field.
+func (_u *GroupUpdateOne) SetResponseCacheEnabled(v bool) *GroupUpdateOne {
+	_u.mutation.SetResponseCacheEnabled(v)
+	return _u
+}
+
+// SetNillableResponseCacheEnabled sets the "response_cache_enabled" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableResponseCacheEnabled(v *bool) *GroupUpdateOne {
+	if v != nil {
+		_u.SetResponseCacheEnabled(*v)
+	}
+	return _u
+}
+
+// SetResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field.
+func (_u *GroupUpdateOne) SetResponseCacheTTLSeconds(v int) *GroupUpdateOne {
+	_u.mutation.ResetResponseCacheTTLSeconds()
+	_u.mutation.SetResponseCacheTTLSeconds(v)
+	return _u
+}
+
+// SetNillableResponseCacheTTLSeconds sets the "response_cache_ttl_seconds" field if the given value is not nil.
+func (_u *GroupUpdateOne) SetNillableResponseCacheTTLSeconds(v *int) *GroupUpdateOne {
+	if v != nil {
+		_u.SetResponseCacheTTLSeconds(*v)
+	}
+	return _u
+}
+
+// AddResponseCacheTTLSeconds adds value to the "response_cache_ttl_seconds" field.
+func (_u *GroupUpdateOne) AddResponseCacheTTLSeconds(v int) *GroupUpdateOne {
+	_u.mutation.AddResponseCacheTTLSeconds(v)
+	return _u
+}
+
+// SetOpenaiFallbackModelMapping sets the "openai_fallback_model_mapping" field.
+func (_u *GroupUpdateOne) SetOpenaiFallbackModelMapping(v map[string]string) *GroupUpdateOne {
+	_u.mutation.SetOpenaiFallbackModelMapping(v)
+	return _u
+}
+
+// ClearOpenaiFallbackModelMapping clears the value of the "openai_fallback_model_mapping" field.
+func (_u *GroupUpdateOne) ClearOpenaiFallbackModelMapping() *GroupUpdateOne {
+	_u.mutation.ClearOpenaiFallbackModelMapping()
+	return _u
+}
+
+// SetRequestPolicy sets the "request_policy" field.
+func (_u *GroupUpdateOne) SetRequestPolicy(v *domain.RequestPolicy) *GroupUpdateOne {
+	_u.mutation.SetRequestPolicy(v)
+	return _u
+}
+
+// ClearRequestPolicy clears the value of the "request_policy" field.
+func (_u *GroupUpdateOne) ClearRequestPolicy() *GroupUpdateOne {
+	_u.mutation.ClearRequestPolicy()
+	return _u
+}
+
 // AddAPIKeyIDs adds the "api_keys" edge to the APIKey entity by IDs.
 func (_u *GroupUpdateOne) AddAPIKeyIDs(ids ...int64) *GroupUpdateOne {
 	_u.mutation.AddAPIKeyIDs(ids...)
@@ -2187,6 +2471,45 @@ func (_u *GroupUpdateOne) sqlSave(ctx context.Context) (_node *Group, err error)
 	if value, ok := _u.mutation.AddedSortOrder(); ok {
 		_spec.AddField(group.FieldSortOrder, field.TypeInt, value)
 	}
+	if value, ok := _u.mutation.DefaultRpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDefaultRpmLimit(); ok {
+		_spec.AddField(group.FieldDefaultRpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.DefaultTpmLimit(); ok {
+		_spec.SetField(group.FieldDefaultTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDefaultTpmLimit(); ok {
+		_spec.AddField(group.FieldDefaultTpmLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.DefaultDailyRequestLimit(); ok {
+		_spec.SetField(group.FieldDefaultDailyRequestLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedDefaultDailyRequestLimit(); ok {
+		_spec.AddField(group.FieldDefaultDailyRequestLimit, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.ResponseCacheEnabled(); ok {
+		_spec.SetField(group.FieldResponseCacheEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.ResponseCacheTTLSeconds(); ok {
+		_spec.SetField(group.FieldResponseCacheTTLSeconds, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedResponseCacheTTLSeconds(); ok {
+		_spec.AddField(group.FieldResponseCacheTTLSeconds, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.OpenaiFallbackModelMapping(); ok {
+		_spec.SetField(group.FieldOpenaiFallbackModelMapping, field.TypeJSON, value)
+	}
+	if _u.mutation.OpenaiFallbackModelMappingCleared() {
+		_spec.ClearField(group.FieldOpenaiFallbackModelMapping, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.RequestPolicy(); ok {
+		_spec.SetField(group.FieldRequestPolicy, field.TypeJSON, value)
+	}
+	if _u.mutation.RequestPolicyCleared() {
+		_spec.ClearField(group.FieldRequestPolicy, field.TypeJSON)
+	}
 	if _u.mutation.APIKeysCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
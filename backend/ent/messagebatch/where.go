@@ -0,0 +1,645 @@
+// Code generated by ent, DO NOT EDIT.
+
+package messagebatch
+
+import (
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/Wei-Shaw/sub2api/ent/predicate"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldID, id))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UpdatedAt applies equality check predicate on the "updated_at" field. It's identical to UpdatedAtEQ.
+func UpdatedAt(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// BatchID applies equality check predicate on the "batch_id" field. It's identical to BatchIDEQ.
+func BatchID(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldBatchID, v))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldUserID, v))
+}
+
+// APIKeyID applies equality check predicate on the "api_key_id" field. It's identical to APIKeyIDEQ.
+func APIKeyID(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldAPIKeyID, v))
+}
+
+// GroupID applies equality check predicate on the "group_id" field. It's identical to GroupIDEQ.
+func GroupID(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldGroupID, v))
+}
+
+// ProcessingStatus applies equality check predicate on the "processing_status" field. It's identical to ProcessingStatusEQ.
+func ProcessingStatus(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldProcessingStatus, v))
+}
+
+// RequestCount applies equality check predicate on the "request_count" field. It's identical to RequestCountEQ.
+func RequestCount(v int) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldRequestCount, v))
+}
+
+// ExpiresAt applies equality check predicate on the "expires_at" field. It's identical to ExpiresAtEQ.
+func ExpiresAt(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// CancelInitiatedAt applies equality check predicate on the "cancel_initiated_at" field. It's identical to CancelInitiatedAtEQ.
+func CancelInitiatedAt(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldCancelInitiatedAt, v))
+}
+
+// EndedAt applies equality check predicate on the "ended_at" field. It's identical to EndedAtEQ.
+func EndedAt(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldEndedAt, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// UpdatedAtEQ applies the EQ predicate on the "updated_at" field.
+func UpdatedAtEQ(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtNEQ applies the NEQ predicate on the "updated_at" field.
+func UpdatedAtNEQ(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldUpdatedAt, v))
+}
+
+// UpdatedAtIn applies the In predicate on the "updated_at" field.
+func UpdatedAtIn(vs ...time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtNotIn applies the NotIn predicate on the "updated_at" field.
+func UpdatedAtNotIn(vs ...time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldUpdatedAt, vs...))
+}
+
+// UpdatedAtGT applies the GT predicate on the "updated_at" field.
+func UpdatedAtGT(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtGTE applies the GTE predicate on the "updated_at" field.
+func UpdatedAtGTE(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLT applies the LT predicate on the "updated_at" field.
+func UpdatedAtLT(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldUpdatedAt, v))
+}
+
+// UpdatedAtLTE applies the LTE predicate on the "updated_at" field.
+func UpdatedAtLTE(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldUpdatedAt, v))
+}
+
+// BatchIDEQ applies the EQ predicate on the "batch_id" field.
+func BatchIDEQ(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldBatchID, v))
+}
+
+// BatchIDNEQ applies the NEQ predicate on the "batch_id" field.
+func BatchIDNEQ(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldBatchID, v))
+}
+
+// BatchIDIn applies the In predicate on the "batch_id" field.
+func BatchIDIn(vs ...string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldBatchID, vs...))
+}
+
+// BatchIDNotIn applies the NotIn predicate on the "batch_id" field.
+func BatchIDNotIn(vs ...string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldBatchID, vs...))
+}
+
+// BatchIDGT applies the GT predicate on the "batch_id" field.
+func BatchIDGT(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldBatchID, v))
+}
+
+// BatchIDGTE applies the GTE predicate on the "batch_id" field.
+func BatchIDGTE(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldBatchID, v))
+}
+
+// BatchIDLT applies the LT predicate on the "batch_id" field.
+func BatchIDLT(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldBatchID, v))
+}
+
+// BatchIDLTE applies the LTE predicate on the "batch_id" field.
+func BatchIDLTE(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldBatchID, v))
+}
+
+// BatchIDContains applies the Contains predicate on the "batch_id" field.
+func BatchIDContains(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldContains(FieldBatchID, v))
+}
+
+// BatchIDHasPrefix applies the HasPrefix predicate on the "batch_id" field.
+func BatchIDHasPrefix(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldHasPrefix(FieldBatchID, v))
+}
+
+// BatchIDHasSuffix applies the HasSuffix predicate on the "batch_id" field.
+func BatchIDHasSuffix(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldHasSuffix(FieldBatchID, v))
+}
+
+// BatchIDEqualFold applies the EqualFold predicate on the "batch_id" field.
+func BatchIDEqualFold(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEqualFold(FieldBatchID, v))
+}
+
+// BatchIDContainsFold applies the ContainsFold predicate on the "batch_id" field.
+func BatchIDContainsFold(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldContainsFold(FieldBatchID, v))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldUserID, v))
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldUserID, v))
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldUserID, v))
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldUserID, v))
+}
+
+// APIKeyIDEQ applies the EQ predicate on the "api_key_id" field.
+func APIKeyIDEQ(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldAPIKeyID, v))
+}
+
+// APIKeyIDNEQ applies the NEQ predicate on the "api_key_id" field.
+func APIKeyIDNEQ(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldAPIKeyID, v))
+}
+
+// APIKeyIDIn applies the In predicate on the "api_key_id" field.
+func APIKeyIDIn(vs ...int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldAPIKeyID, vs...))
+}
+
+// APIKeyIDNotIn applies the NotIn predicate on the "api_key_id" field.
+func APIKeyIDNotIn(vs ...int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldAPIKeyID, vs...))
+}
+
+// APIKeyIDGT applies the GT predicate on the "api_key_id" field.
+func APIKeyIDGT(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldAPIKeyID, v))
+}
+
+// APIKeyIDGTE applies the GTE predicate on the "api_key_id" field.
+func APIKeyIDGTE(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldAPIKeyID, v))
+}
+
+// APIKeyIDLT applies the LT predicate on the "api_key_id" field.
+func APIKeyIDLT(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldAPIKeyID, v))
+}
+
+// APIKeyIDLTE applies the LTE predicate on the "api_key_id" field.
+func APIKeyIDLTE(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldAPIKeyID, v))
+}
+
+// GroupIDEQ applies the EQ predicate on the "group_id" field.
+func GroupIDEQ(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldGroupID, v))
+}
+
+// GroupIDNEQ applies the NEQ predicate on the "group_id" field.
+func GroupIDNEQ(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldGroupID, v))
+}
+
+// GroupIDIn applies the In predicate on the "group_id" field.
+func GroupIDIn(vs ...int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldGroupID, vs...))
+}
+
+// GroupIDNotIn applies the NotIn predicate on the "group_id" field.
+func GroupIDNotIn(vs ...int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldGroupID, vs...))
+}
+
+// GroupIDGT applies the GT predicate on the "group_id" field.
+func GroupIDGT(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldGroupID, v))
+}
+
+// GroupIDGTE applies the GTE predicate on the "group_id" field.
+func GroupIDGTE(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldGroupID, v))
+}
+
+// GroupIDLT applies the LT predicate on the "group_id" field.
+func GroupIDLT(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldGroupID, v))
+}
+
+// GroupIDLTE applies the LTE predicate on the "group_id" field.
+func GroupIDLTE(v int64) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldGroupID, v))
+}
+
+// GroupIDIsNil applies the IsNil predicate on the "group_id" field.
+func GroupIDIsNil() predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIsNull(FieldGroupID))
+}
+
+// GroupIDNotNil applies the NotNil predicate on the "group_id" field.
+func GroupIDNotNil() predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotNull(FieldGroupID))
+}
+
+// ProcessingStatusEQ applies the EQ predicate on the "processing_status" field.
+func ProcessingStatusEQ(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldProcessingStatus, v))
+}
+
+// ProcessingStatusNEQ applies the NEQ predicate on the "processing_status" field.
+func ProcessingStatusNEQ(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldProcessingStatus, v))
+}
+
+// ProcessingStatusIn applies the In predicate on the "processing_status" field.
+func ProcessingStatusIn(vs ...string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldProcessingStatus, vs...))
+}
+
+// ProcessingStatusNotIn applies the NotIn predicate on the "processing_status" field.
+func ProcessingStatusNotIn(vs ...string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldProcessingStatus, vs...))
+}
+
+// ProcessingStatusGT applies the GT predicate on the "processing_status" field.
+func ProcessingStatusGT(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldProcessingStatus, v))
+}
+
+// ProcessingStatusGTE applies the GTE predicate on the "processing_status" field.
+func ProcessingStatusGTE(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldProcessingStatus, v))
+}
+
+// ProcessingStatusLT applies the LT predicate on the "processing_status" field.
+func ProcessingStatusLT(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldProcessingStatus, v))
+}
+
+// ProcessingStatusLTE applies the LTE predicate on the "processing_status" field.
+func ProcessingStatusLTE(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldProcessingStatus, v))
+}
+
+// ProcessingStatusContains applies the Contains predicate on the "processing_status" field.
+func ProcessingStatusContains(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldContains(FieldProcessingStatus, v))
+}
+
+// ProcessingStatusHasPrefix applies the HasPrefix predicate on the "processing_status" field.
+func ProcessingStatusHasPrefix(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldHasPrefix(FieldProcessingStatus, v))
+}
+
+// ProcessingStatusHasSuffix applies the HasSuffix predicate on the "processing_status" field.
+func ProcessingStatusHasSuffix(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldHasSuffix(FieldProcessingStatus, v))
+}
+
+// ProcessingStatusEqualFold applies the EqualFold predicate on the "processing_status" field.
+func ProcessingStatusEqualFold(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEqualFold(FieldProcessingStatus, v))
+}
+
+// ProcessingStatusContainsFold applies the ContainsFold predicate on the "processing_status" field.
+func ProcessingStatusContainsFold(v string) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldContainsFold(FieldProcessingStatus, v))
+}
+
+// RequestCountEQ applies the EQ predicate on the "request_count" field.
+func RequestCountEQ(v int) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldRequestCount, v))
+}
+
+// RequestCountNEQ applies the NEQ predicate on the "request_count" field.
+func RequestCountNEQ(v int) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldRequestCount, v))
+}
+
+// RequestCountIn applies the In predicate on the "request_count" field.
+func RequestCountIn(vs ...int) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldRequestCount, vs...))
+}
+
+// RequestCountNotIn applies the NotIn predicate on the "request_count" field.
+func RequestCountNotIn(vs ...int) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldRequestCount, vs...))
+}
+
+// RequestCountGT applies the GT predicate on the "request_count" field.
+func RequestCountGT(v int) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldRequestCount, v))
+}
+
+// RequestCountGTE applies the GTE predicate on the "request_count" field.
+func RequestCountGTE(v int) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldRequestCount, v))
+}
+
+// RequestCountLT applies the LT predicate on the "request_count" field.
+func RequestCountLT(v int) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldRequestCount, v))
+}
+
+// RequestCountLTE applies the LTE predicate on the "request_count" field.
+func RequestCountLTE(v int) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldRequestCount, v))
+}
+
+// ExpiresAtEQ applies the EQ predicate on the "expires_at" field.
+func ExpiresAtEQ(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtNEQ applies the NEQ predicate on the "expires_at" field.
+func ExpiresAtNEQ(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldExpiresAt, v))
+}
+
+// ExpiresAtIn applies the In predicate on the "expires_at" field.
+func ExpiresAtIn(vs ...time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtNotIn applies the NotIn predicate on the "expires_at" field.
+func ExpiresAtNotIn(vs ...time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldExpiresAt, vs...))
+}
+
+// ExpiresAtGT applies the GT predicate on the "expires_at" field.
+func ExpiresAtGT(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldExpiresAt, v))
+}
+
+// ExpiresAtGTE applies the GTE predicate on the "expires_at" field.
+func ExpiresAtGTE(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldExpiresAt, v))
+}
+
+// ExpiresAtLT applies the LT predicate on the "expires_at" field.
+func ExpiresAtLT(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldExpiresAt, v))
+}
+
+// ExpiresAtLTE applies the LTE predicate on the "expires_at" field.
+func ExpiresAtLTE(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldExpiresAt, v))
+}
+
+// CancelInitiatedAtEQ applies the EQ predicate on the "cancel_initiated_at" field.
+func CancelInitiatedAtEQ(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldCancelInitiatedAt, v))
+}
+
+// CancelInitiatedAtNEQ applies the NEQ predicate on the "cancel_initiated_at" field.
+func CancelInitiatedAtNEQ(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldCancelInitiatedAt, v))
+}
+
+// CancelInitiatedAtIn applies the In predicate on the "cancel_initiated_at" field.
+func CancelInitiatedAtIn(vs ...time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldCancelInitiatedAt, vs...))
+}
+
+// CancelInitiatedAtNotIn applies the NotIn predicate on the "cancel_initiated_at" field.
+func CancelInitiatedAtNotIn(vs ...time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldCancelInitiatedAt, vs...))
+}
+
+// CancelInitiatedAtGT applies the GT predicate on the "cancel_initiated_at" field.
+func CancelInitiatedAtGT(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldCancelInitiatedAt, v))
+}
+
+// CancelInitiatedAtGTE applies the GTE predicate on the "cancel_initiated_at" field.
+func CancelInitiatedAtGTE(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldCancelInitiatedAt, v))
+}
+
+// CancelInitiatedAtLT applies the LT predicate on the "cancel_initiated_at" field.
+func CancelInitiatedAtLT(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldCancelInitiatedAt, v))
+}
+
+// CancelInitiatedAtLTE applies the LTE predicate on the "cancel_initiated_at" field.
+func CancelInitiatedAtLTE(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldCancelInitiatedAt, v))
+}
+
+// CancelInitiatedAtIsNil applies the IsNil predicate on the "cancel_initiated_at" field.
+func CancelInitiatedAtIsNil() predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIsNull(FieldCancelInitiatedAt))
+}
+
+// CancelInitiatedAtNotNil applies the NotNil predicate on the "cancel_initiated_at" field.
+func CancelInitiatedAtNotNil() predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotNull(FieldCancelInitiatedAt))
+}
+
+// EndedAtEQ applies the EQ predicate on the "ended_at" field.
+func EndedAtEQ(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldEQ(FieldEndedAt, v))
+}
+
+// EndedAtNEQ applies the NEQ predicate on the "ended_at" field.
+func EndedAtNEQ(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNEQ(FieldEndedAt, v))
+}
+
+// EndedAtIn applies the In predicate on the "ended_at" field.
+func EndedAtIn(vs ...time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIn(FieldEndedAt, vs...))
+}
+
+// EndedAtNotIn applies the NotIn predicate on the "ended_at" field.
+func EndedAtNotIn(vs ...time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotIn(FieldEndedAt, vs...))
+}
+
+// EndedAtGT applies the GT predicate on the "ended_at" field.
+func EndedAtGT(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGT(FieldEndedAt, v))
+}
+
+// EndedAtGTE applies the GTE predicate on the "ended_at" field.
+func EndedAtGTE(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldGTE(FieldEndedAt, v))
+}
+
+// EndedAtLT applies the LT predicate on the "ended_at" field.
+func EndedAtLT(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLT(FieldEndedAt, v))
+}
+
+// EndedAtLTE applies the LTE predicate on the "ended_at" field.
+func EndedAtLTE(v time.Time) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldLTE(FieldEndedAt, v))
+}
+
+// EndedAtIsNil applies the IsNil predicate on the "ended_at" field.
+func EndedAtIsNil() predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldIsNull(FieldEndedAt))
+}
+
+// EndedAtNotNil applies the NotNil predicate on the "ended_at" field.
+func EndedAtNotNil() predicate.MessageBatch {
+	return predicate.MessageBatch(sql.FieldNotNull(FieldEndedAt))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.MessageBatch) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.MessageBatch) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.MessageBatch) predicate.MessageBatch {
+	return predicate.MessageBatch(sql.NotPredicates(p))
+}
@@ -0,0 +1,1176 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/Wei-Shaw/sub2api/ent/messagebatch"
+)
+
+// MessageBatchCreate is the builder for creating a MessageBatch entity.
+type MessageBatchCreate struct {
+	config
+	mutation *MessageBatchMutation
+	hooks    []Hook
+	conflict []sql.ConflictOption
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (_c *MessageBatchCreate) SetCreatedAt(v time.Time) *MessageBatchCreate {
+	_c.mutation.SetCreatedAt(v)
+	return _c
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (_c *MessageBatchCreate) SetNillableCreatedAt(v *time.Time) *MessageBatchCreate {
+	if v != nil {
+		_c.SetCreatedAt(*v)
+	}
+	return _c
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (_c *MessageBatchCreate) SetUpdatedAt(v time.Time) *MessageBatchCreate {
+	_c.mutation.SetUpdatedAt(v)
+	return _c
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (_c *MessageBatchCreate) SetNillableUpdatedAt(v *time.Time) *MessageBatchCreate {
+	if v != nil {
+		_c.SetUpdatedAt(*v)
+	}
+	return _c
+}
+
+// SetBatchID sets the "batch_id" field.
+func (_c *MessageBatchCreate) SetBatchID(v string) *MessageBatchCreate {
+	_c.mutation.SetBatchID(v)
+	return _c
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *MessageBatchCreate) SetUserID(v int64) *MessageBatchCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetAPIKeyID sets the "api_key_id" field.
+func (_c *MessageBatchCreate) SetAPIKeyID(v int64) *MessageBatchCreate {
+	_c.mutation.SetAPIKeyID(v)
+	return _c
+}
+
+// SetGroupID sets the "group_id" field.
+func (_c *MessageBatchCreate) SetGroupID(v int64) *MessageBatchCreate {
+	_c.mutation.SetGroupID(v)
+	return _c
+}
+
+// SetNillableGroupID sets the "group_id" field if the given value is not nil.
+func (_c *MessageBatchCreate) SetNillableGroupID(v *int64) *MessageBatchCreate {
+	if v != nil {
+		_c.SetGroupID(*v)
+	}
+	return _c
+}
+
+// SetProcessingStatus sets the "processing_status" field.
+func (_c *MessageBatchCreate) SetProcessingStatus(v string) *MessageBatchCreate {
+	_c.mutation.SetProcessingStatus(v)
+	return _c
+}
+
+// SetNillableProcessingStatus sets the "processing_status" field if the given value is not nil.
+func (_c *MessageBatchCreate) SetNillableProcessingStatus(v *string) *MessageBatchCreate {
+	if v != nil {
+		_c.SetProcessingStatus(*v)
+	}
+	return _c
+}
+
+// SetRequestCount sets the "request_count" field.
+func (_c *MessageBatchCreate) SetRequestCount(v int) *MessageBatchCreate {
+	_c.mutation.SetRequestCount(v)
+	return _c
+}
+
+// SetNillableRequestCount sets the "request_count" field if the given value is not nil.
+func (_c *MessageBatchCreate) SetNillableRequestCount(v *int) *MessageBatchCreate {
+	if v != nil {
+		_c.SetRequestCount(*v)
+	}
+	return _c
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (_c *MessageBatchCreate) SetExpiresAt(v time.Time) *MessageBatchCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetCancelInitiatedAt sets the "cancel_initiated_at" field.
+func (_c *MessageBatchCreate) SetCancelInitiatedAt(v time.Time) *MessageBatchCreate {
+	_c.mutation.SetCancelInitiatedAt(v)
+	return _c
+}
+
+// SetNillableCancelInitiatedAt sets the "cancel_initiated_at" field if the given value is not nil.
+func (_c *MessageBatchCreate) SetNillableCancelInitiatedAt(v *time.Time) *MessageBatchCreate {
+	if v != nil {
+		_c.SetCancelInitiatedAt(*v)
+	}
+	return _c
+}
+
+// SetEndedAt sets the "ended_at" field.
+func (_c *MessageBatchCreate) SetEndedAt(v time.Time) *MessageBatchCreate {
+	_c.mutation.SetEndedAt(v)
+	return _c
+}
+
+// SetNillableEndedAt sets the "ended_at" field if the given value is not nil.
+func (_c *MessageBatchCreate) SetNillableEndedAt(v *time.Time) *MessageBatchCreate {
+	if v != nil {
+		_c.SetEndedAt(*v)
+	}
+	return _c
+}
+
+// Mutation returns the MessageBatchMutation object of the builder.
+func (_c *MessageBatchCreate) Mutation() *MessageBatchMutation {
+	return _c.mutation
+}
+
+// Save creates the MessageBatch in the database.
+func (_c *MessageBatchCreate) Save(ctx context.Context) (*MessageBatch, error) {
+	_c.defaults()
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *MessageBatchCreate) SaveX(ctx context.Context) *MessageBatch {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *MessageBatchCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *MessageBatchCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *MessageBatchCreate) defaults() {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		v := messagebatch.DefaultCreatedAt()
+		_c.mutation.SetCreatedAt(v)
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		v := messagebatch.DefaultUpdatedAt()
+		_c.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := _c.mutation.ProcessingStatus(); !ok {
+		v := messagebatch.DefaultProcessingStatus
+		_c.mutation.SetProcessingStatus(v)
+	}
+	if _, ok := _c.mutation.RequestCount(); !ok {
+		v := messagebatch.DefaultRequestCount
+		_c.mutation.SetRequestCount(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *MessageBatchCreate) check() error {
+	if _, ok := _c.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "MessageBatch.created_at"`)}
+	}
+	if _, ok := _c.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "MessageBatch.updated_at"`)}
+	}
+	if _, ok := _c.mutation.BatchID(); !ok {
+		return &ValidationError{Name: "batch_id", err: errors.New(`ent: missing required field "MessageBatch.batch_id"`)}
+	}
+	if v, ok := _c.mutation.BatchID(); ok {
+		if err := messagebatch.BatchIDValidator(v); err != nil {
+			return &ValidationError{Name: "batch_id", err: fmt.Errorf(`ent: validator failed for field "MessageBatch.batch_id": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "MessageBatch.user_id"`)}
+	}
+	if _, ok := _c.mutation.APIKeyID(); !ok {
+		return &ValidationError{Name: "api_key_id", err: errors.New(`ent: missing required field "MessageBatch.api_key_id"`)}
+	}
+	if _, ok := _c.mutation.ProcessingStatus(); !ok {
+		return &ValidationError{Name: "processing_status", err: errors.New(`ent: missing required field "MessageBatch.processing_status"`)}
+	}
+	if v, ok := _c.mutation.ProcessingStatus(); ok {
+		if err := messagebatch.ProcessingStatusValidator(v); err != nil {
+			return &ValidationError{Name: "processing_status", err: fmt.Errorf(`ent: validator failed for field "MessageBatch.processing_status": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.RequestCount(); !ok {
+		return &ValidationError{Name: "request_count", err: errors.New(`ent: missing required field "MessageBatch.request_count"`)}
+	}
+	if _, ok := _c.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "MessageBatch.expires_at"`)}
+	}
+	return nil
+}
+
+func (_c *MessageBatchCreate) sqlSave(ctx context.Context) (*MessageBatch, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int64(id)
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *MessageBatchCreate) createSpec() (*MessageBatch, *sqlgraph.CreateSpec) {
+	var (
+		_node = &MessageBatch{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(messagebatch.Table, sqlgraph.NewFieldSpec(messagebatch.FieldID, field.TypeInt64))
+	)
+	_spec.OnConflict = _c.conflict
+	if value, ok := _c.mutation.CreatedAt(); ok {
+		_spec.SetField(messagebatch.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := _c.mutation.UpdatedAt(); ok {
+		_spec.SetField(messagebatch.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := _c.mutation.BatchID(); ok {
+		_spec.SetField(messagebatch.FieldBatchID, field.TypeString, value)
+		_node.BatchID = value
+	}
+	if value, ok := _c.mutation.UserID(); ok {
+		_spec.SetField(messagebatch.FieldUserID, field.TypeInt64, value)
+		_node.UserID = value
+	}
+	if value, ok := _c.mutation.APIKeyID(); ok {
+		_spec.SetField(messagebatch.FieldAPIKeyID, field.TypeInt64, value)
+		_node.APIKeyID = value
+	}
+	if value, ok := _c.mutation.GroupID(); ok {
+		_spec.SetField(messagebatch.FieldGroupID, field.TypeInt64, value)
+		_node.GroupID = &value
+	}
+	if value, ok := _c.mutation.ProcessingStatus(); ok {
+		_spec.SetField(messagebatch.FieldProcessingStatus, field.TypeString, value)
+		_node.ProcessingStatus = value
+	}
+	if value, ok := _c.mutation.RequestCount(); ok {
+		_spec.SetField(messagebatch.FieldRequestCount, field.TypeInt, value)
+		_node.RequestCount = value
+	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(messagebatch.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := _c.mutation.CancelInitiatedAt(); ok {
+		_spec.SetField(messagebatch.FieldCancelInitiatedAt, field.TypeTime, value)
+		_node.CancelInitiatedAt = &value
+	}
+	if value, ok := _c.mutation.EndedAt(); ok {
+		_spec.SetField(messagebatch.FieldEndedAt, field.TypeTime, value)
+		_node.EndedAt = &value
+	}
+	return _node, _spec
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.MessageBatch.Create().
+//		SetCreatedAt(v).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.MessageBatchUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *MessageBatchCreate) OnConflict(opts ...sql.ConflictOption) *MessageBatchUpsertOne {
+	_c.conflict = opts
+	return &MessageBatchUpsertOne{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.MessageBatch.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *MessageBatchCreate) OnConflictColumns(columns ...string) *MessageBatchUpsertOne {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &MessageBatchUpsertOne{
+		create: _c,
+	}
+}
+
+type (
+	// MessageBatchUpsertOne is the builder for "upsert"-ing
+	//  one MessageBatch node.
+	MessageBatchUpsertOne struct {
+		create *MessageBatchCreate
+	}
+
+	// MessageBatchUpsert is the "OnConflict" setter.
+	MessageBatchUpsert struct {
+		*sql.UpdateSet
+	}
+)
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *MessageBatchUpsert) SetUpdatedAt(v time.Time) *MessageBatchUpsert {
+	u.Set(messagebatch.FieldUpdatedAt, v)
+	return u
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *MessageBatchUpsert) UpdateUpdatedAt() *MessageBatchUpsert {
+	u.SetExcluded(messagebatch.FieldUpdatedAt)
+	return u
+}
+
+// SetBatchID sets the "batch_id" field.
+func (u *MessageBatchUpsert) SetBatchID(v string) *MessageBatchUpsert {
+	u.Set(messagebatch.FieldBatchID, v)
+	return u
+}
+
+// UpdateBatchID sets the "batch_id" field to the value that was provided on create.
+func (u *MessageBatchUpsert) UpdateBatchID() *MessageBatchUpsert {
+	u.SetExcluded(messagebatch.FieldBatchID)
+	return u
+}
+
+// SetUserID sets the "user_id" field.
+func (u *MessageBatchUpsert) SetUserID(v int64) *MessageBatchUpsert {
+	u.Set(messagebatch.FieldUserID, v)
+	return u
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *MessageBatchUpsert) UpdateUserID() *MessageBatchUpsert {
+	u.SetExcluded(messagebatch.FieldUserID)
+	return u
+}
+
+// AddUserID adds v to the "user_id" field.
+func (u *MessageBatchUpsert) AddUserID(v int64) *MessageBatchUpsert {
+	u.Add(messagebatch.FieldUserID, v)
+	return u
+}
+
+// SetAPIKeyID sets the "api_key_id" field.
+func (u *MessageBatchUpsert) SetAPIKeyID(v int64) *MessageBatchUpsert {
+	u.Set(messagebatch.FieldAPIKeyID, v)
+	return u
+}
+
+// UpdateAPIKeyID sets the "api_key_id" field to the value that was provided on create.
+func (u *MessageBatchUpsert) UpdateAPIKeyID() *MessageBatchUpsert {
+	u.SetExcluded(messagebatch.FieldAPIKeyID)
+	return u
+}
+
+// AddAPIKeyID adds v to the "api_key_id" field.
+func (u *MessageBatchUpsert) AddAPIKeyID(v int64) *MessageBatchUpsert {
+	u.Add(messagebatch.FieldAPIKeyID, v)
+	return u
+}
+
+// SetGroupID sets the "group_id" field.
+func (u *MessageBatchUpsert) SetGroupID(v int64) *MessageBatchUpsert {
+	u.Set(messagebatch.FieldGroupID, v)
+	return u
+}
+
+// UpdateGroupID sets the "group_id" field to the value that was provided on create.
+func (u *MessageBatchUpsert) UpdateGroupID() *MessageBatchUpsert {
+	u.SetExcluded(messagebatch.FieldGroupID)
+	return u
+}
+
+// AddGroupID adds v to the "group_id" field.
+func (u *MessageBatchUpsert) AddGroupID(v int64) *MessageBatchUpsert {
+	u.Add(messagebatch.FieldGroupID, v)
+	return u
+}
+
+// ClearGroupID clears the value of the "group_id" field.
+func (u *MessageBatchUpsert) ClearGroupID() *MessageBatchUpsert {
+	u.SetNull(messagebatch.FieldGroupID)
+	return u
+}
+
+// SetProcessingStatus sets the "processing_status" field.
+func (u *MessageBatchUpsert) SetProcessingStatus(v string) *MessageBatchUpsert {
+	u.Set(messagebatch.FieldProcessingStatus, v)
+	return u
+}
+
+// UpdateProcessingStatus sets the "processing_status" field to the value that was provided on create.
+func (u *MessageBatchUpsert) UpdateProcessingStatus() *MessageBatchUpsert {
+	u.SetExcluded(messagebatch.FieldProcessingStatus)
+	return u
+}
+
+// SetRequestCount sets the "request_count" field.
+func (u *MessageBatchUpsert) SetRequestCount(v int) *MessageBatchUpsert {
+	u.Set(messagebatch.FieldRequestCount, v)
+	return u
+}
+
+// UpdateRequestCount sets the "request_count" field to the value that was provided on create.
+func (u *MessageBatchUpsert) UpdateRequestCount() *MessageBatchUpsert {
+	u.SetExcluded(messagebatch.FieldRequestCount)
+	return u
+}
+
+// AddRequestCount adds v to the "request_count" field.
+func (u *MessageBatchUpsert) AddRequestCount(v int) *MessageBatchUpsert {
+	u.Add(messagebatch.FieldRequestCount, v)
+	return u
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *MessageBatchUpsert) SetExpiresAt(v time.Time) *MessageBatchUpsert {
+	u.Set(messagebatch.FieldExpiresAt, v)
+	return u
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *MessageBatchUpsert) UpdateExpiresAt() *MessageBatchUpsert {
+	u.SetExcluded(messagebatch.FieldExpiresAt)
+	return u
+}
+
+// SetCancelInitiatedAt sets the "cancel_initiated_at" field.
+func (u *MessageBatchUpsert) SetCancelInitiatedAt(v time.Time) *MessageBatchUpsert {
+	u.Set(messagebatch.FieldCancelInitiatedAt, v)
+	return u
+}
+
+// UpdateCancelInitiatedAt sets the "cancel_initiated_at" field to the value that was provided on create.
+func (u *MessageBatchUpsert) UpdateCancelInitiatedAt() *MessageBatchUpsert {
+	u.SetExcluded(messagebatch.FieldCancelInitiatedAt)
+	return u
+}
+
+// ClearCancelInitiatedAt clears the value of the "cancel_initiated_at" field.
+func (u *MessageBatchUpsert) ClearCancelInitiatedAt() *MessageBatchUpsert {
+	u.SetNull(messagebatch.FieldCancelInitiatedAt)
+	return u
+}
+
+// SetEndedAt sets the "ended_at" field.
+func (u *MessageBatchUpsert) SetEndedAt(v time.Time) *MessageBatchUpsert {
+	u.Set(messagebatch.FieldEndedAt, v)
+	return u
+}
+
+// UpdateEndedAt sets the "ended_at" field to the value that was provided on create.
+func (u *MessageBatchUpsert) UpdateEndedAt() *MessageBatchUpsert {
+	u.SetExcluded(messagebatch.FieldEndedAt)
+	return u
+}
+
+// ClearEndedAt clears the value of the "ended_at" field.
+func (u *MessageBatchUpsert) ClearEndedAt() *MessageBatchUpsert {
+	u.SetNull(messagebatch.FieldEndedAt)
+	return u
+}
+
+// UpdateNewValues updates the mutable fields using the new values that were set on create.
+// Using this option is equivalent to using:
+//
+//	client.MessageBatch.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *MessageBatchUpsertOne) UpdateNewValues() *MessageBatchUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		if _, exists := u.create.mutation.CreatedAt(); exists {
+			s.SetIgnore(messagebatch.FieldCreatedAt)
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.MessageBatch.Create().
+//	    OnConflict(sql.ResolveWithIgnore()).
+//	    Exec(ctx)
+func (u *MessageBatchUpsertOne) Ignore() *MessageBatchUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *MessageBatchUpsertOne) DoNothing() *MessageBatchUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the MessageBatchCreate.OnConflict
+// documentation for more info.
+func (u *MessageBatchUpsertOne) Update(set func(*MessageBatchUpsert)) *MessageBatchUpsertOne {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&MessageBatchUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *MessageBatchUpsertOne) SetUpdatedAt(v time.Time) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *MessageBatchUpsertOne) UpdateUpdatedAt() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetBatchID sets the "batch_id" field.
+func (u *MessageBatchUpsertOne) SetBatchID(v string) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetBatchID(v)
+	})
+}
+
+// UpdateBatchID sets the "batch_id" field to the value that was provided on create.
+func (u *MessageBatchUpsertOne) UpdateBatchID() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateBatchID()
+	})
+}
+
+// SetUserID sets the "user_id" field.
+func (u *MessageBatchUpsertOne) SetUserID(v int64) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// AddUserID adds v to the "user_id" field.
+func (u *MessageBatchUpsertOne) AddUserID(v int64) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.AddUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *MessageBatchUpsertOne) UpdateUserID() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetAPIKeyID sets the "api_key_id" field.
+func (u *MessageBatchUpsertOne) SetAPIKeyID(v int64) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetAPIKeyID(v)
+	})
+}
+
+// AddAPIKeyID adds v to the "api_key_id" field.
+func (u *MessageBatchUpsertOne) AddAPIKeyID(v int64) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.AddAPIKeyID(v)
+	})
+}
+
+// UpdateAPIKeyID sets the "api_key_id" field to the value that was provided on create.
+func (u *MessageBatchUpsertOne) UpdateAPIKeyID() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateAPIKeyID()
+	})
+}
+
+// SetGroupID sets the "group_id" field.
+func (u *MessageBatchUpsertOne) SetGroupID(v int64) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetGroupID(v)
+	})
+}
+
+// AddGroupID adds v to the "group_id" field.
+func (u *MessageBatchUpsertOne) AddGroupID(v int64) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.AddGroupID(v)
+	})
+}
+
+// UpdateGroupID sets the "group_id" field to the value that was provided on create.
+func (u *MessageBatchUpsertOne) UpdateGroupID() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateGroupID()
+	})
+}
+
+// ClearGroupID clears the value of the "group_id" field.
+func (u *MessageBatchUpsertOne) ClearGroupID() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.ClearGroupID()
+	})
+}
+
+// SetProcessingStatus sets the "processing_status" field.
+func (u *MessageBatchUpsertOne) SetProcessingStatus(v string) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetProcessingStatus(v)
+	})
+}
+
+// UpdateProcessingStatus sets the "processing_status" field to the value that was provided on create.
+func (u *MessageBatchUpsertOne) UpdateProcessingStatus() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateProcessingStatus()
+	})
+}
+
+// SetRequestCount sets the "request_count" field.
+func (u *MessageBatchUpsertOne) SetRequestCount(v int) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetRequestCount(v)
+	})
+}
+
+// AddRequestCount adds v to the "request_count" field.
+func (u *MessageBatchUpsertOne) AddRequestCount(v int) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.AddRequestCount(v)
+	})
+}
+
+// UpdateRequestCount sets the "request_count" field to the value that was provided on create.
+func (u *MessageBatchUpsertOne) UpdateRequestCount() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateRequestCount()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *MessageBatchUpsertOne) SetExpiresAt(v time.Time) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *MessageBatchUpsertOne) UpdateExpiresAt() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// SetCancelInitiatedAt sets the "cancel_initiated_at" field.
+func (u *MessageBatchUpsertOne) SetCancelInitiatedAt(v time.Time) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetCancelInitiatedAt(v)
+	})
+}
+
+// UpdateCancelInitiatedAt sets the "cancel_initiated_at" field to the value that was provided on create.
+func (u *MessageBatchUpsertOne) UpdateCancelInitiatedAt() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateCancelInitiatedAt()
+	})
+}
+
+// ClearCancelInitiatedAt clears the value of the "cancel_initiated_at" field.
+func (u *MessageBatchUpsertOne) ClearCancelInitiatedAt() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.ClearCancelInitiatedAt()
+	})
+}
+
+// SetEndedAt sets the "ended_at" field.
+func (u *MessageBatchUpsertOne) SetEndedAt(v time.Time) *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetEndedAt(v)
+	})
+}
+
+// UpdateEndedAt sets the "ended_at" field to the value that was provided on create.
+func (u *MessageBatchUpsertOne) UpdateEndedAt() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateEndedAt()
+	})
+}
+
+// ClearEndedAt clears the value of the "ended_at" field.
+func (u *MessageBatchUpsertOne) ClearEndedAt() *MessageBatchUpsertOne {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.ClearEndedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *MessageBatchUpsertOne) Exec(ctx context.Context) error {
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for MessageBatchCreate.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *MessageBatchUpsertOne) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// Exec executes the UPSERT query and returns the inserted/updated ID.
+func (u *MessageBatchUpsertOne) ID(ctx context.Context) (id int64, err error) {
+	node, err := u.create.Save(ctx)
+	if err != nil {
+		return id, err
+	}
+	return node.ID, nil
+}
+
+// IDX is like ID, but panics if an error occurs.
+func (u *MessageBatchUpsertOne) IDX(ctx context.Context) int64 {
+	id, err := u.ID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// MessageBatchCreateBulk is the builder for creating many MessageBatch entities in bulk.
+type MessageBatchCreateBulk struct {
+	config
+	err      error
+	builders []*MessageBatchCreate
+	conflict []sql.ConflictOption
+}
+
+// Save creates the MessageBatch entities in the database.
+func (_c *MessageBatchCreateBulk) Save(ctx context.Context) ([]*MessageBatch, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*MessageBatch, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*MessageBatchMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					spec.OnConflict = _c.conflict
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *MessageBatchCreateBulk) SaveX(ctx context.Context) []*MessageBatch {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *MessageBatchCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *MessageBatchCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// OnConflict allows configuring the `ON CONFLICT` / `ON DUPLICATE KEY` clause
+// of the `INSERT` statement. For example:
+//
+//	client.MessageBatch.CreateBulk(builders...).
+//		OnConflict(
+//			// Update the row with the new values
+//			// the was proposed for insertion.
+//			sql.ResolveWithNewValues(),
+//		).
+//		// Override some of the fields with custom
+//		// update values.
+//		Update(func(u *ent.MessageBatchUpsert) {
+//			SetCreatedAt(v+v).
+//		}).
+//		Exec(ctx)
+func (_c *MessageBatchCreateBulk) OnConflict(opts ...sql.ConflictOption) *MessageBatchUpsertBulk {
+	_c.conflict = opts
+	return &MessageBatchUpsertBulk{
+		create: _c,
+	}
+}
+
+// OnConflictColumns calls `OnConflict` and configures the columns
+// as conflict target. Using this option is equivalent to using:
+//
+//	client.MessageBatch.Create().
+//		OnConflict(sql.ConflictColumns(columns...)).
+//		Exec(ctx)
+func (_c *MessageBatchCreateBulk) OnConflictColumns(columns ...string) *MessageBatchUpsertBulk {
+	_c.conflict = append(_c.conflict, sql.ConflictColumns(columns...))
+	return &MessageBatchUpsertBulk{
+		create: _c,
+	}
+}
+
+// MessageBatchUpsertBulk is the builder for "upsert"-ing
+// a bulk of MessageBatch nodes.
+type MessageBatchUpsertBulk struct {
+	create *MessageBatchCreateBulk
+}
+
+// UpdateNewValues updates the mutable fields using the new values that
+// were set on create. Using this option is equivalent to using:
+//
+//	client.MessageBatch.Create().
+//		OnConflict(
+//			sql.ResolveWithNewValues(),
+//		).
+//		Exec(ctx)
+func (u *MessageBatchUpsertBulk) UpdateNewValues() *MessageBatchUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithNewValues())
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(s *sql.UpdateSet) {
+		for _, b := range u.create.builders {
+			if _, exists := b.mutation.CreatedAt(); exists {
+				s.SetIgnore(messagebatch.FieldCreatedAt)
+			}
+		}
+	}))
+	return u
+}
+
+// Ignore sets each column to itself in case of conflict.
+// Using this option is equivalent to using:
+//
+//	client.MessageBatch.Create().
+//		OnConflict(sql.ResolveWithIgnore()).
+//		Exec(ctx)
+func (u *MessageBatchUpsertBulk) Ignore() *MessageBatchUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWithIgnore())
+	return u
+}
+
+// DoNothing configures the conflict_action to `DO NOTHING`.
+// Supported only by SQLite and PostgreSQL.
+func (u *MessageBatchUpsertBulk) DoNothing() *MessageBatchUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.DoNothing())
+	return u
+}
+
+// Update allows overriding fields `UPDATE` values. See the MessageBatchCreateBulk.OnConflict
+// documentation for more info.
+func (u *MessageBatchUpsertBulk) Update(set func(*MessageBatchUpsert)) *MessageBatchUpsertBulk {
+	u.create.conflict = append(u.create.conflict, sql.ResolveWith(func(update *sql.UpdateSet) {
+		set(&MessageBatchUpsert{UpdateSet: update})
+	}))
+	return u
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (u *MessageBatchUpsertBulk) SetUpdatedAt(v time.Time) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetUpdatedAt(v)
+	})
+}
+
+// UpdateUpdatedAt sets the "updated_at" field to the value that was provided on create.
+func (u *MessageBatchUpsertBulk) UpdateUpdatedAt() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateUpdatedAt()
+	})
+}
+
+// SetBatchID sets the "batch_id" field.
+func (u *MessageBatchUpsertBulk) SetBatchID(v string) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetBatchID(v)
+	})
+}
+
+// UpdateBatchID sets the "batch_id" field to the value that was provided on create.
+func (u *MessageBatchUpsertBulk) UpdateBatchID() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateBatchID()
+	})
+}
+
+// SetUserID sets the "user_id" field.
+func (u *MessageBatchUpsertBulk) SetUserID(v int64) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetUserID(v)
+	})
+}
+
+// AddUserID adds v to the "user_id" field.
+func (u *MessageBatchUpsertBulk) AddUserID(v int64) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.AddUserID(v)
+	})
+}
+
+// UpdateUserID sets the "user_id" field to the value that was provided on create.
+func (u *MessageBatchUpsertBulk) UpdateUserID() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateUserID()
+	})
+}
+
+// SetAPIKeyID sets the "api_key_id" field.
+func (u *MessageBatchUpsertBulk) SetAPIKeyID(v int64) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetAPIKeyID(v)
+	})
+}
+
+// AddAPIKeyID adds v to the "api_key_id" field.
+func (u *MessageBatchUpsertBulk) AddAPIKeyID(v int64) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.AddAPIKeyID(v)
+	})
+}
+
+// UpdateAPIKeyID sets the "api_key_id" field to the value that was provided on create.
+func (u *MessageBatchUpsertBulk) UpdateAPIKeyID() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateAPIKeyID()
+	})
+}
+
+// SetGroupID sets the "group_id" field.
+func (u *MessageBatchUpsertBulk) SetGroupID(v int64) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetGroupID(v)
+	})
+}
+
+// AddGroupID adds v to the "group_id" field.
+func (u *MessageBatchUpsertBulk) AddGroupID(v int64) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.AddGroupID(v)
+	})
+}
+
+// UpdateGroupID sets the "group_id" field to the value that was provided on create.
+func (u *MessageBatchUpsertBulk) UpdateGroupID() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateGroupID()
+	})
+}
+
+// ClearGroupID clears the value of the "group_id" field.
+func (u *MessageBatchUpsertBulk) ClearGroupID() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.ClearGroupID()
+	})
+}
+
+// SetProcessingStatus sets the "processing_status" field.
+func (u *MessageBatchUpsertBulk) SetProcessingStatus(v string) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetProcessingStatus(v)
+	})
+}
+
+// UpdateProcessingStatus sets the "processing_status" field to the value that was provided on create.
+func (u *MessageBatchUpsertBulk) UpdateProcessingStatus() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateProcessingStatus()
+	})
+}
+
+// SetRequestCount sets the "request_count" field.
+func (u *MessageBatchUpsertBulk) SetRequestCount(v int) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetRequestCount(v)
+	})
+}
+
+// AddRequestCount adds v to the "request_count" field.
+func (u *MessageBatchUpsertBulk) AddRequestCount(v int) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.AddRequestCount(v)
+	})
+}
+
+// UpdateRequestCount sets the "request_count" field to the value that was provided on create.
+func (u *MessageBatchUpsertBulk) UpdateRequestCount() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateRequestCount()
+	})
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (u *MessageBatchUpsertBulk) SetExpiresAt(v time.Time) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetExpiresAt(v)
+	})
+}
+
+// UpdateExpiresAt sets the "expires_at" field to the value that was provided on create.
+func (u *MessageBatchUpsertBulk) UpdateExpiresAt() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateExpiresAt()
+	})
+}
+
+// SetCancelInitiatedAt sets the "cancel_initiated_at" field.
+func (u *MessageBatchUpsertBulk) SetCancelInitiatedAt(v time.Time) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetCancelInitiatedAt(v)
+	})
+}
+
+// UpdateCancelInitiatedAt sets the "cancel_initiated_at" field to the value that was provided on create.
+func (u *MessageBatchUpsertBulk) UpdateCancelInitiatedAt() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateCancelInitiatedAt()
+	})
+}
+
+// ClearCancelInitiatedAt clears the value of the "cancel_initiated_at" field.
+func (u *MessageBatchUpsertBulk) ClearCancelInitiatedAt() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.ClearCancelInitiatedAt()
+	})
+}
+
+// SetEndedAt sets the "ended_at" field.
+func (u *MessageBatchUpsertBulk) SetEndedAt(v time.Time) *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.SetEndedAt(v)
+	})
+}
+
+// UpdateEndedAt sets the "ended_at" field to the value that was provided on create.
+func (u *MessageBatchUpsertBulk) UpdateEndedAt() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.UpdateEndedAt()
+	})
+}
+
+// ClearEndedAt clears the value of the "ended_at" field.
+func (u *MessageBatchUpsertBulk) ClearEndedAt() *MessageBatchUpsertBulk {
+	return u.Update(func(s *MessageBatchUpsert) {
+		s.ClearEndedAt()
+	})
+}
+
+// Exec executes the query.
+func (u *MessageBatchUpsertBulk) Exec(ctx context.Context) error {
+	if u.create.err != nil {
+		return u.create.err
+	}
+	for i, b := range u.create.builders {
+		if len(b.conflict) != 0 {
+			return fmt.Errorf("ent: OnConflict was set for builder %d. Set it on the MessageBatchCreateBulk instead", i)
+		}
+	}
+	if len(u.create.conflict) == 0 {
+		return errors.New("ent: missing options for MessageBatchCreateBulk.OnConflict")
+	}
+	return u.create.Exec(ctx)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (u *MessageBatchUpsertBulk) ExecX(ctx context.Context) {
+	if err := u.create.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -7,11 +7,15 @@ import (
 
 	"github.com/Wei-Shaw/sub2api/ent/account"
 	"github.com/Wei-Shaw/sub2api/ent/accountgroup"
+	"github.com/Wei-Shaw/sub2api/ent/adminauditlog"
 	"github.com/Wei-Shaw/sub2api/ent/announcement"
 	"github.com/Wei-Shaw/sub2api/ent/announcementread"
 	"github.com/Wei-Shaw/sub2api/ent/apikey"
+	"github.com/Wei-Shaw/sub2api/ent/balanceledgerentry"
 	"github.com/Wei-Shaw/sub2api/ent/errorpassthroughrule"
 	"github.com/Wei-Shaw/sub2api/ent/group"
+	"github.com/Wei-Shaw/sub2api/ent/messagebatch"
+	"github.com/Wei-Shaw/sub2api/ent/messagebatchitem"
 	"github.com/Wei-Shaw/sub2api/ent/promocode"
 	"github.com/Wei-Shaw/sub2api/ent/promocodeusage"
 	"github.com/Wei-Shaw/sub2api/ent/proxy"
@@ -25,6 +29,8 @@ import (
 	"github.com/Wei-Shaw/sub2api/ent/userattributedefinition"
 	"github.com/Wei-Shaw/sub2api/ent/userattributevalue"
 	"github.com/Wei-Shaw/sub2api/ent/usersubscription"
+	"github.com/Wei-Shaw/sub2api/ent/webhookdelivery"
+	"github.com/Wei-Shaw/sub2api/ent/webhookendpoint"
 )
 
 // The init function reads all schema descriptors with runtime code
@@ -53,23 +59,23 @@ func init() {
 	// apikeyDescKey is the schema descriptor for key field.
 	apikeyDescKey := apikeyFields[1].Descriptor()
 	// apikey.KeyValidator is a validator for the "key" field. It is called by the builders before save.
-	apikey.KeyValidator = func() func(string) error {
-		validators := apikeyDescKey.Validators
-		fns := [...]func(string) error{
-			validators[0].(func(string) error),
-			validators[1].(func(string) error),
-		}
-		return func(key string) error {
-			for _, fn := range fns {
-				if err := fn(key); err != nil {
-					return err
-				}
-			}
-			return nil
-		}
-	}()
+	apikey.KeyValidator = apikeyDescKey.Validators[0].(func(string) error)
+	// apikeyDescKeyPrefix is the schema descriptor for key_prefix field.
+	apikeyDescKeyPrefix := apikeyFields[2].Descriptor()
+	// apikey.DefaultKeyPrefix holds the default value on creation for the key_prefix field.
+	apikey.DefaultKeyPrefix = apikeyDescKeyPrefix.Default.(string)
+	// apikey.KeyPrefixValidator is a validator for the "key_prefix" field. It is called by the builders before save.
+	apikey.KeyPrefixValidator = apikeyDescKeyPrefix.Validators[0].(func(string) error)
+	// apikeyDescKeySalt is the schema descriptor for key_salt field.
+	apikeyDescKeySalt := apikeyFields[3].Descriptor()
+	// apikey.KeySaltValidator is a validator for the "key_salt" field. It is called by the builders before save.
+	apikey.KeySaltValidator = apikeyDescKeySalt.Validators[0].(func(string) error)
+	// apikeyDescKeyHash is the schema descriptor for key_hash field.
+	apikeyDescKeyHash := apikeyFields[4].Descriptor()
+	// apikey.KeyHashValidator is a validator for the "key_hash" field. It is called by the builders before save.
+	apikey.KeyHashValidator = apikeyDescKeyHash.Validators[0].(func(string) error)
 	// apikeyDescName is the schema descriptor for name field.
-	apikeyDescName := apikeyFields[2].Descriptor()
+	apikeyDescName := apikeyFields[5].Descriptor()
 	// apikey.NameValidator is a validator for the "name" field. It is called by the builders before save.
 	apikey.NameValidator = func() func(string) error {
 		validators := apikeyDescName.Validators
@@ -87,19 +93,31 @@ func init() {
 		}
 	}()
 	// apikeyDescStatus is the schema descriptor for status field.
-	apikeyDescStatus := apikeyFields[4].Descriptor()
+	apikeyDescStatus := apikeyFields[7].Descriptor()
 	// apikey.DefaultStatus holds the default value on creation for the status field.
 	apikey.DefaultStatus = apikeyDescStatus.Default.(string)
 	// apikey.StatusValidator is a validator for the "status" field. It is called by the builders before save.
 	apikey.StatusValidator = apikeyDescStatus.Validators[0].(func(string) error)
 	// apikeyDescQuota is the schema descriptor for quota field.
-	apikeyDescQuota := apikeyFields[7].Descriptor()
+	apikeyDescQuota := apikeyFields[10].Descriptor()
 	// apikey.DefaultQuota holds the default value on creation for the quota field.
 	apikey.DefaultQuota = apikeyDescQuota.Default.(float64)
 	// apikeyDescQuotaUsed is the schema descriptor for quota_used field.
-	apikeyDescQuotaUsed := apikeyFields[8].Descriptor()
+	apikeyDescQuotaUsed := apikeyFields[11].Descriptor()
 	// apikey.DefaultQuotaUsed holds the default value on creation for the quota_used field.
 	apikey.DefaultQuotaUsed = apikeyDescQuotaUsed.Default.(float64)
+	// apikeyDescRpmLimit is the schema descriptor for rpm_limit field.
+	apikeyDescRpmLimit := apikeyFields[13].Descriptor()
+	// apikey.DefaultRpmLimit holds the default value on creation for the rpm_limit field.
+	apikey.DefaultRpmLimit = apikeyDescRpmLimit.Default.(int)
+	// apikeyDescTpmLimit is the schema descriptor for tpm_limit field.
+	apikeyDescTpmLimit := apikeyFields[14].Descriptor()
+	// apikey.DefaultTpmLimit holds the default value on creation for the tpm_limit field.
+	apikey.DefaultTpmLimit = apikeyDescTpmLimit.Default.(int)
+	// apikeyDescDailyRequestLimit is the schema descriptor for daily_request_limit field.
+	apikeyDescDailyRequestLimit := apikeyFields[15].Descriptor()
+	// apikey.DefaultDailyRequestLimit holds the default value on creation for the daily_request_limit field.
+	apikey.DefaultDailyRequestLimit = apikeyDescDailyRequestLimit.Default.(int)
 	accountMixin := schema.Account{}.Mixin()
 	accountMixinHooks1 := accountMixin[1].Hooks()
 	account.Hooks[0] = accountMixinHooks1[0]
@@ -221,6 +239,50 @@ func init() {
 	accountgroupDescCreatedAt := accountgroupFields[3].Descriptor()
 	// accountgroup.DefaultCreatedAt holds the default value on creation for the created_at field.
 	accountgroup.DefaultCreatedAt = accountgroupDescCreatedAt.Default.(func() time.Time)
+	adminauditlogFields := schema.AdminAuditLog{}.Fields()
+	_ = adminauditlogFields
+	// adminauditlogDescAuthMethod is the schema descriptor for auth_method field.
+	adminauditlogDescAuthMethod := adminauditlogFields[1].Descriptor()
+	// adminauditlog.DefaultAuthMethod holds the default value on creation for the auth_method field.
+	adminauditlog.DefaultAuthMethod = adminauditlogDescAuthMethod.Default.(string)
+	// adminauditlog.AuthMethodValidator is a validator for the "auth_method" field. It is called by the builders before save.
+	adminauditlog.AuthMethodValidator = adminauditlogDescAuthMethod.Validators[0].(func(string) error)
+	// adminauditlogDescMethod is the schema descriptor for method field.
+	adminauditlogDescMethod := adminauditlogFields[2].Descriptor()
+	// adminauditlog.MethodValidator is a validator for the "method" field. It is called by the builders before save.
+	adminauditlog.MethodValidator = adminauditlogDescMethod.Validators[0].(func(string) error)
+	// adminauditlogDescRoute is the schema descriptor for route field.
+	adminauditlogDescRoute := adminauditlogFields[3].Descriptor()
+	// adminauditlog.RouteValidator is a validator for the "route" field. It is called by the builders before save.
+	adminauditlog.RouteValidator = adminauditlogDescRoute.Validators[0].(func(string) error)
+	// adminauditlogDescStatusCode is the schema descriptor for status_code field.
+	adminauditlogDescStatusCode := adminauditlogFields[5].Descriptor()
+	// adminauditlog.DefaultStatusCode holds the default value on creation for the status_code field.
+	adminauditlog.DefaultStatusCode = adminauditlogDescStatusCode.Default.(int)
+	// adminauditlogDescTargetType is the schema descriptor for target_type field.
+	adminauditlogDescTargetType := adminauditlogFields[6].Descriptor()
+	// adminauditlog.DefaultTargetType holds the default value on creation for the target_type field.
+	adminauditlog.DefaultTargetType = adminauditlogDescTargetType.Default.(string)
+	// adminauditlog.TargetTypeValidator is a validator for the "target_type" field. It is called by the builders before save.
+	adminauditlog.TargetTypeValidator = adminauditlogDescTargetType.Validators[0].(func(string) error)
+	// adminauditlogDescTargetID is the schema descriptor for target_id field.
+	adminauditlogDescTargetID := adminauditlogFields[7].Descriptor()
+	// adminauditlog.TargetIDValidator is a validator for the "target_id" field. It is called by the builders before save.
+	adminauditlog.TargetIDValidator = adminauditlogDescTargetID.Validators[0].(func(string) error)
+	// adminauditlogDescIPAddress is the schema descriptor for ip_address field.
+	adminauditlogDescIPAddress := adminauditlogFields[9].Descriptor()
+	// adminauditlog.DefaultIPAddress holds the default value on creation for the ip_address field.
+	adminauditlog.DefaultIPAddress = adminauditlogDescIPAddress.Default.(string)
+	// adminauditlog.IPAddressValidator is a validator for the "ip_address" field. It is called by the builders before save.
+	adminauditlog.IPAddressValidator = adminauditlogDescIPAddress.Validators[0].(func(string) error)
+	// adminauditlogDescUserAgent is the schema descriptor for user_agent field.
+	adminauditlogDescUserAgent := adminauditlogFields[10].Descriptor()
+	// adminauditlog.UserAgentValidator is a validator for the "user_agent" field. It is called by the builders before save.
+	adminauditlog.UserAgentValidator = adminauditlogDescUserAgent.Validators[0].(func(string) error)
+	// adminauditlogDescCreatedAt is the schema descriptor for created_at field.
+	adminauditlogDescCreatedAt := adminauditlogFields[11].Descriptor()
+	// adminauditlog.DefaultCreatedAt holds the default value on creation for the created_at field.
+	adminauditlog.DefaultCreatedAt = adminauditlogDescCreatedAt.Default.(func() time.Time)
 	announcementFields := schema.Announcement{}.Fields()
 	_ = announcementFields
 	// announcementDescTitle is the schema descriptor for title field.
@@ -271,6 +333,20 @@ func init() {
 	announcementreadDescCreatedAt := announcementreadFields[3].Descriptor()
 	// announcementread.DefaultCreatedAt holds the default value on creation for the created_at field.
 	announcementread.DefaultCreatedAt = announcementreadDescCreatedAt.Default.(func() time.Time)
+	balanceledgerentryFields := schema.BalanceLedgerEntry{}.Fields()
+	_ = balanceledgerentryFields
+	// balanceledgerentryDescEntryType is the schema descriptor for entry_type field.
+	balanceledgerentryDescEntryType := balanceledgerentryFields[1].Descriptor()
+	// balanceledgerentry.EntryTypeValidator is a validator for the "entry_type" field. It is called by the builders before save.
+	balanceledgerentry.EntryTypeValidator = balanceledgerentryDescEntryType.Validators[0].(func(string) error)
+	// balanceledgerentryDescNote is the schema descriptor for note field.
+	balanceledgerentryDescNote := balanceledgerentryFields[7].Descriptor()
+	// balanceledgerentry.DefaultNote holds the default value on creation for the note field.
+	balanceledgerentry.DefaultNote = balanceledgerentryDescNote.Default.(string)
+	// balanceledgerentryDescCreatedAt is the schema descriptor for created_at field.
+	balanceledgerentryDescCreatedAt := balanceledgerentryFields[8].Descriptor()
+	// balanceledgerentry.DefaultCreatedAt holds the default value on creation for the created_at field.
+	balanceledgerentry.DefaultCreatedAt = balanceledgerentryDescCreatedAt.Default.(func() time.Time)
 	errorpassthroughruleMixin := schema.ErrorPassthroughRule{}.Mixin()
 	errorpassthroughruleMixinFields0 := errorpassthroughruleMixin[0].Fields()
 	_ = errorpassthroughruleMixinFields0
@@ -417,6 +493,84 @@ func init() {
 	groupDescSortOrder := groupFields[21].Descriptor()
 	// group.DefaultSortOrder holds the default value on creation for the sort_order field.
 	group.DefaultSortOrder = groupDescSortOrder.Default.(int)
+	// groupDescDefaultRpmLimit is the schema descriptor for default_rpm_limit field.
+	groupDescDefaultRpmLimit := groupFields[22].Descriptor()
+	// group.DefaultDefaultRpmLimit holds the default value on creation for the default_rpm_limit field.
+	group.DefaultDefaultRpmLimit = groupDescDefaultRpmLimit.Default.(int)
+	// groupDescDefaultTpmLimit is the schema descriptor for default_tpm_limit field.
+	groupDescDefaultTpmLimit := groupFields[23].Descriptor()
+	// group.DefaultDefaultTpmLimit holds the default value on creation for the default_tpm_limit field.
+	group.DefaultDefaultTpmLimit = groupDescDefaultTpmLimit.Default.(int)
+	// groupDescDefaultDailyRequestLimit is the schema descriptor for default_daily_request_limit field.
+	groupDescDefaultDailyRequestLimit := groupFields[24].Descriptor()
+	// group.DefaultDefaultDailyRequestLimit holds the default value on creation for the default_daily_request_limit field.
+	group.DefaultDefaultDailyRequestLimit = groupDescDefaultDailyRequestLimit.Default.(int)
+	// groupDescResponseCacheEnabled is the schema descriptor for response_cache_enabled field.
+	groupDescResponseCacheEnabled := groupFields[25].Descriptor()
+	// group.DefaultResponseCacheEnabled holds the default value on creation for the response_cache_enabled field.
+	group.DefaultResponseCacheEnabled = groupDescResponseCacheEnabled.Default.(bool)
+	// groupDescResponseCacheTTLSeconds is the schema descriptor for response_cache_ttl_seconds field.
+	groupDescResponseCacheTTLSeconds := groupFields[26].Descriptor()
+	// group.DefaultResponseCacheTTLSeconds holds the default value on creation for the response_cache_ttl_seconds field.
+	group.DefaultResponseCacheTTLSeconds = groupDescResponseCacheTTLSeconds.Default.(int)
+	messagebatchMixin := schema.MessageBatch{}.Mixin()
+	messagebatchMixinFields0 := messagebatchMixin[0].Fields()
+	_ = messagebatchMixinFields0
+	messagebatchFields := schema.MessageBatch{}.Fields()
+	_ = messagebatchFields
+	// messagebatchDescCreatedAt is the schema descriptor for created_at field.
+	messagebatchDescCreatedAt := messagebatchMixinFields0[0].Descriptor()
+	// messagebatch.DefaultCreatedAt holds the default value on creation for the created_at field.
+	messagebatch.DefaultCreatedAt = messagebatchDescCreatedAt.Default.(func() time.Time)
+	// messagebatchDescUpdatedAt is the schema descriptor for updated_at field.
+	messagebatchDescUpdatedAt := messagebatchMixinFields0[1].Descriptor()
+	// messagebatch.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	messagebatch.DefaultUpdatedAt = messagebatchDescUpdatedAt.Default.(func() time.Time)
+	// messagebatch.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	messagebatch.UpdateDefaultUpdatedAt = messagebatchDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// messagebatchDescBatchID is the schema descriptor for batch_id field.
+	messagebatchDescBatchID := messagebatchFields[0].Descriptor()
+	// messagebatch.BatchIDValidator is a validator for the "batch_id" field. It is called by the builders before save.
+	messagebatch.BatchIDValidator = messagebatchDescBatchID.Validators[0].(func(string) error)
+	// messagebatchDescProcessingStatus is the schema descriptor for processing_status field.
+	messagebatchDescProcessingStatus := messagebatchFields[4].Descriptor()
+	// messagebatch.DefaultProcessingStatus holds the default value on creation for the processing_status field.
+	messagebatch.DefaultProcessingStatus = messagebatchDescProcessingStatus.Default.(string)
+	// messagebatch.ProcessingStatusValidator is a validator for the "processing_status" field. It is called by the builders before save.
+	messagebatch.ProcessingStatusValidator = messagebatchDescProcessingStatus.Validators[0].(func(string) error)
+	// messagebatchDescRequestCount is the schema descriptor for request_count field.
+	messagebatchDescRequestCount := messagebatchFields[5].Descriptor()
+	// messagebatch.DefaultRequestCount holds the default value on creation for the request_count field.
+	messagebatch.DefaultRequestCount = messagebatchDescRequestCount.Default.(int)
+	messagebatchitemMixin := schema.MessageBatchItem{}.Mixin()
+	messagebatchitemMixinFields0 := messagebatchitemMixin[0].Fields()
+	_ = messagebatchitemMixinFields0
+	messagebatchitemFields := schema.MessageBatchItem{}.Fields()
+	_ = messagebatchitemFields
+	// messagebatchitemDescCreatedAt is the schema descriptor for created_at field.
+	messagebatchitemDescCreatedAt := messagebatchitemMixinFields0[0].Descriptor()
+	// messagebatchitem.DefaultCreatedAt holds the default value on creation for the created_at field.
+	messagebatchitem.DefaultCreatedAt = messagebatchitemDescCreatedAt.Default.(func() time.Time)
+	// messagebatchitemDescUpdatedAt is the schema descriptor for updated_at field.
+	messagebatchitemDescUpdatedAt := messagebatchitemMixinFields0[1].Descriptor()
+	// messagebatchitem.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	messagebatchitem.DefaultUpdatedAt = messagebatchitemDescUpdatedAt.Default.(func() time.Time)
+	// messagebatchitem.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	messagebatchitem.UpdateDefaultUpdatedAt = messagebatchitemDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// messagebatchitemDescCustomID is the schema descriptor for custom_id field.
+	messagebatchitemDescCustomID := messagebatchitemFields[1].Descriptor()
+	// messagebatchitem.CustomIDValidator is a validator for the "custom_id" field. It is called by the builders before save.
+	messagebatchitem.CustomIDValidator = messagebatchitemDescCustomID.Validators[0].(func(string) error)
+	// messagebatchitemDescStatus is the schema descriptor for status field.
+	messagebatchitemDescStatus := messagebatchitemFields[3].Descriptor()
+	// messagebatchitem.DefaultStatus holds the default value on creation for the status field.
+	messagebatchitem.DefaultStatus = messagebatchitemDescStatus.Default.(string)
+	// messagebatchitem.StatusValidator is a validator for the "status" field. It is called by the builders before save.
+	messagebatchitem.StatusValidator = messagebatchitemDescStatus.Validators[0].(func(string) error)
+	// messagebatchitemDescAttempts is the schema descriptor for attempts field.
+	messagebatchitemDescAttempts := messagebatchitemFields[5].Descriptor()
+	// messagebatchitem.DefaultAttempts holds the default value on creation for the attempts field.
+	messagebatchitem.DefaultAttempts = messagebatchitemDescAttempts.Default.(int)
 	promocodeFields := schema.PromoCode{}.Fields()
 	_ = promocodeFields
 	// promocodeDescCode is the schema descriptor for code field.
@@ -1041,6 +1195,92 @@ func init() {
 	usersubscriptionDescAssignedAt := usersubscriptionFields[12].Descriptor()
 	// usersubscription.DefaultAssignedAt holds the default value on creation for the assigned_at field.
 	usersubscription.DefaultAssignedAt = usersubscriptionDescAssignedAt.Default.(func() time.Time)
+	webhookdeliveryMixin := schema.WebhookDelivery{}.Mixin()
+	webhookdeliveryMixinFields0 := webhookdeliveryMixin[0].Fields()
+	_ = webhookdeliveryMixinFields0
+	webhookdeliveryFields := schema.WebhookDelivery{}.Fields()
+	_ = webhookdeliveryFields
+	// webhookdeliveryDescCreatedAt is the schema descriptor for created_at field.
+	webhookdeliveryDescCreatedAt := webhookdeliveryMixinFields0[0].Descriptor()
+	// webhookdelivery.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhookdelivery.DefaultCreatedAt = webhookdeliveryDescCreatedAt.Default.(func() time.Time)
+	// webhookdeliveryDescUpdatedAt is the schema descriptor for updated_at field.
+	webhookdeliveryDescUpdatedAt := webhookdeliveryMixinFields0[1].Descriptor()
+	// webhookdelivery.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	webhookdelivery.DefaultUpdatedAt = webhookdeliveryDescUpdatedAt.Default.(func() time.Time)
+	// webhookdelivery.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	webhookdelivery.UpdateDefaultUpdatedAt = webhookdeliveryDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// webhookdeliveryDescEventID is the schema descriptor for event_id field.
+	webhookdeliveryDescEventID := webhookdeliveryFields[1].Descriptor()
+	// webhookdelivery.EventIDValidator is a validator for the "event_id" field. It is called by the builders before save.
+	webhookdelivery.EventIDValidator = webhookdeliveryDescEventID.Validators[0].(func(string) error)
+	// webhookdeliveryDescEventType is the schema descriptor for event_type field.
+	webhookdeliveryDescEventType := webhookdeliveryFields[2].Descriptor()
+	// webhookdelivery.EventTypeValidator is a validator for the "event_type" field. It is called by the builders before save.
+	webhookdelivery.EventTypeValidator = webhookdeliveryDescEventType.Validators[0].(func(string) error)
+	// webhookdeliveryDescStatus is the schema descriptor for status field.
+	webhookdeliveryDescStatus := webhookdeliveryFields[4].Descriptor()
+	// webhookdelivery.DefaultStatus holds the default value on creation for the status field.
+	webhookdelivery.DefaultStatus = webhookdeliveryDescStatus.Default.(string)
+	// webhookdelivery.StatusValidator is a validator for the "status" field. It is called by the builders before save.
+	webhookdelivery.StatusValidator = webhookdeliveryDescStatus.Validators[0].(func(string) error)
+	// webhookdeliveryDescAttempts is the schema descriptor for attempts field.
+	webhookdeliveryDescAttempts := webhookdeliveryFields[5].Descriptor()
+	// webhookdelivery.DefaultAttempts holds the default value on creation for the attempts field.
+	webhookdelivery.DefaultAttempts = webhookdeliveryDescAttempts.Default.(int)
+	webhookendpointMixin := schema.WebhookEndpoint{}.Mixin()
+	webhookendpointMixinFields0 := webhookendpointMixin[0].Fields()
+	_ = webhookendpointMixinFields0
+	webhookendpointFields := schema.WebhookEndpoint{}.Fields()
+	_ = webhookendpointFields
+	// webhookendpointDescCreatedAt is the schema descriptor for created_at field.
+	webhookendpointDescCreatedAt := webhookendpointMixinFields0[0].Descriptor()
+	// webhookendpoint.DefaultCreatedAt holds the default value on creation for the created_at field.
+	webhookendpoint.DefaultCreatedAt = webhookendpointDescCreatedAt.Default.(func() time.Time)
+	// webhookendpointDescUpdatedAt is the schema descriptor for updated_at field.
+	webhookendpointDescUpdatedAt := webhookendpointMixinFields0[1].Descriptor()
+	// webhookendpoint.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	webhookendpoint.DefaultUpdatedAt = webhookendpointDescUpdatedAt.Default.(func() time.Time)
+	// webhookendpoint.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	webhookendpoint.UpdateDefaultUpdatedAt = webhookendpointDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// webhookendpointDescName is the schema descriptor for name field.
+	webhookendpointDescName := webhookendpointFields[0].Descriptor()
+	// webhookendpoint.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	webhookendpoint.NameValidator = func() func(string) error {
+		validators := webhookendpointDescName.Validators
+		fns := [...]func(string) error{
+			validators[0].(func(string) error),
+			validators[1].(func(string) error),
+		}
+		return func(name string) error {
+			for _, fn := range fns {
+				if err := fn(name); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}()
+	// webhookendpointDescURL is the schema descriptor for url field.
+	webhookendpointDescURL := webhookendpointFields[1].Descriptor()
+	// webhookendpoint.URLValidator is a validator for the "url" field. It is called by the builders before save.
+	webhookendpoint.URLValidator = webhookendpointDescURL.Validators[0].(func(string) error)
+	// webhookendpointDescSecret is the schema descriptor for secret field.
+	webhookendpointDescSecret := webhookendpointFields[2].Descriptor()
+	// webhookendpoint.DefaultSecret holds the default value on creation for the secret field.
+	webhookendpoint.DefaultSecret = webhookendpointDescSecret.Default.(string)
+	// webhookendpoint.SecretValidator is a validator for the "secret" field. It is called by the builders before save.
+	webhookendpoint.SecretValidator = webhookendpointDescSecret.Validators[0].(func(string) error)
+	// webhookendpointDescFormat is the schema descriptor for format field.
+	webhookendpointDescFormat := webhookendpointFields[3].Descriptor()
+	// webhookendpoint.DefaultFormat holds the default value on creation for the format field.
+	webhookendpoint.DefaultFormat = webhookendpointDescFormat.Default.(string)
+	// webhookendpoint.FormatValidator is a validator for the "format" field. It is called by the builders before save.
+	webhookendpoint.FormatValidator = webhookendpointDescFormat.Validators[0].(func(string) error)
+	// webhookendpointDescEnabled is the schema descriptor for enabled field.
+	webhookendpointDescEnabled := webhookendpointFields[5].Descriptor()
+	// webhookendpoint.DefaultEnabled holds the default value on creation for the enabled field.
+	webhookendpoint.DefaultEnabled = webhookendpointDescEnabled.Default.(bool)
 }
 
 const (
@@ -0,0 +1,88 @@
+package schema
+
+import (
+	"encoding/json"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AdminAuditLog 定义管理后台审计日志的 schema。
+//
+// 每次修改类的管理 API 调用（POST/PUT/PATCH/DELETE）记录一条日志，
+// 包含操作者、路由、目标实体以及 Service 层提交的脱敏前后差异。
+// 这是一个只追加的表，不支持更新。
+type AdminAuditLog struct {
+	ent.Schema
+}
+
+// Annotations 返回 schema 的注解配置。
+func (AdminAuditLog) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "admin_audit_logs"},
+	}
+}
+
+// Fields 定义审计日志的字段。
+func (AdminAuditLog) Fields() []ent.Field {
+	return []ent.Field{
+		// 操作者：JWT 登录的管理员，或 Admin API Key（映射到首个管理员）
+		field.Int64("actor_user_id").
+			Optional().
+			Nillable(),
+		field.String("auth_method").
+			MaxLen(20).
+			Default(""),
+
+		// 请求信息
+		field.String("method").
+			MaxLen(10),
+		field.String("route").
+			MaxLen(255),
+		field.String("path").
+			SchemaType(map[string]string{dialect.Postgres: "text"}),
+		field.Int("status_code").
+			Default(0),
+
+		// 目标实体
+		field.String("target_type").
+			MaxLen(64).
+			Default(""),
+		field.String("target_id").
+			MaxLen(64).
+			Optional().
+			Nillable(),
+
+		// 脱敏后的字段级差异（[]AdminAuditChange）
+		field.JSON("changes", json.RawMessage{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}),
+
+		field.String("ip_address").
+			MaxLen(64).
+			Default(""),
+		field.String("user_agent").
+			MaxLen(512).
+			Optional().
+			Nillable(),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+	}
+}
+
+// Indexes 定义审计日志的索引。
+func (AdminAuditLog) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at"),
+		index.Fields("actor_user_id", "created_at"),
+		index.Fields("target_type", "target_id"),
+	}
+}
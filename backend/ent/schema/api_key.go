@@ -34,10 +34,28 @@ func (APIKey) Mixin() []ent.Mixin {
 func (APIKey) Fields() []ent.Field {
 	return []ent.Field{
 		field.Int64("user_id"),
+		// key 仅保留尚未迁移的历史明文 Key，首次使用时哈希并清空（见 migration 060）
 		field.String("key").
 			MaxLen(128).
-			NotEmpty().
-			Unique(),
+			Optional().
+			Nillable().
+			Unique().
+			Sensitive(),
+		// 可见前缀，用于认证时按前缀查找候选 Key 与展示
+		field.String("key_prefix").
+			MaxLen(64).
+			Default(""),
+		field.String("key_salt").
+			MaxLen(64).
+			Optional().
+			Nillable().
+			Sensitive(),
+		// 生成的 Key：HMAC-SHA256(key_salt, key) 的十六进制编码；自定义 Key：对该摘要再做 bcrypt
+		field.String("key_hash").
+			MaxLen(64).
+			Optional().
+			Nillable().
+			Sensitive(),
 		field.String("name").
 			MaxLen(100).
 			NotEmpty(),
@@ -70,6 +88,18 @@ func (APIKey) Fields() []ent.Field {
 			Optional().
 			Nillable().
 			Comment("Expiration time for this API key (null = never expires)"),
+
+		// ========== Rate limit fields (added by migration 056) ==========
+		// 0 = 继承分组默认值
+		field.Int("rpm_limit").
+			Default(0).
+			Comment("Requests per minute limit (0 = inherit group default)"),
+		field.Int("tpm_limit").
+			Default(0).
+			Comment("Tokens per minute limit (0 = inherit group default)"),
+		field.Int("daily_request_limit").
+			Default(0).
+			Comment("Daily request cap (0 = inherit group default)"),
 	}
 }
 
@@ -91,6 +121,7 @@ func (APIKey) Edges() []ent.Edge {
 func (APIKey) Indexes() []ent.Index {
 	return []ent.Index{
 		// key 字段已在 Fields() 中声明 Unique()，无需重复索引
+		index.Fields("key_prefix"),
 		index.Fields("user_id"),
 		index.Fields("group_id"),
 		index.Fields("status"),
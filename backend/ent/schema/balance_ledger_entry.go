@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// BalanceLedgerEntry 定义用户余额流水的 schema。
+//
+// 每次 users.balance 变更都在同一语句/事务内追加一条流水，记录变更类型、关联对象、
+// 变更前后余额与备注；对任一用户，流水 amount 之和应等于当前余额（由对账任务校验）。
+// 这是一个只追加的表，数据库触发器禁止 UPDATE/DELETE。
+type BalanceLedgerEntry struct {
+	ent.Schema
+}
+
+// Annotations 返回 schema 的注解配置。
+func (BalanceLedgerEntry) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "balance_ledger_entries"},
+	}
+}
+
+// Fields 定义余额流水的字段。
+func (BalanceLedgerEntry) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("user_id"),
+		// entry_type: initial / usage / redeem / promo / admin_adjust / adjustment
+		field.String("entry_type").
+			MaxLen(32),
+		// amount: 有符号变更额（入账为正，扣费为负）
+		field.Float("amount").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,8)"}),
+		field.Float("balance_before").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,8)"}),
+		field.Float("balance_after").
+			SchemaType(map[string]string{dialect.Postgres: "decimal(20,8)"}),
+
+		// reference_id: 关联对象 ID，含义取决于 entry_type
+		// （usage → usage_logs.id，redeem → redeem_codes.id，promo → promo_codes.id，admin_adjust → 操作管理员 users.id）
+		field.Int64("reference_id").
+			Optional().
+			Nillable(),
+		// api_key_id: 产生该笔扣费的 API Key（用于追溯 Key 配额消耗）
+		field.Int64("api_key_id").
+			Optional().
+			Nillable(),
+		field.String("note").
+			SchemaType(map[string]string{dialect.Postgres: "text"}).
+			Default(""),
+
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable().
+			SchemaType(map[string]string{dialect.Postgres: "timestamptz"}),
+	}
+}
+
+// Indexes 定义余额流水的索引。
+func (BalanceLedgerEntry) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "created_at"),
+		index.Fields("entry_type", "reference_id"),
+	}
+}
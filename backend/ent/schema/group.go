@@ -126,6 +126,37 @@ func (Group) Fields() []ent.Field {
 		field.Int("sort_order").
 			Default(0).
 			Comment("分组显示排序，数值越小越靠前"),
+
+		// API Key 默认限流 (added by migration 056)
+		field.Int("default_rpm_limit").
+			Default(0).
+			Comment("分组内 API Key 默认每分钟请求数上限（0 = 不限制）"),
+		field.Int("default_tpm_limit").
+			Default(0).
+			Comment("分组内 API Key 默认每分钟 token 数上限（0 = 不限制）"),
+		field.Int("default_daily_request_limit").
+			Default(0).
+			Comment("分组内 API Key 默认每日请求数上限（0 = 不限制）"),
+
+		// 响应缓存（确定性请求）
+		field.Bool("response_cache_enabled").
+			Default(false).
+			Comment("是否缓存 temperature=0 的确定性请求响应"),
+		field.Int("response_cache_ttl_seconds").
+			Default(0).
+			Comment("响应缓存过期时间（秒，0 = 使用全局默认值）"),
+
+		// Claude → OpenAI 协议转换兜底 (added by migration 061)
+		field.JSON("openai_fallback_model_mapping", map[string]string{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("Claude 模型 → OpenAI 模型映射：Claude 账号不可用时转换为 Responses API 请求 OpenAI 账号"),
+
+		// 分组请求策略 (added by migration 062)
+		field.JSON("request_policy", &domain.RequestPolicy{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}).
+			Comment("分组请求策略：max_tokens/thinking 预算上限、禁用工具、强制 temperature、合规系统提示词、拦截正则"),
 	}
 }
 
@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"github.com/Wei-Shaw/sub2api/ent/schema/mixins"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// MessageBatch 定义 Message Batches API 批次的 schema。
+//
+// 批次由用户通过 /v1/messages/batches 提交，其中每条请求存为一条 MessageBatchItem，
+// 由后台 worker 异步经正常网关调度执行；全部请求结束（或取消/过期）后批次进入 ended。
+type MessageBatch struct {
+	ent.Schema
+}
+
+// Annotations 返回 schema 的注解配置。
+func (MessageBatch) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "message_batches"},
+	}
+}
+
+// Mixin 返回该 schema 使用的混入组件。
+func (MessageBatch) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.TimeMixin{},
+	}
+}
+
+// Fields 定义批次实体的所有字段。
+func (MessageBatch) Fields() []ent.Field {
+	return []ent.Field{
+		// batch_id: 对外暴露的批次 ID（msgbatch_ 前缀）
+		field.String("batch_id").
+			MaxLen(64).
+			Unique(),
+		field.Int64("user_id"),
+		field.Int64("api_key_id"),
+		field.Int64("group_id").
+			Optional().
+			Nillable(),
+		// processing_status: in_progress / canceling / ended
+		field.String("processing_status").
+			MaxLen(20).
+			Default("in_progress"),
+		field.Int("request_count").
+			Default(0),
+		field.Time("expires_at"),
+		field.Time("cancel_initiated_at").
+			Optional().
+			Nillable(),
+		field.Time("ended_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes 定义数据库索引，优化查询性能。
+func (MessageBatch) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "created_at"),
+		index.Fields("processing_status"),
+		index.Fields("ended_at"),
+	}
+}
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"github.com/Wei-Shaw/sub2api/ent/schema/mixins"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// MessageBatchItem 定义批次内单条请求的 schema。
+//
+// worker 按 status/locked_until 领取 pending 请求并加租约，执行完成后写回结果；
+// 租约过期仍处于 processing 的请求（实例崩溃）会被重新领取。
+type MessageBatchItem struct {
+	ent.Schema
+}
+
+// Annotations 返回 schema 的注解配置。
+func (MessageBatchItem) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "message_batch_items"},
+	}
+}
+
+// Mixin 返回该 schema 使用的混入组件。
+func (MessageBatchItem) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.TimeMixin{},
+	}
+}
+
+// Fields 定义批次请求实体的所有字段。
+func (MessageBatchItem) Fields() []ent.Field {
+	return []ent.Field{
+		// batch_id: 所属批次的内部 ID（message_batches.id）
+		field.Int64("batch_id"),
+		field.String("custom_id").
+			MaxLen(64),
+		// params: Messages API 请求体（JSON）
+		field.Text("params"),
+		// status: pending / processing / succeeded / errored / canceled / expired
+		field.String("status").
+			MaxLen(20).
+			Default("pending"),
+		// result: 结束后的结果对象（JSON，对应结果文件中的 result 字段）
+		field.Text("result").
+			Optional().
+			Nillable(),
+		field.Int("attempts").
+			Default(0),
+		field.Int64("account_id").
+			Optional().
+			Nillable(),
+		field.Time("locked_until").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes 定义数据库索引，优化查询性能。
+func (MessageBatchItem) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("batch_id", "status"),
+		index.Fields("status", "locked_until"),
+	}
+}
@@ -0,0 +1,74 @@
+package schema
+
+import (
+	"github.com/Wei-Shaw/sub2api/ent/schema/mixins"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// WebhookDelivery 定义 Webhook 投递记录的 schema。
+//
+// 每个事件对每个订阅端点生成一条投递记录，后台 worker 按 next_attempt_at 领取并发送，
+// 失败时按指数退避重试，超过最大次数后标记为 failed。
+type WebhookDelivery struct {
+	ent.Schema
+}
+
+// Annotations 返回 schema 的注解配置。
+func (WebhookDelivery) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "webhook_deliveries"},
+	}
+}
+
+// Mixin 返回该 schema 使用的混入组件。
+func (WebhookDelivery) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.TimeMixin{},
+	}
+}
+
+// Fields 定义投递记录实体的所有字段。
+func (WebhookDelivery) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("endpoint_id"),
+		// event_id: 事件唯一 ID，同一事件投递到多个端点时相同，接收方可用于幂等
+		field.String("event_id").
+			MaxLen(64),
+		field.String("event_type").
+			MaxLen(64),
+		// payload: 已序列化的请求体（重试时原样发送）
+		field.Text("payload"),
+		// status: pending / success / failed
+		field.String("status").
+			MaxLen(20).
+			Default("pending"),
+		field.Int("attempts").
+			Default(0),
+		field.Time("next_attempt_at").
+			Optional().
+			Nillable(),
+		field.Int("last_status_code").
+			Optional().
+			Nillable(),
+		field.Text("last_error").
+			Optional().
+			Nillable(),
+		field.Time("delivered_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes 定义数据库索引，优化查询性能。
+func (WebhookDelivery) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("endpoint_id", "created_at"),
+		index.Fields("status", "next_attempt_at"),
+		index.Fields("created_at"),
+	}
+}
@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"github.com/Wei-Shaw/sub2api/ent/schema/mixins"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// WebhookEndpoint 定义出站 Webhook 端点的 schema。
+//
+// 端点订阅一组事件类型（空列表表示订阅全部事件），
+// 事件发生时按端点的 format 组装 JSON 请求体并使用 secret 进行 HMAC-SHA256 签名。
+type WebhookEndpoint struct {
+	ent.Schema
+}
+
+// Annotations 返回 schema 的注解配置。
+func (WebhookEndpoint) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "webhook_endpoints"},
+	}
+}
+
+// Mixin 返回该 schema 使用的混入组件。
+func (WebhookEndpoint) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.TimeMixin{},
+	}
+}
+
+// Fields 定义 Webhook 端点实体的所有字段。
+func (WebhookEndpoint) Fields() []ent.Field {
+	return []ent.Field{
+		// name: 端点名称，用于在界面中标识
+		field.String("name").
+			MaxLen(100).
+			NotEmpty(),
+
+		// url: 接收事件的 HTTP(S) 地址
+		field.Text("url").
+			NotEmpty(),
+
+		// secret: HMAC 签名密钥（接收方用于校验 X-Sub2API-Signature）
+		field.String("secret").
+			MaxLen(255).
+			Default("").
+			Sensitive(),
+
+		// format: 请求体格式
+		// - "generic": 标准事件 JSON（默认）
+		// - "slack" / "feishu" / "dingtalk": 对应 IM 机器人的消息格式
+		field.String("format").
+			MaxLen(20).
+			Default("generic"),
+
+		// events: 订阅的事件类型列表，空列表表示订阅全部事件
+		field.JSON("events", []string{}).
+			Optional().
+			SchemaType(map[string]string{dialect.Postgres: "jsonb"}),
+
+		// enabled: 是否启用
+		field.Bool("enabled").
+			Default(true),
+
+		// description: 端点描述
+		field.Text("description").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes 定义数据库索引，优化查询性能。
+func (WebhookEndpoint) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("enabled"),
+	}
+}
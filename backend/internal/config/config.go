@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -46,8 +47,13 @@ type Config struct {
 	Database     DatabaseConfig             `mapstructure:"database"`
 	Redis        RedisConfig                `mapstructure:"redis"`
 	Ops          OpsConfig                  `mapstructure:"ops"`
+	Metrics      MetricsConfig              `mapstructure:"metrics"`
+	Webhook      WebhookConfig              `mapstructure:"webhook"`
+	MessageBatch MessageBatchConfig         `mapstructure:"message_batch"`
+	Ledger       BalanceLedgerConfig        `mapstructure:"balance_ledger"`
 	JWT          JWTConfig                  `mapstructure:"jwt"`
 	Totp         TotpConfig                 `mapstructure:"totp"`
+	Credential   CredentialEncryptionConfig `mapstructure:"credential_encryption"`
 	LinuxDo      LinuxDoConnectConfig       `mapstructure:"linuxdo_connect"`
 	Default      DefaultConfig              `mapstructure:"default"`
 	RateLimit    RateLimitConfig            `mapstructure:"rate_limit"`
@@ -141,6 +147,30 @@ type PricingConfig struct {
 	UpdateIntervalHours int `mapstructure:"update_interval_hours"`
 	// 哈希校验间隔（分钟）
 	HashCheckIntervalMinutes int `mapstructure:"hash_check_interval_minutes"`
+	// 自定义模型价格，优先于 LiteLLM 价格表；
+	// 主要用于 compatible 平台上 LiteLLM 未收录的模型（如自托管 vLLM 模型）。
+	// 使用列表而非 map：viper 会按 "." 拆分 map 键，qwen2.5-72b-instruct 之类的模型名无法作为键
+	ModelOverrides []ModelPriceOverride `mapstructure:"model_overrides"`
+}
+
+// ModelOverride 按模型名（大小写不敏感）查找自定义价格
+func (p PricingConfig) ModelOverride(model string) (ModelPriceOverride, bool) {
+	model = strings.TrimSpace(model)
+	for _, override := range p.ModelOverrides {
+		if strings.EqualFold(strings.TrimSpace(override.Model), model) {
+			return override, true
+		}
+	}
+	return ModelPriceOverride{}, false
+}
+
+// ModelPriceOverride 单个模型的自定义价格（USD per million tokens）
+type ModelPriceOverride struct {
+	Model                string  `mapstructure:"model"`
+	InputPerMTok         float64 `mapstructure:"input_per_mtok"`
+	OutputPerMTok        float64 `mapstructure:"output_per_mtok"`
+	CacheReadPerMTok     float64 `mapstructure:"cache_read_per_mtok"`
+	CacheCreationPerMTok float64 `mapstructure:"cache_creation_per_mtok"`
 }
 
 type ServerConfig struct {
@@ -282,6 +312,12 @@ type GatewayConfig struct {
 	// Scheduling: 账号调度相关配置
 	Scheduling GatewaySchedulingConfig `mapstructure:"scheduling"`
 
+	// DigestSession: Gemini/Anthropic 摘要会话粘性存储配置
+	DigestSession GatewayDigestSessionConfig `mapstructure:"digest_session"`
+
+	// ResponseCache: 确定性请求（temperature=0）响应缓存配置，需在分组上单独开启
+	ResponseCache GatewayResponseCacheConfig `mapstructure:"response_cache"`
+
 	// TLSFingerprint: TLS指纹伪装配置
 	TLSFingerprint TLSFingerprintConfig `mapstructure:"tls_fingerprint"`
 }
@@ -310,6 +346,24 @@ type TLSProfileConfig struct {
 	PointFormats []uint8 `mapstructure:"point_formats"`
 }
 
+// GatewayDigestSessionConfig 摘要会话（基于内容摘要链的会话粘性）存储配置
+type GatewayDigestSessionConfig struct {
+	// Backend: 存储后端 memory（进程内，默认）/ redis（多副本共享）；run_mode=simple 时始终使用 memory
+	Backend string `mapstructure:"backend"`
+	// TTLSeconds: 会话条目过期时间（秒）
+	TTLSeconds int `mapstructure:"ttl_seconds"`
+}
+
+// GatewayResponseCacheConfig 响应缓存配置（仅对开启缓存的分组生效）
+type GatewayResponseCacheConfig struct {
+	// DefaultTTLSeconds: 分组未指定 TTL 时的缓存过期时间（秒）
+	DefaultTTLSeconds int `mapstructure:"default_ttl_seconds"`
+	// Discount: 缓存命中的计费折扣（0-1），命中费用 = 原始费用 × (1 - discount)
+	Discount float64 `mapstructure:"discount"`
+	// MaxEntryBytes: 单条缓存响应的最大字节数，超出时不缓存
+	MaxEntryBytes int `mapstructure:"max_entry_bytes"`
+}
+
 // GatewaySchedulingConfig accounts scheduling configuration.
 type GatewaySchedulingConfig struct {
 	// 粘性会话排队配置
@@ -351,6 +405,21 @@ type GatewaySchedulingConfig struct {
 	// 全量重建周期配置
 	// 全量重建周期（秒），0 表示禁用
 	FullRebuildIntervalSeconds int `mapstructure:"full_rebuild_interval_seconds"`
+
+	// 账号满载时等待请求的公平排队
+	FairQueue GatewayFairQueueConfig `mapstructure:"fair_queue"`
+}
+
+// GatewayFairQueueConfig 账号槽位公平排队配置
+type GatewayFairQueueConfig struct {
+	// Enabled 启用后等待账号槽位的请求按流（用户或 API Key）加权公平排队，关闭时退回退避轮询（默认关闭）
+	Enabled bool `mapstructure:"enabled"`
+	// Key 公平粒度: "user"(默认) | "api_key"
+	Key string `mapstructure:"key"`
+	// WeightSource 流权重来源: "none"(默认，等权) | "user_concurrency"(用户并发上限)
+	WeightSource string `mapstructure:"weight_source"`
+	// MaxWeight 单个流的权重上限，避免高并发用户完全压制其他用户
+	MaxWeight int `mapstructure:"max_weight"`
 }
 
 func (s *ServerConfig) Address() string {
@@ -455,6 +524,68 @@ type OpsConfig struct {
 	Aggregation OpsAggregationConfig `mapstructure:"aggregation"`
 }
 
+// MetricsConfig Prometheus 指标导出配置
+type MetricsConfig struct {
+	// Enabled 是否暴露 Prometheus 指标端点（默认关闭）
+	Enabled bool `mapstructure:"enabled"`
+	// Path 指标端点路径，默认 /metrics
+	Path string `mapstructure:"path"`
+	// AuthToken 可选的 Bearer Token；非空时抓取请求必须携带 Authorization: Bearer <token>
+	AuthToken string `mapstructure:"auth_token"`
+	// AccountConcurrency 是否在抓取时采集每个可调度账号的并发槽位占用（会查询数据库与 Redis）
+	AccountConcurrency bool `mapstructure:"account_concurrency"`
+}
+
+// WebhookConfig 出站 Webhook 投递配置（端点本身在管理后台维护）
+type WebhookConfig struct {
+	// Enabled 是否启用 Webhook 事件生成与投递
+	Enabled bool `mapstructure:"enabled"`
+	// WorkerIntervalSeconds 投递 worker 轮询间隔（秒）
+	WorkerIntervalSeconds int `mapstructure:"worker_interval_seconds"`
+	// TimeoutSeconds 单次投递的 HTTP 超时（秒）
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+	// MaxAttempts 单条投递最大尝试次数（含首次），超过后标记为 failed
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// RetryBackoffSeconds 重试退避基数（秒），第 n 次重试等待 base * 2^(n-1)
+	RetryBackoffSeconds int `mapstructure:"retry_backoff_seconds"`
+	// LowBalanceThreshold 用户余额由高于阈值降至阈值及以下时触发 user.balance_low 事件（<=0 关闭）
+	LowBalanceThreshold float64 `mapstructure:"low_balance_threshold"`
+	// DeliveryRetentionDays 投递记录保留天数（0 表示不清理）
+	DeliveryRetentionDays int `mapstructure:"delivery_retention_days"`
+}
+
+// MessageBatchConfig Message Batches API（/v1/messages/batches）异步执行配置
+type MessageBatchConfig struct {
+	// Enabled 是否启用批处理接口与后台执行 worker
+	Enabled bool `mapstructure:"enabled"`
+	// WorkerIntervalSeconds worker 轮询间隔（秒）
+	WorkerIntervalSeconds int `mapstructure:"worker_interval_seconds"`
+	// Concurrency 单实例同时执行的批处理请求数上限
+	Concurrency int `mapstructure:"concurrency"`
+	// MaxRequestsPerBatch 单个批次最多包含的请求数
+	MaxRequestsPerBatch int `mapstructure:"max_requests_per_batch"`
+	// MaxAttempts 单条请求在无可用账号/上游可重试错误时的最大尝试次数
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// RequestTimeoutSeconds 单条请求的上游超时（秒）
+	RequestTimeoutSeconds int `mapstructure:"request_timeout_seconds"`
+	// Discount 批处理折扣（0-1），实际扣费 = 原费用 * (1 - discount)
+	Discount float64 `mapstructure:"discount"`
+	// ExpirationHours 批次过期时间（小时），到期仍未执行的请求标记为 expired
+	ExpirationHours int `mapstructure:"expiration_hours"`
+	// RetentionDays 已结束批次及结果的保留天数（0 表示不清理）
+	RetentionDays int `mapstructure:"retention_days"`
+}
+
+// BalanceLedgerConfig 余额流水对账配置
+type BalanceLedgerConfig struct {
+	// ReconcileIntervalMinutes 对账任务间隔（分钟），0 表示关闭定时对账（管理接口仍可手动触发）
+	ReconcileIntervalMinutes int `mapstructure:"reconcile_interval_minutes"`
+	// DriftTolerance 允许的 |余额 - 流水合计| 误差，超过即视为漂移
+	DriftTolerance float64 `mapstructure:"drift_tolerance"`
+	// MaxReportedUsers 单次对账最多报告的漂移用户数
+	MaxReportedUsers int `mapstructure:"max_reported_users"`
+}
+
 type OpsCleanupConfig struct {
 	Enabled  bool   `mapstructure:"enabled"`
 	Schedule string `mapstructure:"schedule"`
@@ -498,6 +629,22 @@ type TotpConfig struct {
 	EncryptionKeyConfigured bool `mapstructure:"-"`
 }
 
+// CredentialEncryptionConfig 上游账号凭证加密配置
+//
+// 账号凭证中的敏感字段（access_token、refresh_token、api_key 等）使用信封加密存储：
+// 每个字段值使用随机数据密钥（DEK）加密，DEK 再由 Keys 中的主密钥包裹。
+// 轮换主密钥时新增一个密钥并切换 ActiveKeyID，旧密钥需保留到后台重新加密任务完成。
+type CredentialEncryptionConfig struct {
+	// ActiveKeyID 当前用于加密的主密钥 ID；为空时新写入的凭证保持明文（已有密文仍可解密）
+	ActiveKeyID string `mapstructure:"active_key_id"`
+	// Keys 主密钥 ID -> AES-256 密钥（32 字节 hex 编码）；ID 仅允许小写字母、数字、"-"、"_"
+	Keys map[string]string `mapstructure:"keys"`
+	// ReencryptIntervalMinutes 后台重新加密任务间隔（分钟），0 表示关闭
+	ReencryptIntervalMinutes int `mapstructure:"reencrypt_interval_minutes"`
+	// ReencryptBatchSize 每批重新加密的账号数
+	ReencryptBatchSize int `mapstructure:"reencrypt_batch_size"`
+}
+
 type TurnstileConfig struct {
 	Required bool `mapstructure:"required"`
 }
@@ -807,6 +954,42 @@ func setDefaults() {
 	// TTL should be slightly larger than collection interval (1m) to maximize cross-replica cache hits.
 	viper.SetDefault("ops.metrics_collector_cache.ttl", 65*time.Second)
 
+	// Metrics (Prometheus)
+	viper.SetDefault("metrics.enabled", false)
+	viper.SetDefault("metrics.path", "/metrics")
+	viper.SetDefault("metrics.auth_token", "")
+	viper.SetDefault("metrics.account_concurrency", true)
+
+	// Webhook
+	viper.SetDefault("webhook.enabled", true)
+	viper.SetDefault("webhook.worker_interval_seconds", 5)
+	viper.SetDefault("webhook.timeout_seconds", 10)
+	viper.SetDefault("webhook.max_attempts", 6)
+	viper.SetDefault("webhook.retry_backoff_seconds", 30)
+	viper.SetDefault("webhook.low_balance_threshold", 1.0)
+	viper.SetDefault("webhook.delivery_retention_days", 30)
+
+	// Balance ledger
+	viper.SetDefault("balance_ledger.reconcile_interval_minutes", 60)
+	viper.SetDefault("balance_ledger.drift_tolerance", 0.000001)
+	viper.SetDefault("balance_ledger.max_reported_users", 100)
+
+	// Credential encryption
+	viper.SetDefault("credential_encryption.active_key_id", "")
+	viper.SetDefault("credential_encryption.reencrypt_interval_minutes", 10)
+	viper.SetDefault("credential_encryption.reencrypt_batch_size", 100)
+
+	// Message Batches
+	viper.SetDefault("message_batch.enabled", true)
+	viper.SetDefault("message_batch.worker_interval_seconds", 5)
+	viper.SetDefault("message_batch.concurrency", 8)
+	viper.SetDefault("message_batch.max_requests_per_batch", 10000)
+	viper.SetDefault("message_batch.max_attempts", 3)
+	viper.SetDefault("message_batch.request_timeout_seconds", 600)
+	viper.SetDefault("message_batch.discount", 0.5)
+	viper.SetDefault("message_batch.expiration_hours", 24)
+	viper.SetDefault("message_batch.retention_days", 29)
+
 	// JWT
 	viper.SetDefault("jwt.secret", "")
 	viper.SetDefault("jwt.expire_hour", 24)
@@ -912,6 +1095,19 @@ func setDefaults() {
 	viper.SetDefault("gateway.scheduling.outbox_lag_rebuild_failures", 3)
 	viper.SetDefault("gateway.scheduling.outbox_backlog_rebuild_rows", 10000)
 	viper.SetDefault("gateway.scheduling.full_rebuild_interval_seconds", 300)
+	// 公平排队（默认关闭，需显式开启）：账号满载时等待请求按用户或 API Key 加权公平获取槽位，
+	// 关闭时保持原有的退避轮询；队列按账号划分，原因见 service.FairQueueService
+	viper.SetDefault("gateway.scheduling.fair_queue.enabled", false)
+	viper.SetDefault("gateway.scheduling.fair_queue.key", "user")
+	viper.SetDefault("gateway.scheduling.fair_queue.weight_source", "none")
+	viper.SetDefault("gateway.scheduling.fair_queue.max_weight", 16)
+	// 摘要会话粘性存储（默认进程内存储）
+	viper.SetDefault("gateway.digest_session.backend", "memory")
+	viper.SetDefault("gateway.digest_session.ttl_seconds", 300)
+	// 确定性请求响应缓存（需在分组上单独开启）
+	viper.SetDefault("gateway.response_cache.default_ttl_seconds", 3600)
+	viper.SetDefault("gateway.response_cache.discount", 0.9)
+	viper.SetDefault("gateway.response_cache.max_entry_bytes", 1024*1024)
 	// TLS指纹伪装配置（默认关闭，需要账号级别单独启用）
 	viper.SetDefault("gateway.tls_fingerprint.enabled", true)
 	viper.SetDefault("concurrency.ping_interval", 10)
@@ -958,6 +1154,20 @@ func (c *Config) Validate() error {
 	if c.JWT.RefreshWindowMinutes < 0 {
 		return fmt.Errorf("jwt.refresh_window_minutes must be non-negative")
 	}
+	seenPriceOverrides := make(map[string]struct{}, len(c.Pricing.ModelOverrides))
+	for i, override := range c.Pricing.ModelOverrides {
+		model := strings.ToLower(strings.TrimSpace(override.Model))
+		if model == "" {
+			return fmt.Errorf("pricing.model_overrides[%d].model is required", i)
+		}
+		if _, ok := seenPriceOverrides[model]; ok {
+			return fmt.Errorf("pricing.model_overrides[%d]: duplicate model %q", i, override.Model)
+		}
+		seenPriceOverrides[model] = struct{}{}
+		if override.InputPerMTok < 0 || override.OutputPerMTok < 0 || override.CacheReadPerMTok < 0 || override.CacheCreationPerMTok < 0 {
+			return fmt.Errorf("pricing.model_overrides[%d]: prices must be non-negative", i)
+		}
+	}
 	if c.Security.CSP.Enabled && strings.TrimSpace(c.Security.CSP.Policy) == "" {
 		return fmt.Errorf("security.csp.policy is required when CSP is enabled")
 	}
@@ -1170,6 +1380,23 @@ func (c *Config) Validate() error {
 				ConnectionPoolIsolationProxy, ConnectionPoolIsolationAccount, ConnectionPoolIsolationAccountProxy)
 		}
 	}
+	switch strings.ToLower(strings.TrimSpace(c.Gateway.DigestSession.Backend)) {
+	case "", "memory", "redis":
+	default:
+		return fmt.Errorf("gateway.digest_session.backend must be one of: memory/redis")
+	}
+	if c.Gateway.DigestSession.TTLSeconds < 0 {
+		return fmt.Errorf("gateway.digest_session.ttl_seconds must be non-negative")
+	}
+	if c.Gateway.ResponseCache.DefaultTTLSeconds < 0 {
+		return fmt.Errorf("gateway.response_cache.default_ttl_seconds must be non-negative")
+	}
+	if c.Gateway.ResponseCache.Discount < 0 || c.Gateway.ResponseCache.Discount > 1 {
+		return fmt.Errorf("gateway.response_cache.discount must be between 0 and 1")
+	}
+	if c.Gateway.ResponseCache.MaxEntryBytes < 0 {
+		return fmt.Errorf("gateway.response_cache.max_entry_bytes must be non-negative")
+	}
 	if c.Gateway.MaxIdleConns <= 0 {
 		return fmt.Errorf("gateway.max_idle_conns must be positive")
 	}
@@ -1250,6 +1477,19 @@ func (c *Config) Validate() error {
 	if c.Gateway.Scheduling.OutboxBacklogRebuildRows < 0 {
 		return fmt.Errorf("gateway.scheduling.outbox_backlog_rebuild_rows must be non-negative")
 	}
+	switch c.Gateway.Scheduling.FairQueue.Key {
+	case "", "user", "api_key":
+	default:
+		return fmt.Errorf("gateway.scheduling.fair_queue.key must be one of: user, api_key")
+	}
+	switch c.Gateway.Scheduling.FairQueue.WeightSource {
+	case "", "none", "user_concurrency":
+	default:
+		return fmt.Errorf("gateway.scheduling.fair_queue.weight_source must be one of: none, user_concurrency")
+	}
+	if c.Gateway.Scheduling.FairQueue.MaxWeight < 0 {
+		return fmt.Errorf("gateway.scheduling.fair_queue.max_weight must be non-negative")
+	}
 	if c.Gateway.Scheduling.FullRebuildIntervalSeconds < 0 {
 		return fmt.Errorf("gateway.scheduling.full_rebuild_interval_seconds must be non-negative")
 	}
@@ -1273,6 +1513,70 @@ func (c *Config) Validate() error {
 	if c.Ops.Cleanup.Enabled && strings.TrimSpace(c.Ops.Cleanup.Schedule) == "" {
 		return fmt.Errorf("ops.cleanup.schedule is required when ops.cleanup.enabled=true")
 	}
+	if c.Metrics.Enabled {
+		path := strings.TrimSpace(c.Metrics.Path)
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("metrics.path must start with /")
+		}
+		if strings.HasPrefix(path, "/api/") || strings.HasPrefix(path, "/v1/") || strings.HasPrefix(path, "/v1beta/") || strings.HasPrefix(path, "/antigravity/") {
+			return fmt.Errorf("metrics.path must not overlap with API routes")
+		}
+	}
+	if c.Webhook.Enabled {
+		if c.Webhook.WorkerIntervalSeconds <= 0 {
+			return fmt.Errorf("webhook.worker_interval_seconds must be positive")
+		}
+		if c.Webhook.TimeoutSeconds <= 0 {
+			return fmt.Errorf("webhook.timeout_seconds must be positive")
+		}
+		if c.Webhook.MaxAttempts <= 0 {
+			return fmt.Errorf("webhook.max_attempts must be positive")
+		}
+		if c.Webhook.RetryBackoffSeconds <= 0 {
+			return fmt.Errorf("webhook.retry_backoff_seconds must be positive")
+		}
+	}
+	if c.Webhook.DeliveryRetentionDays < 0 {
+		return fmt.Errorf("webhook.delivery_retention_days must be non-negative")
+	}
+	if c.MessageBatch.Enabled {
+		if c.MessageBatch.WorkerIntervalSeconds <= 0 {
+			return fmt.Errorf("message_batch.worker_interval_seconds must be positive")
+		}
+		if c.MessageBatch.Concurrency <= 0 {
+			return fmt.Errorf("message_batch.concurrency must be positive")
+		}
+		if c.MessageBatch.MaxRequestsPerBatch <= 0 {
+			return fmt.Errorf("message_batch.max_requests_per_batch must be positive")
+		}
+		if c.MessageBatch.MaxAttempts <= 0 {
+			return fmt.Errorf("message_batch.max_attempts must be positive")
+		}
+		if c.MessageBatch.RequestTimeoutSeconds <= 0 {
+			return fmt.Errorf("message_batch.request_timeout_seconds must be positive")
+		}
+		if c.MessageBatch.ExpirationHours <= 0 {
+			return fmt.Errorf("message_batch.expiration_hours must be positive")
+		}
+	}
+	if c.MessageBatch.Discount < 0 || c.MessageBatch.Discount > 1 {
+		return fmt.Errorf("message_batch.discount must be between 0 and 1")
+	}
+	if c.MessageBatch.RetentionDays < 0 {
+		return fmt.Errorf("message_batch.retention_days must be non-negative")
+	}
+	if c.Ledger.ReconcileIntervalMinutes < 0 {
+		return fmt.Errorf("balance_ledger.reconcile_interval_minutes must be non-negative")
+	}
+	if c.Ledger.DriftTolerance < 0 {
+		return fmt.Errorf("balance_ledger.drift_tolerance must be non-negative")
+	}
+	if c.Ledger.MaxReportedUsers < 0 {
+		return fmt.Errorf("balance_ledger.max_reported_users must be non-negative")
+	}
+	if err := validateCredentialEncryption(c.Credential); err != nil {
+		return err
+	}
 	if c.Concurrency.PingInterval < 5 || c.Concurrency.PingInterval > 30 {
 		return fmt.Errorf("concurrency.ping_interval must be between 5-30 seconds")
 	}
@@ -1423,3 +1727,29 @@ func warnIfInsecureURL(field, raw string) {
 		log.Printf("Warning: %s uses http scheme; use https in production to avoid token leakage.", field)
 	}
 }
+
+var credentialKeyIDPattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+func validateCredentialEncryption(c CredentialEncryptionConfig) error {
+	for id, key := range c.Keys {
+		if !credentialKeyIDPattern.MatchString(id) {
+			return fmt.Errorf("credential_encryption.keys: invalid key id %q (use 1-32 chars of a-z, 0-9, '-', '_')", id)
+		}
+		raw, err := hex.DecodeString(strings.TrimSpace(key))
+		if err != nil || len(raw) != 32 {
+			return fmt.Errorf("credential_encryption.keys.%s must be 32 bytes (64 hex chars)", id)
+		}
+	}
+	if c.ActiveKeyID != "" {
+		if _, ok := c.Keys[c.ActiveKeyID]; !ok {
+			return fmt.Errorf("credential_encryption.active_key_id %q not found in credential_encryption.keys", c.ActiveKeyID)
+		}
+	}
+	if c.ReencryptIntervalMinutes < 0 {
+		return fmt.Errorf("credential_encryption.reencrypt_interval_minutes must be non-negative")
+	}
+	if c.ReencryptBatchSize < 0 {
+		return fmt.Errorf("credential_encryption.reencrypt_batch_size must be non-negative")
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -56,6 +58,35 @@ func TestLoadDefaultSchedulingConfig(t *testing.T) {
 	}
 }
 
+func TestLoadDefaultFairQueueConfig(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	fq := cfg.Gateway.Scheduling.FairQueue
+	if fq.Enabled {
+		t.Fatalf("FairQueue.Enabled = true, want false (opt-in)")
+	}
+	if fq.Key != "user" {
+		t.Fatalf("FairQueue.Key = %q, want user", fq.Key)
+	}
+	if fq.WeightSource != "none" {
+		t.Fatalf("FairQueue.WeightSource = %q, want none", fq.WeightSource)
+	}
+	if fq.MaxWeight != 16 {
+		t.Fatalf("FairQueue.MaxWeight = %d, want 16", fq.MaxWeight)
+	}
+
+	cfg.Gateway.Scheduling.FairQueue.Key = "ip"
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "gateway.scheduling.fair_queue.key") {
+		t.Fatalf("Validate() expected fair_queue.key error, got: %v", err)
+	}
+}
+
 func TestLoadSchedulingConfigFromEnv(t *testing.T) {
 	viper.Reset()
 	t.Setenv("GATEWAY_SCHEDULING_STICKY_SESSION_MAX_WAITING", "5")
@@ -837,6 +868,16 @@ func TestValidateConfigErrors(t *testing.T) {
 			mutate:  func(c *Config) { c.Ops.Cleanup.MinuteMetricsRetentionDays = -1 },
 			wantErr: "ops.cleanup.minute_metrics_retention_days",
 		},
+		{
+			name:    "metrics path prefix",
+			mutate:  func(c *Config) { c.Metrics.Enabled = true; c.Metrics.Path = "metrics" },
+			wantErr: "metrics.path must start with /",
+		},
+		{
+			name:    "metrics path overlaps api",
+			mutate:  func(c *Config) { c.Metrics.Enabled = true; c.Metrics.Path = "/v1/metrics" },
+			wantErr: "metrics.path must not overlap",
+		},
 	}
 
 	for _, tt := range cases {
@@ -850,3 +891,105 @@ func TestValidateConfigErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateCredentialEncryptionConfig(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if cfg.Credential.ActiveKeyID != "" {
+		t.Fatalf("Credential.ActiveKeyID = %q, want empty by default", cfg.Credential.ActiveKeyID)
+	}
+
+	cfg.Credential.Keys = map[string]string{"k1": strings.Repeat("ab", 32)}
+	cfg.Credential.ActiveKeyID = "k2"
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "credential_encryption.active_key_id") {
+		t.Fatalf("Validate() expected active_key_id error, got: %v", err)
+	}
+
+	cfg.Credential.ActiveKeyID = "k1"
+	cfg.Credential.Keys["k1"] = "short"
+	err = cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "credential_encryption.keys.k1") {
+		t.Fatalf("Validate() expected key length error, got: %v", err)
+	}
+
+	cfg.Credential.Keys["k1"] = strings.Repeat("ab", 32)
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestLoadPricingModelOverridesWithDottedModelNames(t *testing.T) {
+	viper.Reset()
+
+	dir := t.TempDir()
+	content := `pricing:
+  model_overrides:
+    - model: qwen2.5-72b-instruct
+      input_per_mtok: 0.4
+      output_per_mtok: 1.2
+    - model: GLM-4.6
+      input_per_mtok: 0.6
+      output_per_mtok: 2.2
+      cache_read_per_mtok: 0.11
+`
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("DATA_DIR", dir)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.Pricing.ModelOverrides) != 2 {
+		t.Fatalf("ModelOverrides = %+v, want 2 entries", cfg.Pricing.ModelOverrides)
+	}
+
+	qwen, ok := cfg.Pricing.ModelOverride("qwen2.5-72b-instruct")
+	if !ok || qwen.InputPerMTok != 0.4 || qwen.OutputPerMTok != 1.2 {
+		t.Fatalf("ModelOverride(qwen2.5-72b-instruct) = %+v, %v", qwen, ok)
+	}
+	glm, ok := cfg.Pricing.ModelOverride("glm-4.6")
+	if !ok || glm.InputPerMTok != 0.6 || glm.CacheReadPerMTok != 0.11 {
+		t.Fatalf("ModelOverride(glm-4.6) = %+v, %v", glm, ok)
+	}
+	for _, truncated := range []string{"qwen2", "glm-4"} {
+		if _, ok := cfg.Pricing.ModelOverride(truncated); ok {
+			t.Fatalf("ModelOverride(%q) should not match", truncated)
+		}
+	}
+}
+
+func TestValidatePricingModelOverrides(t *testing.T) {
+	viper.Reset()
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	cfg.Pricing.ModelOverrides = []ModelPriceOverride{{InputPerMTok: 1}}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "pricing.model_overrides[0].model") {
+		t.Fatalf("Validate() expected missing model error, got: %v", err)
+	}
+
+	cfg.Pricing.ModelOverrides = []ModelPriceOverride{{Model: "glm-4.6"}, {Model: "GLM-4.6"}}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "duplicate model") {
+		t.Fatalf("Validate() expected duplicate model error, got: %v", err)
+	}
+
+	cfg.Pricing.ModelOverrides = []ModelPriceOverride{{Model: "glm-4.6", InputPerMTok: -1}}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "non-negative") {
+		t.Fatalf("Validate() expected negative price error, got: %v", err)
+	}
+
+	cfg.Pricing.ModelOverrides = []ModelPriceOverride{{Model: "glm-4.6", InputPerMTok: 0.6}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+}
@@ -22,6 +22,7 @@ const (
 	PlatformOpenAI      = "openai"
 	PlatformGemini      = "gemini"
 	PlatformAntigravity = "antigravity"
+	PlatformCompatible  = "compatible" // 任意 OpenAI Chat Completions 兼容上游（DeepSeek、Qwen、vLLM 等）
 )
 
 // Account type constants
@@ -30,6 +31,8 @@ const (
 	AccountTypeSetupToken = "setup-token" // Setup Token类型账号（inference only scope）
 	AccountTypeAPIKey     = "apikey"      // API Key类型账号
 	AccountTypeUpstream   = "upstream"    // 上游透传类型账号（通过 Base URL + API Key 连接上游）
+	AccountTypeBedrock    = "bedrock"     // AWS Bedrock 类型账号（AWS AccessKey + SigV4 签名，仅 Anthropic 平台）
+	AccountTypeVertex     = "vertex"      // Google Vertex AI 类型账号（服务账号 JSON，Anthropic / Gemini 平台）
 )
 
 // Redeem type constants
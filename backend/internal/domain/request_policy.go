@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+const (
+	requestPolicyMaxBlockedTools    = 100
+	requestPolicyMaxBlockedPatterns = 50
+	requestPolicyMaxSystemPrompt    = 32 * 1024
+)
+
+// 敏感信息命中后的处理方式
+const (
+	RedactionActionMask  = "mask"  // 替换为 [REDACTED:<detector>] 后继续转发
+	RedactionActionBlock = "block" // 拒绝请求
+)
+
+// 内置敏感信息检测器
+const (
+	RedactionDetectorAWSKey     = "aws_key"     // AWS Access Key ID / Secret Access Key
+	RedactionDetectorPrivateKey = "private_key" // PEM 私钥
+	RedactionDetectorAPIKey     = "api_key"     // 常见平台 API Key / Token（sk-、ghp_、xox 等）
+	RedactionDetectorEmail      = "email"
+	RedactionDetectorPhone      = "phone"
+)
+
+// RedactionDetectors 全部内置检测器
+var RedactionDetectors = []string{
+	RedactionDetectorAWSKey,
+	RedactionDetectorPrivateKey,
+	RedactionDetectorAPIKey,
+	RedactionDetectorEmail,
+	RedactionDetectorPhone,
+}
+
+// RequestPolicy 分组级请求策略，在请求转发到上游前执行。
+// 所有字段均为可选，零值表示不启用对应规则。
+type RequestPolicy struct {
+	// MaxTokens 输出 token 上限（Claude max_tokens / Responses max_output_tokens / Gemini maxOutputTokens），超出时下调
+	MaxTokens int `json:"max_tokens,omitempty"`
+	// MaxThinkingBudgetTokens thinking 预算上限（Claude thinking.budget_tokens / Gemini thinkingConfig.thinkingBudget）
+	MaxThinkingBudgetTokens int `json:"max_thinking_budget_tokens,omitempty"`
+	// BlockedTools 禁止声明的工具名（大小写不敏感，支持 * 结尾的前缀通配），命中时拒绝请求
+	BlockedTools []string `json:"blocked_tools,omitempty"`
+	// ForceTemperature 强制覆盖 temperature（Claude 开启 thinking 时不覆盖，上游要求 temperature=1）
+	ForceTemperature *float64 `json:"force_temperature,omitempty"`
+	// SystemPrompt 前置到请求系统提示词之前的合规提示词
+	SystemPrompt string `json:"system_prompt,omitempty"`
+	// BlockedPatterns 正则表达式列表，请求文本（系统提示词与消息）命中任一规则时拒绝请求
+	BlockedPatterns []string `json:"blocked_patterns,omitempty"`
+	// Redaction 消息文本的敏感信息（PII / 密钥）检测，nil 表示不启用
+	Redaction *RedactionPolicy `json:"redaction,omitempty"`
+}
+
+// RedactionPolicy 敏感信息检测配置
+type RedactionPolicy struct {
+	// Detectors 启用的内置检测器，见 RedactionDetectors
+	Detectors []string `json:"detectors,omitempty"`
+	// CustomPatterns 自定义检测正则
+	CustomPatterns []RedactionPattern `json:"custom_patterns,omitempty"`
+	// Action 命中后的处理方式：mask（默认）| block
+	Action string `json:"action,omitempty"`
+}
+
+// RedactionPattern 自定义检测器，Name 用于掩码标记与运维日志统计
+type RedactionPattern struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+}
+
+// IsEmpty 判断是否未启用任何检测器
+func (r *RedactionPolicy) IsEmpty() bool {
+	return r == nil || (len(r.Detectors) == 0 && len(r.CustomPatterns) == 0)
+}
+
+// IsEmpty 判断策略是否未配置任何规则
+func (p *RequestPolicy) IsEmpty() bool {
+	return p == nil ||
+		(p.MaxTokens <= 0 && p.MaxThinkingBudgetTokens <= 0 && len(p.BlockedTools) == 0 &&
+			p.ForceTemperature == nil && p.SystemPrompt == "" && len(p.BlockedPatterns) == 0 && p.Redaction.IsEmpty())
+}
+
+// NormalizeAndValidate 去除空白项并校验规则，返回规范化后的副本
+func (p RequestPolicy) NormalizeAndValidate() (RequestPolicy, error) {
+	normalized := RequestPolicy{
+		MaxTokens:               p.MaxTokens,
+		MaxThinkingBudgetTokens: p.MaxThinkingBudgetTokens,
+		ForceTemperature:        p.ForceTemperature,
+		SystemPrompt:            strings.TrimSpace(p.SystemPrompt),
+	}
+	if normalized.MaxTokens < 0 {
+		return RequestPolicy{}, invalidRequestPolicy("max_tokens must be >= 0")
+	}
+	if normalized.MaxThinkingBudgetTokens < 0 {
+		return RequestPolicy{}, invalidRequestPolicy("max_thinking_budget_tokens must be >= 0")
+	}
+	if t := normalized.ForceTemperature; t != nil && (*t < 0 || *t > 2) {
+		return RequestPolicy{}, invalidRequestPolicy("force_temperature must be between 0 and 2")
+	}
+	if len(normalized.SystemPrompt) > requestPolicyMaxSystemPrompt {
+		return RequestPolicy{}, invalidRequestPolicy(fmt.Sprintf("system_prompt must be at most %d bytes", requestPolicyMaxSystemPrompt))
+	}
+
+	for _, name := range p.BlockedTools {
+		if name = strings.TrimSpace(name); name != "" {
+			normalized.BlockedTools = append(normalized.BlockedTools, name)
+		}
+	}
+	if len(normalized.BlockedTools) > requestPolicyMaxBlockedTools {
+		return RequestPolicy{}, invalidRequestPolicy(fmt.Sprintf("blocked_tools must have at most %d entries", requestPolicyMaxBlockedTools))
+	}
+
+	for _, pattern := range p.BlockedPatterns {
+		if strings.TrimSpace(pattern) == "" {
+			continue
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return RequestPolicy{}, invalidRequestPolicy(fmt.Sprintf("invalid blocked pattern %q: %v", pattern, err))
+		}
+		normalized.BlockedPatterns = append(normalized.BlockedPatterns, pattern)
+	}
+	if len(normalized.BlockedPatterns) > requestPolicyMaxBlockedPatterns {
+		return RequestPolicy{}, invalidRequestPolicy(fmt.Sprintf("blocked_patterns must have at most %d entries", requestPolicyMaxBlockedPatterns))
+	}
+
+	if p.Redaction != nil {
+		redaction, err := p.Redaction.normalizeAndValidate()
+		if err != nil {
+			return RequestPolicy{}, err
+		}
+		if !redaction.IsEmpty() {
+			normalized.Redaction = &redaction
+		}
+	}
+
+	return normalized, nil
+}
+
+func (r RedactionPolicy) normalizeAndValidate() (RedactionPolicy, error) {
+	normalized := RedactionPolicy{Action: strings.ToLower(strings.TrimSpace(r.Action))}
+	switch normalized.Action {
+	case "":
+		normalized.Action = RedactionActionMask
+	case RedactionActionMask, RedactionActionBlock:
+	default:
+		return RedactionPolicy{}, invalidRequestPolicy(fmt.Sprintf("unsupported redaction action %q", r.Action))
+	}
+
+	seen := make(map[string]struct{})
+	for _, detector := range r.Detectors {
+		detector = strings.ToLower(strings.TrimSpace(detector))
+		if detector == "" {
+			continue
+		}
+		if !slices.Contains(RedactionDetectors, detector) {
+			return RedactionPolicy{}, invalidRequestPolicy(fmt.Sprintf("unknown redaction detector %q", detector))
+		}
+		if _, ok := seen[detector]; ok {
+			continue
+		}
+		seen[detector] = struct{}{}
+		normalized.Detectors = append(normalized.Detectors, detector)
+	}
+
+	for _, custom := range r.CustomPatterns {
+		name := strings.TrimSpace(custom.Name)
+		if name == "" && strings.TrimSpace(custom.Pattern) == "" {
+			continue
+		}
+		if name == "" {
+			return RedactionPolicy{}, invalidRequestPolicy("redaction custom pattern name is required")
+		}
+		if _, ok := seen[name]; ok {
+			return RedactionPolicy{}, invalidRequestPolicy(fmt.Sprintf("duplicate redaction detector name %q", name))
+		}
+		if _, err := regexp.Compile(custom.Pattern); err != nil || strings.TrimSpace(custom.Pattern) == "" {
+			return RedactionPolicy{}, invalidRequestPolicy(fmt.Sprintf("invalid redaction pattern %q", custom.Pattern))
+		}
+		seen[name] = struct{}{}
+		normalized.CustomPatterns = append(normalized.CustomPatterns, RedactionPattern{Name: name, Pattern: custom.Pattern})
+	}
+	if len(normalized.CustomPatterns) > requestPolicyMaxBlockedPatterns {
+		return RedactionPolicy{}, invalidRequestPolicy(fmt.Sprintf("redaction custom_patterns must have at most %d entries", requestPolicyMaxBlockedPatterns))
+	}
+	return normalized, nil
+}
+
+// IsToolBlocked 判断工具名是否被策略禁止
+func (p *RequestPolicy) IsToolBlocked(name string) bool {
+	if p == nil || name == "" {
+		return false
+	}
+	lower := strings.ToLower(name)
+	for _, blocked := range p.BlockedTools {
+		blocked = strings.ToLower(blocked)
+		if prefix, ok := strings.CutSuffix(blocked, "*"); ok {
+			if strings.HasPrefix(lower, prefix) {
+				return true
+			}
+			continue
+		}
+		if lower == blocked {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidRequestPolicy(message string) error {
+	return infraerrors.BadRequest("INVALID_REQUEST_POLICY", "invalid request policy: "+message)
+}
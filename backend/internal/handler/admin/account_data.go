@@ -79,6 +79,8 @@ func buildProxyKey(protocol, host string, port int, username, password string) s
 	return fmt.Sprintf("%s|%s|%d|%s|%s", strings.TrimSpace(protocol), strings.TrimSpace(host), port, strings.TrimSpace(username), strings.TrimSpace(password))
 }
 
+// ExportData 导出账号（及关联代理）数据。
+// 敏感凭证字段默认以占位值导出；include_credentials=true 时导出明文凭证，并为每个账号写入审计日志。
 func (h *AccountHandler) ExportData(c *gin.Context) {
 	ctx := c.Request.Context()
 
@@ -99,6 +101,11 @@ func (h *AccountHandler) ExportData(c *gin.Context) {
 		response.BadRequest(c, err.Error())
 		return
 	}
+	includeCredentials, err := parseIncludeCredentials(c)
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
 
 	var proxies []service.Proxy
 	if includeProxies {
@@ -143,12 +150,18 @@ func (h *AccountHandler) ExportData(c *gin.Context) {
 			v := acc.ExpiresAt.Unix()
 			expiresAt = &v
 		}
+		// 明文凭证仅在显式 include_credentials=true 时导出，并逐个账号写入审计日志
+		credentials := service.RedactAccountCredentials(acc.Credentials)
+		if includeCredentials {
+			credentials = acc.Credentials
+			service.RecordAdminAuditAction(ctx, "account", acc.ID, service.AdminAuditActionExportCredentials)
+		}
 		dataAccounts = append(dataAccounts, DataAccount{
 			Name:               acc.Name,
 			Notes:              acc.Notes,
 			Platform:           acc.Platform,
 			Type:               acc.Type,
-			Credentials:        acc.Credentials,
+			Credentials:        credentials,
 			Extra:              acc.Extra,
 			ProxyKey:           proxyKey,
 			Concurrency:        acc.Concurrency,
@@ -438,9 +451,18 @@ func parseAccountIDs(c *gin.Context) ([]int64, error) {
 }
 
 func parseIncludeProxies(c *gin.Context) (bool, error) {
-	raw := strings.TrimSpace(strings.ToLower(c.Query("include_proxies")))
+	return parseDataBoolQuery(c, "include_proxies", true)
+}
+
+// parseIncludeCredentials 是否导出明文凭证；默认关闭，敏感凭证字段以占位值导出
+func parseIncludeCredentials(c *gin.Context) (bool, error) {
+	return parseDataBoolQuery(c, "include_credentials", false)
+}
+
+func parseDataBoolQuery(c *gin.Context, key string, defaultValue bool) (bool, error) {
+	raw := strings.TrimSpace(strings.ToLower(c.Query(key)))
 	if raw == "" {
-		return true, nil
+		return defaultValue, nil
 	}
 	switch raw {
 	case "1", "true", "yes", "on":
@@ -448,7 +470,7 @@ func parseIncludeProxies(c *gin.Context) (bool, error) {
 	case "0", "false", "no", "off":
 		return false, nil
 	default:
-		return true, fmt.Errorf("invalid include_proxies value: %s", raw)
+		return defaultValue, fmt.Errorf("invalid %s value: %s", key, raw)
 	}
 }
 
@@ -505,8 +527,11 @@ func validateDataAccount(item DataAccount) error {
 	if len(item.Credentials) == 0 {
 		return errors.New("account credentials is required")
 	}
+	if service.HasRedactedCredentials(item.Credentials) {
+		return errors.New("account credentials are redacted; export with include_credentials=true to import")
+	}
 	switch item.Type {
-	case service.AccountTypeOAuth, service.AccountTypeSetupToken, service.AccountTypeAPIKey, service.AccountTypeUpstream:
+	case service.AccountTypeOAuth, service.AccountTypeSetupToken, service.AccountTypeAPIKey, service.AccountTypeUpstream, service.AccountTypeBedrock, service.AccountTypeVertex:
 	default:
 		return fmt.Errorf("account type is invalid: %s", item.Type)
 	}
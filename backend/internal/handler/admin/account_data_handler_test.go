@@ -103,7 +103,7 @@ func TestExportDataIncludesSecrets(t *testing.T) {
 			Name:        "account",
 			Platform:    service.PlatformOpenAI,
 			Type:        service.AccountTypeOAuth,
-			Credentials: map[string]any{"token": "secret"},
+			Credentials: map[string]any{"token": "secret", "api_key": "sk-secret"},
 			Extra:       map[string]any{"note": "x"},
 			ProxyID:     &proxyID,
 			Concurrency: 3,
@@ -113,7 +113,7 @@ func TestExportDataIncludesSecrets(t *testing.T) {
 	}
 
 	rec := httptest.NewRecorder()
-	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/data", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/data?include_credentials=true", nil)
 	router.ServeHTTP(rec, req)
 	require.Equal(t, http.StatusOK, rec.Code)
 
@@ -126,6 +126,46 @@ func TestExportDataIncludesSecrets(t *testing.T) {
 	require.Equal(t, "pass", resp.Data.Proxies[0].Password)
 	require.Len(t, resp.Data.Accounts, 1)
 	require.Equal(t, "secret", resp.Data.Accounts[0].Credentials["token"])
+	require.Equal(t, "sk-secret", resp.Data.Accounts[0].Credentials["api_key"])
+}
+
+func TestExportDataRedactsCredentialsByDefault(t *testing.T) {
+	router, adminSvc := setupAccountDataRouter()
+	adminSvc.accounts = []service.Account{
+		{
+			ID:          21,
+			Name:        "account",
+			Platform:    service.PlatformAnthropic,
+			Type:        service.AccountTypeAPIKey,
+			Credentials: map[string]any{"api_key": "sk-secret", "base_url": "https://api.anthropic.com"},
+			Concurrency: 3,
+			Priority:    50,
+			Status:      service.StatusActive,
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/data", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), "sk-secret")
+
+	var resp dataResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Data.Accounts, 1)
+	require.Equal(t, service.RedactedCredentialValue, resp.Data.Accounts[0].Credentials["api_key"])
+	require.Equal(t, "https://api.anthropic.com", resp.Data.Accounts[0].Credentials["base_url"])
+
+	// 脱敏导出的数据不能直接导入
+	importBody, err := json.Marshal(map[string]any{"data": resp.Data})
+	require.NoError(t, err)
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/accounts/data", bytes.NewReader(importBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"account_failed":1`)
+	require.Contains(t, rec.Body.String(), "include_credentials=true")
+	require.Empty(t, adminSvc.createdAccounts)
 }
 
 func TestExportDataWithoutProxies(t *testing.T) {
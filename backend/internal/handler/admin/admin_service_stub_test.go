@@ -220,6 +220,16 @@ func (s *stubAdminService) SetAccountSchedulable(ctx context.Context, id int64,
 	return &account, nil
 }
 
+func (s *stubAdminService) StartAccountDrain(ctx context.Context, id int64, input *service.StartAccountDrainInput) (*service.Account, error) {
+	account := service.Account{ID: id, Name: "account", Status: service.StatusActive, Schedulable: true}
+	return &account, nil
+}
+
+func (s *stubAdminService) CancelAccountDrain(ctx context.Context, id int64) (*service.Account, error) {
+	account := service.Account{ID: id, Name: "account", Status: service.StatusActive, Schedulable: true}
+	return &account, nil
+}
+
 func (s *stubAdminService) BulkUpdateAccounts(ctx context.Context, input *service.BulkUpdateAccountsInput) (*service.BulkUpdateAccountsResult, error) {
 	return &service.BulkUpdateAccountsResult{Success: 1, Failed: 0, SuccessIDs: []int64{1}}, nil
 }
@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLogHandler 处理管理后台审计日志查询
+type AuditLogHandler struct {
+	auditService *service.AdminAuditService
+}
+
+// NewAuditLogHandler 创建审计日志处理器
+func NewAuditLogHandler(auditService *service.AdminAuditService) *AuditLogHandler {
+	return &AuditLogHandler{auditService: auditService}
+}
+
+// List 分页查询审计日志
+// GET /api/v1/admin/audit-logs
+// Query params: actor_user_id, method, target_type, target_id, route, start_date, end_date (YYYY-MM-DD), timezone
+func (h *AuditLogHandler) List(c *gin.Context) {
+	page, pageSize := response.ParsePagination(c)
+
+	var filters service.AdminAuditLogFilters
+	if raw := strings.TrimSpace(c.Query("actor_user_id")); raw != "" {
+		actorID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			response.BadRequest(c, "Invalid actor_user_id")
+			return
+		}
+		filters.ActorUserID = &actorID
+	}
+	filters.Method = strings.TrimSpace(c.Query("method"))
+	filters.TargetType = strings.TrimSpace(c.Query("target_type"))
+	filters.TargetID = strings.TrimSpace(c.Query("target_id"))
+	filters.Route = strings.TrimSpace(c.Query("route"))
+
+	userTZ := c.Query("timezone")
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		t, err := timezone.ParseInUserLocation("2006-01-02", startDateStr, userTZ)
+		if err != nil {
+			response.BadRequest(c, "Invalid start_date format, use YYYY-MM-DD")
+			return
+		}
+		filters.StartTime = &t
+	}
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		t, err := timezone.ParseInUserLocation("2006-01-02", endDateStr, userTZ)
+		if err != nil {
+			response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD")
+			return
+		}
+		t = t.Add(24 * time.Hour)
+		filters.EndTime = &t
+	}
+
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+	logs, result, err := h.auditService.List(c.Request.Context(), params, filters)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	out := make([]dto.AdminAuditLog, 0, len(logs))
+	for i := range logs {
+		out = append(out, *dto.AdminAuditLogFromService(&logs[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// BalanceLedgerHandler 处理余额流水查询与对账
+type BalanceLedgerHandler struct {
+	ledgerService *service.BalanceLedgerService
+}
+
+// NewBalanceLedgerHandler 创建余额流水处理器
+func NewBalanceLedgerHandler(ledgerService *service.BalanceLedgerService) *BalanceLedgerHandler {
+	return &BalanceLedgerHandler{ledgerService: ledgerService}
+}
+
+// ListByUser 分页查询指定用户的余额流水
+// GET /api/v1/admin/users/:id/balance-ledger
+// Query params: type, start_date, end_date (YYYY-MM-DD), timezone
+func (h *BalanceLedgerHandler) ListByUser(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid user ID")
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	filters := service.BalanceLedgerFilters{Type: strings.TrimSpace(c.Query("type"))}
+
+	userTZ := c.Query("timezone")
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		t, err := timezone.ParseInUserLocation("2006-01-02", startDateStr, userTZ)
+		if err != nil {
+			response.BadRequest(c, "Invalid start_date format, use YYYY-MM-DD")
+			return
+		}
+		filters.StartTime = &t
+	}
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		t, err := timezone.ParseInUserLocation("2006-01-02", endDateStr, userTZ)
+		if err != nil {
+			response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD")
+			return
+		}
+		t = t.Add(24 * time.Hour)
+		filters.EndTime = &t
+	}
+
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+	entries, result, err := h.ledgerService.ListByUser(c.Request.Context(), userID, params, filters)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	out := make([]dto.AdminBalanceLedgerEntry, 0, len(entries))
+	for i := range entries {
+		out = append(out, *dto.BalanceLedgerEntryFromServiceAdmin(&entries[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
+
+// GetDrift 返回最近一次对账结果（尚未执行过对账时 data 为 null）
+// GET /api/v1/admin/balance-ledger/drift
+func (h *BalanceLedgerHandler) GetDrift(c *gin.Context) {
+	response.Success(c, dto.BalanceLedgerReportFromService(h.ledgerService.LastReport()))
+}
+
+// Reconcile 立即执行一次对账
+// POST /api/v1/admin/balance-ledger/reconcile
+func (h *BalanceLedgerHandler) Reconcile(c *gin.Context) {
+	report, err := h.ledgerService.Reconcile(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.BalanceLedgerReportFromService(report))
+}
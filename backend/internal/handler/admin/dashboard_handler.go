@@ -14,17 +14,19 @@ import (
 
 // DashboardHandler handles admin dashboard statistics
 type DashboardHandler struct {
-	dashboardService   *service.DashboardService
-	aggregationService *service.DashboardAggregationService
-	startTime          time.Time // Server start time for uptime calculation
+	dashboardService     *service.DashboardService
+	aggregationService   *service.DashboardAggregationService
+	responseCacheService *service.ResponseCacheService
+	startTime            time.Time // Server start time for uptime calculation
 }
 
 // NewDashboardHandler creates a new admin dashboard handler
-func NewDashboardHandler(dashboardService *service.DashboardService, aggregationService *service.DashboardAggregationService) *DashboardHandler {
+func NewDashboardHandler(dashboardService *service.DashboardService, aggregationService *service.DashboardAggregationService, responseCacheService *service.ResponseCacheService) *DashboardHandler {
 	return &DashboardHandler{
-		dashboardService:   dashboardService,
-		aggregationService: aggregationService,
-		startTime:          time.Now(),
+		dashboardService:     dashboardService,
+		aggregationService:   aggregationService,
+		responseCacheService: responseCacheService,
+		startTime:            time.Now(),
 	}
 }
 
@@ -360,6 +362,29 @@ func (h *DashboardHandler) GetUserUsageTrend(c *gin.Context) {
 	})
 }
 
+// GetResponseCacheStats handles getting response cache hit-rate statistics
+// GET /api/v1/admin/dashboard/response-cache
+// Query params: start_date, end_date (YYYY-MM-DD)，最多返回 31 天
+func (h *DashboardHandler) GetResponseCacheStats(c *gin.Context) {
+	startTime, endTime := parseTimeRange(c)
+
+	stats, err := h.responseCacheService.GetStats(c.Request.Context(), startTime, endTime)
+	if err != nil {
+		response.Error(c, 500, "Failed to get response cache statistics")
+		return
+	}
+
+	response.Success(c, gin.H{
+		"hits":       stats.Hits,
+		"misses":     stats.Misses,
+		"stores":     stats.Stores,
+		"hit_rate":   stats.HitRate,
+		"daily":      stats.Daily,
+		"start_date": startTime.Format("2006-01-02"),
+		"end_date":   endTime.Add(-24 * time.Hour).Format("2006-01-02"),
+	})
+}
+
 // BatchUsersUsageRequest represents the request body for batch user usage stats
 type BatchUsersUsageRequest struct {
 	UserIDs []int64 `json:"user_ids" binding:"required"`
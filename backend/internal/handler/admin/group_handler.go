@@ -27,7 +27,7 @@ func NewGroupHandler(adminService service.AdminService) *GroupHandler {
 type CreateGroupRequest struct {
 	Name             string   `json:"name" binding:"required"`
 	Description      string   `json:"description"`
-	Platform         string   `json:"platform" binding:"omitempty,oneof=anthropic openai gemini antigravity"`
+	Platform         string   `json:"platform" binding:"omitempty,oneof=anthropic openai gemini antigravity compatible"`
 	RateMultiplier   float64  `json:"rate_multiplier"`
 	IsExclusive      bool     `json:"is_exclusive"`
 	SubscriptionType string   `json:"subscription_type" binding:"omitempty,oneof=standard subscription"`
@@ -47,6 +47,17 @@ type CreateGroupRequest struct {
 	MCPXMLInject        *bool              `json:"mcp_xml_inject"`
 	// 支持的模型系列（仅 antigravity 平台使用）
 	SupportedModelScopes []string `json:"supported_model_scopes"`
+	// API Key 默认限流（0 = 不限制）
+	DefaultRPMLimit          int `json:"default_rpm_limit" binding:"min=0"`
+	DefaultTPMLimit          int `json:"default_tpm_limit" binding:"min=0"`
+	DefaultDailyRequestLimit int `json:"default_daily_request_limit" binding:"min=0"`
+	// 响应缓存（TTL 为 0 时使用全局默认值）
+	ResponseCacheEnabled    bool `json:"response_cache_enabled"`
+	ResponseCacheTTLSeconds int  `json:"response_cache_ttl_seconds" binding:"min=0"`
+	// Claude → OpenAI 兜底模型映射（仅 anthropic 平台生效）
+	OpenAIFallbackModelMapping map[string]string `json:"openai_fallback_model_mapping"`
+	// 分组请求策略（转发到上游前执行）
+	RequestPolicy *service.RequestPolicy `json:"request_policy"`
 	// 从指定分组复制账号（创建后自动绑定）
 	CopyAccountsFromGroupIDs []int64 `json:"copy_accounts_from_group_ids"`
 }
@@ -55,7 +66,7 @@ type CreateGroupRequest struct {
 type UpdateGroupRequest struct {
 	Name             string   `json:"name"`
 	Description      string   `json:"description"`
-	Platform         string   `json:"platform" binding:"omitempty,oneof=anthropic openai gemini antigravity"`
+	Platform         string   `json:"platform" binding:"omitempty,oneof=anthropic openai gemini antigravity compatible"`
 	RateMultiplier   *float64 `json:"rate_multiplier"`
 	IsExclusive      *bool    `json:"is_exclusive"`
 	Status           string   `json:"status" binding:"omitempty,oneof=active inactive"`
@@ -76,6 +87,17 @@ type UpdateGroupRequest struct {
 	MCPXMLInject        *bool              `json:"mcp_xml_inject"`
 	// 支持的模型系列（仅 antigravity 平台使用）
 	SupportedModelScopes *[]string `json:"supported_model_scopes"`
+	// API Key 默认限流（0 = 不限制）
+	DefaultRPMLimit          *int `json:"default_rpm_limit" binding:"omitempty,min=0"`
+	DefaultTPMLimit          *int `json:"default_tpm_limit" binding:"omitempty,min=0"`
+	DefaultDailyRequestLimit *int `json:"default_daily_request_limit" binding:"omitempty,min=0"`
+	// 响应缓存（TTL 为 0 时使用全局默认值）
+	ResponseCacheEnabled    *bool `json:"response_cache_enabled"`
+	ResponseCacheTTLSeconds *int  `json:"response_cache_ttl_seconds" binding:"omitempty,min=0"`
+	// Claude → OpenAI 兜底模型映射（仅 anthropic 平台生效）
+	OpenAIFallbackModelMapping map[string]string `json:"openai_fallback_model_mapping"`
+	// 分组请求策略（空对象表示清除）
+	RequestPolicy *service.RequestPolicy `json:"request_policy"`
 	// 从指定分组复制账号（同步操作：先清空当前分组的账号绑定，再绑定源分组的账号）
 	CopyAccountsFromGroupIDs []int64 `json:"copy_accounts_from_group_ids"`
 }
@@ -186,6 +208,13 @@ func (h *GroupHandler) Create(c *gin.Context) {
 		ModelRoutingEnabled:             req.ModelRoutingEnabled,
 		MCPXMLInject:                    req.MCPXMLInject,
 		SupportedModelScopes:            req.SupportedModelScopes,
+		DefaultRPMLimit:                 req.DefaultRPMLimit,
+		DefaultTPMLimit:                 req.DefaultTPMLimit,
+		DefaultDailyRequestLimit:        req.DefaultDailyRequestLimit,
+		ResponseCacheEnabled:            req.ResponseCacheEnabled,
+		ResponseCacheTTLSeconds:         req.ResponseCacheTTLSeconds,
+		OpenAIFallbackModelMapping:      req.OpenAIFallbackModelMapping,
+		RequestPolicy:                   req.RequestPolicy,
 		CopyAccountsFromGroupIDs:        req.CopyAccountsFromGroupIDs,
 	})
 	if err != nil {
@@ -232,6 +261,13 @@ func (h *GroupHandler) Update(c *gin.Context) {
 		ModelRoutingEnabled:             req.ModelRoutingEnabled,
 		MCPXMLInject:                    req.MCPXMLInject,
 		SupportedModelScopes:            req.SupportedModelScopes,
+		DefaultRPMLimit:                 req.DefaultRPMLimit,
+		DefaultTPMLimit:                 req.DefaultTPMLimit,
+		DefaultDailyRequestLimit:        req.DefaultDailyRequestLimit,
+		ResponseCacheEnabled:            req.ResponseCacheEnabled,
+		ResponseCacheTTLSeconds:         req.ResponseCacheTTLSeconds,
+		OpenAIFallbackModelMapping:      req.OpenAIFallbackModelMapping,
+		RequestPolicy:                   req.RequestPolicy,
 		CopyAccountsFromGroupIDs:        req.CopyAccountsFromGroupIDs,
 	})
 	if err != nil {
@@ -241,3 +241,39 @@ func parseOpsQueryMode(c *gin.Context) service.OpsQueryMode {
 	}
 	return service.ParseOpsQueryMode(raw)
 }
+
+// GetAccountTrafficShares compares configured account weights with the observed traffic share per priority tier.
+// GET /api/v1/admin/ops/dashboard/traffic-shares
+//
+// Query params:
+// - group_id: required
+// - time_range / start_time / end_time: optional (default: 1h, max window 24h)
+func (h *OpsHandler) GetAccountTrafficShares(c *gin.Context) {
+	if h.opsService == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		return
+	}
+	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	groupID, err := strconv.ParseInt(strings.TrimSpace(c.Query("group_id")), 10, 64)
+	if err != nil || groupID <= 0 {
+		response.BadRequest(c, "Invalid group_id")
+		return
+	}
+
+	startTime, endTime, err := parseOpsTimeRange(c, "1h")
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	data, err := h.opsService.GetAccountTrafficShares(c.Request.Context(), groupID, startTime, endTime)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, data)
+}
@@ -716,6 +716,69 @@ type opsResolveRequest struct {
 	Resolved bool `json:"resolved"`
 }
 
+// ListRedactionLogs lists per-request sensitive content detections (counts only).
+// GET /api/v1/admin/ops/redactions
+func (h *OpsHandler) ListRedactionLogs(c *gin.Context) {
+	if h.opsService == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		return
+	}
+	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	page, pageSize := response.ParsePagination(c)
+	if pageSize > 500 {
+		pageSize = 500
+	}
+
+	startTime, endTime, err := parseOpsTimeRange(c, "24h")
+	if err != nil {
+		response.BadRequest(c, err.Error())
+		return
+	}
+
+	filter := &service.OpsRedactionLogFilter{Page: page, PageSize: pageSize}
+	if !startTime.IsZero() {
+		filter.StartTime = &startTime
+	}
+	if !endTime.IsZero() {
+		filter.EndTime = &endTime
+	}
+	filter.Platform = strings.TrimSpace(c.Query("platform"))
+	if action := strings.ToLower(strings.TrimSpace(c.Query("action"))); action != "" {
+		if action != service.RedactionActionMask && action != service.RedactionActionBlock {
+			response.BadRequest(c, "Invalid action")
+			return
+		}
+		filter.Action = action
+	}
+	if v := strings.TrimSpace(c.Query("group_id")); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			response.BadRequest(c, "Invalid group_id")
+			return
+		}
+		filter.GroupID = &id
+	}
+	if v := strings.TrimSpace(c.Query("user_id")); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			response.BadRequest(c, "Invalid user_id")
+			return
+		}
+		filter.UserID = &id
+	}
+
+	result, err := h.opsService.GetRedactionLogs(c.Request.Context(), filter)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Paginated(c, result.Logs, int64(result.Total), result.Page, result.PageSize)
+}
+
 // RetryErrorRequest retries a failed request using stored request_body.
 // POST /api/v1/admin/ops/errors/:id/retry
 func (h *OpsHandler) RetryErrorRequest(c *gin.Context) {
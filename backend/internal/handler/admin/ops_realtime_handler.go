@@ -100,6 +100,52 @@ func (h *OpsHandler) GetUserConcurrencyStats(c *gin.Context) {
 	response.Success(c, payload)
 }
 
+// GetFairQueueEntries returns the requests queued for an account slot (position and wait time).
+// GET /api/v1/admin/ops/fair-queue
+//
+// Query params:
+// - account_id: required
+func (h *OpsHandler) GetFairQueueEntries(c *gin.Context) {
+	if h.opsService == nil {
+		response.Error(c, http.StatusServiceUnavailable, "Ops service not available")
+		return
+	}
+	if err := h.opsService.RequireMonitoringEnabled(c.Request.Context()); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	accountID, err := strconv.ParseInt(strings.TrimSpace(c.Query("account_id")), 10, 64)
+	if err != nil || accountID <= 0 {
+		response.BadRequest(c, "Invalid account_id")
+		return
+	}
+
+	if !h.opsService.IsRealtimeMonitoringEnabled(c.Request.Context()) {
+		response.Success(c, gin.H{
+			"enabled":   false,
+			"entries":   []*service.FairQueueEntry{},
+			"timestamp": time.Now().UTC(),
+		})
+		return
+	}
+
+	entries, collectedAt, err := h.opsService.GetFairQueueEntries(c.Request.Context(), accountID)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	payload := gin.H{
+		"enabled": true,
+		"entries": entries,
+	}
+	if collectedAt != nil {
+		payload["timestamp"] = collectedAt.UTC()
+	}
+	response.Success(c, payload)
+}
+
 // GetAccountAvailability returns account availability statistics.
 // GET /api/v1/admin/ops/account-availability
 //
@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// SchedulerHandler 调度决策解释
+type SchedulerHandler struct {
+	gatewayService *service.GatewayService
+}
+
+// NewSchedulerHandler 创建调度决策解释处理器
+func NewSchedulerHandler(gatewayService *service.GatewayService) *SchedulerHandler {
+	return &SchedulerHandler{gatewayService: gatewayService}
+}
+
+// ExplainSchedulerRequest dry-run 调度请求
+type ExplainSchedulerRequest struct {
+	GroupID            *int64  `json:"group_id"`
+	Platform           string  `json:"platform"`
+	Model              string  `json:"model"`
+	SessionHash        string  `json:"session_hash"`
+	ExcludedAccountIDs []int64 `json:"excluded_account_ids"`
+}
+
+// Explain 重放一次账号选择，返回每个账号的过滤结论、负载与最终选择（不占用槽位、不写粘性会话）
+// POST /api/v1/admin/scheduler/explain
+func (h *SchedulerHandler) Explain(c *gin.Context) {
+	var req ExplainSchedulerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+	if req.GroupID != nil && *req.GroupID <= 0 {
+		response.BadRequest(c, "Invalid group_id")
+		return
+	}
+	platform := strings.ToLower(strings.TrimSpace(req.Platform))
+	// OpenAI 使用独立的选择器（粘性会话键带 "openai:" 前缀），暂不支持重放
+	switch platform {
+	case "", service.PlatformAnthropic, service.PlatformGemini, service.PlatformAntigravity:
+	default:
+		response.BadRequest(c, "Invalid platform")
+		return
+	}
+
+	result, err := h.gatewayService.ExplainAccountSelection(c.Request.Context(), &service.SchedulerExplainInput{
+		GroupID:            req.GroupID,
+		Platform:           platform,
+		Model:              req.Model,
+		SessionHash:        req.SessionHash,
+		ExcludedAccountIDs: req.ExcludedAccountIDs,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, result)
+}
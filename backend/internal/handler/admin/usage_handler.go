@@ -59,14 +59,67 @@ type CreateUsageCleanupTaskRequest struct {
 // GET /api/v1/admin/usage
 func (h *UsageHandler) List(c *gin.Context) {
 	page, pageSize := response.ParsePagination(c)
+	filters, ok := parseUsageLogFilters(c)
+	if !ok {
+		return
+	}
+
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+	records, result, err := h.usageService.ListWithFilters(c.Request.Context(), params, filters)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.AdminUsageLog, 0, len(records))
+	for i := range records {
+		out = append(out, *dto.UsageLogFromServiceAdmin(&records[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
 
+// Export streams usage records matching the List filters as CSV or JSONL
+// GET /api/v1/admin/usage/export?format=csv|jsonl
+func (h *UsageHandler) Export(c *gin.Context) {
+	format := c.DefaultQuery("format", dto.UsageExportFormatCSV)
+	if !dto.IsValidUsageExportFormat(format) {
+		response.BadRequest(c, "Invalid format, use csv or jsonl")
+		return
+	}
+	filters, ok := parseUsageLogFilters(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", dto.UsageExportContentType(format))
+	c.Header("Content-Disposition", `attachment; filename="`+dto.UsageExportFilename(format, time.Now())+`"`)
+	w := dto.NewUsageExportWriter(c.Writer, format, true)
+	err := h.usageService.ExportWithFilters(c.Request.Context(), filters, w.Write)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			response.ErrorFrom(c, err)
+			return
+		}
+		// 已开始输出，只能中断连接
+		log.Printf("[Usage] admin export aborted: %v", err)
+		_ = c.Error(err)
+	}
+}
+
+// parseUsageLogFilters 解析 List/Export 共用的过滤参数；失败时已写出错误响应
+func parseUsageLogFilters(c *gin.Context) (usagestats.UsageLogFilters, bool) {
 	// Parse filters
 	var userID, apiKeyID, accountID, groupID int64
 	if userIDStr := c.Query("user_id"); userIDStr != "" {
 		id, err := strconv.ParseInt(userIDStr, 10, 64)
 		if err != nil {
 			response.BadRequest(c, "Invalid user_id")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		userID = id
 	}
@@ -75,7 +128,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		id, err := strconv.ParseInt(apiKeyIDStr, 10, 64)
 		if err != nil {
 			response.BadRequest(c, "Invalid api_key_id")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		apiKeyID = id
 	}
@@ -84,7 +137,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		id, err := strconv.ParseInt(accountIDStr, 10, 64)
 		if err != nil {
 			response.BadRequest(c, "Invalid account_id")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		accountID = id
 	}
@@ -93,7 +146,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		id, err := strconv.ParseInt(groupIDStr, 10, 64)
 		if err != nil {
 			response.BadRequest(c, "Invalid group_id")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		groupID = id
 	}
@@ -105,7 +158,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		val, err := strconv.ParseBool(streamStr)
 		if err != nil {
 			response.BadRequest(c, "Invalid stream value, use true or false")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		stream = &val
 	}
@@ -115,7 +168,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		val, err := strconv.ParseInt(billingTypeStr, 10, 8)
 		if err != nil {
 			response.BadRequest(c, "Invalid billing_type")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		bt := int8(val)
 		billingType = &bt
@@ -128,7 +181,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		t, err := timezone.ParseInUserLocation("2006-01-02", startDateStr, userTZ)
 		if err != nil {
 			response.BadRequest(c, "Invalid start_date format, use YYYY-MM-DD")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		startTime = &t
 	}
@@ -137,15 +190,14 @@ func (h *UsageHandler) List(c *gin.Context) {
 		t, err := timezone.ParseInUserLocation("2006-01-02", endDateStr, userTZ)
 		if err != nil {
 			response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		// Set end time to end of day
 		t = t.Add(24*time.Hour - time.Nanosecond)
 		endTime = &t
 	}
 
-	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
-	filters := usagestats.UsageLogFilters{
+	return usagestats.UsageLogFilters{
 		UserID:      userID,
 		APIKeyID:    apiKeyID,
 		AccountID:   accountID,
@@ -155,19 +207,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		BillingType: billingType,
 		StartTime:   startTime,
 		EndTime:     endTime,
-	}
-
-	records, result, err := h.usageService.ListWithFilters(c.Request.Context(), params, filters)
-	if err != nil {
-		response.ErrorFrom(c, err)
-		return
-	}
-
-	out := make([]dto.AdminUsageLog, 0, len(records))
-	for i := range records {
-		out = append(out, *dto.UsageLogFromServiceAdmin(&records[i]))
-	}
-	response.Paginated(c, out, result.Total, page, pageSize)
+	}, true
 }
 
 // Stats handles getting usage statistics with filters
@@ -0,0 +1,216 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/handler/dto"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/response"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookHandler 处理出站 Webhook 端点与投递记录的 HTTP 请求
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+// NewWebhookHandler 创建 Webhook 处理器
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: webhookService}
+}
+
+// CreateWebhookEndpointRequest 创建端点请求
+type CreateWebhookEndpointRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	URL         string   `json:"url" binding:"required"`
+	Secret      string   `json:"secret"`
+	Format      string   `json:"format"`
+	Events      []string `json:"events"`
+	Enabled     *bool    `json:"enabled"`
+	Description *string  `json:"description"`
+}
+
+// UpdateWebhookEndpointRequest 更新端点请求（部分更新，所有字段可选）
+type UpdateWebhookEndpointRequest struct {
+	Name        *string  `json:"name"`
+	URL         *string  `json:"url"`
+	Secret      *string  `json:"secret"`
+	Format      *string  `json:"format"`
+	Events      []string `json:"events"`
+	Enabled     *bool    `json:"enabled"`
+	Description *string  `json:"description"`
+}
+
+// ListEventTypes 返回可订阅的事件类型
+// GET /api/v1/admin/webhooks/event-types
+func (h *WebhookHandler) ListEventTypes(c *gin.Context) {
+	response.Success(c, service.WebhookEventTypes)
+}
+
+// List 获取所有端点
+// GET /api/v1/admin/webhooks
+func (h *WebhookHandler) List(c *gin.Context) {
+	endpoints, err := h.webhookService.ListEndpoints(c.Request.Context())
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	out := make([]dto.WebhookEndpoint, 0, len(endpoints))
+	for i := range endpoints {
+		out = append(out, *dto.WebhookEndpointFromService(&endpoints[i]))
+	}
+	response.Success(c, out)
+}
+
+// GetByID 获取端点详情
+// GET /api/v1/admin/webhooks/:id
+func (h *WebhookHandler) GetByID(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	endpoint, err := h.webhookService.GetEndpoint(c.Request.Context(), id)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.WebhookEndpointFromService(endpoint))
+}
+
+// Create 创建端点（响应中返回一次明文密钥）
+// POST /api/v1/admin/webhooks
+func (h *WebhookHandler) Create(c *gin.Context) {
+	var req CreateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	endpoint, err := h.webhookService.CreateEndpoint(c.Request.Context(), &service.CreateWebhookEndpointInput{
+		Name:        req.Name,
+		URL:         req.URL,
+		Secret:      req.Secret,
+		Format:      req.Format,
+		Events:      req.Events,
+		Enabled:     req.Enabled,
+		Description: req.Description,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := dto.WebhookEndpointFromService(endpoint)
+	out.Secret = endpoint.Secret
+	response.Success(c, out)
+}
+
+// Update 更新端点
+// PUT /api/v1/admin/webhooks/:id
+func (h *WebhookHandler) Update(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+
+	var req UpdateWebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	endpoint, err := h.webhookService.UpdateEndpoint(c.Request.Context(), id, &service.UpdateWebhookEndpointInput{
+		Name:        req.Name,
+		URL:         req.URL,
+		Secret:      req.Secret,
+		Format:      req.Format,
+		Events:      req.Events,
+		Enabled:     req.Enabled,
+		Description: req.Description,
+	})
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, dto.WebhookEndpointFromService(endpoint))
+}
+
+// Delete 删除端点
+// DELETE /api/v1/admin/webhooks/:id
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	if err := h.webhookService.DeleteEndpoint(c.Request.Context(), id); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// Test 向端点同步发送一条测试事件
+// POST /api/v1/admin/webhooks/:id/test
+func (h *WebhookHandler) Test(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid webhook ID")
+		return
+	}
+	result, err := h.webhookService.TestEndpoint(c.Request.Context(), id)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, result)
+}
+
+// ListDeliveries 分页查询投递记录
+// GET /api/v1/admin/webhooks/deliveries?endpoint_id=&status=&event_type=
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	page, pageSize := response.ParsePagination(c)
+
+	var filters service.WebhookDeliveryFilters
+	if raw := strings.TrimSpace(c.Query("endpoint_id")); raw != "" {
+		endpointID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			response.BadRequest(c, "Invalid endpoint_id")
+			return
+		}
+		filters.EndpointID = &endpointID
+	}
+	filters.Status = strings.TrimSpace(c.Query("status"))
+	filters.EventType = strings.TrimSpace(c.Query("event_type"))
+
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+	deliveries, result, err := h.webhookService.ListDeliveries(c.Request.Context(), params, filters)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	out := make([]dto.WebhookDelivery, 0, len(deliveries))
+	for i := range deliveries {
+		out = append(out, *dto.WebhookDeliveryFromService(&deliveries[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
+
+// RetryDelivery 手动重试一条投递
+// POST /api/v1/admin/webhooks/deliveries/:id/retry
+func (h *WebhookHandler) RetryDelivery(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		response.BadRequest(c, "Invalid delivery ID")
+		return
+	}
+	if err := h.webhookService.RetryDelivery(c.Request.Context(), id); err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+	response.Success(c, gin.H{"message": "Delivery queued for retry"})
+}
@@ -35,6 +35,11 @@ type CreateAPIKeyRequest struct {
 	IPBlacklist   []string `json:"ip_blacklist"`    // IP 黑名单
 	Quota         *float64 `json:"quota"`           // 配额限制 (USD)
 	ExpiresInDays *int     `json:"expires_in_days"` // 过期天数
+
+	// 限流配置（0 = 继承分组默认值）
+	RPMLimit          int `json:"rpm_limit" binding:"min=0"`
+	TPMLimit          int `json:"tpm_limit" binding:"min=0"`
+	DailyRequestLimit int `json:"daily_request_limit" binding:"min=0"`
 }
 
 // UpdateAPIKeyRequest represents the update API key request payload
@@ -47,6 +52,11 @@ type UpdateAPIKeyRequest struct {
 	Quota       *float64 `json:"quota"`        // 配额限制 (USD), 0=无限制
 	ExpiresAt   *string  `json:"expires_at"`   // 过期时间 (ISO 8601)
 	ResetQuota  *bool    `json:"reset_quota"`  // 重置已用配额
+
+	// 限流配置（0 = 继承分组默认值）
+	RPMLimit          *int `json:"rpm_limit" binding:"omitempty,min=0"`
+	TPMLimit          *int `json:"tpm_limit" binding:"omitempty,min=0"`
+	DailyRequestLimit *int `json:"daily_request_limit" binding:"omitempty,min=0"`
 }
 
 // List handles listing user's API keys with pagination
@@ -126,6 +136,10 @@ func (h *APIKeyHandler) Create(c *gin.Context) {
 		IPWhitelist:   req.IPWhitelist,
 		IPBlacklist:   req.IPBlacklist,
 		ExpiresInDays: req.ExpiresInDays,
+
+		RPMLimit:          req.RPMLimit,
+		TPMLimit:          req.TPMLimit,
+		DailyRequestLimit: req.DailyRequestLimit,
 	}
 	if req.Quota != nil {
 		svcReq.Quota = *req.Quota
@@ -136,7 +150,10 @@ func (h *APIKeyHandler) Create(c *gin.Context) {
 		return
 	}
 
-	response.Success(c, dto.APIKeyFromService(key))
+	// 服务端只保存哈希，完整 Key 仅在此处返回一次
+	out := dto.APIKeyFromService(key)
+	out.Key = key.Key
+	response.Success(c, out)
 }
 
 // Update handles updating an API key
@@ -165,6 +182,10 @@ func (h *APIKeyHandler) Update(c *gin.Context) {
 		IPBlacklist: req.IPBlacklist,
 		Quota:       req.Quota,
 		ResetQuota:  req.ResetQuota,
+
+		RPMLimit:          req.RPMLimit,
+		TPMLimit:          req.TPMLimit,
+		DailyRequestLimit: req.DailyRequestLimit,
 	}
 	if req.Name != "" {
 		svcReq.Name = &req.Name
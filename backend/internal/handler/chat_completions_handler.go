@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChatCompletionsHandler handles the OpenAI Chat Completions compatible endpoint.
+//
+// 请求按分组平台转换后交给现有网关处理器：
+//   - OpenAI 分组 → Responses API（OpenAIGatewayHandler.Responses）
+//   - 其他分组（Anthropic/Gemini/Antigravity）→ Anthropic Messages（GatewayHandler.Messages）
+//   - OpenAI 兼容上游分组（compatible）→ 直接透传（CompatibleGatewayHandler.ChatCompletions）
+//
+// 响应通过 chatCompletionsWriter 转换回 Chat Completions 格式，
+// 因此调度、粘性会话、故障切换、计费与运维日志均与原生端点一致。
+type ChatCompletionsHandler struct {
+	gatewayHandler           *GatewayHandler
+	openaiGatewayHandler     *OpenAIGatewayHandler
+	compatibleGatewayHandler *CompatibleGatewayHandler
+}
+
+// NewChatCompletionsHandler creates a new ChatCompletionsHandler
+func NewChatCompletionsHandler(gatewayHandler *GatewayHandler, openaiGatewayHandler *OpenAIGatewayHandler, compatibleGatewayHandler *CompatibleGatewayHandler) *ChatCompletionsHandler {
+	return &ChatCompletionsHandler{
+		gatewayHandler:           gatewayHandler,
+		openaiGatewayHandler:     openaiGatewayHandler,
+		compatibleGatewayHandler: compatibleGatewayHandler,
+	}
+}
+
+// ChatCompletions handles OpenAI Chat Completions API endpoint
+// POST /v1/chat/completions
+func (h *ChatCompletionsHandler) ChatCompletions(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+
+	if h.compatibleGatewayHandler != nil && isCompatibleGroupRequest(c, apiKey) {
+		h.compatibleGatewayHandler.ChatCompletions(c)
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if maxErr, ok := extractMaxBytesError(err); ok {
+			h.errorResponse(c, http.StatusRequestEntityTooLarge, "invalid_request_error", buildBodyTooLargeMessage(maxErr.Limit))
+			return
+		}
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to read request body")
+		return
+	}
+	if len(body) == 0 {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Request body is empty")
+		return
+	}
+
+	setOpsRequestContext(c, "", false, body)
+
+	req, err := service.ParseChatCompletionsRequest(body)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body: "+err.Error())
+		return
+	}
+
+	platform := ""
+	if apiKey.Group != nil {
+		platform = apiKey.Group.Platform
+	}
+
+	var (
+		converted  []byte
+		next       gin.HandlerFunc
+		writer     *chatCompletionsWriter
+		convertErr error
+	)
+	if platform == service.PlatformOpenAI {
+		converted, convertErr = service.ConvertChatCompletionsToResponses(req)
+		next = h.openaiGatewayHandler.Responses
+		writer = newChatCompletionsWriter(c.Writer, req.Stream,
+			service.NewResponsesChatStreamConverter(req.Model, req.IncludeUsage()),
+			func(b []byte) ([]byte, error) { return service.ConvertResponsesToChatCompletion(b, req.Model) })
+	} else {
+		converted, convertErr = service.ConvertChatCompletionsToClaudeMessages(req)
+		next = h.gatewayHandler.Messages
+		writer = newChatCompletionsWriter(c.Writer, req.Stream,
+			service.NewClaudeChatStreamConverter(req.Model, req.IncludeUsage()),
+			func(b []byte) ([]byte, error) { return service.ConvertClaudeMessageToChatCompletion(b, req.Model) })
+	}
+	if convertErr != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", convertErr.Error())
+		return
+	}
+
+	c.Request.Body = io.NopCloser(bytes.NewReader(converted))
+	c.Request.ContentLength = int64(len(converted))
+	originalWriter := c.Writer
+	c.Writer = writer
+	defer func() {
+		writer.finalize()
+		c.Writer = originalWriter
+	}()
+
+	next(c)
+}
+
+// errorResponse returns OpenAI API format error response
+func (h *ChatCompletionsHandler) errorResponse(c *gin.Context, status int, errType, message string) {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
+
+// chatCompletionsWriter 拦截下游处理器写出的 Anthropic/Responses 响应并转换为 Chat Completions 格式。
+//   - 流式成功响应：按 SSE 事件逐个转换并立即写出；
+//   - 其他响应（非流式、错误）：缓冲完整响应体，在 finalize 时统一转换写出。
+//
+// OpenAI 兜底（Responses -> Anthropic Messages）复用同一写出器，仅替换转换函数，见 newClaudeMessagesWriter。
+type chatCompletionsWriter struct {
+	gin.ResponseWriter
+
+	stream       bool
+	streamConv   service.ChatCompletionsStreamConverter
+	convertBody  func([]byte) ([]byte, error)
+	convertError func([]byte) []byte
+
+	status    int
+	decided   bool
+	streaming bool
+	wrote     bool
+	buf       bytes.Buffer
+}
+
+func newChatCompletionsWriter(w gin.ResponseWriter, stream bool, streamConv service.ChatCompletionsStreamConverter, convertBody func([]byte) ([]byte, error)) *chatCompletionsWriter {
+	return &chatCompletionsWriter{
+		ResponseWriter: w,
+		stream:         stream,
+		streamConv:     streamConv,
+		convertBody:    convertBody,
+		convertError:   service.ConvertErrorToChatCompletions,
+		status:         http.StatusOK,
+	}
+}
+
+func (w *chatCompletionsWriter) WriteHeader(code int) {
+	if code > 0 && !w.decided {
+		w.status = code
+	}
+}
+
+func (w *chatCompletionsWriter) WriteHeaderNow() {
+	w.decide()
+}
+
+func (w *chatCompletionsWriter) Status() int {
+	return w.status
+}
+
+func (w *chatCompletionsWriter) Written() bool {
+	return w.wrote
+}
+
+func (w *chatCompletionsWriter) Write(b []byte) (int, error) {
+	w.decide()
+	w.wrote = true
+	w.buf.Write(b)
+	if w.streaming {
+		if err := w.drainEvents(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+func (w *chatCompletionsWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *chatCompletionsWriter) Flush() {
+	if w.streaming {
+		w.ResponseWriter.Flush()
+	}
+}
+
+// decide 在首次写出时确定输出模式；流式模式下立即提交响应头
+func (w *chatCompletionsWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	contentType := strings.ToLower(w.Header().Get("Content-Type"))
+	w.streaming = w.stream && w.status < http.StatusBadRequest && strings.Contains(contentType, "text/event-stream")
+	if w.streaming {
+		w.Header().Del("Content-Length")
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// drainEvents 将缓冲区中完整的 SSE 事件逐个转换写出，剩余的不完整事件保留到下次写入
+func (w *chatCompletionsWriter) drainEvents() error {
+	for {
+		data := w.buf.Bytes()
+		idx, sepLen := indexSSEEventEnd(data)
+		if idx < 0 {
+			return nil
+		}
+		event := string(data[:idx])
+		w.buf.Next(idx + sepLen)
+		if out := w.streamConv.ConvertEvent(event); len(out) > 0 {
+			if _, err := w.ResponseWriter.Write(out); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// indexSSEEventEnd 返回第一个事件结尾空行（"\n\n" 或 "\r\n\r\n"）的位置及其长度，未找到时返回 -1
+func indexSSEEventEnd(data []byte) (int, int) {
+	lf := bytes.Index(data, []byte("\n\n"))
+	crlf := bytes.Index(data, []byte("\r\n\r\n"))
+	if crlf >= 0 && (lf < 0 || crlf < lf) {
+		return crlf, 4
+	}
+	if lf >= 0 {
+		return lf, 2
+	}
+	return -1, 0
+}
+
+// finalize 在下游处理器返回后调用，输出缓冲的响应或补齐流式结尾。
+// 正常结束的上游流已在结束事件（message_stop / response.completed / [DONE]）时输出结尾，
+// 此时仍未结束说明上游流被截断，输出错误事件而非 finish_reason=stop。
+func (w *chatCompletionsWriter) finalize() {
+	if !w.wrote {
+		return
+	}
+	if w.streaming {
+		if rest := strings.TrimSpace(w.buf.String()); rest != "" {
+			w.buf.Reset()
+			if out := w.streamConv.ConvertEvent(rest); len(out) > 0 {
+				_, _ = w.ResponseWriter.Write(out)
+			}
+		}
+		if out := w.streamConv.Abort(); len(out) > 0 {
+			_, _ = w.ResponseWriter.Write(out)
+		}
+		w.ResponseWriter.Flush()
+		return
+	}
+
+	body := w.buf.Bytes()
+	if w.status >= http.StatusBadRequest {
+		body = w.convertError(body)
+	} else if converted, err := w.convertBody(body); err == nil {
+		body = converted
+	}
+	w.Header().Del("Content-Length")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.ResponseWriter.WriteHeader(w.status)
+	_, _ = w.ResponseWriter.Write(body)
+}
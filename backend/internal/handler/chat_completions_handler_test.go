@@ -0,0 +1,117 @@
+//go:build unit
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newClaudeChatWriter(c *gin.Context, stream bool) *chatCompletionsWriter {
+	return newChatCompletionsWriter(c.Writer, stream,
+		service.NewClaudeChatStreamConverter("m", false),
+		func(b []byte) ([]byte, error) { return service.ConvertClaudeMessageToChatCompletion(b, "m") })
+}
+
+func TestChatCompletionsWriter_NonStreaming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newClaudeChatWriter(c, false)
+	c.Writer = w
+	c.Data(http.StatusOK, "application/json", []byte(`{"id":"msg_1","stop_reason":"end_turn","content":[{"type":"text","text":"hi"}],"usage":{"input_tokens":1,"output_tokens":1}}`))
+	w.finalize()
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"object":"chat.completion"`)
+	require.Contains(t, rec.Body.String(), `"content":"hi"`)
+}
+
+func TestChatCompletionsWriter_ErrorFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newClaudeChatWriter(c, true)
+	c.Writer = w
+	c.JSON(http.StatusTooManyRequests, gin.H{"type": "error", "error": gin.H{"type": "rate_limit_error", "message": "slow"}})
+	require.Equal(t, http.StatusTooManyRequests, w.Status())
+	w.finalize()
+
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.JSONEq(t, `{"error":{"type":"rate_limit_error","message":"slow"}}`, rec.Body.String())
+}
+
+func TestChatCompletionsWriter_Streaming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newClaudeChatWriter(c, true)
+	c.Writer = w
+	c.Header("Content-Type", "text/event-stream")
+	c.Status(http.StatusOK)
+	// 事件被拆分在多次写入中，转换器需要按完整事件边界处理
+	_, _ = c.Writer.WriteString("event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_2\"}}\n\nevent: content_block_delta\ndata: {\"type\":\"content_block_delta\",")
+	_, _ = c.Writer.WriteString("\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"yo\"}}\n\n")
+	c.Writer.Flush()
+	_, _ = c.Writer.WriteString("event: message_delta\ndata: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"}}\n\n")
+	_, _ = c.Writer.WriteString("event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n")
+	w.finalize()
+
+	body := rec.Body.String()
+	require.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	require.Contains(t, body, `"content":"yo"`)
+	require.Contains(t, body, `"finish_reason":"stop"`)
+	require.True(t, strings.HasSuffix(body, "data: [DONE]\n\n"))
+}
+
+func TestChatCompletionsWriter_StreamingCRLF(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newClaudeChatWriter(c, true)
+	c.Writer = w
+	c.Header("Content-Type", "text/event-stream")
+	c.Status(http.StatusOK)
+	_, _ = c.Writer.WriteString("event: message_start\r\ndata: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_3\"}}\r\n\r\n")
+	_, _ = c.Writer.WriteString("event: content_block_delta\r\ndata: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"yo\"}}\r\n\r\n")
+	// 事件转换在写入时完成，不依赖 finalize 处理剩余缓冲
+	require.Contains(t, rec.Body.String(), `"content":"yo"`)
+
+	_, _ = c.Writer.WriteString("event: message_stop\r\ndata: {\"type\":\"message_stop\"}\r\n\r\n")
+	w.finalize()
+
+	body := rec.Body.String()
+	require.Contains(t, body, `"finish_reason":"stop"`)
+	require.True(t, strings.HasSuffix(body, "data: [DONE]\n\n"))
+}
+
+func TestChatCompletionsWriter_StreamingTruncated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newClaudeChatWriter(c, true)
+	c.Writer = w
+	c.Header("Content-Type", "text/event-stream")
+	c.Status(http.StatusOK)
+	_, _ = c.Writer.WriteString("event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_4\"}}\n\n")
+	_, _ = c.Writer.WriteString("event: content_block_delta\ndata: {\"type\":\"content_block_delta\",\"index\":0,\"delta\":{\"type\":\"text_delta\",\"text\":\"yo\"}}\n\n")
+	// 上游未发送 message_stop 即中断：输出错误事件，而不是伪装成正常结束
+	w.finalize()
+
+	body := rec.Body.String()
+	require.Contains(t, body, `"content":"yo"`)
+	require.NotContains(t, body, `"finish_reason":"stop"`)
+	require.Contains(t, body, `"type":"upstream_error"`)
+	require.True(t, strings.HasSuffix(body, "data: [DONE]\n\n"))
+}
@@ -0,0 +1,437 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/domain"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompatibleGatewayHandler handles requests for compatible (OpenAI Chat Completions compatible upstream) groups.
+//
+// /v1/chat/completions 透传到上游，/v1/messages 在 service 层转换为 Chat Completions；
+// 并发控制、计费校验、粘性会话、故障切换与使用记录流程与其他平台一致。
+// 等待槽位期间使用 SSE 注释作为心跳，Anthropic 与 OpenAI 客户端均会忽略。
+type CompatibleGatewayHandler struct {
+	compatibleGatewayService *service.CompatibleGatewayService
+	gatewayService           *service.GatewayService
+	billingCacheService      *service.BillingCacheService
+	apiKeyService            *service.APIKeyService
+	errorPassthroughService  *service.ErrorPassthroughService
+	concurrencyHelper        *ConcurrencyHelper
+	maxAccountSwitches       int
+}
+
+// NewCompatibleGatewayHandler creates a new CompatibleGatewayHandler
+func NewCompatibleGatewayHandler(
+	compatibleGatewayService *service.CompatibleGatewayService,
+	gatewayService *service.GatewayService,
+	concurrencyService *service.ConcurrencyService,
+	billingCacheService *service.BillingCacheService,
+	apiKeyService *service.APIKeyService,
+	errorPassthroughService *service.ErrorPassthroughService,
+	cfg *config.Config,
+) *CompatibleGatewayHandler {
+	pingInterval := time.Duration(0)
+	maxAccountSwitches := 3
+	if cfg != nil {
+		pingInterval = time.Duration(cfg.Concurrency.PingInterval) * time.Second
+		if cfg.Gateway.MaxAccountSwitches > 0 {
+			maxAccountSwitches = cfg.Gateway.MaxAccountSwitches
+		}
+	}
+	return &CompatibleGatewayHandler{
+		compatibleGatewayService: compatibleGatewayService,
+		gatewayService:           gatewayService,
+		billingCacheService:      billingCacheService,
+		apiKeyService:            apiKeyService,
+		errorPassthroughService:  errorPassthroughService,
+		concurrencyHelper:        NewConcurrencyHelper(concurrencyService, SSEPingFormatComment, pingInterval),
+		maxAccountSwitches:       maxAccountSwitches,
+	}
+}
+
+// compatibleRequest 是两个入口解析后的公共请求信息
+type compatibleRequest struct {
+	body         []byte
+	model        string
+	stream       bool
+	includeUsage bool
+	messages     bool // true: /v1/messages（Anthropic 格式）；false: /v1/chat/completions
+	parsed       *service.ParsedRequest
+}
+
+// isCompatibleGroupRequest 判断请求是否应由 compatible 平台处理（/antigravity 等强制平台路由除外）
+func isCompatibleGroupRequest(c *gin.Context, apiKey *service.APIKey) bool {
+	if _, ok := middleware2.GetForcePlatformFromContext(c); ok {
+		return false
+	}
+	return apiKey != nil && apiKey.Group != nil && apiKey.Group.Platform == service.PlatformCompatible
+}
+
+// ChatCompletions handles Chat Completions requests for compatible groups
+// POST /v1/chat/completions
+func (h *CompatibleGatewayHandler) ChatCompletions(c *gin.Context) {
+	body, ok := h.readBody(c, false)
+	if !ok {
+		return
+	}
+	chatReq, err := service.ParseChatCompletionsRequest(body)
+	if err != nil {
+		h.errorResponse(c, false, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body: "+err.Error())
+		return
+	}
+	parsed, err := service.ParseGatewayRequest(body, domain.PlatformAnthropic)
+	if err != nil {
+		h.errorResponse(c, false, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+		return
+	}
+	h.serve(c, &compatibleRequest{
+		body:         body,
+		model:        chatReq.Model,
+		stream:       chatReq.Stream,
+		includeUsage: chatReq.IncludeUsage(),
+		parsed:       parsed,
+	})
+}
+
+// Messages handles Anthropic Messages requests for compatible groups
+// POST /v1/messages
+func (h *CompatibleGatewayHandler) Messages(c *gin.Context) {
+	body, ok := h.readBody(c, true)
+	if !ok {
+		return
+	}
+	parsed, err := service.ParseGatewayRequest(body, domain.PlatformAnthropic)
+	if err != nil {
+		h.errorResponse(c, true, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+		return
+	}
+	h.serve(c, &compatibleRequest{
+		body:     body,
+		model:    parsed.Model,
+		stream:   parsed.Stream,
+		messages: true,
+		parsed:   parsed,
+	})
+}
+
+func (h *CompatibleGatewayHandler) readBody(c *gin.Context, messages bool) ([]byte, bool) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if maxErr, ok := extractMaxBytesError(err); ok {
+			h.errorResponse(c, messages, http.StatusRequestEntityTooLarge, "invalid_request_error", buildBodyTooLargeMessage(maxErr.Limit))
+			return nil, false
+		}
+		h.errorResponse(c, messages, http.StatusBadRequest, "invalid_request_error", "Failed to read request body")
+		return nil, false
+	}
+	if len(body) == 0 {
+		h.errorResponse(c, messages, http.StatusBadRequest, "invalid_request_error", "Request body is empty")
+		return nil, false
+	}
+	setOpsRequestContext(c, "", false, body)
+	return body, true
+}
+
+func (h *CompatibleGatewayHandler) serve(c *gin.Context, req *compatibleRequest) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, req.messages, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		h.errorResponse(c, req.messages, http.StatusInternalServerError, "api_error", "User context not found")
+		return
+	}
+
+	setOpsRequestContext(c, req.model, req.stream, req.body)
+	if req.model == "" {
+		h.errorResponse(c, req.messages, http.StatusBadRequest, "invalid_request_error", "model is required")
+		return
+	}
+
+	if h.errorPassthroughService != nil {
+		service.BindErrorPassthroughService(c, h.errorPassthroughService)
+	}
+
+	subscription, _ := middleware2.GetSubscriptionFromContext(c)
+	streamStarted := false
+
+	// 0. 检查wait队列是否已满
+	maxWait := service.CalculateMaxWait(subject.Concurrency)
+	canWait, err := h.concurrencyHelper.IncrementWaitCount(c.Request.Context(), subject.UserID, maxWait)
+	waitCounted := false
+	if err != nil {
+		log.Printf("Increment wait count failed: %v", err)
+	} else if !canWait {
+		h.errorResponse(c, req.messages, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later")
+		return
+	}
+	if err == nil && canWait {
+		waitCounted = true
+	}
+	defer func() {
+		if waitCounted {
+			h.concurrencyHelper.DecrementWaitCount(c.Request.Context(), subject.UserID)
+		}
+	}()
+
+	// 1. 获取用户并发槽位
+	userReleaseFunc, err := h.concurrencyHelper.AcquireUserSlotWithWait(c, subject.UserID, subject.Concurrency, req.stream, &streamStarted)
+	if err != nil {
+		log.Printf("User concurrency acquire failed: %v", err)
+		h.handleStreamingAwareError(c, req.messages, http.StatusTooManyRequests, "rate_limit_error", "Concurrency limit exceeded for user, please retry later", streamStarted)
+		return
+	}
+	if waitCounted {
+		h.concurrencyHelper.DecrementWaitCount(c.Request.Context(), subject.UserID)
+		waitCounted = false
+	}
+	userReleaseFunc = wrapReleaseOnDone(c.Request.Context(), userReleaseFunc)
+	if userReleaseFunc != nil {
+		defer userReleaseFunc()
+	}
+
+	// 2. Wait后二次检查余额/订阅
+	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
+		log.Printf("Billing eligibility check failed after wait: %v", err)
+		status, code, message := billingErrorDetails(err)
+		h.handleStreamingAwareError(c, req.messages, status, code, message, streamStarted)
+		return
+	}
+
+	// 计算粘性会话hash（Chat Completions 与 Messages 的 messages[].content 结构一致，可复用同一算法）
+	req.parsed.SessionContext = &service.SessionContext{
+		ClientIP:  ip.GetClientIP(c),
+		UserAgent: c.GetHeader("User-Agent"),
+		APIKeyID:  apiKey.ID,
+	}
+	sessionHash := h.gatewayService.GenerateSessionHash(req.parsed)
+	var sessionBoundAccountID int64
+	if sessionHash != "" {
+		sessionBoundAccountID, _ = h.gatewayService.GetCachedSessionAccountID(c.Request.Context(), apiKey.GroupID, sessionHash)
+	}
+	hasBoundSession := sessionHash != "" && sessionBoundAccountID > 0
+
+	switchCount := 0
+	failedAccountIDs := make(map[int64]struct{})
+	var lastFailoverErr *service.UpstreamFailoverError
+	var forceCacheBilling bool
+
+	for {
+		selection, err := h.compatibleGatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, sessionHash, req.model, failedAccountIDs)
+		if err != nil {
+			log.Printf("[Compatible] SelectAccount failed: %v", err)
+			if lastFailoverErr == nil {
+				h.handleStreamingAwareError(c, req.messages, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
+				return
+			}
+			h.handleFailoverExhausted(c, req.messages, lastFailoverErr, streamStarted)
+			return
+		}
+		account := selection.Account
+		setOpsSelectedAccount(c, account.ID)
+
+		// 3. 获取账号并发槽位
+		accountReleaseFunc := selection.ReleaseFunc
+		if !selection.Acquired {
+			if selection.WaitPlan == nil {
+				h.handleStreamingAwareError(c, req.messages, http.StatusServiceUnavailable, "api_error", "No available accounts", streamStarted)
+				return
+			}
+			accountWaitCounted := false
+			canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
+			if err != nil {
+				log.Printf("Increment account wait count failed: %v", err)
+			} else if !canWait {
+				log.Printf("Account wait queue full: account=%d", account.ID)
+				h.handleStreamingAwareError(c, req.messages, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later", streamStarted)
+				return
+			}
+			if err == nil && canWait {
+				accountWaitCounted = true
+			}
+			defer func() {
+				if accountWaitCounted {
+					h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+				}
+			}()
+
+			accountReleaseFunc, err = h.concurrencyHelper.AcquireAccountSlotWithWaitTimeout(
+				c,
+				account.ID,
+				selection.WaitPlan.MaxConcurrency,
+				selection.WaitPlan.Timeout,
+				req.stream,
+				&streamStarted,
+			)
+			if err != nil {
+				log.Printf("Account concurrency acquire failed: %v", err)
+				h.handleStreamingAwareError(c, req.messages, http.StatusTooManyRequests, "rate_limit_error", "Concurrency limit exceeded for account, please retry later", streamStarted)
+				return
+			}
+			if accountWaitCounted {
+				h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+				accountWaitCounted = false
+			}
+			if err := h.compatibleGatewayService.BindStickySession(c.Request.Context(), apiKey.GroupID, sessionHash, account.ID); err != nil {
+				log.Printf("Bind sticky session failed: %v", err)
+			}
+		}
+		accountReleaseFunc = wrapReleaseOnDone(c.Request.Context(), accountReleaseFunc)
+
+		var result *service.ForwardResult
+		if req.messages {
+			result, err = h.compatibleGatewayService.ForwardMessages(c.Request.Context(), c, account, req.body, req.model, req.stream)
+		} else {
+			result, err = h.compatibleGatewayService.ForwardChatCompletions(c.Request.Context(), c, account, req.body, req.model, req.stream, req.includeUsage)
+		}
+		if accountReleaseFunc != nil {
+			accountReleaseFunc()
+		}
+		if err != nil {
+			var failoverErr *service.UpstreamFailoverError
+			if errors.As(err, &failoverErr) {
+				failedAccountIDs[account.ID] = struct{}{}
+				lastFailoverErr = failoverErr
+				if needForceCacheBilling(hasBoundSession, failoverErr) {
+					forceCacheBilling = true
+				}
+				if switchCount >= h.maxAccountSwitches {
+					h.handleFailoverExhausted(c, req.messages, failoverErr, streamStarted)
+					return
+				}
+				switchCount++
+				log.Printf("[Compatible] Account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, h.maxAccountSwitches)
+				continue
+			}
+			// 错误响应已在Forward中处理，这里只记录日志
+			log.Printf("[Compatible] Account %d: Forward request failed: %v", account.ID, err)
+			return
+		}
+
+		userAgent := c.GetHeader("User-Agent")
+		clientIP := ip.GetClientIP(c)
+
+		go func(result *service.ForwardResult, usedAccount *service.Account, ua, clientIP string, fcb bool) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := h.compatibleGatewayService.RecordUsage(ctx, &service.RecordUsageInput{
+				Result:            result,
+				APIKey:            apiKey,
+				User:              apiKey.User,
+				Account:           usedAccount,
+				Subscription:      subscription,
+				UserAgent:         ua,
+				IPAddress:         clientIP,
+				ForceCacheBilling: fcb,
+				APIKeyService:     h.apiKeyService,
+			}); err != nil {
+				log.Printf("Record usage failed: %v", err)
+			}
+		}(result, account, userAgent, clientIP, forceCacheBilling)
+		return
+	}
+}
+
+func (h *CompatibleGatewayHandler) handleFailoverExhausted(c *gin.Context, messages bool, failoverErr *service.UpstreamFailoverError, streamStarted bool) {
+	statusCode := failoverErr.StatusCode
+	responseBody := failoverErr.ResponseBody
+
+	if h.errorPassthroughService != nil && len(responseBody) > 0 {
+		if rule := h.errorPassthroughService.MatchRule(service.PlatformCompatible, statusCode, responseBody); rule != nil {
+			respCode := statusCode
+			if !rule.PassthroughCode && rule.ResponseCode != nil {
+				respCode = *rule.ResponseCode
+			}
+			msg := service.ExtractUpstreamErrorMessage(responseBody)
+			if !rule.PassthroughBody && rule.CustomMessage != nil {
+				msg = *rule.CustomMessage
+			}
+			if rule.SkipMonitoring {
+				c.Set(service.OpsSkipPassthroughKey, true)
+			}
+			h.handleStreamingAwareError(c, messages, respCode, "upstream_error", msg, streamStarted)
+			return
+		}
+	}
+
+	var status int
+	var errType, errMsg string
+	switch statusCode {
+	case 401:
+		status, errType, errMsg = http.StatusBadGateway, "upstream_error", "Upstream authentication failed, please contact administrator"
+	case 402, 403:
+		status, errType, errMsg = http.StatusBadGateway, "upstream_error", "Upstream access forbidden, please contact administrator"
+	case 429:
+		status, errType, errMsg = http.StatusTooManyRequests, "rate_limit_error", "Upstream rate limit exceeded, please retry later"
+	case 529:
+		status, errType, errMsg = http.StatusServiceUnavailable, "overloaded_error", "Upstream service overloaded, please retry later"
+	case 500, 502, 503, 504:
+		status, errType, errMsg = http.StatusBadGateway, "upstream_error", "Upstream service temporarily unavailable"
+	default:
+		status, errType, errMsg = http.StatusBadGateway, "upstream_error", "Upstream request failed"
+	}
+	h.handleStreamingAwareError(c, messages, status, errType, errMsg, streamStarted)
+}
+
+// handleStreamingAwareError 流已开始（等待槽位时已写出心跳）时以 SSE 事件输出错误
+func (h *CompatibleGatewayHandler) handleStreamingAwareError(c *gin.Context, messages bool, status int, errType, message string, streamStarted bool) {
+	if !streamStarted {
+		h.errorResponse(c, messages, status, errType, message)
+		return
+	}
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		return
+	}
+	var payload any = map[string]any{"error": map[string]string{"type": errType, "message": message}}
+	event := "data: %s\n\n"
+	if messages {
+		payload = map[string]any{"type": "error", "error": map[string]string{"type": errType, "message": message}}
+		event = "event: error\ndata: %s\n\n"
+	}
+	jsonBytes, err := json.Marshal(payload)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+	if _, err := fmt.Fprintf(c.Writer, event, jsonBytes); err != nil {
+		_ = c.Error(err)
+	}
+	flusher.Flush()
+}
+
+// errorResponse 按入口协议返回错误：Messages 使用 Anthropic 格式，Chat Completions 使用 OpenAI 格式
+func (h *CompatibleGatewayHandler) errorResponse(c *gin.Context, messages bool, status int, errType, message string) {
+	if messages {
+		c.JSON(status, gin.H{
+			"type": "error",
+			"error": gin.H{
+				"type":    errType,
+				"message": message,
+			},
+		})
+		return
+	}
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
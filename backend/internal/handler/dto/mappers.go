@@ -71,7 +71,7 @@ func APIKeyFromService(k *service.APIKey) *APIKey {
 	return &APIKey{
 		ID:          k.ID,
 		UserID:      k.UserID,
-		Key:         k.Key,
+		KeyPrefix:   k.KeyPrefix,
 		Name:        k.Name,
 		GroupID:     k.GroupID,
 		Status:      k.Status,
@@ -84,6 +84,10 @@ func APIKeyFromService(k *service.APIKey) *APIKey {
 		UpdatedAt:   k.UpdatedAt,
 		User:        UserFromServiceShallow(k.User),
 		Group:       GroupFromServiceShallow(k.Group),
+
+		RPMLimit:          k.RPMLimit,
+		TPMLimit:          k.TPMLimit,
+		DailyRequestLimit: k.DailyRequestLimit,
 	}
 }
 
@@ -116,6 +120,12 @@ func GroupFromServiceAdmin(g *service.Group) *AdminGroup {
 		SupportedModelScopes: g.SupportedModelScopes,
 		AccountCount:         g.AccountCount,
 		SortOrder:            g.SortOrder,
+
+		ResponseCacheEnabled:    g.ResponseCacheEnabled,
+		ResponseCacheTTLSeconds: g.ResponseCacheTTLSeconds,
+
+		OpenAIFallbackModelMapping: g.OpenAIFallbackModelMapping,
+		RequestPolicy:              g.RequestPolicy,
 	}
 	if len(g.AccountGroups) > 0 {
 		out.AccountGroups = make([]AccountGroup, 0, len(g.AccountGroups))
@@ -147,6 +157,9 @@ func groupFromServiceBase(g *service.Group) Group {
 		FallbackGroupID:  g.FallbackGroupID,
 		// 无效请求兜底分组
 		FallbackGroupIDOnInvalidRequest: g.FallbackGroupIDOnInvalidRequest,
+		DefaultRPMLimit:                 g.DefaultRPMLimit,
+		DefaultTPMLimit:                 g.DefaultTPMLimit,
+		DefaultDailyRequestLimit:        g.DefaultDailyRequestLimit,
 		CreatedAt:                       g.CreatedAt,
 		UpdatedAt:                       g.UpdatedAt,
 	}
@@ -187,6 +200,11 @@ func AccountFromServiceShallow(a *service.Account) *Account {
 		GroupIDs:                a.GroupIDs,
 	}
 
+	if weight := a.GetWeight(); weight > 0 {
+		out.Weight = &weight
+	}
+	out.Drain = a.DrainProgress(time.Now())
+
 	// 提取 5h 窗口费用控制和会话数量控制配置（仅 Anthropic OAuth/SetupToken 账号有效）
 	if a.IsAnthropicOAuthOrSetupToken() {
 		if limit := a.GetWindowCostLimit(); limit > 0 {
@@ -392,6 +410,7 @@ func usageLogFromServiceUser(l *service.UsageLog) UsageLog {
 		ActualCost:            l.ActualCost,
 		RateMultiplier:        l.RateMultiplier,
 		BillingType:           l.BillingType,
+		ResponseCacheHit:      l.ResponseCacheHit,
 		Stream:                l.Stream,
 		DurationMs:            l.DurationMs,
 		FirstTokenMs:          l.FirstTokenMs,
@@ -430,6 +449,80 @@ func UsageLogFromServiceAdmin(l *service.UsageLog) *AdminUsageLog {
 	}
 }
 
+func WebhookEndpointFromService(e *service.WebhookEndpoint) *WebhookEndpoint {
+	if e == nil {
+		return nil
+	}
+	events := e.Events
+	if events == nil {
+		events = []string{}
+	}
+	return &WebhookEndpoint{
+		ID:               e.ID,
+		Name:             e.Name,
+		URL:              e.URL,
+		Format:           e.Format,
+		Events:           events,
+		Enabled:          e.Enabled,
+		Description:      e.Description,
+		SecretConfigured: e.Secret != "",
+		CreatedAt:        e.CreatedAt,
+		UpdatedAt:        e.UpdatedAt,
+	}
+}
+
+func WebhookDeliveryFromService(d *service.WebhookDelivery) *WebhookDelivery {
+	if d == nil {
+		return nil
+	}
+	return &WebhookDelivery{
+		ID:             d.ID,
+		EndpointID:     d.EndpointID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
+
+func AdminAuditLogFromService(l *service.AdminAuditLog) *AdminAuditLog {
+	if l == nil {
+		return nil
+	}
+	changes := make([]AdminAuditChange, 0, len(l.Changes))
+	for _, ch := range l.Changes {
+		changes = append(changes, AdminAuditChange{
+			Entity:   ch.Entity,
+			EntityID: ch.EntityID,
+			Action:   ch.Action,
+			Before:   ch.Before,
+			After:    ch.After,
+		})
+	}
+	return &AdminAuditLog{
+		ID:          l.ID,
+		ActorUserID: l.ActorUserID,
+		AuthMethod:  l.AuthMethod,
+		Method:      l.Method,
+		Route:       l.Route,
+		Path:        l.Path,
+		StatusCode:  l.StatusCode,
+		TargetType:  l.TargetType,
+		TargetID:    l.TargetID,
+		Changes:     changes,
+		IPAddress:   l.IPAddress,
+		UserAgent:   l.UserAgent,
+		CreatedAt:   l.CreatedAt,
+	}
+}
+
 func UsageCleanupTaskFromService(task *service.UsageCleanupTask) *UsageCleanupTask {
 	if task == nil {
 		return nil
@@ -563,3 +656,65 @@ func PromoCodeUsageFromService(u *service.PromoCodeUsage) *PromoCodeUsage {
 		User:        UserFromServiceShallow(u.User),
 	}
 }
+
+// BalanceLedgerEntryFromService converts a ledger entry for user-facing endpoints.
+// Admin adjustments are stripped of the acting admin and the internal note.
+func BalanceLedgerEntryFromService(e *service.BalanceLedgerEntry) *BalanceLedgerEntry {
+	if e == nil {
+		return nil
+	}
+	out := balanceLedgerEntryFromServiceBase(e)
+	if e.Type == service.BalanceLedgerTypeAdminAdjust {
+		out.ReferenceID = nil
+		out.Note = ""
+	}
+	return &out
+}
+
+// BalanceLedgerEntryFromServiceAdmin converts a ledger entry for admin endpoints.
+func BalanceLedgerEntryFromServiceAdmin(e *service.BalanceLedgerEntry) *AdminBalanceLedgerEntry {
+	if e == nil {
+		return nil
+	}
+	return &AdminBalanceLedgerEntry{
+		BalanceLedgerEntry: balanceLedgerEntryFromServiceBase(e),
+		UserID:             e.UserID,
+	}
+}
+
+func balanceLedgerEntryFromServiceBase(e *service.BalanceLedgerEntry) BalanceLedgerEntry {
+	return BalanceLedgerEntry{
+		ID:            e.ID,
+		Type:          e.Type,
+		Amount:        e.Amount,
+		BalanceBefore: e.BalanceBefore,
+		BalanceAfter:  e.BalanceAfter,
+		ReferenceID:   e.ReferenceID,
+		APIKeyID:      e.APIKeyID,
+		Note:          e.Note,
+		CreatedAt:     e.CreatedAt,
+	}
+}
+
+func BalanceLedgerReportFromService(r *service.BalanceLedgerReport) *BalanceLedgerReport {
+	if r == nil {
+		return nil
+	}
+	drifts := make([]BalanceLedgerDrift, 0, len(r.Drifts))
+	for _, d := range r.Drifts {
+		drifts = append(drifts, BalanceLedgerDrift{
+			UserID:        d.UserID,
+			Email:         d.Email,
+			Balance:       d.Balance,
+			LedgerBalance: d.LedgerBalance,
+			Drift:         d.Drift,
+			EntryCount:    d.EntryCount,
+			LastEntryAt:   d.LastEntryAt,
+		})
+	}
+	return &BalanceLedgerReport{
+		CheckedAt: r.CheckedAt,
+		Tolerance: r.Tolerance,
+		Drifts:    drifts,
+	}
+}
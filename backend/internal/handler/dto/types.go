@@ -1,6 +1,10 @@
 package dto
 
-import "time"
+import (
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
 
 type User struct {
 	ID            int64     `json:"id"`
@@ -32,7 +36,8 @@ type AdminUser struct {
 type APIKey struct {
 	ID          int64      `json:"id"`
 	UserID      int64      `json:"user_id"`
-	Key         string     `json:"key"`
+	Key         string     `json:"key,omitempty"` // 完整 Key 仅在创建响应中返回一次
+	KeyPrefix   string     `json:"key_prefix"`
 	Name        string     `json:"name"`
 	GroupID     *int64     `json:"group_id"`
 	Status      string     `json:"status"`
@@ -44,6 +49,11 @@ type APIKey struct {
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 
+	// 限流配置（0 = 继承分组默认值）
+	RPMLimit          int `json:"rpm_limit"`
+	TPMLimit          int `json:"tpm_limit"`
+	DailyRequestLimit int `json:"daily_request_limit"`
+
 	User  *User  `json:"user,omitempty"`
 	Group *Group `json:"group,omitempty"`
 }
@@ -73,6 +83,11 @@ type Group struct {
 	// 无效请求兜底分组
 	FallbackGroupIDOnInvalidRequest *int64 `json:"fallback_group_id_on_invalid_request"`
 
+	// API Key 默认限流（0 = 不限制）
+	DefaultRPMLimit          int `json:"default_rpm_limit"`
+	DefaultTPMLimit          int `json:"default_tpm_limit"`
+	DefaultDailyRequestLimit int `json:"default_daily_request_limit"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -96,6 +111,16 @@ type AdminGroup struct {
 
 	// 分组排序
 	SortOrder int `json:"sort_order"`
+
+	// 响应缓存（TTL 为 0 时使用全局默认值）
+	ResponseCacheEnabled    bool `json:"response_cache_enabled"`
+	ResponseCacheTTLSeconds int  `json:"response_cache_ttl_seconds"`
+
+	// Claude → OpenAI 兜底模型映射
+	OpenAIFallbackModelMapping map[string]string `json:"openai_fallback_model_mapping"`
+
+	// 分组请求策略
+	RequestPolicy *service.RequestPolicy `json:"request_policy"`
 }
 
 type Account struct {
@@ -131,6 +156,13 @@ type Account struct {
 	SessionWindowEnd    *time.Time `json:"session_window_end"`
 	SessionWindowStatus string     `json:"session_window_status"`
 
+	// 同优先级内的调度权重（所有平台有效）
+	// 从 extra 字段提取，方便前端显示和编辑
+	Weight *int `json:"weight,omitempty"`
+
+	// drain 进度（仅处于 drain 状态时返回）
+	Drain *service.AccountDrainProgress `json:"drain,omitempty"`
+
 	// 5h窗口费用控制（仅 Anthropic OAuth/SetupToken 账号有效）
 	// 从 extra 字段提取，方便前端显示和编辑
 	WindowCostLimit         *float64 `json:"window_cost_limit,omitempty"`
@@ -261,10 +293,11 @@ type UsageLog struct {
 	ActualCost        float64 `json:"actual_cost"`
 	RateMultiplier    float64 `json:"rate_multiplier"`
 
-	BillingType  int8 `json:"billing_type"`
-	Stream       bool `json:"stream"`
-	DurationMs   *int `json:"duration_ms"`
-	FirstTokenMs *int `json:"first_token_ms"`
+	BillingType      int8 `json:"billing_type"`
+	ResponseCacheHit bool `json:"response_cache_hit"`
+	Stream           bool `json:"stream"`
+	DurationMs       *int `json:"duration_ms"`
+	FirstTokenMs     *int `json:"first_token_ms"`
 
 	// 图片生成字段
 	ImageCount int     `json:"image_count"`
@@ -322,6 +355,64 @@ type UsageCleanupTask struct {
 	UpdatedAt    time.Time           `json:"updated_at"`
 }
 
+// WebhookEndpoint 出站 Webhook 端点（不返回明文密钥，仅创建时返回一次）
+type WebhookEndpoint struct {
+	ID               int64     `json:"id"`
+	Name             string    `json:"name"`
+	URL              string    `json:"url"`
+	Format           string    `json:"format"`
+	Events           []string  `json:"events"`
+	Enabled          bool      `json:"enabled"`
+	Description      *string   `json:"description,omitempty"`
+	SecretConfigured bool      `json:"secret_configured"`
+	Secret           string    `json:"secret,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// WebhookDelivery Webhook 投递记录
+type WebhookDelivery struct {
+	ID             int64      `json:"id"`
+	EndpointID     int64      `json:"endpoint_id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int       `json:"last_status_code,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// AdminAuditLog 管理后台审计日志
+type AdminAuditLog struct {
+	ID          int64              `json:"id"`
+	ActorUserID *int64             `json:"actor_user_id,omitempty"`
+	AuthMethod  string             `json:"auth_method"`
+	Method      string             `json:"method"`
+	Route       string             `json:"route"`
+	Path        string             `json:"path"`
+	StatusCode  int                `json:"status_code"`
+	TargetType  string             `json:"target_type"`
+	TargetID    *string            `json:"target_id,omitempty"`
+	Changes     []AdminAuditChange `json:"changes"`
+	IPAddress   string             `json:"ip_address"`
+	UserAgent   *string            `json:"user_agent,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
+
+// AdminAuditChange 单个实体的脱敏字段差异
+type AdminAuditChange struct {
+	Entity   string         `json:"entity"`
+	EntityID int64          `json:"entity_id,omitempty"`
+	Action   string         `json:"action"`
+	Before   map[string]any `json:"before,omitempty"`
+	After    map[string]any `json:"after,omitempty"`
+}
+
 // AccountSummary is a minimal account info for usage log display.
 // It intentionally excludes sensitive fields like Credentials, Proxy, etc.
 type AccountSummary struct {
@@ -403,3 +494,42 @@ type PromoCodeUsage struct {
 
 	User *User `json:"user,omitempty"`
 }
+
+// BalanceLedgerEntry 是普通用户接口使用的余额流水 DTO。
+// 注意：admin_adjust 流水的 reference_id（操作管理员）与 note 属于内部信息，不对用户返回。
+type BalanceLedgerEntry struct {
+	ID            int64     `json:"id"`
+	Type          string    `json:"type"`
+	Amount        float64   `json:"amount"`
+	BalanceBefore float64   `json:"balance_before"`
+	BalanceAfter  float64   `json:"balance_after"`
+	ReferenceID   *int64    `json:"reference_id,omitempty"`
+	APIKeyID      *int64    `json:"api_key_id,omitempty"`
+	Note          string    `json:"note"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// AdminBalanceLedgerEntry 是管理员接口使用的余额流水 DTO（包含完整 reference_id / note）。
+type AdminBalanceLedgerEntry struct {
+	BalanceLedgerEntry
+
+	UserID int64 `json:"user_id"`
+}
+
+// BalanceLedgerDrift 流水合计与余额不一致的用户
+type BalanceLedgerDrift struct {
+	UserID        int64      `json:"user_id"`
+	Email         string     `json:"email"`
+	Balance       float64    `json:"balance"`
+	LedgerBalance float64    `json:"ledger_balance"`
+	Drift         float64    `json:"drift"`
+	EntryCount    int64      `json:"entry_count"`
+	LastEntryAt   *time.Time `json:"last_entry_at,omitempty"`
+}
+
+// BalanceLedgerReport 对账结果
+type BalanceLedgerReport struct {
+	CheckedAt time.Time            `json:"checked_at"`
+	Tolerance float64              `json:"tolerance"`
+	Drifts    []BalanceLedgerDrift `json:"drifts"`
+}
@@ -0,0 +1,281 @@
+package dto
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+// Usage export formats
+const (
+	UsageExportFormatCSV   = "csv"
+	UsageExportFormatJSONL = "jsonl"
+)
+
+// usageExportColumn 导出列定义；adminOnly 列仅在管理员导出中出现（与 AdminUsageLog 的可见性一致）
+type usageExportColumn struct {
+	name      string
+	adminOnly bool
+	value     func(l *service.UsageLog) any
+}
+
+var usageExportColumns = []usageExportColumn{
+	{name: "id", value: func(l *service.UsageLog) any { return l.ID }},
+	{name: "created_at", value: func(l *service.UsageLog) any { return l.CreatedAt.UTC().Format(time.RFC3339) }},
+	{name: "request_id", value: func(l *service.UsageLog) any { return l.RequestID }},
+	{name: "user_id", value: func(l *service.UsageLog) any { return l.UserID }},
+	{name: "user_email", value: func(l *service.UsageLog) any {
+		if l.User == nil {
+			return nil
+		}
+		return l.User.Email
+	}},
+	{name: "api_key_id", value: func(l *service.UsageLog) any { return l.APIKeyID }},
+	{name: "api_key_name", value: func(l *service.UsageLog) any {
+		if l.APIKey == nil {
+			return nil
+		}
+		return l.APIKey.Name
+	}},
+	{name: "account_id", value: func(l *service.UsageLog) any { return l.AccountID }},
+	{name: "account_name", adminOnly: true, value: func(l *service.UsageLog) any {
+		if l.Account == nil {
+			return nil
+		}
+		return l.Account.Name
+	}},
+	{name: "group_id", value: func(l *service.UsageLog) any { return l.GroupID }},
+	{name: "group_name", value: func(l *service.UsageLog) any {
+		if l.Group == nil {
+			return nil
+		}
+		return l.Group.Name
+	}},
+	{name: "subscription_id", value: func(l *service.UsageLog) any { return l.SubscriptionID }},
+	{name: "model", value: func(l *service.UsageLog) any { return l.Model }},
+	{name: "reasoning_effort", value: func(l *service.UsageLog) any { return l.ReasoningEffort }},
+	{name: "billing_type", value: func(l *service.UsageLog) any { return l.BillingType }},
+	{name: "response_cache_hit", value: func(l *service.UsageLog) any { return l.ResponseCacheHit }},
+	{name: "stream", value: func(l *service.UsageLog) any { return l.Stream }},
+	{name: "input_tokens", value: func(l *service.UsageLog) any { return l.InputTokens }},
+	{name: "output_tokens", value: func(l *service.UsageLog) any { return l.OutputTokens }},
+	{name: "cache_creation_tokens", value: func(l *service.UsageLog) any { return l.CacheCreationTokens }},
+	{name: "cache_read_tokens", value: func(l *service.UsageLog) any { return l.CacheReadTokens }},
+	{name: "cache_creation_5m_tokens", value: func(l *service.UsageLog) any { return l.CacheCreation5mTokens }},
+	{name: "cache_creation_1h_tokens", value: func(l *service.UsageLog) any { return l.CacheCreation1hTokens }},
+	{name: "image_count", value: func(l *service.UsageLog) any { return l.ImageCount }},
+	{name: "image_size", value: func(l *service.UsageLog) any { return l.ImageSize }},
+	{name: "input_cost", value: func(l *service.UsageLog) any { return l.InputCost }},
+	{name: "output_cost", value: func(l *service.UsageLog) any { return l.OutputCost }},
+	{name: "cache_creation_cost", value: func(l *service.UsageLog) any { return l.CacheCreationCost }},
+	{name: "cache_read_cost", value: func(l *service.UsageLog) any { return l.CacheReadCost }},
+	{name: "total_cost", value: func(l *service.UsageLog) any { return l.TotalCost }},
+	{name: "actual_cost", value: func(l *service.UsageLog) any { return l.ActualCost }},
+	{name: "rate_multiplier", value: func(l *service.UsageLog) any { return l.RateMultiplier }},
+	{name: "account_rate_multiplier", adminOnly: true, value: func(l *service.UsageLog) any { return l.AccountRateMultiplier }},
+	{name: "duration_ms", value: func(l *service.UsageLog) any { return l.DurationMs }},
+	{name: "first_token_ms", value: func(l *service.UsageLog) any { return l.FirstTokenMs }},
+	{name: "user_agent", value: func(l *service.UsageLog) any { return l.UserAgent }},
+	{name: "ip_address", adminOnly: true, value: func(l *service.UsageLog) any { return l.IPAddress }},
+}
+
+// usageExportFlushRows 每写出多少行主动 Flush 一次，保证大导出持续向客户端输出
+const usageExportFlushRows = 1000
+
+// IsValidUsageExportFormat reports whether format is a supported export format.
+func IsValidUsageExportFormat(format string) bool {
+	return format == UsageExportFormatCSV || format == UsageExportFormatJSONL
+}
+
+// UsageExportContentType returns the HTTP Content-Type for an export format.
+func UsageExportContentType(format string) string {
+	if format == UsageExportFormatJSONL {
+		return "application/x-ndjson; charset=utf-8"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// UsageExportFilename returns the attachment filename for an export started at now.
+func UsageExportFilename(format string, now time.Time) string {
+	return "usage_export_" + now.UTC().Format("20060102T150405Z") + "." + format
+}
+
+// UsageExportWriter 将使用记录逐行写出为 CSV 或 JSONL。
+// 仅缓冲少量行：每 usageExportFlushRows 行自动 Flush（底层实现 http.Flusher 时同时推送给客户端）。
+type UsageExportWriter struct {
+	out           io.Writer
+	columns       []usageExportColumn
+	buf           *bufio.Writer
+	csv           *csv.Writer
+	headerWritten bool
+	record        []string
+	rows          int
+}
+
+// NewUsageExportWriter creates an export writer. admin controls whether
+// admin-only columns (account name, account rate multiplier, IP) are included.
+func NewUsageExportWriter(w io.Writer, format string, admin bool) *UsageExportWriter {
+	columns := make([]usageExportColumn, 0, len(usageExportColumns))
+	for _, col := range usageExportColumns {
+		if col.adminOnly && !admin {
+			continue
+		}
+		columns = append(columns, col)
+	}
+	ew := &UsageExportWriter{
+		out:     w,
+		columns: columns,
+		buf:     bufio.NewWriter(w),
+		record:  make([]string, len(columns)),
+	}
+	if format == UsageExportFormatCSV {
+		ew.csv = csv.NewWriter(ew.buf)
+	}
+	return ew
+}
+
+// Write writes a single usage log row.
+func (w *UsageExportWriter) Write(l *service.UsageLog) error {
+	if err := w.writeRow(l); err != nil {
+		return err
+	}
+	w.rows++
+	if w.rows%usageExportFlushRows == 0 {
+		return w.Flush()
+	}
+	return nil
+}
+
+func (w *UsageExportWriter) writeRow(l *service.UsageLog) error {
+	if w.csv != nil {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+		for i, col := range w.columns {
+			w.record[i] = formatUsageExportCSVValue(col.value(l))
+		}
+		return w.csv.Write(w.record)
+	}
+
+	// JSONL：手动拼接以保持列顺序与 CSV 一致
+	if err := w.buf.WriteByte('{'); err != nil {
+		return err
+	}
+	for i, col := range w.columns {
+		if i > 0 {
+			if err := w.buf.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		key, _ := json.Marshal(col.name)
+		val, err := json.Marshal(col.value(l))
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", col.name, err)
+		}
+		if _, err := w.buf.Write(key); err != nil {
+			return err
+		}
+		if err := w.buf.WriteByte(':'); err != nil {
+			return err
+		}
+		if _, err := w.buf.Write(val); err != nil {
+			return err
+		}
+	}
+	_, err := w.buf.WriteString("}\n")
+	return err
+}
+
+// Flush flushes buffered rows to the underlying writer. For CSV the header is
+// always emitted, even when no rows were written.
+func (w *UsageExportWriter) Flush() error {
+	if w.csv != nil {
+		if err := w.writeHeader(); err != nil {
+			return err
+		}
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	if f, ok := w.out.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (w *UsageExportWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	header := make([]string, len(w.columns))
+	for i, col := range w.columns {
+		header[i] = col.name
+	}
+	return w.csv.Write(header)
+}
+
+// escapeUsageExportCSVFormula 防止 CSV 公式注入：以 = + - @ 制表符或回车开头的文本单元格前加单引号，
+// 避免 API Key 名称、User-Agent 等用户可控字段在电子表格中被当作公式执行。数值列不经过此处理。
+func escapeUsageExportCSVFormula(s string) string {
+	if s == "" {
+		return s
+	}
+	switch s[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + s
+	}
+	return s
+}
+
+func formatUsageExportCSVValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return escapeUsageExportCSVFormula(val)
+	case *string:
+		if val == nil {
+			return ""
+		}
+		return escapeUsageExportCSVFormula(*val)
+	case *int:
+		if val == nil {
+			return ""
+		}
+		return strconv.Itoa(*val)
+	case *int64:
+		if val == nil {
+			return ""
+		}
+		return strconv.FormatInt(*val, 10)
+	case *float64:
+		if val == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*val, 'f', -1, 64)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(val)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case int8:
+		return strconv.Itoa(int(val))
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		return fmt.Sprint(val)
+	}
+}
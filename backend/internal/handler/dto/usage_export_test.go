@@ -0,0 +1,151 @@
+//go:build unit
+
+package dto
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleExportUsageLog() *service.UsageLog {
+	groupID := int64(3)
+	ip := "10.0.0.1"
+	return &service.UsageLog{
+		ID:                    7,
+		UserID:                1,
+		APIKeyID:              2,
+		AccountID:             9,
+		RequestID:             "req_1",
+		Model:                 "claude-sonnet-4",
+		GroupID:               &groupID,
+		InputTokens:           100,
+		OutputTokens:          50,
+		CacheReadTokens:       10,
+		InputCost:             0.0003,
+		OutputCost:            0.00075,
+		CacheReadCost:         0.000003,
+		TotalCost:             0.001053,
+		ActualCost:            0.0021,
+		RateMultiplier:        2,
+		AccountRateMultiplier: ptrFloat(0.8),
+		IPAddress:             &ip,
+		CreatedAt:             time.Date(2026, 3, 1, 8, 0, 0, 0, time.FixedZone("UTC+8", 8*3600)),
+		User:                  &service.User{Email: "alice@example.com"},
+		APIKey:                &service.APIKey{Name: "prod"},
+		Account:               &service.Account{Name: "upstream-1"},
+	}
+}
+
+func ptrFloat(v float64) *float64 { return &v }
+
+func TestUsageExportWriterCSV(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewUsageExportWriter(&buf, UsageExportFormatCSV, false)
+	require.NoError(t, w.Write(sampleExportUsageLog()))
+	require.NoError(t, w.Flush())
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	row := make(map[string]string, len(records[0]))
+	for i, name := range records[0] {
+		row[name] = records[1][i]
+	}
+	require.Equal(t, "7", row["id"])
+	require.Equal(t, "2026-03-01T00:00:00Z", row["created_at"])
+	require.Equal(t, "alice@example.com", row["user_email"])
+	require.Equal(t, "3", row["group_id"])
+	require.Equal(t, "", row["group_name"])
+	require.Equal(t, "0.0003", row["input_cost"])
+	require.Equal(t, "0.000003", row["cache_read_cost"])
+	require.Equal(t, "0.0021", row["actual_cost"])
+	require.Equal(t, "2", row["rate_multiplier"])
+
+	// 用户导出不包含管理员字段
+	require.NotContains(t, records[0], "account_rate_multiplier")
+	require.NotContains(t, records[0], "ip_address")
+	require.NotContains(t, records[0], "account_name")
+}
+
+func TestUsageExportWriterCSVEscapesFormulas(t *testing.T) {
+	l := sampleExportUsageLog()
+	l.APIKey.Name = "=HYPERLINK(\"http://evil\")"
+	ua := "@SUM(1+1)"
+	l.UserAgent = &ua
+	l.User.Email = "-2+3@example.com"
+	l.RequestID = "\tcmd"
+	l.Model = "model+1"
+
+	var buf bytes.Buffer
+	w := NewUsageExportWriter(&buf, UsageExportFormatCSV, false)
+	require.NoError(t, w.Write(l))
+	require.NoError(t, w.Flush())
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	row := make(map[string]string, len(records[0]))
+	for i, name := range records[0] {
+		row[name] = records[1][i]
+	}
+	require.Equal(t, "'=HYPERLINK(\"http://evil\")", row["api_key_name"])
+	require.Equal(t, "'@SUM(1+1)", row["user_agent"])
+	require.Equal(t, "'-2+3@example.com", row["user_email"])
+	require.Equal(t, "'\tcmd", row["request_id"])
+	require.Equal(t, "model+1", row["model"])
+}
+
+func TestUsageExportWriterCSVEmptyHasHeader(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewUsageExportWriter(&buf, UsageExportFormatCSV, true)
+	require.NoError(t, w.Flush())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+	require.True(t, strings.HasPrefix(lines[0], "id,created_at,"))
+}
+
+func TestUsageExportWriterJSONLAdmin(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewUsageExportWriter(&buf, UsageExportFormatJSONL, true)
+	log := sampleExportUsageLog()
+	require.NoError(t, w.Write(log))
+	log.ID = 8
+	log.AccountRateMultiplier = nil
+	require.NoError(t, w.Write(log))
+	require.NoError(t, w.Flush())
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var first map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.Equal(t, float64(7), first["id"])
+	require.Equal(t, 0.0003, first["input_cost"])
+	require.Equal(t, 0.000003, first["cache_read_cost"])
+	require.Equal(t, 0.0021, first["actual_cost"])
+	require.Equal(t, float64(2), first["rate_multiplier"])
+	require.Equal(t, 0.8, first["account_rate_multiplier"])
+	require.Equal(t, "upstream-1", first["account_name"])
+	require.Equal(t, "10.0.0.1", first["ip_address"])
+	require.True(t, strings.HasPrefix(lines[0], `{"id":7,"created_at":`), "columns keep a stable order")
+
+	var second map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+	require.Contains(t, second, "account_rate_multiplier")
+	require.Nil(t, second["account_rate_multiplier"])
+}
+
+func TestIsValidUsageExportFormat(t *testing.T) {
+	require.True(t, IsValidUsageExportFormat("csv"))
+	require.True(t, IsValidUsageExportFormat("jsonl"))
+	require.False(t, IsValidUsageExportFormat("xlsx"))
+	require.Equal(t, "usage_export_20260301T000000Z.jsonl", UsageExportFilename("jsonl", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,310 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EmbeddingsHandler handles the OpenAI Embeddings compatible endpoint.
+//
+// OpenAI 分组透传到 OpenAI API Key 账号，Gemini 分组转换为 batchEmbedContents；
+// 并发控制、计费校验、故障切换与使用记录流程与 Responses 端点一致（embeddings 不支持流式）。
+type EmbeddingsHandler struct {
+	embeddingService        *service.EmbeddingService
+	billingCacheService     *service.BillingCacheService
+	apiKeyService           *service.APIKeyService
+	errorPassthroughService *service.ErrorPassthroughService
+	concurrencyHelper       *ConcurrencyHelper
+	maxAccountSwitches      int
+}
+
+// NewEmbeddingsHandler creates a new EmbeddingsHandler
+func NewEmbeddingsHandler(
+	embeddingService *service.EmbeddingService,
+	concurrencyService *service.ConcurrencyService,
+	billingCacheService *service.BillingCacheService,
+	apiKeyService *service.APIKeyService,
+	errorPassthroughService *service.ErrorPassthroughService,
+	cfg *config.Config,
+) *EmbeddingsHandler {
+	maxAccountSwitches := 3
+	if cfg != nil && cfg.Gateway.MaxAccountSwitches > 0 {
+		maxAccountSwitches = cfg.Gateway.MaxAccountSwitches
+	}
+	return &EmbeddingsHandler{
+		embeddingService:        embeddingService,
+		billingCacheService:     billingCacheService,
+		apiKeyService:           apiKeyService,
+		errorPassthroughService: errorPassthroughService,
+		concurrencyHelper:       NewConcurrencyHelper(concurrencyService, SSEPingFormatNone, 0),
+		maxAccountSwitches:      maxAccountSwitches,
+	}
+}
+
+// Embeddings handles OpenAI Embeddings API endpoint
+// POST /v1/embeddings
+//
+// 计费：OpenAI 分组按上游返回的 usage.prompt_tokens 计费；Gemini 分组优先使用上游返回的 token 数，
+// 上游未返回 usage 时（AI Studio batchEmbedContents 目前不返回）按输入文本估算 token 数计费，
+// 响应中的 usage 同样为估算值。
+func (h *EmbeddingsHandler) Embeddings(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusInternalServerError, "api_error", "User context not found")
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if maxErr, ok := extractMaxBytesError(err); ok {
+			h.errorResponse(c, http.StatusRequestEntityTooLarge, "invalid_request_error", buildBodyTooLargeMessage(maxErr.Limit))
+			return
+		}
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to read request body")
+		return
+	}
+	if len(body) == 0 {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Request body is empty")
+		return
+	}
+
+	setOpsRequestContext(c, "", false, body)
+
+	req, err := service.ParseEmbeddingsRequest(body)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body: "+err.Error())
+		return
+	}
+
+	setOpsRequestContext(c, req.Model, false, body)
+
+	platform, err := h.embeddingService.ResolvePlatform(apiKey.Group, req.Model)
+	if err != nil {
+		h.errorResponse(c, http.StatusForbidden, "permission_error", err.Error())
+		return
+	}
+
+	if h.errorPassthroughService != nil {
+		service.BindErrorPassthroughService(c, h.errorPassthroughService)
+	}
+
+	subscription, _ := middleware2.GetSubscriptionFromContext(c)
+
+	// 0. Check if wait queue is full
+	streamStarted := false
+	maxWait := service.CalculateMaxWait(subject.Concurrency)
+	canWait, err := h.concurrencyHelper.IncrementWaitCount(c.Request.Context(), subject.UserID, maxWait)
+	waitCounted := false
+	if err != nil {
+		log.Printf("Increment wait count failed: %v", err)
+	} else if !canWait {
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later")
+		return
+	}
+	if err == nil && canWait {
+		waitCounted = true
+	}
+	defer func() {
+		if waitCounted {
+			h.concurrencyHelper.DecrementWaitCount(c.Request.Context(), subject.UserID)
+		}
+	}()
+
+	// 1. Acquire user concurrency slot
+	userReleaseFunc, err := h.concurrencyHelper.AcquireUserSlotWithWait(c, subject.UserID, subject.Concurrency, false, &streamStarted)
+	if err != nil {
+		log.Printf("User concurrency acquire failed: %v", err)
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Concurrency limit exceeded for user, please retry later")
+		return
+	}
+	if waitCounted {
+		h.concurrencyHelper.DecrementWaitCount(c.Request.Context(), subject.UserID)
+		waitCounted = false
+	}
+	userReleaseFunc = wrapReleaseOnDone(c.Request.Context(), userReleaseFunc)
+	if userReleaseFunc != nil {
+		defer userReleaseFunc()
+	}
+
+	// 2. Re-check billing eligibility after wait
+	if err := h.billingCacheService.CheckBillingEligibility(c.Request.Context(), apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
+		log.Printf("Billing eligibility check failed after wait: %v", err)
+		status, code, message := billingErrorDetails(err)
+		h.errorResponse(c, status, code, message)
+		return
+	}
+
+	switchCount := 0
+	failedAccountIDs := make(map[int64]struct{})
+	var lastFailoverErr *service.UpstreamFailoverError
+	var lastPlatform string
+
+	for {
+		selection, err := h.embeddingService.SelectAccount(c.Request.Context(), apiKey.Group, apiKey.GroupID, platform, req.Model, failedAccountIDs)
+		if err != nil {
+			log.Printf("[Embeddings] SelectAccount failed: %v", err)
+			if lastFailoverErr == nil {
+				h.errorResponse(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error())
+				return
+			}
+			h.handleFailoverExhausted(c, lastPlatform, lastFailoverErr)
+			return
+		}
+		account := selection.Account
+		setOpsSelectedAccount(c, account.ID)
+
+		// 3. Acquire account concurrency slot
+		accountReleaseFunc := selection.ReleaseFunc
+		if !selection.Acquired {
+			if selection.WaitPlan == nil {
+				h.errorResponse(c, http.StatusServiceUnavailable, "api_error", "No available accounts")
+				return
+			}
+			accountWaitCounted := false
+			canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
+			if err != nil {
+				log.Printf("Increment account wait count failed: %v", err)
+			} else if !canWait {
+				log.Printf("Account wait queue full: account=%d", account.ID)
+				h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later")
+				return
+			}
+			if err == nil && canWait {
+				accountWaitCounted = true
+			}
+			defer func() {
+				if accountWaitCounted {
+					h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+				}
+			}()
+
+			accountReleaseFunc, err = h.concurrencyHelper.AcquireAccountSlotWithWaitTimeout(
+				c,
+				account.ID,
+				selection.WaitPlan.MaxConcurrency,
+				selection.WaitPlan.Timeout,
+				false,
+				&streamStarted,
+			)
+			if err != nil {
+				log.Printf("Account concurrency acquire failed: %v", err)
+				h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Concurrency limit exceeded for account, please retry later")
+				return
+			}
+			if accountWaitCounted {
+				h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+				accountWaitCounted = false
+			}
+		}
+		accountReleaseFunc = wrapReleaseOnDone(c.Request.Context(), accountReleaseFunc)
+
+		result, err := h.embeddingService.Forward(c.Request.Context(), c, account, req, body)
+		if accountReleaseFunc != nil {
+			accountReleaseFunc()
+		}
+		if err != nil {
+			var failoverErr *service.UpstreamFailoverError
+			if errors.As(err, &failoverErr) {
+				failedAccountIDs[account.ID] = struct{}{}
+				lastFailoverErr = failoverErr
+				lastPlatform = account.Platform
+				if switchCount >= h.maxAccountSwitches {
+					h.handleFailoverExhausted(c, account.Platform, failoverErr)
+					return
+				}
+				switchCount++
+				log.Printf("[Embeddings] Account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, h.maxAccountSwitches)
+				continue
+			}
+			// Error response already handled in Forward, just log
+			log.Printf("[Embeddings] Account %d: Forward request failed: %v", account.ID, err)
+			return
+		}
+
+		userAgent := c.GetHeader("User-Agent")
+		clientIP := ip.GetClientIP(c)
+
+		go func(result *service.ForwardResult, usedAccount *service.Account, ua, ip string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := h.embeddingService.RecordUsage(ctx, &service.RecordUsageInput{
+				Result:        result,
+				APIKey:        apiKey,
+				User:          apiKey.User,
+				Account:       usedAccount,
+				Subscription:  subscription,
+				UserAgent:     ua,
+				IPAddress:     ip,
+				APIKeyService: h.apiKeyService,
+			}); err != nil {
+				log.Printf("Record usage failed: %v", err)
+			}
+		}(result, account, userAgent, clientIP)
+		return
+	}
+}
+
+func (h *EmbeddingsHandler) handleFailoverExhausted(c *gin.Context, platform string, failoverErr *service.UpstreamFailoverError) {
+	statusCode := failoverErr.StatusCode
+	responseBody := failoverErr.ResponseBody
+
+	if h.errorPassthroughService != nil && len(responseBody) > 0 {
+		if rule := h.errorPassthroughService.MatchRule(platform, statusCode, responseBody); rule != nil {
+			respCode := statusCode
+			if !rule.PassthroughCode && rule.ResponseCode != nil {
+				respCode = *rule.ResponseCode
+			}
+			msg := service.ExtractUpstreamErrorMessage(responseBody)
+			if !rule.PassthroughBody && rule.CustomMessage != nil {
+				msg = *rule.CustomMessage
+			}
+			if rule.SkipMonitoring {
+				c.Set(service.OpsSkipPassthroughKey, true)
+			}
+			h.errorResponse(c, respCode, "upstream_error", msg)
+			return
+		}
+	}
+
+	switch statusCode {
+	case 401:
+		h.errorResponse(c, http.StatusBadGateway, "upstream_error", "Upstream authentication failed, please contact administrator")
+	case 403:
+		h.errorResponse(c, http.StatusBadGateway, "upstream_error", "Upstream access forbidden, please contact administrator")
+	case 429:
+		h.errorResponse(c, http.StatusTooManyRequests, "rate_limit_error", "Upstream rate limit exceeded, please retry later")
+	case 529:
+		h.errorResponse(c, http.StatusServiceUnavailable, "upstream_error", "Upstream service overloaded, please retry later")
+	case 500, 502, 503, 504:
+		h.errorResponse(c, http.StatusBadGateway, "upstream_error", "Upstream service temporarily unavailable")
+	default:
+		h.errorResponse(c, http.StatusBadGateway, "upstream_error", "Upstream request failed")
+	}
+}
+
+// errorResponse returns OpenAI API format error response
+func (h *EmbeddingsHandler) errorResponse(c *gin.Context, status int, errType, message string) {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
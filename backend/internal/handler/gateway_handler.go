@@ -16,6 +16,7 @@ import (
 	"github.com/Wei-Shaw/sub2api/internal/domain"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/antigravity"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/compatible"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
 	pkgerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
@@ -31,11 +32,14 @@ type GatewayHandler struct {
 	gatewayService            *service.GatewayService
 	geminiCompatService       *service.GeminiMessagesCompatService
 	antigravityGatewayService *service.AntigravityGatewayService
+	openAIGatewayService      *service.OpenAIGatewayService
 	userService               *service.UserService
 	billingCacheService       *service.BillingCacheService
 	usageService              *service.UsageService
 	apiKeyService             *service.APIKeyService
 	errorPassthroughService   *service.ErrorPassthroughService
+	responseCacheService      *service.ResponseCacheService
+	compatibleGateway         *CompatibleGatewayHandler
 	concurrencyHelper         *ConcurrencyHelper
 	maxAccountSwitches        int
 	maxAccountSwitchesGemini  int
@@ -46,12 +50,15 @@ func NewGatewayHandler(
 	gatewayService *service.GatewayService,
 	geminiCompatService *service.GeminiMessagesCompatService,
 	antigravityGatewayService *service.AntigravityGatewayService,
+	openAIGatewayService *service.OpenAIGatewayService,
 	userService *service.UserService,
 	concurrencyService *service.ConcurrencyService,
 	billingCacheService *service.BillingCacheService,
 	usageService *service.UsageService,
 	apiKeyService *service.APIKeyService,
 	errorPassthroughService *service.ErrorPassthroughService,
+	responseCacheService *service.ResponseCacheService,
+	compatibleGateway *CompatibleGatewayHandler,
 	cfg *config.Config,
 ) *GatewayHandler {
 	pingInterval := time.Duration(0)
@@ -70,11 +77,14 @@ func NewGatewayHandler(
 		gatewayService:            gatewayService,
 		geminiCompatService:       geminiCompatService,
 		antigravityGatewayService: antigravityGatewayService,
+		openAIGatewayService:      openAIGatewayService,
 		userService:               userService,
 		billingCacheService:       billingCacheService,
 		usageService:              usageService,
 		apiKeyService:             apiKeyService,
 		errorPassthroughService:   errorPassthroughService,
+		responseCacheService:      responseCacheService,
+		compatibleGateway:         compatibleGateway,
 		concurrencyHelper:         NewConcurrencyHelper(concurrencyService, SSEPingFormatClaude, pingInterval),
 		maxAccountSwitches:        maxAccountSwitches,
 		maxAccountSwitchesGemini:  maxAccountSwitchesGemini,
@@ -97,6 +107,12 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		return
 	}
 
+	// OpenAI 兼容上游分组：请求转换为 Chat Completions，由独立流程处理
+	if h.compatibleGateway != nil && isCompatibleGroupRequest(c, apiKey) {
+		h.compatibleGateway.Messages(c)
+		return
+	}
+
 	// 读取请求体
 	body, err := io.ReadAll(c.Request.Body)
 	if err != nil {
@@ -115,6 +131,15 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 
 	setOpsRequestContext(c, "", false, body)
 
+	// 分组请求策略：在响应缓存查找与账号调度之前执行，后续流程（含缓存键）均使用改写后的请求体；
+	// 运维日志保留客户端原始请求体（落库前按检测结果掩码），重试时重新执行策略
+	rawBody := body
+	body, err = service.ApplyClaudeRequestPolicy(c, body)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
 	parsedReq, err := service.ParseGatewayRequest(body, domain.PlatformAnthropic)
 	if err != nil {
 		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
@@ -137,7 +162,7 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 	// 在请求上下文中记录 thinking 状态，供 Antigravity 最终模型 key 推导/模型维度限流使用
 	c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ctxkey.ThinkingEnabled, parsedReq.ThinkingEnabled))
 
-	setOpsRequestContext(c, reqModel, reqStream, body)
+	setOpsRequestContext(c, reqModel, reqStream, rawBody)
 
 	// 验证 model 必填
 	if reqModel == "" {
@@ -203,6 +228,19 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 		return
 	}
 
+	// 响应缓存：分组开启且为确定性请求（temperature=0）时，命中直接回放，跳过账号调度
+	cacheReq := h.responseCacheService.Prepare(apiKey.Group, service.ResponseCacheEndpointMessages, reqModel, reqStream, body)
+	if entry := h.responseCacheService.Lookup(c.Request.Context(), cacheReq); entry != nil {
+		h.replayResponseCacheHit(c, apiKey, subscription, entry, reqStream)
+		return
+	}
+	var cacheWriter *responseCacheWriter
+	if cacheReq != nil {
+		cacheWriter = newResponseCacheWriter(c.Writer, h.responseCacheService.MaxEntryBytes())
+		c.Writer = cacheWriter
+		defer func() { c.Writer = cacheWriter.ResponseWriter }()
+	}
+
 	// 计算粘性会话hash
 	parsedReq.SessionContext = &service.SessionContext{
 		ClientIP:  ip.GetClientIP(c),
@@ -349,6 +387,9 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 			if switchCount > 0 {
 				requestCtx = context.WithValue(requestCtx, ctxkey.AccountSwitchCount, switchCount)
 			}
+			if cacheWriter != nil {
+				cacheWriter.reset()
+			}
 			if account.Platform == service.PlatformAntigravity {
 				result, err = h.antigravityGatewayService.ForwardGemini(requestCtx, c, account, reqModel, "generateContent", reqStream, body, hasBoundSession)
 			} else {
@@ -400,6 +441,8 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 				return
 			}
 
+			h.storeResponseCache(c, cacheReq, cacheWriter, account.ID, result)
+
 			// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
 			userAgent := c.GetHeader("User-Agent")
 			clientIP := ip.GetClientIP(c)
@@ -455,6 +498,10 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 			selection, err := h.gatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), currentAPIKey.GroupID, sessionKey, reqModel, failedAccountIDs, parsedReq.MetadataUserID)
 			if err != nil {
 				if len(failedAccountIDs) == 0 {
+					// Claude 账号均不可用：分组配置了 OpenAI 兜底映射时转换协议交给 OpenAI 账号处理
+					if h.forwardOpenAIFallback(c, currentAPIKey, currentSubscription, platform, body, reqModel, reqStream, sessionHash, &streamStarted) {
+						return
+					}
 					h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts: "+err.Error(), streamStarted)
 					return
 				}
@@ -471,6 +518,9 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 						continue
 					}
 				}
+				if h.forwardOpenAIFallback(c, currentAPIKey, currentSubscription, platform, body, reqModel, reqStream, sessionHash, &streamStarted) {
+					return
+				}
 				if lastFailoverErr != nil {
 					h.handleFailoverExhausted(c, lastFailoverErr, platform, streamStarted)
 				} else {
@@ -552,6 +602,9 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 			if switchCount > 0 {
 				requestCtx = context.WithValue(requestCtx, ctxkey.AccountSwitchCount, switchCount)
 			}
+			if cacheWriter != nil {
+				cacheWriter.reset()
+			}
 			if account.Platform == service.PlatformAntigravity && account.Type != service.AccountTypeAPIKey {
 				result, err = h.antigravityGatewayService.Forward(requestCtx, c, account, body, hasBoundSession)
 			} else {
@@ -621,6 +674,9 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 
 					failedAccountIDs[account.ID] = struct{}{}
 					if switchCount >= maxAccountSwitches {
+						if h.forwardOpenAIFallback(c, currentAPIKey, currentSubscription, platform, body, reqModel, reqStream, sessionHash, &streamStarted) {
+							return
+						}
 						h.handleFailoverExhausted(c, failoverErr, account.Platform, streamStarted)
 						return
 					}
@@ -638,6 +694,11 @@ func (h *GatewayHandler) Messages(c *gin.Context) {
 				return
 			}
 
+			// 兜底分组的响应不写入原分组缓存
+			if !fallbackUsed {
+				h.storeResponseCache(c, cacheReq, cacheWriter, account.ID, result)
+			}
+
 			// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
 			userAgent := c.GetHeader("User-Agent")
 			clientIP := ip.GetClientIP(c)
@@ -712,6 +773,13 @@ func (h *GatewayHandler) Models(c *gin.Context) {
 		})
 		return
 	}
+	if platform == service.PlatformCompatible {
+		c.JSON(http.StatusOK, gin.H{
+			"object": "list",
+			"data":   compatible.DefaultModels,
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"object": "list",
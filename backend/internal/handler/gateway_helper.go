@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -239,6 +240,25 @@ func (h *ConcurrencyHelper) waitForSlotWithPingTimeout(c *gin.Context, slotType
 		pingCh = pingTicker.C
 	}
 
+	// 账号槽位启用公平排队时，登记 ticket 按流（用户 / API Key）轮流获取槽位
+	var fairQueue *service.FairQueueService
+	var ticket *service.FairQueueTicket
+	if slotType == "account" {
+		fairQueue = h.concurrencyService.FairQueue()
+	}
+	if fairQueue != nil {
+		apiKey, _ := middleware2.GetAPIKeyFromContext(c)
+		ticket, err = fairQueue.Enqueue(ctx, id, apiKey)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if ticket != nil {
+				fairQueue.Cancel(ticket)
+			}
+		}()
+	}
+
 	backoff := initialBackoff
 	timer := time.NewTimer(backoff)
 	defer timer.Stop()
@@ -267,6 +287,32 @@ func (h *ConcurrencyHelper) waitForSlotWithPingTimeout(c *gin.Context, slotType
 			flusher.Flush()
 
 		case <-timer.C:
+			if ticket != nil {
+				result, queued, err := h.concurrencyService.AcquireAccountSlotFair(ctx, ticket, maxConcurrency)
+				if err != nil {
+					return nil, err
+				}
+				if result.Acquired {
+					ticket = nil
+					return result.ReleaseFunc, nil
+				}
+				if queued.Lost {
+					// ticket 因长时间未心跳被清理，重新入队
+					apiKey, _ := middleware2.GetAPIKeyFromContext(c)
+					if ticket, err = fairQueue.Enqueue(ctx, id, apiKey); err != nil {
+						return nil, err
+					}
+				}
+				// 即将轮到时快速轮询，排在后面时继续指数退避
+				if queued.Position < maxConcurrency {
+					backoff = initialBackoff
+				} else {
+					backoff = nextBackoff(backoff, rng)
+				}
+				timer.Reset(backoff)
+				continue
+			}
+
 			// Try to acquire slot
 			var result *service.AcquireResult
 			var err error
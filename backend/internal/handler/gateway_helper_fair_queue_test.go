@@ -0,0 +1,95 @@
+//go:build unit
+
+package handler
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type helperConcurrencyCacheStub struct {
+	service.ConcurrencyCache
+}
+
+func (helperConcurrencyCacheStub) ReleaseAccountSlot(ctx context.Context, accountID int64, requestID string) error {
+	return nil
+}
+
+type helperFairQueueCacheStub struct {
+	service.FairQueueCache
+	grantAfter int
+	tries      int
+	enqueued   int
+	canceled   int
+}
+
+func (c *helperFairQueueCacheStub) Enqueue(ctx context.Context, ticket *service.FairQueueTicket) error {
+	c.enqueued++
+	return nil
+}
+
+func (c *helperFairQueueCacheStub) TryAcquire(ctx context.Context, ticket *service.FairQueueTicket, maxConcurrency int, requestID string) (*service.FairQueueAcquireResult, error) {
+	c.tries++
+	if c.grantAfter > 0 && c.tries >= c.grantAfter {
+		return &service.FairQueueAcquireResult{Acquired: true}, nil
+	}
+	return &service.FairQueueAcquireResult{Position: 0}, nil
+}
+
+func (c *helperFairQueueCacheStub) Cancel(ctx context.Context, ticket *service.FairQueueTicket) error {
+	c.canceled++
+	return nil
+}
+
+func (c *helperFairQueueCacheStub) AcquireAccountSlotYielding(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
+	return false, nil
+}
+
+func newFairQueueHelper(fairCache *helperFairQueueCacheStub) *ConcurrencyHelper {
+	cfg := &config.Config{}
+	cfg.Gateway.Scheduling.FairQueue = config.GatewayFairQueueConfig{Enabled: true, Key: service.FairQueueKeyUser}
+	concurrency := service.NewConcurrencyService(helperConcurrencyCacheStub{})
+	concurrency.SetFairQueue(service.NewFairQueueService(fairCache, cfg))
+	return NewConcurrencyHelper(concurrency, SSEPingFormatNone, time.Second)
+}
+
+func TestWaitForSlot_FairQueueGrantsQueuedTicket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/v1/messages", nil)
+
+	fairCache := &helperFairQueueCacheStub{grantAfter: 2}
+	helper := newFairQueueHelper(fairCache)
+
+	streamStarted := false
+	release, err := helper.AcquireAccountSlotWithWaitTimeout(c, 1, 2, 5*time.Second, false, &streamStarted)
+	require.NoError(t, err)
+	require.NotNil(t, release)
+	require.Equal(t, 1, fairCache.enqueued)
+	require.Equal(t, 2, fairCache.tries)
+	require.Zero(t, fairCache.canceled, "granted ticket is already dequeued")
+}
+
+func TestWaitForSlot_FairQueueCancelsTicketOnTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/v1/messages", nil)
+
+	fairCache := &helperFairQueueCacheStub{}
+	helper := newFairQueueHelper(fairCache)
+
+	streamStarted := false
+	_, err := helper.AcquireAccountSlotWithWaitTimeout(c, 1, 2, 300*time.Millisecond, false, &streamStarted)
+	var concurrencyErr *ConcurrencyError
+	require.ErrorAs(t, err, &concurrencyErr)
+	require.True(t, concurrencyErr.IsTimeout)
+	require.Equal(t, 1, fairCache.enqueued)
+	require.Equal(t, 1, fairCache.canceled)
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// forwardOpenAIFallback 在 anthropic 分组的 Claude 账号全部不可用时，按分组 openai_fallback_model_mapping
+// 将 /v1/messages 请求转换为 Responses API，交给分组内绑定的 OpenAI 账号处理，响应再转换回 Anthropic 格式。
+//
+// 返回 false 表示未启用兜底或没有可用的 OpenAI 账号，调用方应继续输出原有错误；
+// 返回 true 表示响应（成功或错误）已写出。
+func (h *GatewayHandler) forwardOpenAIFallback(
+	c *gin.Context,
+	apiKey *service.APIKey,
+	subscription *service.UserSubscription,
+	platform string,
+	body []byte,
+	reqModel string,
+	reqStream bool,
+	sessionHash string,
+	streamStarted *bool,
+) bool {
+	if h.openAIGatewayService == nil || platform != service.PlatformAnthropic || apiKey.Group == nil {
+		return false
+	}
+	openAIModel := apiKey.Group.GetOpenAIFallbackModel(reqModel)
+	if openAIModel == "" {
+		return false
+	}
+	converted, err := service.ConvertClaudeMessagesToResponses(body, openAIModel)
+	if err != nil {
+		log.Printf("[OpenAI Fallback] Convert request failed: group=%d model=%s err=%v", apiKey.Group.ID, reqModel, err)
+		return false
+	}
+	toolNames := service.ClaudeRequestToolNames(body)
+
+	maxAccountSwitches := h.maxAccountSwitches
+	switchCount := 0
+	failedAccountIDs := make(map[int64]struct{})
+	var lastFailoverErr *service.UpstreamFailoverError
+
+	for {
+		selection, err := h.openAIGatewayService.SelectAccountWithLoadAwareness(c.Request.Context(), apiKey.GroupID, sessionHash, openAIModel, failedAccountIDs)
+		if err != nil {
+			if len(failedAccountIDs) == 0 {
+				log.Printf("[OpenAI Fallback] No OpenAI account available: group=%d model=%s err=%v", apiKey.Group.ID, openAIModel, err)
+				return false
+			}
+			if lastFailoverErr != nil {
+				h.handleFailoverExhausted(c, lastFailoverErr, service.PlatformOpenAI, *streamStarted)
+			} else {
+				h.handleFailoverExhaustedSimple(c, 502, *streamStarted)
+			}
+			return true
+		}
+		account := selection.Account
+		log.Printf("[OpenAI Fallback] Serving %s via OpenAI account %d as %s", reqModel, account.ID, openAIModel)
+		setOpsSelectedAccount(c, account.ID)
+
+		accountReleaseFunc := selection.ReleaseFunc
+		if !selection.Acquired {
+			if selection.WaitPlan == nil {
+				h.handleStreamingAwareError(c, http.StatusServiceUnavailable, "api_error", "No available accounts", *streamStarted)
+				return true
+			}
+			accountWaitCounted := false
+			canWait, err := h.concurrencyHelper.IncrementAccountWaitCount(c.Request.Context(), account.ID, selection.WaitPlan.MaxWaiting)
+			if err != nil {
+				log.Printf("Increment account wait count failed: %v", err)
+			} else if !canWait {
+				log.Printf("Account wait queue full: account=%d", account.ID)
+				h.handleStreamingAwareError(c, http.StatusTooManyRequests, "rate_limit_error", "Too many pending requests, please retry later", *streamStarted)
+				return true
+			}
+			if err == nil && canWait {
+				accountWaitCounted = true
+			}
+			defer func() {
+				if accountWaitCounted {
+					h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+				}
+			}()
+
+			accountReleaseFunc, err = h.concurrencyHelper.AcquireAccountSlotWithWaitTimeout(
+				c,
+				account.ID,
+				selection.WaitPlan.MaxConcurrency,
+				selection.WaitPlan.Timeout,
+				reqStream,
+				streamStarted,
+			)
+			if err != nil {
+				log.Printf("Account concurrency acquire failed: %v", err)
+				h.handleConcurrencyError(c, err, "account", *streamStarted)
+				return true
+			}
+			if accountWaitCounted {
+				h.concurrencyHelper.DecrementAccountWaitCount(c.Request.Context(), account.ID)
+				accountWaitCounted = false
+			}
+			if err := h.openAIGatewayService.BindStickySession(c.Request.Context(), apiKey.GroupID, sessionHash, account.ID); err != nil {
+				log.Printf("Bind sticky session failed: %v", err)
+			}
+		}
+		// 账号槽位/等待计数需要在超时或断开时安全回收
+		accountReleaseFunc = wrapReleaseOnDone(c.Request.Context(), accountReleaseFunc)
+
+		result, err := h.forwardOpenAIFallbackOnce(c, account, converted, reqModel, reqStream, toolNames)
+		if accountReleaseFunc != nil {
+			accountReleaseFunc()
+		}
+		if err != nil {
+			var failoverErr *service.UpstreamFailoverError
+			if errors.As(err, &failoverErr) {
+				failedAccountIDs[account.ID] = struct{}{}
+				lastFailoverErr = failoverErr
+				if switchCount >= maxAccountSwitches {
+					h.handleFailoverExhausted(c, failoverErr, service.PlatformOpenAI, *streamStarted)
+					return true
+				}
+				switchCount++
+				log.Printf("Account %d: upstream error %d, switching account %d/%d", account.ID, failoverErr.StatusCode, switchCount, maxAccountSwitches)
+				continue
+			}
+			// 错误响应已在 Forward 中写出（并转换为 Anthropic 格式），这里只记录日志
+			log.Printf("Account %d: Forward request failed: %v", account.ID, err)
+			return true
+		}
+
+		// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
+		userAgent := c.GetHeader("User-Agent")
+		clientIP := ip.GetClientIP(c)
+
+		go func(result *service.OpenAIForwardResult, usedAccount *service.Account, ua, clientIP string) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := h.openAIGatewayService.RecordUsage(ctx, &service.OpenAIRecordUsageInput{
+				Result:        result,
+				APIKey:        apiKey,
+				User:          apiKey.User,
+				Account:       usedAccount,
+				Subscription:  subscription,
+				UserAgent:     ua,
+				IPAddress:     clientIP,
+				APIKeyService: h.apiKeyService,
+			}); err != nil {
+				log.Printf("Record usage failed: %v", err)
+			}
+		}(result, account, userAgent, clientIP)
+		return true
+	}
+}
+
+// forwardOpenAIFallbackOnce 以 Responses 请求体调用 OpenAI 账号，并将写出的响应转换为 Anthropic 格式
+func (h *GatewayHandler) forwardOpenAIFallbackOnce(c *gin.Context, account *service.Account, converted []byte, reqModel string, reqStream bool, toolNames []string) (*service.OpenAIForwardResult, error) {
+	writer := newClaudeMessagesWriter(c.Writer, reqStream,
+		service.NewResponsesClaudeStreamConverter(reqModel, toolNames),
+		func(b []byte) ([]byte, error) { return service.ConvertResponsesToClaudeMessage(b, reqModel, toolNames) })
+
+	originalWriter := c.Writer
+	c.Writer = writer
+	defer func() {
+		writer.finalize()
+		c.Writer = originalWriter
+	}()
+
+	return h.openAIGatewayService.Forward(c.Request.Context(), c, account, converted)
+}
+
+// newClaudeMessagesWriter 创建将 Responses 响应转换为 Anthropic Messages 格式的写出器
+func newClaudeMessagesWriter(w gin.ResponseWriter, stream bool, streamConv service.ChatCompletionsStreamConverter, convertBody func([]byte) ([]byte, error)) *chatCompletionsWriter {
+	writer := newChatCompletionsWriter(w, stream, streamConv, convertBody)
+	writer.convertError = service.ConvertErrorToClaudeMessages
+	return writer
+}
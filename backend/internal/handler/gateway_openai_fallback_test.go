@@ -0,0 +1,118 @@
+//go:build unit
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func newResponsesClaudeWriter(c *gin.Context, stream bool) *chatCompletionsWriter {
+	toolNames := []string{"Edit"}
+	return newClaudeMessagesWriter(c.Writer, stream,
+		service.NewResponsesClaudeStreamConverter("claude-sonnet-4-5", toolNames),
+		func(b []byte) ([]byte, error) {
+			return service.ConvertResponsesToClaudeMessage(b, "claude-sonnet-4-5", toolNames)
+		})
+}
+
+func TestClaudeMessagesWriter_NonStreaming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newResponsesClaudeWriter(c, false)
+	c.Writer = w
+	c.Data(http.StatusOK, "application/json", []byte(`{"id":"resp_1","status":"completed","output":[{"type":"function_call","call_id":"call_1","name":"apply_patch","arguments":"{}"}]}`))
+	w.finalize()
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"type":"message"`)
+	require.Contains(t, rec.Body.String(), `"name":"Edit"`)
+	require.Contains(t, rec.Body.String(), `"stop_reason":"tool_use"`)
+}
+
+func TestClaudeMessagesWriter_ErrorFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newResponsesClaudeWriter(c, true)
+	c.Writer = w
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": gin.H{"type": "rate_limit_error", "message": "slow"}})
+	w.finalize()
+
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.JSONEq(t, `{"type":"error","error":{"type":"rate_limit_error","message":"slow"}}`, rec.Body.String())
+}
+
+func TestClaudeMessagesWriter_Streaming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newResponsesClaudeWriter(c, true)
+	c.Writer = w
+	c.Header("Content-Type", "text/event-stream")
+	c.Status(http.StatusOK)
+	_, _ = c.Writer.WriteString("event: response.created\ndata: {\"type\":\"response.created\",\"response\":{\"id\":\"resp_9\"}}\n\n")
+	_, _ = c.Writer.WriteString("event: response.output_text.delta\ndata: {\"type\":\"response.output_text.delta\",\"delta\":\"hi\"}\n\n")
+	_, _ = c.Writer.WriteString("event: response.completed\ndata: {\"type\":\"response.completed\",\"response\":{\"id\":\"resp_9\",\"status\":\"completed\"}}\n\n")
+	w.finalize()
+
+	body := rec.Body.String()
+	require.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	require.Contains(t, body, "event: message_start")
+	require.Contains(t, body, `"text":"hi"`)
+	require.Contains(t, body, `"stop_reason":"end_turn"`)
+	require.True(t, strings.HasSuffix(body, "event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n"))
+}
+
+func TestClaudeMessagesWriter_StreamingTruncated(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	w := newResponsesClaudeWriter(c, true)
+	c.Writer = w
+	c.Header("Content-Type", "text/event-stream")
+	c.Status(http.StatusOK)
+	_, _ = c.Writer.WriteString("event: response.created\ndata: {\"type\":\"response.created\",\"response\":{\"id\":\"resp_10\"}}\n\n")
+	_, _ = c.Writer.WriteString("event: response.output_text.delta\ndata: {\"type\":\"response.output_text.delta\",\"delta\":\"hi\"}\n\n")
+	// 上游未发送 response.completed 即中断时以 error 事件结束
+	w.finalize()
+
+	body := rec.Body.String()
+	require.Contains(t, body, `"text":"hi"`)
+	require.NotContains(t, body, "event: message_stop")
+	require.True(t, strings.HasPrefix(body[strings.LastIndex(body, "event: "):], "event: error\n"))
+}
+
+func TestForwardOpenAIFallback_Disabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+
+	h := &GatewayHandler{openAIGatewayService: &service.OpenAIGatewayService{}}
+	apiKey := &service.APIKey{Group: &service.Group{Platform: service.PlatformAnthropic}}
+	streamStarted := false
+	body := []byte(`{"model":"claude-sonnet-4-5","messages":[{"role":"user","content":"hi"}]}`)
+
+	// 未配置映射
+	require.False(t, h.forwardOpenAIFallback(c, apiKey, nil, service.PlatformAnthropic, body, "claude-sonnet-4-5", false, "", &streamStarted))
+
+	// 配置了映射但不是 anthropic 平台（如 /antigravity 强制平台）
+	apiKey.Group.OpenAIFallbackModelMapping = map[string]string{"claude-sonnet-*": "gpt-5"}
+	require.False(t, h.forwardOpenAIFallback(c, apiKey, nil, service.PlatformAntigravity, body, "claude-sonnet-4-5", false, "", &streamStarted))
+
+	// 模型不匹配
+	require.False(t, h.forwardOpenAIFallback(c, apiKey, nil, service.PlatformAnthropic, body, "claude-opus-4-1", false, "", &streamStarted))
+	require.False(t, c.Writer.Written())
+}
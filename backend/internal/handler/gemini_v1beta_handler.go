@@ -176,6 +176,17 @@ func (h *GatewayHandler) GeminiV1BetaModels(c *gin.Context) {
 
 	setOpsRequestContext(c, modelName, stream, body)
 
+	// 分组请求策略：在调度之前执行，敏感信息检测结果在此后的任何错误路径上都用于掩码运维日志请求体
+	// （countTokens 不生成内容，跳过）
+	if action != "countTokens" {
+		policyBody, err := service.ApplyGeminiRequestPolicy(c, body)
+		if err != nil {
+			googleError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		body = policyBody
+	}
+
 	// Get subscription (may be nil)
 	subscription, _ := middleware.GetSubscriptionFromContext(c)
 
@@ -25,22 +25,31 @@ type AdminHandlers struct {
 	Usage            *admin.UsageHandler
 	UserAttribute    *admin.UserAttributeHandler
 	ErrorPassthrough *admin.ErrorPassthroughHandler
+	Webhook          *admin.WebhookHandler
+	AuditLog         *admin.AuditLogHandler
+	BalanceLedger    *admin.BalanceLedgerHandler
+	Scheduler        *admin.SchedulerHandler
 }
 
 // Handlers contains all HTTP handlers
 type Handlers struct {
-	Auth          *AuthHandler
-	User          *UserHandler
-	APIKey        *APIKeyHandler
-	Usage         *UsageHandler
-	Redeem        *RedeemHandler
-	Subscription  *SubscriptionHandler
-	Announcement  *AnnouncementHandler
-	Admin         *AdminHandlers
-	Gateway       *GatewayHandler
-	OpenAIGateway *OpenAIGatewayHandler
-	Setting       *SettingHandler
-	Totp          *TotpHandler
+	Auth            *AuthHandler
+	User            *UserHandler
+	APIKey          *APIKeyHandler
+	Usage           *UsageHandler
+	Redeem          *RedeemHandler
+	Subscription    *SubscriptionHandler
+	Announcement    *AnnouncementHandler
+	Admin           *AdminHandlers
+	Gateway         *GatewayHandler
+	OpenAIGateway   *OpenAIGatewayHandler
+	ChatCompletions *ChatCompletionsHandler
+	Embeddings      *EmbeddingsHandler
+	Images          *ImagesHandler
+	MessageBatches  *MessageBatchHandler
+	Setting         *SettingHandler
+	Totp            *TotpHandler
+	BalanceLedger   *BalanceLedgerHandler
 }
 
 // BuildInfo contains build-time information
@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImagesHandler handles the OpenAI Images compatible endpoint.
+//
+// 请求转换为 Gemini generateContent 后交给 GatewayHandler.GeminiV1BetaModels 处理，
+// 仅支持 Gemini / Antigravity 分组。每张图片对应一次上游调用，
+// 计费由原生流程按 imageSize 与分组 image_price_1k/2k/4k 完成。
+type ImagesHandler struct {
+	gatewayHandler *GatewayHandler
+}
+
+// NewImagesHandler creates a new ImagesHandler
+func NewImagesHandler(gatewayHandler *GatewayHandler) *ImagesHandler {
+	return &ImagesHandler{gatewayHandler: gatewayHandler}
+}
+
+// Generations handles OpenAI Images generations endpoint
+// POST /v1/images/generations
+func (h *ImagesHandler) Generations(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+
+	platform := ""
+	if apiKey.Group != nil {
+		platform = apiKey.Group.Platform
+	}
+	if forced, ok := middleware2.GetForcePlatformFromContext(c); ok {
+		platform = forced
+	}
+	if platform != service.PlatformGemini && platform != service.PlatformAntigravity {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Images API requires a Gemini or Antigravity group")
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if maxErr, ok := extractMaxBytesError(err); ok {
+			h.errorResponse(c, http.StatusRequestEntityTooLarge, "invalid_request_error", buildBodyTooLargeMessage(maxErr.Limit))
+			return
+		}
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to read request body")
+		return
+	}
+	if len(body) == 0 {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Request body is empty")
+		return
+	}
+
+	setOpsRequestContext(c, "", false, body)
+
+	req, err := service.ParseImagesGenerationRequest(body)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body: "+err.Error())
+		return
+	}
+	converted, err := service.ConvertImagesRequestToGemini(req)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
+	// Antigravity 分组复用 /antigravity/v1beta 的强制平台逻辑，使 Gemini 原生流程接受该分组
+	if platform == service.PlatformAntigravity && !middleware2.HasForcePlatform(c) {
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), ctxkey.ForcePlatform, platform))
+		c.Set(string(middleware2.ContextKeyForcePlatform), platform)
+	}
+	c.Params = append(c.Params, gin.Param{Key: "modelAction", Value: "/" + req.Model + ":generateContent"})
+
+	resp := service.ImagesResponse{Created: time.Now().Unix()}
+	originalWriter := c.Writer
+	for i := 0; i < req.N; i++ {
+		capture := &imagesCaptureWriter{ResponseWriter: originalWriter, status: http.StatusOK}
+		c.Writer = capture
+		c.Request.Body = io.NopCloser(bytes.NewReader(converted))
+		c.Request.ContentLength = int64(len(converted))
+		h.gatewayHandler.GeminiV1BetaModels(c)
+		c.Writer = originalWriter
+		// 上游响应头中的 Content-Length 对应单次 Gemini 响应，不能用于汇总后的响应
+		c.Writer.Header().Del("Content-Length")
+
+		// 已生成的图片已计费，后续调用失败时返回已有结果
+		if capture.status >= http.StatusBadRequest {
+			if len(resp.Data) == 0 {
+				c.Data(capture.status, "application/json; charset=utf-8", service.ConvertErrorToChatCompletions(capture.buf.Bytes()))
+				return
+			}
+			break
+		}
+		image, err := service.ConvertGeminiToImagesData(capture.buf.Bytes(), req.ResponseFormat)
+		if err != nil {
+			if len(resp.Data) == 0 {
+				h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+				return
+			}
+			break
+		}
+		resp.Data = append(resp.Data, *image)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// errorResponse returns OpenAI API format error response
+func (h *ImagesHandler) errorResponse(c *gin.Context, status int, errType, message string) {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
+
+// imagesCaptureWriter 缓冲 Gemini 原生流程写出的单次响应，由 ImagesHandler 汇总后统一输出
+type imagesCaptureWriter struct {
+	gin.ResponseWriter
+
+	status int
+	wrote  bool
+	buf    bytes.Buffer
+}
+
+func (w *imagesCaptureWriter) WriteHeader(code int) {
+	if code > 0 && !w.wrote {
+		w.status = code
+	}
+}
+
+func (w *imagesCaptureWriter) WriteHeaderNow() {}
+
+func (w *imagesCaptureWriter) Status() int {
+	return w.status
+}
+
+func (w *imagesCaptureWriter) Written() bool {
+	return w.wrote
+}
+
+func (w *imagesCaptureWriter) Size() int {
+	return w.buf.Len()
+}
+
+func (w *imagesCaptureWriter) Write(b []byte) (int, error) {
+	w.wrote = true
+	return w.buf.Write(b)
+}
+
+func (w *imagesCaptureWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *imagesCaptureWriter) Flush() {}
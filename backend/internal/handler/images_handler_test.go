@@ -0,0 +1,56 @@
+//go:build unit
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImagesHandler_RejectsNonGeminiGroup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/images/generations", strings.NewReader(`{"prompt":"a cat"}`))
+	c.Set(string(middleware2.ContextKeyAPIKey), &service.APIKey{Group: &service.Group{Platform: service.PlatformAnthropic}})
+
+	NewImagesHandler(nil).Generations(c)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "requires a Gemini or Antigravity group")
+}
+
+func TestImagesHandler_InvalidRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, "/v1/images/generations", strings.NewReader(`{"prompt":"a cat","n":10}`))
+	c.Set(string(middleware2.ContextKeyAPIKey), &service.APIKey{Group: &service.Group{Platform: service.PlatformGemini}})
+
+	NewImagesHandler(nil).Generations(c)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "n must be between 1 and 4")
+}
+
+func TestImagesCaptureWriter_BuffersResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	capture := &imagesCaptureWriter{ResponseWriter: c.Writer, status: http.StatusOK}
+	c.Writer = capture
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": gin.H{"status": "RESOURCE_EXHAUSTED", "message": "quota"}})
+
+	require.Equal(t, http.StatusTooManyRequests, capture.Status())
+	require.Contains(t, capture.buf.String(), "RESOURCE_EXHAUSTED")
+	require.Empty(t, rec.Body.String())
+	require.JSONEq(t, `{"error":{"type":"resource_exhausted","message":"quota"}}`, string(service.ConvertErrorToChatCompletions(capture.buf.Bytes())))
+}
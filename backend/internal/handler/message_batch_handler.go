@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	pkgerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MessageBatchHandler handles the Anthropic Message Batches API.
+//
+// 批次按 API Key 所属用户隔离；请求由后台 worker 异步执行，结果在批次结束后以 JSONL 下载。
+type MessageBatchHandler struct {
+	messageBatchService *service.MessageBatchService
+}
+
+// NewMessageBatchHandler creates a new MessageBatchHandler
+func NewMessageBatchHandler(messageBatchService *service.MessageBatchService) *MessageBatchHandler {
+	return &MessageBatchHandler{messageBatchService: messageBatchService}
+}
+
+type messageBatchRequestCounts struct {
+	Processing int64 `json:"processing"`
+	Succeeded  int64 `json:"succeeded"`
+	Errored    int64 `json:"errored"`
+	Canceled   int64 `json:"canceled"`
+	Expired    int64 `json:"expired"`
+}
+
+// messageBatchResponse Anthropic message_batch 对象
+type messageBatchResponse struct {
+	ID                string                    `json:"id"`
+	Type              string                    `json:"type"`
+	ProcessingStatus  string                    `json:"processing_status"`
+	RequestCounts     messageBatchRequestCounts `json:"request_counts"`
+	EndedAt           *time.Time                `json:"ended_at"`
+	CreatedAt         time.Time                 `json:"created_at"`
+	ExpiresAt         time.Time                 `json:"expires_at"`
+	ArchivedAt        *time.Time                `json:"archived_at"`
+	CancelInitiatedAt *time.Time                `json:"cancel_initiated_at"`
+	ResultsURL        *string                   `json:"results_url"`
+}
+
+func messageBatchToResponse(batch *service.MessageBatch) messageBatchResponse {
+	resp := messageBatchResponse{
+		ID:               batch.BatchID,
+		Type:             "message_batch",
+		ProcessingStatus: batch.ProcessingStatus,
+		RequestCounts: messageBatchRequestCounts{
+			Processing: batch.RequestCounts.Processing,
+			Succeeded:  batch.RequestCounts.Succeeded,
+			Errored:    batch.RequestCounts.Errored,
+			Canceled:   batch.RequestCounts.Canceled,
+			Expired:    batch.RequestCounts.Expired,
+		},
+		EndedAt:           utcTimePtr(batch.EndedAt),
+		CreatedAt:         batch.CreatedAt.UTC(),
+		ExpiresAt:         batch.ExpiresAt.UTC(),
+		CancelInitiatedAt: utcTimePtr(batch.CancelInitiatedAt),
+	}
+	if batch.ProcessingStatus == service.MessageBatchStatusEnded {
+		url := "/v1/messages/batches/" + batch.BatchID + "/results"
+		resp.ResultsURL = &url
+	}
+	return resp
+}
+
+func utcTimePtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.UTC()
+	return &v
+}
+
+// Create creates a message batch
+// POST /v1/messages/batches
+func (h *MessageBatchHandler) Create(c *gin.Context) {
+	apiKey, ok := middleware2.GetAPIKeyFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusUnauthorized, "authentication_error", "Invalid API key")
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		if maxErr, ok := extractMaxBytesError(err); ok {
+			h.errorResponse(c, http.StatusRequestEntityTooLarge, "request_too_large", buildBodyTooLargeMessage(maxErr.Limit))
+			return
+		}
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to read request body")
+		return
+	}
+	var req struct {
+		Requests []service.MessageBatchRequest `json:"requests"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "Failed to parse request body")
+		return
+	}
+
+	batch, err := h.messageBatchService.CreateBatch(c.Request.Context(), apiKey, req.Requests)
+	if err != nil {
+		h.serviceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, messageBatchToResponse(batch))
+}
+
+// List lists message batches of the current user, most recent first
+// GET /v1/messages/batches
+func (h *MessageBatchHandler) List(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusInternalServerError, "api_error", "User context not found")
+		return
+	}
+
+	params := service.MessageBatchListParams{
+		BeforeID: c.Query("before_id"),
+		AfterID:  c.Query("after_id"),
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > 1000 {
+			h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "limit must be an integer between 1 and 1000")
+			return
+		}
+		params.Limit = limit
+	}
+	if params.BeforeID != "" && params.AfterID != "" {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", "before_id and after_id cannot be used together")
+		return
+	}
+
+	batches, hasMore, err := h.messageBatchService.ListBatches(c.Request.Context(), subject.UserID, params)
+	if err != nil {
+		h.serviceErrorResponse(c, err)
+		return
+	}
+	data := make([]messageBatchResponse, 0, len(batches))
+	for i := range batches {
+		data = append(data, messageBatchToResponse(&batches[i]))
+	}
+	var firstID, lastID *string
+	if len(data) > 0 {
+		firstID, lastID = &data[0].ID, &data[len(data)-1].ID
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":     data,
+		"has_more": hasMore,
+		"first_id": firstID,
+		"last_id":  lastID,
+	})
+}
+
+// Get retrieves a message batch
+// GET /v1/messages/batches/:id
+func (h *MessageBatchHandler) Get(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusInternalServerError, "api_error", "User context not found")
+		return
+	}
+	batch, err := h.messageBatchService.GetBatch(c.Request.Context(), subject.UserID, c.Param("id"))
+	if err != nil {
+		h.serviceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, messageBatchToResponse(batch))
+}
+
+// Cancel cancels a message batch
+// POST /v1/messages/batches/:id/cancel
+func (h *MessageBatchHandler) Cancel(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusInternalServerError, "api_error", "User context not found")
+		return
+	}
+	batch, err := h.messageBatchService.CancelBatch(c.Request.Context(), subject.UserID, c.Param("id"))
+	if err != nil {
+		h.serviceErrorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, messageBatchToResponse(batch))
+}
+
+// Results streams the JSONL results of an ended message batch
+// GET /v1/messages/batches/:id/results
+func (h *MessageBatchHandler) Results(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		h.errorResponse(c, http.StatusInternalServerError, "api_error", "User context not found")
+		return
+	}
+	batch, err := h.messageBatchService.GetEndedBatch(c.Request.Context(), subject.UserID, c.Param("id"))
+	if err != nil {
+		h.serviceErrorResponse(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "application/x-jsonl")
+	c.Header("Content-Disposition", `attachment; filename="`+batch.BatchID+`_results.jsonl"`)
+	c.Status(http.StatusOK)
+	if err := h.messageBatchService.StreamResults(c.Request.Context(), batch, func(line []byte) error {
+		_, err := c.Writer.Write(line)
+		return err
+	}); err != nil {
+		// 响应头已写出，只能中断输出
+		log.Printf("[MessageBatch] stream results of %s failed: %v", batch.BatchID, err)
+		_ = c.Error(err)
+		return
+	}
+	c.Writer.Flush()
+}
+
+// serviceErrorResponse 将 service 层错误转换为 Anthropic 错误格式
+func (h *MessageBatchHandler) serviceErrorResponse(c *gin.Context, err error) {
+	status := pkgerrors.Code(err)
+	message := pkgerrors.Message(err)
+	errType := "api_error"
+	switch status {
+	case http.StatusBadRequest, http.StatusConflict:
+		errType = "invalid_request_error"
+	case http.StatusUnauthorized:
+		errType = "authentication_error"
+	case http.StatusForbidden:
+		errType = "permission_error"
+	case http.StatusNotFound:
+		errType = "not_found_error"
+	default:
+		log.Printf("[MessageBatch] request failed: %v", err)
+		status = http.StatusInternalServerError
+		message = "Internal server error"
+	}
+	h.errorResponse(c, status, errType, message)
+}
+
+func (h *MessageBatchHandler) errorResponse(c *gin.Context, status int, errType, message string) {
+	c.JSON(status, gin.H{
+		"type": "error",
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,87 @@
+//go:build unit
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageBatchToResponse(t *testing.T) {
+	loc := time.FixedZone("UTC+8", 8*3600)
+	created := time.Date(2026, 1, 2, 11, 0, 0, 0, loc)
+	batch := &service.MessageBatch{
+		BatchID:          "msgbatch_abc",
+		ProcessingStatus: service.MessageBatchStatusInProgress,
+		RequestCounts:    service.MessageBatchRequestCounts{Processing: 3, Succeeded: 1},
+		CreatedAt:        created,
+		ExpiresAt:        created.Add(24 * time.Hour),
+	}
+
+	raw, err := json.Marshal(messageBatchToResponse(batch))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"id": "msgbatch_abc",
+		"type": "message_batch",
+		"processing_status": "in_progress",
+		"request_counts": {"processing": 3, "succeeded": 1, "errored": 0, "canceled": 0, "expired": 0},
+		"ended_at": null,
+		"created_at": "2026-01-02T03:00:00Z",
+		"expires_at": "2026-01-03T03:00:00Z",
+		"archived_at": null,
+		"cancel_initiated_at": null,
+		"results_url": null
+	}`, string(raw))
+
+	// 结束后提供结果下载地址
+	ended := created.Add(time.Hour)
+	batch.ProcessingStatus = service.MessageBatchStatusEnded
+	batch.EndedAt = &ended
+	resp := messageBatchToResponse(batch)
+	require.NotNil(t, resp.ResultsURL)
+	require.Equal(t, "/v1/messages/batches/msgbatch_abc/results", *resp.ResultsURL)
+	require.Equal(t, time.UTC, resp.EndedAt.Location())
+}
+
+func TestMessageBatchServiceErrorResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &MessageBatchHandler{}
+
+	cases := []struct {
+		err     error
+		status  int
+		errType string
+		message string
+	}{
+		{service.ErrMessageBatchNotFound, http.StatusNotFound, "not_found_error", "message batch not found"},
+		{service.ErrMessageBatchNotEnded, http.StatusConflict, "invalid_request_error", service.ErrMessageBatchNotEnded.Message},
+		{service.ErrMessageBatchDisabled, http.StatusForbidden, "permission_error", "message batches are disabled"},
+		{errors.New("db down"), http.StatusInternalServerError, "api_error", "Internal server error"},
+	}
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		h.serviceErrorResponse(c, tc.err)
+
+		require.Equal(t, tc.status, rec.Code)
+		var body struct {
+			Type  string `json:"type"`
+			Error struct {
+				Type    string `json:"type"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Equal(t, "error", body.Type)
+		require.Equal(t, tc.errType, body.Error.Type)
+		require.Equal(t, tc.message, body.Error.Message)
+	}
+}
@@ -26,6 +26,7 @@ type OpenAIGatewayHandler struct {
 	billingCacheService     *service.BillingCacheService
 	apiKeyService           *service.APIKeyService
 	errorPassthroughService *service.ErrorPassthroughService
+	responseCacheService    *service.ResponseCacheService
 	concurrencyHelper       *ConcurrencyHelper
 	maxAccountSwitches      int
 }
@@ -37,6 +38,7 @@ func NewOpenAIGatewayHandler(
 	billingCacheService *service.BillingCacheService,
 	apiKeyService *service.APIKeyService,
 	errorPassthroughService *service.ErrorPassthroughService,
+	responseCacheService *service.ResponseCacheService,
 	cfg *config.Config,
 ) *OpenAIGatewayHandler {
 	pingInterval := time.Duration(0)
@@ -52,6 +54,7 @@ func NewOpenAIGatewayHandler(
 		billingCacheService:     billingCacheService,
 		apiKeyService:           apiKeyService,
 		errorPassthroughService: errorPassthroughService,
+		responseCacheService:    responseCacheService,
 		concurrencyHelper:       NewConcurrencyHelper(concurrencyService, SSEPingFormatComment, pingInterval),
 		maxAccountSwitches:      maxAccountSwitches,
 	}
@@ -91,6 +94,15 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 
 	setOpsRequestContext(c, "", false, body)
 
+	// 分组请求策略：在响应缓存查找与账号调度之前执行，后续流程（含缓存键）均使用改写后的请求体；
+	// 运维日志保留客户端原始请求体（落库前按检测结果掩码），重试时重新执行策略
+	rawBody := body
+	body, err = service.ApplyResponsesRequestPolicy(c, body)
+	if err != nil {
+		h.errorResponse(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return
+	}
+
 	// Parse request body to map for potential modification
 	var reqBody map[string]any
 	if err := json.Unmarshal(body, &reqBody); err != nil {
@@ -124,7 +136,7 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		}
 	}
 
-	setOpsRequestContext(c, reqModel, reqStream, body)
+	setOpsRequestContext(c, reqModel, reqStream, rawBody)
 
 	// 提前校验 function_call_output 是否具备可关联上下文，避免上游 400。
 	// 要求 previous_response_id，或 input 内存在带 call_id 的 tool_call/function_call，
@@ -203,6 +215,19 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		return
 	}
 
+	// 响应缓存：分组开启且为确定性请求（temperature=0）时，命中直接回放，跳过账号调度
+	cacheReq := h.responseCacheService.Prepare(apiKey.Group, service.ResponseCacheEndpointResponses, reqModel, reqStream, body)
+	if entry := h.responseCacheService.Lookup(c.Request.Context(), cacheReq); entry != nil {
+		h.replayResponseCacheHit(c, apiKey, subscription, entry, reqStream)
+		return
+	}
+	var cacheWriter *responseCacheWriter
+	if cacheReq != nil {
+		cacheWriter = newResponseCacheWriter(c.Writer, h.responseCacheService.MaxEntryBytes())
+		c.Writer = cacheWriter
+		defer func() { c.Writer = cacheWriter.ResponseWriter }()
+	}
+
 	// Generate session hash (header first; fallback to prompt_cache_key)
 	sessionHash := h.gatewayService.GenerateSessionHash(c, reqBody)
 
@@ -282,6 +307,9 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 		accountReleaseFunc = wrapReleaseOnDone(c.Request.Context(), accountReleaseFunc)
 
 		// Forward request
+		if cacheWriter != nil {
+			cacheWriter.reset()
+		}
 		result, err := h.gatewayService.Forward(c.Request.Context(), c, account, body)
 		if accountReleaseFunc != nil {
 			accountReleaseFunc()
@@ -304,6 +332,8 @@ func (h *OpenAIGatewayHandler) Responses(c *gin.Context) {
 			return
 		}
 
+		h.storeResponseCache(c, cacheReq, cacheWriter, account.ID, result)
+
 		// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
 		userAgent := c.GetHeader("User-Agent")
 		clientIP := ip.GetClientIP(c)
@@ -44,6 +44,9 @@ type opsErrorLogJob struct {
 	ops         *service.OpsService
 	entry       *service.OpsInsertErrorLogInput
 	requestBody []byte
+
+	// redaction 非空时为敏感信息检测记录（与错误日志共用队列）
+	redaction *service.OpsInsertRedactionLogInput
 }
 
 var (
@@ -84,7 +87,7 @@ func startOpsErrorLogWorkers() {
 			defer opsErrorLogWorkersWg.Done()
 			for job := range opsErrorLogQueue {
 				opsErrorLogQueueLen.Add(-1)
-				if job.ops == nil || job.entry == nil {
+				if job.ops == nil || (job.entry == nil && job.redaction == nil) {
 					continue
 				}
 				func() {
@@ -94,7 +97,11 @@ func startOpsErrorLogWorkers() {
 						}
 					}()
 					ctx, cancel := context.WithTimeout(context.Background(), opsErrorLogTimeout)
-					_ = job.ops.RecordError(ctx, job.entry, job.requestBody)
+					if job.redaction != nil {
+						_ = job.ops.RecordRedaction(ctx, job.redaction)
+					} else {
+						_ = job.ops.RecordError(ctx, job.entry, job.requestBody)
+					}
 					cancel()
 					opsErrorLogProcessed.Add(1)
 				}()
@@ -107,6 +114,17 @@ func enqueueOpsErrorLog(ops *service.OpsService, entry *service.OpsInsertErrorLo
 	if ops == nil || entry == nil {
 		return
 	}
+	enqueueOpsLogJob(opsErrorLogJob{ops: ops, entry: entry, requestBody: requestBody})
+}
+
+func enqueueOpsRedactionLog(ops *service.OpsService, entry *service.OpsInsertRedactionLogInput) {
+	if ops == nil || entry == nil {
+		return
+	}
+	enqueueOpsLogJob(opsErrorLogJob{ops: ops, redaction: entry})
+}
+
+func enqueueOpsLogJob(job opsErrorLogJob) {
 	select {
 	case <-opsErrorLogShutdownCh:
 		return
@@ -129,7 +147,7 @@ func enqueueOpsErrorLog(ops *service.OpsService, entry *service.OpsInsertErrorLo
 	}
 
 	select {
-	case opsErrorLogQueue <- opsErrorLogJob{ops: ops, entry: entry, requestBody: requestBody}:
+	case opsErrorLogQueue <- job:
 		opsErrorLogQueueLen.Add(1)
 		opsErrorLogEnqueued.Add(1)
 	default:
@@ -317,6 +335,9 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 			return
 		}
 
+		// 敏感信息检测统计与请求结果无关，成功与失败的请求都记录
+		recordOpsRedaction(c, ops)
+
 		status := c.Writer.Status()
 		if status < 400 {
 			// Even when the client request succeeds, we still want to persist upstream error attempts
@@ -528,12 +549,7 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 				entry.ClientIP = &clientIP
 			}
 
-			var requestBody []byte
-			if v, ok := c.Get(opsRequestBodyKey); ok {
-				if b, ok := v.([]byte); ok && len(b) > 0 {
-					requestBody = b
-				}
-			}
+			requestBody := opsRequestBodyForStorage(c)
 			// Store request headers/body only when an upstream error occurred to keep overhead minimal.
 			entry.RequestHeadersJSON = extractOpsRetryRequestHeaders(c)
 
@@ -707,12 +723,7 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 			entry.ClientIP = &clientIP
 		}
 
-		var requestBody []byte
-		if v, ok := c.Get(opsRequestBodyKey); ok {
-			if b, ok := v.([]byte); ok && len(b) > 0 {
-				requestBody = b
-			}
-		}
+		requestBody := opsRequestBodyForStorage(c)
 		// Persist only a minimal, whitelisted set of request headers to improve retry fidelity.
 		// Do NOT store Authorization/Cookie/etc.
 		entry.RequestHeadersJSON = extractOpsRetryRequestHeaders(c)
@@ -721,6 +732,76 @@ func OpsErrorLoggerMiddleware(ops *service.OpsService) gin.HandlerFunc {
 	}
 }
 
+// opsRequestBodyForStorage 返回用于持久化的客户端请求体；
+// 分组启用敏感信息检测且本次请求有命中时，按同一组检测器掩码后再交给 RecordError，避免原文落库。
+// 网关 handler 在读取请求体后即执行分组策略，Forward 之前的错误（排队、计费校验等）同样会被掩码。
+func opsRequestBodyForStorage(c *gin.Context) []byte {
+	v, ok := c.Get(opsRequestBodyKey)
+	if !ok {
+		return nil
+	}
+	b, ok := v.([]byte)
+	if !ok || len(b) == 0 {
+		return nil
+	}
+	if redaction := getOpsRedaction(c); redaction != nil {
+		return redaction.MaskRequestBody(b)
+	}
+	return b
+}
+
+func getOpsRedaction(c *gin.Context) *service.RedactionResult {
+	v, ok := c.Get(service.OpsRedactionKey)
+	if !ok {
+		return nil
+	}
+	result, _ := v.(*service.RedactionResult)
+	return result
+}
+
+// recordOpsRedaction 将分组策略写入的敏感信息检测统计写入 ops_redaction_logs（仅次数，不含原文）
+func recordOpsRedaction(c *gin.Context, ops *service.OpsService) {
+	result := getOpsRedaction(c)
+	if result == nil || result.Total <= 0 {
+		return
+	}
+
+	apiKey, _ := middleware2.GetAPIKeyFromContext(c)
+	clientRequestID, _ := c.Request.Context().Value(ctxkey.ClientRequestID).(string)
+	requestID := c.Writer.Header().Get("X-Request-Id")
+	if requestID == "" {
+		requestID = c.Writer.Header().Get("x-request-id")
+	}
+	var modelName string
+	if v, ok := c.Get(opsModelKey); ok {
+		modelName, _ = v.(string)
+	}
+
+	counts := make(map[string]int, len(result.Counts))
+	for name, n := range result.Counts {
+		counts[name] = n
+	}
+	entry := &service.OpsInsertRedactionLogInput{
+		RequestID:       requestID,
+		ClientRequestID: clientRequestID,
+		Platform:        resolveOpsPlatform(apiKey, guessPlatformFromPath(c.Request.URL.Path)),
+		Model:           modelName,
+		RequestPath:     c.Request.URL.Path,
+		Action:          result.Action,
+		Counts:          counts,
+		TotalCount:      result.Total,
+		CreatedAt:       time.Now(),
+	}
+	if apiKey != nil {
+		entry.APIKeyID = &apiKey.ID
+		if apiKey.User != nil {
+			entry.UserID = &apiKey.User.ID
+		}
+		entry.GroupID = apiKey.GroupID
+	}
+	enqueueOpsRedactionLog(ops, entry)
+}
+
 var opsRetryRequestHeaderAllowlist = []string{
 	"anthropic-beta",
 	"anthropic-version",
@@ -788,8 +869,12 @@ func parseOpsErrorResponse(body []byte) parsedOpsError {
 			}
 		}
 		if t == "" {
-			// Gemini error does not have "type" field.
-			t = "api_error"
+			// Gemini error does not have "type" field; INVALID_ARGUMENT is a client-side error.
+			if status, _ := errObj["status"].(string); status == "INVALID_ARGUMENT" {
+				t = "invalid_request_error"
+			} else {
+				t = "api_error"
+			}
 		}
 		// For gemini error, capture numeric code as string for business-limited mapping if needed.
 		var code string
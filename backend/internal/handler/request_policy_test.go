@@ -0,0 +1,126 @@
+//go:build unit
+
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/domain"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	middleware2 "github.com/Wei-Shaw/sub2api/internal/server/middleware"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// newRequestPolicyTestContext 构造已通过认证、分组开启响应缓存与请求策略的请求上下文
+func newRequestPolicyTestContext(path, body string) (*gin.Context, *httptest.ResponseRecorder) {
+	return newRequestPolicyTestContextWithPolicy(path, body, service.PlatformAnthropic, &service.RequestPolicy{BlockedTools: []string{"Bash"}})
+}
+
+func newRequestPolicyTestContextWithPolicy(path, body, platform string, policy *service.RequestPolicy) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	group := &service.Group{
+		ID:                   1,
+		Platform:             platform,
+		ResponseCacheEnabled: true,
+		RequestPolicy:        policy,
+	}
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	c.Request = req.WithContext(context.WithValue(req.Context(), ctxkey.Group, group))
+	c.Set(string(middleware2.ContextKeyAPIKey), &service.APIKey{ID: 1, Group: group})
+	c.Set(string(middleware2.ContextKeyUser), middleware2.AuthSubject{UserID: 1, Concurrency: 1})
+	return c, rec
+}
+
+// 策略在响应缓存查找与调度之前执行：handler 未注入任何依赖，走到后续流程会直接 panic
+func TestGatewayHandlerMessages_RequestPolicyBeforeResponseCache(t *testing.T) {
+	c, rec := newRequestPolicyTestContext("/v1/messages",
+		`{"model":"claude-sonnet-4-5","temperature":0,"tools":[{"name":"Bash"}],"messages":[{"role":"user","content":"hi"}]}`)
+
+	(&GatewayHandler{}).Messages(c)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), `tool \"Bash\" is not allowed`)
+}
+
+func TestOpenAIGatewayHandlerResponses_RequestPolicyBeforeResponseCache(t *testing.T) {
+	c, rec := newRequestPolicyTestContext("/v1/responses",
+		`{"model":"gpt-5","temperature":0,"tools":[{"type":"function","name":"Bash"}],"input":"hi"}`)
+
+	(&OpenAIGatewayHandler{}).Responses(c)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), `tool \"Bash\" is not allowed`)
+}
+
+// 敏感信息检测同样在缓存查找之前执行：block 模式命中时直接拒绝，运维日志请求体按检测结果掩码
+func TestGatewayHandlerMessages_RedactionBlockBeforeResponseCache(t *testing.T) {
+	policy := &service.RequestPolicy{Redaction: &service.RedactionPolicy{
+		Action:    service.RedactionActionBlock,
+		Detectors: []string{domain.RedactionDetectorEmail},
+	}}
+	c, rec := newRequestPolicyTestContextWithPolicy("/v1/messages",
+		`{"model":"claude-sonnet-4-5","temperature":0,"messages":[{"role":"user","content":"mail alice@example.com"}]}`,
+		service.PlatformAnthropic, policy)
+
+	(&GatewayHandler{}).Messages(c)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "sensitive content detected (email)")
+	stored := string(opsRequestBodyForStorage(c))
+	require.NotContains(t, stored, "alice@example.com")
+	require.Contains(t, stored, "[REDACTED:email]")
+}
+
+// Forward 之前的错误（缺少 model）同样按检测结果掩码运维日志请求体
+func TestGatewayHandlerMessages_PreForwardErrorMasksOpsBody(t *testing.T) {
+	policy := &service.RequestPolicy{Redaction: &service.RedactionPolicy{
+		Action:    service.RedactionActionMask,
+		Detectors: []string{domain.RedactionDetectorEmail},
+	}}
+	c, rec := newRequestPolicyTestContextWithPolicy("/v1/messages",
+		`{"messages":[{"role":"user","content":"mail alice@example.com"}]}`,
+		service.PlatformAnthropic, policy)
+
+	(&GatewayHandler{}).Messages(c)
+
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Contains(t, rec.Body.String(), "model is required")
+	stored := string(opsRequestBodyForStorage(c))
+	require.NotContains(t, stored, "alice@example.com")
+	require.Contains(t, stored, "[REDACTED:email]")
+}
+
+type requestPolicyWaitQueueFullStub struct {
+	service.ConcurrencyCache
+}
+
+func (requestPolicyWaitQueueFullStub) IncrementWaitCount(ctx context.Context, userID int64, maxWait int) (bool, error) {
+	return false, nil
+}
+
+func TestGeminiV1BetaModels_PreForwardErrorMasksOpsBody(t *testing.T) {
+	policy := &service.RequestPolicy{Redaction: &service.RedactionPolicy{
+		Action:    service.RedactionActionMask,
+		Detectors: []string{domain.RedactionDetectorEmail},
+	}}
+	c, rec := newRequestPolicyTestContextWithPolicy("/v1beta/models/gemini-2.5-pro:generateContent",
+		`{"contents":[{"role":"user","parts":[{"text":"mail alice@example.com"}]}]}`,
+		service.PlatformGemini, policy)
+	c.Params = gin.Params{{Key: "modelAction", Value: "/gemini-2.5-pro:generateContent"}}
+
+	h := &GatewayHandler{concurrencyHelper: NewConcurrencyHelper(service.NewConcurrencyService(requestPolicyWaitQueueFullStub{}), SSEPingFormatNone, 0)}
+	h.GeminiV1BetaModels(c)
+
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	stored := string(opsRequestBodyForStorage(c))
+	require.NotContains(t, stored, "alice@example.com")
+	require.Contains(t, stored, "[REDACTED:email]")
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// responseCacheWriter 旁路记录写给客户端的完整响应（JSON 或 SSE 事件序列），用于写入响应缓存。
+// 超出大小上限后停止记录，不影响正常写出。
+type responseCacheWriter struct {
+	gin.ResponseWriter
+	limit    int
+	buf      bytes.Buffer
+	overflow bool
+}
+
+func newResponseCacheWriter(w gin.ResponseWriter, limit int) *responseCacheWriter {
+	return &responseCacheWriter{ResponseWriter: w, limit: limit}
+}
+
+func (w *responseCacheWriter) Write(b []byte) (int, error) {
+	w.capture(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseCacheWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *responseCacheWriter) capture(b []byte) {
+	if w.overflow {
+		return
+	}
+	if w.buf.Len()+len(b) > w.limit {
+		w.overflow = true
+		w.buf.Reset()
+		return
+	}
+	_, _ = w.buf.Write(b)
+}
+
+// reset 清空已记录内容（每次转发前调用，丢弃排队 ping 与失败尝试的输出）
+func (w *responseCacheWriter) reset() {
+	w.buf.Reset()
+	w.overflow = false
+}
+
+// captured 返回完整记录的成功响应
+func (w *responseCacheWriter) captured() ([]byte, bool) {
+	if w.overflow || w.buf.Len() == 0 || w.Status() != http.StatusOK {
+		return nil, false
+	}
+	return bytes.Clone(w.buf.Bytes()), true
+}
+
+// storeCapturedResponse 将本次成功转发的响应写入缓存；客户端中途断开时响应不完整，跳过
+func storeCapturedResponse(c *gin.Context, responseCache *service.ResponseCacheService, cacheReq *service.ResponseCacheRequest, w *responseCacheWriter, build func(contentType string, body []byte) *service.ResponseCacheEntry) {
+	if w == nil || cacheReq == nil || c.Request.Context().Err() != nil {
+		return
+	}
+	body, ok := w.captured()
+	if !ok {
+		return
+	}
+	responseCache.Store(c.Request.Context(), cacheReq, build(w.Header().Get("Content-Type"), body))
+}
+
+// replayCachedResponse 回放缓存响应：非流式直接返回 JSON，流式按原始 SSE 事件序列一次性写出
+func replayCachedResponse(c *gin.Context, entry *service.ResponseCacheEntry, stream bool) {
+	if entry.ContentType != "" {
+		c.Header("Content-Type", entry.ContentType)
+	}
+	if stream {
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+	}
+	c.Header("X-Response-Cache", "HIT")
+	c.Status(http.StatusOK)
+	if _, err := c.Writer.Write(entry.Body); err != nil {
+		_ = c.Error(err)
+		return
+	}
+	c.Writer.Flush()
+}
+
+// replayResponseCacheHit 回放缓存响应并按缓存命中记录用量（用量日志关联生成该响应的账号）
+func (h *GatewayHandler) replayResponseCacheHit(c *gin.Context, apiKey *service.APIKey, subscription *service.UserSubscription, entry *service.ResponseCacheEntry, stream bool) {
+	startTime := time.Now()
+	replayCachedResponse(c, entry, stream)
+	result := entry.ForwardResult(stream, time.Since(startTime))
+
+	// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
+	userAgent := c.GetHeader("User-Agent")
+	clientIP := ip.GetClientIP(c)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := h.gatewayService.RecordUsage(ctx, &service.RecordUsageInput{
+			Result:           result,
+			APIKey:           apiKey,
+			User:             apiKey.User,
+			Account:          entry.OriginAccount(),
+			Subscription:     subscription,
+			UserAgent:        userAgent,
+			IPAddress:        clientIP,
+			ResponseCacheHit: true,
+			APIKeyService:    h.apiKeyService,
+		}); err != nil {
+			log.Printf("Record usage failed: %v", err)
+		}
+	}()
+}
+
+// storeResponseCache 缓存本次成功转发的响应
+func (h *GatewayHandler) storeResponseCache(c *gin.Context, cacheReq *service.ResponseCacheRequest, w *responseCacheWriter, accountID int64, result *service.ForwardResult) {
+	if result == nil || result.ClientDisconnect {
+		return
+	}
+	storeCapturedResponse(c, h.responseCacheService, cacheReq, w, func(contentType string, body []byte) *service.ResponseCacheEntry {
+		return service.NewResponseCacheEntry(contentType, body, accountID, result)
+	})
+}
+
+// replayResponseCacheHit 回放缓存响应并按缓存命中记录用量（用量日志关联生成该响应的账号）
+func (h *OpenAIGatewayHandler) replayResponseCacheHit(c *gin.Context, apiKey *service.APIKey, subscription *service.UserSubscription, entry *service.ResponseCacheEntry, stream bool) {
+	startTime := time.Now()
+	replayCachedResponse(c, entry, stream)
+	result := entry.OpenAIForwardResult(stream, time.Since(startTime))
+
+	// 捕获请求信息（用于异步记录，避免在 goroutine 中访问 gin.Context）
+	userAgent := c.GetHeader("User-Agent")
+	clientIP := ip.GetClientIP(c)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := h.gatewayService.RecordUsage(ctx, &service.OpenAIRecordUsageInput{
+			Result:           result,
+			APIKey:           apiKey,
+			User:             apiKey.User,
+			Account:          entry.OriginAccount(),
+			Subscription:     subscription,
+			UserAgent:        userAgent,
+			IPAddress:        clientIP,
+			APIKeyService:    h.apiKeyService,
+			ResponseCacheHit: true,
+		}); err != nil {
+			log.Printf("Record usage failed: %v", err)
+		}
+	}()
+}
+
+// storeResponseCache 缓存本次成功转发的响应
+func (h *OpenAIGatewayHandler) storeResponseCache(c *gin.Context, cacheReq *service.ResponseCacheRequest, w *responseCacheWriter, accountID int64, result *service.OpenAIForwardResult) {
+	if result == nil {
+		return
+	}
+	storeCapturedResponse(c, h.responseCacheService, cacheReq, w, func(contentType string, body []byte) *service.ResponseCacheEntry {
+		return service.NewOpenAIResponseCacheEntry(contentType, body, accountID, result)
+	})
+}
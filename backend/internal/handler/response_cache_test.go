@@ -0,0 +1,67 @@
+//go:build unit
+
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCacheWriterCapture(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	w := newResponseCacheWriter(c.Writer, 32)
+	c.Writer = w
+
+	// 排队期间的 ping 在转发前被丢弃
+	_, _ = c.Writer.WriteString(": ping\n\n")
+	w.reset()
+
+	c.Header("Content-Type", "text/event-stream")
+	_, _ = c.Writer.WriteString("data: {\"a\":1}\n\n")
+	_, _ = c.Writer.Write([]byte("data: [DONE]\n\n"))
+
+	body, ok := w.captured()
+	require.True(t, ok)
+	require.Equal(t, "data: {\"a\":1}\n\ndata: [DONE]\n\n", string(body))
+	require.Equal(t, ": ping\n\ndata: {\"a\":1}\n\ndata: [DONE]\n\n", rec.Body.String())
+
+	// 超出上限后不再记录，但正常写出
+	_, _ = c.Writer.WriteString("data: more than the capture limit\n\n")
+	_, ok = w.captured()
+	require.False(t, ok)
+	require.Contains(t, rec.Body.String(), "more than the capture limit")
+}
+
+func TestResponseCacheWriterSkipsErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	w := newResponseCacheWriter(c.Writer, 1024)
+	c.Writer = w
+
+	c.JSON(http.StatusBadGateway, gin.H{"error": "upstream"})
+	_, ok := w.captured()
+	require.False(t, ok)
+}
+
+func TestReplayCachedResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+
+	entry := &service.ResponseCacheEntry{ContentType: "text/event-stream", Body: []byte("event: message_stop\ndata: {}\n\n")}
+	replayCachedResponse(c, entry, true)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	require.Equal(t, "HIT", rec.Header().Get("X-Response-Cache"))
+	require.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	require.Equal(t, string(entry.Body), rec.Body.String())
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"log"
 	"strconv"
 	"time"
 
@@ -39,24 +40,83 @@ func (h *UsageHandler) List(c *gin.Context) {
 	}
 
 	page, pageSize := response.ParsePagination(c)
+	filters, ok := h.parseUsageLogFilters(c, subject.UserID)
+	if !ok {
+		return
+	}
+
+	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
+	records, result, err := h.usageService.ListWithFilters(c.Request.Context(), params, filters)
+	if err != nil {
+		response.ErrorFrom(c, err)
+		return
+	}
+
+	out := make([]dto.UsageLog, 0, len(records))
+	for i := range records {
+		out = append(out, *dto.UsageLogFromService(&records[i]))
+	}
+	response.Paginated(c, out, result.Total, page, pageSize)
+}
+
+// Export streams the current user's usage records as CSV or JSONL
+// GET /api/v1/usage/export?format=csv|jsonl
+func (h *UsageHandler) Export(c *gin.Context) {
+	subject, ok := middleware2.GetAuthSubjectFromContext(c)
+	if !ok {
+		response.Unauthorized(c, "User not authenticated")
+		return
+	}
 
+	format := c.DefaultQuery("format", dto.UsageExportFormatCSV)
+	if !dto.IsValidUsageExportFormat(format) {
+		response.BadRequest(c, "Invalid format, use csv or jsonl")
+		return
+	}
+	filters, ok := h.parseUsageLogFilters(c, subject.UserID)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", dto.UsageExportContentType(format))
+	c.Header("Content-Disposition", `attachment; filename="`+dto.UsageExportFilename(format, time.Now())+`"`)
+	w := dto.NewUsageExportWriter(c.Writer, format, false)
+	err := h.usageService.ExportWithFilters(c.Request.Context(), filters, w.Write)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+			response.ErrorFrom(c, err)
+			return
+		}
+		// 已开始输出，只能中断连接
+		log.Printf("[Usage] export for user %d aborted: %v", subject.UserID, err)
+		_ = c.Error(err)
+	}
+}
+
+// parseUsageLogFilters 解析列表/导出共用的过滤参数；失败时已写出错误响应
+func (h *UsageHandler) parseUsageLogFilters(c *gin.Context, userID int64) (usagestats.UsageLogFilters, bool) {
 	var apiKeyID int64
 	if apiKeyIDStr := c.Query("api_key_id"); apiKeyIDStr != "" {
 		id, err := strconv.ParseInt(apiKeyIDStr, 10, 64)
 		if err != nil {
 			response.BadRequest(c, "Invalid api_key_id")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 
 		// [Security Fix] Verify API Key ownership to prevent horizontal privilege escalation
 		apiKey, err := h.apiKeyService.GetByID(c.Request.Context(), id)
 		if err != nil {
 			response.ErrorFrom(c, err)
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
-		if apiKey.UserID != subject.UserID {
+		if apiKey.UserID != userID {
 			response.Forbidden(c, "Not authorized to access this API key's usage records")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 
 		apiKeyID = id
@@ -70,7 +130,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		val, err := strconv.ParseBool(streamStr)
 		if err != nil {
 			response.BadRequest(c, "Invalid stream value, use true or false")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		stream = &val
 	}
@@ -80,7 +140,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		val, err := strconv.ParseInt(billingTypeStr, 10, 8)
 		if err != nil {
 			response.BadRequest(c, "Invalid billing_type")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		bt := int8(val)
 		billingType = &bt
@@ -93,7 +153,7 @@ func (h *UsageHandler) List(c *gin.Context) {
 		t, err := timezone.ParseInUserLocation("2006-01-02", startDateStr, userTZ)
 		if err != nil {
 			response.BadRequest(c, "Invalid start_date format, use YYYY-MM-DD")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		startTime = &t
 	}
@@ -102,35 +162,22 @@ func (h *UsageHandler) List(c *gin.Context) {
 		t, err := timezone.ParseInUserLocation("2006-01-02", endDateStr, userTZ)
 		if err != nil {
 			response.BadRequest(c, "Invalid end_date format, use YYYY-MM-DD")
-			return
+			return usagestats.UsageLogFilters{}, false
 		}
 		// Set end time to end of day
 		t = t.Add(24*time.Hour - time.Nanosecond)
 		endTime = &t
 	}
 
-	params := pagination.PaginationParams{Page: page, PageSize: pageSize}
-	filters := usagestats.UsageLogFilters{
-		UserID:      subject.UserID, // Always filter by current user for security
+	return usagestats.UsageLogFilters{
+		UserID:      userID, // Always filter by current user for security
 		APIKeyID:    apiKeyID,
 		Model:       model,
 		Stream:      stream,
 		BillingType: billingType,
 		StartTime:   startTime,
 		EndTime:     endTime,
-	}
-
-	records, result, err := h.usageService.ListWithFilters(c.Request.Context(), params, filters)
-	if err != nil {
-		response.ErrorFrom(c, err)
-		return
-	}
-
-	out := make([]dto.UsageLog, 0, len(records))
-	for i := range records {
-		out = append(out, *dto.UsageLogFromService(&records[i]))
-	}
-	response.Paginated(c, out, result.Total, page, pageSize)
+	}, true
 }
 
 // GetByID handles getting a single usage record
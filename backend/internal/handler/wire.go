@@ -28,6 +28,10 @@ func ProvideAdminHandlers(
 	usageHandler *admin.UsageHandler,
 	userAttributeHandler *admin.UserAttributeHandler,
 	errorPassthroughHandler *admin.ErrorPassthroughHandler,
+	webhookHandler *admin.WebhookHandler,
+	auditLogHandler *admin.AuditLogHandler,
+	balanceLedgerHandler *admin.BalanceLedgerHandler,
+	schedulerHandler *admin.SchedulerHandler,
 ) *AdminHandlers {
 	return &AdminHandlers{
 		Dashboard:        dashboardHandler,
@@ -49,6 +53,10 @@ func ProvideAdminHandlers(
 		Usage:            usageHandler,
 		UserAttribute:    userAttributeHandler,
 		ErrorPassthrough: errorPassthroughHandler,
+		Webhook:          webhookHandler,
+		AuditLog:         auditLogHandler,
+		BalanceLedger:    balanceLedgerHandler,
+		Scheduler:        schedulerHandler,
 	}
 }
 
@@ -74,22 +82,32 @@ func ProvideHandlers(
 	adminHandlers *AdminHandlers,
 	gatewayHandler *GatewayHandler,
 	openaiGatewayHandler *OpenAIGatewayHandler,
+	chatCompletionsHandler *ChatCompletionsHandler,
+	embeddingsHandler *EmbeddingsHandler,
+	imagesHandler *ImagesHandler,
+	messageBatchHandler *MessageBatchHandler,
 	settingHandler *SettingHandler,
 	totpHandler *TotpHandler,
+	balanceLedgerHandler *BalanceLedgerHandler,
 ) *Handlers {
 	return &Handlers{
-		Auth:          authHandler,
-		User:          userHandler,
-		APIKey:        apiKeyHandler,
-		Usage:         usageHandler,
-		Redeem:        redeemHandler,
-		Subscription:  subscriptionHandler,
-		Announcement:  announcementHandler,
-		Admin:         adminHandlers,
-		Gateway:       gatewayHandler,
-		OpenAIGateway: openaiGatewayHandler,
-		Setting:       settingHandler,
-		Totp:          totpHandler,
+		Auth:            authHandler,
+		User:            userHandler,
+		APIKey:          apiKeyHandler,
+		Usage:           usageHandler,
+		Redeem:          redeemHandler,
+		Subscription:    subscriptionHandler,
+		Announcement:    announcementHandler,
+		Admin:           adminHandlers,
+		Gateway:         gatewayHandler,
+		OpenAIGateway:   openaiGatewayHandler,
+		ChatCompletions: chatCompletionsHandler,
+		Embeddings:      embeddingsHandler,
+		Images:          imagesHandler,
+		MessageBatches:  messageBatchHandler,
+		Setting:         settingHandler,
+		Totp:            totpHandler,
+		BalanceLedger:   balanceLedgerHandler,
 	}
 }
 
@@ -105,7 +123,13 @@ var ProviderSet = wire.NewSet(
 	NewAnnouncementHandler,
 	NewGatewayHandler,
 	NewOpenAIGatewayHandler,
+	NewChatCompletionsHandler,
+	NewCompatibleGatewayHandler,
+	NewEmbeddingsHandler,
+	NewImagesHandler,
+	NewMessageBatchHandler,
 	NewTotpHandler,
+	NewBalanceLedgerHandler,
 	ProvideSettingHandler,
 
 	// Admin handlers
@@ -128,6 +152,10 @@ var ProviderSet = wire.NewSet(
 	admin.NewUsageHandler,
 	admin.NewUserAttributeHandler,
 	admin.NewErrorPassthroughHandler,
+	admin.NewWebhookHandler,
+	admin.NewAuditLogHandler,
+	admin.NewBalanceLedgerHandler,
+	admin.NewSchedulerHandler,
 
 	// AdminHandlers and Handlers constructors
 	ProvideAdminHandlers,
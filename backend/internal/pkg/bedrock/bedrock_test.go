@@ -0,0 +1,95 @@
+package bedrock
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// 使用 AWS SigV4 测试套件中的 get-vanilla 用例
+func TestSignRequest_AWSTestSuiteVanilla(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+
+	creds := Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	now := time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+	require.NoError(t, SignRequest(req, nil, creds, "us-east-1", "service", now))
+
+	require.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	require.Equal(t,
+		"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+			"SignedHeaders=host;x-amz-date, "+
+			"Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+func TestSignRequest_SessionTokenAndEscapedPath(t *testing.T) {
+	modelID := "anthropic.claude-3-5-sonnet-20241022-v2:0"
+	req, err := http.NewRequest(http.MethodPost, "https://bedrock-runtime.us-east-1.amazonaws.com/model/x/invoke", strings.NewReader("{}"))
+	require.NoError(t, err)
+	req.URL.Path = "/model/" + modelID + "/invoke"
+	req.URL.RawPath = "/model/" + EscapePathSegment(modelID) + "/invoke"
+	req.Header.Set("Content-Type", "application/json")
+
+	creds := Credentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "session"}
+	require.NoError(t, SignRequest(req, []byte("{}"), creds, "us-east-1", ServiceName, time.Now()))
+
+	require.Equal(t, "session", req.Header.Get("X-Amz-Security-Token"))
+	require.Contains(t, req.Header.Get("Authorization"), "SignedHeaders=content-type;host;x-amz-date;x-amz-security-token,")
+	require.Equal(t, "/model/anthropic.claude-3-5-sonnet-20241022-v2%253A0/invoke", canonicalURI(req))
+}
+
+func TestSignRequest_RequiresCredentials(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/", nil)
+	require.NoError(t, err)
+	require.Error(t, SignRequest(req, nil, Credentials{}, "us-east-1", ServiceName, time.Now()))
+	require.Error(t, SignRequest(req, nil, Credentials{AccessKeyID: "a", SecretAccessKey: "b"}, "", ServiceName, time.Now()))
+}
+
+func TestEventStreamDecoder_RoundTrip(t *testing.T) {
+	frame := EncodeMessage(map[string]string{":message-type": "event", ":event-type": "chunk"}, []byte(`{"bytes":""}`))
+	dec := NewEventStreamDecoder(bytes.NewReader(append(frame, frame...)))
+
+	for i := 0; i < 2; i++ {
+		msg, err := dec.Next()
+		require.NoError(t, err)
+		require.Equal(t, "chunk", msg.Headers[":event-type"])
+		require.Equal(t, `{"bytes":""}`, string(msg.Payload))
+	}
+	_, err := dec.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestEventStreamDecoder_RejectsCorruptFrame(t *testing.T) {
+	frame := EncodeMessage(map[string]string{":message-type": "event"}, []byte("payload"))
+	frame[len(frame)-6] ^= 0xFF
+
+	_, err := NewEventStreamDecoder(bytes.NewReader(frame)).Next()
+	require.ErrorContains(t, err, "checksum")
+
+	_, err = NewEventStreamDecoder(bytes.NewReader(frame[:8])).Next()
+	require.ErrorContains(t, err, "truncated")
+}
+
+func TestSSEReader_TranslatesChunksAndExceptions(t *testing.T) {
+	var stream bytes.Buffer
+	stream.Write(EncodeChunk([]byte(`{"type":"message_start","message":{"usage":{"input_tokens":3}}}`)))
+	stream.Write(EncodeChunk([]byte(`{"type":"message_delta","usage":{"output_tokens":5}}`)))
+	stream.Write(EncodeMessage(map[string]string{
+		":message-type":   "exception",
+		":exception-type": "throttlingException",
+	}, []byte(`{"message":"Too many requests"}`)))
+
+	out, err := io.ReadAll(NewSSEReader(io.NopCloser(&stream)))
+	require.NoError(t, err)
+	require.Equal(t,
+		"event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"usage\":{\"input_tokens\":3}}}\n\n"+
+			"event: message_delta\ndata: {\"type\":\"message_delta\",\"usage\":{\"output_tokens\":5}}\n\n"+
+			"event: error\ndata: {\"error\":{\"message\":\"Too many requests\",\"type\":\"throttlingException\"},\"type\":\"error\"}\n\n",
+		string(out))
+}
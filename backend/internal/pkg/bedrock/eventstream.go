@@ -0,0 +1,249 @@
+package bedrock
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// ContentTypeEventStream invoke-with-response-stream 的响应类型
+const ContentTypeEventStream = "application/vnd.amazon.eventstream"
+
+const (
+	preludeLen = 12 // total_length(4) + headers_length(4) + prelude_crc(4)
+	trailerLen = 4  // message_crc(4)
+
+	// maxMessageLen 单条 event-stream 消息的上限，防止异常长度字段导致超大分配
+	maxMessageLen = 16 << 20
+)
+
+// Message 一条 event-stream 消息。仅保留字符串类型的头，其余类型的头会被跳过。
+type Message struct {
+	Headers map[string]string
+	Payload []byte
+}
+
+// EventStreamDecoder 解码 AWS event-stream 二进制分帧
+type EventStreamDecoder struct {
+	r io.Reader
+}
+
+// NewEventStreamDecoder 创建 event-stream 解码器
+func NewEventStreamDecoder(r io.Reader) *EventStreamDecoder {
+	return &EventStreamDecoder{r: r}
+}
+
+// Next 读取下一条消息；流正常结束时返回 io.EOF。
+func (d *EventStreamDecoder) Next() (*Message, error) {
+	prelude := make([]byte, preludeLen)
+	if _, err := io.ReadFull(d.r, prelude); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, fmt.Errorf("event stream: truncated prelude: %w", err)
+		}
+		return nil, err
+	}
+	totalLen := binary.BigEndian.Uint32(prelude[0:4])
+	headersLen := binary.BigEndian.Uint32(prelude[4:8])
+	if crc32.ChecksumIEEE(prelude[0:8]) != binary.BigEndian.Uint32(prelude[8:12]) {
+		return nil, errors.New("event stream: prelude checksum mismatch")
+	}
+	if totalLen < preludeLen+trailerLen || totalLen > maxMessageLen || uint64(headersLen) > uint64(totalLen-preludeLen-trailerLen) {
+		return nil, fmt.Errorf("event stream: invalid message length %d (headers %d)", totalLen, headersLen)
+	}
+
+	rest := make([]byte, totalLen-preludeLen)
+	if _, err := io.ReadFull(d.r, rest); err != nil {
+		return nil, fmt.Errorf("event stream: truncated message: %w", err)
+	}
+	body := rest[:len(rest)-trailerLen]
+	crc := crc32.NewIEEE()
+	_, _ = crc.Write(prelude)
+	_, _ = crc.Write(body)
+	if crc.Sum32() != binary.BigEndian.Uint32(rest[len(rest)-trailerLen:]) {
+		return nil, errors.New("event stream: message checksum mismatch")
+	}
+
+	headers, err := decodeHeaders(body[:headersLen])
+	if err != nil {
+		return nil, err
+	}
+	return &Message{Headers: headers, Payload: body[headersLen:]}, nil
+}
+
+func decodeHeaders(b []byte) (map[string]string, error) {
+	headers := make(map[string]string)
+	for len(b) > 0 {
+		nameLen := int(b[0])
+		if len(b) < 1+nameLen+1 {
+			return nil, errors.New("event stream: truncated header")
+		}
+		name := string(b[1 : 1+nameLen])
+		valueType := b[1+nameLen]
+		b = b[2+nameLen:]
+
+		var size int
+		switch valueType {
+		case 0, 1: // bool true / false
+			size = 0
+		case 2: // byte
+			size = 1
+		case 3: // short
+			size = 2
+		case 4: // int
+			size = 4
+		case 5, 8: // long / timestamp
+			size = 8
+		case 9: // uuid
+			size = 16
+		case 6, 7: // bytes / string
+			if len(b) < 2 {
+				return nil, errors.New("event stream: truncated header value")
+			}
+			size = int(binary.BigEndian.Uint16(b[:2]))
+			b = b[2:]
+		default:
+			return nil, fmt.Errorf("event stream: unknown header type %d", valueType)
+		}
+		if len(b) < size {
+			return nil, errors.New("event stream: truncated header value")
+		}
+		if valueType == 7 {
+			headers[name] = string(b[:size])
+		}
+		b = b[size:]
+	}
+	return headers, nil
+}
+
+// EncodeMessage 按 event-stream 格式编码一条仅含字符串头的消息（用于测试桩与调试）
+func EncodeMessage(headers map[string]string, payload []byte) []byte {
+	var hb bytes.Buffer
+	for name, value := range headers {
+		hb.WriteByte(byte(len(name)))
+		hb.WriteString(name)
+		hb.WriteByte(7)
+		_ = binary.Write(&hb, binary.BigEndian, uint16(len(value)))
+		hb.WriteString(value)
+	}
+	totalLen := preludeLen + hb.Len() + len(payload) + trailerLen
+
+	var out bytes.Buffer
+	_ = binary.Write(&out, binary.BigEndian, uint32(totalLen))
+	_ = binary.Write(&out, binary.BigEndian, uint32(hb.Len()))
+	_ = binary.Write(&out, binary.BigEndian, crc32.ChecksumIEEE(out.Bytes()))
+	out.Write(hb.Bytes())
+	out.Write(payload)
+	_ = binary.Write(&out, binary.BigEndian, crc32.ChecksumIEEE(out.Bytes()))
+	return out.Bytes()
+}
+
+// EncodeChunk 将一个 Anthropic 流式事件（JSON）包装为 Bedrock chunk 消息
+func EncodeChunk(event []byte) []byte {
+	payload, _ := json.Marshal(map[string]string{"bytes": base64.StdEncoding.EncodeToString(event)})
+	return EncodeMessage(map[string]string{
+		":message-type": "event",
+		":event-type":   "chunk",
+		":content-type": "application/json",
+	}, payload)
+}
+
+// sseReader 将 Bedrock event-stream 转换为 Anthropic SSE 文本流
+type sseReader struct {
+	body    io.ReadCloser
+	decoder *EventStreamDecoder
+	buf     bytes.Buffer
+	err     error
+}
+
+// NewSSEReader 包装 invoke-with-response-stream 的响应体，输出与 Anthropic /v1/messages 一致的 SSE：
+//   - chunk 事件：解出 base64 的 Anthropic 事件，输出 "event: <type>\ndata: <json>\n\n"
+//   - exception / error 消息：输出 Anthropic 格式的 error 事件
+func NewSSEReader(body io.ReadCloser) io.ReadCloser {
+	return &sseReader{body: body, decoder: NewEventStreamDecoder(body)}
+}
+
+func (r *sseReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		msg, err := r.decoder.Next()
+		if err != nil {
+			r.err = err
+			continue
+		}
+		if err := r.writeSSE(msg); err != nil {
+			r.err = err
+		}
+	}
+	return r.buf.Read(p)
+}
+
+func (r *sseReader) Close() error {
+	return r.body.Close()
+}
+
+func (r *sseReader) writeSSE(msg *Message) error {
+	switch msg.Headers[":message-type"] {
+	case "event":
+		if msg.Headers[":event-type"] != "chunk" {
+			return nil
+		}
+		var chunk struct {
+			Bytes string `json:"bytes"`
+		}
+		if err := json.Unmarshal(msg.Payload, &chunk); err != nil {
+			return fmt.Errorf("event stream: decode chunk: %w", err)
+		}
+		event, err := base64.StdEncoding.DecodeString(chunk.Bytes)
+		if err != nil {
+			return fmt.Errorf("event stream: decode chunk bytes: %w", err)
+		}
+		var head struct {
+			Type string `json:"type"`
+		}
+		_ = json.Unmarshal(event, &head)
+		if head.Type != "" {
+			r.buf.WriteString("event: " + head.Type + "\n")
+		}
+		r.buf.WriteString("data: ")
+		r.buf.Write(bytes.TrimSpace(event))
+		r.buf.WriteString("\n\n")
+	case "exception":
+		r.writeError(msg.Headers[":exception-type"], exceptionMessage(msg.Payload))
+	case "error":
+		r.writeError(msg.Headers[":error-code"], msg.Headers[":error-message"])
+	}
+	return nil
+}
+
+func (r *sseReader) writeError(errType, message string) {
+	if errType == "" {
+		errType = "api_error"
+	}
+	data, _ := json.Marshal(map[string]any{
+		"type": "error",
+		"error": map[string]string{
+			"type":    errType,
+			"message": message,
+		},
+	})
+	r.buf.WriteString("event: error\ndata: ")
+	r.buf.Write(data)
+	r.buf.WriteString("\n\n")
+}
+
+func exceptionMessage(payload []byte) string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(payload, &body) == nil && body.Message != "" {
+		return body.Message
+	}
+	return string(payload)
+}
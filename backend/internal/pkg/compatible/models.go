@@ -0,0 +1,56 @@
+// Package compatible provides model metadata for generic OpenAI Chat Completions compatible upstreams
+// (DeepSeek, Qwen/DashScope, Moonshot, Zhipu, self-hosted vLLM, etc.).
+package compatible
+
+// Model represents a model exposed by an OpenAI-compatible upstream
+type Model struct {
+	ID          string `json:"id"`
+	Object      string `json:"object"`
+	Created     int64  `json:"created"`
+	OwnedBy     string `json:"owned_by"`
+	Type        string `json:"type"`
+	DisplayName string `json:"display_name"`
+}
+
+// Pricing 模型价格（USD per million tokens）
+type Pricing struct {
+	InputPerMTok     float64
+	OutputPerMTok    float64
+	CacheReadPerMTok float64
+}
+
+// DefaultModels 常见 OpenAI 兼容上游模型列表（账号未配置 model_mapping 时使用）
+var DefaultModels = []Model{
+	{ID: "deepseek-chat", Object: "model", Created: 1735689600, OwnedBy: "deepseek", Type: "model", DisplayName: "DeepSeek V3"},
+	{ID: "deepseek-reasoner", Object: "model", Created: 1735689600, OwnedBy: "deepseek", Type: "model", DisplayName: "DeepSeek R1"},
+	{ID: "qwen3-max", Object: "model", Created: 1735689600, OwnedBy: "qwen", Type: "model", DisplayName: "Qwen3 Max"},
+	{ID: "qwen-plus", Object: "model", Created: 1735689600, OwnedBy: "qwen", Type: "model", DisplayName: "Qwen Plus"},
+	{ID: "qwen3-coder-plus", Object: "model", Created: 1735689600, OwnedBy: "qwen", Type: "model", DisplayName: "Qwen3 Coder Plus"},
+	{ID: "kimi-k2-0905-preview", Object: "model", Created: 1735689600, OwnedBy: "moonshot", Type: "model", DisplayName: "Kimi K2"},
+	{ID: "glm-4.6", Object: "model", Created: 1735689600, OwnedBy: "zhipu", Type: "model", DisplayName: "GLM-4.6"},
+}
+
+// DefaultPricing 默认模型的价格条目。
+// LiteLLM 价格表中以 provider 前缀登记（如 dashscope/qwen-plus）或未收录的模型在此兜底，
+// 避免计费回退到 Claude Sonnet 价格。
+var DefaultPricing = map[string]Pricing{
+	"deepseek-chat":        {InputPerMTok: 0.28, OutputPerMTok: 0.42, CacheReadPerMTok: 0.028},
+	"deepseek-reasoner":    {InputPerMTok: 0.28, OutputPerMTok: 0.42, CacheReadPerMTok: 0.028},
+	"qwen3-max":            {InputPerMTok: 1.2, OutputPerMTok: 6},
+	"qwen-plus":            {InputPerMTok: 0.4, OutputPerMTok: 1.2},
+	"qwen3-coder-plus":     {InputPerMTok: 1, OutputPerMTok: 5},
+	"kimi-k2-0905-preview": {InputPerMTok: 0.6, OutputPerMTok: 2.5, CacheReadPerMTok: 0.15},
+	"glm-4.6":              {InputPerMTok: 0.6, OutputPerMTok: 2.2, CacheReadPerMTok: 0.11},
+}
+
+// DefaultModelIDs returns the default model ID list
+func DefaultModelIDs() []string {
+	ids := make([]string, len(DefaultModels))
+	for i, m := range DefaultModels {
+		ids[i] = m.ID
+	}
+	return ids
+}
+
+// DefaultTestModel default model for testing compatible accounts
+const DefaultTestModel = "deepseek-chat"
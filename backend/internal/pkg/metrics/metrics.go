@@ -0,0 +1,183 @@
+// Package metrics 提供 Prometheus 指标注册与记录入口。
+//
+// 指标始终在进程内累积（开销极低），是否通过 HTTP 暴露由 metrics.enabled 配置控制。
+// 所有记录函数都是并发安全的，可在 service/handler/middleware 任意位置直接调用。
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "sub2api"
+
+var (
+	registry = prometheus.NewRegistry()
+
+	// 网关请求
+	gatewayRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "gateway",
+		Name:      "requests_total",
+		Help:      "Total gateway requests by platform, group and HTTP status code.",
+	}, []string{"platform", "group_id", "status"})
+
+	gatewayRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "gateway",
+		Name:      "request_duration_seconds",
+		Help:      "End-to-end gateway request latency in seconds.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120, 300, 600},
+	}, []string{"platform", "group_id"})
+
+	gatewayFirstTokenDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "gateway",
+		Name:      "first_token_seconds",
+		Help:      "Latency until the first upstream token of streaming responses, in seconds.",
+		Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 3, 5, 8, 13, 20, 30, 60},
+	}, []string{"platform", "group_id"}) // 模型名由客户端决定，不作为标签以免基数失控
+
+	// 调度器
+	schedulerOutboxLagSeconds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "outbox_lag_seconds",
+		Help:      "Age of the oldest scheduler outbox event processed in the last poll.",
+	})
+
+	schedulerOutboxBacklog = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "outbox_backlog_rows",
+		Help:      "Number of scheduler outbox rows not yet applied to the snapshot cache.",
+	})
+
+	schedulerRebuildsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "scheduler",
+		Name:      "snapshot_rebuilds_total",
+		Help:      "Full scheduler snapshot rebuilds by trigger reason.",
+	}, []string{"reason"})
+
+	// 计费缓存
+	billingCacheWriteDropsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "billing",
+		Name:      "cache_write_drops_total",
+		Help:      "Billing cache write tasks dropped by the async queue, by reason and task kind.",
+	}, []string{"reason", "kind"})
+
+	// Token 刷新
+	tokenRefreshTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "token_refresh",
+		Name:      "attempts_total",
+		Help:      "OAuth token refresh outcomes by platform.",
+	}, []string{"platform", "outcome"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		gatewayRequestsTotal,
+		gatewayRequestDuration,
+		gatewayFirstTokenDuration,
+		schedulerOutboxLagSeconds,
+		schedulerOutboxBacklog,
+		schedulerRebuildsTotal,
+		billingCacheWriteDropsTotal,
+		tokenRefreshTotal,
+	)
+}
+
+// Token refresh outcomes
+const (
+	TokenRefreshSuccess = "success"
+	TokenRefreshFailure = "failure"
+	TokenRefreshRetry   = "retry"
+)
+
+// Handler 返回 /metrics 的 HTTP 处理器
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// Register 注册额外的采集器（如按需查询的账号并发采集器）。
+// 重复注册同一采集器会被忽略。
+func Register(c prometheus.Collector) error {
+	if err := registry.Register(c); err != nil {
+		if _, ok := err.(prometheus.AlreadyRegisteredError); ok {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// GroupLabel 将分组 ID 转为标签值，无分组时为 "none"
+func GroupLabel(groupID *int64) string {
+	if groupID == nil || *groupID <= 0 {
+		return "none"
+	}
+	return strconv.FormatInt(*groupID, 10)
+}
+
+func platformLabel(platform string) string {
+	if platform == "" {
+		return "unknown"
+	}
+	return platform
+}
+
+// ObserveGatewayRequest 记录一次网关请求的状态码与耗时
+func ObserveGatewayRequest(platform, groupID string, status int, elapsed time.Duration) {
+	platform = platformLabel(platform)
+	gatewayRequestsTotal.WithLabelValues(platform, groupID, strconv.Itoa(status)).Inc()
+	gatewayRequestDuration.WithLabelValues(platform, groupID).Observe(elapsed.Seconds())
+}
+
+// ObserveFirstToken 记录流式请求的首 token 延迟
+func ObserveFirstToken(platform, groupID string, firstTokenMs int) {
+	if firstTokenMs < 0 {
+		return
+	}
+	gatewayFirstTokenDuration.WithLabelValues(platformLabel(platform), groupID).Observe(float64(firstTokenMs) / 1000)
+}
+
+// SetSchedulerOutboxLag 更新调度器 outbox 延迟（秒）
+func SetSchedulerOutboxLag(lag time.Duration) {
+	if lag < 0 {
+		lag = 0
+	}
+	schedulerOutboxLagSeconds.Set(lag.Seconds())
+}
+
+// SetSchedulerOutboxBacklog 更新调度器 outbox 积压行数
+func SetSchedulerOutboxBacklog(rows int64) {
+	if rows < 0 {
+		rows = 0
+	}
+	schedulerOutboxBacklog.Set(float64(rows))
+}
+
+// IncSchedulerRebuild 记录一次全量快照重建
+func IncSchedulerRebuild(reason string) {
+	schedulerRebuildsTotal.WithLabelValues(reason).Inc()
+}
+
+// IncBillingCacheWriteDrop 记录一次计费缓存写入任务被丢弃
+func IncBillingCacheWriteDrop(reason, kind string) {
+	billingCacheWriteDropsTotal.WithLabelValues(reason, kind).Inc()
+}
+
+// IncTokenRefresh 记录一次 Token 刷新结果
+func IncTokenRefresh(platform, outcome string) {
+	tokenRefreshTotal.WithLabelValues(platformLabel(platform), outcome).Inc()
+}
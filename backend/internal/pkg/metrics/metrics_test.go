@@ -0,0 +1,46 @@
+//go:build unit
+
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerExposesFamilies(t *testing.T) {
+	groupID := int64(7)
+	ObserveGatewayRequest("anthropic", GroupLabel(&groupID), 200, 1500*time.Millisecond)
+	ObserveFirstToken("openai", GroupLabel(nil), 320)
+	SetSchedulerOutboxLag(3 * time.Second)
+	SetSchedulerOutboxBacklog(-5)
+	IncSchedulerRebuild("outbox_lag")
+	IncBillingCacheWriteDrop("full", "deduct_balance")
+	IncTokenRefresh("", TokenRefreshFailure)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	out := string(body)
+
+	require.Contains(t, out, `sub2api_gateway_requests_total{group_id="7",platform="anthropic",status="200"} 1`)
+	require.Contains(t, out, `sub2api_gateway_request_duration_seconds_bucket{group_id="7",platform="anthropic",le="2.5"} 1`)
+	require.Contains(t, out, `sub2api_gateway_first_token_seconds_count{group_id="none",platform="openai"} 1`)
+	require.Contains(t, out, "sub2api_scheduler_outbox_lag_seconds 3")
+	require.Contains(t, out, "sub2api_scheduler_outbox_backlog_rows 0")
+	require.Contains(t, out, `sub2api_scheduler_snapshot_rebuilds_total{reason="outbox_lag"} 1`)
+	require.Contains(t, out, `sub2api_billing_cache_write_drops_total{kind="deduct_balance",reason="full"} 1`)
+	require.Contains(t, out, `sub2api_token_refresh_attempts_total{outcome="failure",platform="unknown"} 1`)
+	require.Contains(t, out, "go_goroutines")
+}
+
+func TestRegisterIgnoresDuplicates(t *testing.T) {
+	c := prometheus.NewCounter(prometheus.CounterOpts{Name: "sub2api_test_duplicate_total", Help: "test"})
+	require.NoError(t, Register(c))
+	require.NoError(t, Register(c))
+}
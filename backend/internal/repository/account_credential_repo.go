@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/lib/pq"
+)
+
+type accountCredentialRepository struct {
+	sql    sqlExecutor
+	cipher *CredentialCipher
+}
+
+// NewAccountCredentialRepository 创建账号凭证重新加密仓库
+func NewAccountCredentialRepository(sqlDB *sql.DB, cipher *CredentialCipher) service.AccountCredentialRepository {
+	return &accountCredentialRepository{sql: sqlDB, cipher: cipher}
+}
+
+func (r *accountCredentialRepository) ReencryptStale(ctx context.Context, afterID int64, limit int) (result service.CredentialReencryptResult, err error) {
+	result.LastID = afterID
+	if !r.cipher.Enabled() || limit <= 0 {
+		return result, nil
+	}
+
+	sensitive := service.SensitiveCredentialKeys()
+	activePrefix := credentialCiphertextPrefix + r.cipher.ActiveKeyID() + ":"
+
+	// 已软删除的账号同样处理：数据库备份中不应残留明文凭证
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT id, credentials
+		FROM accounts
+		WHERE id > $1
+			AND EXISTS (
+				SELECT 1 FROM jsonb_each(credentials) e
+				WHERE jsonb_typeof(e.value) = 'string'
+					AND e.value #>> '{}' <> ''
+					AND (e.key = ANY($2) OR left(e.value #>> '{}', length($4)) = $4)
+					AND left(e.value #>> '{}', length($3)) <> $3
+			)
+		ORDER BY id ASC
+		LIMIT $5
+	`, afterID, pq.Array(sensitive), activePrefix, credentialCiphertextPrefix, limit)
+	if err != nil {
+		return result, err
+	}
+
+	type staleRow struct {
+		id  int64
+		raw []byte
+	}
+	stale := make([]staleRow, 0, limit)
+	for rows.Next() {
+		var row staleRow
+		if err := rows.Scan(&row.id, &row.raw); err != nil {
+			_ = rows.Close()
+			return result, err
+		}
+		stale = append(stale, row)
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return result, err
+	}
+	if err := rows.Close(); err != nil {
+		return result, err
+	}
+
+	for _, row := range stale {
+		result.Scanned++
+		result.LastID = row.id
+
+		var credentials map[string]any
+		if err := json.Unmarshal(row.raw, &credentials); err != nil {
+			log.Printf("[CredentialReencrypt] account=%d decode credentials failed: %v", row.id, err)
+			result.Failed++
+			continue
+		}
+		rotated, changed, err := r.cipher.RotateCredentials(credentials)
+		if err != nil {
+			log.Printf("[CredentialReencrypt] account=%d rotate failed: %v", row.id, err)
+			result.Failed++
+			continue
+		}
+		if !changed {
+			continue
+		}
+		payload, err := json.Marshal(rotated)
+		if err != nil {
+			return result, err
+		}
+		// 仅当凭证未被并发修改（如 Token 刷新）时才写回；被修改的账号留给下一轮处理
+		res, err := r.sql.ExecContext(ctx,
+			`UPDATE accounts SET credentials = $1::jsonb WHERE id = $2 AND credentials = $3::jsonb`,
+			payload, row.id, row.raw)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return result, err
+			}
+			log.Printf("[CredentialReencrypt] account=%d update failed: %v", row.id, err)
+			result.Failed++
+			continue
+		}
+		if n, _ := res.RowsAffected(); n > 0 {
+			result.Updated++
+		}
+	}
+	return result, nil
+}
@@ -40,6 +40,7 @@ import (
 //   - client: Ent 客户端，用于类型安全的 ORM 操作
 //   - sql: 原生 SQL 执行器，用于复杂查询和批量操作
 //   - schedulerCache: 调度器缓存，用于在账号状态变更时同步快照
+//   - cipher: 凭证加密器，写入时加密敏感凭证字段、读取时解密（nil 表示不加密）
 type accountRepository struct {
 	client *dbent.Client // Ent ORM 客户端
 	sql    sqlExecutor   // 原生 SQL 执行接口
@@ -48,6 +49,7 @@ type accountRepository struct {
 	// Used to proactively sync account snapshot to cache when status changes,
 	// ensuring sticky sessions can promptly detect unavailable accounts.
 	schedulerCache service.SchedulerCache
+	cipher         *CredentialCipher
 }
 
 type tempUnschedSnapshot struct {
@@ -57,8 +59,10 @@ type tempUnschedSnapshot struct {
 
 // NewAccountRepository 创建账户仓储实例。
 // 这是对外暴露的构造函数，返回接口类型以便于依赖注入。
-func NewAccountRepository(client *dbent.Client, sqlDB *sql.DB, schedulerCache service.SchedulerCache) service.AccountRepository {
-	return newAccountRepositoryWithSQL(client, sqlDB, schedulerCache)
+func NewAccountRepository(client *dbent.Client, sqlDB *sql.DB, schedulerCache service.SchedulerCache, cipher *CredentialCipher) service.AccountRepository {
+	repo := newAccountRepositoryWithSQL(client, sqlDB, schedulerCache)
+	repo.cipher = cipher
+	return repo
 }
 
 // newAccountRepositoryWithSQL 是内部构造函数，支持依赖注入 SQL 执行器。
@@ -72,12 +76,17 @@ func (r *accountRepository) Create(ctx context.Context, account *service.Account
 		return service.ErrAccountNilInput
 	}
 
+	credentials, err := r.cipher.EncryptCredentials(normalizeJSONMap(account.Credentials))
+	if err != nil {
+		return err
+	}
+
 	builder := r.client.Account.Create().
 		SetName(account.Name).
 		SetNillableNotes(account.Notes).
 		SetPlatform(account.Platform).
 		SetType(account.Type).
-		SetCredentials(normalizeJSONMap(account.Credentials)).
+		SetCredentials(credentials).
 		SetExtra(normalizeJSONMap(account.Extra)).
 		SetConcurrency(account.Concurrency).
 		SetPriority(account.Priority).
@@ -205,6 +214,7 @@ func (r *accountRepository) GetByIDs(ctx context.Context, ids []int64) ([]*servi
 		if out == nil {
 			continue
 		}
+		r.decryptAccountCredentials(out)
 
 		// Prefer the preloaded proxy edge when available.
 		if entAcc.Edges.Proxy != nil {
@@ -315,12 +325,17 @@ func (r *accountRepository) Update(ctx context.Context, account *service.Account
 		return nil
 	}
 
+	credentials, err := r.cipher.EncryptCredentials(normalizeJSONMap(account.Credentials))
+	if err != nil {
+		return err
+	}
+
 	builder := r.client.Account.UpdateOneID(account.ID).
 		SetName(account.Name).
 		SetNillableNotes(account.Notes).
 		SetPlatform(account.Platform).
 		SetType(account.Type).
-		SetCredentials(normalizeJSONMap(account.Credentials)).
+		SetCredentials(credentials).
 		SetExtra(normalizeJSONMap(account.Extra)).
 		SetConcurrency(account.Concurrency).
 		SetPriority(account.Priority).
@@ -387,6 +402,8 @@ func (r *accountRepository) Update(ctx context.Context, account *service.Account
 		return translatePersistenceError(err, service.ErrAccountNotFound, nil)
 	}
 	account.UpdatedAt = updated.UpdatedAt
+	account.ParseSchedule()
+	account.ParseDrain()
 	if err := enqueueSchedulerOutbox(ctx, r.sql, service.SchedulerOutboxEventAccountChanged, &account.ID, nil, buildSchedulerGroupPayload(account.GroupIDs)); err != nil {
 		log.Printf("[SchedulerOutbox] enqueue account update failed: account=%d err=%v", account.ID, err)
 	}
@@ -501,6 +518,23 @@ func (r *accountRepository) ListActive(ctx context.Context) ([]service.Account,
 	return r.accountsToService(ctx, accounts)
 }
 
+// ListActiveWithSchedule 列出配置了 extra.schedule 的活跃账号（时间段调度边界检查使用）
+func (r *accountRepository) ListActiveWithSchedule(ctx context.Context) ([]service.Account, error) {
+	accounts, err := r.client.Account.Query().
+		Where(
+			dbaccount.StatusEQ(service.StatusActive),
+			func(s *entsql.Selector) {
+				s.Where(sqljson.ValueIsNotNull(dbaccount.FieldExtra, sqljson.Path("schedule")))
+			},
+		).
+		Order(dbent.Asc(dbaccount.FieldPriority)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.accountsToService(ctx, accounts)
+}
+
 func (r *accountRepository) ListByPlatform(ctx context.Context, platform string) ([]service.Account, error) {
 	accounts, err := r.client.Account.Query().
 		Where(
@@ -1146,7 +1180,12 @@ func (r *accountRepository) BulkUpdate(ctx context.Context, ids []int64, updates
 	}
 	// JSONB 需要合并而非覆盖，使用 raw SQL 保持旧行为。
 	if len(updates.Credentials) > 0 {
-		payload, err := json.Marshal(updates.Credentials)
+		// 密文自包含数据密钥，同一份密文可直接合并进所有目标账号
+		credentials, err := r.cipher.EncryptCredentials(updates.Credentials)
+		if err != nil {
+			return 0, err
+		}
+		payload, err := json.Marshal(credentials)
 		if err != nil {
 			return 0, err
 		}
@@ -1303,6 +1342,7 @@ func (r *accountRepository) accountsToService(ctx context.Context, accounts []*d
 		if out == nil {
 			continue
 		}
+		r.decryptAccountCredentials(out)
 		if acc.ProxyID != nil {
 			if proxy, ok := proxyMap[*acc.ProxyID]; ok {
 				out.Proxy = proxy
@@ -1489,6 +1529,23 @@ func buildSchedulerGroupPayload(groupIDs []int64) map[string]any {
 	return map[string]any{"group_ids": groupIDs}
 }
 
+// decryptAccountCredentials 就地解密账号凭证中的密文字段。
+//
+// 单个账号解密失败（密文损坏、主密钥已移除）不应拖垮整个列表查询：
+// 保留原始密文（写回时 EncryptCredentials 会原样保存），并仅在内存中将该账号标记为错误且不可调度，
+// 调度器不会选中它，管理后台仍能看到并修复。
+func (r *accountRepository) decryptAccountCredentials(account *service.Account) {
+	credentials, err := r.cipher.DecryptCredentials(account.Credentials)
+	if err != nil {
+		log.Printf("[AccountRepo] decrypt credentials failed, account marked unschedulable: account=%d err=%v", account.ID, err)
+		account.Status = service.StatusError
+		account.Schedulable = false
+		account.ErrorMessage = "credential decryption failed: " + err.Error()
+		return
+	}
+	account.Credentials = credentials
+}
+
 func accountEntityToService(m *dbent.Account) *service.Account {
 	if m == nil {
 		return nil
@@ -1496,7 +1553,7 @@ func accountEntityToService(m *dbent.Account) *service.Account {
 
 	rateMultiplier := m.RateMultiplier
 
-	return &service.Account{
+	account := &service.Account{
 		ID:                  m.ID,
 		Name:                m.Name,
 		Notes:               m.Notes,
@@ -1523,6 +1580,9 @@ func accountEntityToService(m *dbent.Account) *service.Account {
 		SessionWindowEnd:    m.SessionWindowEnd,
 		SessionWindowStatus: derefString(m.SessionWindowStatus),
 	}
+	account.ParseSchedule()
+	account.ParseDrain()
+	return account
 }
 
 func normalizeJSONMap(in map[string]any) map[string]any {
@@ -4,11 +4,14 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	dbent "github.com/Wei-Shaw/sub2api/ent"
 	"github.com/Wei-Shaw/sub2api/ent/accountgroup"
+	"github.com/Wei-Shaw/sub2api/internal/config"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
 	"github.com/Wei-Shaw/sub2api/internal/service"
 	"github.com/stretchr/testify/suite"
@@ -101,6 +104,131 @@ func (s *AccountRepoSuite) TestCreate() {
 	s.Require().Equal("test-create", got.Name)
 }
 
+func (s *AccountRepoSuite) rawCredentials(id int64) map[string]any {
+	s.T().Helper()
+
+	var raw []byte
+	s.Require().NoError(scanSingleRow(s.ctx, s.repo.sql, "SELECT credentials FROM accounts WHERE id = $1", []any{id}, &raw))
+	var out map[string]any
+	s.Require().NoError(json.Unmarshal(raw, &out))
+	return out
+}
+
+func (s *AccountRepoSuite) TestCredentialEncryption_TransparentAndRotatable() {
+	keys := map[string]string{"k1": strings.Repeat("11", 32)}
+	cipherK1, err := NewCredentialCipher(&config.Config{Credential: config.CredentialEncryptionConfig{ActiveKeyID: "k1", Keys: keys}})
+	s.Require().NoError(err)
+	s.repo.cipher = cipherK1
+
+	account := &service.Account{
+		Name:        "enc-create",
+		Platform:    service.PlatformAnthropic,
+		Type:        service.AccountTypeOAuth,
+		Status:      service.StatusActive,
+		Credentials: map[string]any{"access_token": "at-secret", "scope": "user:inference"},
+		Extra:       map[string]any{},
+		Concurrency: 3,
+		Priority:    50,
+		Schedulable: true,
+	}
+	s.Require().NoError(s.repo.Create(s.ctx, account), "Create")
+	s.Require().Equal("at-secret", account.Credentials["access_token"], "caller keeps plaintext")
+
+	raw := s.rawCredentials(account.ID)
+	s.Require().True(strings.HasPrefix(raw["access_token"].(string), "enc:v1:k1:"))
+	s.Require().Equal("user:inference", raw["scope"])
+
+	got, err := s.repo.GetByID(s.ctx, account.ID)
+	s.Require().NoError(err)
+	s.Require().Equal("at-secret", got.Credentials["access_token"])
+
+	_, err = s.repo.BulkUpdate(s.ctx, []int64{account.ID}, service.AccountBulkUpdate{Credentials: map[string]any{"refresh_token": "rt-secret"}})
+	s.Require().NoError(err, "BulkUpdate")
+	s.Require().True(strings.HasPrefix(s.rawCredentials(account.ID)["refresh_token"].(string), "enc:v1:k1:"))
+	got, err = s.repo.GetByID(s.ctx, account.ID)
+	s.Require().NoError(err)
+	s.Require().Equal("rt-secret", got.Credentials["refresh_token"])
+
+	// 旧账号为明文：切换到 k2 后重新加密任务应同时加密明文并重新包裹 k1 密文
+	legacy := mustCreateAccount(s.T(), s.client, &service.Account{Name: "enc-legacy", Credentials: map[string]any{"api_key": "sk-legacy"}})
+
+	keys["k2"] = strings.Repeat("22", 32)
+	cipherK2, err := NewCredentialCipher(&config.Config{Credential: config.CredentialEncryptionConfig{ActiveKeyID: "k2", Keys: keys}})
+	s.Require().NoError(err)
+	s.repo.cipher = cipherK2
+	credRepo := &accountCredentialRepository{sql: s.repo.sql, cipher: cipherK2}
+
+	res, err := credRepo.ReencryptStale(s.ctx, 0, 100)
+	s.Require().NoError(err, "ReencryptStale")
+	s.Require().GreaterOrEqual(res.Updated, 2)
+	s.Require().Zero(res.Failed)
+
+	s.Require().True(strings.HasPrefix(s.rawCredentials(account.ID)["access_token"].(string), "enc:v1:k2:"))
+	s.Require().True(strings.HasPrefix(s.rawCredentials(legacy.ID)["api_key"].(string), "enc:v1:k2:"))
+
+	res, err = credRepo.ReencryptStale(s.ctx, 0, 100)
+	s.Require().NoError(err)
+	s.Require().Zero(res.Scanned, "nothing left to re-encrypt")
+
+	// k1 移除后仍可读取
+	delete(keys, "k1")
+	onlyK2, err := NewCredentialCipher(&config.Config{Credential: config.CredentialEncryptionConfig{ActiveKeyID: "k2", Keys: keys}})
+	s.Require().NoError(err)
+	s.repo.cipher = onlyK2
+	got, err = s.repo.GetByID(s.ctx, legacy.ID)
+	s.Require().NoError(err)
+	s.Require().Equal("sk-legacy", got.Credentials["api_key"])
+}
+
+func (s *AccountRepoSuite) TestCredentialDecryptFailure_IsolatedToAccount() {
+	keys := map[string]string{"k1": strings.Repeat("11", 32)}
+	cipherK1, err := NewCredentialCipher(&config.Config{Credential: config.CredentialEncryptionConfig{ActiveKeyID: "k1", Keys: keys}})
+	s.Require().NoError(err)
+	s.repo.cipher = cipherK1
+
+	broken := &service.Account{
+		Name:        "enc-broken",
+		Platform:    service.PlatformAnthropic,
+		Type:        service.AccountTypeAPIKey,
+		Status:      service.StatusActive,
+		Credentials: map[string]any{"api_key": "sk-broken"},
+		Extra:       map[string]any{},
+		Concurrency: 1,
+		Schedulable: true,
+	}
+	s.Require().NoError(s.repo.Create(s.ctx, broken), "Create")
+	healthy := mustCreateAccount(s.T(), s.client, &service.Account{Name: "enc-healthy", Credentials: map[string]any{"api_key": "sk-healthy"}})
+	ciphertext := s.rawCredentials(broken.ID)["api_key"]
+
+	// k1 被移除：broken 无法解密，但不影响其他账号
+	onlyK2, err := NewCredentialCipher(&config.Config{Credential: config.CredentialEncryptionConfig{
+		ActiveKeyID: "k2",
+		Keys:        map[string]string{"k2": strings.Repeat("22", 32)},
+	}})
+	s.Require().NoError(err)
+	s.repo.cipher = onlyK2
+
+	accounts, err := s.repo.GetByIDs(s.ctx, []int64{broken.ID, healthy.ID})
+	s.Require().NoError(err, "GetByIDs")
+	s.Require().Len(accounts, 2)
+	for _, acc := range accounts {
+		switch acc.ID {
+		case healthy.ID:
+			s.Require().Equal("sk-healthy", acc.Credentials["api_key"])
+			s.Require().True(acc.IsSchedulable())
+		case broken.ID:
+			s.Require().Equal(service.StatusError, acc.Status)
+			s.Require().False(acc.IsSchedulable())
+			s.Require().Contains(acc.ErrorMessage, "credential decryption failed")
+			s.Require().Equal(ciphertext, acc.Credentials["api_key"], "ciphertext is kept, never replaced")
+		}
+	}
+
+	active, err := s.repo.ListActive(s.ctx)
+	s.Require().NoError(err, "ListActive")
+	s.Require().NotEmpty(active)
+}
+
 func (s *AccountRepoSuite) TestGetByID_NotFound() {
 	_, err := s.repo.GetByID(s.ctx, 999999)
 	s.Require().Error(err, "expected error for non-existent ID")
@@ -274,6 +402,20 @@ func (s *AccountRepoSuite) TestListActive() {
 	s.Require().Equal("active1", accounts[0].Name)
 }
 
+func (s *AccountRepoSuite) TestListActiveWithSchedule() {
+	schedule := map[string]any{"windows": []any{map[string]any{"start": "09:00", "end": "18:00"}}}
+	scheduled := mustCreateAccount(s.T(), s.client, &service.Account{Name: "scheduled", Status: service.StatusActive, Extra: map[string]any{"schedule": schedule}})
+	mustCreateAccount(s.T(), s.client, &service.Account{Name: "plain", Status: service.StatusActive, Extra: map[string]any{"foo": "bar"}})
+	mustCreateAccount(s.T(), s.client, &service.Account{Name: "cleared", Status: service.StatusActive, Extra: map[string]any{"schedule": nil}})
+	mustCreateAccount(s.T(), s.client, &service.Account{Name: "disabled", Status: service.StatusDisabled, Extra: map[string]any{"schedule": schedule}})
+
+	accounts, err := s.repo.ListActiveWithSchedule(s.ctx)
+	s.Require().NoError(err, "ListActiveWithSchedule")
+	s.Require().Len(accounts, 1)
+	s.Require().Equal(scheduled.ID, accounts[0].ID)
+	s.Require().NotNil(accounts[0].GetSchedule())
+}
+
 func (s *AccountRepoSuite) TestListByPlatform() {
 	mustCreateAccount(s.T(), s.client, &service.Account{Name: "p1", Platform: service.PlatformAnthropic, Status: service.StatusActive})
 	mustCreateAccount(s.T(), s.client, &service.Account{Name: "p2", Platform: service.PlatformOpenAI, Status: service.StatusActive})
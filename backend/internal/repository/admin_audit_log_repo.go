@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/ent/adminauditlog"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type adminAuditLogRepository struct {
+	client *dbent.Client
+}
+
+// NewAdminAuditLogRepository 创建管理后台审计日志仓库
+func NewAdminAuditLogRepository(client *dbent.Client) service.AdminAuditLogRepository {
+	return &adminAuditLogRepository{client: client}
+}
+
+func (r *adminAuditLogRepository) Create(ctx context.Context, entry *service.AdminAuditLog) error {
+	builder := r.client.AdminAuditLog.Create().
+		SetNillableActorUserID(entry.ActorUserID).
+		SetAuthMethod(entry.AuthMethod).
+		SetMethod(entry.Method).
+		SetRoute(entry.Route).
+		SetPath(entry.Path).
+		SetStatusCode(entry.StatusCode).
+		SetTargetType(entry.TargetType).
+		SetNillableTargetID(entry.TargetID).
+		SetIPAddress(entry.IPAddress).
+		SetNillableUserAgent(entry.UserAgent)
+	if len(entry.Changes) > 0 {
+		raw, err := json.Marshal(entry.Changes)
+		if err != nil {
+			return err
+		}
+		builder.SetChanges(raw)
+	}
+
+	created, err := builder.Save(ctx)
+	if err != nil {
+		return err
+	}
+	entry.ID = created.ID
+	entry.CreatedAt = created.CreatedAt
+	return nil
+}
+
+func (r *adminAuditLogRepository) List(ctx context.Context, params pagination.PaginationParams, filters service.AdminAuditLogFilters) ([]service.AdminAuditLog, *pagination.PaginationResult, error) {
+	query := r.client.AdminAuditLog.Query()
+	if filters.ActorUserID != nil {
+		query = query.Where(adminauditlog.ActorUserIDEQ(*filters.ActorUserID))
+	}
+	if filters.Method != "" {
+		query = query.Where(adminauditlog.MethodEQ(filters.Method))
+	}
+	if filters.TargetType != "" {
+		query = query.Where(adminauditlog.TargetTypeEQ(filters.TargetType))
+	}
+	if filters.TargetID != "" {
+		query = query.Where(adminauditlog.TargetIDEQ(filters.TargetID))
+	}
+	if filters.Route != "" {
+		query = query.Where(adminauditlog.RouteContains(filters.Route))
+	}
+	if filters.StartTime != nil {
+		query = query.Where(adminauditlog.CreatedAtGTE(*filters.StartTime))
+	}
+	if filters.EndTime != nil {
+		query = query.Where(adminauditlog.CreatedAtLT(*filters.EndTime))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := query.
+		Order(dbent.Desc(adminauditlog.FieldCreatedAt), dbent.Desc(adminauditlog.FieldID)).
+		Offset(params.Offset()).
+		Limit(params.Limit()).
+		All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make([]service.AdminAuditLog, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *adminAuditLogEntityToService(row))
+	}
+	return out, paginationResultFromTotal(int64(total), params), nil
+}
+
+func adminAuditLogEntityToService(row *dbent.AdminAuditLog) *service.AdminAuditLog {
+	out := &service.AdminAuditLog{
+		ID:          row.ID,
+		ActorUserID: row.ActorUserID,
+		AuthMethod:  row.AuthMethod,
+		Method:      row.Method,
+		Route:       row.Route,
+		Path:        row.Path,
+		StatusCode:  row.StatusCode,
+		TargetType:  row.TargetType,
+		TargetID:    row.TargetID,
+		IPAddress:   row.IPAddress,
+		UserAgent:   row.UserAgent,
+		CreatedAt:   row.CreatedAt,
+	}
+	if len(row.Changes) > 0 {
+		_ = json.Unmarshal(row.Changes, &out.Changes)
+	}
+	return out
+}
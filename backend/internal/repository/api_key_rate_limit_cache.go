@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// API Key 限流缓存
+//
+// 设计说明：
+// - RPM：有序集合，Member 为请求唯一 ID，Score 为请求时间（毫秒），窗口外记录通过 ZREMRANGEBYSCORE 清理
+// - TPM：有序集合，Member 为 "{tokens}:{uuid}"，Score 为记录时间（毫秒），脚本内累加窗口内 token 数
+// - 每日请求数：按日期分键的计数器，过期时间为当日结束
+//
+// 同一 Key 的三个计数使用相同 hash tag，保证 Redis Cluster 下可在同一脚本内访问。
+const apiKeyRequestLimitKeyPrefix = "apikey:limit:"
+
+var (
+	// apiKeyRateLimitAcquireScript 检查 RPM/TPM/每日请求数并登记本次请求
+	// 使用 Redis 服务器时间，避免多实例时钟不同步
+	// KEYS[1] = RPM 有序集合, KEYS[2] = TPM 有序集合, KEYS[3] = 每日计数器
+	// ARGV[1] = rpmLimit, ARGV[2] = tpmLimit, ARGV[3] = dailyLimit
+	// ARGV[4] = 窗口（毫秒）, ARGV[5] = 每日计数器 TTL（毫秒）, ARGV[6] = 请求唯一 ID
+	// 返回: {超限类型(0=允许,1=rpm,2=tpm,3=daily), 重试等待毫秒, 请求数, token 数, 当日请求数, 请求窗口重置毫秒, token 窗口重置毫秒}
+	apiKeyRateLimitAcquireScript = redis.NewScript(`
+		local rpmLimit = tonumber(ARGV[1])
+		local tpmLimit = tonumber(ARGV[2])
+		local dailyLimit = tonumber(ARGV[3])
+		local window = tonumber(ARGV[4])
+		local dailyTTL = tonumber(ARGV[5])
+
+		local timeResult = redis.call('TIME')
+		local now = tonumber(timeResult[1]) * 1000 + math.floor(tonumber(timeResult[2]) / 1000)
+		local expireBefore = now - window
+
+		local requests, requestsReset = 0, 0
+		if rpmLimit > 0 then
+			redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', expireBefore)
+			requests = redis.call('ZCARD', KEYS[1])
+			if requests > 0 then
+				local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+				requestsReset = tonumber(oldest[2]) + window - now
+			end
+			if requests >= rpmLimit then
+				-- 需等待第 (requests - rpmLimit + 1) 早的请求滑出窗口
+				local pivot = redis.call('ZRANGE', KEYS[1], requests - rpmLimit, requests - rpmLimit, 'WITHSCORES')
+				return {1, tonumber(pivot[2]) + window - now, requests, 0, 0, requestsReset, 0}
+			end
+		end
+
+		local tokens, tokensReset = 0, 0
+		if tpmLimit > 0 then
+			redis.call('ZREMRANGEBYSCORE', KEYS[2], '-inf', expireBefore)
+			local entries = redis.call('ZRANGE', KEYS[2], 0, -1, 'WITHSCORES')
+			for i = 1, #entries, 2 do
+				tokens = tokens + (tonumber(string.match(entries[i], '^(%d+):')) or 0)
+			end
+			if #entries > 0 then
+				tokensReset = tonumber(entries[2]) + window - now
+			end
+			if tokens >= tpmLimit then
+				-- 需等待足够多的早期记录滑出窗口，使窗口内 token 数回落到上限以下
+				local remaining = tokens
+				local retry = tokensReset
+				for i = 1, #entries, 2 do
+					remaining = remaining - (tonumber(string.match(entries[i], '^(%d+):')) or 0)
+					if remaining < tpmLimit then
+						retry = tonumber(entries[i + 1]) + window - now
+						break
+					end
+				end
+				return {2, retry, requests, tokens, 0, requestsReset, tokensReset}
+			end
+		end
+
+		local daily = 0
+		if dailyLimit > 0 then
+			daily = tonumber(redis.call('GET', KEYS[3]) or '0')
+			if daily >= dailyLimit then
+				local ttl = redis.call('PTTL', KEYS[3])
+				if ttl < 0 then
+					ttl = dailyTTL
+				end
+				return {3, ttl, requests, tokens, daily, requestsReset, tokensReset}
+			end
+		end
+
+		if rpmLimit > 0 then
+			redis.call('ZADD', KEYS[1], now, ARGV[6])
+			redis.call('PEXPIRE', KEYS[1], window)
+			requests = requests + 1
+			if requestsReset == 0 then
+				requestsReset = window
+			end
+		end
+		if dailyLimit > 0 then
+			daily = redis.call('INCR', KEYS[3])
+			if daily == 1 or redis.call('PTTL', KEYS[3]) < 0 then
+				redis.call('PEXPIRE', KEYS[3], dailyTTL)
+			end
+		end
+		return {0, 0, requests, tokens, daily, requestsReset, tokensReset}
+	`)
+
+	// apiKeyRateLimitAddTokensScript 记录 token 消耗
+	// KEYS[1] = TPM 有序集合
+	// ARGV[1] = 成员（"{tokens}:{uuid}"）, ARGV[2] = 窗口（毫秒）
+	apiKeyRateLimitAddTokensScript = redis.NewScript(`
+		local window = tonumber(ARGV[2])
+		local timeResult = redis.call('TIME')
+		local now = tonumber(timeResult[1]) * 1000 + math.floor(tonumber(timeResult[2]) / 1000)
+		redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+		redis.call('ZADD', KEYS[1], now, ARGV[1])
+		redis.call('PEXPIRE', KEYS[1], window)
+		return 1
+	`)
+)
+
+var apiKeyRateLimitExceeded = map[int64]string{
+	1: service.APIKeyRateLimitRPM,
+	2: service.APIKeyRateLimitTPM,
+	3: service.APIKeyRateLimitDaily,
+}
+
+type apiKeyRateLimitCache struct {
+	rdb *redis.Client
+}
+
+// NewAPIKeyRateLimitCache 创建 API Key 限流缓存
+func NewAPIKeyRateLimitCache(rdb *redis.Client) service.APIKeyRateLimitCache {
+	return &apiKeyRateLimitCache{rdb: rdb}
+}
+
+// apiKeyRequestLimitKey 格式: apikey:limit:{apiKeyID}:{kind}
+func apiKeyRequestLimitKey(apiKeyID int64, kind string) string {
+	return fmt.Sprintf("%s{%d}:%s", apiKeyRequestLimitKeyPrefix, apiKeyID, kind)
+}
+
+// apiKeyRequestLimitDailyKey 格式: apikey:limit:{apiKeyID}:daily:{yyyymmdd}
+func apiKeyRequestLimitDailyKey(apiKeyID int64, day time.Time) string {
+	return apiKeyRequestLimitKey(apiKeyID, "daily:"+day.Format("20060102"))
+}
+
+func (c *apiKeyRateLimitCache) Acquire(ctx context.Context, apiKeyID int64, limits service.APIKeyRateLimits, window, dailyTTL time.Duration) (*service.APIKeyRateLimitDecision, error) {
+	keys := []string{
+		apiKeyRequestLimitKey(apiKeyID, "rpm"),
+		apiKeyRequestLimitKey(apiKeyID, "tpm"),
+		apiKeyRequestLimitDailyKey(apiKeyID, timezone.Now()),
+	}
+	values, err := apiKeyRateLimitAcquireScript.Run(ctx, c.rdb, keys,
+		limits.RPM, limits.TPM, limits.DailyRequests,
+		window.Milliseconds(), dailyTTL.Milliseconds(), uuid.NewString(),
+	).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(values) < 7 {
+		return nil, fmt.Errorf("api key rate limit script returned %d values", len(values))
+	}
+
+	decision := &service.APIKeyRateLimitDecision{
+		Allowed:    values[0] == 0,
+		Exceeded:   apiKeyRateLimitExceeded[values[0]],
+		RetryAfter: time.Duration(max(values[1], 0)) * time.Millisecond,
+		Usage: service.APIKeyRateLimitUsage{
+			Requests:      int(values[2]),
+			Tokens:        int(values[3]),
+			DailyRequests: int(values[4]),
+			RequestsReset: time.Duration(max(values[5], 0)) * time.Millisecond,
+			TokensReset:   time.Duration(max(values[6], 0)) * time.Millisecond,
+			DailyReset:    dailyTTL,
+		},
+	}
+	return decision, nil
+}
+
+func (c *apiKeyRateLimitCache) AddTokens(ctx context.Context, apiKeyID int64, tokens int, window time.Duration) error {
+	member := strconv.Itoa(tokens) + ":" + uuid.NewString()
+	return apiKeyRateLimitAddTokensScript.Run(ctx, c.rdb,
+		[]string{apiKeyRequestLimitKey(apiKeyID, "tpm")},
+		member, window.Milliseconds(),
+	).Err()
+}
@@ -0,0 +1,107 @@
+//go:build integration
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type APIKeyRateLimitCacheSuite struct {
+	IntegrationRedisSuite
+	cache service.APIKeyRateLimitCache
+}
+
+func (s *APIKeyRateLimitCacheSuite) SetupTest() {
+	s.IntegrationRedisSuite.SetupTest()
+	s.cache = NewAPIKeyRateLimitCache(s.rdb)
+}
+
+func (s *APIKeyRateLimitCacheSuite) TestRPMLimit() {
+	limits := service.APIKeyRateLimits{RPM: 2}
+	for i := 1; i <= 2; i++ {
+		decision, err := s.cache.Acquire(s.ctx, 1, limits, time.Minute, time.Hour)
+		require.NoError(s.T(), err)
+		require.True(s.T(), decision.Allowed)
+		require.Equal(s.T(), i, decision.Usage.Requests)
+	}
+
+	decision, err := s.cache.Acquire(s.ctx, 1, limits, time.Minute, time.Hour)
+	require.NoError(s.T(), err)
+	require.False(s.T(), decision.Allowed)
+	require.Equal(s.T(), service.APIKeyRateLimitRPM, decision.Exceeded)
+	require.Greater(s.T(), decision.RetryAfter, time.Duration(0))
+	require.LessOrEqual(s.T(), decision.RetryAfter, time.Minute)
+
+	// 被拒绝的请求不计数
+	count, err := s.rdb.ZCard(s.ctx, apiKeyRequestLimitKey(1, "rpm")).Result()
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(2), count)
+
+	// 不同 Key 互不影响
+	decision, err = s.cache.Acquire(s.ctx, 2, limits, time.Minute, time.Hour)
+	require.NoError(s.T(), err)
+	require.True(s.T(), decision.Allowed)
+}
+
+func (s *APIKeyRateLimitCacheSuite) TestRPMWindowSlides() {
+	limits := service.APIKeyRateLimits{RPM: 1}
+	window := 200 * time.Millisecond
+
+	decision, err := s.cache.Acquire(s.ctx, 1, limits, window, time.Hour)
+	require.NoError(s.T(), err)
+	require.True(s.T(), decision.Allowed)
+	decision, err = s.cache.Acquire(s.ctx, 1, limits, window, time.Hour)
+	require.NoError(s.T(), err)
+	require.False(s.T(), decision.Allowed)
+
+	time.Sleep(window + 50*time.Millisecond)
+	decision, err = s.cache.Acquire(s.ctx, 1, limits, window, time.Hour)
+	require.NoError(s.T(), err)
+	require.True(s.T(), decision.Allowed)
+}
+
+func (s *APIKeyRateLimitCacheSuite) TestTPMLimit() {
+	limits := service.APIKeyRateLimits{TPM: 100}
+
+	decision, err := s.cache.Acquire(s.ctx, 1, limits, time.Minute, time.Hour)
+	require.NoError(s.T(), err)
+	require.True(s.T(), decision.Allowed)
+
+	require.NoError(s.T(), s.cache.AddTokens(s.ctx, 1, 60, time.Minute))
+	decision, err = s.cache.Acquire(s.ctx, 1, limits, time.Minute, time.Hour)
+	require.NoError(s.T(), err)
+	require.True(s.T(), decision.Allowed)
+	require.Equal(s.T(), 60, decision.Usage.Tokens)
+
+	require.NoError(s.T(), s.cache.AddTokens(s.ctx, 1, 40, time.Minute))
+	decision, err = s.cache.Acquire(s.ctx, 1, limits, time.Minute, time.Hour)
+	require.NoError(s.T(), err)
+	require.False(s.T(), decision.Allowed)
+	require.Equal(s.T(), service.APIKeyRateLimitTPM, decision.Exceeded)
+	require.Equal(s.T(), 100, decision.Usage.Tokens)
+	require.Greater(s.T(), decision.RetryAfter, time.Duration(0))
+}
+
+func (s *APIKeyRateLimitCacheSuite) TestDailyLimit() {
+	limits := service.APIKeyRateLimits{DailyRequests: 1}
+
+	decision, err := s.cache.Acquire(s.ctx, 1, limits, time.Minute, time.Hour)
+	require.NoError(s.T(), err)
+	require.True(s.T(), decision.Allowed)
+	require.Equal(s.T(), 1, decision.Usage.DailyRequests)
+
+	decision, err = s.cache.Acquire(s.ctx, 1, limits, time.Minute, time.Hour)
+	require.NoError(s.T(), err)
+	require.False(s.T(), decision.Allowed)
+	require.Equal(s.T(), service.APIKeyRateLimitDaily, decision.Exceeded)
+	s.AssertTTLWithin(decision.RetryAfter, 59*time.Minute, time.Hour)
+}
+
+func TestAPIKeyRateLimitCacheSuite(t *testing.T) {
+	suite.Run(t, new(APIKeyRateLimitCacheSuite))
+}
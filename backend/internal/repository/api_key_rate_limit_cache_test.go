@@ -0,0 +1,17 @@
+//go:build unit
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRequestLimitKeys(t *testing.T) {
+	require.Equal(t, "apikey:limit:{42}:rpm", apiKeyRequestLimitKey(42, "rpm"))
+
+	day := time.Date(2026, 3, 9, 23, 59, 0, 0, time.UTC)
+	require.Equal(t, "apikey:limit:{42}:daily:20260309", apiKeyRequestLimitDailyKey(42, day))
+}
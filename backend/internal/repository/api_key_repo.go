@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"crypto/subtle"
+	"log"
 	"time"
 
 	dbent "github.com/Wei-Shaw/sub2api/ent"
@@ -27,16 +29,27 @@ func (r *apiKeyRepository) activeQuery() *dbent.APIKeyQuery {
 	return r.client.APIKey.Query().Where(apikey.DeletedAtIsNil())
 }
 
+// Create 只保存 Key 的可见前缀、盐与哈希，不落库明文
 func (r *apiKeyRepository) Create(ctx context.Context, key *service.APIKey) error {
+	salt, hash, err := service.HashAPIKey(key.Key)
+	if err != nil {
+		return err
+	}
+	prefix := service.APIKeyPrefix(key.Key)
 	builder := r.client.APIKey.Create().
 		SetUserID(key.UserID).
-		SetKey(key.Key).
+		SetKeyPrefix(prefix).
+		SetKeySalt(salt).
+		SetKeyHash(hash).
 		SetName(key.Name).
 		SetStatus(key.Status).
 		SetNillableGroupID(key.GroupID).
 		SetQuota(key.Quota).
 		SetQuotaUsed(key.QuotaUsed).
-		SetNillableExpiresAt(key.ExpiresAt)
+		SetNillableExpiresAt(key.ExpiresAt).
+		SetRpmLimit(key.RPMLimit).
+		SetTpmLimit(key.TPMLimit).
+		SetDailyRequestLimit(key.DailyRequestLimit)
 
 	if len(key.IPWhitelist) > 0 {
 		builder.SetIPWhitelist(key.IPWhitelist)
@@ -48,6 +61,9 @@ func (r *apiKeyRepository) Create(ctx context.Context, key *service.APIKey) erro
 	created, err := builder.Save(ctx)
 	if err == nil {
 		key.ID = created.ID
+		key.KeyPrefix = prefix
+		key.KeySalt = salt
+		key.KeyHash = hash
 		key.CreatedAt = created.CreatedAt
 		key.UpdatedAt = created.UpdatedAt
 	}
@@ -69,15 +85,15 @@ func (r *apiKeyRepository) GetByID(ctx context.Context, id int64) (*service.APIK
 	return apiKeyEntityToService(m), nil
 }
 
-// GetKeyAndOwnerID 根据 API Key ID 获取其 key 与所有者（用户）ID。
+// GetKeyPrefixAndOwnerID 根据 API Key ID 获取其可见前缀与所有者（用户）ID。
 // 相比 GetByID，此方法性能更优，因为：
 //   - 使用 Select() 只查询必要字段，减少数据传输量
 //   - 不加载完整的 API Key 实体及其关联数据（User、Group 等）
-//   - 适用于删除等只需 key 与用户 ID 的场景
-func (r *apiKeyRepository) GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
+//   - 适用于删除等只需前缀（失效认证缓存）与用户 ID 的场景
+func (r *apiKeyRepository) GetKeyPrefixAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
 	m, err := r.activeQuery().
 		Where(apikey.IDEQ(id)).
-		Select(apikey.FieldKey, apikey.FieldUserID).
+		Select(apikey.FieldKeyPrefix, apikey.FieldUserID).
 		Only(ctx)
 	if err != nil {
 		if dbent.IsNotFound(err) {
@@ -85,30 +101,41 @@ func (r *apiKeyRepository) GetKeyAndOwnerID(ctx context.Context, id int64) (stri
 		}
 		return "", 0, err
 	}
-	return m.Key, m.UserID, nil
+	return m.KeyPrefix, m.UserID, nil
 }
 
 func (r *apiKeyRepository) GetByKey(ctx context.Context, key string) (*service.APIKey, error) {
-	m, err := r.activeQuery().
-		Where(apikey.KeyEQ(key)).
+	if key == "" {
+		return nil, service.ErrAPIKeyNotFound
+	}
+	candidates, err := r.activeQuery().
+		Where(apikey.KeyPrefixEQ(service.APIKeyPrefix(key))).
 		WithUser().
 		WithGroup().
-		Only(ctx)
+		All(ctx)
 	if err != nil {
-		if dbent.IsNotFound(err) {
-			return nil, service.ErrAPIKeyNotFound
-		}
 		return nil, err
 	}
+	m := matchAPIKeyEntity(candidates, key)
+	if m == nil {
+		return nil, service.ErrAPIKeyNotFound
+	}
 	return apiKeyEntityToService(m), nil
 }
 
-func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*service.APIKey, error) {
-	m, err := r.activeQuery().
-		Where(apikey.KeyEQ(key)).
+func (r *apiKeyRepository) ListByKeyPrefixForAuth(ctx context.Context, prefix string) ([]service.APIKey, error) {
+	if prefix == "" {
+		return nil, nil
+	}
+	candidates, err := r.activeQuery().
+		Where(apikey.KeyPrefixEQ(prefix)).
 		Select(
 			apikey.FieldID,
 			apikey.FieldUserID,
+			apikey.FieldKey,
+			apikey.FieldKeyPrefix,
+			apikey.FieldKeySalt,
+			apikey.FieldKeyHash,
 			apikey.FieldGroupID,
 			apikey.FieldStatus,
 			apikey.FieldIPWhitelist,
@@ -116,6 +143,9 @@ func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*se
 			apikey.FieldQuota,
 			apikey.FieldQuotaUsed,
 			apikey.FieldExpiresAt,
+			apikey.FieldRpmLimit,
+			apikey.FieldTpmLimit,
+			apikey.FieldDailyRequestLimit,
 		).
 		WithUser(func(q *dbent.UserQuery) {
 			q.Select(
@@ -147,16 +177,52 @@ func (r *apiKeyRepository) GetByKeyForAuth(ctx context.Context, key string) (*se
 				group.FieldModelRouting,
 				group.FieldMcpXMLInject,
 				group.FieldSupportedModelScopes,
+				group.FieldDefaultRpmLimit,
+				group.FieldDefaultTpmLimit,
+				group.FieldDefaultDailyRequestLimit,
+				group.FieldResponseCacheEnabled,
+				group.FieldResponseCacheTTLSeconds,
+				group.FieldOpenaiFallbackModelMapping,
+				group.FieldRequestPolicy,
 			)
 		}).
-		Only(ctx)
+		All(ctx)
 	if err != nil {
-		if dbent.IsNotFound(err) {
-			return nil, service.ErrAPIKeyNotFound
-		}
 		return nil, err
 	}
-	return apiKeyEntityToService(m), nil
+
+	out := make([]service.APIKey, 0, len(candidates))
+	for _, m := range candidates {
+		if m.KeyHash == nil && m.Key != nil {
+			r.hashLegacyKey(ctx, m)
+		}
+		out = append(out, *apiKeyEntityToService(m))
+	}
+	return out, nil
+}
+
+// hashLegacyKey 将迁移前的明文 Key 改为哈希存储并清除明文。
+// 写回失败（或已被其他实例迁移）不影响本次认证：内存中的实体总会带上可校验的哈希。
+func (r *apiKeyRepository) hashLegacyKey(ctx context.Context, m *dbent.APIKey) {
+	plain := *m.Key
+	salt, hash, err := service.HashAPIKey(plain)
+	if err != nil {
+		log.Printf("[APIKey] hash legacy key failed: api_key_id=%d err=%v", m.ID, err)
+		return
+	}
+	// 以明文作为条件，避免覆盖其他实例已写入的盐与哈希
+	if _, err := r.client.APIKey.Update().
+		Where(apikey.IDEQ(m.ID), apikey.KeyEQ(plain)).
+		SetKeyPrefix(service.APIKeyPrefix(plain)).
+		SetKeySalt(salt).
+		SetKeyHash(hash).
+		ClearKey().
+		Save(ctx); err != nil {
+		log.Printf("[APIKey] persist hashed legacy key failed: api_key_id=%d err=%v", m.ID, err)
+	}
+	m.Key = nil
+	m.KeySalt = &salt
+	m.KeyHash = &hash
 }
 
 func (r *apiKeyRepository) Update(ctx context.Context, key *service.APIKey) error {
@@ -172,6 +238,9 @@ func (r *apiKeyRepository) Update(ctx context.Context, key *service.APIKey) erro
 		SetStatus(key.Status).
 		SetQuota(key.Quota).
 		SetQuotaUsed(key.QuotaUsed).
+		SetRpmLimit(key.RPMLimit).
+		SetTpmLimit(key.TPMLimit).
+		SetDailyRequestLimit(key.DailyRequestLimit).
 		SetUpdatedAt(now)
 	if key.GroupID != nil {
 		builder.SetGroupID(*key.GroupID)
@@ -285,8 +354,17 @@ func (r *apiKeyRepository) CountByUserID(ctx context.Context, userID int64) (int
 }
 
 func (r *apiKeyRepository) ExistsByKey(ctx context.Context, key string) (bool, error) {
-	count, err := r.activeQuery().Where(apikey.KeyEQ(key)).Count(ctx)
-	return count > 0, err
+	if key == "" {
+		return false, nil
+	}
+	candidates, err := r.activeQuery().
+		Where(apikey.KeyPrefixEQ(service.APIKeyPrefix(key))).
+		Select(apikey.FieldID, apikey.FieldKey, apikey.FieldKeySalt, apikey.FieldKeyHash).
+		All(ctx)
+	if err != nil {
+		return false, err
+	}
+	return matchAPIKeyEntity(candidates, key) != nil, nil
 }
 
 func (r *apiKeyRepository) ListByGroupID(ctx context.Context, groupID int64, params pagination.PaginationParams) ([]service.APIKey, *pagination.PaginationResult, error) {
@@ -353,26 +431,28 @@ func (r *apiKeyRepository) CountByGroupID(ctx context.Context, groupID int64) (i
 	return int64(count), err
 }
 
-func (r *apiKeyRepository) ListKeysByUserID(ctx context.Context, userID int64) ([]string, error) {
-	keys, err := r.activeQuery().
+func (r *apiKeyRepository) ListKeyPrefixesByUserID(ctx context.Context, userID int64) ([]string, error) {
+	prefixes, err := r.activeQuery().
 		Where(apikey.UserIDEQ(userID)).
-		Select(apikey.FieldKey).
+		Unique(true).
+		Select(apikey.FieldKeyPrefix).
 		Strings(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return keys, nil
+	return prefixes, nil
 }
 
-func (r *apiKeyRepository) ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error) {
-	keys, err := r.activeQuery().
+func (r *apiKeyRepository) ListKeyPrefixesByGroupID(ctx context.Context, groupID int64) ([]string, error) {
+	prefixes, err := r.activeQuery().
 		Where(apikey.GroupIDEQ(groupID)).
-		Select(apikey.FieldKey).
+		Unique(true).
+		Select(apikey.FieldKeyPrefix).
 		Strings(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return keys, nil
+	return prefixes, nil
 }
 
 // IncrementQuotaUsed atomically increments the quota_used field and returns the new value
@@ -414,7 +494,9 @@ func apiKeyEntityToService(m *dbent.APIKey) *service.APIKey {
 	out := &service.APIKey{
 		ID:          m.ID,
 		UserID:      m.UserID,
-		Key:         m.Key,
+		KeyPrefix:   m.KeyPrefix,
+		KeySalt:     derefString(m.KeySalt),
+		KeyHash:     derefString(m.KeyHash),
 		Name:        m.Name,
 		Status:      m.Status,
 		IPWhitelist: m.IPWhitelist,
@@ -425,6 +507,10 @@ func apiKeyEntityToService(m *dbent.APIKey) *service.APIKey {
 		Quota:       m.Quota,
 		QuotaUsed:   m.QuotaUsed,
 		ExpiresAt:   m.ExpiresAt,
+
+		RPMLimit:          m.RpmLimit,
+		TPMLimit:          m.TpmLimit,
+		DailyRequestLimit: m.DailyRequestLimit,
 	}
 	if m.Edges.User != nil {
 		out.User = userEntityToService(m.Edges.User)
@@ -435,6 +521,25 @@ func apiKeyEntityToService(m *dbent.APIKey) *service.APIKey {
 	return out
 }
 
+// matchAPIKeyEntity 在同一前缀的候选中查找与 key 匹配的记录：
+// 已哈希的记录校验哈希，迁移前的记录比较明文，均为常量时间比较。
+func matchAPIKeyEntity(candidates []*dbent.APIKey, key string) *dbent.APIKey {
+	var matched *dbent.APIKey
+	for _, m := range candidates {
+		ok := false
+		switch {
+		case m.KeyHash != nil && m.KeySalt != nil:
+			ok = service.VerifyAPIKeyHash(key, *m.KeySalt, *m.KeyHash)
+		case m.Key != nil:
+			ok = subtle.ConstantTimeCompare([]byte(*m.Key), []byte(key)) == 1
+		}
+		if ok && matched == nil {
+			matched = m
+		}
+	}
+	return matched
+}
+
 func userEntityToService(u *dbent.User) *service.User {
 	if u == nil {
 		return nil
@@ -486,6 +591,13 @@ func groupEntityToService(g *dbent.Group) *service.Group {
 		MCPXMLInject:                    g.McpXMLInject,
 		SupportedModelScopes:            g.SupportedModelScopes,
 		SortOrder:                       g.SortOrder,
+		DefaultRPMLimit:                 g.DefaultRpmLimit,
+		DefaultTPMLimit:                 g.DefaultTpmLimit,
+		DefaultDailyRequestLimit:        g.DefaultDailyRequestLimit,
+		ResponseCacheEnabled:            g.ResponseCacheEnabled,
+		ResponseCacheTTLSeconds:         g.ResponseCacheTTLSeconds,
+		OpenAIFallbackModelMapping:      g.OpenaiFallbackModelMapping,
+		RequestPolicy:                   g.RequestPolicy,
 		CreatedAt:                       g.CreatedAt,
 		UpdatedAt:                       g.UpdatedAt,
 	}
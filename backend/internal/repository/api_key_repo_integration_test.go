@@ -48,7 +48,13 @@ func (s *APIKeyRepoSuite) TestCreate() {
 
 	got, err := s.repo.GetByID(s.ctx, key.ID)
 	s.Require().NoError(err, "GetByID")
-	s.Require().Equal("sk-create-test", got.Key)
+	s.Require().Empty(got.Key, "plaintext key must not be readable after create")
+	s.Require().Equal(service.APIKeyPrefix("sk-create-test"), got.KeyPrefix)
+	s.Require().True(service.VerifyAPIKeyHash("sk-create-test", got.KeySalt, got.KeyHash))
+
+	row, err := s.client.APIKey.Get(s.ctx, key.ID)
+	s.Require().NoError(err)
+	s.Require().Nil(row.Key, "plaintext key must not be stored")
 }
 
 func (s *APIKeyRepoSuite) TestGetByID_NotFound() {
@@ -102,7 +108,7 @@ func (s *APIKeyRepoSuite) TestUpdate() {
 
 	got, err := s.repo.GetByID(s.ctx, key.ID)
 	s.Require().NoError(err, "GetByID after update")
-	s.Require().Equal("sk-update", got.Key, "Update should not change key")
+	s.Require().Equal(key.KeyHash, got.KeyHash, "Update should not change key")
 	s.Require().Equal(user.ID, got.UserID, "Update should not change user_id")
 	s.Require().Equal("Renamed", got.Name)
 	s.Require().Equal(service.StatusDisabled, got.Status)
@@ -227,6 +233,61 @@ func (s *APIKeyRepoSuite) TestExistsByKey() {
 	s.Require().False(notExists)
 }
 
+func (s *APIKeyRepoSuite) TestGetByKey_SharedPrefix() {
+	user := s.mustCreateUser("sharedprefix@test.com")
+	a := s.mustCreateApiKey(user.ID, "sk-shared-prefix-aaaa", "A", nil)
+	b := s.mustCreateApiKey(user.ID, "sk-shared-prefix-bbbb", "B", nil)
+	s.Require().Equal(a.KeyPrefix, b.KeyPrefix)
+
+	got, err := s.repo.GetByKey(s.ctx, "sk-shared-prefix-bbbb")
+	s.Require().NoError(err)
+	s.Require().Equal(b.ID, got.ID)
+
+	_, err = s.repo.GetByKey(s.ctx, "sk-shared-prefix-cccc")
+	s.Require().ErrorIs(err, service.ErrAPIKeyNotFound)
+
+	candidates, err := s.repo.ListByKeyPrefixForAuth(s.ctx, a.KeyPrefix)
+	s.Require().NoError(err)
+	s.Require().Len(candidates, 2)
+}
+
+// --- Legacy plaintext keys ---
+
+func (s *APIKeyRepoSuite) TestListByKeyPrefixForAuth_HashesLegacyKey() {
+	user := s.mustCreateUser("legacy@test.com")
+	const plain = "sk-legacy-plaintext-key"
+	// 模拟 migration 060 之前写入的记录：只有明文与回填的前缀
+	legacy, err := s.client.APIKey.Create().
+		SetUserID(user.ID).
+		SetKey(plain).
+		SetKeyPrefix(service.APIKeyPrefix(plain)).
+		SetName("legacy").
+		SetStatus(service.StatusActive).
+		Save(s.ctx)
+	s.Require().NoError(err)
+
+	exists, err := s.repo.ExistsByKey(s.ctx, plain)
+	s.Require().NoError(err)
+	s.Require().True(exists, "legacy plaintext key should still be found before migration")
+
+	candidates, err := s.repo.ListByKeyPrefixForAuth(s.ctx, service.APIKeyPrefix(plain))
+	s.Require().NoError(err)
+	s.Require().Len(candidates, 1)
+	s.Require().Equal(legacy.ID, candidates[0].ID)
+	s.Require().NotNil(candidates[0].User)
+	s.Require().True(service.VerifyAPIKeyHash(plain, candidates[0].KeySalt, candidates[0].KeyHash))
+
+	row, err := s.client.APIKey.Get(s.ctx, legacy.ID)
+	s.Require().NoError(err)
+	s.Require().Nil(row.Key, "plaintext should be cleared on first use")
+	s.Require().NotNil(row.KeyHash)
+	s.Require().Equal(candidates[0].KeyHash, *row.KeyHash)
+
+	got, err := s.repo.GetByKey(s.ctx, plain)
+	s.Require().NoError(err)
+	s.Require().Equal(legacy.ID, got.ID)
+}
+
 // --- SearchAPIKeys ---
 
 func (s *APIKeyRepoSuite) TestSearchAPIKeys() {
@@ -305,7 +366,7 @@ func (s *APIKeyRepoSuite) TestCRUD_Search_ClearGroupID() {
 
 	got2, err := s.repo.GetByID(s.ctx, key.ID)
 	s.Require().NoError(err, "GetByID")
-	s.Require().Equal("sk-test-1", got2.Key, "Update should not change key")
+	s.Require().Equal(key.KeyHash, got2.KeyHash, "Update should not change key")
 	s.Require().Equal(user.ID, got2.UserID, "Update should not change user_id")
 	s.Require().Equal("Renamed", got2.Name)
 	s.Require().Equal(service.StatusDisabled, got2.Status)
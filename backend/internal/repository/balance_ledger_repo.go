@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/ent/balanceledgerentry"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type balanceLedgerRepository struct {
+	client *dbent.Client
+}
+
+// NewBalanceLedgerRepository 创建余额流水仓库（只读查询与对账；写入见 userRepository.ApplyBalanceChange）
+func NewBalanceLedgerRepository(client *dbent.Client) service.BalanceLedgerRepository {
+	return &balanceLedgerRepository{client: client}
+}
+
+func (r *balanceLedgerRepository) ListByUser(ctx context.Context, userID int64, params pagination.PaginationParams, filters service.BalanceLedgerFilters) ([]service.BalanceLedgerEntry, *pagination.PaginationResult, error) {
+	query := r.client.BalanceLedgerEntry.Query().
+		Where(balanceledgerentry.UserIDEQ(userID))
+	if filters.Type != "" {
+		query = query.Where(balanceledgerentry.EntryTypeEQ(filters.Type))
+	}
+	if filters.StartTime != nil {
+		query = query.Where(balanceledgerentry.CreatedAtGTE(*filters.StartTime))
+	}
+	if filters.EndTime != nil {
+		query = query.Where(balanceledgerentry.CreatedAtLT(*filters.EndTime))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// 按 id 倒序：同一时刻的多条流水也能保持写入顺序
+	rows, err := query.
+		Order(dbent.Desc(balanceledgerentry.FieldID)).
+		Offset(params.Offset()).
+		Limit(params.Limit()).
+		All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make([]service.BalanceLedgerEntry, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, balanceLedgerEntityToService(row))
+	}
+	return out, paginationResultFromTotal(int64(total), params), nil
+}
+
+func (r *balanceLedgerRepository) FindDrift(ctx context.Context, tolerance float64, limit int) (out []service.BalanceLedgerDrift, err error) {
+	query := `
+		SELECT u.id, u.email, u.balance, COALESCE(l.total, 0), COALESCE(l.entry_count, 0), l.last_entry_at
+		FROM users u
+		LEFT JOIN (
+			SELECT user_id, SUM(amount) AS total, COUNT(*) AS entry_count, MAX(created_at) AS last_entry_at
+			FROM balance_ledger_entries
+			GROUP BY user_id
+		) l ON l.user_id = u.id
+		WHERE u.deleted_at IS NULL
+		  AND ABS(u.balance - COALESCE(l.total, 0)) > $1
+		ORDER BY ABS(u.balance - COALESCE(l.total, 0)) DESC, u.id ASC
+		LIMIT $2`
+
+	rows, err := r.client.QueryContext(ctx, query, tolerance, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			err = errors.Join(err, closeErr)
+		}
+	}()
+
+	out = make([]service.BalanceLedgerDrift, 0)
+	for rows.Next() {
+		var d service.BalanceLedgerDrift
+		var lastEntryAt sql.NullTime
+		if err = rows.Scan(&d.UserID, &d.Email, &d.Balance, &d.LedgerBalance, &d.EntryCount, &lastEntryAt); err != nil {
+			return nil, err
+		}
+		d.Drift = d.Balance - d.LedgerBalance
+		if lastEntryAt.Valid {
+			t := lastEntryAt.Time
+			d.LastEntryAt = &t
+		}
+		out = append(out, d)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func balanceLedgerEntityToService(row *dbent.BalanceLedgerEntry) service.BalanceLedgerEntry {
+	return service.BalanceLedgerEntry{
+		ID:            row.ID,
+		UserID:        row.UserID,
+		Type:          row.EntryType,
+		Amount:        row.Amount,
+		BalanceBefore: row.BalanceBefore,
+		BalanceAfter:  row.BalanceAfter,
+		ReferenceID:   row.ReferenceID,
+		APIKeyID:      row.APIKeyID,
+		Note:          row.Note,
+		CreatedAt:     row.CreatedAt,
+	}
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+// credentialCiphertextPrefix 标记已加密的凭证字段值。
+// 完整格式：enc:v1:<key_id>:<base64(nonce|wrapped_dek)>:<base64(nonce|ciphertext)>
+const credentialCiphertextPrefix = "enc:v1:"
+
+var errCredentialKeyNotConfigured = errors.New("credential encryption key not configured")
+
+// CredentialCipher 对账号凭证中的敏感字段做信封加密。
+//
+// 每个字段值使用独立的随机数据密钥（DEK）以 AES-256-GCM 加密，DEK 再由主密钥（KEK）包裹，
+// 密文自包含主密钥 ID，因此：
+//   - 同一份密文可直接合并进多个账号（BulkUpdate 的 JSONB 合并无需逐行加密）
+//   - 轮换主密钥时只需重新包裹 DEK，无需解密业务数据以外的任何内容
+//
+// 未配置 ActiveKeyID 时写入保持明文，但仍可解密已有密文（只要对应主密钥仍在 Keys 中）。
+type CredentialCipher struct {
+	activeKeyID string
+	keys        map[string][]byte
+}
+
+// NewCredentialCipher 根据配置创建凭证加密器
+func NewCredentialCipher(cfg *config.Config) (*CredentialCipher, error) {
+	c := &CredentialCipher{keys: make(map[string][]byte)}
+	if cfg == nil {
+		return c, nil
+	}
+	for id, raw := range cfg.Credential.Keys {
+		key, err := hex.DecodeString(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("invalid credential encryption key %q: %w", id, err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("credential encryption key %q must be 32 bytes (64 hex chars), got %d bytes", id, len(key))
+		}
+		c.keys[id] = key
+	}
+	if cfg.Credential.ActiveKeyID != "" {
+		if _, ok := c.keys[cfg.Credential.ActiveKeyID]; !ok {
+			return nil, fmt.Errorf("credential encryption active key %q not configured", cfg.Credential.ActiveKeyID)
+		}
+		c.activeKeyID = cfg.Credential.ActiveKeyID
+	}
+	return c, nil
+}
+
+// Enabled 是否对新写入的凭证加密
+func (c *CredentialCipher) Enabled() bool {
+	return c != nil && c.activeKeyID != ""
+}
+
+// ActiveKeyID 返回当前主密钥 ID
+func (c *CredentialCipher) ActiveKeyID() string {
+	if c == nil {
+		return ""
+	}
+	return c.activeKeyID
+}
+
+// EncryptCredentials 返回敏感字段已加密的凭证副本；已加密的字段保持原样。
+func (c *CredentialCipher) EncryptCredentials(in map[string]any) (map[string]any, error) {
+	if !c.Enabled() || len(in) == 0 {
+		return in, nil
+	}
+	out := make(map[string]any, len(in))
+	for k, v := range in {
+		out[k] = v
+		s, ok := v.(string)
+		if !ok || s == "" || !service.IsSensitiveCredentialKey(k) || isEncryptedCredentialValue(s) {
+			continue
+		}
+		enc, err := c.encryptValue(k, s)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt credential %q: %w", k, err)
+		}
+		out[k] = enc
+	}
+	return out, nil
+}
+
+// DecryptCredentials 返回所有密文字段已解密的凭证副本
+func (c *CredentialCipher) DecryptCredentials(in map[string]any) (map[string]any, error) {
+	if len(in) == 0 {
+		return in, nil
+	}
+	var out map[string]any
+	for k, v := range in {
+		s, ok := v.(string)
+		if !ok || !isEncryptedCredentialValue(s) {
+			continue
+		}
+		if out == nil {
+			out = make(map[string]any, len(in))
+			for k2, v2 := range in {
+				out[k2] = v2
+			}
+		}
+		plain, err := c.decryptValue(k, s)
+		if err != nil {
+			return nil, fmt.Errorf("decrypt credential %q: %w", k, err)
+		}
+		out[k] = plain
+	}
+	if out == nil {
+		return in, nil
+	}
+	return out, nil
+}
+
+// RotateCredentials 将明文敏感字段加密、将旧主密钥包裹的字段改用当前主密钥重新包裹 DEK。
+// 返回新的凭证副本以及是否有变化。
+func (c *CredentialCipher) RotateCredentials(in map[string]any) (map[string]any, bool, error) {
+	if !c.Enabled() || len(in) == 0 {
+		return in, false, nil
+	}
+	out := make(map[string]any, len(in))
+	changed := false
+	for k, v := range in {
+		out[k] = v
+		s, ok := v.(string)
+		if !ok || s == "" {
+			continue
+		}
+		switch {
+		case isEncryptedCredentialValue(s):
+			keyID, _, _, err := parseCredentialCiphertext(s)
+			if err != nil {
+				return nil, false, fmt.Errorf("credential %q: %w", k, err)
+			}
+			if keyID == c.activeKeyID {
+				continue
+			}
+			rewrapped, err := c.rewrapValue(k, s)
+			if err != nil {
+				return nil, false, fmt.Errorf("rewrap credential %q: %w", k, err)
+			}
+			out[k] = rewrapped
+			changed = true
+		case service.IsSensitiveCredentialKey(k):
+			enc, err := c.encryptValue(k, s)
+			if err != nil {
+				return nil, false, fmt.Errorf("encrypt credential %q: %w", k, err)
+			}
+			out[k] = enc
+			changed = true
+		}
+	}
+	return out, changed, nil
+}
+
+func (c *CredentialCipher) encryptValue(field, plaintext string) (string, error) {
+	dek := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dek); err != nil {
+		return "", fmt.Errorf("generate data key: %w", err)
+	}
+	// 以字段名作为附加数据，防止密文在字段之间被挪用
+	payload, err := gcmSeal(dek, []byte(plaintext), []byte(field))
+	if err != nil {
+		return "", err
+	}
+	return c.wrap(dek, payload)
+}
+
+func (c *CredentialCipher) decryptValue(field, value string) (string, error) {
+	dek, payload, err := c.unwrap(value)
+	if err != nil {
+		return "", err
+	}
+	plain, err := gcmOpen(dek, payload, []byte(field))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
+
+func (c *CredentialCipher) rewrapValue(field, value string) (string, error) {
+	dek, payload, err := c.unwrap(value)
+	if err != nil {
+		return "", err
+	}
+	// 确认 DEK 与载荷匹配后再重新包裹，避免把损坏的密文固化下来
+	if _, err := gcmOpen(dek, payload, []byte(field)); err != nil {
+		return "", err
+	}
+	return c.wrap(dek, payload)
+}
+
+func (c *CredentialCipher) wrap(dek, payload []byte) (string, error) {
+	kek := c.keys[c.activeKeyID]
+	wrapped, err := gcmSeal(kek, dek, []byte(c.activeKeyID))
+	if err != nil {
+		return "", err
+	}
+	return credentialCiphertextPrefix + c.activeKeyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrapped) + ":" +
+		base64.RawStdEncoding.EncodeToString(payload), nil
+}
+
+func (c *CredentialCipher) unwrap(value string) (dek, payload []byte, err error) {
+	keyID, wrapped, payload, err := parseCredentialCiphertext(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	var kek []byte
+	if c != nil {
+		kek = c.keys[keyID]
+	}
+	if kek == nil {
+		return nil, nil, fmt.Errorf("%w: %s", errCredentialKeyNotConfigured, keyID)
+	}
+	dek, err = gcmOpen(kek, wrapped, []byte(keyID))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+	return dek, payload, nil
+}
+
+func parseCredentialCiphertext(value string) (keyID string, wrapped, payload []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(value, credentialCiphertextPrefix), ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", nil, nil, errors.New("malformed credential ciphertext")
+	}
+	wrapped, err = base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("decode wrapped data key: %w", err)
+	}
+	payload, err = base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("decode ciphertext: %w", err)
+	}
+	return parts[0], wrapped, payload, nil
+}
+
+func isEncryptedCredentialValue(s string) bool {
+	return strings.HasPrefix(s, credentialCiphertextPrefix)
+}
+
+// gcmSeal 输出 nonce|ciphertext|tag
+func gcmSeal(key, plaintext, additionalData []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create gcm: %w", err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func gcmOpen(key, data, additionalData []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create gcm: %w", err)
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt: %w", err)
+	}
+	return plain, nil
+}
@@ -0,0 +1,117 @@
+//go:build unit
+
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testCredentialKeyA = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	testCredentialKeyB = "1f1e1d1c1b1a191817161514131211100f0e0d0c0b0a09080706050403020100"
+)
+
+func newTestCredentialCipher(t *testing.T, active string, keys map[string]string) *CredentialCipher {
+	t.Helper()
+	c, err := NewCredentialCipher(&config.Config{Credential: config.CredentialEncryptionConfig{ActiveKeyID: active, Keys: keys}})
+	require.NoError(t, err)
+	return c
+}
+
+func TestCredentialCipher_RoundTrip(t *testing.T) {
+	c := newTestCredentialCipher(t, "k1", map[string]string{"k1": testCredentialKeyA})
+	in := map[string]any{
+		"access_token":  "sk-ant-oat-secret",
+		"refresh_token": "refresh-secret",
+		"base_url":      "https://api.example.com",
+		"model_mapping": map[string]any{"a": "b"},
+		"expires_at":    float64(1700000000),
+	}
+
+	enc, err := c.EncryptCredentials(in)
+	require.NoError(t, err)
+	require.Equal(t, "sk-ant-oat-secret", in["access_token"], "input must not be mutated")
+	require.True(t, strings.HasPrefix(enc["access_token"].(string), "enc:v1:k1:"))
+	require.True(t, strings.HasPrefix(enc["refresh_token"].(string), "enc:v1:k1:"))
+	require.Equal(t, "https://api.example.com", enc["base_url"], "non-sensitive fields stay plaintext")
+	require.Equal(t, in["model_mapping"], enc["model_mapping"])
+
+	again, err := c.EncryptCredentials(enc)
+	require.NoError(t, err)
+	require.Equal(t, enc["access_token"], again["access_token"], "already encrypted values are kept")
+
+	dec, err := c.DecryptCredentials(enc)
+	require.NoError(t, err)
+	require.Equal(t, in, dec)
+}
+
+func TestCredentialCipher_DisabledKeepsPlaintextButDecrypts(t *testing.T) {
+	enabled := newTestCredentialCipher(t, "k1", map[string]string{"k1": testCredentialKeyA})
+	enc, err := enabled.EncryptCredentials(map[string]any{"api_key": "sk-1"})
+	require.NoError(t, err)
+
+	disabled := newTestCredentialCipher(t, "", map[string]string{"k1": testCredentialKeyA})
+	plain, err := disabled.EncryptCredentials(map[string]any{"api_key": "sk-2"})
+	require.NoError(t, err)
+	require.Equal(t, "sk-2", plain["api_key"])
+
+	dec, err := disabled.DecryptCredentials(enc)
+	require.NoError(t, err)
+	require.Equal(t, "sk-1", dec["api_key"])
+
+	var nilCipher *CredentialCipher
+	_, err = nilCipher.DecryptCredentials(enc)
+	require.ErrorIs(t, err, errCredentialKeyNotConfigured)
+}
+
+func TestCredentialCipher_BindsCiphertextToField(t *testing.T) {
+	c := newTestCredentialCipher(t, "k1", map[string]string{"k1": testCredentialKeyA})
+	enc, err := c.EncryptCredentials(map[string]any{"access_token": "a", "refresh_token": "r"})
+	require.NoError(t, err)
+
+	swapped := map[string]any{"access_token": enc["refresh_token"]}
+	_, err = c.DecryptCredentials(swapped)
+	require.Error(t, err)
+}
+
+func TestCredentialCipher_RotateRewrapsOldKey(t *testing.T) {
+	old := newTestCredentialCipher(t, "k1", map[string]string{"k1": testCredentialKeyA})
+	enc, err := old.EncryptCredentials(map[string]any{"access_token": "tok"})
+	require.NoError(t, err)
+	oldPayload := enc["access_token"].(string)[strings.LastIndex(enc["access_token"].(string), ":"):]
+
+	rotating := newTestCredentialCipher(t, "k2", map[string]string{"k1": testCredentialKeyA, "k2": testCredentialKeyB})
+	rotated, changed, err := rotating.RotateCredentials(map[string]any{
+		"access_token": enc["access_token"],
+		"api_key":      "plain-key",
+		"base_url":     "https://x",
+	})
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.True(t, strings.HasPrefix(rotated["access_token"].(string), "enc:v1:k2:"))
+	require.True(t, strings.HasSuffix(rotated["access_token"].(string), oldPayload), "rotation only rewraps the data key")
+	require.True(t, strings.HasPrefix(rotated["api_key"].(string), "enc:v1:k2:"))
+	require.Equal(t, "https://x", rotated["base_url"])
+
+	_, changed, err = rotating.RotateCredentials(rotated)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	onlyNew := newTestCredentialCipher(t, "k2", map[string]string{"k2": testCredentialKeyB})
+	dec, err := onlyNew.DecryptCredentials(rotated)
+	require.NoError(t, err)
+	require.Equal(t, "tok", dec["access_token"])
+	require.Equal(t, "plain-key", dec["api_key"])
+}
+
+func TestNewCredentialCipher_InvalidConfig(t *testing.T) {
+	_, err := NewCredentialCipher(&config.Config{Credential: config.CredentialEncryptionConfig{Keys: map[string]string{"k1": "abcd"}}})
+	require.Error(t, err)
+
+	_, err = NewCredentialCipher(&config.Config{Credential: config.CredentialEncryptionConfig{ActiveKeyID: "missing", Keys: map[string]string{"k1": testCredentialKeyA}}})
+	require.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	digestSessionKeyPrefix  = "digest_session:"
+	digestSessionDefaultTTL = 5 * time.Minute
+)
+
+type digestSessionCache struct {
+	rdb *redis.Client
+	ttl time.Duration
+}
+
+// NewDigestSessionCache 创建 Redis 摘要会话存储，多副本间共享 Gemini/Anthropic 会话粘性
+func NewDigestSessionCache(rdb *redis.Client, cfg *config.Config) service.DigestSessionCache {
+	ttl := digestSessionDefaultTTL
+	if cfg != nil && cfg.Gateway.DigestSession.TTLSeconds > 0 {
+		ttl = time.Duration(cfg.Gateway.DigestSession.TTLSeconds) * time.Second
+	}
+	return &digestSessionCache{rdb: rdb, ttl: ttl}
+}
+
+// buildDigestSessionKey 构建摘要会话 key
+// 格式: digest_session:{groupID:prefixHash}|digestChain
+// namespace 使用 hash tag，保证同一会话的所有候选 key 落在同一 slot，可一次 MGET
+func buildDigestSessionKey(groupID int64, prefixHash, digestChain string) string {
+	return digestSessionKeyPrefix + "{" + strconv.FormatInt(groupID, 10) + ":" + prefixHash + "}|" + digestChain
+}
+
+// encodeDigestSessionValue 格式: accountID:uuid
+func encodeDigestSessionValue(uuid string, accountID int64) string {
+	return strconv.FormatInt(accountID, 10) + ":" + uuid
+}
+
+func decodeDigestSessionValue(raw string) (string, int64, bool) {
+	idx := strings.IndexByte(raw, ':')
+	if idx <= 0 {
+		return "", 0, false
+	}
+	accountID, err := strconv.ParseInt(raw[:idx], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return raw[idx+1:], accountID, true
+}
+
+func (c *digestSessionCache) Save(ctx context.Context, groupID int64, prefixHash, digestChain, uuid string, accountID int64, oldDigestChain string) error {
+	if digestChain == "" {
+		return nil
+	}
+	pipe := c.rdb.Pipeline()
+	pipe.Set(ctx, buildDigestSessionKey(groupID, prefixHash, digestChain), encodeDigestSessionValue(uuid, accountID), c.ttl)
+	if oldDigestChain != "" && oldDigestChain != digestChain {
+		pipe.Del(ctx, buildDigestSessionKey(groupID, prefixHash, oldDigestChain))
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *digestSessionCache) Find(ctx context.Context, groupID int64, prefixHash, digestChain string) (string, int64, string, bool) {
+	candidates := service.DigestChainCandidates(digestChain)
+	if len(candidates) == 0 {
+		return "", 0, "", false
+	}
+	keys := make([]string, len(candidates))
+	for i, chain := range candidates {
+		keys[i] = buildDigestSessionKey(groupID, prefixHash, chain)
+	}
+
+	values, err := c.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.Printf("[DigestSession] redis mget failed: group_id=%d err=%v", groupID, err)
+		}
+		return "", 0, "", false
+	}
+	// candidates 按最长到最短排列，第一个命中即最长匹配
+	for i, v := range values {
+		raw, ok := v.(string)
+		if !ok {
+			continue
+		}
+		if uuid, accountID, ok := decodeDigestSessionValue(raw); ok {
+			return uuid, accountID, candidates[i], true
+		}
+	}
+	return "", 0, "", false
+}
@@ -0,0 +1,66 @@
+//go:build integration
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type DigestSessionCacheSuite struct {
+	IntegrationRedisSuite
+	cache *digestSessionCache
+}
+
+func (s *DigestSessionCacheSuite) SetupTest() {
+	s.IntegrationRedisSuite.SetupTest()
+	cfg := &config.Config{}
+	cfg.Gateway.DigestSession.TTLSeconds = 60
+	s.cache = NewDigestSessionCache(s.rdb, cfg).(*digestSessionCache)
+}
+
+func (s *DigestSessionCacheSuite) TestSaveAndFindLongestPrefix() {
+	require.NoError(s.T(), s.cache.Save(s.ctx, 1, "prefix", "u:a", "uuid-1", 1, ""))
+	require.NoError(s.T(), s.cache.Save(s.ctx, 1, "prefix", "u:a-m:b", "uuid-2", 2, ""))
+
+	uuid, accountID, matched, found := s.cache.Find(s.ctx, 1, "prefix", "u:a-m:b-u:c")
+	require.True(s.T(), found)
+	require.Equal(s.T(), "uuid-2", uuid)
+	require.Equal(s.T(), int64(2), accountID)
+	require.Equal(s.T(), "u:a-m:b", matched)
+}
+
+func (s *DigestSessionCacheSuite) TestSaveDeletesOldChain() {
+	require.NoError(s.T(), s.cache.Save(s.ctx, 1, "prefix", "u:a-m:b", "uuid-1", 100, ""))
+	require.NoError(s.T(), s.cache.Save(s.ctx, 1, "prefix", "u:a-m:b-u:c-m:d", "uuid-1", 100, "u:a-m:b"))
+
+	_, _, _, found := s.cache.Find(s.ctx, 1, "prefix", "u:a-m:b")
+	require.False(s.T(), found, "old chain should be deleted")
+
+	_, _, _, found = s.cache.Find(s.ctx, 1, "prefix", "u:a-m:b-u:c-m:d")
+	require.True(s.T(), found)
+}
+
+func (s *DigestSessionCacheSuite) TestIsolationByGroupAndPrefix() {
+	require.NoError(s.T(), s.cache.Save(s.ctx, 1, "prefix", "u:a-m:b", "uuid-1", 100, ""))
+
+	_, _, _, found := s.cache.Find(s.ctx, 2, "prefix", "u:a-m:b")
+	require.False(s.T(), found)
+	_, _, _, found = s.cache.Find(s.ctx, 1, "other", "u:a-m:b")
+	require.False(s.T(), found)
+}
+
+func (s *DigestSessionCacheSuite) TestTTL() {
+	require.NoError(s.T(), s.cache.Save(s.ctx, 1, "prefix", "u:a", "uuid-1", 1, ""))
+	ttl, err := s.rdb.TTL(s.ctx, buildDigestSessionKey(1, "prefix", "u:a")).Result()
+	require.NoError(s.T(), err)
+	s.AssertTTLWithin(ttl, time.Second, 60*time.Second)
+}
+
+func TestDigestSessionCacheSuite(t *testing.T) {
+	suite.Run(t, new(DigestSessionCacheSuite))
+}
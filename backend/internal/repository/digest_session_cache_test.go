@@ -0,0 +1,25 @@
+//go:build unit
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDigestSessionKey(t *testing.T) {
+	require.Equal(t, "digest_session:{12:abc}|u:a-m:b", buildDigestSessionKey(12, "abc", "u:a-m:b"))
+}
+
+func TestDigestSessionValueRoundTrip(t *testing.T) {
+	uuid, accountID, ok := decodeDigestSessionValue(encodeDigestSessionValue("uuid:with:colons", 42))
+	require.True(t, ok)
+	require.Equal(t, "uuid:with:colons", uuid)
+	require.Equal(t, int64(42), accountID)
+
+	_, _, ok = decodeDigestSessionValue("garbage")
+	require.False(t, ok)
+	_, _, ok = decodeDigestSessionValue("x:uuid")
+	require.False(t, ok)
+}
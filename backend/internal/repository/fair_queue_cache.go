@@ -0,0 +1,401 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+// 账号槽位公平排队缓存
+//
+// 每个账号一组键（与 concurrency:account:{id} 槽位键配合使用；按账号而非分组划分的原因见 service.FairQueueService）：
+//   - fairqueue:account:{id}          有序集合，成员为 ticket，分数为 SFQ 起始标签（队列顺序）
+//   - fairqueue:account:{id}:hb       有序集合，成员为 ticket，分数为最近一次心跳（Redis 服务器时间，秒）
+//   - fairqueue:account:{id}:meta     哈希，ticket -> "flow|weight|enqueuedMs|start|finish|groupID"
+//   - fairqueue:account:{id}:flows    哈希，flow -> 该流最后一个 ticket 的结束标签
+//   - fairqueue:account:{id}:vtime    账号虚拟时间（最近获取槽位的 ticket 起始标签）
+//   - fairqueue:account:{id}:stats    哈希，最近窗口内通过队列获取槽位的次数与等待耗时
+//
+// 等待者每次轮询都会刷新心跳，超过 fairQueueStaleSeconds 未心跳的 ticket（进程崩溃等）
+// 会在排到队列前部时被清理，不会永久阻塞队列。
+const (
+	fairQueueKeyPrefix = "fairqueue:account:"
+
+	fairQueueStaleSeconds       = 30
+	fairQueueKeyTTLSeconds      = 600
+	fairQueueStatsWindowSeconds = 300
+)
+
+var (
+	// fairQueueEnqueueScript 登记 ticket 并计算 SFQ 标签
+	// KEYS: line, hb, meta, flows, vtime
+	// ARGV: ticket, flow, weight, keyTTL, groupID
+	fairQueueEnqueueScript = redis.NewScript(`
+		local t = redis.call('TIME')
+		local now = tonumber(t[1])
+		local nowMs = now * 1000 + math.floor(tonumber(t[2]) / 1000)
+
+		local vt = tonumber(redis.call('GET', KEYS[5]) or '0')
+		local last = tonumber(redis.call('HGET', KEYS[4], ARGV[2]) or '0')
+		local start = vt
+		if last > start then
+			start = last
+		end
+		local finish = start + 1000 / tonumber(ARGV[3])
+		local startStr = tostring(start)
+		local finishStr = tostring(finish)
+
+		redis.call('HSET', KEYS[4], ARGV[2], finishStr)
+		redis.call('ZADD', KEYS[1], startStr, ARGV[1])
+		redis.call('ZADD', KEYS[2], now, ARGV[1])
+		redis.call('HSET', KEYS[3], ARGV[1], ARGV[2] .. '|' .. ARGV[3] .. '|' .. nowMs .. '|' .. startStr .. '|' .. finishStr .. '|' .. ARGV[5])
+		for i = 1, 4 do
+			redis.call('EXPIRE', KEYS[i], ARGV[4])
+		end
+		return {startStr, finishStr}
+	`)
+
+	// fairQueueTryAcquireScript 排名小于空闲槽位数时获取账号槽位并出队
+	// KEYS: line, hb, meta, vtime, slot, stats
+	// ARGV: ticket, maxConcurrency, slotTTL, requestID, staleSeconds, keyTTL, statsWindow
+	// 返回 {acquired(1/0/-1), rank}，-1 表示 ticket 已不在队列中
+	fairQueueTryAcquireScript = redis.NewScript(`
+		local t = redis.call('TIME')
+		local now = tonumber(t[1])
+		local nowMs = now * 1000 + math.floor(tonumber(t[2]) / 1000)
+		local ticket = ARGV[1]
+		local maxConcurrency = tonumber(ARGV[2])
+		local stale = tonumber(ARGV[5])
+
+		local score = redis.call('ZSCORE', KEYS[1], ticket)
+		if not score then
+			return {-1, 0}
+		end
+		redis.call('ZADD', KEYS[2], now, ticket)
+
+		-- 只有前 maxConcurrency 名有机会获取槽位，清理其中失联的 ticket
+		local rank = redis.call('ZRANK', KEYS[1], ticket)
+		if rank > 0 then
+			local limit = rank
+			if limit > maxConcurrency then
+				limit = maxConcurrency
+			end
+			local ahead = redis.call('ZRANGE', KEYS[1], 0, limit - 1)
+			for _, other in ipairs(ahead) do
+				local hb = redis.call('ZSCORE', KEYS[2], other)
+				if (not hb) or tonumber(hb) < now - stale then
+					redis.call('ZREM', KEYS[1], other)
+					redis.call('ZREM', KEYS[2], other)
+					redis.call('HDEL', KEYS[3], other)
+				end
+			end
+			rank = redis.call('ZRANK', KEYS[1], ticket)
+		end
+
+		local slotTTL = tonumber(ARGV[3])
+		redis.call('ZREMRANGEBYSCORE', KEYS[5], '-inf', now - slotTTL)
+		local count = redis.call('ZCARD', KEYS[5])
+		if rank >= maxConcurrency - count then
+			return {0, rank}
+		end
+
+		redis.call('ZADD', KEYS[5], now, ARGV[4])
+		redis.call('EXPIRE', KEYS[5], slotTTL)
+
+		local meta = redis.call('HGET', KEYS[3], ticket)
+		redis.call('ZREM', KEYS[1], ticket)
+		redis.call('ZREM', KEYS[2], ticket)
+		redis.call('HDEL', KEYS[3], ticket)
+
+		local vt = tonumber(redis.call('GET', KEYS[4]) or '0')
+		if tonumber(score) > vt then
+			redis.call('SET', KEYS[4], score, 'EX', ARGV[6])
+		else
+			redis.call('EXPIRE', KEYS[4], ARGV[6])
+		end
+
+		if meta then
+			local enqueued = tonumber(string.match(meta, '^[^|]*|[^|]*|([^|]*)'))
+			if enqueued then
+				local waited = nowMs - enqueued
+				if waited < 0 then
+					waited = 0
+				end
+				local fresh = redis.call('EXISTS', KEYS[6]) == 0
+				redis.call('HINCRBY', KEYS[6], 'granted', 1)
+				redis.call('HINCRBY', KEYS[6], 'total_wait_ms', waited)
+				local maxWait = tonumber(redis.call('HGET', KEYS[6], 'max_wait_ms') or '0')
+				if waited > maxWait then
+					redis.call('HSET', KEYS[6], 'max_wait_ms', waited)
+				end
+				if fresh then
+					redis.call('EXPIRE', KEYS[6], ARGV[7])
+				end
+			end
+		end
+		return {1, rank}
+	`)
+
+	// fairQueueCancelScript 撤销 ticket；若该 ticket 是流的最后一个，则退还流的结束标签
+	// KEYS: line, hb, meta, flows
+	// ARGV: ticket, flow, start, finish
+	fairQueueCancelScript = redis.NewScript(`
+		local removed = redis.call('ZREM', KEYS[1], ARGV[1])
+		redis.call('ZREM', KEYS[2], ARGV[1])
+		redis.call('HDEL', KEYS[3], ARGV[1])
+		if removed == 1 then
+			local current = redis.call('HGET', KEYS[4], ARGV[2])
+			if current == ARGV[4] then
+				redis.call('HSET', KEYS[4], ARGV[2], ARGV[3])
+			end
+		end
+		return removed
+	`)
+
+	// fairQueueYieldingAcquireScript 非排队请求获取槽位：空闲槽位需多于仍在心跳的排队请求
+	// KEYS: slot, hb
+	// ARGV: maxConcurrency, slotTTL, requestID, staleSeconds
+	fairQueueYieldingAcquireScript = redis.NewScript(`
+		local t = redis.call('TIME')
+		local now = tonumber(t[1])
+		local slotTTL = tonumber(ARGV[2])
+
+		redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - slotTTL)
+		if redis.call('ZSCORE', KEYS[1], ARGV[3]) ~= false then
+			redis.call('ZADD', KEYS[1], now, ARGV[3])
+			redis.call('EXPIRE', KEYS[1], slotTTL)
+			return 1
+		end
+
+		local count = redis.call('ZCARD', KEYS[1])
+		local queued = redis.call('ZCOUNT', KEYS[2], now - tonumber(ARGV[4]), '+inf')
+		if count + queued < tonumber(ARGV[1]) then
+			redis.call('ZADD', KEYS[1], now, ARGV[3])
+			redis.call('EXPIRE', KEYS[1], slotTTL)
+			return 1
+		end
+		return 0
+	`)
+
+	// fairQueueStatsBatchScript 批量统计账号队列
+	// ARGV: staleSeconds, accountID...
+	// 返回扁平数组：accountID, waiting, maxWaitMs, granted, totalWaitMs, maxGrantWaitMs, flows
+	fairQueueStatsBatchScript = redis.NewScript(`
+		local t = redis.call('TIME')
+		local now = tonumber(t[1])
+		local nowMs = now * 1000 + math.floor(tonumber(t[2]) / 1000)
+		local stale = tonumber(ARGV[1])
+		local result = {}
+		for i = 2, #ARGV do
+			local prefix = 'fairqueue:account:' .. ARGV[i]
+			local waiting = redis.call('ZCOUNT', prefix .. ':hb', now - stale, '+inf')
+			local maxWait = 0
+			local flows = {}
+			local flowCount = 0
+			if waiting > 0 then
+				local metas = redis.call('HVALS', prefix .. ':meta')
+				for _, meta in ipairs(metas) do
+					local flow, enqueued = string.match(meta, '^([^|]*)|[^|]*|([^|]*)')
+					enqueued = tonumber(enqueued)
+					if enqueued and nowMs - enqueued > maxWait then
+						maxWait = nowMs - enqueued
+					end
+					if flow and not flows[flow] then
+						flows[flow] = true
+						flowCount = flowCount + 1
+					end
+				end
+			end
+			local stats = redis.call('HMGET', prefix .. ':stats', 'granted', 'total_wait_ms', 'max_wait_ms')
+			table.insert(result, ARGV[i])
+			table.insert(result, waiting)
+			table.insert(result, maxWait)
+			table.insert(result, tonumber(stats[1] or '0'))
+			table.insert(result, tonumber(stats[2] or '0'))
+			table.insert(result, tonumber(stats[3] or '0'))
+			table.insert(result, flowCount)
+		end
+		return result
+	`)
+)
+
+type fairQueueCache struct {
+	rdb            *redis.Client
+	slotTTLSeconds int
+}
+
+// ProvideFairQueueCache 创建公平排队缓存，槽位 TTL 与并发控制缓存保持一致
+func ProvideFairQueueCache(rdb *redis.Client, cfg *config.Config) service.FairQueueCache {
+	return NewFairQueueCache(rdb, cfg.Gateway.ConcurrencySlotTTLMinutes)
+}
+
+// NewFairQueueCache 创建公平排队缓存
+func NewFairQueueCache(rdb *redis.Client, slotTTLMinutes int) service.FairQueueCache {
+	if slotTTLMinutes <= 0 {
+		slotTTLMinutes = defaultSlotTTLMinutes
+	}
+	return &fairQueueCache{rdb: rdb, slotTTLSeconds: slotTTLMinutes * 60}
+}
+
+func fairQueueKeys(accountID int64) (line, hb, meta, flows, vtime, stats string) {
+	line = fmt.Sprintf("%s%d", fairQueueKeyPrefix, accountID)
+	return line, line + ":hb", line + ":meta", line + ":flows", line + ":vtime", line + ":stats"
+}
+
+func (c *fairQueueCache) Enqueue(ctx context.Context, ticket *service.FairQueueTicket) error {
+	line, hb, meta, flows, vtime, _ := fairQueueKeys(ticket.AccountID)
+	weight := ticket.Weight
+	if weight < 1 {
+		weight = 1
+	}
+	result, err := fairQueueEnqueueScript.Run(ctx, c.rdb, []string{line, hb, meta, flows, vtime},
+		ticket.ID, ticket.Flow, weight, fairQueueKeyTTLSeconds, ticket.GroupID).StringSlice()
+	if err != nil {
+		return err
+	}
+	if len(result) != 2 {
+		return fmt.Errorf("unexpected fair queue enqueue result: %v", result)
+	}
+	ticket.StartTag, ticket.FinishTag = result[0], result[1]
+	return nil
+}
+
+func (c *fairQueueCache) TryAcquire(ctx context.Context, ticket *service.FairQueueTicket, maxConcurrency int, requestID string) (*service.FairQueueAcquireResult, error) {
+	line, hb, meta, _, vtime, stats := fairQueueKeys(ticket.AccountID)
+	result, err := fairQueueTryAcquireScript.Run(ctx, c.rdb,
+		[]string{line, hb, meta, vtime, accountSlotKey(ticket.AccountID), stats},
+		ticket.ID, maxConcurrency, c.slotTTLSeconds, requestID, fairQueueStaleSeconds, fairQueueKeyTTLSeconds, fairQueueStatsWindowSeconds,
+	).Int64Slice()
+	if err != nil {
+		return nil, err
+	}
+	if len(result) != 2 {
+		return nil, fmt.Errorf("unexpected fair queue acquire result: %v", result)
+	}
+	return &service.FairQueueAcquireResult{
+		Acquired: result[0] == 1,
+		Lost:     result[0] < 0,
+		Position: int(result[1]),
+	}, nil
+}
+
+func (c *fairQueueCache) Cancel(ctx context.Context, ticket *service.FairQueueTicket) error {
+	line, hb, meta, flows, _, _ := fairQueueKeys(ticket.AccountID)
+	return fairQueueCancelScript.Run(ctx, c.rdb, []string{line, hb, meta, flows},
+		ticket.ID, ticket.Flow, ticket.StartTag, ticket.FinishTag).Err()
+}
+
+func (c *fairQueueCache) AcquireAccountSlotYielding(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
+	_, hb, _, _, _, _ := fairQueueKeys(accountID)
+	result, err := fairQueueYieldingAcquireScript.Run(ctx, c.rdb, []string{accountSlotKey(accountID), hb},
+		maxConcurrency, c.slotTTLSeconds, requestID, fairQueueStaleSeconds).Int()
+	if err != nil {
+		return false, err
+	}
+	return result == 1, nil
+}
+
+func (c *fairQueueCache) GetAccountStatsBatch(ctx context.Context, accountIDs []int64) (map[int64]*service.FairQueueAccountStats, error) {
+	out := make(map[int64]*service.FairQueueAccountStats, len(accountIDs))
+	if len(accountIDs) == 0 {
+		return out, nil
+	}
+	args := make([]any, 0, len(accountIDs)+1)
+	args = append(args, fairQueueStaleSeconds)
+	for _, id := range accountIDs {
+		args = append(args, id)
+	}
+	result, err := fairQueueStatsBatchScript.Run(ctx, c.rdb, []string{}, args...).Slice()
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i+6 < len(result); i += 7 {
+		accountID, _ := strconv.ParseInt(fmt.Sprintf("%v", result[i]), 10, 64)
+		stats := &service.FairQueueAccountStats{
+			AccountID:     accountID,
+			Waiting:       int(fairQueueInt64(result[i+1])),
+			MaxWaitMs:     fairQueueInt64(result[i+2]),
+			Granted:       fairQueueInt64(result[i+3]),
+			MaxGrantWait:  fairQueueInt64(result[i+5]),
+			FlowsQueueing: int(fairQueueInt64(result[i+6])),
+		}
+		if stats.Granted > 0 {
+			stats.AvgGrantWait = fairQueueInt64(result[i+4]) / stats.Granted
+		}
+		out[accountID] = stats
+	}
+	return out, nil
+}
+
+func (c *fairQueueCache) ListAccountEntries(ctx context.Context, accountID int64) ([]*service.FairQueueEntry, error) {
+	line, hb, meta, _, _, _ := fairQueueKeys(accountID)
+	tickets, err := c.rdb.ZRange(ctx, line, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*service.FairQueueEntry, 0, len(tickets))
+	if len(tickets) == 0 {
+		return entries, nil
+	}
+
+	pipe := c.rdb.Pipeline()
+	metaCmd := pipe.HMGet(ctx, meta, tickets...)
+	hbCmd := pipe.ZMScore(ctx, hb, tickets...)
+	timeCmd := pipe.Time(ctx)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	now := timeCmd.Val()
+	heartbeats := hbCmd.Val()
+
+	position := 0
+	for i, raw := range metaCmd.Val() {
+		value, ok := raw.(string)
+		if !ok {
+			continue
+		}
+		// 跳过失联 ticket（排到前部时会被清理）
+		if i < len(heartbeats) && heartbeats[i] < float64(now.Unix()-fairQueueStaleSeconds) {
+			continue
+		}
+		parts := strings.Split(value, "|")
+		if len(parts) < 6 {
+			continue
+		}
+		weight, _ := strconv.Atoi(parts[1])
+		enqueuedMs, _ := strconv.ParseInt(parts[2], 10, 64)
+		groupID, _ := strconv.ParseInt(parts[5], 10, 64)
+		waitMs := now.UnixMilli() - enqueuedMs
+		if waitMs < 0 {
+			waitMs = 0
+		}
+		entries = append(entries, &service.FairQueueEntry{
+			AccountID: accountID,
+			GroupID:   groupID,
+			Flow:      parts[0],
+			Weight:    weight,
+			Position:  position,
+			WaitMs:    waitMs,
+		})
+		position++
+	}
+	return entries, nil
+}
+
+func fairQueueInt64(v any) int64 {
+	switch n := v.(type) {
+	case int64:
+		return n
+	case string:
+		parsed, _ := strconv.ParseInt(n, 10, 64)
+		return parsed
+	default:
+		parsed, _ := strconv.ParseInt(fmt.Sprintf("%v", v), 10, 64)
+		return parsed
+	}
+}
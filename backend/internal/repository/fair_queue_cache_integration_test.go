@@ -0,0 +1,131 @@
+//go:build integration
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type FairQueueCacheSuite struct {
+	IntegrationRedisSuite
+	cache       service.FairQueueCache
+	concurrency service.ConcurrencyCache
+}
+
+func (s *FairQueueCacheSuite) SetupTest() {
+	s.IntegrationRedisSuite.SetupTest()
+	s.cache = NewFairQueueCache(s.rdb, testSlotTTLMinutes)
+	s.concurrency = NewConcurrencyCache(s.rdb, testSlotTTLMinutes, int(testSlotTTL.Seconds()))
+}
+
+func (s *FairQueueCacheSuite) enqueue(accountID int64, id, flow string, weight int) *service.FairQueueTicket {
+	ticket := &service.FairQueueTicket{ID: id, AccountID: accountID, Flow: flow, Weight: weight}
+	require.NoError(s.T(), s.cache.Enqueue(s.ctx, ticket), "Enqueue %s", id)
+	return ticket
+}
+
+func (s *FairQueueCacheSuite) TestFlowsInterleave() {
+	accountID := int64(30)
+	// 用户 a 先提交 3 个请求，用户 b 随后提交 1 个：b 应排在 a 的第二个请求之前
+	s.enqueue(accountID, "a1", "user:1", 1)
+	s.enqueue(accountID, "a2", "user:1", 1)
+	s.enqueue(accountID, "a3", "user:1", 1)
+	b1 := s.enqueue(accountID, "b1", "user:2", 1)
+
+	entries, err := s.cache.ListAccountEntries(s.ctx, accountID)
+	require.NoError(s.T(), err, "ListAccountEntries")
+	require.Len(s.T(), entries, 4)
+	require.Equal(s.T(), []string{"user:1", "user:2", "user:1", "user:1"},
+		[]string{entries[0].Flow, entries[1].Flow, entries[2].Flow, entries[3].Flow})
+	require.Equal(s.T(), 1, entries[1].Position)
+
+	// 两个空闲槽位：b1 位于第二位，可以直接获取
+	result, err := s.cache.TryAcquire(s.ctx, b1, 2, "req-b1")
+	require.NoError(s.T(), err)
+	require.True(s.T(), result.Acquired)
+	require.Equal(s.T(), 1, result.Position)
+
+	stats, err := s.cache.GetAccountStatsBatch(s.ctx, []int64{accountID})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 3, stats[accountID].Waiting)
+	require.Equal(s.T(), 1, stats[accountID].FlowsQueueing)
+}
+
+func (s *FairQueueCacheSuite) TestTryAcquireRespectsPosition() {
+	accountID := int64(31)
+	a1 := s.enqueue(accountID, "a1", "user:1", 1)
+	b1 := s.enqueue(accountID, "b1", "user:2", 1)
+
+	// 只有一个空闲槽位时，排在第二位的 ticket 不能插队
+	result, err := s.cache.TryAcquire(s.ctx, b1, 1, "req-b1")
+	require.NoError(s.T(), err)
+	require.False(s.T(), result.Acquired)
+	require.Equal(s.T(), 1, result.Position)
+
+	result, err = s.cache.TryAcquire(s.ctx, a1, 1, "req-a1")
+	require.NoError(s.T(), err)
+	require.True(s.T(), result.Acquired)
+
+	cur, err := s.concurrency.GetAccountConcurrency(s.ctx, accountID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 1, cur, "granted ticket occupies a regular account slot")
+
+	// 槽位已满
+	result, err = s.cache.TryAcquire(s.ctx, b1, 1, "req-b1")
+	require.NoError(s.T(), err)
+	require.False(s.T(), result.Acquired)
+	require.Equal(s.T(), 0, result.Position)
+
+	require.NoError(s.T(), s.concurrency.ReleaseAccountSlot(s.ctx, accountID, "req-a1"))
+	result, err = s.cache.TryAcquire(s.ctx, b1, 1, "req-b1")
+	require.NoError(s.T(), err)
+	require.True(s.T(), result.Acquired)
+
+	stats, err := s.cache.GetAccountStatsBatch(s.ctx, []int64{accountID})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), 0, stats[accountID].Waiting)
+	require.Equal(s.T(), int64(2), stats[accountID].Granted)
+}
+
+func (s *FairQueueCacheSuite) TestYieldingAcquireLeavesSlotsForQueue() {
+	accountID := int64(32)
+	s.enqueue(accountID, "a1", "user:1", 1)
+
+	ok, err := s.cache.AcquireAccountSlotYielding(s.ctx, accountID, 1, "req-new")
+	require.NoError(s.T(), err)
+	require.False(s.T(), ok, "the only free slot is reserved for the queued ticket")
+
+	ok, err = s.cache.AcquireAccountSlotYielding(s.ctx, accountID, 2, "req-new")
+	require.NoError(s.T(), err)
+	require.True(s.T(), ok)
+}
+
+func (s *FairQueueCacheSuite) TestCancelAndLostTicket() {
+	accountID := int64(33)
+	a1 := s.enqueue(accountID, "a1", "user:1", 1)
+	require.NoError(s.T(), s.cache.Cancel(s.ctx, a1))
+
+	result, err := s.cache.TryAcquire(s.ctx, a1, 1, "req-a1")
+	require.NoError(s.T(), err)
+	require.True(s.T(), result.Lost)
+
+	// 撤销后流的标签被退还，重新入队仍从原位置开始
+	again := s.enqueue(accountID, "a2", "user:1", 1)
+	require.Equal(s.T(), a1.StartTag, again.StartTag)
+}
+
+func (s *FairQueueCacheSuite) TestWeightedFlowAdvancesSlower() {
+	accountID := int64(34)
+	heavy := s.enqueue(accountID, "h1", "user:1", 4)
+	light := s.enqueue(accountID, "l1", "user:2", 1)
+	require.Equal(s.T(), "250", heavy.FinishTag)
+	require.Equal(s.T(), "1000", light.FinishTag)
+}
+
+func TestFairQueueCacheSuite(t *testing.T) {
+	suite.Run(t, new(FairQueueCacheSuite))
+}
@@ -257,9 +257,14 @@ func mustCreateApiKey(t *testing.T, client *dbent.Client, k *service.APIKey) *se
 		k.Name = "default"
 	}
 
+	salt, hash, err := service.HashAPIKey(k.Key)
+	require.NoError(t, err, "hash api key")
+
 	create := client.APIKey.Create().
 		SetUserID(k.UserID).
-		SetKey(k.Key).
+		SetKeyPrefix(service.APIKeyPrefix(k.Key)).
+		SetKeySalt(salt).
+		SetKeyHash(hash).
 		SetName(k.Name).
 		SetStatus(k.Status)
 	if k.GroupID != nil {
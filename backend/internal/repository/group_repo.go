@@ -52,12 +52,25 @@ func (r *groupRepository) Create(ctx context.Context, groupIn *service.Group) er
 		SetNillableFallbackGroupID(groupIn.FallbackGroupID).
 		SetNillableFallbackGroupIDOnInvalidRequest(groupIn.FallbackGroupIDOnInvalidRequest).
 		SetModelRoutingEnabled(groupIn.ModelRoutingEnabled).
-		SetMcpXMLInject(groupIn.MCPXMLInject)
+		SetMcpXMLInject(groupIn.MCPXMLInject).
+		SetDefaultRpmLimit(groupIn.DefaultRPMLimit).
+		SetDefaultTpmLimit(groupIn.DefaultTPMLimit).
+		SetDefaultDailyRequestLimit(groupIn.DefaultDailyRequestLimit).
+		SetResponseCacheEnabled(groupIn.ResponseCacheEnabled).
+		SetResponseCacheTTLSeconds(groupIn.ResponseCacheTTLSeconds)
 
 	// 设置模型路由配置
 	if groupIn.ModelRouting != nil {
 		builder = builder.SetModelRouting(groupIn.ModelRouting)
 	}
+	// 设置 Claude → OpenAI 兜底模型映射
+	if groupIn.OpenAIFallbackModelMapping != nil {
+		builder = builder.SetOpenaiFallbackModelMapping(groupIn.OpenAIFallbackModelMapping)
+	}
+	// 设置分组请求策略
+	if !groupIn.RequestPolicy.IsEmpty() {
+		builder = builder.SetRequestPolicy(groupIn.RequestPolicy)
+	}
 
 	// 设置支持的模型系列（始终设置，空数组表示不限制）
 	builder = builder.SetSupportedModelScopes(groupIn.SupportedModelScopes)
@@ -113,7 +126,12 @@ func (r *groupRepository) Update(ctx context.Context, groupIn *service.Group) er
 		SetDefaultValidityDays(groupIn.DefaultValidityDays).
 		SetClaudeCodeOnly(groupIn.ClaudeCodeOnly).
 		SetModelRoutingEnabled(groupIn.ModelRoutingEnabled).
-		SetMcpXMLInject(groupIn.MCPXMLInject)
+		SetMcpXMLInject(groupIn.MCPXMLInject).
+		SetDefaultRpmLimit(groupIn.DefaultRPMLimit).
+		SetDefaultTpmLimit(groupIn.DefaultTPMLimit).
+		SetDefaultDailyRequestLimit(groupIn.DefaultDailyRequestLimit).
+		SetResponseCacheEnabled(groupIn.ResponseCacheEnabled).
+		SetResponseCacheTTLSeconds(groupIn.ResponseCacheTTLSeconds)
 
 	// 处理 FallbackGroupID：nil 时清除，否则设置
 	if groupIn.FallbackGroupID != nil {
@@ -134,6 +152,18 @@ func (r *groupRepository) Update(ctx context.Context, groupIn *service.Group) er
 	} else {
 		builder = builder.ClearModelRouting()
 	}
+	// 处理 OpenAIFallbackModelMapping：nil 时清除，否则设置
+	if groupIn.OpenAIFallbackModelMapping != nil {
+		builder = builder.SetOpenaiFallbackModelMapping(groupIn.OpenAIFallbackModelMapping)
+	} else {
+		builder = builder.ClearOpenaiFallbackModelMapping()
+	}
+	// 处理 RequestPolicy：空策略时清除，否则设置
+	if !groupIn.RequestPolicy.IsEmpty() {
+		builder = builder.SetRequestPolicy(groupIn.RequestPolicy)
+	} else {
+		builder = builder.ClearRequestPolicy()
+	}
 
 	// 处理 SupportedModelScopes（始终设置，空数组表示不限制）
 	builder = builder.SetSupportedModelScopes(groupIn.SupportedModelScopes)
@@ -0,0 +1,424 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/ent/messagebatch"
+	"github.com/Wei-Shaw/sub2api/ent/messagebatchitem"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/lib/pq"
+)
+
+// messageBatchItemInsertChunk 批量写入请求时每条 INSERT 的最大行数（避免超出 PostgreSQL 参数上限）
+const messageBatchItemInsertChunk = 1000
+
+type messageBatchRepository struct {
+	client *dbent.Client
+	sql    sqlExecutor
+}
+
+// NewMessageBatchRepository 创建 Message Batches 批次与请求仓库
+func NewMessageBatchRepository(client *dbent.Client, sqlDB *sql.DB) service.MessageBatchRepository {
+	return &messageBatchRepository{client: client, sql: sqlDB}
+}
+
+func (r *messageBatchRepository) CreateBatch(ctx context.Context, batch *service.MessageBatch, items []*service.MessageBatchItem) error {
+	tx, err := r.client.Tx(ctx)
+	if err != nil && !errors.Is(err, dbent.ErrTxStarted) {
+		return err
+	}
+	txClient := r.client
+	if err == nil {
+		defer func() { _ = tx.Rollback() }()
+		txClient = tx.Client()
+	}
+
+	created, err := txClient.MessageBatch.Create().
+		SetBatchID(batch.BatchID).
+		SetUserID(batch.UserID).
+		SetAPIKeyID(batch.APIKeyID).
+		SetNillableGroupID(batch.GroupID).
+		SetProcessingStatus(batch.ProcessingStatus).
+		SetRequestCount(batch.RequestCount).
+		SetExpiresAt(batch.ExpiresAt).
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(items); start += messageBatchItemInsertChunk {
+		end := min(start+messageBatchItemInsertChunk, len(items))
+		builders := make([]*dbent.MessageBatchItemCreate, 0, end-start)
+		for _, item := range items[start:end] {
+			builders = append(builders, txClient.MessageBatchItem.Create().
+				SetBatchID(created.ID).
+				SetCustomID(item.CustomID).
+				SetParams(item.Params).
+				SetStatus(item.Status))
+		}
+		rows, err := txClient.MessageBatchItem.CreateBulk(builders...).Save(ctx)
+		if err != nil {
+			return err
+		}
+		for i, row := range rows {
+			items[start+i].ID = row.ID
+			items[start+i].BatchID = row.BatchID
+			items[start+i].CreatedAt = row.CreatedAt
+			items[start+i].UpdatedAt = row.UpdatedAt
+		}
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	batch.ID = created.ID
+	batch.CreatedAt = created.CreatedAt
+	batch.UpdatedAt = created.UpdatedAt
+	return nil
+}
+
+func (r *messageBatchRepository) GetBatch(ctx context.Context, id int64) (*service.MessageBatch, error) {
+	row, err := r.client.MessageBatch.Get(ctx, id)
+	if err != nil {
+		return nil, translatePersistenceError(err, service.ErrMessageBatchNotFound, nil)
+	}
+	return messageBatchEntityToService(row), nil
+}
+
+func (r *messageBatchRepository) GetBatchByBatchID(ctx context.Context, userID int64, batchID string) (*service.MessageBatch, error) {
+	row, err := r.client.MessageBatch.Query().
+		Where(messagebatch.BatchIDEQ(batchID), messagebatch.UserIDEQ(userID)).
+		Only(ctx)
+	if err != nil {
+		return nil, translatePersistenceError(err, service.ErrMessageBatchNotFound, nil)
+	}
+	return messageBatchEntityToService(row), nil
+}
+
+func (r *messageBatchRepository) ListBatches(ctx context.Context, userID int64, params service.MessageBatchListParams) ([]service.MessageBatch, bool, error) {
+	query := r.client.MessageBatch.Query().Where(messagebatch.UserIDEQ(userID))
+
+	// before_id 取游标之前（更新）的一页，after_id 取游标之后（更早）的一页；ID 单调递增，按 ID 排序即按创建时间排序
+	ascending := false
+	switch {
+	case params.BeforeID != "":
+		cursor, err := r.GetBatchByBatchID(ctx, userID, params.BeforeID)
+		if err != nil {
+			return nil, false, err
+		}
+		query = query.Where(messagebatch.IDGT(cursor.ID))
+		ascending = true
+	case params.AfterID != "":
+		cursor, err := r.GetBatchByBatchID(ctx, userID, params.AfterID)
+		if err != nil {
+			return nil, false, err
+		}
+		query = query.Where(messagebatch.IDLT(cursor.ID))
+	}
+	if ascending {
+		query = query.Order(dbent.Asc(messagebatch.FieldID))
+	} else {
+		query = query.Order(dbent.Desc(messagebatch.FieldID))
+	}
+
+	rows, err := query.Limit(params.Limit + 1).All(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	hasMore := len(rows) > params.Limit
+	if hasMore {
+		rows = rows[:params.Limit]
+	}
+	out := make([]service.MessageBatch, len(rows))
+	for i, row := range rows {
+		idx := i
+		if ascending {
+			idx = len(rows) - 1 - i
+		}
+		out[idx] = *messageBatchEntityToService(row)
+	}
+	return out, hasMore, nil
+}
+
+func (r *messageBatchRepository) CountItemsByBatch(ctx context.Context, batchIDs []int64) (map[int64]service.MessageBatchRequestCounts, error) {
+	out := make(map[int64]service.MessageBatchRequestCounts, len(batchIDs))
+	if len(batchIDs) == 0 {
+		return out, nil
+	}
+	rows, err := r.sql.QueryContext(ctx, `
+		SELECT batch_id, status, COUNT(*)
+		FROM message_batch_items
+		WHERE batch_id = ANY($1)
+		GROUP BY batch_id, status
+	`, pq.Array(batchIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var batchID, count int64
+		var status string
+		if err := rows.Scan(&batchID, &status, &count); err != nil {
+			return nil, err
+		}
+		counts := out[batchID]
+		switch status {
+		case service.MessageBatchItemStatusSucceeded:
+			counts.Succeeded += count
+		case service.MessageBatchItemStatusErrored:
+			counts.Errored += count
+		case service.MessageBatchItemStatusCanceled:
+			counts.Canceled += count
+		case service.MessageBatchItemStatusExpired:
+			counts.Expired += count
+		default:
+			counts.Processing += count
+		}
+		out[batchID] = counts
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *messageBatchRepository) CancelBatch(ctx context.Context, id int64) error {
+	tx, err := r.client.Tx(ctx)
+	if err != nil && !errors.Is(err, dbent.ErrTxStarted) {
+		return err
+	}
+	txClient := r.client
+	if err == nil {
+		defer func() { _ = tx.Rollback() }()
+		txClient = tx.Client()
+	}
+
+	n, err := txClient.MessageBatch.Update().
+		Where(messagebatch.IDEQ(id), messagebatch.ProcessingStatusEQ(service.MessageBatchStatusInProgress)).
+		SetProcessingStatus(service.MessageBatchStatusCanceling).
+		SetCancelInitiatedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		if _, err := txClient.MessageBatchItem.Update().
+			Where(messagebatchitem.BatchIDEQ(id), messagebatchitem.StatusEQ(service.MessageBatchItemStatusPending)).
+			SetStatus(service.MessageBatchItemStatusCanceled).
+			ClearLockedUntil().
+			Save(ctx); err != nil {
+			return err
+		}
+	}
+
+	if tx != nil {
+		return tx.Commit()
+	}
+	return nil
+}
+
+func (r *messageBatchRepository) ClaimItems(ctx context.Context, limit int, lease time.Duration) ([]service.MessageBatchItem, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	query := `
+		WITH due AS (
+			SELECT i.id
+			FROM message_batch_items AS i
+			JOIN message_batches AS b ON b.id = i.batch_id
+			WHERE b.processing_status IN ($1, $2)
+				AND (
+					(i.status = $3 AND (i.locked_until IS NULL OR i.locked_until <= NOW()))
+					OR (i.status = $4 AND i.locked_until <= NOW())
+				)
+			ORDER BY i.id ASC
+			LIMIT $5
+			FOR UPDATE OF i SKIP LOCKED
+		)
+		UPDATE message_batch_items AS i
+		SET status = $4,
+			locked_until = NOW() + ($6 * interval '1 second'),
+			updated_at = NOW()
+		FROM due
+		WHERE i.id = due.id
+		RETURNING i.id, i.batch_id, i.custom_id, i.params, i.status, i.result, i.attempts,
+			i.account_id, i.locked_until, i.created_at, i.updated_at
+	`
+	rows, err := r.sql.QueryContext(ctx, query,
+		service.MessageBatchStatusInProgress,
+		service.MessageBatchStatusCanceling,
+		service.MessageBatchItemStatusPending,
+		service.MessageBatchItemStatusProcessing,
+		limit,
+		int64(lease.Seconds()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]service.MessageBatchItem, 0, limit)
+	for rows.Next() {
+		var item service.MessageBatchItem
+		var result sql.NullString
+		var accountID sql.NullInt64
+		var lockedUntil sql.NullTime
+		if err := rows.Scan(
+			&item.ID,
+			&item.BatchID,
+			&item.CustomID,
+			&item.Params,
+			&item.Status,
+			&result,
+			&item.Attempts,
+			&accountID,
+			&lockedUntil,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if result.Valid {
+			item.Result = &result.String
+		}
+		if accountID.Valid {
+			item.AccountID = &accountID.Int64
+		}
+		if lockedUntil.Valid {
+			item.LockedUntil = &lockedUntil.Time
+		}
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *messageBatchRepository) UpdateItem(ctx context.Context, item *service.MessageBatchItem) error {
+	builder := r.client.MessageBatchItem.UpdateOneID(item.ID).
+		SetStatus(item.Status).
+		SetAttempts(item.Attempts).
+		SetNillableAccountID(item.AccountID)
+	if item.Result != nil {
+		builder.SetResult(*item.Result)
+	} else {
+		builder.ClearResult()
+	}
+	if item.LockedUntil != nil {
+		builder.SetLockedUntil(*item.LockedUntil)
+	} else {
+		builder.ClearLockedUntil()
+	}
+	_, err := builder.Save(ctx)
+	return translatePersistenceError(err, service.ErrMessageBatchNotFound, nil)
+}
+
+func (r *messageBatchRepository) ListItems(ctx context.Context, batchID int64, afterID int64, limit int) ([]service.MessageBatchItem, error) {
+	rows, err := r.client.MessageBatchItem.Query().
+		Where(messagebatchitem.BatchIDEQ(batchID), messagebatchitem.IDGT(afterID)).
+		Order(dbent.Asc(messagebatchitem.FieldID)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]service.MessageBatchItem, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *messageBatchItemEntityToService(row))
+	}
+	return out, nil
+}
+
+func (r *messageBatchRepository) ExpireItems(ctx context.Context) (int64, error) {
+	res, err := r.sql.ExecContext(ctx, `
+		UPDATE message_batch_items AS i
+		SET status = $1, locked_until = NULL, updated_at = NOW()
+		FROM message_batches AS b
+		WHERE b.id = i.batch_id
+			AND b.processing_status = $2
+			AND b.expires_at <= NOW()
+			AND (i.status = $3 OR (i.status = $4 AND i.locked_until <= NOW()))
+	`,
+		service.MessageBatchItemStatusExpired,
+		service.MessageBatchStatusInProgress,
+		service.MessageBatchItemStatusPending,
+		service.MessageBatchItemStatusProcessing,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *messageBatchRepository) FinalizeBatches(ctx context.Context) (int64, error) {
+	res, err := r.sql.ExecContext(ctx, `
+		UPDATE message_batches AS b
+		SET processing_status = $1, ended_at = NOW(), updated_at = NOW()
+		WHERE b.processing_status IN ($2, $3)
+			AND NOT EXISTS (
+				SELECT 1 FROM message_batch_items AS i
+				WHERE i.batch_id = b.id AND i.status IN ($4, $5)
+			)
+	`,
+		service.MessageBatchStatusEnded,
+		service.MessageBatchStatusInProgress,
+		service.MessageBatchStatusCanceling,
+		service.MessageBatchItemStatusPending,
+		service.MessageBatchItemStatusProcessing,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *messageBatchRepository) DeleteEndedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	n, err := r.client.MessageBatch.Delete().
+		Where(
+			messagebatch.ProcessingStatusEQ(service.MessageBatchStatusEnded),
+			messagebatch.EndedAtLT(cutoff),
+		).
+		Exec(ctx)
+	return int64(n), err
+}
+
+func messageBatchEntityToService(row *dbent.MessageBatch) *service.MessageBatch {
+	return &service.MessageBatch{
+		ID:                row.ID,
+		BatchID:           row.BatchID,
+		UserID:            row.UserID,
+		APIKeyID:          row.APIKeyID,
+		GroupID:           row.GroupID,
+		ProcessingStatus:  row.ProcessingStatus,
+		RequestCount:      row.RequestCount,
+		ExpiresAt:         row.ExpiresAt,
+		CancelInitiatedAt: row.CancelInitiatedAt,
+		EndedAt:           row.EndedAt,
+		CreatedAt:         row.CreatedAt,
+		UpdatedAt:         row.UpdatedAt,
+	}
+}
+
+func messageBatchItemEntityToService(row *dbent.MessageBatchItem) *service.MessageBatchItem {
+	return &service.MessageBatchItem{
+		ID:          row.ID,
+		BatchID:     row.BatchID,
+		CustomID:    row.CustomID,
+		Params:      row.Params,
+		Status:      row.Status,
+		Result:      row.Result,
+		Attempts:    row.Attempts,
+		AccountID:   row.AccountID,
+		LockedUntil: row.LockedUntil,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
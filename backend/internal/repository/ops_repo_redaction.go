@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+func (r *opsRepository) InsertRedactionLog(ctx context.Context, input *service.OpsInsertRedactionLogInput) (int64, error) {
+	if r == nil || r.db == nil {
+		return 0, fmt.Errorf("nil ops repository")
+	}
+	if input == nil {
+		return 0, fmt.Errorf("nil input")
+	}
+	if strings.TrimSpace(input.Action) == "" {
+		return 0, fmt.Errorf("invalid action")
+	}
+
+	counts := input.Counts
+	if counts == nil {
+		counts = map[string]int{}
+	}
+	detections, err := json.Marshal(counts)
+	if err != nil {
+		return 0, err
+	}
+
+	q := `
+INSERT INTO ops_redaction_logs (
+  request_id,
+  client_request_id,
+  user_id,
+  api_key_id,
+  group_id,
+  platform,
+  model,
+  request_path,
+  action,
+  detections,
+  total_count,
+  created_at
+) VALUES (
+  $1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12
+) RETURNING id`
+
+	var id int64
+	err = r.db.QueryRowContext(
+		ctx,
+		q,
+		opsNullString(input.RequestID),
+		opsNullString(input.ClientRequestID),
+		opsNullInt64(input.UserID),
+		opsNullInt64(input.APIKeyID),
+		opsNullInt64(input.GroupID),
+		opsNullString(input.Platform),
+		opsNullString(input.Model),
+		opsNullString(input.RequestPath),
+		strings.TrimSpace(input.Action),
+		string(detections),
+		input.TotalCount,
+		input.CreatedAt,
+	).Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *opsRepository) ListRedactionLogs(ctx context.Context, filter *service.OpsRedactionLogFilter) (*service.OpsRedactionLogList, error) {
+	if r == nil || r.db == nil {
+		return nil, fmt.Errorf("nil ops repository")
+	}
+	if filter == nil {
+		filter = &service.OpsRedactionLogFilter{}
+	}
+
+	page := filter.Page
+	if page <= 0 {
+		page = 1
+	}
+	pageSize := filter.PageSize
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if pageSize > 500 {
+		pageSize = 500
+	}
+
+	where, args := buildOpsRedactionLogsWhere(filter)
+	countSQL := "SELECT COUNT(*) FROM ops_redaction_logs l " + where
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, countSQL, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	offset := (page - 1) * pageSize
+	argsWithLimit := append(args, pageSize, offset)
+	selectSQL := `
+SELECT
+  l.id,
+  l.created_at,
+  COALESCE(l.request_id, ''),
+  COALESCE(l.client_request_id, ''),
+  l.user_id,
+  COALESCE(u.email, ''),
+  l.api_key_id,
+  l.group_id,
+  COALESCE(g.name, ''),
+  COALESCE(l.platform, ''),
+  COALESCE(l.model, ''),
+  COALESCE(l.request_path, ''),
+  l.action,
+  l.detections,
+  l.total_count
+FROM ops_redaction_logs l
+LEFT JOIN groups g ON l.group_id = g.id
+LEFT JOIN users u ON l.user_id = u.id
+` + where + `
+ORDER BY l.created_at DESC
+LIMIT $` + itoa(len(args)+1) + ` OFFSET $` + itoa(len(args)+2)
+
+	rows, err := r.db.QueryContext(ctx, selectSQL, argsWithLimit...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]*service.OpsRedactionLog, 0, pageSize)
+	for rows.Next() {
+		var item service.OpsRedactionLog
+		var userID sql.NullInt64
+		var apiKeyID sql.NullInt64
+		var groupID sql.NullInt64
+		var detections []byte
+		if err := rows.Scan(
+			&item.ID,
+			&item.CreatedAt,
+			&item.RequestID,
+			&item.ClientRequestID,
+			&userID,
+			&item.UserEmail,
+			&apiKeyID,
+			&groupID,
+			&item.GroupName,
+			&item.Platform,
+			&item.Model,
+			&item.RequestPath,
+			&item.Action,
+			&detections,
+			&item.TotalCount,
+		); err != nil {
+			return nil, err
+		}
+		if userID.Valid {
+			v := userID.Int64
+			item.UserID = &v
+		}
+		if apiKeyID.Valid {
+			v := apiKeyID.Int64
+			item.APIKeyID = &v
+		}
+		if groupID.Valid {
+			v := groupID.Int64
+			item.GroupID = &v
+		}
+		item.Counts = map[string]int{}
+		if len(detections) > 0 {
+			_ = json.Unmarshal(detections, &item.Counts)
+		}
+		out = append(out, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &service.OpsRedactionLogList{
+		Logs:     out,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func buildOpsRedactionLogsWhere(filter *service.OpsRedactionLogFilter) (string, []any) {
+	clauses := []string{"1=1"}
+	args := make([]any, 0, 6)
+
+	if filter.StartTime != nil && !filter.StartTime.IsZero() {
+		args = append(args, filter.StartTime.UTC())
+		clauses = append(clauses, "l.created_at >= $"+itoa(len(args)))
+	}
+	if filter.EndTime != nil && !filter.EndTime.IsZero() {
+		args = append(args, filter.EndTime.UTC())
+		// [start, end)
+		clauses = append(clauses, "l.created_at < $"+itoa(len(args)))
+	}
+	if p := strings.TrimSpace(filter.Platform); p != "" {
+		args = append(args, p)
+		clauses = append(clauses, "l.platform = $"+itoa(len(args)))
+	}
+	if filter.GroupID != nil && *filter.GroupID > 0 {
+		args = append(args, *filter.GroupID)
+		clauses = append(clauses, "l.group_id = $"+itoa(len(args)))
+	}
+	if filter.UserID != nil && *filter.UserID > 0 {
+		args = append(args, *filter.UserID)
+		clauses = append(clauses, "l.user_id = $"+itoa(len(args)))
+	}
+	if action := strings.TrimSpace(strings.ToLower(filter.Action)); action != "" {
+		args = append(args, action)
+		clauses = append(clauses, "l.action = $"+itoa(len(args)))
+	}
+
+	return "WHERE " + strings.Join(clauses, " AND "), args
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	responseCacheKeyPrefix      = "response_cache:"
+	responseCacheStatsKeyPrefix = "response_cache:stats:"
+	// responseCacheStatsTTL 每日统计保留时间（略长于最大查询范围）
+	responseCacheStatsTTL = 35 * 24 * time.Hour
+)
+
+type responseCache struct {
+	rdb *redis.Client
+}
+
+// NewResponseCache 创建基于 Redis 的响应缓存
+func NewResponseCache(rdb *redis.Client) service.ResponseCache {
+	return &responseCache{rdb: rdb}
+}
+
+// responseCacheKey 格式: response_cache:{groupID}:{endpoint}:{model}:{stream}:{hash}
+func responseCacheKey(key string) string {
+	return responseCacheKeyPrefix + key
+}
+
+// responseCacheStatsKey 格式: response_cache:stats:{yyyy-mm-dd}
+func responseCacheStatsKey(date string) string {
+	return responseCacheStatsKeyPrefix + date
+}
+
+func (c *responseCache) GetResponse(ctx context.Context, key string) (*service.ResponseCacheEntry, error) {
+	raw, err := c.rdb.Get(ctx, responseCacheKey(key)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var entry service.ResponseCacheEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		// 损坏的条目视为未命中，后续写入会覆盖
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+func (c *responseCache) SetResponse(ctx context.Context, key string, entry *service.ResponseCacheEntry, ttl time.Duration) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return c.rdb.Set(ctx, responseCacheKey(key), raw, ttl).Err()
+}
+
+func (c *responseCache) IncrStats(ctx context.Context, date string, hits, misses, stores int64) error {
+	key := responseCacheStatsKey(date)
+	pipe := c.rdb.Pipeline()
+	if hits != 0 {
+		pipe.HIncrBy(ctx, key, "hits", hits)
+	}
+	if misses != 0 {
+		pipe.HIncrBy(ctx, key, "misses", misses)
+	}
+	if stores != 0 {
+		pipe.HIncrBy(ctx, key, "stores", stores)
+	}
+	pipe.Expire(ctx, key, responseCacheStatsTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *responseCache) GetStats(ctx context.Context, dates []string) (map[string]service.ResponseCacheDailyStats, error) {
+	result := make(map[string]service.ResponseCacheDailyStats, len(dates))
+	if len(dates) == 0 {
+		return result, nil
+	}
+	pipe := c.rdb.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(dates))
+	for i, date := range dates {
+		cmds[i] = pipe.HGetAll(ctx, responseCacheStatsKey(date))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	for i, date := range dates {
+		values := cmds[i].Val()
+		if len(values) == 0 {
+			continue
+		}
+		result[date] = service.ResponseCacheDailyStats{
+			Date:   date,
+			Hits:   parseResponseCacheCounter(values["hits"]),
+			Misses: parseResponseCacheCounter(values["misses"]),
+			Stores: parseResponseCacheCounter(values["stores"]),
+		}
+	}
+	return result, nil
+}
+
+func parseResponseCacheCounter(raw string) int64 {
+	n, _ := strconv.ParseInt(raw, 10, 64)
+	return n
+}
@@ -0,0 +1,67 @@
+//go:build integration
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ResponseCacheSuite struct {
+	IntegrationRedisSuite
+	cache service.ResponseCache
+}
+
+func (s *ResponseCacheSuite) SetupTest() {
+	s.IntegrationRedisSuite.SetupTest()
+	s.cache = NewResponseCache(s.rdb)
+}
+
+func (s *ResponseCacheSuite) TestSetAndGetResponse() {
+	entry, err := s.cache.GetResponse(s.ctx, "1:messages:m:false:abc")
+	require.NoError(s.T(), err)
+	require.Nil(s.T(), entry)
+
+	want := &service.ResponseCacheEntry{
+		ContentType: "application/json",
+		Body:        []byte(`{"id":"msg_1"}`),
+		Model:       "m",
+		AccountID:   3,
+		Usage:       service.ClaudeUsage{InputTokens: 10, OutputTokens: 2},
+	}
+	require.NoError(s.T(), s.cache.SetResponse(s.ctx, "1:messages:m:false:abc", want, time.Minute))
+
+	got, err := s.cache.GetResponse(s.ctx, "1:messages:m:false:abc")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), want.Body, got.Body)
+	require.Equal(s.T(), want.Usage, got.Usage)
+	require.Equal(s.T(), int64(3), got.AccountID)
+
+	ttl, err := s.rdb.TTL(s.ctx, responseCacheKey("1:messages:m:false:abc")).Result()
+	require.NoError(s.T(), err)
+	s.AssertTTLWithin(ttl, 1*time.Second, time.Minute)
+}
+
+func (s *ResponseCacheSuite) TestStats() {
+	require.NoError(s.T(), s.cache.IncrStats(s.ctx, "2026-01-01", 1, 0, 0))
+	require.NoError(s.T(), s.cache.IncrStats(s.ctx, "2026-01-01", 0, 2, 1))
+	require.NoError(s.T(), s.cache.IncrStats(s.ctx, "2026-01-02", 4, 0, 0))
+
+	stats, err := s.cache.GetStats(s.ctx, []string{"2026-01-01", "2026-01-02", "2026-01-03"})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), stats, 2)
+	require.Equal(s.T(), service.ResponseCacheDailyStats{Date: "2026-01-01", Hits: 1, Misses: 2, Stores: 1}, stats["2026-01-01"])
+	require.Equal(s.T(), int64(4), stats["2026-01-02"].Hits)
+
+	ttl, err := s.rdb.TTL(s.ctx, responseCacheStatsKey("2026-01-01")).Result()
+	require.NoError(s.T(), err)
+	s.AssertTTLWithin(ttl, time.Hour, responseCacheStatsTTL)
+}
+
+func TestResponseCacheSuite(t *testing.T) {
+	suite.Run(t, new(ResponseCacheSuite))
+}
@@ -0,0 +1,16 @@
+//go:build unit
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCacheKeys(t *testing.T) {
+	require.Equal(t, "response_cache:1:messages:m:false:abc", responseCacheKey("1:messages:m:false:abc"))
+	require.Equal(t, "response_cache:stats:2026-01-01", responseCacheStatsKey("2026-01-01"))
+	require.Equal(t, int64(0), parseResponseCacheCounter(""))
+	require.Equal(t, int64(42), parseResponseCacheCounter("42"))
+}
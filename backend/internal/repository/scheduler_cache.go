@@ -274,5 +274,7 @@ func decodeCachedAccount(val any) (*service.Account, error) {
 	if err := json.Unmarshal(payload, &account); err != nil {
 		return nil, err
 	}
+	account.ParseSchedule()
+	account.ParseDrain()
 	return &account, nil
 }
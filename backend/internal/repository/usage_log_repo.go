@@ -22,7 +22,7 @@ import (
 	"github.com/lib/pq"
 )
 
-const usageLogSelectColumns = "id, user_id, api_key_id, account_id, request_id, model, group_id, subscription_id, input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cache_creation_5m_tokens, cache_creation_1h_tokens, input_cost, output_cost, cache_creation_cost, cache_read_cost, total_cost, actual_cost, rate_multiplier, account_rate_multiplier, billing_type, stream, duration_ms, first_token_ms, user_agent, ip_address, image_count, image_size, reasoning_effort, created_at"
+const usageLogSelectColumns = "id, user_id, api_key_id, account_id, request_id, model, group_id, subscription_id, input_tokens, output_tokens, cache_creation_tokens, cache_read_tokens, cache_creation_5m_tokens, cache_creation_1h_tokens, input_cost, output_cost, cache_creation_cost, cache_read_cost, total_cost, actual_cost, rate_multiplier, account_rate_multiplier, billing_type, response_cache_hit, stream, duration_ms, first_token_ms, user_agent, ip_address, image_count, image_size, reasoning_effort, created_at"
 
 type usageLogRepository struct {
 	client *dbent.Client
@@ -107,6 +107,7 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 			rate_multiplier,
 			account_rate_multiplier,
 			billing_type,
+			response_cache_hit,
 			stream,
 			duration_ms,
 			first_token_ms,
@@ -122,7 +123,7 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 				$8, $9, $10, $11,
 				$12, $13,
 				$14, $15, $16, $17, $18, $19,
-				$20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31
+				$20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32
 			)
 			ON CONFLICT (request_id, api_key_id) DO NOTHING
 			RETURNING id, created_at
@@ -165,6 +166,7 @@ func (r *usageLogRepository) Create(ctx context.Context, log *service.UsageLog)
 		rateMultiplier,
 		log.AccountRateMultiplier,
 		log.BillingType,
+		log.ResponseCacheHit,
 		log.Stream,
 		duration,
 		firstToken,
@@ -1311,6 +1313,75 @@ type UsageLogFilters = usagestats.UsageLogFilters
 
 // ListWithFilters lists usage logs with optional filters (for admin)
 func (r *usageLogRepository) ListWithFilters(ctx context.Context, params pagination.PaginationParams, filters UsageLogFilters) ([]service.UsageLog, *pagination.PaginationResult, error) {
+	whereClause, args := buildUsageLogFilterWhere(filters)
+	logs, page, err := r.listUsageLogsWithPagination(ctx, whereClause, args, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := r.hydrateUsageLogAssociations(ctx, logs); err != nil {
+		return nil, nil, err
+	}
+	return logs, page, nil
+}
+
+// usageLogExportFetchSize 导出时每次从游标拉取的行数
+const usageLogExportFetchSize = 1000
+
+// StreamWithFilters 按 id 升序逐行回调符合过滤条件的使用记录（用于导出）。
+//
+// 使用只读事务内的服务端游标（DECLARE ... CURSOR + FETCH）分批读取，
+// 内存占用只与 usageLogExportFetchSize 相关，与结果总行数无关。
+// fn 返回错误时立即停止并返回该错误。
+func (r *usageLogRepository) StreamWithFilters(ctx context.Context, filters UsageLogFilters, fn func(*service.UsageLog) error) error {
+	// 游标只能存活在事务内；已处于事务中（如测试）时直接复用外层事务。
+	var sqlq sqlExecutor = r.sql
+	tx, err := r.client.Tx(ctx)
+	if err != nil && !errors.Is(err, dbent.ErrTxStarted) {
+		return fmt.Errorf("begin export transaction: %w", err)
+	}
+	if tx != nil {
+		// 只读导出无需提交，结束时回滚即可释放游标与快照
+		defer func() { _ = tx.Rollback() }()
+		sqlq = tx.Client()
+		if _, err := sqlq.ExecContext(ctx, "SET TRANSACTION READ ONLY"); err != nil {
+			return fmt.Errorf("set export transaction read only: %w", err)
+		}
+	} else {
+		defer func() { _, _ = sqlq.ExecContext(context.WithoutCancel(ctx), "CLOSE usage_log_export") }()
+	}
+
+	whereClause, args := buildUsageLogFilterWhere(filters)
+	declare := fmt.Sprintf("DECLARE usage_log_export NO SCROLL CURSOR FOR SELECT %s FROM usage_logs %s ORDER BY id ASC", usageLogSelectColumns, whereClause)
+	if _, err := sqlq.ExecContext(ctx, declare, args...); err != nil {
+		return fmt.Errorf("declare export cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH FORWARD %d FROM usage_log_export", usageLogExportFetchSize)
+	for {
+		logs, err := queryUsageLogsWith(ctx, sqlq, fetch)
+		if err != nil {
+			return fmt.Errorf("fetch export cursor: %w", err)
+		}
+		if len(logs) == 0 {
+			return nil
+		}
+		if err := r.hydrateUsageLogAssociations(ctx, logs); err != nil {
+			return err
+		}
+		for i := range logs {
+			if err := fn(&logs[i]); err != nil {
+				return err
+			}
+		}
+		if len(logs) < usageLogExportFetchSize {
+			return nil
+		}
+	}
+}
+
+// buildUsageLogFilterWhere 将 UsageLogFilters 转换为 WHERE 子句与参数
+func buildUsageLogFilterWhere(filters UsageLogFilters) (string, []any) {
 	conditions := make([]string, 0, 8)
 	args := make([]any, 0, 8)
 
@@ -1351,16 +1422,7 @@ func (r *usageLogRepository) ListWithFilters(ctx context.Context, params paginat
 		args = append(args, *filters.EndTime)
 	}
 
-	whereClause := buildWhere(conditions)
-	logs, page, err := r.listUsageLogsWithPagination(ctx, whereClause, args, params)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	if err := r.hydrateUsageLogAssociations(ctx, logs); err != nil {
-		return nil, nil, err
-	}
-	return logs, page, nil
+	return buildWhere(conditions), args
 }
 
 // UsageStats represents usage statistics
@@ -1968,8 +2030,12 @@ func (r *usageLogRepository) listUsageLogsWithPagination(ctx context.Context, wh
 	return logs, paginationResultFromTotal(total, params), nil
 }
 
-func (r *usageLogRepository) queryUsageLogs(ctx context.Context, query string, args ...any) (logs []service.UsageLog, err error) {
-	rows, err := r.sql.QueryContext(ctx, query, args...)
+func (r *usageLogRepository) queryUsageLogs(ctx context.Context, query string, args ...any) ([]service.UsageLog, error) {
+	return queryUsageLogsWith(ctx, r.sql, query, args...)
+}
+
+func queryUsageLogsWith(ctx context.Context, sqlq sqlExecutor, query string, args ...any) (logs []service.UsageLog, err error) {
+	rows, err := sqlq.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -2187,6 +2253,7 @@ func scanUsageLog(scanner interface{ Scan(...any) error }) (*service.UsageLog, e
 		rateMultiplier        float64
 		accountRateMultiplier sql.NullFloat64
 		billingType           int16
+		responseCacheHit      bool
 		stream                bool
 		durationMs            sql.NullInt64
 		firstTokenMs          sql.NullInt64
@@ -2222,6 +2289,7 @@ func scanUsageLog(scanner interface{ Scan(...any) error }) (*service.UsageLog, e
 		&rateMultiplier,
 		&accountRateMultiplier,
 		&billingType,
+		&responseCacheHit,
 		&stream,
 		&durationMs,
 		&firstTokenMs,
@@ -2256,6 +2324,7 @@ func scanUsageLog(scanner interface{ Scan(...any) error }) (*service.UsageLog, e
 		RateMultiplier:        rateMultiplier,
 		AccountRateMultiplier: nullFloat64Ptr(accountRateMultiplier),
 		BillingType:           int8(billingType),
+		ResponseCacheHit:      responseCacheHit,
 		Stream:                stream,
 		ImageCount:            imageCount,
 		CreatedAt:             createdAt,
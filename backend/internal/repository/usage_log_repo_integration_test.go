@@ -4,6 +4,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -84,6 +85,30 @@ func (s *UsageLogRepoSuite) TestCreate() {
 	s.Require().NotZero(log.ID)
 }
 
+func (s *UsageLogRepoSuite) TestCreate_ResponseCacheHitKeepsBillingType() {
+	user := mustCreateUser(s.T(), s.client, &service.User{Email: "cachehit@test.com"})
+	apiKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: user.ID, Key: "sk-cachehit", Name: "k"})
+	account := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-cachehit"})
+
+	log := &service.UsageLog{
+		UserID:           user.ID,
+		APIKeyID:         apiKey.ID,
+		AccountID:        account.ID,
+		RequestID:        uuid.New().String(),
+		Model:            "claude-3",
+		BillingType:      service.BillingTypeSubscription,
+		ResponseCacheHit: true,
+		CreatedAt:        time.Now(),
+	}
+	_, err := s.repo.Create(s.ctx, log)
+	s.Require().NoError(err, "Create")
+
+	got, err := s.repo.GetByID(s.ctx, log.ID)
+	s.Require().NoError(err, "GetByID")
+	s.Require().Equal(service.BillingTypeSubscription, got.BillingType)
+	s.Require().True(got.ResponseCacheHit)
+}
+
 func (s *UsageLogRepoSuite) TestGetByID() {
 	user := mustCreateUser(s.T(), s.client, &service.User{Email: "getbyid@test.com"})
 	apiKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: user.ID, Key: "sk-getbyid", Name: "k"})
@@ -229,6 +254,41 @@ func (s *UsageLogRepoSuite) TestListWithFilters() {
 	s.Require().Equal(int64(1), page.Total)
 }
 
+func (s *UsageLogRepoSuite) TestStreamWithFilters() {
+	user := mustCreateUser(s.T(), s.client, &service.User{Email: "stream@test.com"})
+	other := mustCreateUser(s.T(), s.client, &service.User{Email: "stream-other@test.com"})
+	apiKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: user.ID, Key: "sk-stream", Name: "k"})
+	otherKey := mustCreateApiKey(s.T(), s.client, &service.APIKey{UserID: other.ID, Key: "sk-stream-other", Name: "k2"})
+	account := mustCreateAccount(s.T(), s.client, &service.Account{Name: "acc-stream"})
+
+	base := time.Now().Add(-time.Hour)
+	first := s.createUsageLog(user, apiKey, account, 10, 20, 0.5, base)
+	second := s.createUsageLog(user, apiKey, account, 15, 25, 0.6, base.Add(time.Minute))
+	s.createUsageLog(other, otherKey, account, 5, 5, 0.1, base)
+
+	var got []service.UsageLog
+	err := s.repo.StreamWithFilters(s.ctx, usagestats.UsageLogFilters{UserID: user.ID}, func(l *service.UsageLog) error {
+		got = append(got, *l)
+		return nil
+	})
+	s.Require().NoError(err, "StreamWithFilters")
+	s.Require().Len(got, 2)
+	s.Require().Equal(first.ID, got[0].ID)
+	s.Require().Equal(second.ID, got[1].ID)
+	s.Require().NotNil(got[0].User, "associations should be hydrated")
+	s.Require().Equal("stream@test.com", got[0].User.Email)
+
+	// 回调返回错误时中断导出，且游标可再次声明
+	stopErr := errors.New("stop")
+	calls := 0
+	err = s.repo.StreamWithFilters(s.ctx, usagestats.UsageLogFilters{UserID: user.ID}, func(*service.UsageLog) error {
+		calls++
+		return stopErr
+	})
+	s.Require().ErrorIs(err, stopErr)
+	s.Require().Equal(1, calls)
+}
+
 // --- GetDashboardStats ---
 
 func (s *UsageLogRepoSuite) TestDashboardStats_TodayTotalsAndPerformance() {
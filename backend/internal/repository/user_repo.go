@@ -70,6 +70,19 @@ func (r *userRepository) Create(ctx context.Context, userIn *service.User) error
 		return err
 	}
 
+	// 初始余额同样记入余额流水，保证流水合计与余额一致
+	if created.Balance != 0 {
+		if _, err := txClient.BalanceLedgerEntry.Create().
+			SetUserID(created.ID).
+			SetEntryType(service.BalanceLedgerTypeInitial).
+			SetAmount(created.Balance).
+			SetBalanceBefore(0).
+			SetBalanceAfter(created.Balance).
+			Save(ctx); err != nil {
+			return err
+		}
+	}
+
 	if tx != nil {
 		if err := tx.Commit(); err != nil {
 			return err
@@ -140,7 +153,6 @@ func (r *userRepository) Update(ctx context.Context, userIn *service.User) error
 		SetNotes(userIn.Notes).
 		SetPasswordHash(userIn.PasswordHash).
 		SetRole(userIn.Role).
-		SetBalance(userIn.Balance).
 		SetConcurrency(userIn.Concurrency).
 		SetStatus(userIn.Status).
 		Save(ctx)
@@ -322,33 +334,70 @@ func (r *userRepository) filterUsersByAttributes(ctx context.Context, attrs map[
 }
 
 func (r *userRepository) UpdateBalance(ctx context.Context, id int64, amount float64) error {
-	client := clientFromContext(ctx, r.client)
-	n, err := client.User.Update().Where(dbuser.IDEQ(id)).AddBalance(amount).Save(ctx)
-	if err != nil {
-		return translatePersistenceError(err, service.ErrUserNotFound, nil)
-	}
-	if n == 0 {
-		return service.ErrUserNotFound
-	}
-	return nil
+	return r.ApplyBalanceChange(ctx, &service.BalanceLedgerEntry{
+		UserID: id,
+		Type:   service.BalanceLedgerTypeAdjustment,
+		Amount: amount,
+	})
 }
 
 // DeductBalance 扣除用户余额
 // 透支策略：允许余额变为负数，确保当前请求能够完成
 // 中间件会阻止余额 <= 0 的用户发起后续请求
 func (r *userRepository) DeductBalance(ctx context.Context, id int64, amount float64) error {
+	return r.ApplyBalanceChange(ctx, &service.BalanceLedgerEntry{
+		UserID: id,
+		Type:   service.BalanceLedgerTypeAdjustment,
+		Amount: -amount,
+	})
+}
+
+// ApplyBalanceChange 在单条语句内完成余额增减与流水追加，天然原子；
+// 处于事务上下文时随外部事务提交。
+func (r *userRepository) ApplyBalanceChange(ctx context.Context, entry *service.BalanceLedgerEntry) error {
+	// 统一按 decimal(20,8) 参与运算，保证 balance_before + amount = balance_after 精确成立
+	query := `
+		WITH updated AS (
+			UPDATE users
+			SET balance = balance + $2::decimal(20,8), updated_at = NOW()
+			WHERE id = $1 AND deleted_at IS NULL
+			RETURNING id, balance
+		)
+		INSERT INTO balance_ledger_entries (user_id, entry_type, amount, balance_before, balance_after, reference_id, api_key_id, note)
+		SELECT id, $3, $2::decimal(20,8), balance - $2::decimal(20,8), balance, $4, $5, $6 FROM updated
+		RETURNING id, amount, balance_before, balance_after, created_at`
+	args := []any{entry.UserID, entry.Amount, entry.Type, nullInt64(entry.ReferenceID), nullInt64(entry.APIKeyID), entry.Note}
+	return r.insertBalanceLedgerEntry(ctx, query, args, entry)
+}
+
+// SetBalance 锁定用户行后将余额设为指定值，变更额按锁定时的余额计算，避免与并发扣费互相覆盖。
+func (r *userRepository) SetBalance(ctx context.Context, entry *service.BalanceLedgerEntry, balance float64) error {
+	query := `
+		WITH prev AS (
+			SELECT id, balance FROM users
+			WHERE id = $1 AND deleted_at IS NULL
+			FOR UPDATE
+		), updated AS (
+			UPDATE users u
+			SET balance = $2::decimal(20,8), updated_at = NOW()
+			FROM prev
+			WHERE u.id = prev.id
+			RETURNING u.id, prev.balance AS balance_before, u.balance AS balance_after
+		)
+		INSERT INTO balance_ledger_entries (user_id, entry_type, amount, balance_before, balance_after, reference_id, api_key_id, note)
+		SELECT id, $3, balance_after - balance_before, balance_before, balance_after, $4, $5, $6 FROM updated
+		RETURNING id, amount, balance_before, balance_after, created_at`
+	args := []any{entry.UserID, balance, entry.Type, nullInt64(entry.ReferenceID), nullInt64(entry.APIKeyID), entry.Note}
+	return r.insertBalanceLedgerEntry(ctx, query, args, entry)
+}
+
+func (r *userRepository) insertBalanceLedgerEntry(ctx context.Context, query string, args []any, entry *service.BalanceLedgerEntry) error {
 	client := clientFromContext(ctx, r.client)
-	n, err := client.User.Update().
-		Where(dbuser.IDEQ(id)).
-		AddBalance(-amount).
-		Save(ctx)
-	if err != nil {
-		return err
-	}
-	if n == 0 {
+	err := scanSingleRow(ctx, client, query, args, &entry.ID, &entry.Amount, &entry.BalanceBefore, &entry.BalanceAfter, &entry.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
 		return service.ErrUserNotFound
 	}
-	return nil
+	return err
 }
 
 func (r *userRepository) UpdateConcurrency(ctx context.Context, id int64, amount int) error {
@@ -324,6 +324,98 @@ func (s *UserRepoSuite) TestDeductBalance_AllowsOverdraft() {
 	s.Require().InDelta(-5.0, got.Balance, 1e-6, "Balance should be -5.0 after overdraft")
 }
 
+func (s *UserRepoSuite) ledgerEntries(userID int64) []service.BalanceLedgerEntry {
+	s.T().Helper()
+
+	repo := NewBalanceLedgerRepository(s.client)
+	entries, _, err := repo.ListByUser(s.ctx, userID, pagination.PaginationParams{Page: 1, PageSize: 100}, service.BalanceLedgerFilters{})
+	s.Require().NoError(err, "ListByUser")
+	return entries
+}
+
+func (s *UserRepoSuite) TestCreate_WritesInitialLedgerEntry() {
+	user := s.mustCreateUser(&service.User{Email: "ledger-init@test.com", Balance: 3})
+	empty := s.mustCreateUser(&service.User{Email: "ledger-empty@test.com"})
+
+	entries := s.ledgerEntries(user.ID)
+	s.Require().Len(entries, 1)
+	s.Require().Equal(service.BalanceLedgerTypeInitial, entries[0].Type)
+	s.Require().InDelta(3.0, entries[0].Amount, 1e-9)
+	s.Require().InDelta(3.0, entries[0].BalanceAfter, 1e-9)
+
+	s.Require().Empty(s.ledgerEntries(empty.ID))
+}
+
+func (s *UserRepoSuite) TestApplyBalanceChange_WritesLedgerEntry() {
+	user := s.mustCreateUser(&service.User{Email: "ledger-apply@test.com", Balance: 10})
+
+	refID := int64(99)
+	apiKeyID := int64(5)
+	entry := &service.BalanceLedgerEntry{
+		UserID:      user.ID,
+		Type:        service.BalanceLedgerTypeUsage,
+		Amount:      -0.12345678,
+		ReferenceID: &refID,
+		APIKeyID:    &apiKeyID,
+		Note:        "claude-sonnet-4",
+	}
+	s.Require().NoError(s.repo.ApplyBalanceChange(s.ctx, entry), "ApplyBalanceChange")
+	s.Require().NotZero(entry.ID)
+	s.Require().InDelta(10.0, entry.BalanceBefore, 1e-9)
+	s.Require().InDelta(9.87654322, entry.BalanceAfter, 1e-9)
+
+	got, err := s.repo.GetByID(s.ctx, user.ID)
+	s.Require().NoError(err)
+	s.Require().InDelta(entry.BalanceAfter, got.Balance, 1e-9)
+
+	entries := s.ledgerEntries(user.ID)
+	s.Require().Len(entries, 2)
+	s.Require().Equal(entry.ID, entries[0].ID, "newest first")
+	s.Require().Equal(service.BalanceLedgerTypeUsage, entries[0].Type)
+	s.Require().Equal(refID, *entries[0].ReferenceID)
+	s.Require().Equal(apiKeyID, *entries[0].APIKeyID)
+	s.Require().Equal("claude-sonnet-4", entries[0].Note)
+
+	err = s.repo.ApplyBalanceChange(s.ctx, &service.BalanceLedgerEntry{UserID: 999999, Type: service.BalanceLedgerTypeAdjustment, Amount: 1})
+	s.Require().ErrorIs(err, service.ErrUserNotFound)
+}
+
+func (s *UserRepoSuite) TestSetBalance_WritesLedgerEntry() {
+	user := s.mustCreateUser(&service.User{Email: "ledger-set@test.com", Balance: 10})
+	s.Require().NoError(s.repo.DeductBalance(s.ctx, user.ID, 4), "DeductBalance")
+
+	entry := &service.BalanceLedgerEntry{UserID: user.ID, Type: service.BalanceLedgerTypeAdminAdjust, Note: "reset"}
+	s.Require().NoError(s.repo.SetBalance(s.ctx, entry, 20), "SetBalance")
+	s.Require().InDelta(6.0, entry.BalanceBefore, 1e-9)
+	s.Require().InDelta(14.0, entry.Amount, 1e-9)
+	s.Require().InDelta(20.0, entry.BalanceAfter, 1e-9)
+
+	var sum float64
+	for _, e := range s.ledgerEntries(user.ID) {
+		sum += e.Amount
+	}
+	s.Require().InDelta(20.0, sum, 1e-9, "ledger sum must equal balance")
+}
+
+func (s *UserRepoSuite) TestFindDrift() {
+	consistent := s.mustCreateUser(&service.User{Email: "ledger-ok@test.com", Balance: 10})
+	s.Require().NoError(s.repo.DeductBalance(s.ctx, consistent.ID, 1))
+	drifted := s.mustCreateUser(&service.User{Email: "ledger-drift@test.com", Balance: 10})
+	// 绕过仓储直接改余额，模拟漂移
+	_, err := integrationDB.ExecContext(s.ctx, "UPDATE users SET balance = balance + 2 WHERE id = $1", drifted.ID)
+	s.Require().NoError(err)
+
+	drifts, err := NewBalanceLedgerRepository(s.client).FindDrift(s.ctx, 1e-6, 100)
+	s.Require().NoError(err, "FindDrift")
+	s.Require().Len(drifts, 1)
+	s.Require().Equal(drifted.ID, drifts[0].UserID)
+	s.Require().InDelta(12.0, drifts[0].Balance, 1e-9)
+	s.Require().InDelta(10.0, drifts[0].LedgerBalance, 1e-9)
+	s.Require().InDelta(2.0, drifts[0].Drift, 1e-9)
+	s.Require().Equal(int64(1), drifts[0].EntryCount)
+	s.Require().NotNil(drifts[0].LastEntryAt)
+}
+
 // --- Concurrency ---
 
 func (s *UserRepoSuite) TestUpdateConcurrency() {
@@ -0,0 +1,309 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	dbent "github.com/Wei-Shaw/sub2api/ent"
+	"github.com/Wei-Shaw/sub2api/ent/webhookdelivery"
+	"github.com/Wei-Shaw/sub2api/ent/webhookendpoint"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+type webhookRepository struct {
+	client *dbent.Client
+	sql    sqlExecutor
+}
+
+// NewWebhookRepository 创建 Webhook 端点与投递记录仓库
+func NewWebhookRepository(client *dbent.Client, sqlDB *sql.DB) service.WebhookRepository {
+	return &webhookRepository{client: client, sql: sqlDB}
+}
+
+func (r *webhookRepository) ListEndpoints(ctx context.Context) ([]service.WebhookEndpoint, error) {
+	rows, err := r.client.WebhookEndpoint.Query().
+		Order(dbent.Asc(webhookendpoint.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]service.WebhookEndpoint, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *webhookEndpointEntityToService(row))
+	}
+	return out, nil
+}
+
+func (r *webhookRepository) GetEndpoint(ctx context.Context, id int64) (*service.WebhookEndpoint, error) {
+	row, err := r.client.WebhookEndpoint.Get(ctx, id)
+	if err != nil {
+		return nil, translatePersistenceError(err, service.ErrWebhookEndpointNotFound, nil)
+	}
+	return webhookEndpointEntityToService(row), nil
+}
+
+func (r *webhookRepository) CreateEndpoint(ctx context.Context, endpoint *service.WebhookEndpoint) error {
+	builder := r.client.WebhookEndpoint.Create().
+		SetName(endpoint.Name).
+		SetURL(endpoint.URL).
+		SetSecret(endpoint.Secret).
+		SetFormat(endpoint.Format).
+		SetEvents(endpoint.Events).
+		SetEnabled(endpoint.Enabled).
+		SetNillableDescription(endpoint.Description)
+
+	created, err := builder.Save(ctx)
+	if err != nil {
+		return err
+	}
+	endpoint.ID = created.ID
+	endpoint.CreatedAt = created.CreatedAt
+	endpoint.UpdatedAt = created.UpdatedAt
+	return nil
+}
+
+func (r *webhookRepository) UpdateEndpoint(ctx context.Context, endpoint *service.WebhookEndpoint) error {
+	builder := r.client.WebhookEndpoint.UpdateOneID(endpoint.ID).
+		SetName(endpoint.Name).
+		SetURL(endpoint.URL).
+		SetSecret(endpoint.Secret).
+		SetFormat(endpoint.Format).
+		SetEvents(endpoint.Events).
+		SetEnabled(endpoint.Enabled)
+	if endpoint.Description != nil {
+		builder.SetDescription(*endpoint.Description)
+	} else {
+		builder.ClearDescription()
+	}
+
+	updated, err := builder.Save(ctx)
+	if err != nil {
+		return translatePersistenceError(err, service.ErrWebhookEndpointNotFound, nil)
+	}
+	endpoint.UpdatedAt = updated.UpdatedAt
+	return nil
+}
+
+func (r *webhookRepository) DeleteEndpoint(ctx context.Context, id int64) error {
+	err := r.client.WebhookEndpoint.DeleteOneID(id).Exec(ctx)
+	return translatePersistenceError(err, service.ErrWebhookEndpointNotFound, nil)
+}
+
+func (r *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*service.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	builders := make([]*dbent.WebhookDeliveryCreate, 0, len(deliveries))
+	for _, d := range deliveries {
+		builders = append(builders, r.client.WebhookDelivery.Create().
+			SetEndpointID(d.EndpointID).
+			SetEventID(d.EventID).
+			SetEventType(d.EventType).
+			SetPayload(d.Payload).
+			SetStatus(d.Status).
+			SetAttempts(d.Attempts).
+			SetNillableNextAttemptAt(d.NextAttemptAt))
+	}
+	created, err := r.client.WebhookDelivery.CreateBulk(builders...).Save(ctx)
+	if err != nil {
+		return err
+	}
+	for i, row := range created {
+		deliveries[i].ID = row.ID
+		deliveries[i].CreatedAt = row.CreatedAt
+		deliveries[i].UpdatedAt = row.UpdatedAt
+	}
+	return nil
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, id int64) (*service.WebhookDelivery, error) {
+	row, err := r.client.WebhookDelivery.Get(ctx, id)
+	if err != nil {
+		return nil, translatePersistenceError(err, service.ErrWebhookDeliveryNotFound, nil)
+	}
+	return webhookDeliveryEntityToService(row), nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, params pagination.PaginationParams, filters service.WebhookDeliveryFilters) ([]service.WebhookDelivery, *pagination.PaginationResult, error) {
+	query := r.client.WebhookDelivery.Query()
+	if filters.EndpointID != nil {
+		query = query.Where(webhookdelivery.EndpointIDEQ(*filters.EndpointID))
+	}
+	if filters.Status != "" {
+		query = query.Where(webhookdelivery.StatusEQ(filters.Status))
+	}
+	if filters.EventType != "" {
+		query = query.Where(webhookdelivery.EventTypeEQ(filters.EventType))
+	}
+
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := query.
+		Order(dbent.Desc(webhookdelivery.FieldCreatedAt), dbent.Desc(webhookdelivery.FieldID)).
+		Offset(params.Offset()).
+		Limit(params.Limit()).
+		All(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := make([]service.WebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *webhookDeliveryEntityToService(row))
+	}
+	return out, paginationResultFromTotal(int64(total), params), nil
+}
+
+func (r *webhookRepository) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]service.WebhookDelivery, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	query := `
+		WITH due AS (
+			SELECT id
+			FROM webhook_deliveries
+			WHERE status = $1
+				AND next_attempt_at IS NOT NULL
+				AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at ASC
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries AS d
+		SET next_attempt_at = NOW() + ($3 * interval '1 second'),
+			updated_at = NOW()
+		FROM due
+		WHERE d.id = due.id
+		RETURNING d.id, d.endpoint_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+			d.next_attempt_at, d.last_status_code, d.last_error, d.delivered_at, d.created_at, d.updated_at
+	`
+	rows, err := r.sql.QueryContext(ctx, query, service.WebhookDeliveryStatusPending, limit, int64(lease.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	out := make([]service.WebhookDelivery, 0)
+	for rows.Next() {
+		var d service.WebhookDelivery
+		var nextAttemptAt sql.NullTime
+		var lastStatusCode sql.NullInt64
+		var lastError sql.NullString
+		var deliveredAt sql.NullTime
+		if err := rows.Scan(
+			&d.ID,
+			&d.EndpointID,
+			&d.EventID,
+			&d.EventType,
+			&d.Payload,
+			&d.Status,
+			&d.Attempts,
+			&nextAttemptAt,
+			&lastStatusCode,
+			&lastError,
+			&deliveredAt,
+			&d.CreatedAt,
+			&d.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if nextAttemptAt.Valid {
+			d.NextAttemptAt = &nextAttemptAt.Time
+		}
+		if lastStatusCode.Valid {
+			v := int(lastStatusCode.Int64)
+			d.LastStatusCode = &v
+		}
+		if lastError.Valid {
+			d.LastError = &lastError.String
+		}
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.Time
+		}
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *webhookRepository) UpdateDeliveryResult(ctx context.Context, delivery *service.WebhookDelivery) error {
+	builder := r.client.WebhookDelivery.UpdateOneID(delivery.ID).
+		SetStatus(delivery.Status).
+		SetAttempts(delivery.Attempts)
+	if delivery.NextAttemptAt != nil {
+		builder.SetNextAttemptAt(*delivery.NextAttemptAt)
+	} else {
+		builder.ClearNextAttemptAt()
+	}
+	if delivery.LastStatusCode != nil {
+		builder.SetLastStatusCode(*delivery.LastStatusCode)
+	}
+	if delivery.LastError != nil {
+		builder.SetLastError(*delivery.LastError)
+	} else {
+		builder.ClearLastError()
+	}
+	if delivery.DeliveredAt != nil {
+		builder.SetDeliveredAt(*delivery.DeliveredAt)
+	}
+	_, err := builder.Save(ctx)
+	return translatePersistenceError(err, service.ErrWebhookDeliveryNotFound, nil)
+}
+
+func (r *webhookRepository) ResetDelivery(ctx context.Context, id int64) error {
+	_, err := r.client.WebhookDelivery.UpdateOneID(id).
+		SetStatus(service.WebhookDeliveryStatusPending).
+		SetAttempts(0).
+		SetNextAttemptAt(time.Now()).
+		Save(ctx)
+	return translatePersistenceError(err, service.ErrWebhookDeliveryNotFound, nil)
+}
+
+func (r *webhookRepository) DeleteDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	n, err := r.client.WebhookDelivery.Delete().
+		Where(webhookdelivery.CreatedAtLT(cutoff)).
+		Exec(ctx)
+	return int64(n), err
+}
+
+func webhookEndpointEntityToService(row *dbent.WebhookEndpoint) *service.WebhookEndpoint {
+	events := row.Events
+	if events == nil {
+		events = []string{}
+	}
+	return &service.WebhookEndpoint{
+		ID:          row.ID,
+		Name:        row.Name,
+		URL:         row.URL,
+		Secret:      row.Secret,
+		Format:      row.Format,
+		Events:      events,
+		Enabled:     row.Enabled,
+		Description: row.Description,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
+
+func webhookDeliveryEntityToService(row *dbent.WebhookDelivery) *service.WebhookDelivery {
+	return &service.WebhookDelivery{
+		ID:             row.ID,
+		EndpointID:     row.EndpointID,
+		EventID:        row.EventID,
+		EventType:      row.EventType,
+		Payload:        row.Payload,
+		Status:         row.Status,
+		Attempts:       row.Attempts,
+		NextAttemptAt:  row.NextAttemptAt,
+		LastStatusCode: row.LastStatusCode,
+		LastError:      row.LastError,
+		DeliveredAt:    row.DeliveredAt,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+}
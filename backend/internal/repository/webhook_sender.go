@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/httpclient"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+)
+
+const webhookResponseMaxBytes = 1024
+
+type webhookSender struct {
+	httpClient *http.Client
+}
+
+// NewWebhookSender 创建 Webhook HTTP 发送器
+// 始终校验解析后的 IP，防止 DNS Rebinding 指向内网（与 URL 白名单配置无关）
+func NewWebhookSender(cfg *config.Config) service.WebhookSender {
+	timeout := 10 * time.Second
+	if cfg != nil && cfg.Webhook.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.Webhook.TimeoutSeconds) * time.Second
+	}
+	client, err := httpclient.GetClient(httpclient.Options{
+		Timeout:            timeout,
+		ValidateResolvedIP: true,
+	})
+	if err != nil {
+		client = &http.Client{Timeout: timeout}
+	}
+	return &webhookSender{httpClient: client}
+}
+
+func (s *webhookSender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", fmt.Errorf("create request: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("send request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseMaxBytes))
+	return resp.StatusCode, string(respBody), nil
+}
@@ -68,14 +68,22 @@ var ProviderSet = wire.NewSet(
 	NewUserAttributeValueRepository,
 	NewUserGroupRateRepository,
 	NewErrorPassthroughRepository,
+	NewWebhookRepository,
+	NewMessageBatchRepository,
+	NewAdminAuditLogRepository,
+	NewBalanceLedgerRepository,
+	NewCredentialCipher,
+	NewAccountCredentialRepository,
 
 	// Cache implementations
 	NewGatewayCache,
+	NewDigestSessionCache,
 	NewBillingCache,
 	NewAPIKeyCache,
 	NewTempUnschedCache,
 	NewTimeoutCounterCache,
 	ProvideConcurrencyCache,
+	ProvideFairQueueCache,
 	ProvideSessionLimitCache,
 	NewDashboardCache,
 	NewEmailCache,
@@ -89,6 +97,8 @@ var ProviderSet = wire.NewSet(
 	NewTotpCache,
 	NewRefreshTokenCache,
 	NewErrorPassthroughCache,
+	NewAPIKeyRateLimitCache,
+	NewResponseCache,
 
 	// Encryptors
 	NewAESEncryptor,
@@ -104,6 +114,7 @@ var ProviderSet = wire.NewSet(
 	NewOpenAIOAuthClient,
 	NewGeminiOAuthClient,
 	NewGeminiCliCodeAssistClient,
+	NewWebhookSender,
 
 	ProvideEnt,
 	ProvideSQLDB,
@@ -78,6 +78,7 @@ func TestAPIContracts(t *testing.T) {
 					"id": 100,
 					"user_id": 1,
 					"key": "sk_custom_1234567890",
+					"key_prefix": "sk_custo",
 					"name": "Key One",
 					"group_id": null,
 					"status": "active",
@@ -86,6 +87,9 @@ func TestAPIContracts(t *testing.T) {
 					"quota": 0,
 					"quota_used": 0,
 					"expires_at": null,
+					"rpm_limit": 0,
+					"tpm_limit": 0,
+					"daily_request_limit": 0,
 					"created_at": "2025-01-02T03:04:05Z",
 					"updated_at": "2025-01-02T03:04:05Z"
 				}
@@ -116,7 +120,7 @@ func TestAPIContracts(t *testing.T) {
 						{
 							"id": 100,
 							"user_id": 1,
-							"key": "sk_custom_1234567890",
+							"key_prefix": "sk_custo",
 							"name": "Key One",
 							"group_id": null,
 							"status": "active",
@@ -125,6 +129,9 @@ func TestAPIContracts(t *testing.T) {
 							"quota": 0,
 							"quota_used": 0,
 							"expires_at": null,
+							"rpm_limit": 0,
+							"tpm_limit": 0,
+							"daily_request_limit": 0,
 							"created_at": "2025-01-02T03:04:05Z",
 							"updated_at": "2025-01-02T03:04:05Z"
 						}
@@ -187,6 +194,9 @@ func TestAPIContracts(t *testing.T) {
 						"claude_code_only": false,
 						"fallback_group_id": null,
 						"fallback_group_id_on_invalid_request": null,
+						"default_rpm_limit": 0,
+						"default_tpm_limit": 0,
+						"default_daily_request_limit": 0,
 						"created_at": "2025-01-02T03:04:05Z",
 						"updated_at": "2025-01-02T03:04:05Z"
 					}
@@ -396,6 +406,7 @@ func TestAPIContracts(t *testing.T) {
 						"actual_cost": 0.5,
 						"rate_multiplier": 1,
 						"billing_type": 0,
+							"response_cache_hit": false,
 							"stream": true,
 							"duration_ms": 100,
 							"first_token_ms": 50,
@@ -755,6 +766,14 @@ func (r *stubUserRepo) DeductBalance(ctx context.Context, id int64, amount float
 	return errors.New("not implemented")
 }
 
+func (r *stubUserRepo) ApplyBalanceChange(ctx context.Context, entry *service.BalanceLedgerEntry) error {
+	return errors.New("not implemented")
+}
+
+func (r *stubUserRepo) SetBalance(ctx context.Context, entry *service.BalanceLedgerEntry, balance float64) error {
+	return errors.New("not implemented")
+}
+
 func (r *stubUserRepo) UpdateConcurrency(ctx context.Context, id int64, amount int) error {
 	return errors.New("not implemented")
 }
@@ -948,6 +967,10 @@ func (s *stubAccountRepo) ListActive(ctx context.Context) ([]service.Account, er
 	return nil, errors.New("not implemented")
 }
 
+func (s *stubAccountRepo) ListActiveWithSchedule(ctx context.Context) ([]service.Account, error) {
+	return nil, errors.New("not implemented")
+}
+
 func (s *stubAccountRepo) ListByPlatform(ctx context.Context, platform string) ([]service.Account, error) {
 	return nil, errors.New("not implemented")
 }
@@ -1264,7 +1287,7 @@ type stubApiKeyRepo struct {
 
 	nextID int64
 	byID   map[int64]*service.APIKey
-	byKey  map[string]*service.APIKey
+	byKey  map[string]int64 // 明文 key -> id，仅用于模拟哈希查找
 }
 
 func newStubApiKeyRepo(now time.Time) *stubApiKeyRepo {
@@ -1272,7 +1295,7 @@ func newStubApiKeyRepo(now time.Time) *stubApiKeyRepo {
 		now:    now,
 		nextID: 100,
 		byID:   make(map[int64]*service.APIKey),
-		byKey:  make(map[string]*service.APIKey),
+		byKey:  make(map[string]int64),
 	}
 }
 
@@ -1281,8 +1304,18 @@ func (r *stubApiKeyRepo) MustSeed(key *service.APIKey) {
 		return
 	}
 	clone := *key
+	r.store(&clone)
+}
+
+// store 模拟真实仓库：只保留前缀，不保存明文
+func (r *stubApiKeyRepo) store(key *service.APIKey) {
+	if key.Key != "" {
+		key.KeyPrefix = service.APIKeyPrefix(key.Key)
+		r.byKey[key.Key] = key.ID
+	}
+	clone := *key
+	clone.Key = ""
 	r.byID[clone.ID] = &clone
-	r.byKey[clone.Key] = &clone
 }
 
 func (r *stubApiKeyRepo) Create(ctx context.Context, key *service.APIKey) error {
@@ -1299,9 +1332,7 @@ func (r *stubApiKeyRepo) Create(ctx context.Context, key *service.APIKey) error
 	if key.UpdatedAt.IsZero() {
 		key.UpdatedAt = r.now
 	}
-	clone := *key
-	r.byID[clone.ID] = &clone
-	r.byKey[clone.Key] = &clone
+	r.store(key)
 	return nil
 }
 
@@ -1314,16 +1345,16 @@ func (r *stubApiKeyRepo) GetByID(ctx context.Context, id int64) (*service.APIKey
 	return &clone, nil
 }
 
-func (r *stubApiKeyRepo) GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
+func (r *stubApiKeyRepo) GetKeyPrefixAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
 	key, ok := r.byID[id]
 	if !ok {
 		return "", 0, service.ErrAPIKeyNotFound
 	}
-	return key.Key, key.UserID, nil
+	return key.KeyPrefix, key.UserID, nil
 }
 
 func (r *stubApiKeyRepo) GetByKey(ctx context.Context, key string) (*service.APIKey, error) {
-	found, ok := r.byKey[key]
+	found, ok := r.byID[r.byKey[key]]
 	if !ok {
 		return nil, service.ErrAPIKeyNotFound
 	}
@@ -1331,8 +1362,8 @@ func (r *stubApiKeyRepo) GetByKey(ctx context.Context, key string) (*service.API
 	return &clone, nil
 }
 
-func (r *stubApiKeyRepo) GetByKeyForAuth(ctx context.Context, key string) (*service.APIKey, error) {
-	return r.GetByKey(ctx, key)
+func (r *stubApiKeyRepo) ListByKeyPrefixForAuth(ctx context.Context, prefix string) ([]service.APIKey, error) {
+	return nil, errors.New("not implemented")
 }
 
 func (r *stubApiKeyRepo) Update(ctx context.Context, key *service.APIKey) error {
@@ -1347,17 +1378,15 @@ func (r *stubApiKeyRepo) Update(ctx context.Context, key *service.APIKey) error
 	}
 	clone := *key
 	r.byID[clone.ID] = &clone
-	r.byKey[clone.Key] = &clone
 	return nil
 }
 
 func (r *stubApiKeyRepo) Delete(ctx context.Context, id int64) error {
-	key, ok := r.byID[id]
+	_, ok := r.byID[id]
 	if !ok {
 		return service.ErrAPIKeyNotFound
 	}
 	delete(r.byID, id)
-	delete(r.byKey, key.Key)
 	return nil
 }
 
@@ -1429,7 +1458,7 @@ func (r *stubApiKeyRepo) CountByUserID(ctx context.Context, userID int64) (int64
 }
 
 func (r *stubApiKeyRepo) ExistsByKey(ctx context.Context, key string) (bool, error) {
-	_, ok := r.byKey[key]
+	_, ok := r.byID[r.byKey[key]]
 	return ok, nil
 }
 
@@ -1449,11 +1478,11 @@ func (r *stubApiKeyRepo) CountByGroupID(ctx context.Context, groupID int64) (int
 	return 0, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) ListKeysByUserID(ctx context.Context, userID int64) ([]string, error) {
+func (r *stubApiKeyRepo) ListKeyPrefixesByUserID(ctx context.Context, userID int64) ([]string, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error) {
+func (r *stubApiKeyRepo) ListKeyPrefixesByGroupID(ctx context.Context, groupID int64) ([]string, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -1679,6 +1708,10 @@ func (r *stubUsageLogRepo) GetStatsWithFilters(ctx context.Context, filters usag
 	return nil, errors.New("not implemented")
 }
 
+func (r *stubUsageLogRepo) StreamWithFilters(ctx context.Context, filters usagestats.UsageLogFilters, fn func(*service.UsageLog) error) error {
+	return errors.New("not implemented")
+}
+
 type stubSettingRepo struct {
 	all map[string]string
 }
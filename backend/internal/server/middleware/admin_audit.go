@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ip"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const adminAuditWriteTimeout = 3 * time.Second
+
+// NewAdminAuditMiddleware 创建管理后台审计中间件
+func NewAdminAuditMiddleware(auditService *service.AdminAuditService) AdminAuditMiddleware {
+	return AdminAuditMiddleware(adminAudit(auditService))
+}
+
+// adminAudit 记录所有修改类的管理 API 调用（需挂在 adminAuth 之后，以便读取操作者身份）。
+// 请求 context 中会挂载差异收集器，AdminService/SettingService 在修改实体时写入脱敏后的前后差异；
+// 读请求仅在处理器显式记录了敏感操作（如导出明文凭证）时写入审计日志。
+func adminAudit(auditService *service.AdminAuditService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if auditService == nil {
+			c.Next()
+			return
+		}
+
+		ctx, recorder := service.WithAdminAuditRecorder(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		changes := recorder.Changes()
+		if !isMutatingMethod(c.Request.Method) && len(changes) == 0 {
+			return
+		}
+
+		entry := &service.AdminAuditLog{
+			Method:     c.Request.Method,
+			Route:      c.FullPath(),
+			Path:       c.Request.URL.Path,
+			StatusCode: c.Writer.Status(),
+			TargetType: adminAuditTargetType(c.FullPath()),
+			Changes:    changes,
+			IPAddress:  ip.GetClientIP(c),
+		}
+		if subject, ok := GetAuthSubjectFromContext(c); ok {
+			actorID := subject.UserID
+			entry.ActorUserID = &actorID
+		}
+		if method, ok := c.Get("auth_method"); ok {
+			entry.AuthMethod, _ = method.(string)
+		}
+		if targetID := adminAuditTargetID(c, entry.Changes); targetID != "" {
+			entry.TargetID = &targetID
+		}
+		if ua := c.Request.UserAgent(); ua != "" {
+			entry.UserAgent = &ua
+		}
+
+		writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), adminAuditWriteTimeout)
+		defer cancel()
+		auditService.Record(writeCtx, entry)
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// adminAuditTargetType 取 /admin/ 之后的第一段路径作为目标类型，例如 /api/v1/admin/users/:id/balance -> users
+func adminAuditTargetType(fullPath string) string {
+	idx := strings.Index(fullPath, "/admin/")
+	if idx < 0 {
+		return ""
+	}
+	rest := fullPath[idx+len("/admin/"):]
+	if slash := strings.Index(rest, "/"); slash >= 0 {
+		rest = rest[:slash]
+	}
+	return rest
+}
+
+// adminAuditTargetID 优先使用路由参数 :id；创建类请求没有路由参数时使用唯一一条差异的实体 ID
+func adminAuditTargetID(c *gin.Context, changes []service.AdminAuditChange) string {
+	if id := c.Param("id"); id != "" {
+		return id
+	}
+	if len(changes) == 1 && changes[0].EntityID > 0 {
+		return strconv.FormatInt(changes[0].EntityID, 10)
+	}
+	return ""
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type auditLogRepoStub struct {
+	logs []*service.AdminAuditLog
+}
+
+func (r *auditLogRepoStub) Create(ctx context.Context, log *service.AdminAuditLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *auditLogRepoStub) List(ctx context.Context, params pagination.PaginationParams, filters service.AdminAuditLogFilters) ([]service.AdminAuditLog, *pagination.PaginationResult, error) {
+	return nil, &pagination.PaginationResult{}, nil
+}
+
+func newAdminAuditTestRouter(repo *auditLogRepoStub) *gin.Engine {
+	r := gin.New()
+	admin := r.Group("/api/v1/admin")
+	admin.Use(func(c *gin.Context) {
+		c.Set(string(ContextKeyUser), AuthSubject{UserID: 42})
+		c.Set("auth_method", "admin_api_key")
+		c.Next()
+	}, gin.HandlerFunc(NewAdminAuditMiddleware(service.NewAdminAuditService(repo))))
+	admin.GET("/users/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	admin.PUT("/users/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	admin.POST("/groups", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	admin.GET("/accounts/data", func(c *gin.Context) {
+		service.RecordAdminAuditAction(c.Request.Context(), "account", 7, service.AdminAuditActionExportCredentials)
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestAdminAudit_RecordsMutatingRequests(t *testing.T) {
+	repo := &auditLogRepoStub{}
+	r := newAdminAuditTestRouter(repo)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/admin/users/9", nil)
+	req.Header.Set("User-Agent", "audit-test")
+	req.RemoteAddr = "203.0.113.5:1234"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Len(t, repo.logs, 1)
+	entry := repo.logs[0]
+	require.Equal(t, http.MethodPut, entry.Method)
+	require.Equal(t, "/api/v1/admin/users/:id", entry.Route)
+	require.Equal(t, "/api/v1/admin/users/9", entry.Path)
+	require.Equal(t, "users", entry.TargetType)
+	require.NotNil(t, entry.TargetID)
+	require.Equal(t, "9", *entry.TargetID)
+	require.NotNil(t, entry.ActorUserID)
+	require.Equal(t, int64(42), *entry.ActorUserID)
+	require.Equal(t, "admin_api_key", entry.AuthMethod)
+	require.Equal(t, http.StatusOK, entry.StatusCode)
+	require.Equal(t, "203.0.113.5", entry.IPAddress)
+	require.NotNil(t, entry.UserAgent)
+}
+
+func TestAdminAudit_SkipsReadRequests(t *testing.T) {
+	repo := &auditLogRepoStub{}
+	r := newAdminAuditTestRouter(repo)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/users/9", nil))
+	require.Empty(t, repo.logs)
+}
+
+func TestAdminAudit_RecordsReadRequestsWithSensitiveAction(t *testing.T) {
+	repo := &auditLogRepoStub{}
+	r := newAdminAuditTestRouter(repo)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/accounts/data?include_credentials=true", nil))
+	require.Len(t, repo.logs, 1)
+	entry := repo.logs[0]
+	require.Equal(t, http.MethodGet, entry.Method)
+	require.Equal(t, "accounts", entry.TargetType)
+	require.Len(t, entry.Changes, 1)
+	require.Equal(t, service.AdminAuditActionExportCredentials, entry.Changes[0].Action)
+	require.Equal(t, int64(7), entry.Changes[0].EntityID)
+}
+
+func TestAdminAuditTargetType(t *testing.T) {
+	require.Equal(t, "users", adminAuditTargetType("/api/v1/admin/users/:id/balance"))
+	require.Equal(t, "settings", adminAuditTargetType("/api/v1/admin/settings"))
+	require.Equal(t, "", adminAuditTargetType("/api/v1/keys"))
+}
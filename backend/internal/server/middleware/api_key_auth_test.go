@@ -48,12 +48,11 @@ func TestSimpleModeBypassesQuotaCheck(t *testing.T) {
 	apiKey.GroupID = &group.ID
 
 	apiKeyRepo := &stubApiKeyRepo{
-		getByKey: func(ctx context.Context, key string) (*service.APIKey, error) {
-			if key != apiKey.Key {
-				return nil, service.ErrAPIKeyNotFound
+		listByKeyPrefix: func(ctx context.Context, prefix string) ([]service.APIKey, error) {
+			if prefix != service.APIKeyPrefix(apiKey.Key) {
+				return nil, nil
 			}
-			clone := *apiKey
-			return &clone, nil
+			return hashedAuthCandidates(apiKey.Key, *apiKey), nil
 		},
 	}
 
@@ -140,19 +139,18 @@ func TestAPIKeyAuthSetsGroupContext(t *testing.T) {
 	apiKey.GroupID = &group.ID
 
 	apiKeyRepo := &stubApiKeyRepo{
-		getByKey: func(ctx context.Context, key string) (*service.APIKey, error) {
-			if key != apiKey.Key {
-				return nil, service.ErrAPIKeyNotFound
+		listByKeyPrefix: func(ctx context.Context, prefix string) ([]service.APIKey, error) {
+			if prefix != service.APIKeyPrefix(apiKey.Key) {
+				return nil, nil
 			}
-			clone := *apiKey
-			return &clone, nil
+			return hashedAuthCandidates(apiKey.Key, *apiKey), nil
 		},
 	}
 
 	cfg := &config.Config{RunMode: config.RunModeSimple}
 	apiKeyService := service.NewAPIKeyService(apiKeyRepo, nil, nil, nil, nil, nil, cfg)
 	router := gin.New()
-	router.Use(gin.HandlerFunc(NewAPIKeyAuthMiddleware(apiKeyService, nil, cfg)))
+	router.Use(gin.HandlerFunc(NewAPIKeyAuthMiddleware(apiKeyService, nil, nil, cfg)))
 	router.GET("/t", func(c *gin.Context) {
 		groupFromCtx, ok := c.Request.Context().Value(ctxkey.Group).(*service.Group)
 		if !ok || groupFromCtx == nil || groupFromCtx.ID != group.ID {
@@ -198,19 +196,18 @@ func TestAPIKeyAuthOverwritesInvalidContextGroup(t *testing.T) {
 	apiKey.GroupID = &group.ID
 
 	apiKeyRepo := &stubApiKeyRepo{
-		getByKey: func(ctx context.Context, key string) (*service.APIKey, error) {
-			if key != apiKey.Key {
-				return nil, service.ErrAPIKeyNotFound
+		listByKeyPrefix: func(ctx context.Context, prefix string) ([]service.APIKey, error) {
+			if prefix != service.APIKeyPrefix(apiKey.Key) {
+				return nil, nil
 			}
-			clone := *apiKey
-			return &clone, nil
+			return hashedAuthCandidates(apiKey.Key, *apiKey), nil
 		},
 	}
 
 	cfg := &config.Config{RunMode: config.RunModeSimple}
 	apiKeyService := service.NewAPIKeyService(apiKeyRepo, nil, nil, nil, nil, nil, cfg)
 	router := gin.New()
-	router.Use(gin.HandlerFunc(NewAPIKeyAuthMiddleware(apiKeyService, nil, cfg)))
+	router.Use(gin.HandlerFunc(NewAPIKeyAuthMiddleware(apiKeyService, nil, nil, cfg)))
 
 	invalidGroup := &service.Group{
 		ID:       group.ID,
@@ -237,7 +234,7 @@ func TestAPIKeyAuthOverwritesInvalidContextGroup(t *testing.T) {
 
 func newAuthTestRouter(apiKeyService *service.APIKeyService, subscriptionService *service.SubscriptionService, cfg *config.Config) *gin.Engine {
 	router := gin.New()
-	router.Use(gin.HandlerFunc(NewAPIKeyAuthMiddleware(apiKeyService, subscriptionService, cfg)))
+	router.Use(gin.HandlerFunc(NewAPIKeyAuthMiddleware(apiKeyService, subscriptionService, nil, cfg)))
 	router.GET("/t", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"ok": true})
 	})
@@ -245,7 +242,7 @@ func newAuthTestRouter(apiKeyService *service.APIKeyService, subscriptionService
 }
 
 type stubApiKeyRepo struct {
-	getByKey func(ctx context.Context, key string) (*service.APIKey, error)
+	listByKeyPrefix func(ctx context.Context, prefix string) ([]service.APIKey, error)
 }
 
 func (r *stubApiKeyRepo) Create(ctx context.Context, key *service.APIKey) error {
@@ -256,19 +253,19 @@ func (r *stubApiKeyRepo) GetByID(ctx context.Context, id int64) (*service.APIKey
 	return nil, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
+func (r *stubApiKeyRepo) GetKeyPrefixAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
 	return "", 0, errors.New("not implemented")
 }
 
 func (r *stubApiKeyRepo) GetByKey(ctx context.Context, key string) (*service.APIKey, error) {
-	if r.getByKey != nil {
-		return r.getByKey(ctx, key)
-	}
 	return nil, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) GetByKeyForAuth(ctx context.Context, key string) (*service.APIKey, error) {
-	return r.GetByKey(ctx, key)
+func (r *stubApiKeyRepo) ListByKeyPrefixForAuth(ctx context.Context, prefix string) ([]service.APIKey, error) {
+	if r.listByKeyPrefix != nil {
+		return r.listByKeyPrefix(ctx, prefix)
+	}
+	return nil, errors.New("not implemented")
 }
 
 func (r *stubApiKeyRepo) Update(ctx context.Context, key *service.APIKey) error {
@@ -311,11 +308,11 @@ func (r *stubApiKeyRepo) CountByGroupID(ctx context.Context, groupID int64) (int
 	return 0, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) ListKeysByUserID(ctx context.Context, userID int64) ([]string, error) {
+func (r *stubApiKeyRepo) ListKeyPrefixesByUserID(ctx context.Context, userID int64) ([]string, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (r *stubApiKeyRepo) ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error) {
+func (r *stubApiKeyRepo) ListKeyPrefixesByGroupID(ctx context.Context, groupID int64) ([]string, error) {
 	return nil, errors.New("not implemented")
 }
 
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/googleapi"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// apiKeyRateLimitErrorFormat 429 响应格式（与各平台官方 API 保持一致）
+type apiKeyRateLimitErrorFormat int
+
+const (
+	apiKeyRateLimitFormatAnthropic apiKeyRateLimitErrorFormat = iota
+	apiKeyRateLimitFormatOpenAI
+	apiKeyRateLimitFormatGoogle
+)
+
+// apiKeyRateLimitFormatForPath 根据请求路径选择错误格式：OpenAI 兼容端点使用 OpenAI 格式，其余使用 Anthropic 格式
+func apiKeyRateLimitFormatForPath(path string) apiKeyRateLimitErrorFormat {
+	for _, suffix := range []string{"/responses", "/chat/completions", "/embeddings"} {
+		if strings.HasSuffix(path, suffix) {
+			return apiKeyRateLimitFormatOpenAI
+		}
+	}
+	return apiKeyRateLimitFormatAnthropic
+}
+
+// enforceAPIKeyRateLimit 检查 API Key 的 RPM/TPM/每日请求数限制，超限时写入 429 并中断请求。
+// 返回 false 表示请求已被中断。GET 请求（模型列表、用量查询）不计入限流。
+func enforceAPIKeyRateLimit(c *gin.Context, rateLimitService *service.APIKeyRateLimitService, apiKey *service.APIKey, format apiKeyRateLimitErrorFormat) bool {
+	if rateLimitService == nil || c.Request.Method == http.MethodGet {
+		return true
+	}
+	decision := rateLimitService.Acquire(c.Request.Context(), apiKey)
+	if decision.Allowed {
+		return true
+	}
+	abortWithAPIKeyRateLimit(c, decision, format)
+	return false
+}
+
+func abortWithAPIKeyRateLimit(c *gin.Context, decision *service.APIKeyRateLimitDecision, format apiKeyRateLimitErrorFormat) {
+	retryAfter := max(int(math.Ceil(decision.RetryAfter.Seconds())), 1)
+	c.Header("retry-after", strconv.Itoa(retryAfter))
+
+	message := apiKeyRateLimitMessage(decision)
+	switch format {
+	case apiKeyRateLimitFormatOpenAI:
+		setOpenAIRateLimitHeaders(c, decision)
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": gin.H{
+				"type":    "rate_limit_error",
+				"code":    "rate_limit_exceeded",
+				"message": message,
+			},
+		})
+	case apiKeyRateLimitFormatGoogle:
+		setOpenAIRateLimitHeaders(c, decision)
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error": gin.H{
+				"code":    http.StatusTooManyRequests,
+				"message": message,
+				"status":  googleapi.HTTPStatusToGoogleStatus(http.StatusTooManyRequests),
+				"details": []gin.H{{
+					"@type":      "type.googleapis.com/google.rpc.RetryInfo",
+					"retryDelay": strconv.Itoa(retryAfter) + "s",
+				}},
+			},
+		})
+	default:
+		setAnthropicRateLimitHeaders(c, decision)
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"type": "error",
+			"error": gin.H{
+				"type":    "rate_limit_error",
+				"message": message,
+			},
+		})
+	}
+	c.Abort()
+}
+
+func apiKeyRateLimitMessage(decision *service.APIKeyRateLimitDecision) string {
+	switch decision.Exceeded {
+	case service.APIKeyRateLimitTPM:
+		return fmt.Sprintf("API key rate limit exceeded: %d tokens per minute", decision.Limits.TPM)
+	case service.APIKeyRateLimitDaily:
+		return fmt.Sprintf("API key daily request limit exceeded: %d requests per day", decision.Limits.DailyRequests)
+	default:
+		return fmt.Sprintf("API key rate limit exceeded: %d requests per minute", decision.Limits.RPM)
+	}
+}
+
+// apiKeyRateLimitWindowState 单个维度（请求数/token 数）的限额、剩余与重置时间
+type apiKeyRateLimitWindowState struct {
+	limit     int
+	remaining int
+	reset     time.Duration
+}
+
+// requestWindowState 请求维度：超出每日上限或未配置 RPM 时展示每日限额，否则展示 RPM
+func requestWindowState(decision *service.APIKeyRateLimitDecision) (apiKeyRateLimitWindowState, bool) {
+	limits, usage := decision.Limits, decision.Usage
+	if decision.Exceeded == service.APIKeyRateLimitDaily || (limits.RPM <= 0 && limits.DailyRequests > 0) {
+		return apiKeyRateLimitWindowState{
+			limit:     limits.DailyRequests,
+			remaining: max(limits.DailyRequests-usage.DailyRequests, 0),
+			reset:     max(decision.RetryAfter, usage.DailyReset),
+		}, true
+	}
+	if limits.RPM <= 0 {
+		return apiKeyRateLimitWindowState{}, false
+	}
+	state := apiKeyRateLimitWindowState{
+		limit:     limits.RPM,
+		remaining: max(limits.RPM-usage.Requests, 0),
+		reset:     usage.RequestsReset,
+	}
+	if decision.Exceeded == service.APIKeyRateLimitRPM {
+		state.reset = decision.RetryAfter
+	}
+	return state, true
+}
+
+func tokenWindowState(decision *service.APIKeyRateLimitDecision) (apiKeyRateLimitWindowState, bool) {
+	limits, usage := decision.Limits, decision.Usage
+	if limits.TPM <= 0 {
+		return apiKeyRateLimitWindowState{}, false
+	}
+	state := apiKeyRateLimitWindowState{
+		limit:     limits.TPM,
+		remaining: max(limits.TPM-usage.Tokens, 0),
+		reset:     usage.TokensReset,
+	}
+	if decision.Exceeded == service.APIKeyRateLimitTPM {
+		state.reset = decision.RetryAfter
+	}
+	return state, true
+}
+
+// setAnthropicRateLimitHeaders 设置 anthropic-ratelimit-* 响应头（reset 为 RFC 3339 时间）
+func setAnthropicRateLimitHeaders(c *gin.Context, decision *service.APIKeyRateLimitDecision) {
+	now := time.Now().UTC()
+	if state, ok := requestWindowState(decision); ok {
+		c.Header("anthropic-ratelimit-requests-limit", strconv.Itoa(state.limit))
+		c.Header("anthropic-ratelimit-requests-remaining", strconv.Itoa(state.remaining))
+		c.Header("anthropic-ratelimit-requests-reset", now.Add(state.reset).Format(time.RFC3339))
+	}
+	if state, ok := tokenWindowState(decision); ok {
+		c.Header("anthropic-ratelimit-tokens-limit", strconv.Itoa(state.limit))
+		c.Header("anthropic-ratelimit-tokens-remaining", strconv.Itoa(state.remaining))
+		c.Header("anthropic-ratelimit-tokens-reset", now.Add(state.reset).Format(time.RFC3339))
+	}
+}
+
+// setOpenAIRateLimitHeaders 设置 x-ratelimit-* 响应头（reset 为时长，如 "1s"、"6m0s"）
+func setOpenAIRateLimitHeaders(c *gin.Context, decision *service.APIKeyRateLimitDecision) {
+	if state, ok := requestWindowState(decision); ok {
+		c.Header("x-ratelimit-limit-requests", strconv.Itoa(state.limit))
+		c.Header("x-ratelimit-remaining-requests", strconv.Itoa(state.remaining))
+		c.Header("x-ratelimit-reset-requests", formatRateLimitReset(state.reset))
+	}
+	if state, ok := tokenWindowState(decision); ok {
+		c.Header("x-ratelimit-limit-tokens", strconv.Itoa(state.limit))
+		c.Header("x-ratelimit-remaining-tokens", strconv.Itoa(state.remaining))
+		c.Header("x-ratelimit-reset-tokens", formatRateLimitReset(state.reset))
+	}
+}
+
+func formatRateLimitReset(d time.Duration) string {
+	return max(d.Round(time.Second), time.Second).String()
+}
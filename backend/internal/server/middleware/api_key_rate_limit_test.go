@@ -0,0 +1,212 @@
+//go:build unit
+
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAPIKeyRateLimitCache struct {
+	decision *service.APIKeyRateLimitDecision
+	err      error
+	calls    int
+}
+
+func (s *stubAPIKeyRateLimitCache) Acquire(ctx context.Context, apiKeyID int64, limits service.APIKeyRateLimits, window, dailyTTL time.Duration) (*service.APIKeyRateLimitDecision, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	clone := *s.decision
+	return &clone, nil
+}
+
+func (s *stubAPIKeyRateLimitCache) AddTokens(ctx context.Context, apiKeyID int64, tokens int, window time.Duration) error {
+	return nil
+}
+
+func newRateLimitedAPIKeyService() *service.APIKeyService {
+	return newTestAPIKeyService(fakeAPIKeyRepo{
+		listByKeyPrefix: func(ctx context.Context, prefix string) ([]service.APIKey, error) {
+			return hashedAuthCandidates("k", service.APIKey{
+				ID:       1,
+				Status:   service.StatusActive,
+				RPMLimit: 10,
+				TPMLimit: 1000,
+				User: &service.User{
+					ID:      123,
+					Status:  service.StatusActive,
+					Balance: 10,
+				},
+			}), nil
+		},
+	})
+}
+
+func TestAPIKeyAuthRateLimitAnthropicFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cache := &stubAPIKeyRateLimitCache{decision: &service.APIKeyRateLimitDecision{
+		Exceeded:   service.APIKeyRateLimitRPM,
+		RetryAfter: 1500 * time.Millisecond,
+		Usage:      service.APIKeyRateLimitUsage{Requests: 10, Tokens: 200, TokensReset: 30 * time.Second},
+	}}
+	r := gin.New()
+	r.Use(gin.HandlerFunc(NewAPIKeyAuthMiddleware(newRateLimitedAPIKeyService(), nil, service.NewAPIKeyRateLimitService(cache), &config.Config{})))
+	r.POST("/v1/messages", func(c *gin.Context) { c.JSON(200, gin.H{"ok": true}) })
+	r.GET("/v1/models", func(c *gin.Context) { c.JSON(200, gin.H{"ok": true}) })
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+	req.Header.Set("x-api-key", "k")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "2", rec.Header().Get("retry-after"))
+	require.Equal(t, "10", rec.Header().Get("anthropic-ratelimit-requests-limit"))
+	require.Equal(t, "0", rec.Header().Get("anthropic-ratelimit-requests-remaining"))
+	require.NotEmpty(t, rec.Header().Get("anthropic-ratelimit-requests-reset"))
+	require.Equal(t, "1000", rec.Header().Get("anthropic-ratelimit-tokens-limit"))
+	require.Equal(t, "800", rec.Header().Get("anthropic-ratelimit-tokens-remaining"))
+
+	var resp struct {
+		Type  string `json:"type"`
+		Error struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "error", resp.Type)
+	require.Equal(t, "rate_limit_error", resp.Error.Type)
+	require.Contains(t, resp.Error.Message, "10 requests per minute")
+
+	// GET 请求不计入限流
+	req = httptest.NewRequest(http.MethodGet, "/v1/models", nil)
+	req.Header.Set("x-api-key", "k")
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, 1, cache.calls)
+}
+
+func TestAPIKeyAuthRateLimitOpenAIFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cache := &stubAPIKeyRateLimitCache{decision: &service.APIKeyRateLimitDecision{
+		Exceeded:   service.APIKeyRateLimitTPM,
+		RetryAfter: 20 * time.Second,
+		Usage:      service.APIKeyRateLimitUsage{Requests: 3, RequestsReset: 40 * time.Second, Tokens: 1200},
+	}}
+	r := gin.New()
+	r.Use(gin.HandlerFunc(NewAPIKeyAuthMiddleware(newRateLimitedAPIKeyService(), nil, service.NewAPIKeyRateLimitService(cache), &config.Config{})))
+	r.POST("/v1/chat/completions", func(c *gin.Context) { c.JSON(200, gin.H{"ok": true}) })
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/chat/completions", nil)
+	req.Header.Set("Authorization", "Bearer k")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "20", rec.Header().Get("retry-after"))
+	require.Equal(t, "10", rec.Header().Get("x-ratelimit-limit-requests"))
+	require.Equal(t, "7", rec.Header().Get("x-ratelimit-remaining-requests"))
+	require.Equal(t, "40s", rec.Header().Get("x-ratelimit-reset-requests"))
+	require.Equal(t, "1000", rec.Header().Get("x-ratelimit-limit-tokens"))
+	require.Equal(t, "0", rec.Header().Get("x-ratelimit-remaining-tokens"))
+	require.Equal(t, "20s", rec.Header().Get("x-ratelimit-reset-tokens"))
+
+	var resp struct {
+		Error struct {
+			Type    string `json:"type"`
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, "rate_limit_error", resp.Error.Type)
+	require.Equal(t, "rate_limit_exceeded", resp.Error.Code)
+	require.Contains(t, resp.Error.Message, "1000 tokens per minute")
+}
+
+func TestAPIKeyAuthGoogleRateLimitFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cache := &stubAPIKeyRateLimitCache{decision: &service.APIKeyRateLimitDecision{
+		Exceeded:   service.APIKeyRateLimitRPM,
+		RetryAfter: 5 * time.Second,
+	}}
+	r := gin.New()
+	r.Use(APIKeyAuthWithSubscriptionGoogle(newRateLimitedAPIKeyService(), nil, service.NewAPIKeyRateLimitService(cache), &config.Config{}))
+	r.POST("/v1beta/models/*modelAction", func(c *gin.Context) { c.JSON(200, gin.H{"ok": true}) })
+
+	req := httptest.NewRequest(http.MethodPost, "/v1beta/models/gemini-2.5-pro:generateContent", nil)
+	req.Header.Set("x-goog-api-key", "k")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "5", rec.Header().Get("retry-after"))
+	require.Equal(t, "10", rec.Header().Get("x-ratelimit-limit-requests"))
+	var resp googleErrorResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Equal(t, http.StatusTooManyRequests, resp.Error.Code)
+	require.Equal(t, "RESOURCE_EXHAUSTED", resp.Error.Status)
+}
+
+func TestAPIKeyAuthRateLimitFailOpen(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	cache := &stubAPIKeyRateLimitCache{err: errors.New("redis down")}
+	r := gin.New()
+	r.Use(gin.HandlerFunc(NewAPIKeyAuthMiddleware(newRateLimitedAPIKeyService(), nil, service.NewAPIKeyRateLimitService(cache), &config.Config{})))
+	r.POST("/v1/messages", func(c *gin.Context) { c.JSON(200, gin.H{"ok": true}) })
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+	req.Header.Set("x-api-key", "k")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, 1, cache.calls)
+}
+
+func TestAPIKeyRateLimitDailyHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	decision := &service.APIKeyRateLimitDecision{
+		Exceeded:   service.APIKeyRateLimitDaily,
+		RetryAfter: 2 * time.Hour,
+		Limits:     service.APIKeyRateLimits{RPM: 60, DailyRequests: 500},
+		Usage:      service.APIKeyRateLimitUsage{Requests: 1, DailyRequests: 500, DailyReset: 2 * time.Hour},
+	}
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	abortWithAPIKeyRateLimit(c, decision, apiKeyRateLimitFormatOpenAI)
+
+	require.Equal(t, "7200", rec.Header().Get("retry-after"))
+	require.Equal(t, "500", rec.Header().Get("x-ratelimit-limit-requests"))
+	require.Equal(t, "0", rec.Header().Get("x-ratelimit-remaining-requests"))
+	require.Equal(t, "2h0m0s", rec.Header().Get("x-ratelimit-reset-requests"))
+	require.Empty(t, rec.Header().Get("x-ratelimit-limit-tokens"))
+	require.Contains(t, rec.Body.String(), "500 requests per day")
+}
+
+func TestAPIKeyRateLimitFormatForPath(t *testing.T) {
+	require.Equal(t, apiKeyRateLimitFormatAnthropic, apiKeyRateLimitFormatForPath("/v1/messages"))
+	require.Equal(t, apiKeyRateLimitFormatAnthropic, apiKeyRateLimitFormatForPath("/antigravity/v1/messages"))
+	require.Equal(t, apiKeyRateLimitFormatOpenAI, apiKeyRateLimitFormatForPath("/v1/responses"))
+	require.Equal(t, apiKeyRateLimitFormatOpenAI, apiKeyRateLimitFormatForPath("/chat/completions"))
+	require.Equal(t, apiKeyRateLimitFormatOpenAI, apiKeyRateLimitFormatForPath("/v1/embeddings"))
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
+	"github.com/Wei-Shaw/sub2api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// GatewayMetrics 记录网关请求的 Prometheus 计数与耗时。
+//
+// 需放在 APIKeyAuth 之前：认证失败的请求同样计入（platform/group 为 unknown/none），
+// 认证成功后 API Key 已写入 gin.Context，c.Next() 返回后即可读取分组信息。
+func GatewayMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		platform := ""
+		groupID := metrics.GroupLabel(nil)
+		if apiKey, ok := GetAPIKeyFromContext(c); ok && apiKey != nil {
+			groupID = metrics.GroupLabel(apiKey.GroupID)
+			if apiKey.Group != nil {
+				platform = apiKey.Group.Platform
+			}
+		}
+		if forcePlatform, ok := GetForcePlatformFromContext(c); ok && forcePlatform != "" {
+			platform = forcePlatform
+		}
+		if platform == "" {
+			platform = guessPlatformFromPath(c.Request.URL.Path)
+		}
+		metrics.ObserveGatewayRequest(platform, groupID, c.Writer.Status(), time.Since(start))
+	}
+}
+
+func guessPlatformFromPath(path string) string {
+	p := strings.ToLower(path)
+	switch {
+	case strings.HasPrefix(p, "/antigravity/"):
+		return service.PlatformAntigravity
+	case strings.HasPrefix(p, "/v1beta/"):
+		return service.PlatformGemini
+	case strings.Contains(p, "/responses"):
+		return service.PlatformOpenAI
+	default:
+		return ""
+	}
+}
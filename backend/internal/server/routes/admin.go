@@ -13,9 +13,10 @@ func RegisterAdminRoutes(
 	v1 *gin.RouterGroup,
 	h *handler.Handlers,
 	adminAuth middleware.AdminAuthMiddleware,
+	adminAudit middleware.AdminAuditMiddleware,
 ) {
 	admin := v1.Group("/admin")
-	admin.Use(gin.HandlerFunc(adminAuth))
+	admin.Use(gin.HandlerFunc(adminAuth), gin.HandlerFunc(adminAudit))
 	{
 		// 仪表盘
 		registerDashboardRoutes(admin, h)
@@ -70,6 +71,18 @@ func RegisterAdminRoutes(
 
 		// 错误透传规则管理
 		registerErrorPassthroughRoutes(admin, h)
+
+		// 出站 Webhook 管理
+		registerWebhookRoutes(admin, h)
+
+		// 审计日志
+		registerAuditLogRoutes(admin, h)
+
+		// 余额流水对账
+		registerBalanceLedgerRoutes(admin, h)
+
+		// 调度决策解释（dry-run）
+		registerSchedulerRoutes(admin, h)
 	}
 }
 
@@ -79,6 +92,7 @@ func registerOpsRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		// Realtime ops signals
 		ops.GET("/concurrency", h.Admin.Ops.GetConcurrencyStats)
 		ops.GET("/user-concurrency", h.Admin.Ops.GetUserConcurrencyStats)
+		ops.GET("/fair-queue", h.Admin.Ops.GetFairQueueEntries)
 		ops.GET("/account-availability", h.Admin.Ops.GetAccountAvailability)
 		ops.GET("/realtime-traffic", h.Admin.Ops.GetRealtimeTrafficSummary)
 
@@ -144,12 +158,16 @@ func registerOpsRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		// Request drilldown (success + error)
 		ops.GET("/requests", h.Admin.Ops.ListRequestDetails)
 
+		// Sensitive content detections (group request policy redaction)
+		ops.GET("/redactions", h.Admin.Ops.ListRedactionLogs)
+
 		// Dashboard (vNext - raw path for MVP)
 		ops.GET("/dashboard/overview", h.Admin.Ops.GetDashboardOverview)
 		ops.GET("/dashboard/throughput-trend", h.Admin.Ops.GetDashboardThroughputTrend)
 		ops.GET("/dashboard/latency-histogram", h.Admin.Ops.GetDashboardLatencyHistogram)
 		ops.GET("/dashboard/error-trend", h.Admin.Ops.GetDashboardErrorTrend)
 		ops.GET("/dashboard/error-distribution", h.Admin.Ops.GetDashboardErrorDistribution)
+		ops.GET("/dashboard/traffic-shares", h.Admin.Ops.GetAccountTrafficShares)
 	}
 }
 
@@ -162,6 +180,7 @@ func registerDashboardRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		dashboard.GET("/models", h.Admin.Dashboard.GetModelStats)
 		dashboard.GET("/api-keys-trend", h.Admin.Dashboard.GetAPIKeyUsageTrend)
 		dashboard.GET("/users-trend", h.Admin.Dashboard.GetUserUsageTrend)
+		dashboard.GET("/response-cache", h.Admin.Dashboard.GetResponseCacheStats)
 		dashboard.POST("/users-usage", h.Admin.Dashboard.GetBatchUsersUsage)
 		dashboard.POST("/api-keys-usage", h.Admin.Dashboard.GetBatchAPIKeysUsage)
 		dashboard.POST("/aggregation/backfill", h.Admin.Dashboard.BackfillAggregation)
@@ -180,6 +199,7 @@ func registerUserManagementRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		users.GET("/:id/api-keys", h.Admin.User.GetUserAPIKeys)
 		users.GET("/:id/usage", h.Admin.User.GetUserUsage)
 		users.GET("/:id/balance-history", h.Admin.User.GetBalanceHistory)
+		users.GET("/:id/balance-ledger", h.Admin.BalanceLedger.ListByUser)
 
 		// User attribute values
 		users.GET("/:id/attributes", h.Admin.UserAttribute.GetUserAttributes)
@@ -223,6 +243,8 @@ func registerAccountRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 		accounts.GET("/:id/temp-unschedulable", h.Admin.Account.GetTempUnschedulable)
 		accounts.DELETE("/:id/temp-unschedulable", h.Admin.Account.ClearTempUnschedulable)
 		accounts.POST("/:id/schedulable", h.Admin.Account.SetSchedulable)
+		accounts.POST("/:id/drain", h.Admin.Account.StartDrain)
+		accounts.DELETE("/:id/drain", h.Admin.Account.CancelDrain)
 		accounts.GET("/:id/models", h.Admin.Account.GetAvailableModels)
 		accounts.POST("/batch", h.Admin.Account.BatchCreate)
 		accounts.GET("/data", h.Admin.Account.ExportData)
@@ -382,6 +404,7 @@ func registerUsageRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
 	{
 		usage.GET("", h.Admin.Usage.List)
 		usage.GET("/stats", h.Admin.Usage.Stats)
+		usage.GET("/export", h.Admin.Usage.Export)
 		usage.GET("/search-users", h.Admin.Usage.SearchUsers)
 		usage.GET("/search-api-keys", h.Admin.Usage.SearchAPIKeys)
 		usage.GET("/cleanup-tasks", h.Admin.Usage.ListCleanupTasks)
@@ -412,3 +435,40 @@ func registerErrorPassthroughRoutes(admin *gin.RouterGroup, h *handler.Handlers)
 		rules.DELETE("/:id", h.Admin.ErrorPassthrough.Delete)
 	}
 }
+
+func registerWebhookRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	webhooks := admin.Group("/webhooks")
+	{
+		webhooks.GET("/event-types", h.Admin.Webhook.ListEventTypes)
+		webhooks.GET("/deliveries", h.Admin.Webhook.ListDeliveries)
+		webhooks.POST("/deliveries/:id/retry", h.Admin.Webhook.RetryDelivery)
+		webhooks.GET("", h.Admin.Webhook.List)
+		webhooks.GET("/:id", h.Admin.Webhook.GetByID)
+		webhooks.POST("", h.Admin.Webhook.Create)
+		webhooks.PUT("/:id", h.Admin.Webhook.Update)
+		webhooks.DELETE("/:id", h.Admin.Webhook.Delete)
+		webhooks.POST("/:id/test", h.Admin.Webhook.Test)
+	}
+}
+
+func registerAuditLogRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	auditLogs := admin.Group("/audit-logs")
+	{
+		auditLogs.GET("", h.Admin.AuditLog.List)
+	}
+}
+
+func registerBalanceLedgerRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	ledger := admin.Group("/balance-ledger")
+	{
+		ledger.GET("/drift", h.Admin.BalanceLedger.GetDrift)
+		ledger.POST("/reconcile", h.Admin.BalanceLedger.Reconcile)
+	}
+}
+
+func registerSchedulerRoutes(admin *gin.RouterGroup, h *handler.Handlers) {
+	scheduler := admin.Group("/scheduler")
+	{
+		scheduler.POST("/explain", h.Admin.Scheduler.Explain)
+	}
+}
@@ -16,26 +16,45 @@ func RegisterGatewayRoutes(
 	apiKeyAuth middleware.APIKeyAuthMiddleware,
 	apiKeyService *service.APIKeyService,
 	subscriptionService *service.SubscriptionService,
+	apiKeyRateLimitService *service.APIKeyRateLimitService,
 	opsService *service.OpsService,
 	cfg *config.Config,
 ) {
 	bodyLimit := middleware.RequestBodyLimit(cfg.Gateway.MaxBodySize)
 	clientRequestID := middleware.ClientRequestID()
 	opsErrorLogger := handler.OpsErrorLoggerMiddleware(opsService)
+	// Prometheus 请求指标（metrics.enabled=false 时为空操作）
+	gatewayMetrics := gin.HandlerFunc(func(c *gin.Context) { c.Next() })
+	if cfg.Metrics.Enabled {
+		gatewayMetrics = middleware.GatewayMetrics()
+	}
 
 	// API网关（Claude API兼容）
 	gateway := r.Group("/v1")
 	gateway.Use(bodyLimit)
 	gateway.Use(clientRequestID)
 	gateway.Use(opsErrorLogger)
+	gateway.Use(gatewayMetrics)
 	gateway.Use(gin.HandlerFunc(apiKeyAuth))
 	{
 		gateway.POST("/messages", h.Gateway.Messages)
 		gateway.POST("/messages/count_tokens", h.Gateway.CountTokens)
+		// Anthropic Message Batches API（后台 worker 异步执行）
+		gateway.POST("/messages/batches", h.MessageBatches.Create)
+		gateway.GET("/messages/batches", h.MessageBatches.List)
+		gateway.GET("/messages/batches/:id", h.MessageBatches.Get)
+		gateway.POST("/messages/batches/:id/cancel", h.MessageBatches.Cancel)
+		gateway.GET("/messages/batches/:id/results", h.MessageBatches.Results)
 		gateway.GET("/models", h.Gateway.Models)
 		gateway.GET("/usage", h.Gateway.Usage)
 		// OpenAI Responses API
 		gateway.POST("/responses", h.OpenAIGateway.Responses)
+		// OpenAI Chat Completions API（按分组平台转换后复用 Messages/Responses 流程）
+		gateway.POST("/chat/completions", h.ChatCompletions.ChatCompletions)
+		// OpenAI Embeddings API（OpenAI API Key 账号透传 / Gemini batchEmbedContents）
+		gateway.POST("/embeddings", h.Embeddings.Embeddings)
+		// OpenAI Images API（Gemini/Antigravity 分组，转换为 generateContent 后复用 Gemini 原生流程）
+		gateway.POST("/images/generations", h.Images.Generations)
 	}
 
 	// Gemini 原生 API 兼容层（Gemini SDK/CLI 直连）
@@ -43,7 +62,8 @@ func RegisterGatewayRoutes(
 	gemini.Use(bodyLimit)
 	gemini.Use(clientRequestID)
 	gemini.Use(opsErrorLogger)
-	gemini.Use(middleware.APIKeyAuthWithSubscriptionGoogle(apiKeyService, subscriptionService, cfg))
+	gemini.Use(gatewayMetrics)
+	gemini.Use(middleware.APIKeyAuthWithSubscriptionGoogle(apiKeyService, subscriptionService, apiKeyRateLimitService, cfg))
 	{
 		gemini.GET("/models", h.Gateway.GeminiV1BetaListModels)
 		gemini.GET("/models/:model", h.Gateway.GeminiV1BetaGetModel)
@@ -52,7 +72,13 @@ func RegisterGatewayRoutes(
 	}
 
 	// OpenAI Responses API（不带v1前缀的别名）
-	r.POST("/responses", bodyLimit, clientRequestID, opsErrorLogger, gin.HandlerFunc(apiKeyAuth), h.OpenAIGateway.Responses)
+	r.POST("/responses", bodyLimit, clientRequestID, opsErrorLogger, gatewayMetrics, gin.HandlerFunc(apiKeyAuth), h.OpenAIGateway.Responses)
+	// OpenAI Chat Completions API（不带v1前缀的别名）
+	r.POST("/chat/completions", bodyLimit, clientRequestID, opsErrorLogger, gatewayMetrics, gin.HandlerFunc(apiKeyAuth), h.ChatCompletions.ChatCompletions)
+	// OpenAI Embeddings API（不带v1前缀的别名）
+	r.POST("/embeddings", bodyLimit, clientRequestID, opsErrorLogger, gatewayMetrics, gin.HandlerFunc(apiKeyAuth), h.Embeddings.Embeddings)
+	// OpenAI Images API（不带v1前缀的别名）
+	r.POST("/images/generations", bodyLimit, clientRequestID, opsErrorLogger, gatewayMetrics, gin.HandlerFunc(apiKeyAuth), h.Images.Generations)
 
 	// Antigravity 模型列表
 	r.GET("/antigravity/models", gin.HandlerFunc(apiKeyAuth), h.Gateway.AntigravityModels)
@@ -62,6 +88,7 @@ func RegisterGatewayRoutes(
 	antigravityV1.Use(bodyLimit)
 	antigravityV1.Use(clientRequestID)
 	antigravityV1.Use(opsErrorLogger)
+	antigravityV1.Use(gatewayMetrics)
 	antigravityV1.Use(middleware.ForcePlatform(service.PlatformAntigravity))
 	antigravityV1.Use(gin.HandlerFunc(apiKeyAuth))
 	{
@@ -75,8 +102,9 @@ func RegisterGatewayRoutes(
 	antigravityV1Beta.Use(bodyLimit)
 	antigravityV1Beta.Use(clientRequestID)
 	antigravityV1Beta.Use(opsErrorLogger)
+	antigravityV1Beta.Use(gatewayMetrics)
 	antigravityV1Beta.Use(middleware.ForcePlatform(service.PlatformAntigravity))
-	antigravityV1Beta.Use(middleware.APIKeyAuthWithSubscriptionGoogle(apiKeyService, subscriptionService, cfg))
+	antigravityV1Beta.Use(middleware.APIKeyAuthWithSubscriptionGoogle(apiKeyService, subscriptionService, apiKeyRateLimitService, cfg))
 	{
 		antigravityV1Beta.GET("/models", h.Gateway.GeminiV1BetaListModels)
 		antigravityV1Beta.GET("/models/:model", h.Gateway.GeminiV1BetaGetModel)
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RegisterMetricsRoutes 注册 Prometheus 指标端点（metrics.enabled=true 时生效）
+func RegisterMetricsRoutes(r *gin.Engine, cfg *config.Config) {
+	if cfg == nil || !cfg.Metrics.Enabled {
+		return
+	}
+	path := strings.TrimSpace(cfg.Metrics.Path)
+	if path == "" {
+		path = "/metrics"
+	}
+	token := strings.TrimSpace(cfg.Metrics.AuthToken)
+	handler := metrics.Handler()
+
+	r.GET(path, func(c *gin.Context) {
+		if token != "" {
+			provided := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+			if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+		}
+		handler.ServeHTTP(c.Writer, c.Request)
+	})
+}
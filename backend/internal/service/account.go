@@ -50,6 +50,13 @@ type Account struct {
 	AccountGroups []AccountGroup
 	GroupIDs      []int64
 	Groups        []*Group
+
+	// schedule 由 ParseSchedule 预先解析的 extra.schedule（不参与序列化）
+	schedule       *AccountSchedule
+	scheduleParsed bool
+	// drain 由 ParseDrain 预先解析的 extra.drain（不参与序列化）
+	drain       *AccountDrain
+	drainParsed bool
 }
 
 type TempUnschedulableRule struct {
@@ -94,6 +101,10 @@ func (a *Account) IsSchedulable() bool {
 	if a.TempUnschedulableUntil != nil && now.Before(*a.TempUnschedulableUntil) {
 		return false
 	}
+	// 时间段调度与维护窗口（extra.schedule）
+	if !a.IsWithinSchedule(now) {
+		return false
+	}
 	return true
 }
 
@@ -595,6 +606,21 @@ func (a *Account) IsOpenAIApiKey() bool {
 	return a.IsOpenAI() && a.Type == AccountTypeAPIKey
 }
 
+// IsCompatible 判断是否为 OpenAI Chat Completions 兼容上游账号（DeepSeek、Qwen、vLLM 等）
+func (a *Account) IsCompatible() bool {
+	return a.Platform == PlatformCompatible
+}
+
+// GetCompatibleChatCompletionsURL 返回兼容上游的 Chat Completions 地址。
+// base_url 必填，可填写到 /v1（如 https://api.deepseek.com/v1）或完整的 /chat/completions 地址。
+func (a *Account) GetCompatibleChatCompletionsURL() string {
+	baseURL := strings.TrimRight(strings.TrimSpace(a.GetCredential("base_url")), "/")
+	if baseURL == "" || strings.HasSuffix(baseURL, "/chat/completions") {
+		return baseURL
+	}
+	return baseURL + "/chat/completions"
+}
+
 func (a *Account) GetOpenAIBaseURL() string {
 	if !a.IsOpenAI() {
 		return ""
@@ -714,6 +740,22 @@ func (a *Account) IsAnthropicOAuthOrSetupToken() bool {
 	return a.Platform == PlatformAnthropic && (a.Type == AccountTypeOAuth || a.Type == AccountTypeSetupToken)
 }
 
+// IsBedrock 判断是否为 AWS Bedrock 类型账号（Anthropic 平台，SigV4 签名）
+func (a *Account) IsBedrock() bool {
+	return a.Platform == PlatformAnthropic && a.Type == AccountTypeBedrock
+}
+
+// IsVertex 判断是否为 Google Vertex AI 类型账号（Anthropic 或 Gemini 平台，服务账号鉴权）
+func (a *Account) IsVertex() bool {
+	return a.Type == AccountTypeVertex
+}
+
+// IsCloudProvider 判断是否为云厂商托管模型账号（Bedrock / Vertex）。
+// 这类账号与 APIKey 账号一样按账号级 model_mapping 映射模型。
+func (a *Account) IsCloudProvider() bool {
+	return a.Type == AccountTypeBedrock || a.Type == AccountTypeVertex
+}
+
 // IsTLSFingerprintEnabled 检查是否启用 TLS 指纹伪装
 // 仅适用于 Anthropic OAuth/SetupToken 类型账号
 // 启用后将模拟 Claude Code (Node.js) 客户端的 TLS 握手特征
@@ -0,0 +1,65 @@
+package service
+
+import "sort"
+
+// RedactedCredentialValue 导出时替换敏感凭证字段的占位值
+const RedactedCredentialValue = "[REDACTED]"
+
+// sensitiveCredentialKeys 敏感凭证字段：存储时加密，默认导出时脱敏。
+// 其余字段（model_mapping、base_url、scope、expires_at 等）保持明文，
+// 便于迁移脚本与 JSONB 查询继续使用。
+var sensitiveCredentialKeys = map[string]struct{}{
+	"access_token":  {},
+	"refresh_token": {},
+	"id_token":      {},
+	"api_key":       {},
+	"session_key":   {},
+	"session_token": {},
+	"client_secret": {},
+
+	"aws_secret_access_key": {},
+	"aws_session_token":     {},
+	"service_account_json":  {},
+}
+
+// IsSensitiveCredentialKey 判断凭证字段是否为敏感字段
+func IsSensitiveCredentialKey(key string) bool {
+	_, ok := sensitiveCredentialKeys[key]
+	return ok
+}
+
+// SensitiveCredentialKeys 返回排序后的敏感凭证字段列表
+func SensitiveCredentialKeys() []string {
+	keys := make([]string, 0, len(sensitiveCredentialKeys))
+	for k := range sensitiveCredentialKeys {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// RedactAccountCredentials 返回凭证副本，敏感字段的非空值替换为 RedactedCredentialValue
+func RedactAccountCredentials(credentials map[string]any) map[string]any {
+	if credentials == nil {
+		return nil
+	}
+	out := make(map[string]any, len(credentials))
+	for k, v := range credentials {
+		if s, ok := v.(string); ok && s != "" && IsSensitiveCredentialKey(k) {
+			out[k] = RedactedCredentialValue
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// HasRedactedCredentials 判断凭证中是否包含脱敏占位值（脱敏导出的数据不能直接导入）
+func HasRedactedCredentials(credentials map[string]any) bool {
+	for k, v := range credentials {
+		if s, ok := v.(string); ok && s == RedactedCredentialValue && IsSensitiveCredentialKey(k) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package service
+
+import (
+	"encoding/json"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// 账号平滑下线（drain）
+//
+// 与直接关闭调度不同，drain 状态下账号仍保留在调度快照中，但只承接已有粘性会话（包括摘要会话回落匹配后绑定的会话），
+// 不再分配给新会话，从而避免对话被迫切换账号导致提示词缓存失效。
+// 当账号空闲（最后一次请求距今超过 idle timeout，默认与粘性会话 TTL 一致）或超过截止时间后，
+// AccountDrainService 会自动将其设为不可调度并清除 drain 标记。
+//
+// 状态保存在 account.extra.drain。
+
+const (
+	accountDrainExtraKey = "drain"
+
+	// defaultAccountDrainIdleTimeout 粘性会话绑定过期后会话必然迁移，因此默认空闲超时与其一致
+	defaultAccountDrainIdleTimeout = stickySessionTTL
+)
+
+var (
+	ErrAccountDrainDeadlineInvalid = infraerrors.BadRequest("ACCOUNT_DRAIN_DEADLINE_INVALID", "drain deadline must be in the future")
+	ErrAccountDrainIdleInvalid     = infraerrors.BadRequest("ACCOUNT_DRAIN_IDLE_INVALID", "idle_timeout_minutes must be >= 0")
+	ErrAccountNotSchedulable       = infraerrors.BadRequest("ACCOUNT_NOT_SCHEDULABLE", "account is not schedulable, nothing to drain")
+)
+
+// AccountDrain drain 状态
+type AccountDrain struct {
+	StartedAt          time.Time  `json:"started_at"`
+	Deadline           *time.Time `json:"deadline,omitempty"`
+	IdleTimeoutMinutes int        `json:"idle_timeout_minutes,omitempty"`
+}
+
+// AccountDrainProgress drain 进度（供管理接口与运维视图展示）
+type AccountDrainProgress struct {
+	StartedAt          time.Time  `json:"started_at"`
+	Deadline           *time.Time `json:"deadline,omitempty"`
+	IdleTimeoutMinutes int        `json:"idle_timeout_minutes"`
+	// LastActivityAt 最近一次承接请求的时间（drain 开始前的请求按开始时间计）
+	LastActivityAt time.Time `json:"last_activity_at"`
+	IdleSeconds    int64     `json:"idle_seconds"`
+	// CompletesAt 按当前空闲情况预计完成的时间（空闲超时与截止时间取较早者）
+	CompletesAt time.Time `json:"completes_at"`
+	// Completed 已满足完成条件，等待后台任务切换为不可调度
+	Completed bool `json:"completed"`
+}
+
+// StartAccountDrainInput 开始 drain 的参数
+type StartAccountDrainInput struct {
+	// Deadline 截止时间（Unix 秒），为空表示仅按空闲超时完成
+	Deadline *int64
+	// IdleTimeoutMinutes 空闲超时（分钟），0 表示使用默认值
+	IdleTimeoutMinutes int
+}
+
+// GetDrain 返回 extra.drain；未处于 drain 状态时返回 nil。
+// 已调用 ParseDrain 的账号直接返回缓存结果，否则每次重新解析。
+func (a *Account) GetDrain() *AccountDrain {
+	if a == nil {
+		return nil
+	}
+	if a.drainParsed {
+		return a.drain
+	}
+	return a.parseDrainExtra()
+}
+
+// ParseDrain 解析 extra.drain 并缓存在账号上，调度热路径（IsEligibleForNewSession）不再重复做 JSON 编解码。
+// 仓储层加载账号（数据库 / 调度快照缓存）时调用；之后修改 Extra 需要重新调用。
+func (a *Account) ParseDrain() {
+	if a == nil {
+		return
+	}
+	a.drain = a.parseDrainExtra()
+	a.drainParsed = true
+}
+
+func (a *Account) parseDrainExtra() *AccountDrain {
+	if a.Extra == nil {
+		return nil
+	}
+	raw, ok := a.Extra[accountDrainExtraKey]
+	if !ok || raw == nil {
+		return nil
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil
+	}
+	var drain AccountDrain
+	if err := json.Unmarshal(data, &drain); err != nil || drain.StartedAt.IsZero() {
+		return nil
+	}
+	return &drain
+}
+
+// IsDraining 账号是否处于 drain 状态（只承接已有粘性会话）
+func (a *Account) IsDraining() bool {
+	return a.GetDrain() != nil
+}
+
+// IsEligibleForNewSession 账号是否可以分配新会话：可调度且未处于 drain 状态。
+// 各选择器的粘性会话层不使用该判断，drain 中的账号仍可承接已绑定的会话。
+func (a *Account) IsEligibleForNewSession() bool {
+	return a.IsSchedulable() && !a.IsDraining()
+}
+
+// IdleTimeout 返回 drain 的空闲超时
+func (d *AccountDrain) IdleTimeout() time.Duration {
+	if d == nil || d.IdleTimeoutMinutes <= 0 {
+		return defaultAccountDrainIdleTimeout
+	}
+	return time.Duration(d.IdleTimeoutMinutes) * time.Minute
+}
+
+// DrainProgress 计算 drain 进度；未处于 drain 状态时返回 nil
+func (a *Account) DrainProgress(now time.Time) *AccountDrainProgress {
+	drain := a.GetDrain()
+	if drain == nil {
+		return nil
+	}
+	lastActivity := drain.StartedAt
+	if a.LastUsedAt != nil && a.LastUsedAt.After(lastActivity) {
+		lastActivity = *a.LastUsedAt
+	}
+	idle := drain.IdleTimeout()
+	completesAt := lastActivity.Add(idle)
+	if drain.Deadline != nil && drain.Deadline.Before(completesAt) {
+		completesAt = *drain.Deadline
+	}
+	idleSeconds := int64(now.Sub(lastActivity).Seconds())
+	if idleSeconds < 0 {
+		idleSeconds = 0
+	}
+	return &AccountDrainProgress{
+		StartedAt:          drain.StartedAt,
+		Deadline:           drain.Deadline,
+		IdleTimeoutMinutes: int(idle / time.Minute),
+		LastActivityAt:     lastActivity,
+		IdleSeconds:        idleSeconds,
+		CompletesAt:        completesAt,
+		Completed:          !now.Before(completesAt),
+	}
+}
+
+// newAccountDrainExtra 构造写入 extra 的 drain 标记
+func newAccountDrainExtra(now time.Time, input *StartAccountDrainInput) (map[string]any, error) {
+	drain := AccountDrain{StartedAt: now.UTC()}
+	if input != nil {
+		if input.IdleTimeoutMinutes < 0 {
+			return nil, ErrAccountDrainIdleInvalid
+		}
+		drain.IdleTimeoutMinutes = input.IdleTimeoutMinutes
+		if input.Deadline != nil && *input.Deadline > 0 {
+			deadline := time.Unix(*input.Deadline, 0).UTC()
+			if !deadline.After(now) {
+				return nil, ErrAccountDrainDeadlineInvalid
+			}
+			drain.Deadline = &deadline
+		}
+	}
+	data, err := json.Marshal(drain)
+	if err != nil {
+		return nil, err
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	return map[string]any{accountDrainExtraKey: payload}, nil
+}
+
+// clearAccountDrainExtra 清除 drain 标记（JSONB 合并写入 null）
+func clearAccountDrainExtra() map[string]any {
+	return map[string]any{accountDrainExtraKey: nil}
+}
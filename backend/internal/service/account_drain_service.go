@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// AccountDrainService periodically finishes drains whose last session went idle or whose deadline passed.
+type AccountDrainService struct {
+	accountRepo AccountRepository
+	interval    time.Duration
+	stopCh      chan struct{}
+	stopOnce    sync.Once
+	wg          sync.WaitGroup
+}
+
+func NewAccountDrainService(accountRepo AccountRepository, interval time.Duration) *AccountDrainService {
+	return &AccountDrainService{
+		accountRepo: accountRepo,
+		interval:    interval,
+		stopCh:      make(chan struct{}),
+	}
+}
+
+func (s *AccountDrainService) Start() {
+	if s == nil || s.accountRepo == nil || s.interval <= 0 {
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.runOnce()
+		for {
+			select {
+			case <-ticker.C:
+				s.runOnce()
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (s *AccountDrainService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+}
+
+func (s *AccountDrainService) runOnce() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	finished, err := s.finishCompletedDrains(ctx, time.Now())
+	if err != nil {
+		log.Printf("[AccountDrain] Finish drains failed: %v", err)
+		return
+	}
+	if finished > 0 {
+		log.Printf("[AccountDrain] Finished %d drained accounts", finished)
+	}
+}
+
+// finishCompletedDrains 将满足完成条件的 drain 账号设为不可调度并清除 drain 标记
+func (s *AccountDrainService) finishCompletedDrains(ctx context.Context, now time.Time) (int, error) {
+	accounts, err := s.accountRepo.ListActive(ctx)
+	if err != nil {
+		return 0, err
+	}
+	finished := 0
+	for i := range accounts {
+		progress := accounts[i].DrainProgress(now)
+		if progress == nil || !progress.Completed {
+			continue
+		}
+		// 列表是快照：关闭调度前重新读取账号，drain 已被取消/重新开始（started_at 变化）
+		// 或期间又承接了请求时不做处理，避免误停管理员刚恢复的账号
+		acc, err := s.accountRepo.GetByID(ctx, accounts[i].ID)
+		if err != nil {
+			log.Printf("[AccountDrain] Reload account failed: account=%d err=%v", accounts[i].ID, err)
+			continue
+		}
+		current := acc.DrainProgress(now)
+		if current == nil || !current.StartedAt.Equal(progress.StartedAt) || !current.Completed {
+			continue
+		}
+		progress = current
+		// 先关闭调度再清除标记，避免中间状态被当作普通可调度账号分配新会话
+		if err := s.accountRepo.SetSchedulable(ctx, acc.ID, false); err != nil {
+			log.Printf("[AccountDrain] Pause account failed: account=%d err=%v", acc.ID, err)
+			continue
+		}
+		if err := s.accountRepo.UpdateExtra(ctx, acc.ID, clearAccountDrainExtra()); err != nil {
+			log.Printf("[AccountDrain] Clear drain failed: account=%d err=%v", acc.ID, err)
+			continue
+		}
+		log.Printf("[AccountDrain] Account drained: account=%d idle=%ds", acc.ID, progress.IdleSeconds)
+		finished++
+	}
+	return finished, nil
+}
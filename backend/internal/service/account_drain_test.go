@@ -0,0 +1,196 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func drainingAccount(id int64, priority int, startedAt time.Time) Account {
+	extra, err := newAccountDrainExtra(startedAt, nil)
+	if err != nil {
+		panic(err)
+	}
+	return Account{
+		ID:          id,
+		Platform:    PlatformAnthropic,
+		Priority:    priority,
+		Status:      StatusActive,
+		Schedulable: true,
+		Concurrency: 1,
+		Extra:       extra,
+	}
+}
+
+func TestAccountParseDrain_CachesParsedDrain(t *testing.T) {
+	acc := drainingAccount(1, 1, time.Now().Add(-time.Minute))
+	acc.ParseDrain()
+	first := acc.GetDrain()
+	require.NotNil(t, first)
+	require.Same(t, first, acc.GetDrain(), "parsed drain state is reused instead of re-decoding extra")
+
+	// 修改 Extra 后需重新调用 ParseDrain
+	acc.Extra = nil
+	require.True(t, acc.IsDraining())
+	acc.ParseDrain()
+	require.Nil(t, acc.GetDrain())
+	require.True(t, acc.IsEligibleForNewSession())
+}
+
+func TestAccountDrainProgress(t *testing.T) {
+	now := time.Now()
+
+	plain := &Account{ID: 1}
+	require.False(t, plain.IsDraining())
+	require.Nil(t, plain.DrainProgress(now))
+
+	acc := drainingAccount(2, 1, now.Add(-30*time.Minute))
+	require.True(t, acc.IsDraining())
+	progress := acc.DrainProgress(now)
+	require.NotNil(t, progress)
+	require.Equal(t, int(defaultAccountDrainIdleTimeout/time.Minute), progress.IdleTimeoutMinutes)
+	require.False(t, progress.Completed)
+	require.InDelta(t, 30*60, progress.IdleSeconds, 1)
+
+	// 最近仍有粘性会话请求：空闲时间从最后一次使用开始计算
+	lastUsed := now.Add(-5 * time.Minute)
+	acc.LastUsedAt = &lastUsed
+	progress = acc.DrainProgress(now)
+	require.Equal(t, lastUsed.Unix(), progress.LastActivityAt.Unix())
+	require.Equal(t, lastUsed.Add(defaultAccountDrainIdleTimeout).Unix(), progress.CompletesAt.Unix())
+
+	// 空闲超过超时时间即完成
+	require.True(t, acc.DrainProgress(lastUsed.Add(defaultAccountDrainIdleTimeout)).Completed)
+}
+
+func TestAccountDrainProgress_Deadline(t *testing.T) {
+	now := time.Now()
+	deadline := now.Add(10 * time.Minute).Unix()
+	extra, err := newAccountDrainExtra(now, &StartAccountDrainInput{Deadline: &deadline, IdleTimeoutMinutes: 120})
+	require.NoError(t, err)
+
+	acc := &Account{ID: 1, Extra: extra}
+	lastUsed := now.Add(9 * time.Minute)
+	acc.LastUsedAt = &lastUsed
+
+	progress := acc.DrainProgress(now)
+	require.Equal(t, 120, progress.IdleTimeoutMinutes)
+	require.Equal(t, deadline, progress.CompletesAt.Unix(), "截止时间早于空闲超时")
+	require.False(t, progress.Completed)
+	require.True(t, acc.DrainProgress(now.Add(11*time.Minute)).Completed, "到达截止时间后即使仍有会话也完成")
+}
+
+func TestNewAccountDrainExtra_Validation(t *testing.T) {
+	now := time.Now()
+
+	past := now.Add(-time.Minute).Unix()
+	_, err := newAccountDrainExtra(now, &StartAccountDrainInput{Deadline: &past})
+	require.ErrorIs(t, err, ErrAccountDrainDeadlineInvalid)
+
+	_, err = newAccountDrainExtra(now, &StartAccountDrainInput{IdleTimeoutMinutes: -1})
+	require.ErrorIs(t, err, ErrAccountDrainIdleInvalid)
+
+	extra := clearAccountDrainExtra()
+	acc := &Account{Extra: extra}
+	require.False(t, acc.IsDraining(), "写入 null 后不再处于 drain 状态")
+}
+
+type drainAccountRepo struct {
+	mockAccountRepoForPlatform
+	unschedulable []int64
+	extraUpdates  map[int64]map[string]any
+}
+
+func (m *drainAccountRepo) ListActive(ctx context.Context) ([]Account, error) {
+	return m.accounts, nil
+}
+
+func (m *drainAccountRepo) SetSchedulable(ctx context.Context, id int64, schedulable bool) error {
+	if !schedulable {
+		m.unschedulable = append(m.unschedulable, id)
+	}
+	return nil
+}
+
+func (m *drainAccountRepo) UpdateExtra(ctx context.Context, id int64, updates map[string]any) error {
+	if m.extraUpdates == nil {
+		m.extraUpdates = map[int64]map[string]any{}
+	}
+	m.extraUpdates[id] = updates
+	return nil
+}
+
+func TestAccountDrainService_FinishCompletedDrains(t *testing.T) {
+	now := time.Now()
+	idle := drainingAccount(1, 1, now.Add(-2*time.Hour))
+	busy := drainingAccount(2, 1, now.Add(-2*time.Hour))
+	recent := now.Add(-time.Minute)
+	busy.LastUsedAt = &recent
+	plain := Account{ID: 3, Status: StatusActive, Schedulable: true}
+
+	// 快照中的 4、5 已完成，但重新读取时：4 的 drain 已被取消，5 的 drain 已重新开始
+	cancelled := drainingAccount(4, 1, now.Add(-2*time.Hour))
+	restarted := drainingAccount(5, 1, now.Add(-2*time.Hour))
+
+	repo := &drainAccountRepo{mockAccountRepoForPlatform: mockAccountRepoForPlatform{
+		accounts:     []Account{idle, busy, plain, cancelled, restarted},
+		accountsByID: map[int64]*Account{},
+	}}
+	for i := range repo.accounts {
+		acc := repo.accounts[i]
+		repo.accountsByID[acc.ID] = &acc
+	}
+	repo.accountsByID[4] = &Account{ID: 4, Status: StatusActive, Schedulable: true}
+	restartedNow := drainingAccount(5, 1, now.Add(-3*time.Hour))
+	repo.accountsByID[5] = &restartedNow
+	svc := NewAccountDrainService(repo, time.Minute)
+
+	finished, err := svc.finishCompletedDrains(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, 1, finished)
+	require.Equal(t, []int64{1}, repo.unschedulable)
+	require.Contains(t, repo.extraUpdates, int64(1))
+	require.Nil(t, repo.extraUpdates[1][accountDrainExtraKey])
+	require.NotContains(t, repo.extraUpdates, int64(2))
+	require.NotContains(t, repo.extraUpdates, int64(4))
+	require.NotContains(t, repo.extraUpdates, int64(5))
+}
+
+func TestGatewayService_DrainingAccountOnlyServesStickySessions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	newService := func(bindings map[string]int64) *GatewayService {
+		repo := &mockAccountRepoForPlatform{
+			accounts: []Account{
+				drainingAccount(1, 1, now),
+				{ID: 2, Platform: PlatformAnthropic, Priority: 2, Status: StatusActive, Schedulable: true},
+			},
+			accountsByID: map[int64]*Account{},
+		}
+		for i := range repo.accounts {
+			repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+		}
+		return &GatewayService{
+			accountRepo: repo,
+			cache:       &mockGatewayCacheForPlatform{sessionBindings: bindings},
+			cfg:         testConfig(),
+		}
+	}
+
+	// 新会话不会分配到 drain 中的账号，即使其优先级更高
+	svc := newService(nil)
+	acc, err := svc.selectAccountForModelWithPlatform(ctx, nil, "new-session", "claude-3-5-sonnet-20241022", nil, PlatformAnthropic)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), acc.ID)
+
+	// 已绑定到 drain 账号的粘性会话继续由其承接
+	svc = newService(map[string]int64{"existing-session": 1})
+	acc, err = svc.selectAccountForModelWithPlatform(ctx, nil, "existing-session", "claude-3-5-sonnet-20241022", nil, PlatformAnthropic)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), acc.ID)
+}
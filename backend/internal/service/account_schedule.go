@@ -0,0 +1,361 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+	"github.com/robfig/cron/v3"
+)
+
+// 账号时间段调度与维护窗口
+//
+// 配置保存在 account.extra.schedule：
+//
+//	{
+//	  "timezone": "Asia/Shanghai",                       // 可选，默认使用服务端时区（pkg/timezone）
+//	  "windows": [{"days": [1,2,3,4,5], "start": "22:00", "end": "08:00"}],
+//	  "cron": "0 22 * * 1-5", "cron_duration_minutes": 600,
+//	  "maintenance": [{"start": "2026-01-01T02:00:00Z", "end": "2026-01-01T04:00:00Z", "reason": "..."}]
+//	}
+//
+// windows / cron 定义允许承载流量的时间段（任一命中即可），都未配置时全天可用；
+// maintenance 为一次性维护窗口，窗口内账号始终不可调度。
+// days 使用 0=周日 … 6=周六，为空表示每天；end <= start 表示跨越午夜。
+
+const accountScheduleExtraKey = "schedule"
+
+// ErrInvalidAccountSchedule extra.schedule 配置非法
+var ErrInvalidAccountSchedule = infraerrors.BadRequest("INVALID_ACCOUNT_SCHEDULE", "invalid account schedule")
+
+// AccountSchedule 账号的周期调度时间段与维护窗口
+type AccountSchedule struct {
+	Timezone            string                     `json:"timezone,omitempty"`
+	Windows             []AccountScheduleWindow    `json:"windows,omitempty"`
+	Cron                string                     `json:"cron,omitempty"`
+	CronDurationMinutes int                        `json:"cron_duration_minutes,omitempty"`
+	Maintenance         []AccountMaintenanceWindow `json:"maintenance,omitempty"`
+}
+
+// AccountScheduleWindow 按星期 + 时刻定义的周期时间段
+type AccountScheduleWindow struct {
+	Days  []int  `json:"days,omitempty"`
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// AccountMaintenanceWindow 一次性维护窗口 [Start, End)
+type AccountMaintenanceWindow struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// AccountScheduleState 某一时刻的调度时间段判定结果
+type AccountScheduleState struct {
+	// Allowed 为 false 表示当前处于维护窗口或不在调度时间段内
+	Allowed bool
+	// InMaintenance 当前处于维护窗口
+	InMaintenance bool
+	Reason        string
+	// NextTransition 下一个可能改变判定结果的时间点（零值表示没有）
+	NextTransition time.Time
+}
+
+var (
+	accountScheduleCronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+
+	// IsSchedulable 在调度热路径上频繁调用，缓存 cron 解析与时区加载结果
+	accountScheduleCronCache     sync.Map // spec -> cron.Schedule
+	accountScheduleLocationCache sync.Map // name -> *time.Location
+)
+
+// GetSchedule 返回 extra.schedule；未配置或配置无法解析时返回 nil。
+// 已调用 ParseSchedule 的账号直接返回缓存结果，否则每次重新解析。
+func (a *Account) GetSchedule() *AccountSchedule {
+	if a == nil {
+		return nil
+	}
+	if a.scheduleParsed {
+		return a.schedule
+	}
+	return a.parseScheduleExtra()
+}
+
+// ParseSchedule 解析 extra.schedule 并缓存在账号上，调度热路径（IsSchedulable）不再重复做 JSON 编解码。
+// 仓储层加载账号（数据库 / 调度快照缓存）时调用；之后修改 Extra 需要重新调用。
+func (a *Account) ParseSchedule() {
+	if a == nil {
+		return
+	}
+	a.schedule = a.parseScheduleExtra()
+	a.scheduleParsed = true
+}
+
+func (a *Account) parseScheduleExtra() *AccountSchedule {
+	if a.Extra == nil {
+		return nil
+	}
+	raw, ok := a.Extra[accountScheduleExtraKey]
+	if !ok || raw == nil {
+		return nil
+	}
+	schedule, err := parseAccountSchedule(raw)
+	if err != nil {
+		return nil
+	}
+	return schedule
+}
+
+// ScheduleState 返回账号在 now 时刻的调度时间段判定
+func (a *Account) ScheduleState(now time.Time) AccountScheduleState {
+	schedule := a.GetSchedule()
+	if schedule == nil {
+		return AccountScheduleState{Allowed: true}
+	}
+	return schedule.State(now)
+}
+
+// IsWithinSchedule 账号当前是否处于允许调度的时间段（且不在维护窗口内）
+func (a *Account) IsWithinSchedule(now time.Time) bool {
+	return a.ScheduleState(now).Allowed
+}
+
+// ValidateAccountScheduleExtra 校验 extra 中的 schedule 配置（未配置时返回 nil）
+func ValidateAccountScheduleExtra(extra map[string]any) error {
+	if extra == nil {
+		return nil
+	}
+	raw, ok := extra[accountScheduleExtraKey]
+	if !ok || raw == nil {
+		return nil
+	}
+	schedule, err := parseAccountSchedule(raw)
+	if err == nil {
+		err = schedule.Validate()
+	}
+	if err != nil {
+		return infraerrors.Newf(http.StatusBadRequest, ErrInvalidAccountSchedule.Reason, "invalid account schedule: %v", err)
+	}
+	return nil
+}
+
+func parseAccountSchedule(raw any) (*AccountSchedule, error) {
+	var data []byte
+	switch v := raw.(type) {
+	case string:
+		data = []byte(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		data = b
+	}
+	var schedule AccountSchedule
+	if err := json.Unmarshal(data, &schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// Validate 校验时区、时间段、cron 与维护窗口
+func (s *AccountSchedule) Validate() error {
+	if _, err := s.location(); err != nil {
+		return err
+	}
+	for i, w := range s.Windows {
+		startClock, err := parseScheduleClock(w.Start)
+		if err != nil {
+			return fmt.Errorf("windows[%d].start: %w", i, err)
+		}
+		endClock, err := parseScheduleClock(w.End)
+		if err != nil {
+			return fmt.Errorf("windows[%d].end: %w", i, err)
+		}
+		if startClock == endClock {
+			return fmt.Errorf("windows[%d]: start and end must differ", i)
+		}
+		for _, d := range w.Days {
+			if d < 0 || d > 6 {
+				return fmt.Errorf("windows[%d].days: %d out of range 0-6", i, d)
+			}
+		}
+	}
+	if strings.TrimSpace(s.Cron) != "" {
+		if _, err := scheduleCron(s.Cron); err != nil {
+			return fmt.Errorf("cron: %w", err)
+		}
+		if s.CronDurationMinutes <= 0 {
+			return fmt.Errorf("cron_duration_minutes must be > 0")
+		}
+	}
+	for i, m := range s.Maintenance {
+		if m.Start.IsZero() || m.End.IsZero() {
+			return fmt.Errorf("maintenance[%d]: start and end are required", i)
+		}
+		if !m.End.After(m.Start) {
+			return fmt.Errorf("maintenance[%d]: end must be after start", i)
+		}
+	}
+	return nil
+}
+
+// State 计算 now 时刻是否允许调度，以及下一个边界时间点
+func (s *AccountSchedule) State(now time.Time) AccountScheduleState {
+	state := AccountScheduleState{Allowed: true}
+	var next time.Time
+	consider := func(t time.Time) {
+		if t.After(now) && (next.IsZero() || t.Before(next)) {
+			next = t
+		}
+	}
+
+	for _, m := range s.Maintenance {
+		consider(m.Start)
+		consider(m.End)
+		if !now.Before(m.Start) && now.Before(m.End) && !state.InMaintenance {
+			state.Allowed = false
+			state.InMaintenance = true
+			state.Reason = "maintenance until " + m.End.UTC().Format(time.RFC3339)
+			if m.Reason != "" {
+				state.Reason += ": " + m.Reason
+			}
+		}
+	}
+
+	hasCron := strings.TrimSpace(s.Cron) != "" && s.CronDurationMinutes > 0
+	if len(s.Windows) > 0 || hasCron {
+		loc, err := s.location()
+		if err != nil {
+			loc = timezone.Location()
+		}
+		local := now.In(loc)
+		inside := false
+
+		for _, w := range s.Windows {
+			startClock, err1 := parseScheduleClock(w.Start)
+			endClock, err2 := parseScheduleClock(w.End)
+			if err1 != nil || err2 != nil {
+				continue
+			}
+			// 前一天开始的跨午夜时间段可能覆盖当前时刻，向后看一周以找到下一个边界
+			for offset := -1; offset <= 7; offset++ {
+				day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+				if !scheduleWindowIncludesDay(w.Days, day.Weekday()) {
+					continue
+				}
+				start := scheduleClockOn(day, 0, startClock)
+				end := scheduleClockOn(day, 0, endClock)
+				if endClock <= startClock {
+					end = scheduleClockOn(day, 1, endClock)
+				}
+				consider(start)
+				consider(end)
+				if !now.Before(start) && now.Before(end) {
+					inside = true
+				}
+			}
+		}
+
+		if hasCron {
+			if sched, err := scheduleCron(s.Cron); err == nil {
+				duration := time.Duration(s.CronDurationMinutes) * time.Minute
+				// 在 (now-duration, now] 内触发过则处于时间段内
+				if fired := sched.Next(local.Add(-duration)); !fired.After(local) {
+					inside = true
+					consider(fired.Add(duration))
+				}
+				consider(sched.Next(local))
+			}
+		}
+
+		if !inside {
+			state.Allowed = false
+			if !state.InMaintenance {
+				state.Reason = "outside schedule"
+			}
+		}
+	}
+
+	state.NextTransition = next
+	if !state.Allowed && !state.InMaintenance && !next.IsZero() {
+		state.Reason += ", next change at " + next.UTC().Format(time.RFC3339)
+	}
+	return state
+}
+
+func (s *AccountSchedule) location() (*time.Location, error) {
+	name := strings.TrimSpace(s.Timezone)
+	if name == "" {
+		return timezone.Location(), nil
+	}
+	if loc, ok := accountScheduleLocationCache.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q", name)
+	}
+	accountScheduleLocationCache.Store(name, loc)
+	return loc, nil
+}
+
+func scheduleCron(spec string) (cron.Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if sched, ok := accountScheduleCronCache.Load(spec); ok {
+		return sched.(cron.Schedule), nil
+	}
+	sched, err := accountScheduleCronParser.Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	accountScheduleCronCache.Store(spec, sched)
+	return sched, nil
+}
+
+// parseScheduleClock 解析 HH:MM，"24:00" 表示当天结束
+func parseScheduleClock(v string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(v), ":")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", v)
+	}
+	hour, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", v)
+	}
+	minute, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", v)
+	}
+	if hour == 24 && minute == 0 {
+		return 24 * time.Hour, nil
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("invalid time %q, expected HH:MM", v)
+	}
+	return time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute, nil
+}
+
+// scheduleClockOn 返回 day 之后第 addDays 天的本地时刻 clock（按日历计算，夏令时切换日也保持墙上时间）
+func scheduleClockOn(day time.Time, addDays int, clock time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day()+addDays, 0, int(clock/time.Minute), 0, 0, day.Location())
+}
+
+func scheduleWindowIncludesDay(days []int, weekday time.Weekday) bool {
+	if len(days) == 0 {
+		return true
+	}
+	for _, d := range days {
+		if d == int(weekday) {
+			return true
+		}
+	}
+	return false
+}
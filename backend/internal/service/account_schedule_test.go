@@ -0,0 +1,213 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func scheduledAccount(id int64, schedule map[string]any) Account {
+	return Account{
+		ID:          id,
+		Platform:    PlatformAnthropic,
+		Status:      StatusActive,
+		Schedulable: true,
+		Concurrency: 1,
+		Extra:       map[string]any{"schedule": schedule},
+	}
+}
+
+func TestAccountSchedule_OvernightWeekdayWindow(t *testing.T) {
+	acc := scheduledAccount(1, map[string]any{
+		"timezone": "Asia/Shanghai",
+		"windows":  []any{map[string]any{"days": []any{1, 2, 3, 4, 5}, "start": "22:00", "end": "08:00"}},
+	})
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+
+	// 2026-10-12 是周一
+	monday2300 := time.Date(2026, 10, 12, 23, 0, 0, 0, loc)
+	tuesday0700 := time.Date(2026, 10, 13, 7, 0, 0, 0, loc)
+	tuesday1200 := time.Date(2026, 10, 13, 12, 0, 0, 0, loc)
+	sunday2300 := time.Date(2026, 10, 18, 23, 0, 0, 0, loc)
+	saturday0700 := time.Date(2026, 10, 17, 7, 0, 0, 0, loc)
+
+	require.True(t, acc.IsWithinSchedule(monday2300))
+	require.True(t, acc.IsWithinSchedule(tuesday0700))
+	require.True(t, acc.IsWithinSchedule(saturday0700), "friday night window spans into saturday morning")
+	require.False(t, acc.IsWithinSchedule(sunday2300))
+
+	state := acc.ScheduleState(tuesday1200)
+	require.False(t, state.Allowed)
+	require.False(t, state.InMaintenance)
+	require.Contains(t, state.Reason, "outside schedule")
+	require.True(t, state.NextTransition.Equal(time.Date(2026, 10, 13, 22, 0, 0, 0, loc)))
+
+	state = acc.ScheduleState(monday2300)
+	require.True(t, state.NextTransition.Equal(time.Date(2026, 10, 13, 8, 0, 0, 0, loc)))
+}
+
+func TestAccountSchedule_Cron(t *testing.T) {
+	acc := scheduledAccount(1, map[string]any{
+		"timezone":              "UTC",
+		"cron":                  "0 1 * * *",
+		"cron_duration_minutes": 120,
+	})
+	require.True(t, acc.IsWithinSchedule(time.Date(2026, 10, 12, 1, 0, 0, 0, time.UTC)))
+	require.True(t, acc.IsWithinSchedule(time.Date(2026, 10, 12, 2, 59, 0, 0, time.UTC)))
+	require.False(t, acc.IsWithinSchedule(time.Date(2026, 10, 12, 3, 0, 0, 0, time.UTC)))
+
+	state := acc.ScheduleState(time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC))
+	require.False(t, state.Allowed)
+	require.True(t, state.NextTransition.Equal(time.Date(2026, 10, 13, 1, 0, 0, 0, time.UTC)))
+}
+
+func TestAccountSchedule_MaintenanceOverridesWindows(t *testing.T) {
+	start := time.Date(2026, 10, 12, 2, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	acc := scheduledAccount(1, map[string]any{
+		"maintenance": []any{map[string]any{
+			"start":  start.Format(time.RFC3339),
+			"end":    end.Format(time.RFC3339),
+			"reason": "key rotation",
+		}},
+	})
+
+	require.True(t, acc.IsWithinSchedule(start.Add(-time.Minute)))
+	state := acc.ScheduleState(start.Add(time.Minute))
+	require.False(t, state.Allowed)
+	require.True(t, state.InMaintenance)
+	require.Contains(t, state.Reason, "key rotation")
+	require.True(t, state.NextTransition.Equal(end))
+	require.True(t, acc.IsWithinSchedule(end))
+}
+
+func TestAccountIsSchedulable_RespectsSchedule(t *testing.T) {
+	now := time.Now()
+	acc := scheduledAccount(1, map[string]any{
+		"maintenance": []any{map[string]any{
+			"start": now.Add(-time.Hour).Format(time.RFC3339),
+			"end":   now.Add(time.Hour).Format(time.RFC3339),
+		}},
+	})
+	require.False(t, acc.IsSchedulable())
+
+	plain := scheduledAccount(2, nil)
+	plain.Extra = nil
+	require.True(t, plain.IsSchedulable())
+}
+
+func TestAccountParseSchedule_CachesParsedSchedule(t *testing.T) {
+	now := time.Now()
+	acc := scheduledAccount(1, map[string]any{
+		"maintenance": []any{map[string]any{
+			"start": now.Add(-time.Hour).Format(time.RFC3339),
+			"end":   now.Add(time.Hour).Format(time.RFC3339),
+		}},
+	})
+	acc.ParseSchedule()
+	first := acc.GetSchedule()
+	require.NotNil(t, first)
+	require.Same(t, first, acc.GetSchedule(), "parsed schedule is reused instead of re-decoding extra")
+
+	// 修改 Extra 后需重新调用 ParseSchedule
+	acc.Extra = nil
+	require.Same(t, first, acc.GetSchedule())
+	acc.ParseSchedule()
+	require.Nil(t, acc.GetSchedule())
+	require.True(t, acc.IsSchedulable())
+}
+
+func TestValidateAccountScheduleExtra(t *testing.T) {
+	require.NoError(t, ValidateAccountScheduleExtra(nil))
+	require.NoError(t, ValidateAccountScheduleExtra(map[string]any{"weight": 2}))
+	require.NoError(t, ValidateAccountScheduleExtra(map[string]any{"schedule": map[string]any{
+		"timezone": "America/New_York",
+		"windows":  []any{map[string]any{"start": "09:00", "end": "17:30"}},
+	}}))
+
+	invalid := []map[string]any{
+		{"timezone": "Mars/Olympus"},
+		{"windows": []any{map[string]any{"start": "25:00", "end": "08:00"}}},
+		{"windows": []any{map[string]any{"start": "08:00", "end": "08:00"}}},
+		{"windows": []any{map[string]any{"days": []any{7}, "start": "08:00", "end": "09:00"}}},
+		{"cron": "not a cron", "cron_duration_minutes": 10},
+		{"cron": "0 1 * * *"},
+		{"maintenance": []any{map[string]any{"start": "2026-01-02T00:00:00Z", "end": "2026-01-01T00:00:00Z"}}},
+		{"windows": "nope"},
+	}
+	for _, schedule := range invalid {
+		err := ValidateAccountScheduleExtra(map[string]any{"schedule": schedule})
+		require.ErrorIs(t, err, ErrInvalidAccountSchedule, "schedule=%v", schedule)
+	}
+}
+
+type scheduleSnapshotCacheStub struct {
+	SchedulerCache
+	snapshots map[SchedulerBucket][]Account
+}
+
+func (c *scheduleSnapshotCacheStub) TryLockBucket(ctx context.Context, bucket SchedulerBucket, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (c *scheduleSnapshotCacheStub) SetSnapshot(ctx context.Context, bucket SchedulerBucket, accounts []Account) error {
+	c.snapshots[bucket] = append([]Account(nil), accounts...)
+	return nil
+}
+
+type scheduleAccountRepo struct {
+	mockAccountRepoForPlatform
+}
+
+// ListActiveWithSchedule 模拟数据库按 extra.schedule 过滤
+func (m *scheduleAccountRepo) ListActiveWithSchedule(ctx context.Context) ([]Account, error) {
+	var result []Account
+	for _, acc := range m.accounts {
+		if acc.Extra[accountScheduleExtraKey] != nil {
+			result = append(result, acc)
+		}
+	}
+	return result, nil
+}
+
+func TestSchedulerSnapshot_RebuildsOnScheduleBoundary(t *testing.T) {
+	now := time.Now()
+	maintained := scheduledAccount(1, map[string]any{
+		"maintenance": []any{map[string]any{
+			"start": now.Add(-time.Hour).Format(time.RFC3339),
+			"end":   now.Add(time.Hour).Format(time.RFC3339),
+		}},
+	})
+	plain := scheduledAccount(2, nil)
+	plain.Extra = nil
+
+	repo := &scheduleAccountRepo{mockAccountRepoForPlatform{accounts: []Account{maintained, plain}}}
+	// 模拟数据库：SQL 层无法识别 extra.schedule，返回全部账号
+	repo.listPlatformFunc = func(ctx context.Context, platform string) ([]Account, error) {
+		return append([]Account(nil), repo.accounts...), nil
+	}
+	cache := &scheduleSnapshotCacheStub{snapshots: map[SchedulerBucket][]Account{}}
+	svc := NewSchedulerSnapshotService(cache, nil, repo, nil, testConfig())
+
+	states := map[int64]bool{}
+	next := svc.syncAccountSchedules(states, now.Add(-2*time.Hour))
+	require.Empty(t, cache.snapshots, "first observation does not rebuild")
+	require.True(t, states[1])
+	require.NotContains(t, states, int64(2), "accounts without schedule are not tracked")
+	require.WithinDuration(t, now.Add(-time.Hour), next, time.Second)
+
+	next = svc.syncAccountSchedules(states, now)
+	require.False(t, states[1])
+	require.WithinDuration(t, now.Add(time.Hour), next, time.Second)
+
+	bucket := SchedulerBucket{GroupID: 0, Platform: PlatformAnthropic, Mode: SchedulerModeSingle}
+	require.Contains(t, cache.snapshots, bucket)
+	snapshot := cache.snapshots[bucket]
+	require.Len(t, snapshot, 1)
+	require.Equal(t, int64(2), snapshot[0].ID, "account in maintenance is filtered out of the snapshot")
+}
@@ -87,6 +87,10 @@ func (s *accountRepoStub) ListActive(ctx context.Context) ([]Account, error) {
 	panic("unexpected ListActive call")
 }
 
+func (s *accountRepoStub) ListActiveWithSchedule(ctx context.Context) ([]Account, error) {
+	panic("unexpected ListActiveWithSchedule call")
+}
+
 func (s *accountRepoStub) ListByPlatform(ctx context.Context, platform string) ([]Account, error) {
 	panic("unexpected ListByPlatform call")
 }
@@ -17,11 +17,13 @@ import (
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/compatible"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/geminicli"
 	"github.com/Wei-Shaw/sub2api/internal/pkg/openai"
 	"github.com/Wei-Shaw/sub2api/internal/util/urlvalidator"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/tidwall/gjson"
 )
 
 // sseDataPrefix matches SSE data lines with optional whitespace after colon.
@@ -46,6 +48,7 @@ type TestEvent struct {
 type AccountTestService struct {
 	accountRepo               AccountRepository
 	geminiTokenProvider       *GeminiTokenProvider
+	vertexTokenProvider       *VertexTokenProvider
 	antigravityGatewayService *AntigravityGatewayService
 	httpUpstream              HTTPUpstream
 	cfg                       *config.Config
@@ -55,6 +58,7 @@ type AccountTestService struct {
 func NewAccountTestService(
 	accountRepo AccountRepository,
 	geminiTokenProvider *GeminiTokenProvider,
+	vertexTokenProvider *VertexTokenProvider,
 	antigravityGatewayService *AntigravityGatewayService,
 	httpUpstream HTTPUpstream,
 	cfg *config.Config,
@@ -62,6 +66,7 @@ func NewAccountTestService(
 	return &AccountTestService{
 		accountRepo:               accountRepo,
 		geminiTokenProvider:       geminiTokenProvider,
+		vertexTokenProvider:       vertexTokenProvider,
 		antigravityGatewayService: antigravityGatewayService,
 		httpUpstream:              httpUpstream,
 		cfg:                       cfg,
@@ -163,6 +168,10 @@ func (s *AccountTestService) TestAccountConnection(c *gin.Context, accountID int
 		return s.testAntigravityAccountConnection(c, account, modelID)
 	}
 
+	if account.IsCompatible() {
+		return s.testCompatibleAccountConnection(c, account, modelID)
+	}
+
 	return s.testClaudeAccountConnection(c, account, modelID)
 }
 
@@ -176,6 +185,10 @@ func (s *AccountTestService) testClaudeAccountConnection(c *gin.Context, account
 		testModelID = claude.DefaultTestModel
 	}
 
+	if account.IsCloudProvider() {
+		return s.testCloudProviderAccountConnection(c, account, testModelID)
+	}
+
 	// For API Key accounts with model mapping, map the model
 	if account.Type == "apikey" {
 		mapping := account.GetModelMapping()
@@ -281,6 +294,89 @@ func (s *AccountTestService) testClaudeAccountConnection(c *gin.Context, account
 	return s.processClaudeStream(c, resp.Body)
 }
 
+// testCloudProviderAccountConnection tests an AWS Bedrock / Google Vertex AI account serving Claude models
+// via a streaming request (Bedrock invoke-with-response-stream, Vertex streamRawPredict)
+func (s *AccountTestService) testCloudProviderAccountConnection(c *gin.Context, account *Account, testModelID string) error {
+	ctx := c.Request.Context()
+
+	if mappedModel := account.GetMappedModel(testModelID); mappedModel != "" {
+		testModelID = mappedModel
+	}
+
+	// Set SSE headers
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+	c.Writer.Header().Set("X-Accel-Buffering", "no")
+	c.Writer.Flush()
+
+	payload, err := createTestPayload(testModelID)
+	if err != nil {
+		return s.sendErrorAndEnd(c, "Failed to create test payload")
+	}
+	payloadBytes, _ := json.Marshal(payload)
+
+	s.sendEvent(c, TestEvent{Type: "test_start", Model: testModelID})
+
+	var req *http.Request
+	if account.IsVertex() {
+		req, err = s.buildVertexClaudeRequest(ctx, account, testModelID, payloadBytes)
+	} else {
+		req, err = newBedrockRequest(ctx, account, s.validateUpstreamBaseURL, payloadBytes, testModelID, "", true)
+	}
+	if err != nil {
+		return s.sendErrorAndEnd(c, fmt.Sprintf("Failed to create request: %s", err.Error()))
+	}
+
+	proxyURL := ""
+	if account.ProxyID != nil && account.Proxy != nil {
+		proxyURL = account.Proxy.URL()
+	}
+
+	resp, err := s.httpUpstream.DoWithTLS(req, proxyURL, account.ID, account.Concurrency, false)
+	if err != nil {
+		return s.sendErrorAndEnd(c, fmt.Sprintf("Request failed: %s", err.Error()))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return s.sendErrorAndEnd(c, fmt.Sprintf("API returned %d: %s", resp.StatusCode, string(body)))
+	}
+
+	if account.IsBedrock() {
+		adaptBedrockResponse(resp, true)
+	}
+	return s.processClaudeStream(c, resp.Body)
+}
+
+// buildVertexClaudeRequest builds a streamRawPredict request for a Vertex AI account serving Claude models
+func (s *AccountTestService) buildVertexClaudeRequest(ctx context.Context, account *Account, modelID string, payload []byte) (*http.Request, error) {
+	if s.vertexTokenProvider == nil {
+		return nil, fmt.Errorf("vertex token provider not configured")
+	}
+	accessToken, err := s.vertexTokenProvider.GetAccessToken(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	targetURL, err := buildVertexModelURL(account, s.validateUpstreamBaseURL, vertexPublisherAnthropic, vertexAnthropicModelID(modelID), "streamRawPredict")
+	if err != nil {
+		return nil, err
+	}
+	body, err := buildVertexAnthropicRequestBody(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", targetURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	return req, nil
+}
+
 // testOpenAIAccountConnection tests an OpenAI account's connection
 func (s *AccountTestService) testOpenAIAccountConnection(c *gin.Context, account *Account, modelID string) error {
 	ctx := c.Request.Context()
@@ -291,6 +387,10 @@ func (s *AccountTestService) testOpenAIAccountConnection(c *gin.Context, account
 		testModelID = openai.DefaultTestModel
 	}
 
+	if account.IsBedrock() {
+		return s.testCloudProviderAccountConnection(c, account, testModelID)
+	}
+
 	// For API Key accounts with model mapping, map the model
 	if account.Type == "apikey" {
 		mapping := account.GetModelMapping()
@@ -391,6 +491,72 @@ func (s *AccountTestService) testOpenAIAccountConnection(c *gin.Context, account
 	return s.processOpenAIStream(c, resp.Body)
 }
 
+// testCompatibleAccountConnection tests an OpenAI-compatible upstream account via streaming Chat Completions
+func (s *AccountTestService) testCompatibleAccountConnection(c *gin.Context, account *Account, modelID string) error {
+	ctx := c.Request.Context()
+
+	testModelID := modelID
+	if testModelID == "" {
+		testModelID = compatible.DefaultTestModel
+	}
+	testModelID = account.GetMappedModel(testModelID)
+
+	apiKey := strings.TrimSpace(account.GetCredential("api_key"))
+	if apiKey == "" {
+		return s.sendErrorAndEnd(c, "No API key available")
+	}
+	chatURL := account.GetCompatibleChatCompletionsURL()
+	if chatURL == "" {
+		return s.sendErrorAndEnd(c, "No base URL configured")
+	}
+	apiURL, err := s.validateUpstreamBaseURL(chatURL)
+	if err != nil {
+		return s.sendErrorAndEnd(c, fmt.Sprintf("Invalid base URL: %s", err.Error()))
+	}
+
+	// Set SSE headers
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set("Cache-Control", "no-cache")
+	c.Writer.Header().Set("Connection", "keep-alive")
+	c.Writer.Header().Set("X-Accel-Buffering", "no")
+	c.Writer.Flush()
+
+	payloadBytes, _ := json.Marshal(map[string]any{
+		"model":      testModelID,
+		"messages":   []map[string]any{{"role": "user", "content": "hi"}},
+		"max_tokens": 32,
+		"stream":     true,
+	})
+
+	s.sendEvent(c, TestEvent{Type: "test_start", Model: testModelID})
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiURL, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return s.sendErrorAndEnd(c, "Failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	req.Header.Set("accept", "text/event-stream")
+
+	proxyURL := ""
+	if account.ProxyID != nil && account.Proxy != nil {
+		proxyURL = account.Proxy.URL()
+	}
+
+	resp, err := s.httpUpstream.Do(req, proxyURL, account.ID, account.Concurrency)
+	if err != nil {
+		return s.sendErrorAndEnd(c, fmt.Sprintf("Request failed: %s", err.Error()))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return s.sendErrorAndEnd(c, fmt.Sprintf("API returned %d: %s", resp.StatusCode, string(body)))
+	}
+
+	return s.processChatCompletionsStream(c, resp.Body)
+}
+
 // testGeminiAccountConnection tests a Gemini account's connection
 func (s *AccountTestService) testGeminiAccountConnection(c *gin.Context, account *Account, modelID string) error {
 	ctx := c.Request.Context()
@@ -401,8 +567,8 @@ func (s *AccountTestService) testGeminiAccountConnection(c *gin.Context, account
 		testModelID = geminicli.DefaultTestModel
 	}
 
-	// For API Key accounts with model mapping, map the model
-	if account.Type == AccountTypeAPIKey {
+	// For API Key / Vertex accounts with model mapping, map the model
+	if account.Type == AccountTypeAPIKey || account.IsVertex() {
 		mapping := account.GetModelMapping()
 		if len(mapping) > 0 {
 			if mappedModel, exists := mapping[testModelID]; exists {
@@ -430,6 +596,8 @@ func (s *AccountTestService) testGeminiAccountConnection(c *gin.Context, account
 		req, err = s.buildGeminiAPIKeyRequest(ctx, account, testModelID, payload)
 	case AccountTypeOAuth:
 		req, err = s.buildGeminiOAuthRequest(ctx, account, testModelID, payload)
+	case AccountTypeVertex:
+		req, err = s.buildGeminiVertexRequest(ctx, account, testModelID, payload)
 	default:
 		return s.sendErrorAndEnd(c, fmt.Sprintf("Unsupported account type: %s", account.Type))
 	}
@@ -533,6 +701,29 @@ func (s *AccountTestService) buildGeminiAPIKeyRequest(ctx context.Context, accou
 	return req, nil
 }
 
+// buildGeminiVertexRequest builds request for Gemini models on Vertex AI (service account)
+func (s *AccountTestService) buildGeminiVertexRequest(ctx context.Context, account *Account, modelID string, payload []byte) (*http.Request, error) {
+	if s.vertexTokenProvider == nil {
+		return nil, fmt.Errorf("vertex token provider not configured")
+	}
+	accessToken, err := s.vertexTokenProvider.GetAccessToken(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	targetURL, err := buildVertexModelURL(account, s.validateUpstreamBaseURL, vertexPublisherGoogle, modelID, "streamGenerateContent")
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", targetURL+"?alt=sse", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	return req, nil
+}
+
 // buildGeminiOAuthRequest builds request for Gemini OAuth accounts
 func (s *AccountTestService) buildGeminiOAuthRequest(ctx context.Context, account *Account, modelID string, payload []byte) (*http.Request, error) {
 	if s.geminiTokenProvider == nil {
@@ -830,6 +1021,45 @@ func (s *AccountTestService) processOpenAIStream(c *gin.Context, body io.Reader)
 	}
 }
 
+// processChatCompletionsStream processes a chat.completion.chunk SSE stream
+func (s *AccountTestService) processChatCompletionsStream(c *gin.Context, body io.Reader) error {
+	reader := bufio.NewReader(body)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				s.sendEvent(c, TestEvent{Type: "test_complete", Success: true})
+				return nil
+			}
+			return s.sendErrorAndEnd(c, fmt.Sprintf("Stream read error: %s", err.Error()))
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" || !sseDataPrefix.MatchString(line) {
+			continue
+		}
+
+		jsonStr := sseDataPrefix.ReplaceAllString(line, "")
+		if jsonStr == "[DONE]" {
+			s.sendEvent(c, TestEvent{Type: "test_complete", Success: true})
+			return nil
+		}
+
+		data := []byte(jsonStr)
+		if errMsg := gjson.GetBytes(data, "error.message").String(); errMsg != "" {
+			return s.sendErrorAndEnd(c, errMsg)
+		}
+		// 推理模型（如 deepseek-reasoner）先输出 reasoning_content，也视为有效响应
+		delta := gjson.GetBytes(data, "choices.0.delta")
+		for _, field := range []string{"reasoning_content", "content"} {
+			if text := delta.Get(field).String(); text != "" {
+				s.sendEvent(c, TestEvent{Type: "content", Text: text})
+			}
+		}
+	}
+}
+
 // sendEvent sends a SSE event to the client
 func (s *AccountTestService) sendEvent(c *gin.Context, event TestEvent) {
 	eventJSON, _ := json.Marshal(event)
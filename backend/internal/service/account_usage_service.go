@@ -49,6 +49,8 @@ type UsageLogRepository interface {
 	ListWithFilters(ctx context.Context, params pagination.PaginationParams, filters usagestats.UsageLogFilters) ([]UsageLog, *pagination.PaginationResult, error)
 	GetGlobalStats(ctx context.Context, startTime, endTime time.Time) (*usagestats.UsageStats, error)
 	GetStatsWithFilters(ctx context.Context, filters usagestats.UsageLogFilters) (*usagestats.UsageStats, error)
+	// StreamWithFilters 以游标方式逐条回调符合条件的记录（按 id 升序），用于大批量导出
+	StreamWithFilters(ctx context.Context, filters usagestats.UsageLogFilters, fn func(*UsageLog) error) error
 
 	// Account stats
 	GetAccountUsageStats(ctx context.Context, accountID int64, startTime, endTime time.Time) (*usagestats.AccountUsageStatsResponse, error)
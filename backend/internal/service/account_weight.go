@@ -0,0 +1,195 @@
+package service
+
+import (
+	mathrand "math/rand"
+)
+
+// 账号权重：同一优先级内按权重分配流量
+//
+// 权重保存在 account.extra.weight（正整数），未配置的账号按 DefaultAccountWeight 计算。
+// 只有当同一优先级内至少一个账号配置了权重时才启用加权随机选择，否则保持原有的“负载率 → LRU”规则，
+// 因此未使用该功能的部署行为不变。
+//
+// 负载感知路径中有效权重 = weight × 剩余容量比例（100 - LoadRate），避免继续把流量压向接近满载的账号；
+// 加权随机是无状态的，多实例部署下各实例独立采样，整体分布仍收敛到配置比例。
+
+const (
+	accountWeightExtraKey = "weight"
+
+	// DefaultAccountWeight 未配置权重的账号在加权选择中的权重
+	DefaultAccountWeight = 1
+	// MaxAccountWeight 权重上限，防止误配置导致单账号独占
+	MaxAccountWeight = 1000
+)
+
+// GetWeight 获取账号在同优先级内的调度权重
+// 返回 0 表示未配置
+func (a *Account) GetWeight() int {
+	if a == nil || a.Extra == nil {
+		return 0
+	}
+	v, ok := a.Extra[accountWeightExtraKey]
+	if !ok {
+		return 0
+	}
+	weight := parseExtraInt(v)
+	if weight <= 0 {
+		return 0
+	}
+	if weight > MaxAccountWeight {
+		return MaxAccountWeight
+	}
+	return weight
+}
+
+// EffectiveWeight 返回加权选择使用的权重（未配置时为 DefaultAccountWeight）
+func (a *Account) EffectiveWeight() int {
+	if weight := a.GetWeight(); weight > 0 {
+		return weight
+	}
+	return DefaultAccountWeight
+}
+
+// hasWeightedAccount 判断候选中是否有账号配置了权重
+func hasWeightedAccount(accounts []*Account) bool {
+	for _, acc := range accounts {
+		if acc.GetWeight() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// tierHasWeights 判断同一优先级集合中是否有账号配置了权重
+func tierHasWeights(items []accountWithLoad) bool {
+	for _, item := range items {
+		if item.account.GetWeight() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// loadAdjustedWeight 负载感知的有效权重
+func loadAdjustedWeight(item accountWithLoad) float64 {
+	free := 100
+	if item.loadInfo != nil {
+		free = 100 - item.loadInfo.LoadRate
+	}
+	if free <= 0 {
+		return 0
+	}
+	return float64(item.account.EffectiveWeight()) * float64(free) / 100
+}
+
+// weightedPick 按权重随机返回下标；权重全为 0 时返回 -1
+func weightedPick(weights []float64) int {
+	total := 0.0
+	for _, w := range weights {
+		if w > 0 {
+			total += w
+		}
+	}
+	if total <= 0 {
+		return -1
+	}
+	r := mathrand.Float64() * total
+	for i, w := range weights {
+		if w <= 0 {
+			continue
+		}
+		if r < w {
+			return i
+		}
+		r -= w
+	}
+	// 浮点误差兜底：返回最后一个正权重
+	for i := len(weights) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// selectByWeight 在同一优先级集合内按负载感知权重随机选择
+func selectByWeight(items []accountWithLoad) *accountWithLoad {
+	weights := make([]float64, len(items))
+	for i, item := range items {
+		weights[i] = loadAdjustedWeight(item)
+	}
+	idx := weightedPick(weights)
+	if idx < 0 {
+		return nil
+	}
+	return &items[idx]
+}
+
+// selectFromPriorityTier 在同一优先级集合中选择账号：
+// 配置了权重时按权重随机，否则按负载率 → LRU
+func selectFromPriorityTier(tier []accountWithLoad, preferOAuth bool) *accountWithLoad {
+	if tierHasWeights(tier) {
+		if selected := selectByWeight(tier); selected != nil {
+			return selected
+		}
+	}
+	return selectByLRU(filterByMinLoadRate(tier), preferOAuth)
+}
+
+// orderByWeightWithinPriority 对已按优先级排序的列表，在配置了权重的优先级分组内
+// 按权重不放回抽样重排，调用方依次尝试获取槽位时即按权重分配流量
+func orderByWeightWithinPriority(items []accountWithLoad) {
+	i := 0
+	for i < len(items) {
+		j := i + 1
+		for j < len(items) && items[j].account.Priority == items[i].account.Priority {
+			j++
+		}
+		if j-i > 1 && tierHasWeights(items[i:j]) {
+			weightedShuffle(items[i:j])
+		}
+		i = j
+	}
+}
+
+// weightedShuffle 按负载感知权重进行不放回抽样排序（权重为 0 的账号保持原相对顺序排在最后）
+func weightedShuffle(items []accountWithLoad) {
+	for start := 0; start < len(items)-1; start++ {
+		weights := make([]float64, len(items)-start)
+		for k := range weights {
+			weights[k] = loadAdjustedWeight(items[start+k])
+		}
+		idx := weightedPick(weights)
+		if idx < 0 {
+			return
+		}
+		picked := items[start+idx]
+		copy(items[start+1:start+idx+1], items[start:start+idx])
+		items[start] = picked
+	}
+}
+
+// preferWeightedInTier 用于非负载感知的选择路径：selected 为按“优先级 → LRU”选出的账号，
+// 若与其同优先级的候选中配置了权重，则改为在该优先级内按权重随机选择
+func preferWeightedInTier(selected *Account, eligible []*Account) *Account {
+	if selected == nil {
+		return nil
+	}
+	tier := make([]*Account, 0, len(eligible))
+	for _, acc := range eligible {
+		if acc.Priority == selected.Priority {
+			tier = append(tier, acc)
+		}
+	}
+	if len(tier) <= 1 || !hasWeightedAccount(tier) {
+		return selected
+	}
+	weights := make([]float64, len(tier))
+	for i, acc := range tier {
+		weights[i] = float64(acc.EffectiveWeight())
+	}
+	if idx := weightedPick(weights); idx >= 0 {
+		return tier[idx]
+	}
+	return selected
+}
@@ -0,0 +1,153 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func weightedAccount(id int64, priority int, weight any) *Account {
+	acc := &Account{ID: id, Priority: priority, Status: StatusActive, Schedulable: true, Concurrency: 10}
+	if weight != nil {
+		acc.Extra = map[string]any{"weight": weight}
+	}
+	return acc
+}
+
+func TestAccountGetWeight(t *testing.T) {
+	require.Equal(t, 0, weightedAccount(1, 0, nil).GetWeight())
+	require.Equal(t, DefaultAccountWeight, weightedAccount(1, 0, nil).EffectiveWeight())
+	require.Equal(t, 3, weightedAccount(1, 0, float64(3)).GetWeight())
+	require.Equal(t, 5, weightedAccount(1, 0, "5").GetWeight())
+	require.Equal(t, 0, weightedAccount(1, 0, -2).GetWeight())
+	require.Equal(t, DefaultAccountWeight, weightedAccount(1, 0, 0).EffectiveWeight())
+	require.Equal(t, MaxAccountWeight, weightedAccount(1, 0, 100000).GetWeight())
+}
+
+func TestSelectFromPriorityTier_WeightedDistribution(t *testing.T) {
+	tier := []accountWithLoad{
+		{account: weightedAccount(1, 0, 3), loadInfo: &AccountLoadInfo{AccountID: 1}},
+		{account: weightedAccount(2, 0, 1), loadInfo: &AccountLoadInfo{AccountID: 2}},
+	}
+	counts := map[int64]int{}
+	const rounds = 4000
+	for i := 0; i < rounds; i++ {
+		selected := selectFromPriorityTier(tier, false)
+		require.NotNil(t, selected)
+		counts[selected.account.ID]++
+	}
+	share := float64(counts[1]) / rounds
+	require.InDelta(t, 0.75, share, 0.05)
+}
+
+func TestSelectFromPriorityTier_LoadReducesWeight(t *testing.T) {
+	tier := []accountWithLoad{
+		{account: weightedAccount(1, 0, 1), loadInfo: &AccountLoadInfo{AccountID: 1, LoadRate: 100}},
+		{account: weightedAccount(2, 0, 1), loadInfo: &AccountLoadInfo{AccountID: 2, LoadRate: 50}},
+	}
+	for i := 0; i < 50; i++ {
+		require.Equal(t, int64(2), selectFromPriorityTier(tier, false).account.ID)
+	}
+}
+
+func TestSelectFromPriorityTier_NoWeightsKeepsLoadThenLRU(t *testing.T) {
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now()
+	a1 := weightedAccount(1, 0, nil)
+	a1.LastUsedAt = &newer
+	a2 := weightedAccount(2, 0, nil)
+	a2.LastUsedAt = &older
+	a3 := weightedAccount(3, 0, nil)
+	tier := []accountWithLoad{
+		{account: a1, loadInfo: &AccountLoadInfo{AccountID: 1, LoadRate: 10}},
+		{account: a2, loadInfo: &AccountLoadInfo{AccountID: 2, LoadRate: 10}},
+		{account: a3, loadInfo: &AccountLoadInfo{AccountID: 3, LoadRate: 20}},
+	}
+	for i := 0; i < 20; i++ {
+		require.Equal(t, int64(2), selectFromPriorityTier(tier, false).account.ID)
+	}
+}
+
+func TestOrderByWeightWithinPriority_KeepsPriorityOrder(t *testing.T) {
+	items := []accountWithLoad{
+		{account: weightedAccount(1, 0, 1), loadInfo: &AccountLoadInfo{AccountID: 1}},
+		{account: weightedAccount(2, 0, 9), loadInfo: &AccountLoadInfo{AccountID: 2}},
+		{account: weightedAccount(3, 1, nil), loadInfo: &AccountLoadInfo{AccountID: 3}},
+		{account: weightedAccount(4, 1, nil), loadInfo: &AccountLoadInfo{AccountID: 4}},
+	}
+	firstCounts := map[int64]int{}
+	for i := 0; i < 1000; i++ {
+		ordered := append([]accountWithLoad(nil), items...)
+		orderByWeightWithinPriority(ordered)
+		require.Equal(t, 0, ordered[0].account.Priority)
+		require.Equal(t, 0, ordered[1].account.Priority)
+		// 未配置权重的优先级保持原顺序
+		require.Equal(t, int64(3), ordered[2].account.ID)
+		require.Equal(t, int64(4), ordered[3].account.ID)
+		firstCounts[ordered[0].account.ID]++
+	}
+	require.Greater(t, firstCounts[2], firstCounts[1]*4)
+}
+
+func TestPreferWeightedInTier(t *testing.T) {
+	low := weightedAccount(1, 0, 1)
+	high := weightedAccount(2, 0, 1000)
+	other := weightedAccount(3, 1, 1000)
+
+	// 未配置权重时保持原选择
+	plain := weightedAccount(4, 0, nil)
+	plain2 := weightedAccount(5, 0, nil)
+	require.Same(t, plain, preferWeightedInTier(plain, []*Account{plain, plain2}))
+	require.Nil(t, preferWeightedInTier(nil, []*Account{plain}))
+
+	counts := map[int64]int{}
+	for i := 0; i < 500; i++ {
+		selected := preferWeightedInTier(low, []*Account{low, high, other})
+		counts[selected.ID]++
+	}
+	require.Zero(t, counts[3], "other priority tiers are never picked")
+	require.Greater(t, counts[2], 450)
+}
+
+func TestOpenAISelectBestAccount_UsesWeights(t *testing.T) {
+	svc := &OpenAIGatewayService{}
+	accounts := []Account{
+		{ID: 1, Platform: PlatformOpenAI, Priority: 0, Status: StatusActive, Schedulable: true, Extra: map[string]any{"weight": 1}},
+		{ID: 2, Platform: PlatformOpenAI, Priority: 0, Status: StatusActive, Schedulable: true, Extra: map[string]any{"weight": 1000}},
+		{ID: 3, Platform: PlatformOpenAI, Priority: 1, Status: StatusActive, Schedulable: true, Extra: map[string]any{"weight": 1000}},
+	}
+	counts := map[int64]int{}
+	for i := 0; i < 200; i++ {
+		counts[svc.selectBestAccount(accounts, "", nil).ID]++
+	}
+	require.Zero(t, counts[3])
+	require.Greater(t, counts[2], 180)
+}
+
+func TestBuildTrafficShareReport(t *testing.T) {
+	accounts := []Account{
+		*weightedAccount(1, 0, 3),
+		*weightedAccount(2, 0, nil),
+		*weightedAccount(3, 1, nil),
+	}
+	accounts[2].Schedulable = false
+	now := time.Now()
+	report := buildTrafficShareReport(7, now.Add(-time.Hour), now, accounts, map[int64]int64{1: 60, 2: 40, 3: 5})
+
+	require.Len(t, report.Tiers, 2)
+	tier0 := report.Tiers[0]
+	require.Equal(t, 0, tier0.Priority)
+	require.True(t, tier0.Weighted)
+	require.Equal(t, int64(100), tier0.TotalRequests)
+	require.InDelta(t, 0.75, tier0.Accounts[0].ConfiguredShare, 1e-9)
+	require.InDelta(t, 0.60, tier0.Accounts[0].ObservedShare, 1e-9)
+	require.InDelta(t, 0.25, tier0.Accounts[1].ConfiguredShare, 1e-9)
+
+	tier1 := report.Tiers[1]
+	require.False(t, tier1.Weighted)
+	require.Zero(t, tier1.Accounts[0].ConfiguredShare, "unschedulable accounts get no configured share")
+	require.InDelta(t, 1.0, tier1.Accounts[0].ObservedShare, 1e-9)
+}
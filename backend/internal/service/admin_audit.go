@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/util/logredact"
+)
+
+// 单次请求最多记录的实体差异数（批量操作时截断，避免单条日志过大）
+const adminAuditMaxChangesPerRequest = 200
+
+// adminAuditSensitiveKeys 差异中需要脱敏的字段（按 Go 字段名小写匹配）
+var adminAuditSensitiveKeys = []string{
+	"passwordhash",
+	"credentials",
+	"totpsecretencrypted",
+	"smtppassword",
+	"turnstilesecretkey",
+	"linuxdoconnectclientsecret",
+	"secret",
+	"key",
+}
+
+// adminAuditIgnoredKeys 不参与差异计算的字段（关联对象、时间戳等）
+var adminAuditIgnoredKeys = map[string]struct{}{
+	"UpdatedAt":     {},
+	"APIKeys":       {},
+	"Subscriptions": {},
+	"Proxy":         {},
+	"AccountGroups": {},
+	"Groups":        {},
+	"Group":         {},
+	"User":          {},
+}
+
+// AdminAuditLog 管理后台审计日志
+type AdminAuditLog struct {
+	ID          int64
+	ActorUserID *int64
+	AuthMethod  string
+	Method      string
+	Route       string
+	Path        string
+	StatusCode  int
+	TargetType  string
+	TargetID    *string
+	Changes     []AdminAuditChange
+	IPAddress   string
+	UserAgent   *string
+	CreatedAt   time.Time
+}
+
+// AdminAuditChange 单个实体的字段级差异（仅包含变化的字段，敏感字段已脱敏）
+type AdminAuditChange struct {
+	Entity   string         `json:"entity"`
+	EntityID int64          `json:"entity_id,omitempty"`
+	Action   string         `json:"action"`
+	Before   map[string]any `json:"before,omitempty"`
+	After    map[string]any `json:"after,omitempty"`
+}
+
+// Admin 审计动作
+const (
+	AdminAuditActionCreate = "create"
+	AdminAuditActionUpdate = "update"
+	AdminAuditActionDelete = "delete"
+	// AdminAuditActionExportCredentials 导出明文凭证（只读操作，但必须留痕）
+	AdminAuditActionExportCredentials = "export_credentials"
+)
+
+// AdminAuditLogFilters 审计日志查询条件
+type AdminAuditLogFilters struct {
+	ActorUserID *int64
+	Method      string
+	TargetType  string
+	TargetID    string
+	Route       string
+	StartTime   *time.Time
+	EndTime     *time.Time
+}
+
+// AdminAuditLogRepository 审计日志持久化接口
+type AdminAuditLogRepository interface {
+	Create(ctx context.Context, log *AdminAuditLog) error
+	List(ctx context.Context, params pagination.PaginationParams, filters AdminAuditLogFilters) ([]AdminAuditLog, *pagination.PaginationResult, error)
+}
+
+type adminAuditCtxKey struct{}
+
+// AdminAuditRecorder 收集单个管理请求内 Service 层提交的实体差异
+type AdminAuditRecorder struct {
+	mu      sync.Mutex
+	changes []AdminAuditChange
+}
+
+// WithAdminAuditRecorder 在 context 中挂载差异收集器（由审计中间件调用）
+func WithAdminAuditRecorder(ctx context.Context) (context.Context, *AdminAuditRecorder) {
+	recorder := &AdminAuditRecorder{}
+	return context.WithValue(ctx, adminAuditCtxKey{}, recorder), recorder
+}
+
+func adminAuditRecorderFromContext(ctx context.Context) *AdminAuditRecorder {
+	if ctx == nil {
+		return nil
+	}
+	recorder, _ := ctx.Value(adminAuditCtxKey{}).(*AdminAuditRecorder)
+	return recorder
+}
+
+// Changes 返回已收集的差异
+func (r *AdminAuditRecorder) Changes() []AdminAuditChange {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]AdminAuditChange, len(r.changes))
+	copy(out, r.changes)
+	return out
+}
+
+func (r *AdminAuditRecorder) add(change AdminAuditChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.changes) >= adminAuditMaxChangesPerRequest {
+		return
+	}
+	r.changes = append(r.changes, change)
+}
+
+// adminAuditSnapshot 在修改前对实体做快照（序列化为字段 map）。
+// context 中没有收集器时返回 nil，调用方无需额外判断。
+func adminAuditSnapshot(ctx context.Context, v any) map[string]any {
+	if adminAuditRecorderFromContext(ctx) == nil || isNilAuditValue(v) {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var out map[string]any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil
+	}
+	for k := range adminAuditIgnoredKeys {
+		delete(out, k)
+	}
+	return out
+}
+
+// recordAdminAuditChange 计算 before/after 的字段差异并写入收集器。
+// before 为 nil 表示创建，after 为 nil 表示删除；更新但无字段变化时不记录。
+func recordAdminAuditChange(ctx context.Context, entity string, entityID int64, before map[string]any, after any) {
+	recorder := adminAuditRecorderFromContext(ctx)
+	if recorder == nil {
+		return
+	}
+	afterSnap := adminAuditSnapshot(ctx, after)
+
+	change := AdminAuditChange{Entity: entity, EntityID: entityID}
+	switch {
+	case before == nil && afterSnap == nil:
+		return
+	case before == nil:
+		change.Action = AdminAuditActionCreate
+		change.After = afterSnap
+	case afterSnap == nil:
+		change.Action = AdminAuditActionDelete
+		change.Before = before
+	default:
+		change.Action = AdminAuditActionUpdate
+		change.Before, change.After = diffAuditSnapshots(before, afterSnap)
+		if len(change.Before) == 0 && len(change.After) == 0 {
+			return
+		}
+	}
+	if change.Before != nil {
+		change.Before = logredact.RedactMap(change.Before, adminAuditSensitiveKeys...)
+	}
+	if change.After != nil {
+		change.After = logredact.RedactMap(change.After, adminAuditSensitiveKeys...)
+	}
+	recorder.add(change)
+}
+
+// RecordAdminAuditAction 记录不修改实体的敏感操作（如导出明文凭证）。
+// 读请求中只要记录了动作，审计中间件同样会写入审计日志。
+func RecordAdminAuditAction(ctx context.Context, entity string, entityID int64, action string) {
+	recorder := adminAuditRecorderFromContext(ctx)
+	if recorder == nil {
+		return
+	}
+	recorder.add(AdminAuditChange{Entity: entity, EntityID: entityID, Action: action})
+}
+
+// diffAuditSnapshots 仅保留取值发生变化的字段
+func diffAuditSnapshots(before, after map[string]any) (map[string]any, map[string]any) {
+	keys := make(map[string]struct{}, len(before)+len(after))
+	for k := range before {
+		keys[k] = struct{}{}
+	}
+	for k := range after {
+		keys[k] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	outBefore := make(map[string]any)
+	outAfter := make(map[string]any)
+	for _, k := range sorted {
+		b, a := before[k], after[k]
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		outBefore[k] = b
+		outAfter[k] = a
+	}
+	return outBefore, outAfter
+}
+
+func isNilAuditValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+// AdminAuditService 管理后台审计日志服务
+type AdminAuditService struct {
+	repo AdminAuditLogRepository
+}
+
+// NewAdminAuditService 创建审计日志服务
+func NewAdminAuditService(repo AdminAuditLogRepository) *AdminAuditService {
+	return &AdminAuditService{repo: repo}
+}
+
+// Record 写入一条审计日志；失败只记录日志，不影响已完成的管理操作
+func (s *AdminAuditService) Record(ctx context.Context, entry *AdminAuditLog) {
+	if s == nil || s.repo == nil || entry == nil {
+		return
+	}
+	entry.Method = strings.ToUpper(entry.Method)
+	entry.Route = truncateString(entry.Route, 255)
+	entry.TargetType = truncateString(entry.TargetType, 64)
+	if entry.TargetID != nil {
+		v := truncateString(*entry.TargetID, 64)
+		entry.TargetID = &v
+	}
+	if entry.UserAgent != nil {
+		v := truncateString(*entry.UserAgent, 512)
+		entry.UserAgent = &v
+	}
+	if err := s.repo.Create(ctx, entry); err != nil {
+		log.Printf("[AdminAudit] write audit log failed: method=%s route=%s err=%v", entry.Method, entry.Route, err)
+	}
+}
+
+// List 分页查询审计日志
+func (s *AdminAuditService) List(ctx context.Context, params pagination.PaginationParams, filters AdminAuditLogFilters) ([]AdminAuditLog, *pagination.PaginationResult, error) {
+	filters.Method = strings.ToUpper(strings.TrimSpace(filters.Method))
+	return s.repo.List(ctx, params, filters)
+}
@@ -0,0 +1,73 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAdminAuditChange_NoRecorderIsNoop(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, adminAuditSnapshot(ctx, &User{ID: 1}))
+	require.NotPanics(t, func() {
+		recordAdminAuditChange(ctx, "user", 1, nil, &User{ID: 1})
+	})
+}
+
+func TestRecordAdminAuditChange_UpdateDiffOnlyChangedFields(t *testing.T) {
+	ctx, recorder := WithAdminAuditRecorder(context.Background())
+	user := &User{ID: 7, Email: "a@example.com", Balance: 10, Concurrency: 5, PasswordHash: "old-hash"}
+	before := adminAuditSnapshot(ctx, user)
+
+	user.Balance = 25
+	user.PasswordHash = "new-hash"
+	recordAdminAuditChange(ctx, "user", user.ID, before, user)
+
+	changes := recorder.Changes()
+	require.Len(t, changes, 1)
+	change := changes[0]
+	require.Equal(t, AdminAuditActionUpdate, change.Action)
+	require.Equal(t, int64(7), change.EntityID)
+	require.Equal(t, float64(10), change.Before["Balance"])
+	require.Equal(t, float64(25), change.After["Balance"])
+	require.Equal(t, "***", change.Before["PasswordHash"])
+	require.Equal(t, "***", change.After["PasswordHash"])
+	require.NotContains(t, change.After, "Email")
+	require.NotContains(t, change.After, "Concurrency")
+}
+
+func TestRecordAdminAuditChange_UnchangedUpdateSkipped(t *testing.T) {
+	ctx, recorder := WithAdminAuditRecorder(context.Background())
+	account := &Account{ID: 1, Name: "a", Credentials: map[string]any{"api_key": "sk-xxx"}}
+	before := adminAuditSnapshot(ctx, account)
+	recordAdminAuditChange(ctx, "account", account.ID, before, account)
+	require.Empty(t, recorder.Changes())
+}
+
+func TestRecordAdminAuditChange_CreateAndDeleteRedactCredentials(t *testing.T) {
+	ctx, recorder := WithAdminAuditRecorder(context.Background())
+	account := &Account{ID: 3, Name: "acc", Credentials: map[string]any{"api_key": "sk-secret"}}
+
+	recordAdminAuditChange(ctx, "account", account.ID, nil, account)
+	recordAdminAuditChange(ctx, "account", account.ID, adminAuditSnapshot(ctx, account), nil)
+
+	changes := recorder.Changes()
+	require.Len(t, changes, 2)
+	require.Equal(t, AdminAuditActionCreate, changes[0].Action)
+	require.Equal(t, "***", changes[0].After["Credentials"])
+	require.Nil(t, changes[0].Before)
+	require.Equal(t, AdminAuditActionDelete, changes[1].Action)
+	require.Equal(t, "***", changes[1].Before["Credentials"])
+	require.Equal(t, "acc", changes[1].Before["Name"])
+}
+
+func TestAdminAuditRecorder_CapsChanges(t *testing.T) {
+	ctx, recorder := WithAdminAuditRecorder(context.Background())
+	for i := 0; i < adminAuditMaxChangesPerRequest+10; i++ {
+		recordAdminAuditChange(ctx, "proxy", int64(i+1), map[string]any{"ID": i + 1}, nil)
+	}
+	require.Len(t, recorder.Changes(), adminAuditMaxChangesPerRequest)
+}
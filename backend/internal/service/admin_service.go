@@ -49,6 +49,8 @@ type AdminService interface {
 	ClearAccountError(ctx context.Context, id int64) (*Account, error)
 	SetAccountError(ctx context.Context, id int64, errorMsg string) error
 	SetAccountSchedulable(ctx context.Context, id int64, schedulable bool) (*Account, error)
+	StartAccountDrain(ctx context.Context, id int64, input *StartAccountDrainInput) (*Account, error)
+	CancelAccountDrain(ctx context.Context, id int64) (*Account, error)
 	BulkUpdateAccounts(ctx context.Context, input *BulkUpdateAccountsInput) (*BulkUpdateAccountsResult, error)
 
 	// Proxy management
@@ -124,6 +126,17 @@ type CreateGroupInput struct {
 	MCPXMLInject        *bool
 	// 支持的模型系列（仅 antigravity 平台使用）
 	SupportedModelScopes []string
+	// API Key 默认限流（0 = 不限制）
+	DefaultRPMLimit          int
+	DefaultTPMLimit          int
+	DefaultDailyRequestLimit int
+	// 响应缓存（TTL 为 0 时使用全局默认值）
+	ResponseCacheEnabled    bool
+	ResponseCacheTTLSeconds int
+	// Claude → OpenAI 兜底模型映射（为空表示不启用）
+	OpenAIFallbackModelMapping map[string]string
+	// 分组请求策略（nil 表示不启用）
+	RequestPolicy *RequestPolicy
 	// 从指定分组复制账号（创建分组后在同一事务内绑定）
 	CopyAccountsFromGroupIDs []int64
 }
@@ -153,6 +166,17 @@ type UpdateGroupInput struct {
 	MCPXMLInject        *bool
 	// 支持的模型系列（仅 antigravity 平台使用）
 	SupportedModelScopes *[]string
+	// API Key 默认限流（nil = 不修改，0 = 不限制）
+	DefaultRPMLimit          *int
+	DefaultTPMLimit          *int
+	DefaultDailyRequestLimit *int
+	// 响应缓存（nil = 不修改）
+	ResponseCacheEnabled    *bool
+	ResponseCacheTTLSeconds *int
+	// Claude → OpenAI 兜底模型映射（nil = 不修改，空 map = 清空）
+	OpenAIFallbackModelMapping map[string]string
+	// 分组请求策略（nil = 不修改，空对象 = 清除）
+	RequestPolicy *RequestPolicy
 	// 从指定分组复制账号（同步操作：先清空当前分组的账号绑定，再绑定源分组的账号）
 	CopyAccountsFromGroupIDs []int64
 }
@@ -391,6 +415,7 @@ func (s *adminServiceImpl) CreateUser(ctx context.Context, input *CreateUserInpu
 	if err := s.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
+	recordAdminAuditChange(ctx, "user", user.ID, nil, user)
 	return user, nil
 }
 
@@ -405,6 +430,7 @@ func (s *adminServiceImpl) UpdateUser(ctx context.Context, id int64, input *Upda
 		return nil, errors.New("cannot disable admin user")
 	}
 
+	auditBefore := adminAuditSnapshot(ctx, user)
 	oldConcurrency := user.Concurrency
 	oldStatus := user.Status
 	oldRole := user.Role
@@ -440,6 +466,7 @@ func (s *adminServiceImpl) UpdateUser(ctx context.Context, id int64, input *Upda
 	if err := s.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
+	recordAdminAuditChange(ctx, "user", user.ID, auditBefore, user)
 
 	// 同步用户专属分组倍率
 	if input.GroupRates != nil && s.userGroupRateRepo != nil {
@@ -487,10 +514,12 @@ func (s *adminServiceImpl) DeleteUser(ctx context.Context, id int64) error {
 	if user.Role == "admin" {
 		return errors.New("cannot delete admin user")
 	}
+	auditBefore := adminAuditSnapshot(ctx, user)
 	if err := s.userRepo.Delete(ctx, id); err != nil {
 		log.Printf("delete user failed: user_id=%d err=%v", id, err)
 		return err
 	}
+	recordAdminAuditChange(ctx, "user", id, auditBefore, nil)
 	if s.authCacheInvalidator != nil {
 		s.authCacheInvalidator.InvalidateAuthCacheByUserID(ctx, id)
 	}
@@ -503,25 +532,45 @@ func (s *adminServiceImpl) UpdateUserBalance(ctx context.Context, userID int64,
 		return nil, err
 	}
 
+	auditBefore := adminAuditSnapshot(ctx, user)
 	oldBalance := user.Balance
 
+	newBalance := oldBalance
 	switch operation {
 	case "set":
-		user.Balance = balance
+		newBalance = balance
 	case "add":
-		user.Balance += balance
+		newBalance += balance
 	case "subtract":
-		user.Balance -= balance
+		newBalance -= balance
 	}
 
-	if user.Balance < 0 {
-		return nil, fmt.Errorf("balance cannot be negative, current balance: %.2f, requested operation would result in: %.2f", oldBalance, user.Balance)
+	if newBalance < 0 {
+		return nil, fmt.Errorf("balance cannot be negative, current balance: %.2f, requested operation would result in: %.2f", oldBalance, newBalance)
+	}
+	if newBalance == oldBalance {
+		return user, nil
 	}
 
-	if err := s.userRepo.Update(ctx, user); err != nil {
+	// 余额变更与流水写入在仓储层原子完成；add/subtract 按增量计入，避免覆盖并发扣费
+	entry := &BalanceLedgerEntry{
+		UserID:      userID,
+		Type:        BalanceLedgerTypeAdminAdjust,
+		ReferenceID: balanceLedgerActorFromContext(ctx),
+		Note:        notes,
+	}
+	if operation == "set" {
+		err = s.userRepo.SetBalance(ctx, entry, newBalance)
+	} else {
+		entry.Amount = newBalance - oldBalance
+		err = s.userRepo.ApplyBalanceChange(ctx, entry)
+	}
+	if err != nil {
 		return nil, err
 	}
-	balanceDiff := user.Balance - oldBalance
+	user.Balance = entry.BalanceAfter
+	recordAdminAuditChange(ctx, "user", user.ID, auditBefore, user)
+	balanceDiff := entry.Amount
 	if s.authCacheInvalidator != nil && balanceDiff != 0 {
 		s.authCacheInvalidator.InvalidateAuthCacheByUserID(ctx, userID)
 	}
@@ -663,6 +712,11 @@ func (s *adminServiceImpl) CreateGroup(ctx context.Context, input *CreateGroupIn
 		mcpXMLInject = *input.MCPXMLInject
 	}
 
+	requestPolicy, err := normalizeRequestPolicy(input.RequestPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	// 如果指定了复制账号的源分组，先获取账号 ID 列表
 	var accountIDsToCopy []int64
 	if len(input.CopyAccountsFromGroupIDs) > 0 {
@@ -715,10 +769,18 @@ func (s *adminServiceImpl) CreateGroup(ctx context.Context, input *CreateGroupIn
 		ModelRouting:                    input.ModelRouting,
 		MCPXMLInject:                    mcpXMLInject,
 		SupportedModelScopes:            input.SupportedModelScopes,
+		DefaultRPMLimit:                 input.DefaultRPMLimit,
+		DefaultTPMLimit:                 input.DefaultTPMLimit,
+		DefaultDailyRequestLimit:        input.DefaultDailyRequestLimit,
+		ResponseCacheEnabled:            input.ResponseCacheEnabled,
+		ResponseCacheTTLSeconds:         input.ResponseCacheTTLSeconds,
+		OpenAIFallbackModelMapping:      input.OpenAIFallbackModelMapping,
+		RequestPolicy:                   requestPolicy,
 	}
 	if err := s.groupRepo.Create(ctx, group); err != nil {
 		return nil, err
 	}
+	recordAdminAuditChange(ctx, "group", group.ID, nil, group)
 
 	// 如果有需要复制的账号，绑定到新分组
 	if len(accountIDsToCopy) > 0 {
@@ -731,6 +793,21 @@ func (s *adminServiceImpl) CreateGroup(ctx context.Context, input *CreateGroupIn
 	return group, nil
 }
 
+// normalizeRequestPolicy 校验分组请求策略，未配置任何规则时返回 nil
+func normalizeRequestPolicy(policy *RequestPolicy) (*RequestPolicy, error) {
+	if policy == nil {
+		return nil, nil
+	}
+	normalized, err := policy.NormalizeAndValidate()
+	if err != nil {
+		return nil, err
+	}
+	if normalized.IsEmpty() {
+		return nil, nil
+	}
+	return &normalized, nil
+}
+
 // normalizeLimit 将 0 或负数转换为 nil（表示无限制）
 func normalizeLimit(limit *float64) *float64 {
 	if limit == nil || *limit <= 0 {
@@ -821,6 +898,7 @@ func (s *adminServiceImpl) UpdateGroup(ctx context.Context, id int64, input *Upd
 	if err != nil {
 		return nil, err
 	}
+	auditBefore := adminAuditSnapshot(ctx, group)
 
 	if input.Name != "" {
 		group.Name = input.Name
@@ -913,9 +991,43 @@ func (s *adminServiceImpl) UpdateGroup(ctx context.Context, id int64, input *Upd
 		group.SupportedModelScopes = *input.SupportedModelScopes
 	}
 
+	// API Key 默认限流
+	if input.DefaultRPMLimit != nil {
+		group.DefaultRPMLimit = *input.DefaultRPMLimit
+	}
+	if input.DefaultTPMLimit != nil {
+		group.DefaultTPMLimit = *input.DefaultTPMLimit
+	}
+	if input.DefaultDailyRequestLimit != nil {
+		group.DefaultDailyRequestLimit = *input.DefaultDailyRequestLimit
+	}
+
+	// 响应缓存
+	if input.ResponseCacheEnabled != nil {
+		group.ResponseCacheEnabled = *input.ResponseCacheEnabled
+	}
+	if input.ResponseCacheTTLSeconds != nil {
+		group.ResponseCacheTTLSeconds = *input.ResponseCacheTTLSeconds
+	}
+
+	// Claude → OpenAI 兜底模型映射
+	if input.OpenAIFallbackModelMapping != nil {
+		group.OpenAIFallbackModelMapping = input.OpenAIFallbackModelMapping
+	}
+
+	// 分组请求策略：空对象表示清除
+	if input.RequestPolicy != nil {
+		requestPolicy, err := normalizeRequestPolicy(input.RequestPolicy)
+		if err != nil {
+			return nil, err
+		}
+		group.RequestPolicy = requestPolicy
+	}
+
 	if err := s.groupRepo.Update(ctx, group); err != nil {
 		return nil, err
 	}
+	recordAdminAuditChange(ctx, "group", group.ID, auditBefore, group)
 
 	// 如果指定了复制账号的源分组，同步绑定（替换当前分组的账号）
 	if len(input.CopyAccountsFromGroupIDs) > 0 {
@@ -971,11 +1083,18 @@ func (s *adminServiceImpl) UpdateGroup(ctx context.Context, id int64, input *Upd
 }
 
 func (s *adminServiceImpl) DeleteGroup(ctx context.Context, id int64) error {
-	var groupKeys []string
+	var auditBefore map[string]any
+	if adminAuditRecorderFromContext(ctx) != nil {
+		if group, err := s.groupRepo.GetByID(ctx, id); err == nil {
+			auditBefore = adminAuditSnapshot(ctx, group)
+		}
+	}
+
+	var groupKeyPrefixes []string
 	if s.authCacheInvalidator != nil {
-		keys, err := s.apiKeyRepo.ListKeysByGroupID(ctx, id)
+		prefixes, err := s.apiKeyRepo.ListKeyPrefixesByGroupID(ctx, id)
 		if err == nil {
-			groupKeys = keys
+			groupKeyPrefixes = prefixes
 		}
 	}
 
@@ -983,6 +1102,7 @@ func (s *adminServiceImpl) DeleteGroup(ctx context.Context, id int64) error {
 	if err != nil {
 		return err
 	}
+	recordAdminAuditChange(ctx, "group", id, auditBefore, nil)
 	// 注意：user_group_rate_multipliers 表通过外键 ON DELETE CASCADE 自动清理
 
 	// 事务成功后，异步失效受影响用户的订阅缓存
@@ -999,8 +1119,8 @@ func (s *adminServiceImpl) DeleteGroup(ctx context.Context, id int64) error {
 		}()
 	}
 	if s.authCacheInvalidator != nil {
-		for _, key := range groupKeys {
-			s.authCacheInvalidator.InvalidateAuthCacheByKey(ctx, key)
+		for _, prefix := range groupKeyPrefixes {
+			s.authCacheInvalidator.InvalidateAuthCacheByKeyPrefix(ctx, prefix)
 		}
 	}
 
@@ -1048,6 +1168,10 @@ func (s *adminServiceImpl) GetAccountsByIDs(ctx context.Context, ids []int64) ([
 }
 
 func (s *adminServiceImpl) CreateAccount(ctx context.Context, input *CreateAccountInput) (*Account, error) {
+	if err := ValidateAccountScheduleExtra(input.Extra); err != nil {
+		return nil, err
+	}
+
 	// 绑定分组
 	groupIDs := input.GroupIDs
 	// 如果没有指定分组,自动绑定对应平台的默认分组
@@ -1102,6 +1226,7 @@ func (s *adminServiceImpl) CreateAccount(ctx context.Context, input *CreateAccou
 	if err := s.accountRepo.Create(ctx, account); err != nil {
 		return nil, err
 	}
+	recordAdminAuditChange(ctx, "account", account.ID, nil, account)
 
 	// 绑定分组
 	if len(groupIDs) > 0 {
@@ -1118,6 +1243,7 @@ func (s *adminServiceImpl) UpdateAccount(ctx context.Context, id int64, input *U
 	if err != nil {
 		return nil, err
 	}
+	auditBefore := adminAuditSnapshot(ctx, account)
 
 	if input.Name != "" {
 		account.Name = input.Name
@@ -1132,6 +1258,9 @@ func (s *adminServiceImpl) UpdateAccount(ctx context.Context, id int64, input *U
 		account.Credentials = input.Credentials
 	}
 	if len(input.Extra) > 0 {
+		if err := ValidateAccountScheduleExtra(input.Extra); err != nil {
+			return nil, err
+		}
 		account.Extra = input.Extra
 	}
 	if input.ProxyID != nil {
@@ -1200,7 +1329,12 @@ func (s *adminServiceImpl) UpdateAccount(ctx context.Context, id int64, input *U
 	}
 
 	// 重新查询以确保返回完整数据（包括正确的 Proxy 关联对象）
-	return s.accountRepo.GetByID(ctx, id)
+	updated, err := s.accountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	recordAdminAuditChange(ctx, "account", id, auditBefore, updated)
+	return updated, nil
 }
 
 // BulkUpdateAccounts updates multiple accounts in one request.
@@ -1235,6 +1369,9 @@ func (s *adminServiceImpl) BulkUpdateAccounts(ctx context.Context, input *BulkUp
 			return nil, errors.New("rate_multiplier must be >= 0")
 		}
 	}
+	if err := ValidateAccountScheduleExtra(input.Extra); err != nil {
+		return nil, err
+	}
 
 	// Prepare bulk updates for columns and JSONB fields.
 	repoUpdates := AccountBulkUpdate{
@@ -1263,6 +1400,8 @@ func (s *adminServiceImpl) BulkUpdateAccounts(ctx context.Context, input *BulkUp
 		repoUpdates.Schedulable = input.Schedulable
 	}
 
+	auditBefore := s.snapshotAccountsForAudit(ctx, input.AccountIDs)
+
 	// Run bulk update for column/jsonb fields first.
 	if _, err := s.accountRepo.BulkUpdate(ctx, input.AccountIDs, repoUpdates); err != nil {
 		return nil, err
@@ -1314,11 +1453,51 @@ func (s *adminServiceImpl) BulkUpdateAccounts(ctx context.Context, input *BulkUp
 		result.Results = append(result.Results, entry)
 	}
 
+	s.recordAccountsAudit(ctx, input.AccountIDs, auditBefore)
 	return result, nil
 }
 
+// snapshotAccountsForAudit 批量操作前为审计日志记录账号快照（未启用审计时不查询）
+func (s *adminServiceImpl) snapshotAccountsForAudit(ctx context.Context, ids []int64) map[int64]map[string]any {
+	if adminAuditRecorderFromContext(ctx) == nil || len(ids) == 0 {
+		return nil
+	}
+	accounts, err := s.accountRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil
+	}
+	out := make(map[int64]map[string]any, len(accounts))
+	for _, account := range accounts {
+		if account != nil {
+			out[account.ID] = adminAuditSnapshot(ctx, account)
+		}
+	}
+	return out
+}
+
+func (s *adminServiceImpl) recordAccountsAudit(ctx context.Context, ids []int64, before map[int64]map[string]any) {
+	if len(before) == 0 {
+		return
+	}
+	accounts, err := s.accountRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return
+	}
+	for _, account := range accounts {
+		if account == nil || before[account.ID] == nil {
+			continue
+		}
+		recordAdminAuditChange(ctx, "account", account.ID, before[account.ID], account)
+	}
+}
+
 func (s *adminServiceImpl) DeleteAccount(ctx context.Context, id int64) error {
-	return s.accountRepo.Delete(ctx, id)
+	auditBefore := s.snapshotAccountsForAudit(ctx, []int64{id})[id]
+	if err := s.accountRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	recordAdminAuditChange(ctx, "account", id, auditBefore, nil)
+	return nil
 }
 
 func (s *adminServiceImpl) RefreshAccountCredentials(ctx context.Context, id int64) (*Account, error) {
@@ -1335,11 +1514,13 @@ func (s *adminServiceImpl) ClearAccountError(ctx context.Context, id int64) (*Ac
 	if err != nil {
 		return nil, err
 	}
+	auditBefore := adminAuditSnapshot(ctx, account)
 	account.Status = StatusActive
 	account.ErrorMessage = ""
 	if err := s.accountRepo.Update(ctx, account); err != nil {
 		return nil, err
 	}
+	recordAdminAuditChange(ctx, "account", id, auditBefore, account)
 	return account, nil
 }
 
@@ -1348,10 +1529,70 @@ func (s *adminServiceImpl) SetAccountError(ctx context.Context, id int64, errorM
 }
 
 func (s *adminServiceImpl) SetAccountSchedulable(ctx context.Context, id int64, schedulable bool) (*Account, error) {
+	auditBefore := s.snapshotAccountsForAudit(ctx, []int64{id})[id]
 	if err := s.accountRepo.SetSchedulable(ctx, id, schedulable); err != nil {
 		return nil, err
 	}
-	return s.accountRepo.GetByID(ctx, id)
+	account, err := s.accountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// 显式切换调度状态会覆盖进行中的 drain
+	if account.IsDraining() {
+		if err := s.accountRepo.UpdateExtra(ctx, id, clearAccountDrainExtra()); err != nil {
+			return nil, err
+		}
+		delete(account.Extra, accountDrainExtraKey)
+		account.ParseDrain()
+	}
+	recordAdminAuditChange(ctx, "account", id, auditBefore, account)
+	return account, nil
+}
+
+// StartAccountDrain 将账号切换为 drain 状态：只承接已有粘性会话，空闲或到达截止时间后自动关闭调度
+func (s *adminServiceImpl) StartAccountDrain(ctx context.Context, id int64, input *StartAccountDrainInput) (*Account, error) {
+	account, err := s.accountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !account.IsActive() || !account.Schedulable {
+		return nil, ErrAccountNotSchedulable
+	}
+	updates, err := newAccountDrainExtra(time.Now(), input)
+	if err != nil {
+		return nil, err
+	}
+	auditBefore := adminAuditSnapshot(ctx, account)
+	if err := s.accountRepo.UpdateExtra(ctx, id, updates); err != nil {
+		return nil, err
+	}
+	account, err = s.accountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	recordAdminAuditChange(ctx, "account", id, auditBefore, account)
+	return account, nil
+}
+
+// CancelAccountDrain 取消 drain，账号恢复为普通可调度状态
+func (s *adminServiceImpl) CancelAccountDrain(ctx context.Context, id int64) (*Account, error) {
+	account, err := s.accountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !account.IsDraining() {
+		return account, nil
+	}
+	auditBefore := adminAuditSnapshot(ctx, account)
+	if err := s.accountRepo.UpdateExtra(ctx, id, clearAccountDrainExtra()); err != nil {
+		return nil, err
+	}
+	account, err = s.accountRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	recordAdminAuditChange(ctx, "account", id, auditBefore, account)
+	return account, nil
 }
 
 // Proxy management implementations
@@ -1408,6 +1649,7 @@ func (s *adminServiceImpl) CreateProxy(ctx context.Context, input *CreateProxyIn
 	if err := s.proxyRepo.Create(ctx, proxy); err != nil {
 		return nil, err
 	}
+	recordAdminAuditChange(ctx, "proxy", proxy.ID, nil, proxy)
 	// Probe latency asynchronously so creation isn't blocked by network timeout.
 	go s.probeProxyLatency(context.Background(), proxy)
 	return proxy, nil
@@ -1418,6 +1660,7 @@ func (s *adminServiceImpl) UpdateProxy(ctx context.Context, id int64, input *Upd
 	if err != nil {
 		return nil, err
 	}
+	auditBefore := adminAuditSnapshot(ctx, proxy)
 
 	if input.Name != "" {
 		proxy.Name = input.Name
@@ -1444,6 +1687,7 @@ func (s *adminServiceImpl) UpdateProxy(ctx context.Context, id int64, input *Upd
 	if err := s.proxyRepo.Update(ctx, proxy); err != nil {
 		return nil, err
 	}
+	recordAdminAuditChange(ctx, "proxy", id, auditBefore, proxy)
 	return proxy, nil
 }
 
@@ -1455,7 +1699,11 @@ func (s *adminServiceImpl) DeleteProxy(ctx context.Context, id int64) error {
 	if count > 0 {
 		return ErrProxyInUse
 	}
-	return s.proxyRepo.Delete(ctx, id)
+	if err := s.proxyRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	recordAdminAuditChange(ctx, "proxy", id, map[string]any{"ID": id}, nil)
+	return nil
 }
 
 func (s *adminServiceImpl) BatchDeleteProxies(ctx context.Context, ids []int64) (*ProxyBatchDeleteResult, error) {
@@ -1487,6 +1735,7 @@ func (s *adminServiceImpl) BatchDeleteProxies(ctx context.Context, ids []int64)
 			})
 			continue
 		}
+		recordAdminAuditChange(ctx, "proxy", id, map[string]any{"ID": id}, nil)
 		result.DeletedIDs = append(result.DeletedIDs, id)
 	}
 
@@ -78,6 +78,14 @@ func (s *userRepoStub) DeductBalance(ctx context.Context, id int64, amount float
 	panic("unexpected DeductBalance call")
 }
 
+func (s *userRepoStub) ApplyBalanceChange(ctx context.Context, entry *BalanceLedgerEntry) error {
+	panic("unexpected ApplyBalanceChange call")
+}
+
+func (s *userRepoStub) SetBalance(ctx context.Context, entry *BalanceLedgerEntry, balance float64) error {
+	panic("unexpected SetBalance call")
+}
+
 func (s *userRepoStub) UpdateConcurrency(ctx context.Context, id int64, amount int) error {
 	panic("unexpected UpdateConcurrency call")
 }
@@ -13,6 +13,26 @@ type balanceUserRepoStub struct {
 	*userRepoStub
 	updateErr error
 	updated   []*User
+	entries   []BalanceLedgerEntry
+}
+
+func (s *balanceUserRepoStub) ApplyBalanceChange(ctx context.Context, entry *BalanceLedgerEntry) error {
+	return s.SetBalance(ctx, entry, s.userRepoStub.user.Balance+entry.Amount)
+}
+
+func (s *balanceUserRepoStub) SetBalance(ctx context.Context, entry *BalanceLedgerEntry, balance float64) error {
+	if s.updateErr != nil {
+		return s.updateErr
+	}
+	entry.BalanceBefore = s.userRepoStub.user.Balance
+	entry.BalanceAfter = balance
+	entry.Amount = balance - entry.BalanceBefore
+	entry.ID = int64(len(s.entries) + 1)
+	s.entries = append(s.entries, *entry)
+	clone := *s.userRepoStub.user
+	clone.Balance = balance
+	s.userRepoStub.user = &clone
+	return nil
 }
 
 func (s *balanceUserRepoStub) Update(ctx context.Context, user *User) error {
@@ -47,11 +67,11 @@ func (s *balanceRedeemRepoStub) Create(ctx context.Context, code *RedeemCode) er
 type authCacheInvalidatorStub struct {
 	userIDs  []int64
 	groupIDs []int64
-	keys     []string
+	prefixes []string
 }
 
-func (s *authCacheInvalidatorStub) InvalidateAuthCacheByKey(ctx context.Context, key string) {
-	s.keys = append(s.keys, key)
+func (s *authCacheInvalidatorStub) InvalidateAuthCacheByKeyPrefix(ctx context.Context, prefix string) {
+	s.prefixes = append(s.prefixes, prefix)
 }
 
 func (s *authCacheInvalidatorStub) InvalidateAuthCacheByUserID(ctx context.Context, userID int64) {
@@ -95,3 +115,34 @@ func TestAdminService_UpdateUserBalance_NoChangeNoInvalidate(t *testing.T) {
 	require.Empty(t, invalidator.userIDs)
 	require.Empty(t, redeemRepo.created)
 }
+
+func TestAdminService_UpdateUserBalance_WritesLedgerEntry(t *testing.T) {
+	baseRepo := &userRepoStub{user: &User{ID: 7, Balance: 10}}
+	repo := &balanceUserRepoStub{userRepoStub: baseRepo}
+	redeemRepo := &balanceRedeemRepoStub{redeemRepoStub: &redeemRepoStub{}}
+	svc := &adminServiceImpl{
+		userRepo:       repo,
+		redeemCodeRepo: redeemRepo,
+	}
+
+	ctx := WithBalanceLedgerActor(context.Background(), 1)
+	user, err := svc.UpdateUserBalance(ctx, 7, 4, "subtract", "refund correction")
+	require.NoError(t, err)
+	require.Equal(t, 6.0, user.Balance)
+
+	require.Len(t, repo.entries, 1)
+	entry := repo.entries[0]
+	require.Equal(t, BalanceLedgerTypeAdminAdjust, entry.Type)
+	require.Equal(t, -4.0, entry.Amount)
+	require.Equal(t, 10.0, entry.BalanceBefore)
+	require.Equal(t, 6.0, entry.BalanceAfter)
+	require.NotNil(t, entry.ReferenceID)
+	require.Equal(t, int64(1), *entry.ReferenceID)
+	require.Equal(t, "refund correction", entry.Note)
+
+	_, err = svc.UpdateUserBalance(context.Background(), 7, 20, "set", "")
+	require.NoError(t, err)
+	require.Len(t, repo.entries, 2)
+	require.Equal(t, 14.0, repo.entries[1].Amount)
+	require.Nil(t, repo.entries[1].ReferenceID)
+}
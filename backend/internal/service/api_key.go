@@ -13,7 +13,10 @@ const (
 type APIKey struct {
 	ID          int64
 	UserID      int64
-	Key         string
+	Key         string // 明文仅在创建时返回一次，从存储加载时为空
+	KeyPrefix   string
+	KeySalt     string
+	KeyHash     string
 	Name        string
 	GroupID     *int64
 	Status      string
@@ -28,6 +31,11 @@ type APIKey struct {
 	Quota     float64    // Quota limit in USD (0 = unlimited)
 	QuotaUsed float64    // Used quota amount
 	ExpiresAt *time.Time // Expiration time (nil = never expires)
+
+	// Rate limit fields (0 = inherit group default)
+	RPMLimit          int // Requests per minute
+	TPMLimit          int // Tokens per minute
+	DailyRequestLimit int // Requests per day
 }
 
 func (k *APIKey) IsActive() bool {
@@ -6,6 +6,8 @@ import "time"
 type APIKeyAuthSnapshot struct {
 	APIKeyID    int64                    `json:"api_key_id"`
 	UserID      int64                    `json:"user_id"`
+	KeySalt     string                   `json:"key_salt"`
+	KeyHash     string                   `json:"key_hash"`
 	GroupID     *int64                   `json:"group_id,omitempty"`
 	Status      string                   `json:"status"`
 	IPWhitelist []string                 `json:"ip_whitelist,omitempty"`
@@ -19,6 +21,11 @@ type APIKeyAuthSnapshot struct {
 
 	// Expiration field for API Key expiration feature
 	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Expiration time (nil = never expires)
+
+	// Rate limit fields (0 = inherit group default)
+	RPMLimit          int `json:"rpm_limit,omitempty"`
+	TPMLimit          int `json:"tpm_limit,omitempty"`
+	DailyRequestLimit int `json:"daily_request_limit,omitempty"`
 }
 
 // APIKeyAuthUserSnapshot 用户快照
@@ -56,10 +63,27 @@ type APIKeyAuthGroupSnapshot struct {
 
 	// 支持的模型系列（仅 antigravity 平台使用）
 	SupportedModelScopes []string `json:"supported_model_scopes,omitempty"`
+
+	// API Key 默认限流
+	DefaultRPMLimit          int `json:"default_rpm_limit,omitempty"`
+	DefaultTPMLimit          int `json:"default_tpm_limit,omitempty"`
+	DefaultDailyRequestLimit int `json:"default_daily_request_limit,omitempty"`
+
+	// 响应缓存
+	ResponseCacheEnabled    bool `json:"response_cache_enabled,omitempty"`
+	ResponseCacheTTLSeconds int  `json:"response_cache_ttl_seconds,omitempty"`
+
+	// Claude → OpenAI 兜底模型映射
+	OpenAIFallbackModelMapping map[string]string `json:"openai_fallback_model_mapping,omitempty"`
+
+	// 分组请求策略
+	RequestPolicy *RequestPolicy `json:"request_policy,omitempty"`
 }
 
-// APIKeyAuthCacheEntry 缓存条目，支持负缓存
+// APIKeyAuthCacheEntry 缓存条目，支持负缓存。
+// 条目以 Key 的可见前缀为键，Candidates 为该前缀下的全部 Key（仅含盐与哈希，不含明文），
+// 因此对同一前缀的候选列表是权威的：哈希均不匹配即可判定 Key 不存在。
 type APIKeyAuthCacheEntry struct {
-	NotFound bool                `json:"not_found"`
-	Snapshot *APIKeyAuthSnapshot `json:"snapshot,omitempty"`
+	NotFound   bool                  `json:"not_found"`
+	Candidates []*APIKeyAuthSnapshot `json:"candidates,omitempty"`
 }
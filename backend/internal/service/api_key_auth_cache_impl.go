@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"math/rand"
 	"sync"
@@ -108,8 +107,9 @@ func (s *APIKeyService) StartAuthCacheInvalidationSubscriber(ctx context.Context
 	}
 }
 
-func (s *APIKeyService) authCacheKey(key string) string {
-	sum := sha256.Sum256([]byte(key))
+// authCacheKey 由 Key 的可见前缀派生缓存键，使失效时无需明文 Key
+func (s *APIKeyService) authCacheKey(prefix string) string {
+	sum := sha256.Sum256([]byte("prefix:" + prefix))
 	return hex.EncodeToString(sum[:])
 }
 
@@ -167,24 +167,25 @@ func (s *APIKeyService) deleteAuthCache(ctx context.Context, cacheKey string) {
 	_ = s.cache.PublishAuthCacheInvalidation(ctx, cacheKey)
 }
 
-func (s *APIKeyService) loadAuthCacheEntry(ctx context.Context, key, cacheKey string) (*APIKeyAuthCacheEntry, error) {
-	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, key)
+func (s *APIKeyService) loadAuthCacheEntry(ctx context.Context, prefix, cacheKey string) (*APIKeyAuthCacheEntry, error) {
+	apiKeys, err := s.apiKeyRepo.ListByKeyPrefixForAuth(ctx, prefix)
 	if err != nil {
-		if errors.Is(err, ErrAPIKeyNotFound) {
-			entry := &APIKeyAuthCacheEntry{NotFound: true}
-			if s.authCfg.negativeEnabled() {
-				s.setAuthCacheEntry(ctx, cacheKey, entry, s.authCfg.negativeTTL)
-			}
-			return entry, nil
-		}
 		return nil, fmt.Errorf("get api key: %w", err)
 	}
-	apiKey.Key = key
-	snapshot := s.snapshotFromAPIKey(apiKey)
-	if snapshot == nil {
-		return nil, fmt.Errorf("get api key: %w", ErrAPIKeyNotFound)
+	candidates := make([]*APIKeyAuthSnapshot, 0, len(apiKeys))
+	for i := range apiKeys {
+		if snapshot := s.snapshotFromAPIKey(&apiKeys[i]); snapshot != nil {
+			candidates = append(candidates, snapshot)
+		}
 	}
-	entry := &APIKeyAuthCacheEntry{Snapshot: snapshot}
+	if len(candidates) == 0 {
+		entry := &APIKeyAuthCacheEntry{NotFound: true}
+		if s.authCfg.negativeEnabled() {
+			s.setAuthCacheEntry(ctx, cacheKey, entry, s.authCfg.negativeTTL)
+		}
+		return entry, nil
+	}
+	entry := &APIKeyAuthCacheEntry{Candidates: candidates}
 	s.setAuthCacheEntry(ctx, cacheKey, entry, s.authCfg.l2TTL)
 	return entry, nil
 }
@@ -196,19 +197,31 @@ func (s *APIKeyService) applyAuthCacheEntry(key string, entry *APIKeyAuthCacheEn
 	if entry.NotFound {
 		return nil, true, ErrAPIKeyNotFound
 	}
-	if entry.Snapshot == nil {
+	if len(entry.Candidates) == 0 {
 		return nil, false, nil
 	}
-	return s.snapshotToAPIKey(key, entry.Snapshot), true, nil
+	// 逐个比较全部候选，不提前退出
+	var matched *APIKeyAuthSnapshot
+	for _, candidate := range entry.Candidates {
+		if candidate != nil && VerifyAPIKeyHash(key, candidate.KeySalt, candidate.KeyHash) && matched == nil {
+			matched = candidate
+		}
+	}
+	if matched == nil {
+		return nil, true, ErrAPIKeyNotFound
+	}
+	return s.snapshotToAPIKey(key, matched), true, nil
 }
 
 func (s *APIKeyService) snapshotFromAPIKey(apiKey *APIKey) *APIKeyAuthSnapshot {
-	if apiKey == nil || apiKey.User == nil {
+	if apiKey == nil || apiKey.User == nil || apiKey.KeyHash == "" {
 		return nil
 	}
 	snapshot := &APIKeyAuthSnapshot{
 		APIKeyID:    apiKey.ID,
 		UserID:      apiKey.UserID,
+		KeySalt:     apiKey.KeySalt,
+		KeyHash:     apiKey.KeyHash,
 		GroupID:     apiKey.GroupID,
 		Status:      apiKey.Status,
 		IPWhitelist: apiKey.IPWhitelist,
@@ -216,6 +229,11 @@ func (s *APIKeyService) snapshotFromAPIKey(apiKey *APIKey) *APIKeyAuthSnapshot {
 		Quota:       apiKey.Quota,
 		QuotaUsed:   apiKey.QuotaUsed,
 		ExpiresAt:   apiKey.ExpiresAt,
+
+		RPMLimit:          apiKey.RPMLimit,
+		TPMLimit:          apiKey.TPMLimit,
+		DailyRequestLimit: apiKey.DailyRequestLimit,
+
 		User: APIKeyAuthUserSnapshot{
 			ID:          apiKey.User.ID,
 			Status:      apiKey.User.Status,
@@ -245,6 +263,13 @@ func (s *APIKeyService) snapshotFromAPIKey(apiKey *APIKey) *APIKeyAuthSnapshot {
 			ModelRoutingEnabled:             apiKey.Group.ModelRoutingEnabled,
 			MCPXMLInject:                    apiKey.Group.MCPXMLInject,
 			SupportedModelScopes:            apiKey.Group.SupportedModelScopes,
+			DefaultRPMLimit:                 apiKey.Group.DefaultRPMLimit,
+			DefaultTPMLimit:                 apiKey.Group.DefaultTPMLimit,
+			DefaultDailyRequestLimit:        apiKey.Group.DefaultDailyRequestLimit,
+			ResponseCacheEnabled:            apiKey.Group.ResponseCacheEnabled,
+			ResponseCacheTTLSeconds:         apiKey.Group.ResponseCacheTTLSeconds,
+			OpenAIFallbackModelMapping:      apiKey.Group.OpenAIFallbackModelMapping,
+			RequestPolicy:                   apiKey.Group.RequestPolicy,
 		}
 	}
 	return snapshot
@@ -259,12 +284,20 @@ func (s *APIKeyService) snapshotToAPIKey(key string, snapshot *APIKeyAuthSnapsho
 		UserID:      snapshot.UserID,
 		GroupID:     snapshot.GroupID,
 		Key:         key,
+		KeyPrefix:   APIKeyPrefix(key),
+		KeySalt:     snapshot.KeySalt,
+		KeyHash:     snapshot.KeyHash,
 		Status:      snapshot.Status,
 		IPWhitelist: snapshot.IPWhitelist,
 		IPBlacklist: snapshot.IPBlacklist,
 		Quota:       snapshot.Quota,
 		QuotaUsed:   snapshot.QuotaUsed,
 		ExpiresAt:   snapshot.ExpiresAt,
+
+		RPMLimit:          snapshot.RPMLimit,
+		TPMLimit:          snapshot.TPMLimit,
+		DailyRequestLimit: snapshot.DailyRequestLimit,
+
 		User: &User{
 			ID:          snapshot.User.ID,
 			Status:      snapshot.User.Status,
@@ -295,6 +328,13 @@ func (s *APIKeyService) snapshotToAPIKey(key string, snapshot *APIKeyAuthSnapsho
 			ModelRoutingEnabled:             snapshot.Group.ModelRoutingEnabled,
 			MCPXMLInject:                    snapshot.Group.MCPXMLInject,
 			SupportedModelScopes:            snapshot.Group.SupportedModelScopes,
+			DefaultRPMLimit:                 snapshot.Group.DefaultRPMLimit,
+			DefaultTPMLimit:                 snapshot.Group.DefaultTPMLimit,
+			DefaultDailyRequestLimit:        snapshot.Group.DefaultDailyRequestLimit,
+			ResponseCacheEnabled:            snapshot.Group.ResponseCacheEnabled,
+			ResponseCacheTTLSeconds:         snapshot.Group.ResponseCacheTTLSeconds,
+			OpenAIFallbackModelMapping:      snapshot.Group.OpenAIFallbackModelMapping,
+			RequestPolicy:                   snapshot.Group.RequestPolicy,
 		}
 	}
 	return apiKey
@@ -2,13 +2,12 @@ package service
 
 import "context"
 
-// InvalidateAuthCacheByKey 清除指定 API Key 的认证缓存
-func (s *APIKeyService) InvalidateAuthCacheByKey(ctx context.Context, key string) {
-	if key == "" {
+// InvalidateAuthCacheByKeyPrefix 清除指定 Key 前缀的认证缓存
+func (s *APIKeyService) InvalidateAuthCacheByKeyPrefix(ctx context.Context, prefix string) {
+	if prefix == "" {
 		return
 	}
-	cacheKey := s.authCacheKey(key)
-	s.deleteAuthCache(ctx, cacheKey)
+	s.deleteAuthCache(ctx, s.authCacheKey(prefix))
 }
 
 // InvalidateAuthCacheByUserID 清除用户相关的 API Key 认证缓存
@@ -16,11 +15,11 @@ func (s *APIKeyService) InvalidateAuthCacheByUserID(ctx context.Context, userID
 	if userID <= 0 {
 		return
 	}
-	keys, err := s.apiKeyRepo.ListKeysByUserID(ctx, userID)
+	prefixes, err := s.apiKeyRepo.ListKeyPrefixesByUserID(ctx, userID)
 	if err != nil {
 		return
 	}
-	s.deleteAuthCacheByKeys(ctx, keys)
+	s.deleteAuthCacheByKeyPrefixes(ctx, prefixes)
 }
 
 // InvalidateAuthCacheByGroupID 清除分组相关的 API Key 认证缓存
@@ -28,21 +27,21 @@ func (s *APIKeyService) InvalidateAuthCacheByGroupID(ctx context.Context, groupI
 	if groupID <= 0 {
 		return
 	}
-	keys, err := s.apiKeyRepo.ListKeysByGroupID(ctx, groupID)
+	prefixes, err := s.apiKeyRepo.ListKeyPrefixesByGroupID(ctx, groupID)
 	if err != nil {
 		return
 	}
-	s.deleteAuthCacheByKeys(ctx, keys)
+	s.deleteAuthCacheByKeyPrefixes(ctx, prefixes)
 }
 
-func (s *APIKeyService) deleteAuthCacheByKeys(ctx context.Context, keys []string) {
-	if len(keys) == 0 {
+func (s *APIKeyService) deleteAuthCacheByKeyPrefixes(ctx context.Context, prefixes []string) {
+	if len(prefixes) == 0 {
 		return
 	}
-	for _, key := range keys {
-		if key == "" {
+	for _, prefix := range prefixes {
+		if prefix == "" {
 			continue
 		}
-		s.deleteAuthCache(ctx, s.authCacheKey(key))
+		s.deleteAuthCache(ctx, s.authCacheKey(prefix))
 	}
 }
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// apiKeyPrefixLen 自定义 Key 的可见前缀长度（固定值）。
+// 与 Key 长度无关，避免短的自定义 Key 暴露过多字符。
+const apiKeyPrefixLen = 8
+
+// apiKeyPrefixRandomLen 生成的 Key 的可见前缀中包含的随机字符数。
+// 生成的 Key 前缀为「配置的 default.api_key_prefix + 随机部分的前 8 位」，
+// 分桶粒度与配置的前缀长度无关（否则前缀 >= 8 位时所有 Key 落入同一个桶）。
+const apiKeyPrefixRandomLen = 8
+
+// apiKeyPrefixMaxLen 可见前缀的最大长度（key_prefix 列宽）；配置的前缀过长时只保留其末尾部分
+const apiKeyPrefixMaxLen = 64
+
+// apiKeyGeneratedHexLen 生成的 Key 末尾随机十六进制串的长度（32 字节）
+const apiKeyGeneratedHexLen = 64
+
+// apiKeyBcryptCost 自定义 Key 慢哈希的 bcrypt cost
+const apiKeyBcryptCost = bcrypt.DefaultCost
+
+// apiKeySlowHashVerified 缓存已通过 bcrypt 校验的 (bcrypt 哈希 -> HMAC 摘要)，
+// 认证热路径上同一 Key 只需付出一次 bcrypt 代价；只缓存成功结果，条目数不超过有效的自定义 Key 数量。
+var apiKeySlowHashVerified sync.Map
+
+// APIKeyPrefix 返回 Key 的可见前缀，用于认证查找与展示：
+//   - 生成的 Key（前缀 + 64 位十六进制）：前缀 + 随机部分的前 8 位，总长不超过 64；
+//   - 自定义 Key：前 8 个字符（Key 更短时返回整个 Key）。
+//
+// 生成时使用的前缀由 Key 自身的格式确定，不依赖当前配置，修改 default.api_key_prefix 不影响已有 Key 的查找。
+// 修改规则时需同步 migration 060 中的回填语句。
+func APIKeyPrefix(key string) string {
+	if isGeneratedAPIKey(key) {
+		end := len(key) - apiKeyGeneratedHexLen + apiKeyPrefixRandomLen
+		start := 0
+		if end > apiKeyPrefixMaxLen {
+			start = end - apiKeyPrefixMaxLen
+		}
+		return key[start:end]
+	}
+	if len(key) <= apiKeyPrefixLen {
+		return key
+	}
+	return key[:apiKeyPrefixLen]
+}
+
+// HashAPIKey 为 Key 生成随机盐并返回 (salt, hash)。
+//
+// 生成的 Key 是高熵随机串，且认证处于请求热路径，因此使用 HMAC-SHA256(salt, key)；
+// 自定义 Key 熵可能很低（最短 16 个字符，前 8 个还作为前缀明文存储），
+// 在 HMAC 摘要之上再做 bcrypt，数据库泄露后无法低成本地暴力破解剩余字符。
+func HashAPIKey(key string) (salt, hash string, err error) {
+	saltBytes := make([]byte, 16)
+	if _, err := rand.Read(saltBytes); err != nil {
+		return "", "", fmt.Errorf("generate api key salt: %w", err)
+	}
+	salt = hex.EncodeToString(saltBytes)
+	digest := computeAPIKeyHash(saltBytes, key)
+	if isGeneratedAPIKey(key) {
+		return salt, digest, nil
+	}
+	slow, err := bcrypt.GenerateFromPassword([]byte(digest), apiKeyBcryptCost)
+	if err != nil {
+		return "", "", fmt.Errorf("hash api key: %w", err)
+	}
+	return salt, string(slow), nil
+}
+
+// VerifyAPIKeyHash 校验 Key 与存储的哈希（HMAC 摘要为常量时间比较，bcrypt 哈希走 bcrypt 校验）
+func VerifyAPIKeyHash(key, salt, hash string) bool {
+	saltBytes, err := hex.DecodeString(salt)
+	if err != nil || len(saltBytes) == 0 || hash == "" {
+		return false
+	}
+	digest := computeAPIKeyHash(saltBytes, key)
+	if !isBcryptAPIKeyHash(hash) {
+		return subtle.ConstantTimeCompare([]byte(digest), []byte(hash)) == 1
+	}
+	if cached, ok := apiKeySlowHashVerified.Load(hash); ok {
+		return subtle.ConstantTimeCompare([]byte(cached.(string)), []byte(digest)) == 1
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(digest)) != nil {
+		return false
+	}
+	apiKeySlowHashVerified.Store(hash, digest)
+	return true
+}
+
+func computeAPIKeyHash(salt []byte, key string) string {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// isGeneratedAPIKey 判断 Key 是否为 GenerateKey 生成的格式（任意前缀 + 64 位小写十六进制）。
+// 自定义 Key 恰好满足该格式时同样是高熵随机串，按生成的 Key 处理也是安全的。
+func isGeneratedAPIKey(key string) bool {
+	if len(key) < apiKeyGeneratedHexLen {
+		return false
+	}
+	for _, c := range key[len(key)-apiKeyGeneratedHexLen:] {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func isBcryptAPIKeyHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2")
+}
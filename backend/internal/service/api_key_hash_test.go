@@ -0,0 +1,95 @@
+//go:build unit
+
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyPrefix(t *testing.T) {
+	cases := []struct {
+		key  string
+		want string
+	}{
+		{key: "", want: ""},
+		{key: "k", want: "k"},
+		{key: "sk-12345", want: "sk-12345"},
+		// 前缀长度固定，不随 Key 长度增长：16 位自定义 Key 只暴露 8 位
+		{key: "sk_custom_123456", want: "sk_custo"},
+		{key: "sk-0123456789abcdef0123456789abcdef", want: "sk-01234"},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.want, APIKeyPrefix(tc.key), tc.key)
+	}
+}
+
+func TestAPIKeyPrefix_GeneratedKeyIncludesRandomPart(t *testing.T) {
+	random := strings.Repeat("0123456789abcdef", 4)
+
+	require.Equal(t, "sk-01234567", APIKeyPrefix("sk-"+random))
+	// 配置的前缀不少于 8 位时仍包含随机字符，不同 Key 落入不同的桶
+	require.Equal(t, "company-prod-01234567", APIKeyPrefix("company-prod-"+random))
+	require.NotEqual(t,
+		APIKeyPrefix("company-prod-"+random),
+		APIKeyPrefix("company-prod-f"+random[1:]))
+
+	// 超长前缀只保留末尾部分，结果不超过列宽且仍以随机字符结尾
+	long := strings.Repeat("p", 100) + "-"
+	prefix := APIKeyPrefix(long + random)
+	require.Len(t, prefix, apiKeyPrefixMaxLen)
+	require.True(t, strings.HasSuffix(prefix, "-01234567"))
+}
+
+func TestHashAPIKey_VerifyRoundTrip(t *testing.T) {
+	salt, hash, err := HashAPIKey("sk-secret-value")
+	require.NoError(t, err)
+	require.Len(t, salt, 32)
+	require.NotEmpty(t, hash)
+	require.NotContains(t, hash, "sk-secret-value")
+
+	require.True(t, VerifyAPIKeyHash("sk-secret-value", salt, hash))
+	require.False(t, VerifyAPIKeyHash("sk-secret-valuf", salt, hash))
+	require.False(t, VerifyAPIKeyHash("sk-secret-value", salt, ""))
+	require.False(t, VerifyAPIKeyHash("sk-secret-value", "not-hex", hash))
+}
+
+func TestHashAPIKey_UsesPerKeySalt(t *testing.T) {
+	salt1, hash1, err := HashAPIKey("sk-same-key")
+	require.NoError(t, err)
+	salt2, hash2, err := HashAPIKey("sk-same-key")
+	require.NoError(t, err)
+
+	require.NotEqual(t, salt1, salt2)
+	require.NotEqual(t, hash1, hash2)
+	require.False(t, VerifyAPIKeyHash("sk-same-key", salt1, hash2))
+}
+
+func TestHashAPIKey_GeneratedKeyUsesHMAC(t *testing.T) {
+	key := "sk-" + strings.Repeat("0123456789abcdef", 4)
+	require.True(t, isGeneratedAPIKey(key))
+
+	salt, hash, err := HashAPIKey(key)
+	require.NoError(t, err)
+	require.Len(t, hash, 64)
+	require.False(t, isBcryptAPIKeyHash(hash))
+	require.True(t, VerifyAPIKeyHash(key, salt, hash))
+}
+
+func TestHashAPIKey_CustomKeyUsesBcrypt(t *testing.T) {
+	key := "my-custom-key-16"
+	require.False(t, isGeneratedAPIKey(key))
+	require.False(t, isGeneratedAPIKey("sk-"+strings.Repeat("0123456789ABCDEF", 4)))
+
+	salt, hash, err := HashAPIKey(key)
+	require.NoError(t, err)
+	require.True(t, isBcryptAPIKeyHash(hash))
+	require.LessOrEqual(t, len(hash), 64, "must fit the key_hash column")
+
+	// 两次校验：第二次命中进程内缓存，结果必须一致
+	require.True(t, VerifyAPIKeyHash(key, salt, hash))
+	require.True(t, VerifyAPIKeyHash(key, salt, hash))
+	require.False(t, VerifyAPIKeyHash("my-custom-key-17", salt, hash))
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+)
+
+// API Key 限流类型
+const (
+	APIKeyRateLimitRPM   = "rpm"
+	APIKeyRateLimitTPM   = "tpm"
+	APIKeyRateLimitDaily = "daily"
+)
+
+// apiKeyRateLimitWindow RPM/TPM 滑动窗口长度
+const apiKeyRateLimitWindow = time.Minute
+
+// APIKeyRateLimits API Key 生效的限流配置（0 = 不限制）
+type APIKeyRateLimits struct {
+	RPM           int
+	TPM           int
+	DailyRequests int
+}
+
+// IsZero 是否未配置任何限流
+func (l APIKeyRateLimits) IsZero() bool {
+	return l.RPM <= 0 && l.TPM <= 0 && l.DailyRequests <= 0
+}
+
+// APIKeyRateLimitUsage 限流窗口内的当前用量（对应维度未限制时为 0）
+type APIKeyRateLimitUsage struct {
+	Requests      int // 滑动窗口内请求数（含本次）
+	Tokens        int // 滑动窗口内 token 数
+	DailyRequests int // 当日请求数（含本次）
+
+	RequestsReset time.Duration // 请求窗口中最早一条记录过期的剩余时间
+	TokensReset   time.Duration // token 窗口中最早一条记录过期的剩余时间
+	DailyReset    time.Duration // 距当日计数重置的剩余时间
+}
+
+// APIKeyRateLimitDecision 限流判定结果
+type APIKeyRateLimitDecision struct {
+	Allowed    bool
+	Exceeded   string // 超限维度：rpm / tpm / daily
+	RetryAfter time.Duration
+	Limits     APIKeyRateLimits
+	Usage      APIKeyRateLimitUsage
+}
+
+// APIKeyRateLimitCache API Key 限流计数存储（Redis 滑动窗口）
+type APIKeyRateLimitCache interface {
+	// Acquire 检查限流并登记一次请求；超限时不计数，返回超限维度与重试等待时间。
+	// dailyTTL 为距当日计数重置的剩余时间。
+	Acquire(ctx context.Context, apiKeyID int64, limits APIKeyRateLimits, window, dailyTTL time.Duration) (*APIKeyRateLimitDecision, error)
+	// AddTokens 将一次请求消耗的 token 记入 TPM 滑动窗口
+	AddTokens(ctx context.Context, apiKeyID int64, tokens int, window time.Duration) error
+}
+
+// EffectiveRateLimits 计算 API Key 生效的限流配置。
+// Key 未配置（0）的维度继承分组默认值；分组默认值同时作为上限，Key 只能在其基础上收紧。
+func (k *APIKey) EffectiveRateLimits() APIKeyRateLimits {
+	var groupLimits APIKeyRateLimits
+	if k.Group != nil {
+		groupLimits = APIKeyRateLimits{
+			RPM:           k.Group.DefaultRPMLimit,
+			TPM:           k.Group.DefaultTPMLimit,
+			DailyRequests: k.Group.DefaultDailyRequestLimit,
+		}
+	}
+	return APIKeyRateLimits{
+		RPM:           mergeRateLimit(k.RPMLimit, groupLimits.RPM),
+		TPM:           mergeRateLimit(k.TPMLimit, groupLimits.TPM),
+		DailyRequests: mergeRateLimit(k.DailyRequestLimit, groupLimits.DailyRequests),
+	}
+}
+
+func mergeRateLimit(keyLimit, groupLimit int) int {
+	if keyLimit <= 0 {
+		return max(groupLimit, 0)
+	}
+	if groupLimit > 0 && groupLimit < keyLimit {
+		return groupLimit
+	}
+	return keyLimit
+}
+
+// APIKeyRateLimitService API Key 级别的 RPM/TPM/每日请求数限流
+type APIKeyRateLimitService struct {
+	cache APIKeyRateLimitCache
+}
+
+// NewAPIKeyRateLimitService 创建 API Key 限流服务
+func NewAPIKeyRateLimitService(cache APIKeyRateLimitCache) *APIKeyRateLimitService {
+	return &APIKeyRateLimitService{cache: cache}
+}
+
+// Acquire 在认证阶段检查并登记一次请求。
+// 未配置限流时直接放行；Redis 故障时放行（fail-open），避免限流组件拖垮网关。
+func (s *APIKeyRateLimitService) Acquire(ctx context.Context, apiKey *APIKey) *APIKeyRateLimitDecision {
+	if s == nil || s.cache == nil || apiKey == nil {
+		return &APIKeyRateLimitDecision{Allowed: true}
+	}
+	limits := apiKey.EffectiveRateLimits()
+	if limits.IsZero() {
+		return &APIKeyRateLimitDecision{Allowed: true, Limits: limits}
+	}
+
+	now := timezone.Now()
+	dailyTTL := timezone.EndOfDay(now).Sub(now)
+	if dailyTTL <= 0 {
+		dailyTTL = time.Second
+	}
+
+	decision, err := s.cache.Acquire(ctx, apiKey.ID, limits, apiKeyRateLimitWindow, dailyTTL)
+	if err != nil {
+		log.Printf("[APIKeyRateLimit] acquire failed (fail-open): key=%d err=%v", apiKey.ID, err)
+		return &APIKeyRateLimitDecision{Allowed: true, Limits: limits}
+	}
+	decision.Limits = limits
+	return decision
+}
+
+// RecordTokens 将请求实际消耗的 token 记入 TPM 窗口（仅对配置了 TPM 的 Key 生效）
+func (s *APIKeyRateLimitService) RecordTokens(ctx context.Context, apiKey *APIKey, tokens int) {
+	if s == nil || s.cache == nil || apiKey == nil || tokens <= 0 {
+		return
+	}
+	if apiKey.EffectiveRateLimits().TPM <= 0 {
+		return
+	}
+	if err := s.cache.AddTokens(ctx, apiKey.ID, tokens, apiKeyRateLimitWindow); err != nil {
+		log.Printf("[APIKeyRateLimit] record tokens failed: key=%d err=%v", apiKey.ID, err)
+	}
+}
@@ -0,0 +1,97 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type apiKeyRateLimitCacheStub struct {
+	decision     *APIKeyRateLimitDecision
+	err          error
+	acquireCalls int
+	limits       APIKeyRateLimits
+	dailyTTL     time.Duration
+	tokens       []int
+}
+
+func (s *apiKeyRateLimitCacheStub) Acquire(ctx context.Context, apiKeyID int64, limits APIKeyRateLimits, window, dailyTTL time.Duration) (*APIKeyRateLimitDecision, error) {
+	s.acquireCalls++
+	s.limits = limits
+	s.dailyTTL = dailyTTL
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.decision, nil
+}
+
+func (s *apiKeyRateLimitCacheStub) AddTokens(ctx context.Context, apiKeyID int64, tokens int, window time.Duration) error {
+	s.tokens = append(s.tokens, tokens)
+	return nil
+}
+
+func TestAPIKeyEffectiveRateLimits(t *testing.T) {
+	group := &Group{DefaultRPMLimit: 60, DefaultTPMLimit: 0, DefaultDailyRequestLimit: 1000}
+
+	// Key 未配置时继承分组默认值
+	key := &APIKey{Group: group}
+	require.Equal(t, APIKeyRateLimits{RPM: 60, DailyRequests: 1000}, key.EffectiveRateLimits())
+
+	// Key 配置只能收紧分组默认值；分组未限制的维度以 Key 为准
+	key = &APIKey{Group: group, RPMLimit: 120, TPMLimit: 5000, DailyRequestLimit: 100}
+	require.Equal(t, APIKeyRateLimits{RPM: 60, TPM: 5000, DailyRequests: 100}, key.EffectiveRateLimits())
+
+	// 无分组
+	key = &APIKey{RPMLimit: 5}
+	require.Equal(t, APIKeyRateLimits{RPM: 5}, key.EffectiveRateLimits())
+	require.True(t, (&APIKey{}).EffectiveRateLimits().IsZero())
+}
+
+func TestAPIKeyRateLimitServiceAcquire(t *testing.T) {
+	ctx := context.Background()
+
+	// 未配置限流时不访问 Redis
+	cache := &apiKeyRateLimitCacheStub{}
+	svc := NewAPIKeyRateLimitService(cache)
+	require.True(t, svc.Acquire(ctx, &APIKey{ID: 1}).Allowed)
+	require.Zero(t, cache.acquireCalls)
+
+	// 超限结果透传，并补全生效的限额
+	cache.decision = &APIKeyRateLimitDecision{Exceeded: APIKeyRateLimitRPM, RetryAfter: time.Second}
+	decision := svc.Acquire(ctx, &APIKey{ID: 1, RPMLimit: 2})
+	require.False(t, decision.Allowed)
+	require.Equal(t, APIKeyRateLimitRPM, decision.Exceeded)
+	require.Equal(t, 2, decision.Limits.RPM)
+	require.Equal(t, APIKeyRateLimits{RPM: 2}, cache.limits)
+	require.Positive(t, cache.dailyTTL)
+	require.LessOrEqual(t, cache.dailyTTL, 24*time.Hour)
+
+	// Redis 故障时放行
+	cache.err = errors.New("redis down")
+	require.True(t, svc.Acquire(ctx, &APIKey{ID: 1, RPMLimit: 2}).Allowed)
+
+	// nil 服务放行
+	var nilSvc *APIKeyRateLimitService
+	require.True(t, nilSvc.Acquire(ctx, &APIKey{ID: 1, RPMLimit: 2}).Allowed)
+}
+
+func TestAPIKeyRateLimitServiceRecordTokens(t *testing.T) {
+	ctx := context.Background()
+	cache := &apiKeyRateLimitCacheStub{}
+	svc := NewAPIKeyRateLimitService(cache)
+
+	svc.RecordTokens(ctx, &APIKey{ID: 1, RPMLimit: 10}, 100)
+	svc.RecordTokens(ctx, &APIKey{ID: 1, TPMLimit: 1000}, 0)
+	require.Empty(t, cache.tokens)
+
+	svc.RecordTokens(ctx, &APIKey{ID: 1, Group: &Group{DefaultTPMLimit: 1000}}, 250)
+	require.Equal(t, []int{250}, cache.tokens)
+
+	var nilSvc *APIKeyRateLimitService
+	nilSvc.RecordTokens(ctx, &APIKey{ID: 1, TPMLimit: 1000}, 10)
+}
@@ -37,11 +37,13 @@ const (
 type APIKeyRepository interface {
 	Create(ctx context.Context, key *APIKey) error
 	GetByID(ctx context.Context, id int64) (*APIKey, error)
-	// GetKeyAndOwnerID 仅获取 API Key 的 key 与所有者 ID，用于删除等轻量场景
-	GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error)
+	// GetKeyPrefixAndOwnerID 仅获取 API Key 的可见前缀与所有者 ID，用于删除等轻量场景
+	GetKeyPrefixAndOwnerID(ctx context.Context, id int64) (string, int64, error)
+	// GetByKey 按前缀查找候选并校验哈希，返回完整实体
 	GetByKey(ctx context.Context, key string) (*APIKey, error)
-	// GetByKeyForAuth 认证专用查询，返回最小字段集
-	GetByKeyForAuth(ctx context.Context, key string) (*APIKey, error)
+	// ListByKeyPrefixForAuth 认证专用查询：返回该前缀下的全部候选（最小字段集，含盐与哈希），
+	// 历史明文 Key 在此过程中被哈希并清除明文
+	ListByKeyPrefixForAuth(ctx context.Context, prefix string) ([]APIKey, error)
 	Update(ctx context.Context, key *APIKey) error
 	Delete(ctx context.Context, id int64) error
 
@@ -53,8 +55,8 @@ type APIKeyRepository interface {
 	SearchAPIKeys(ctx context.Context, userID int64, keyword string, limit int) ([]APIKey, error)
 	ClearGroupIDByGroupID(ctx context.Context, groupID int64) (int64, error)
 	CountByGroupID(ctx context.Context, groupID int64) (int64, error)
-	ListKeysByUserID(ctx context.Context, userID int64) ([]string, error)
-	ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error)
+	ListKeyPrefixesByUserID(ctx context.Context, userID int64) ([]string, error)
+	ListKeyPrefixesByGroupID(ctx context.Context, groupID int64) ([]string, error)
 
 	// Quota methods
 	IncrementQuotaUsed(ctx context.Context, id int64, amount float64) (float64, error)
@@ -80,7 +82,7 @@ type APIKeyCache interface {
 
 // APIKeyAuthCacheInvalidator 提供认证缓存失效能力
 type APIKeyAuthCacheInvalidator interface {
-	InvalidateAuthCacheByKey(ctx context.Context, key string)
+	InvalidateAuthCacheByKeyPrefix(ctx context.Context, prefix string)
 	InvalidateAuthCacheByUserID(ctx context.Context, userID int64)
 	InvalidateAuthCacheByGroupID(ctx context.Context, groupID int64)
 }
@@ -96,6 +98,11 @@ type CreateAPIKeyRequest struct {
 	// Quota fields
 	Quota         float64 `json:"quota"`           // Quota limit in USD (0 = unlimited)
 	ExpiresInDays *int    `json:"expires_in_days"` // Days until expiry (nil = never expires)
+
+	// Rate limit fields (0 = inherit group default)
+	RPMLimit          int `json:"rpm_limit"`
+	TPMLimit          int `json:"tpm_limit"`
+	DailyRequestLimit int `json:"daily_request_limit"`
 }
 
 // UpdateAPIKeyRequest 更新API Key请求
@@ -111,6 +118,11 @@ type UpdateAPIKeyRequest struct {
 	ExpiresAt       *time.Time `json:"expires_at"`  // Expiration time (nil = no change)
 	ClearExpiration bool       `json:"-"`           // Clear expiration (internal use)
 	ResetQuota      *bool      `json:"reset_quota"` // Reset quota_used to 0
+
+	// Rate limit fields (nil = no change, 0 = inherit group default)
+	RPMLimit          *int `json:"rpm_limit"`
+	TPMLimit          *int `json:"tpm_limit"`
+	DailyRequestLimit *int `json:"daily_request_limit"`
 }
 
 // APIKeyService API Key服务
@@ -311,6 +323,10 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 		IPBlacklist: req.IPBlacklist,
 		Quota:       req.Quota,
 		QuotaUsed:   0,
+
+		RPMLimit:          req.RPMLimit,
+		TPMLimit:          req.TPMLimit,
+		DailyRequestLimit: req.DailyRequestLimit,
 	}
 
 	// Set expiration time if specified
@@ -323,7 +339,8 @@ func (s *APIKeyService) Create(ctx context.Context, userID int64, req CreateAPIK
 		return nil, fmt.Errorf("create api key: %w", err)
 	}
 
-	s.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	// 新 Key 的前缀可能已有负缓存或旧的候选列表
+	s.InvalidateAuthCacheByKeyPrefix(ctx, apiKey.KeyPrefix)
 
 	return apiKey, nil
 }
@@ -359,8 +376,13 @@ func (s *APIKeyService) GetByID(ctx context.Context, id int64) (*APIKey, error)
 }
 
 // GetByKey 根据Key字符串获取API Key（用于认证）
+// 认证缓存以可见前缀为键、缓存该前缀下全部候选的盐与哈希，命中后以常量时间比较确认 Key。
 func (s *APIKeyService) GetByKey(ctx context.Context, key string) (*APIKey, error) {
-	cacheKey := s.authCacheKey(key)
+	prefix := APIKeyPrefix(key)
+	if prefix == "" {
+		return nil, fmt.Errorf("get api key: %w", ErrAPIKeyNotFound)
+	}
+	cacheKey := s.authCacheKey(prefix)
 
 	if entry, ok := s.getAuthCacheEntry(ctx, cacheKey); ok {
 		if apiKey, used, err := s.applyAuthCacheEntry(key, entry); used {
@@ -371,39 +393,29 @@ func (s *APIKeyService) GetByKey(ctx context.Context, key string) (*APIKey, erro
 		}
 	}
 
+	var entry *APIKeyAuthCacheEntry
 	if s.authCfg.singleflight {
 		value, err, _ := s.authGroup.Do(cacheKey, func() (any, error) {
-			return s.loadAuthCacheEntry(ctx, key, cacheKey)
+			return s.loadAuthCacheEntry(ctx, prefix, cacheKey)
 		})
 		if err != nil {
 			return nil, err
 		}
-		entry, _ := value.(*APIKeyAuthCacheEntry)
-		if apiKey, used, err := s.applyAuthCacheEntry(key, entry); used {
-			if err != nil {
-				return nil, fmt.Errorf("get api key: %w", err)
-			}
-			return apiKey, nil
-		}
+		entry, _ = value.(*APIKeyAuthCacheEntry)
 	} else {
-		entry, err := s.loadAuthCacheEntry(ctx, key, cacheKey)
+		var err error
+		entry, err = s.loadAuthCacheEntry(ctx, prefix, cacheKey)
 		if err != nil {
 			return nil, err
 		}
-		if apiKey, used, err := s.applyAuthCacheEntry(key, entry); used {
-			if err != nil {
-				return nil, fmt.Errorf("get api key: %w", err)
-			}
-			return apiKey, nil
-		}
 	}
-
-	apiKey, err := s.apiKeyRepo.GetByKeyForAuth(ctx, key)
-	if err != nil {
-		return nil, fmt.Errorf("get api key: %w", err)
+	if apiKey, used, err := s.applyAuthCacheEntry(key, entry); used {
+		if err != nil {
+			return nil, fmt.Errorf("get api key: %w", err)
+		}
+		return apiKey, nil
 	}
-	apiKey.Key = key
-	return apiKey, nil
+	return nil, fmt.Errorf("get api key: %w", ErrAPIKeyNotFound)
 }
 
 // Update 更新API Key
@@ -493,6 +505,17 @@ func (s *APIKeyService) Update(ctx context.Context, id int64, userID int64, req
 		}
 	}
 
+	// Update rate limit fields
+	if req.RPMLimit != nil {
+		apiKey.RPMLimit = *req.RPMLimit
+	}
+	if req.TPMLimit != nil {
+		apiKey.TPMLimit = *req.TPMLimit
+	}
+	if req.DailyRequestLimit != nil {
+		apiKey.DailyRequestLimit = *req.DailyRequestLimit
+	}
+
 	// 更新 IP 限制（空数组会清空设置）
 	apiKey.IPWhitelist = req.IPWhitelist
 	apiKey.IPBlacklist = req.IPBlacklist
@@ -501,14 +524,14 @@ func (s *APIKeyService) Update(ctx context.Context, id int64, userID int64, req
 		return nil, fmt.Errorf("update api key: %w", err)
 	}
 
-	s.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+	s.InvalidateAuthCacheByKeyPrefix(ctx, apiKey.KeyPrefix)
 
 	return apiKey, nil
 }
 
 // Delete 删除API Key
 func (s *APIKeyService) Delete(ctx context.Context, id int64, userID int64) error {
-	key, ownerID, err := s.apiKeyRepo.GetKeyAndOwnerID(ctx, id)
+	keyPrefix, ownerID, err := s.apiKeyRepo.GetKeyPrefixAndOwnerID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("get api key: %w", err)
 	}
@@ -522,7 +545,7 @@ func (s *APIKeyService) Delete(ctx context.Context, id int64, userID int64) erro
 	if s.cache != nil {
 		_ = s.cache.DeleteCreateAttemptCount(ctx, userID)
 	}
-	s.InvalidateAuthCacheByKey(ctx, key)
+	s.InvalidateAuthCacheByKeyPrefix(ctx, keyPrefix)
 
 	if err := s.apiKeyRepo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("delete api key: %w", err)
@@ -685,7 +708,7 @@ func (s *APIKeyService) UpdateQuotaUsed(ctx context.Context, apiKeyID int64, cos
 			return nil // Don't fail the request
 		}
 		// Invalidate cache so next request sees the new status
-		s.InvalidateAuthCacheByKey(ctx, apiKey.Key)
+		s.InvalidateAuthCacheByKeyPrefix(ctx, apiKey.KeyPrefix)
 	}
 
 	return nil
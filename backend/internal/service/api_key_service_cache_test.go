@@ -17,9 +17,20 @@ import (
 )
 
 type authRepoStub struct {
-	getByKeyForAuth   func(ctx context.Context, key string) (*APIKey, error)
-	listKeysByUserID  func(ctx context.Context, userID int64) ([]string, error)
-	listKeysByGroupID func(ctx context.Context, groupID int64) ([]string, error)
+	listByKeyPrefixForAuth   func(ctx context.Context, prefix string) ([]APIKey, error)
+	listKeyPrefixesByUserID  func(ctx context.Context, userID int64) ([]string, error)
+	listKeyPrefixesByGroupID func(ctx context.Context, groupID int64) ([]string, error)
+}
+
+// hashedAuthKey 为测试记录补全明文 key 对应的前缀、盐与哈希
+func hashedAuthKey(t *testing.T, key string, apiKey APIKey) APIKey {
+	t.Helper()
+	salt, hash, err := HashAPIKey(key)
+	require.NoError(t, err)
+	apiKey.KeyPrefix = APIKeyPrefix(key)
+	apiKey.KeySalt = salt
+	apiKey.KeyHash = hash
+	return apiKey
 }
 
 func (s *authRepoStub) Create(ctx context.Context, key *APIKey) error {
@@ -30,19 +41,19 @@ func (s *authRepoStub) GetByID(ctx context.Context, id int64) (*APIKey, error) {
 	panic("unexpected GetByID call")
 }
 
-func (s *authRepoStub) GetKeyAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
-	panic("unexpected GetKeyAndOwnerID call")
+func (s *authRepoStub) GetKeyPrefixAndOwnerID(ctx context.Context, id int64) (string, int64, error) {
+	panic("unexpected GetKeyPrefixAndOwnerID call")
 }
 
 func (s *authRepoStub) GetByKey(ctx context.Context, key string) (*APIKey, error) {
 	panic("unexpected GetByKey call")
 }
 
-func (s *authRepoStub) GetByKeyForAuth(ctx context.Context, key string) (*APIKey, error) {
-	if s.getByKeyForAuth == nil {
-		panic("unexpected GetByKeyForAuth call")
+func (s *authRepoStub) ListByKeyPrefixForAuth(ctx context.Context, prefix string) ([]APIKey, error) {
+	if s.listByKeyPrefixForAuth == nil {
+		panic("unexpected ListByKeyPrefixForAuth call")
 	}
-	return s.getByKeyForAuth(ctx, key)
+	return s.listByKeyPrefixForAuth(ctx, prefix)
 }
 
 func (s *authRepoStub) Update(ctx context.Context, key *APIKey) error {
@@ -85,18 +96,18 @@ func (s *authRepoStub) CountByGroupID(ctx context.Context, groupID int64) (int64
 	panic("unexpected CountByGroupID call")
 }
 
-func (s *authRepoStub) ListKeysByUserID(ctx context.Context, userID int64) ([]string, error) {
-	if s.listKeysByUserID == nil {
-		panic("unexpected ListKeysByUserID call")
+func (s *authRepoStub) ListKeyPrefixesByUserID(ctx context.Context, userID int64) ([]string, error) {
+	if s.listKeyPrefixesByUserID == nil {
+		panic("unexpected ListKeyPrefixesByUserID call")
 	}
-	return s.listKeysByUserID(ctx, userID)
+	return s.listKeyPrefixesByUserID(ctx, userID)
 }
 
-func (s *authRepoStub) ListKeysByGroupID(ctx context.Context, groupID int64) ([]string, error) {
-	if s.listKeysByGroupID == nil {
-		panic("unexpected ListKeysByGroupID call")
+func (s *authRepoStub) ListKeyPrefixesByGroupID(ctx context.Context, groupID int64) ([]string, error) {
+	if s.listKeyPrefixesByGroupID == nil {
+		panic("unexpected ListKeyPrefixesByGroupID call")
 	}
-	return s.listKeysByGroupID(ctx, groupID)
+	return s.listKeyPrefixesByGroupID(ctx, groupID)
 }
 
 func (s *authRepoStub) IncrementQuotaUsed(ctx context.Context, id int64, amount float64) (float64, error) {
@@ -157,7 +168,7 @@ func (s *authCacheStub) SubscribeAuthCacheInvalidation(ctx context.Context, hand
 func TestAPIKeyService_GetByKey_UsesL2Cache(t *testing.T) {
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		getByKeyForAuth: func(ctx context.Context, key string) (*APIKey, error) {
+		listByKeyPrefixForAuth: func(ctx context.Context, prefix string) ([]APIKey, error) {
 			return nil, errors.New("unexpected repo call")
 		},
 	}
@@ -170,10 +181,14 @@ func TestAPIKeyService_GetByKey_UsesL2Cache(t *testing.T) {
 	svc := NewAPIKeyService(repo, nil, nil, nil, nil, cache, cfg)
 
 	groupID := int64(9)
+	salt, hash, err := HashAPIKey("k1")
+	require.NoError(t, err)
 	cacheEntry := &APIKeyAuthCacheEntry{
-		Snapshot: &APIKeyAuthSnapshot{
+		Candidates: []*APIKeyAuthSnapshot{{
 			APIKeyID: 1,
 			UserID:   2,
+			KeySalt:  salt,
+			KeyHash:  hash,
 			GroupID:  &groupID,
 			Status:   StatusActive,
 			User: APIKeyAuthUserSnapshot{
@@ -195,7 +210,7 @@ func TestAPIKeyService_GetByKey_UsesL2Cache(t *testing.T) {
 					"claude-opus-*": {1, 2},
 				},
 			},
-		},
+		}},
 	}
 	cache.getAuthCache = func(ctx context.Context, key string) (*APIKeyAuthCacheEntry, error) {
 		return cacheEntry, nil
@@ -204,6 +219,7 @@ func TestAPIKeyService_GetByKey_UsesL2Cache(t *testing.T) {
 	apiKey, err := svc.GetByKey(context.Background(), "k1")
 	require.NoError(t, err)
 	require.Equal(t, int64(1), apiKey.ID)
+	require.Equal(t, "k1", apiKey.Key)
 	require.Equal(t, int64(2), apiKey.User.ID)
 	require.Equal(t, groupID, apiKey.Group.ID)
 	require.True(t, apiKey.Group.ModelRoutingEnabled)
@@ -213,7 +229,7 @@ func TestAPIKeyService_GetByKey_UsesL2Cache(t *testing.T) {
 func TestAPIKeyService_GetByKey_NegativeCache(t *testing.T) {
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		getByKeyForAuth: func(ctx context.Context, key string) (*APIKey, error) {
+		listByKeyPrefixForAuth: func(ctx context.Context, prefix string) ([]APIKey, error) {
 			return nil, errors.New("unexpected repo call")
 		},
 	}
@@ -235,8 +251,8 @@ func TestAPIKeyService_GetByKey_NegativeCache(t *testing.T) {
 func TestAPIKeyService_GetByKey_CacheMissStoresL2(t *testing.T) {
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		getByKeyForAuth: func(ctx context.Context, key string) (*APIKey, error) {
-			return &APIKey{
+		listByKeyPrefixForAuth: func(ctx context.Context, prefix string) ([]APIKey, error) {
+			return []APIKey{hashedAuthKey(t, "k2", APIKey{
 				ID:     5,
 				UserID: 7,
 				Status: StatusActive,
@@ -247,7 +263,7 @@ func TestAPIKeyService_GetByKey_CacheMissStoresL2(t *testing.T) {
 					Balance:     12,
 					Concurrency: 2,
 				},
-			}, nil
+			})}, nil
 		},
 	}
 	cfg := &config.Config{
@@ -271,9 +287,9 @@ func TestAPIKeyService_GetByKey_UsesL1Cache(t *testing.T) {
 	var calls int32
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		getByKeyForAuth: func(ctx context.Context, key string) (*APIKey, error) {
+		listByKeyPrefixForAuth: func(ctx context.Context, prefix string) ([]APIKey, error) {
 			atomic.AddInt32(&calls, 1)
-			return &APIKey{
+			return []APIKey{hashedAuthKey(t, "k-l1", APIKey{
 				ID:     21,
 				UserID: 3,
 				Status: StatusActive,
@@ -284,7 +300,7 @@ func TestAPIKeyService_GetByKey_UsesL1Cache(t *testing.T) {
 					Balance:     5,
 					Concurrency: 2,
 				},
-			}, nil
+			})}, nil
 		},
 	}
 	cfg := &config.Config{
@@ -299,7 +315,7 @@ func TestAPIKeyService_GetByKey_UsesL1Cache(t *testing.T) {
 	_, err := svc.GetByKey(context.Background(), "k-l1")
 	require.NoError(t, err)
 	svc.authCacheL1.Wait()
-	cacheKey := svc.authCacheKey("k-l1")
+	cacheKey := svc.authCacheKey(APIKeyPrefix("k-l1"))
 	_, ok := svc.authCacheL1.Get(cacheKey)
 	require.True(t, ok)
 	_, err = svc.GetByKey(context.Background(), "k-l1")
@@ -310,7 +326,7 @@ func TestAPIKeyService_GetByKey_UsesL1Cache(t *testing.T) {
 func TestAPIKeyService_InvalidateAuthCacheByUserID(t *testing.T) {
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		listKeysByUserID: func(ctx context.Context, userID int64) ([]string, error) {
+		listKeyPrefixesByUserID: func(ctx context.Context, userID int64) ([]string, error) {
 			return []string{"k1", "k2"}, nil
 		},
 	}
@@ -329,7 +345,7 @@ func TestAPIKeyService_InvalidateAuthCacheByUserID(t *testing.T) {
 func TestAPIKeyService_InvalidateAuthCacheByGroupID(t *testing.T) {
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		listKeysByGroupID: func(ctx context.Context, groupID int64) ([]string, error) {
+		listKeyPrefixesByGroupID: func(ctx context.Context, groupID int64) ([]string, error) {
 			return []string{"k1", "k2"}, nil
 		},
 	}
@@ -344,10 +360,10 @@ func TestAPIKeyService_InvalidateAuthCacheByGroupID(t *testing.T) {
 	require.Len(t, cache.deleteAuthKeys, 2)
 }
 
-func TestAPIKeyService_InvalidateAuthCacheByKey(t *testing.T) {
+func TestAPIKeyService_InvalidateAuthCacheByKeyPrefix(t *testing.T) {
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		listKeysByUserID: func(ctx context.Context, userID int64) ([]string, error) {
+		listKeyPrefixesByUserID: func(ctx context.Context, userID int64) ([]string, error) {
 			return nil, nil
 		},
 	}
@@ -358,15 +374,15 @@ func TestAPIKeyService_InvalidateAuthCacheByKey(t *testing.T) {
 	}
 	svc := NewAPIKeyService(repo, nil, nil, nil, nil, cache, cfg)
 
-	svc.InvalidateAuthCacheByKey(context.Background(), "k1")
+	svc.InvalidateAuthCacheByKeyPrefix(context.Background(), "k")
 	require.Len(t, cache.deleteAuthKeys, 1)
 }
 
 func TestAPIKeyService_GetByKey_CachesNegativeOnRepoMiss(t *testing.T) {
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		getByKeyForAuth: func(ctx context.Context, key string) (*APIKey, error) {
-			return nil, ErrAPIKeyNotFound
+		listByKeyPrefixForAuth: func(ctx context.Context, prefix string) ([]APIKey, error) {
+			return nil, nil
 		},
 	}
 	cfg := &config.Config{
@@ -389,10 +405,10 @@ func TestAPIKeyService_GetByKey_SingleflightCollapses(t *testing.T) {
 	var calls int32
 	cache := &authCacheStub{}
 	repo := &authRepoStub{
-		getByKeyForAuth: func(ctx context.Context, key string) (*APIKey, error) {
+		listByKeyPrefixForAuth: func(ctx context.Context, prefix string) ([]APIKey, error) {
 			atomic.AddInt32(&calls, 1)
 			time.Sleep(50 * time.Millisecond)
-			return &APIKey{
+			return []APIKey{hashedAuthKey(t, "k1", APIKey{
 				ID:     11,
 				UserID: 2,
 				Status: StatusActive,
@@ -403,7 +419,7 @@ func TestAPIKeyService_GetByKey_SingleflightCollapses(t *testing.T) {
 					Balance:     1,
 					Concurrency: 1,
 				},
-			}, nil
+			})}, nil
 		},
 	}
 	cfg := &config.Config{
@@ -433,3 +449,66 @@ func TestAPIKeyService_GetByKey_SingleflightCollapses(t *testing.T) {
 	}
 	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
 }
+
+func TestAPIKeyService_GetByKey_RejectsWrongKeyWithCachedPrefix(t *testing.T) {
+	cache := &authCacheStub{}
+	repo := &authRepoStub{
+		listByKeyPrefixForAuth: func(ctx context.Context, prefix string) ([]APIKey, error) {
+			return nil, errors.New("unexpected repo call")
+		},
+	}
+	cfg := &config.Config{
+		APIKeyAuth: config.APIKeyAuthCacheConfig{
+			L2TTLSeconds: 60,
+		},
+	}
+	svc := NewAPIKeyService(repo, nil, nil, nil, nil, cache, cfg)
+
+	salt, hash, err := HashAPIKey("sk-shared-right")
+	require.NoError(t, err)
+	cache.getAuthCache = func(ctx context.Context, key string) (*APIKeyAuthCacheEntry, error) {
+		return &APIKeyAuthCacheEntry{Candidates: []*APIKeyAuthSnapshot{{
+			APIKeyID: 1,
+			UserID:   2,
+			KeySalt:  salt,
+			KeyHash:  hash,
+			Status:   StatusActive,
+			User:     APIKeyAuthUserSnapshot{ID: 2, Status: StatusActive},
+		}}}, nil
+	}
+
+	// 前缀相同但哈希不匹配：候选列表是权威的，直接判定不存在而不回源
+	require.Equal(t, APIKeyPrefix("sk-shared-right"), APIKeyPrefix("sk-shared-wrong"))
+	_, err = svc.GetByKey(context.Background(), "sk-shared-wrong")
+	require.ErrorIs(t, err, ErrAPIKeyNotFound)
+
+	apiKey, err := svc.GetByKey(context.Background(), "sk-shared-right")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), apiKey.ID)
+}
+
+func TestAPIKeyService_GetByKey_SelectsMatchingCandidate(t *testing.T) {
+	cache := &authCacheStub{}
+	user := &User{ID: 3, Status: StatusActive, Role: RoleUser}
+	repo := &authRepoStub{
+		listByKeyPrefixForAuth: func(ctx context.Context, prefix string) ([]APIKey, error) {
+			return []APIKey{
+				hashedAuthKey(t, "sk-collide-aaaa", APIKey{ID: 31, UserID: 3, Status: StatusActive, User: user}),
+				hashedAuthKey(t, "sk-collide-bbbb", APIKey{ID: 32, UserID: 3, Status: StatusActive, User: user}),
+			}, nil
+		},
+	}
+	svc := NewAPIKeyService(repo, nil, nil, nil, nil, cache, &config.Config{})
+
+	a, err := svc.GetByKey(context.Background(), "sk-collide-aaaa")
+	require.NoError(t, err)
+	require.Equal(t, int64(31), a.ID)
+	require.Equal(t, "sk-collide-aaaa", a.Key)
+
+	b, err := svc.GetByKey(context.Background(), "sk-collide-bbbb")
+	require.NoError(t, err)
+	require.Equal(t, int64(32), b.ID)
+
+	_, err = svc.GetByKey(context.Background(), "sk-collide-cccc")
+	require.ErrorIs(t, err, ErrAPIKeyNotFound)
+}
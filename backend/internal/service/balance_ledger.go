@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+// 余额流水类型
+const (
+	// BalanceLedgerTypeInitial 创建用户时的初始余额（迁移时为已有用户写入的期初余额也使用该类型）
+	BalanceLedgerTypeInitial = "initial"
+	// BalanceLedgerTypeUsage 请求计费扣费，reference_id 为 usage_logs.id
+	BalanceLedgerTypeUsage = "usage"
+	// BalanceLedgerTypeRedeem 兑换码充值，reference_id 为 redeem_codes.id
+	BalanceLedgerTypeRedeem = "redeem"
+	// BalanceLedgerTypePromo 优惠码赠送，reference_id 为 promo_codes.id
+	BalanceLedgerTypePromo = "promo"
+	// BalanceLedgerTypeAdminAdjust 管理员调整余额，reference_id 为操作管理员的 users.id
+	BalanceLedgerTypeAdminAdjust = "admin_adjust"
+	// BalanceLedgerTypeAdjustment 未归类的余额变更（直接调用 UpdateBalance/DeductBalance）
+	BalanceLedgerTypeAdjustment = "adjustment"
+)
+
+// BalanceLedgerEntry 一条余额流水
+type BalanceLedgerEntry struct {
+	ID            int64
+	UserID        int64
+	Type          string
+	Amount        float64 // 有符号变更额（入账为正，扣费为负）
+	BalanceBefore float64
+	BalanceAfter  float64
+	ReferenceID   *int64
+	APIKeyID      *int64
+	Note          string
+	CreatedAt     time.Time
+}
+
+// BalanceLedgerFilters 余额流水查询条件
+type BalanceLedgerFilters struct {
+	Type      string
+	StartTime *time.Time
+	EndTime   *time.Time
+}
+
+// BalanceLedgerDrift 流水合计与 users.balance 不一致的用户
+type BalanceLedgerDrift struct {
+	UserID        int64
+	Email         string
+	Balance       float64
+	LedgerBalance float64
+	Drift         float64 // Balance - LedgerBalance
+	EntryCount    int64
+	LastEntryAt   *time.Time
+}
+
+// BalanceLedgerRepository 余额流水查询与对账。
+// 流水的写入与余额变更绑定，由 UserRepository.ApplyBalanceChange / SetBalance 完成。
+type BalanceLedgerRepository interface {
+	ListByUser(ctx context.Context, userID int64, params pagination.PaginationParams, filters BalanceLedgerFilters) ([]BalanceLedgerEntry, *pagination.PaginationResult, error)
+	// FindDrift 返回 |users.balance - SUM(amount)| 超过 tolerance 的用户（最多 limit 个）
+	FindDrift(ctx context.Context, tolerance float64, limit int) ([]BalanceLedgerDrift, error)
+}
+
+// usageBalanceLedgerEntry 构造请求计费扣费流水；usageLog 未成功落库时不带 reference_id
+func usageBalanceLedgerEntry(usageLog *UsageLog, actualCost float64) *BalanceLedgerEntry {
+	entry := &BalanceLedgerEntry{
+		UserID: usageLog.UserID,
+		Type:   BalanceLedgerTypeUsage,
+		Amount: -actualCost,
+		Note:   usageLog.Model,
+	}
+	if usageLog.ID > 0 {
+		id := usageLog.ID
+		entry.ReferenceID = &id
+	}
+	if usageLog.APIKeyID > 0 {
+		apiKeyID := usageLog.APIKeyID
+		entry.APIKeyID = &apiKeyID
+	}
+	return entry
+}
+
+type balanceLedgerActorCtxKey struct{}
+
+// WithBalanceLedgerActor 在上下文中标记执行余额操作的管理员，写入 admin_adjust 流水的 reference_id
+func WithBalanceLedgerActor(ctx context.Context, actorUserID int64) context.Context {
+	return context.WithValue(ctx, balanceLedgerActorCtxKey{}, actorUserID)
+}
+
+func balanceLedgerActorFromContext(ctx context.Context) *int64 {
+	if ctx == nil {
+		return nil
+	}
+	actorID, ok := ctx.Value(balanceLedgerActorCtxKey{}).(int64)
+	if !ok || actorID <= 0 {
+		return nil
+	}
+	return &actorID
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+const balanceLedgerReconcileTimeout = 2 * time.Minute
+
+// BalanceLedgerReport 一次对账的结果
+type BalanceLedgerReport struct {
+	CheckedAt time.Time
+	Tolerance float64
+	Drifts    []BalanceLedgerDrift
+}
+
+// BalanceLedgerService 余额流水查询与对账。
+//
+// 对账任务定期比较每个用户的流水合计与 users.balance，发现漂移时记录告警日志，
+// 最近一次结果可通过管理接口查看。
+type BalanceLedgerService struct {
+	repo     BalanceLedgerRepository
+	cfg      config.BalanceLedgerConfig
+	interval time.Duration
+
+	mu         sync.RWMutex
+	lastReport *BalanceLedgerReport
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewBalanceLedgerService creates a BalanceLedgerService
+func NewBalanceLedgerService(repo BalanceLedgerRepository, cfg *config.Config) *BalanceLedgerService {
+	s := &BalanceLedgerService{
+		repo:   repo,
+		stopCh: make(chan struct{}),
+	}
+	if cfg != nil {
+		s.cfg = cfg.Ledger
+		s.interval = time.Duration(cfg.Ledger.ReconcileIntervalMinutes) * time.Minute
+	}
+	return s
+}
+
+// ListByUser 分页查询用户余额流水（按时间倒序）
+func (s *BalanceLedgerService) ListByUser(ctx context.Context, userID int64, params pagination.PaginationParams, filters BalanceLedgerFilters) ([]BalanceLedgerEntry, *pagination.PaginationResult, error) {
+	filters.Type = strings.TrimSpace(filters.Type)
+	return s.repo.ListByUser(ctx, userID, params, filters)
+}
+
+// Reconcile 立即执行一次对账并更新最近结果
+func (s *BalanceLedgerService) Reconcile(ctx context.Context) (*BalanceLedgerReport, error) {
+	limit := s.cfg.MaxReportedUsers
+	if limit <= 0 {
+		limit = 100
+	}
+	drifts, err := s.repo.FindDrift(ctx, s.cfg.DriftTolerance, limit)
+	if err != nil {
+		return nil, err
+	}
+	report := &BalanceLedgerReport{
+		CheckedAt: time.Now(),
+		Tolerance: s.cfg.DriftTolerance,
+		Drifts:    drifts,
+	}
+	for _, d := range drifts {
+		log.Printf("[BalanceLedger] drift detected: user_id=%d balance=%.8f ledger=%.8f drift=%.8f", d.UserID, d.Balance, d.LedgerBalance, d.Drift)
+	}
+
+	s.mu.Lock()
+	s.lastReport = report
+	s.mu.Unlock()
+	return report, nil
+}
+
+// LastReport 返回最近一次对账结果（尚未执行时为 nil）
+func (s *BalanceLedgerService) LastReport() *BalanceLedgerReport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastReport
+}
+
+// Start 启动定时对账；interval <= 0 时不启动
+func (s *BalanceLedgerService) Start() {
+	if s == nil || s.repo == nil || s.interval <= 0 {
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.runOnce()
+		for {
+			select {
+			case <-ticker.C:
+				s.runOnce()
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定时对账
+func (s *BalanceLedgerService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+}
+
+func (s *BalanceLedgerService) runOnce() {
+	ctx, cancel := context.WithTimeout(context.Background(), balanceLedgerReconcileTimeout)
+	defer cancel()
+
+	report, err := s.Reconcile(ctx)
+	if err != nil {
+		log.Printf("[BalanceLedger] reconcile failed: %v", err)
+		return
+	}
+	if len(report.Drifts) > 0 {
+		log.Printf("[BalanceLedger] reconcile found %d user(s) with balance drift", len(report.Drifts))
+	}
+}
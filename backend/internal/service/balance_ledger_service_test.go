@@ -0,0 +1,88 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+type balanceLedgerRepoStub struct {
+	drifts    []BalanceLedgerDrift
+	driftErr  error
+	tolerance float64
+	limit     int
+	filters   BalanceLedgerFilters
+}
+
+func (s *balanceLedgerRepoStub) ListByUser(ctx context.Context, userID int64, params pagination.PaginationParams, filters BalanceLedgerFilters) ([]BalanceLedgerEntry, *pagination.PaginationResult, error) {
+	s.filters = filters
+	return nil, &pagination.PaginationResult{}, nil
+}
+
+func (s *balanceLedgerRepoStub) FindDrift(ctx context.Context, tolerance float64, limit int) ([]BalanceLedgerDrift, error) {
+	s.tolerance = tolerance
+	s.limit = limit
+	return s.drifts, s.driftErr
+}
+
+func TestBalanceLedgerService_Reconcile(t *testing.T) {
+	repo := &balanceLedgerRepoStub{drifts: []BalanceLedgerDrift{{UserID: 3, Balance: 10, LedgerBalance: 9.5, Drift: 0.5}}}
+	cfg := &config.Config{Ledger: config.BalanceLedgerConfig{DriftTolerance: 0.01, MaxReportedUsers: 20}}
+	svc := NewBalanceLedgerService(repo, cfg)
+	require.Nil(t, svc.LastReport())
+
+	report, err := svc.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 0.01, repo.tolerance)
+	require.Equal(t, 20, repo.limit)
+	require.Len(t, report.Drifts, 1)
+	require.Same(t, report, svc.LastReport())
+
+	repo.driftErr = errors.New("db down")
+	_, err = svc.Reconcile(context.Background())
+	require.Error(t, err)
+	require.Same(t, report, svc.LastReport(), "failed run keeps previous report")
+}
+
+func TestBalanceLedgerService_ReconcileDefaultLimit(t *testing.T) {
+	repo := &balanceLedgerRepoStub{}
+	svc := NewBalanceLedgerService(repo, &config.Config{})
+	_, err := svc.Reconcile(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 100, repo.limit)
+}
+
+func TestBalanceLedgerService_StartDisabled(t *testing.T) {
+	svc := NewBalanceLedgerService(&balanceLedgerRepoStub{}, &config.Config{})
+	svc.Start()
+	svc.Stop()
+	require.Nil(t, svc.LastReport())
+}
+
+func TestUsageBalanceLedgerEntry(t *testing.T) {
+	entry := usageBalanceLedgerEntry(&UsageLog{ID: 42, UserID: 7, APIKeyID: 9, Model: "claude-sonnet-4"}, 0.25)
+	require.Equal(t, BalanceLedgerTypeUsage, entry.Type)
+	require.Equal(t, int64(7), entry.UserID)
+	require.Equal(t, -0.25, entry.Amount)
+	require.Equal(t, int64(42), *entry.ReferenceID)
+	require.Equal(t, int64(9), *entry.APIKeyID)
+	require.Equal(t, "claude-sonnet-4", entry.Note)
+
+	// 使用记录未落库时不带 reference_id
+	entry = usageBalanceLedgerEntry(&UsageLog{UserID: 7, APIKeyID: 9}, 0.1)
+	require.Nil(t, entry.ReferenceID)
+}
+
+func TestBalanceLedgerActorFromContext(t *testing.T) {
+	require.Nil(t, balanceLedgerActorFromContext(context.Background()))
+	require.Nil(t, balanceLedgerActorFromContext(WithBalanceLedgerActor(context.Background(), 0)))
+	actor := balanceLedgerActorFromContext(WithBalanceLedgerActor(context.Background(), 5))
+	require.NotNil(t, actor)
+	require.Equal(t, int64(5), *actor)
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
 	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
 )
 
 // 错误定义
@@ -204,6 +205,7 @@ func (s *BillingCacheService) logCacheWriteDrop(task cacheWriteTask, reason stri
 	}
 
 	atomic.AddUint64(countPtr, 1)
+	metrics.IncBillingCacheWriteDrop(reason, cacheWriteKindName(task.kind))
 	now := time.Now().UnixNano()
 	last := atomic.LoadInt64(lastPtr)
 	if now-last < int64(cacheWriteDropLogInterval) {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/compatible"
 )
 
 // BillingCache defines cache operations for billing service
@@ -56,6 +57,19 @@ type CostBreakdown struct {
 	ActualCost        float64 // 应用倍率后的实际费用
 }
 
+// Scale 按比例缩放各项费用（用于响应缓存、批处理等折扣）
+func (c *CostBreakdown) Scale(factor float64) {
+	if c == nil {
+		return
+	}
+	c.InputCost *= factor
+	c.OutputCost *= factor
+	c.CacheCreationCost *= factor
+	c.CacheReadCost *= factor
+	c.TotalCost *= factor
+	c.ActualCost *= factor
+}
+
 // BillingService 计费服务
 type BillingService struct {
 	cfg            *config.Config
@@ -133,12 +147,45 @@ func (s *BillingService) initFallbackPricing() {
 		CacheReadPricePerToken:     0.03e-6, // $0.03 per MTok
 		SupportsCacheBreakdown:     false,
 	}
+
+	// Embedding 模型（仅输入计费）
+	s.fallbackPrices["text-embedding-3-small"] = &ModelPricing{
+		InputPricePerToken: 0.02e-6, // $0.02 per MTok
+	}
+	s.fallbackPrices["text-embedding-3-large"] = &ModelPricing{
+		InputPricePerToken: 0.13e-6, // $0.13 per MTok
+	}
+	s.fallbackPrices["text-embedding-ada-002"] = &ModelPricing{
+		InputPricePerToken: 0.1e-6, // $0.10 per MTok
+	}
+	s.fallbackPrices["gemini-embedding-001"] = &ModelPricing{
+		InputPricePerToken: 0.15e-6, // $0.15 per MTok
+	}
+
+	// OpenAI 兼容平台默认模型（按模型名精确匹配）
+	for model, p := range compatible.DefaultPricing {
+		s.fallbackPrices[model] = &ModelPricing{
+			InputPricePerToken:     p.InputPerMTok / 1e6,
+			OutputPricePerToken:    p.OutputPerMTok / 1e6,
+			CacheReadPricePerToken: p.CacheReadPerMTok / 1e6,
+		}
+	}
 }
 
 // getFallbackPricing 根据模型系列获取回退价格
 func (s *BillingService) getFallbackPricing(model string) *ModelPricing {
 	modelLower := strings.ToLower(model)
 
+	// 精确匹配（compatible 平台模型等）
+	if pricing, ok := s.fallbackPrices[modelLower]; ok {
+		return pricing
+	}
+
+	// Embedding 模型不能回退到对话模型价格
+	if strings.Contains(modelLower, "embedding") {
+		return s.getEmbeddingFallbackPricing(modelLower)
+	}
+
 	// 按模型系列匹配
 	if strings.Contains(modelLower, "opus") {
 		if strings.Contains(modelLower, "4.5") || strings.Contains(modelLower, "4-5") {
@@ -163,11 +210,37 @@ func (s *BillingService) getFallbackPricing(model string) *ModelPricing {
 	return s.fallbackPrices["claude-sonnet-4"]
 }
 
+// getEmbeddingFallbackPricing 根据 embedding 模型系列获取回退价格
+func (s *BillingService) getEmbeddingFallbackPricing(modelLower string) *ModelPricing {
+	switch {
+	case strings.Contains(modelLower, "gemini") || strings.Contains(modelLower, "text-embedding-00") || strings.HasPrefix(modelLower, "embedding-"):
+		return s.fallbackPrices["gemini-embedding-001"]
+	case strings.Contains(modelLower, "large"):
+		return s.fallbackPrices["text-embedding-3-large"]
+	case strings.Contains(modelLower, "ada"):
+		return s.fallbackPrices["text-embedding-ada-002"]
+	default:
+		return s.fallbackPrices["text-embedding-3-small"]
+	}
+}
+
 // GetModelPricing 获取模型价格配置
 func (s *BillingService) GetModelPricing(model string) (*ModelPricing, error) {
 	// 标准化模型名称（转小写）
 	model = strings.ToLower(model)
 
+	// 0. 配置文件中的自定义价格优先
+	if s.cfg != nil {
+		if override, ok := s.cfg.Pricing.ModelOverride(model); ok {
+			return &ModelPricing{
+				InputPricePerToken:         override.InputPerMTok / 1e6,
+				OutputPricePerToken:        override.OutputPerMTok / 1e6,
+				CacheCreationPricePerToken: override.CacheCreationPerMTok / 1e6,
+				CacheReadPricePerToken:     override.CacheReadPerMTok / 1e6,
+			}, nil
+		}
+	}
+
 	// 1. 优先从动态价格服务获取
 	if s.pricingService != nil {
 		litellmPricing := s.pricingService.GetModelPricing(model)
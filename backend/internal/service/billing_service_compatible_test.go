@@ -0,0 +1,41 @@
+//go:build unit
+
+package service
+
+import (
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGetModelPricing_CompatibleFallback 测试 OpenAI 兼容上游默认模型使用内置价格，而非回退到 Claude 价格
+func TestGetModelPricing_CompatibleFallback(t *testing.T) {
+	svc := NewBillingService(&config.Config{}, nil)
+
+	pricing, err := svc.GetModelPricing("deepseek-chat")
+	require.NoError(t, err)
+	require.InDelta(t, 0.28/1e6, pricing.InputPricePerToken, 1e-12)
+	require.InDelta(t, 0.42/1e6, pricing.OutputPricePerToken, 1e-12)
+	require.InDelta(t, 0.028/1e6, pricing.CacheReadPricePerToken, 1e-12)
+}
+
+// TestGetModelPricing_ConfigOverride 测试 pricing.model_overrides 优先于其他价格来源
+func TestGetModelPricing_ConfigOverride(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Pricing.ModelOverrides = []config.ModelPriceOverride{
+		{Model: "my-vllm-model", InputPerMTok: 0.5, OutputPerMTok: 1.5},
+		{Model: "deepseek-chat", InputPerMTok: 1, OutputPerMTok: 2, CacheReadPerMTok: 0.1},
+	}
+	svc := NewBillingService(cfg, nil)
+
+	pricing, err := svc.GetModelPricing("My-VLLM-Model")
+	require.NoError(t, err)
+	require.InDelta(t, 0.5/1e6, pricing.InputPricePerToken, 1e-12)
+	require.InDelta(t, 1.5/1e6, pricing.OutputPricePerToken, 1e-12)
+
+	pricing, err = svc.GetModelPricing("deepseek-chat")
+	require.NoError(t, err)
+	require.InDelta(t, 1/1e6, pricing.InputPricePerToken, 1e-12)
+	require.InDelta(t, 0.1/1e6, pricing.CacheReadPricePerToken, 1e-12)
+}
@@ -0,0 +1,1096 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OpenAI Chat Completions 兼容层
+//
+// /v1/chat/completions 请求不会单独实现上游转发逻辑，而是：
+//   - 请求侧：转换为 Anthropic Messages（Claude/Gemini/Antigravity 分组）或 Responses API（OpenAI 分组）；
+//   - 响应侧：将对应协议的 JSON / SSE 输出逐块转换回 chat.completion / chat.completion.chunk。
+//
+// 这样调度、粘性会话、计费（RecordUsage）、运维错误日志等逻辑全部复用现有 Forward 流程。
+
+const (
+	chatCompletionsDefaultMaxTokens = 8192
+	chatCompletionsObject           = "chat.completion"
+	chatCompletionsChunkObject      = "chat.completion.chunk"
+)
+
+// ChatCompletionsRequest 是 Chat Completions 请求中网关关心的字段子集
+type ChatCompletionsRequest struct {
+	Model         string                   `json:"model"`
+	Messages      []ChatCompletionsMessage `json:"messages"`
+	Stream        bool                     `json:"stream"`
+	StreamOptions *struct {
+		IncludeUsage bool `json:"include_usage"`
+	} `json:"stream_options,omitempty"`
+	MaxTokens           *int            `json:"max_tokens,omitempty"`
+	MaxCompletionTokens *int            `json:"max_completion_tokens,omitempty"`
+	Temperature         *float64        `json:"temperature,omitempty"`
+	TopP                *float64        `json:"top_p,omitempty"`
+	Stop                json.RawMessage `json:"stop,omitempty"`
+	Tools               []struct {
+		Type     string `json:"type"`
+		Function struct {
+			Name        string          `json:"name"`
+			Description string          `json:"description,omitempty"`
+			Parameters  json.RawMessage `json:"parameters,omitempty"`
+		} `json:"function"`
+	} `json:"tools,omitempty"`
+	ToolChoice      json.RawMessage `json:"tool_choice,omitempty"`
+	ReasoningEffort string          `json:"reasoning_effort,omitempty"`
+	User            string          `json:"user,omitempty"`
+}
+
+// ChatCompletionsMessage 表示 Chat Completions 的单条消息
+type ChatCompletionsMessage struct {
+	Role       string          `json:"role"`
+	Content    json.RawMessage `json:"content,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	ToolCalls  []chatToolCall  `json:"tool_calls,omitempty"`
+	ToolCallID string          `json:"tool_call_id,omitempty"`
+}
+
+type chatToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type chatContentPart struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL *struct {
+		URL string `json:"url"`
+	} `json:"image_url,omitempty"`
+}
+
+// ParseChatCompletionsRequest 解析 Chat Completions 请求体
+func ParseChatCompletionsRequest(body []byte) (*ChatCompletionsRequest, error) {
+	var req ChatCompletionsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Model) == "" {
+		return nil, errors.New("model is required")
+	}
+	if len(req.Messages) == 0 {
+		return nil, errors.New("messages is required")
+	}
+	return &req, nil
+}
+
+// IncludeUsage 返回流式请求是否要求在末尾追加 usage chunk
+func (r *ChatCompletionsRequest) IncludeUsage() bool {
+	return r != nil && r.StreamOptions != nil && r.StreamOptions.IncludeUsage
+}
+
+func (r *ChatCompletionsRequest) maxTokens() (int, bool) {
+	if r.MaxCompletionTokens != nil && *r.MaxCompletionTokens > 0 {
+		return *r.MaxCompletionTokens, true
+	}
+	if r.MaxTokens != nil && *r.MaxTokens > 0 {
+		return *r.MaxTokens, true
+	}
+	return 0, false
+}
+
+func (r *ChatCompletionsRequest) stopSequences() []string {
+	if len(r.Stop) == 0 {
+		return nil
+	}
+	var single string
+	if err := json.Unmarshal(r.Stop, &single); err == nil {
+		if single == "" {
+			return nil
+		}
+		return []string{single}
+	}
+	var list []string
+	if err := json.Unmarshal(r.Stop, &list); err == nil {
+		return list
+	}
+	return nil
+}
+
+// parseChatContent 将 content（字符串或 parts 数组）统一为 parts
+func parseChatContent(raw json.RawMessage) ([]chatContentPart, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		if text == "" {
+			return nil, nil
+		}
+		return []chatContentPart{{Type: "text", Text: text}}, nil
+	}
+	var parts []chatContentPart
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return nil, fmt.Errorf("invalid message content: %w", err)
+	}
+	return parts, nil
+}
+
+func chatContentText(raw json.RawMessage) string {
+	parts, _ := parseChatContent(raw)
+	var sb strings.Builder
+	for _, p := range parts {
+		if p.Type == "text" || p.Type == "input_text" {
+			if sb.Len() > 0 && p.Text != "" {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(p.Text)
+		}
+	}
+	return sb.String()
+}
+
+// parseDataURL 解析 data:<media>;base64,<data> 格式的图片 URL
+func parseDataURL(url string) (mediaType, data string, ok bool) {
+	if !strings.HasPrefix(url, "data:") {
+		return "", "", false
+	}
+	meta, payload, found := strings.Cut(strings.TrimPrefix(url, "data:"), ",")
+	if !found || !strings.HasSuffix(meta, ";base64") {
+		return "", "", false
+	}
+	return strings.TrimSuffix(meta, ";base64"), payload, true
+}
+
+// ========== Chat Completions -> Anthropic Messages ==========
+
+// ConvertChatCompletionsToClaudeMessages 将 Chat Completions 请求转换为 Anthropic Messages 请求体
+func ConvertChatCompletionsToClaudeMessages(req *ChatCompletionsRequest) ([]byte, error) {
+	var systemParts []string
+	messages := make([]map[string]any, 0, len(req.Messages))
+
+	appendBlocks := func(role string, blocks []map[string]any) {
+		if len(blocks) == 0 {
+			return
+		}
+		// 连续的同角色消息合并，满足 Anthropic 的角色交替要求（tool 结果需要合并到同一条 user 消息中）
+		if n := len(messages); n > 0 && messages[n-1]["role"] == role {
+			prev, _ := messages[n-1]["content"].([]map[string]any)
+			messages[n-1]["content"] = append(prev, blocks...)
+			return
+		}
+		messages = append(messages, map[string]any{"role": role, "content": blocks})
+	}
+
+	for _, msg := range req.Messages {
+		switch msg.Role {
+		case "system", "developer":
+			if text := chatContentText(msg.Content); text != "" {
+				systemParts = append(systemParts, text)
+			}
+		case "user", "assistant":
+			parts, err := parseChatContent(msg.Content)
+			if err != nil {
+				return nil, err
+			}
+			blocks := make([]map[string]any, 0, len(parts)+len(msg.ToolCalls))
+			for _, p := range parts {
+				switch p.Type {
+				case "text":
+					if p.Text != "" {
+						blocks = append(blocks, map[string]any{"type": "text", "text": p.Text})
+					}
+				case "image_url":
+					if p.ImageURL == nil || p.ImageURL.URL == "" {
+						continue
+					}
+					if mediaType, data, ok := parseDataURL(p.ImageURL.URL); ok {
+						blocks = append(blocks, map[string]any{
+							"type":   "image",
+							"source": map[string]any{"type": "base64", "media_type": mediaType, "data": data},
+						})
+					} else {
+						blocks = append(blocks, map[string]any{
+							"type":   "image",
+							"source": map[string]any{"type": "url", "url": p.ImageURL.URL},
+						})
+					}
+				}
+			}
+			if msg.Role == "assistant" {
+				for _, tc := range msg.ToolCalls {
+					input := map[string]any{}
+					if strings.TrimSpace(tc.Function.Arguments) != "" {
+						if err := json.Unmarshal([]byte(tc.Function.Arguments), &input); err != nil {
+							return nil, fmt.Errorf("invalid tool call arguments for %s: %w", tc.Function.Name, err)
+						}
+					}
+					blocks = append(blocks, map[string]any{
+						"type":  "tool_use",
+						"id":    tc.ID,
+						"name":  tc.Function.Name,
+						"input": input,
+					})
+				}
+			}
+			appendBlocks(msg.Role, blocks)
+		case "tool", "function":
+			appendBlocks("user", []map[string]any{{
+				"type":        "tool_result",
+				"tool_use_id": msg.ToolCallID,
+				"content":     chatContentText(msg.Content),
+			}})
+		default:
+			return nil, fmt.Errorf("unsupported message role: %s", msg.Role)
+		}
+	}
+	if len(messages) == 0 {
+		return nil, errors.New("messages must contain at least one user or assistant message")
+	}
+
+	out := map[string]any{
+		"model":    req.Model,
+		"messages": messages,
+		"stream":   req.Stream,
+	}
+	if maxTokens, ok := req.maxTokens(); ok {
+		out["max_tokens"] = maxTokens
+	} else {
+		out["max_tokens"] = chatCompletionsDefaultMaxTokens
+	}
+	if len(systemParts) > 0 {
+		out["system"] = strings.Join(systemParts, "\n\n")
+	}
+	if req.Temperature != nil {
+		out["temperature"] = *req.Temperature
+	}
+	if req.TopP != nil {
+		out["top_p"] = *req.TopP
+	}
+	if stops := req.stopSequences(); len(stops) > 0 {
+		out["stop_sequences"] = stops
+	}
+	if len(req.Tools) > 0 {
+		tools := make([]map[string]any, 0, len(req.Tools))
+		for _, t := range req.Tools {
+			if t.Type != "" && t.Type != "function" {
+				continue
+			}
+			schema := json.RawMessage(`{"type":"object","properties":{}}`)
+			if len(t.Function.Parameters) > 0 && string(t.Function.Parameters) != "null" {
+				schema = t.Function.Parameters
+			}
+			tool := map[string]any{"name": t.Function.Name, "input_schema": schema}
+			if t.Function.Description != "" {
+				tool["description"] = t.Function.Description
+			}
+			tools = append(tools, tool)
+		}
+		out["tools"] = tools
+	}
+	if choice := convertChatToolChoiceToClaude(req.ToolChoice); choice != nil {
+		out["tool_choice"] = choice
+	}
+	return json.Marshal(out)
+}
+
+func convertChatToolChoiceToClaude(raw json.RawMessage) map[string]any {
+	if len(raw) == 0 {
+		return nil
+	}
+	var mode string
+	if err := json.Unmarshal(raw, &mode); err == nil {
+		switch mode {
+		case "auto":
+			return map[string]any{"type": "auto"}
+		case "none":
+			return map[string]any{"type": "none"}
+		case "required":
+			return map[string]any{"type": "any"}
+		}
+		return nil
+	}
+	var obj struct {
+		Function struct {
+			Name string `json:"name"`
+		} `json:"function"`
+	}
+	if err := json.Unmarshal(raw, &obj); err == nil && obj.Function.Name != "" {
+		return map[string]any{"type": "tool", "name": obj.Function.Name}
+	}
+	return nil
+}
+
+// ========== Chat Completions -> Responses API ==========
+
+// ConvertChatCompletionsToResponses 将 Chat Completions 请求转换为 OpenAI Responses API 请求体
+func ConvertChatCompletionsToResponses(req *ChatCompletionsRequest) ([]byte, error) {
+	var instructions []string
+	input := make([]map[string]any, 0, len(req.Messages))
+
+	for _, msg := range req.Messages {
+		switch msg.Role {
+		case "system", "developer":
+			if text := chatContentText(msg.Content); text != "" {
+				instructions = append(instructions, text)
+			}
+		case "user", "assistant":
+			parts, err := parseChatContent(msg.Content)
+			if err != nil {
+				return nil, err
+			}
+			textType := "input_text"
+			if msg.Role == "assistant" {
+				textType = "output_text"
+			}
+			content := make([]map[string]any, 0, len(parts))
+			for _, p := range parts {
+				switch p.Type {
+				case "text":
+					if p.Text != "" {
+						content = append(content, map[string]any{"type": textType, "text": p.Text})
+					}
+				case "image_url":
+					if p.ImageURL != nil && p.ImageURL.URL != "" && msg.Role == "user" {
+						content = append(content, map[string]any{"type": "input_image", "image_url": p.ImageURL.URL})
+					}
+				}
+			}
+			if len(content) > 0 {
+				input = append(input, map[string]any{"type": "message", "role": msg.Role, "content": content})
+			}
+			if msg.Role == "assistant" {
+				for _, tc := range msg.ToolCalls {
+					input = append(input, map[string]any{
+						"type":      "function_call",
+						"call_id":   tc.ID,
+						"name":      tc.Function.Name,
+						"arguments": tc.Function.Arguments,
+					})
+				}
+			}
+		case "tool", "function":
+			input = append(input, map[string]any{
+				"type":    "function_call_output",
+				"call_id": msg.ToolCallID,
+				"output":  chatContentText(msg.Content),
+			})
+		default:
+			return nil, fmt.Errorf("unsupported message role: %s", msg.Role)
+		}
+	}
+	if len(input) == 0 {
+		return nil, errors.New("messages must contain at least one user or assistant message")
+	}
+
+	out := map[string]any{
+		"model":  req.Model,
+		"input":  input,
+		"stream": req.Stream,
+	}
+	if len(instructions) > 0 {
+		out["instructions"] = strings.Join(instructions, "\n\n")
+	}
+	if maxTokens, ok := req.maxTokens(); ok {
+		out["max_output_tokens"] = maxTokens
+	}
+	if req.Temperature != nil {
+		out["temperature"] = *req.Temperature
+	}
+	if req.TopP != nil {
+		out["top_p"] = *req.TopP
+	}
+	if req.ReasoningEffort != "" {
+		out["reasoning"] = map[string]any{"effort": req.ReasoningEffort}
+	}
+	if req.User != "" {
+		out["user"] = req.User
+	}
+	if len(req.Tools) > 0 {
+		tools := make([]map[string]any, 0, len(req.Tools))
+		for _, t := range req.Tools {
+			if t.Type != "" && t.Type != "function" {
+				continue
+			}
+			tool := map[string]any{"type": "function", "name": t.Function.Name}
+			if t.Function.Description != "" {
+				tool["description"] = t.Function.Description
+			}
+			if len(t.Function.Parameters) > 0 && string(t.Function.Parameters) != "null" {
+				tool["parameters"] = t.Function.Parameters
+			}
+			tools = append(tools, tool)
+		}
+		out["tools"] = tools
+	}
+	if len(req.ToolChoice) > 0 {
+		var mode string
+		if err := json.Unmarshal(req.ToolChoice, &mode); err == nil {
+			out["tool_choice"] = mode
+		} else {
+			var obj struct {
+				Function struct {
+					Name string `json:"name"`
+				} `json:"function"`
+			}
+			if err := json.Unmarshal(req.ToolChoice, &obj); err == nil && obj.Function.Name != "" {
+				out["tool_choice"] = map[string]any{"type": "function", "name": obj.Function.Name}
+			}
+		}
+	}
+	return json.Marshal(out)
+}
+
+// ========== 响应转换（非流式） ==========
+
+type chatCompletionUsage struct {
+	PromptTokens        int `json:"prompt_tokens"`
+	CompletionTokens    int `json:"completion_tokens"`
+	TotalTokens         int `json:"total_tokens"`
+	PromptTokensDetails *struct {
+		CachedTokens int `json:"cached_tokens"`
+	} `json:"prompt_tokens_details,omitempty"`
+}
+
+func newChatCompletionUsage(input, output, cached int) *chatCompletionUsage {
+	u := &chatCompletionUsage{
+		PromptTokens:     input,
+		CompletionTokens: output,
+		TotalTokens:      input + output,
+	}
+	if cached > 0 {
+		u.PromptTokensDetails = &struct {
+			CachedTokens int `json:"cached_tokens"`
+		}{CachedTokens: cached}
+	}
+	return u
+}
+
+func claudeStopReasonToFinishReason(reason string) string {
+	switch reason {
+	case "max_tokens":
+		return "length"
+	case "tool_use":
+		return "tool_calls"
+	case "refusal":
+		return "content_filter"
+	case "":
+		return ""
+	default:
+		return "stop"
+	}
+}
+
+func chatCompletionID(upstreamID string) string {
+	if upstreamID == "" {
+		return "chatcmpl-" + randomHex(12)
+	}
+	return "chatcmpl-" + upstreamID
+}
+
+// ConvertClaudeMessageToChatCompletion 将 Anthropic Messages 非流式响应转换为 chat.completion
+func ConvertClaudeMessageToChatCompletion(body []byte, model string) ([]byte, error) {
+	var resp struct {
+		ID         string `json:"id"`
+		Model      string `json:"model"`
+		StopReason string `json:"stop_reason"`
+		Content    []struct {
+			Type     string          `json:"type"`
+			Text     string          `json:"text"`
+			Thinking string          `json:"thinking"`
+			ID       string          `json:"id"`
+			Name     string          `json:"name"`
+			Input    json.RawMessage `json:"input"`
+		} `json:"content"`
+		Usage ClaudeUsage `json:"usage"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	var text, reasoning strings.Builder
+	toolCalls := make([]map[string]any, 0)
+	for _, block := range resp.Content {
+		switch block.Type {
+		case "text":
+			text.WriteString(block.Text)
+		case "thinking":
+			reasoning.WriteString(block.Thinking)
+		case "tool_use":
+			args := string(block.Input)
+			if args == "" || args == "null" {
+				args = "{}"
+			}
+			toolCalls = append(toolCalls, map[string]any{
+				"id":       block.ID,
+				"type":     "function",
+				"function": map[string]any{"name": block.Name, "arguments": args},
+			})
+		}
+	}
+
+	message := map[string]any{"role": "assistant", "content": text.String()}
+	if reasoning.Len() > 0 {
+		message["reasoning_content"] = reasoning.String()
+	}
+	if len(toolCalls) > 0 {
+		message["tool_calls"] = toolCalls
+		if text.Len() == 0 {
+			message["content"] = nil
+		}
+	}
+	finishReason := claudeStopReasonToFinishReason(resp.StopReason)
+	if finishReason == "" {
+		finishReason = "stop"
+	}
+	if model == "" {
+		model = resp.Model
+	}
+
+	u := resp.Usage
+	promptTokens := u.InputTokens + u.CacheReadInputTokens + u.CacheCreationInputTokens
+	return json.Marshal(map[string]any{
+		"id":      chatCompletionID(resp.ID),
+		"object":  chatCompletionsObject,
+		"created": time.Now().Unix(),
+		"model":   model,
+		"choices": []any{map[string]any{
+			"index":         0,
+			"message":       message,
+			"finish_reason": finishReason,
+		}},
+		"usage": newChatCompletionUsage(promptTokens, u.OutputTokens, u.CacheReadInputTokens),
+	})
+}
+
+// responsesObject 是 Responses API 响应对象中网关关心的字段子集
+type responsesObject struct {
+	ID                string `json:"id"`
+	Model             string `json:"model"`
+	Status            string `json:"status"`
+	IncompleteDetails *struct {
+		Reason string `json:"reason"`
+	} `json:"incomplete_details"`
+	Output []struct {
+		Type      string `json:"type"`
+		CallID    string `json:"call_id"`
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+		Content   []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		Summary []struct {
+			Text string `json:"text"`
+		} `json:"summary"`
+	} `json:"output"`
+	Usage *struct {
+		InputTokens        int `json:"input_tokens"`
+		OutputTokens       int `json:"output_tokens"`
+		InputTokensDetails struct {
+			CachedTokens int `json:"cached_tokens"`
+		} `json:"input_tokens_details"`
+	} `json:"usage"`
+}
+
+func (r *responsesObject) finishReason(hasToolCalls bool) string {
+	if r.Status == "incomplete" && r.IncompleteDetails != nil && r.IncompleteDetails.Reason == "max_output_tokens" {
+		return "length"
+	}
+	if r.Status == "incomplete" && r.IncompleteDetails != nil && r.IncompleteDetails.Reason == "content_filter" {
+		return "content_filter"
+	}
+	if hasToolCalls {
+		return "tool_calls"
+	}
+	return "stop"
+}
+
+func (r *responsesObject) chatUsage() *chatCompletionUsage {
+	if r.Usage == nil {
+		return nil
+	}
+	return newChatCompletionUsage(r.Usage.InputTokens, r.Usage.OutputTokens, r.Usage.InputTokensDetails.CachedTokens)
+}
+
+// ConvertResponsesToChatCompletion 将 Responses API 非流式响应转换为 chat.completion。
+// 兼容 OAuth 账号返回的 SSE 文本（取 response.completed 事件中的最终响应）。
+func ConvertResponsesToChatCompletion(body []byte, model string) ([]byte, error) {
+	if final, ok := extractCodexFinalResponse(string(body)); ok {
+		body = final
+	}
+	var resp responsesObject
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	var text, reasoning strings.Builder
+	toolCalls := make([]map[string]any, 0)
+	for _, item := range resp.Output {
+		switch item.Type {
+		case "message":
+			for _, c := range item.Content {
+				if c.Type == "output_text" {
+					text.WriteString(c.Text)
+				}
+			}
+		case "reasoning":
+			for _, s := range item.Summary {
+				reasoning.WriteString(s.Text)
+			}
+		case "function_call":
+			toolCalls = append(toolCalls, map[string]any{
+				"id":       item.CallID,
+				"type":     "function",
+				"function": map[string]any{"name": item.Name, "arguments": item.Arguments},
+			})
+		}
+	}
+
+	message := map[string]any{"role": "assistant", "content": text.String()}
+	if reasoning.Len() > 0 {
+		message["reasoning_content"] = reasoning.String()
+	}
+	if len(toolCalls) > 0 {
+		message["tool_calls"] = toolCalls
+		if text.Len() == 0 {
+			message["content"] = nil
+		}
+	}
+	if model == "" {
+		model = resp.Model
+	}
+	out := map[string]any{
+		"id":      chatCompletionID(strings.TrimPrefix(resp.ID, "resp_")),
+		"object":  chatCompletionsObject,
+		"created": time.Now().Unix(),
+		"model":   model,
+		"choices": []any{map[string]any{
+			"index":         0,
+			"message":       message,
+			"finish_reason": resp.finishReason(len(toolCalls) > 0),
+		}},
+	}
+	if usage := resp.chatUsage(); usage != nil {
+		out["usage"] = usage
+	}
+	return json.Marshal(out)
+}
+
+// ConvertErrorToChatCompletions 将 Claude / Gemini 风格错误体统一为 OpenAI 错误格式。
+// 无法识别的内容原样返回。
+func ConvertErrorToChatCompletions(body []byte) []byte {
+	var m map[string]any
+	if err := json.Unmarshal(body, &m); err != nil {
+		return body
+	}
+	errObj, ok := m["error"].(map[string]any)
+	if !ok {
+		return body
+	}
+	message, _ := errObj["message"].(string)
+	errType, _ := errObj["type"].(string)
+	if errType == "" {
+		if status, ok := errObj["status"].(string); ok && status != "" {
+			errType = strings.ToLower(status)
+		} else {
+			errType = "api_error"
+		}
+	}
+	out := map[string]any{"message": message, "type": errType}
+	if code, ok := errObj["code"]; ok {
+		out["code"] = code
+	}
+	b, err := json.Marshal(map[string]any{"error": out})
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+// ========== 响应转换（流式） ==========
+
+// ChatCompletionsStreamConverter 将上游 SSE 事件转换为 chat.completion.chunk 事件
+type ChatCompletionsStreamConverter interface {
+	// ConvertEvent 转换一个完整的 SSE 事件（不含结尾空行），返回需写给客户端的 SSE 文本
+	ConvertEvent(event string) []byte
+	// Finish 在上游流结束后调用，补齐 finish/usage/[DONE]（若尚未输出）
+	Finish() []byte
+	// Abort 在上游流未输出结束事件即中断时调用，输出错误事件而非正常结尾（若尚未结束）
+	Abort() []byte
+}
+
+type chatChunkEmitter struct {
+	id           string
+	model        string
+	created      int64
+	includeUsage bool
+	roleSent     bool
+	done         bool
+}
+
+func (e *chatChunkEmitter) chunk(delta map[string]any, finishReason any) []byte {
+	payload := map[string]any{
+		"id":      e.id,
+		"object":  chatCompletionsChunkObject,
+		"created": e.created,
+		"model":   e.model,
+		"choices": []any{map[string]any{
+			"index":         0,
+			"delta":         delta,
+			"finish_reason": finishReason,
+		}},
+	}
+	b, _ := json.Marshal(payload)
+	return []byte("data: " + string(b) + "\n\n")
+}
+
+func (e *chatChunkEmitter) deltaChunk(delta map[string]any) []byte {
+	if !e.roleSent {
+		e.roleSent = true
+		if _, ok := delta["role"]; !ok {
+			delta["role"] = "assistant"
+		}
+	} else if delta["role"] != nil && delta["content"] == "" && len(delta) == 2 {
+		// 角色已发送过（例如等待期间的重复 message_start），忽略空的角色块
+		return nil
+	}
+	return e.chunk(delta, nil)
+}
+
+func (e *chatChunkEmitter) finish(finishReason string, usage *chatCompletionUsage) []byte {
+	if e.done {
+		return nil
+	}
+	e.done = true
+	var buf bytes.Buffer
+	if !e.roleSent {
+		e.roleSent = true
+		buf.Write(e.chunk(map[string]any{"role": "assistant", "content": ""}, nil))
+	}
+	if finishReason == "" {
+		finishReason = "stop"
+	}
+	buf.Write(e.chunk(map[string]any{}, finishReason))
+	if e.includeUsage && usage != nil {
+		b, _ := json.Marshal(map[string]any{
+			"id":      e.id,
+			"object":  chatCompletionsChunkObject,
+			"created": e.created,
+			"model":   e.model,
+			"choices": []any{},
+			"usage":   usage,
+		})
+		buf.WriteString("data: " + string(b) + "\n\n")
+	}
+	buf.WriteString("data: [DONE]\n\n")
+	return buf.Bytes()
+}
+
+func (e *chatChunkEmitter) errorEvent(data string) []byte {
+	if e.done {
+		return nil
+	}
+	e.done = true
+	return []byte("data: " + string(ConvertErrorToChatCompletions([]byte(data))) + "\n\ndata: [DONE]\n\n")
+}
+
+// upstreamStreamTruncatedMessage 上游流在结束事件之前中断时返回给客户端的错误信息
+const upstreamStreamTruncatedMessage = "upstream stream ended unexpectedly"
+
+func (e *chatChunkEmitter) Abort() []byte {
+	if e.done {
+		return nil
+	}
+	b, _ := json.Marshal(map[string]any{"error": map[string]any{"type": "upstream_error", "message": upstreamStreamTruncatedMessage}})
+	return e.errorEvent(string(b))
+}
+
+// parseSSEEvent 从单个 SSE 事件中提取 event 名与 data 内容
+func parseSSEEvent(event string) (name, data string) {
+	var dataLines []string
+	for _, line := range strings.Split(event, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			dataLines = append(dataLines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	return name, strings.Join(dataLines, "\n")
+}
+
+// claudeChatStreamConverter 将 Anthropic Messages SSE 转换为 chat.completion.chunk
+type claudeChatStreamConverter struct {
+	chatChunkEmitter
+	toolIndexByBlock map[int]int
+	nextToolIndex    int
+	usage            ClaudeUsage
+	stopReason       string
+}
+
+// NewClaudeChatStreamConverter 创建 Anthropic SSE -> Chat Completions SSE 转换器
+func NewClaudeChatStreamConverter(model string, includeUsage bool) ChatCompletionsStreamConverter {
+	return &claudeChatStreamConverter{
+		chatChunkEmitter: chatChunkEmitter{
+			id:           chatCompletionID(""),
+			model:        model,
+			created:      time.Now().Unix(),
+			includeUsage: includeUsage,
+		},
+		toolIndexByBlock: make(map[int]int),
+	}
+}
+
+func (s *claudeChatStreamConverter) ConvertEvent(event string) []byte {
+	if s.done {
+		return nil
+	}
+	name, data := parseSSEEvent(event)
+	if data == "" {
+		if strings.HasPrefix(strings.TrimSpace(event), ":") {
+			return []byte(strings.TrimSpace(event) + "\n\n")
+		}
+		return nil
+	}
+	var evt struct {
+		Type    string `json:"type"`
+		Index   int    `json:"index"`
+		Message *struct {
+			ID    string      `json:"id"`
+			Model string      `json:"model"`
+			Usage ClaudeUsage `json:"usage"`
+		} `json:"message"`
+		ContentBlock *struct {
+			Type string `json:"type"`
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"content_block"`
+		Delta *struct {
+			Type        string `json:"type"`
+			Text        string `json:"text"`
+			Thinking    string `json:"thinking"`
+			PartialJSON string `json:"partial_json"`
+			StopReason  string `json:"stop_reason"`
+		} `json:"delta"`
+		Usage *ClaudeUsage `json:"usage"`
+	}
+	if err := json.Unmarshal([]byte(data), &evt); err != nil {
+		return nil
+	}
+	if evt.Type == "" {
+		evt.Type = name
+	}
+
+	switch evt.Type {
+	case "ping":
+		return []byte(": ping\n\n")
+	case "message_start":
+		if evt.Message != nil {
+			if evt.Message.ID != "" {
+				s.id = chatCompletionID(evt.Message.ID)
+			}
+			if s.model == "" {
+				s.model = evt.Message.Model
+			}
+			s.usage = evt.Message.Usage
+		}
+		return s.deltaChunk(map[string]any{"role": "assistant", "content": ""})
+	case "content_block_start":
+		if evt.ContentBlock != nil && evt.ContentBlock.Type == "tool_use" {
+			idx := s.nextToolIndex
+			s.nextToolIndex++
+			s.toolIndexByBlock[evt.Index] = idx
+			return s.deltaChunk(map[string]any{"tool_calls": []any{map[string]any{
+				"index":    idx,
+				"id":       evt.ContentBlock.ID,
+				"type":     "function",
+				"function": map[string]any{"name": evt.ContentBlock.Name, "arguments": ""},
+			}}})
+		}
+	case "content_block_delta":
+		if evt.Delta == nil {
+			return nil
+		}
+		switch evt.Delta.Type {
+		case "text_delta":
+			if evt.Delta.Text != "" {
+				return s.deltaChunk(map[string]any{"content": evt.Delta.Text})
+			}
+		case "thinking_delta":
+			if evt.Delta.Thinking != "" {
+				return s.deltaChunk(map[string]any{"reasoning_content": evt.Delta.Thinking})
+			}
+		case "input_json_delta":
+			idx, ok := s.toolIndexByBlock[evt.Index]
+			if ok && evt.Delta.PartialJSON != "" {
+				return s.deltaChunk(map[string]any{"tool_calls": []any{map[string]any{
+					"index":    idx,
+					"function": map[string]any{"arguments": evt.Delta.PartialJSON},
+				}}})
+			}
+		}
+	case "message_delta":
+		if evt.Delta != nil && evt.Delta.StopReason != "" {
+			s.stopReason = evt.Delta.StopReason
+		}
+		if evt.Usage != nil {
+			if evt.Usage.InputTokens > 0 {
+				s.usage.InputTokens = evt.Usage.InputTokens
+			}
+			if evt.Usage.CacheReadInputTokens > 0 {
+				s.usage.CacheReadInputTokens = evt.Usage.CacheReadInputTokens
+			}
+			if evt.Usage.CacheCreationInputTokens > 0 {
+				s.usage.CacheCreationInputTokens = evt.Usage.CacheCreationInputTokens
+			}
+			s.usage.OutputTokens = evt.Usage.OutputTokens
+		}
+	case "message_stop":
+		return s.Finish()
+	case "error":
+		return s.errorEvent(data)
+	}
+	return nil
+}
+
+func (s *claudeChatStreamConverter) Finish() []byte {
+	u := s.usage
+	promptTokens := u.InputTokens + u.CacheReadInputTokens + u.CacheCreationInputTokens
+	return s.finish(claudeStopReasonToFinishReason(s.stopReason), newChatCompletionUsage(promptTokens, u.OutputTokens, u.CacheReadInputTokens))
+}
+
+// responsesChatStreamConverter 将 Responses API SSE 转换为 chat.completion.chunk
+type responsesChatStreamConverter struct {
+	chatChunkEmitter
+	toolIndexByItem map[string]int
+	nextToolIndex   int
+	final           *responsesObject
+}
+
+// NewResponsesChatStreamConverter 创建 Responses SSE -> Chat Completions SSE 转换器
+func NewResponsesChatStreamConverter(model string, includeUsage bool) ChatCompletionsStreamConverter {
+	return &responsesChatStreamConverter{
+		chatChunkEmitter: chatChunkEmitter{
+			id:           chatCompletionID(""),
+			model:        model,
+			created:      time.Now().Unix(),
+			includeUsage: includeUsage,
+		},
+		toolIndexByItem: make(map[string]int),
+	}
+}
+
+func (s *responsesChatStreamConverter) ConvertEvent(event string) []byte {
+	if s.done {
+		return nil
+	}
+	name, data := parseSSEEvent(event)
+	if data == "" {
+		if strings.HasPrefix(strings.TrimSpace(event), ":") {
+			return []byte(strings.TrimSpace(event) + "\n\n")
+		}
+		return nil
+	}
+	if data == "[DONE]" {
+		return s.Finish()
+	}
+	var evt struct {
+		Type     string           `json:"type"`
+		Delta    string           `json:"delta"`
+		ItemID   string           `json:"item_id"`
+		Response *responsesObject `json:"response"`
+		Item     *struct {
+			ID     string `json:"id"`
+			Type   string `json:"type"`
+			CallID string `json:"call_id"`
+			Name   string `json:"name"`
+		} `json:"item"`
+	}
+	if err := json.Unmarshal([]byte(data), &evt); err != nil {
+		return nil
+	}
+	if evt.Type == "" && name == "error" {
+		// 网关自身在流开始后写出的错误事件：event: error + {"error":{...}}
+		return s.errorEvent(data)
+	}
+
+	switch evt.Type {
+	case "response.created":
+		if evt.Response != nil && evt.Response.ID != "" {
+			s.id = chatCompletionID(strings.TrimPrefix(evt.Response.ID, "resp_"))
+		}
+		return s.deltaChunk(map[string]any{"role": "assistant", "content": ""})
+	case "response.output_text.delta":
+		if evt.Delta != "" {
+			return s.deltaChunk(map[string]any{"content": evt.Delta})
+		}
+	case "response.reasoning_summary_text.delta":
+		if evt.Delta != "" {
+			return s.deltaChunk(map[string]any{"reasoning_content": evt.Delta})
+		}
+	case "response.output_item.added":
+		if evt.Item != nil && evt.Item.Type == "function_call" {
+			idx := s.nextToolIndex
+			s.nextToolIndex++
+			s.toolIndexByItem[evt.Item.ID] = idx
+			return s.deltaChunk(map[string]any{"tool_calls": []any{map[string]any{
+				"index":    idx,
+				"id":       evt.Item.CallID,
+				"type":     "function",
+				"function": map[string]any{"name": evt.Item.Name, "arguments": ""},
+			}}})
+		}
+	case "response.function_call_arguments.delta":
+		if idx, ok := s.toolIndexByItem[evt.ItemID]; ok && evt.Delta != "" {
+			return s.deltaChunk(map[string]any{"tool_calls": []any{map[string]any{
+				"index":    idx,
+				"function": map[string]any{"arguments": evt.Delta},
+			}}})
+		}
+	case "response.completed", "response.done", "response.incomplete":
+		s.final = evt.Response
+		return s.Finish()
+	case "response.failed", "error":
+		message := "upstream response failed"
+		var failed struct {
+			Message  string `json:"message"`
+			Response *struct {
+				Error *struct {
+					Message string `json:"message"`
+				} `json:"error"`
+			} `json:"response"`
+		}
+		if json.Unmarshal([]byte(data), &failed) == nil {
+			if failed.Message != "" {
+				message = failed.Message
+			} else if failed.Response != nil && failed.Response.Error != nil && failed.Response.Error.Message != "" {
+				message = failed.Response.Error.Message
+			}
+		}
+		b, _ := json.Marshal(map[string]any{"error": map[string]any{"type": "upstream_error", "message": message}})
+		return s.errorEvent(string(b))
+	}
+	return nil
+}
+
+func (s *responsesChatStreamConverter) Finish() []byte {
+	if s.final == nil {
+		return s.finish(s.finishReasonWithoutFinal(), nil)
+	}
+	return s.finish(s.final.finishReason(s.nextToolIndex > 0), s.final.chatUsage())
+}
+
+func (s *responsesChatStreamConverter) finishReasonWithoutFinal() string {
+	if s.nextToolIndex > 0 {
+		return "tool_calls"
+	}
+	return "stop"
+}
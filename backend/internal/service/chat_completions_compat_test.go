@@ -0,0 +1,301 @@
+//go:build unit
+
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertChatCompletionsToClaudeMessages(t *testing.T) {
+	body := []byte(`{
+		"model": "claude-sonnet-4-5",
+		"stream": true,
+		"max_tokens": 256,
+		"stop": "END",
+		"messages": [
+			{"role": "system", "content": "be brief"},
+			{"role": "user", "content": [
+				{"type": "text", "text": "what is in the image?"},
+				{"type": "image_url", "image_url": {"url": "data:image/png;base64,AAAA"}}
+			]},
+			{"role": "assistant", "content": null, "tool_calls": [
+				{"id": "call_1", "type": "function", "function": {"name": "lookup", "arguments": "{\"q\":\"x\"}"}}
+			]},
+			{"role": "tool", "tool_call_id": "call_1", "content": "result"}
+		],
+		"tools": [{"type": "function", "function": {"name": "lookup", "parameters": {"type": "object"}}}],
+		"tool_choice": "required"
+	}`)
+	req, err := ParseChatCompletionsRequest(body)
+	require.NoError(t, err)
+
+	out, err := ConvertChatCompletionsToClaudeMessages(req)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out, &got))
+	require.Equal(t, "claude-sonnet-4-5", got["model"])
+	require.Equal(t, true, got["stream"])
+	require.Equal(t, float64(256), got["max_tokens"])
+	require.Equal(t, "be brief", got["system"])
+	require.Equal(t, []any{"END"}, got["stop_sequences"])
+	require.Equal(t, map[string]any{"type": "any"}, got["tool_choice"])
+
+	messages := got["messages"].([]any)
+	require.Len(t, messages, 3)
+
+	user := messages[0].(map[string]any)
+	require.Equal(t, "user", user["role"])
+	userContent := user["content"].([]any)
+	require.Len(t, userContent, 2)
+	image := userContent[1].(map[string]any)
+	require.Equal(t, "image", image["type"])
+	require.Equal(t, "image/png", image["source"].(map[string]any)["media_type"])
+
+	assistant := messages[1].(map[string]any)
+	toolUse := assistant["content"].([]any)[0].(map[string]any)
+	require.Equal(t, "tool_use", toolUse["type"])
+	require.Equal(t, "call_1", toolUse["id"])
+	require.Equal(t, map[string]any{"q": "x"}, toolUse["input"])
+
+	toolResult := messages[2].(map[string]any)
+	require.Equal(t, "user", toolResult["role"])
+	block := toolResult["content"].([]any)[0].(map[string]any)
+	require.Equal(t, "tool_result", block["type"])
+	require.Equal(t, "call_1", block["tool_use_id"])
+	require.Equal(t, "result", block["content"])
+
+	tools := got["tools"].([]any)
+	require.Equal(t, "lookup", tools[0].(map[string]any)["name"])
+}
+
+func TestConvertChatCompletionsToClaudeMessages_DefaultMaxTokens(t *testing.T) {
+	req, err := ParseChatCompletionsRequest([]byte(`{"model":"m","messages":[{"role":"user","content":"hi"}]}`))
+	require.NoError(t, err)
+	out, err := ConvertChatCompletionsToClaudeMessages(req)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out, &got))
+	require.Equal(t, float64(chatCompletionsDefaultMaxTokens), got["max_tokens"])
+}
+
+func TestParseChatCompletionsRequest_Invalid(t *testing.T) {
+	_, err := ParseChatCompletionsRequest([]byte(`{"messages":[{"role":"user","content":"hi"}]}`))
+	require.Error(t, err)
+	_, err = ParseChatCompletionsRequest([]byte(`{"model":"m","messages":[]}`))
+	require.Error(t, err)
+}
+
+func TestConvertChatCompletionsToResponses(t *testing.T) {
+	body := []byte(`{
+		"model": "gpt-5",
+		"max_completion_tokens": 100,
+		"reasoning_effort": "high",
+		"messages": [
+			{"role": "developer", "content": "sys"},
+			{"role": "user", "content": "hi"},
+			{"role": "assistant", "content": "calling", "tool_calls": [
+				{"id": "call_9", "type": "function", "function": {"name": "f", "arguments": "{}"}}
+			]},
+			{"role": "tool", "tool_call_id": "call_9", "content": "ok"}
+		],
+		"tool_choice": {"type": "function", "function": {"name": "f"}}
+	}`)
+	req, err := ParseChatCompletionsRequest(body)
+	require.NoError(t, err)
+
+	out, err := ConvertChatCompletionsToResponses(req)
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out, &got))
+	require.Equal(t, "sys", got["instructions"])
+	require.Equal(t, float64(100), got["max_output_tokens"])
+	require.Equal(t, map[string]any{"effort": "high"}, got["reasoning"])
+	require.Equal(t, map[string]any{"type": "function", "name": "f"}, got["tool_choice"])
+
+	input := got["input"].([]any)
+	require.Len(t, input, 4)
+	require.Equal(t, "message", input[0].(map[string]any)["type"])
+	require.Equal(t, "output_text", input[1].(map[string]any)["content"].([]any)[0].(map[string]any)["type"])
+	require.Equal(t, "function_call", input[2].(map[string]any)["type"])
+	require.Equal(t, "function_call_output", input[3].(map[string]any)["type"])
+	require.Equal(t, "call_9", input[3].(map[string]any)["call_id"])
+
+	// 转换结果需要满足 Responses 处理器的 function_call_output 关联校验
+	var reqBody map[string]any
+	require.NoError(t, json.Unmarshal(out, &reqBody))
+	require.True(t, HasToolCallContext(reqBody))
+}
+
+func TestConvertClaudeMessageToChatCompletion(t *testing.T) {
+	body := []byte(`{
+		"id": "msg_1",
+		"model": "claude-sonnet-4-5",
+		"stop_reason": "tool_use",
+		"content": [
+			{"type": "thinking", "thinking": "hmm"},
+			{"type": "text", "text": "hello"},
+			{"type": "tool_use", "id": "toolu_1", "name": "lookup", "input": {"q": 1}}
+		],
+		"usage": {"input_tokens": 10, "output_tokens": 5, "cache_read_input_tokens": 3}
+	}`)
+	out, err := ConvertClaudeMessageToChatCompletion(body, "alias-model")
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out, &got))
+	require.Equal(t, "chat.completion", got["object"])
+	require.Equal(t, "chatcmpl-msg_1", got["id"])
+	require.Equal(t, "alias-model", got["model"])
+
+	choice := got["choices"].([]any)[0].(map[string]any)
+	require.Equal(t, "tool_calls", choice["finish_reason"])
+	message := choice["message"].(map[string]any)
+	require.Equal(t, "hello", message["content"])
+	require.Equal(t, "hmm", message["reasoning_content"])
+	toolCall := message["tool_calls"].([]any)[0].(map[string]any)
+	require.Equal(t, "toolu_1", toolCall["id"])
+	require.Equal(t, `{"q": 1}`, toolCall["function"].(map[string]any)["arguments"])
+
+	usage := got["usage"].(map[string]any)
+	require.Equal(t, float64(13), usage["prompt_tokens"])
+	require.Equal(t, float64(5), usage["completion_tokens"])
+	require.Equal(t, float64(18), usage["total_tokens"])
+	require.Equal(t, float64(3), usage["prompt_tokens_details"].(map[string]any)["cached_tokens"])
+}
+
+func TestConvertResponsesToChatCompletion_FromSSE(t *testing.T) {
+	body := []byte("event: response.created\n" +
+		`data: {"type":"response.created","response":{"id":"resp_1"}}` + "\n\n" +
+		"event: response.completed\n" +
+		`data: {"type":"response.completed","response":{"id":"resp_1","model":"gpt-5","status":"completed","output":[` +
+		`{"type":"message","content":[{"type":"output_text","text":"hi there"}]}],` +
+		`"usage":{"input_tokens":7,"output_tokens":2,"input_tokens_details":{"cached_tokens":1}}}}` + "\n\n")
+
+	out, err := ConvertResponsesToChatCompletion(body, "")
+	require.NoError(t, err)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(out, &got))
+	require.Equal(t, "chatcmpl-1", got["id"])
+	require.Equal(t, "gpt-5", got["model"])
+	choice := got["choices"].([]any)[0].(map[string]any)
+	require.Equal(t, "stop", choice["finish_reason"])
+	require.Equal(t, "hi there", choice["message"].(map[string]any)["content"])
+	require.Equal(t, float64(9), got["usage"].(map[string]any)["total_tokens"])
+}
+
+func TestConvertErrorToChatCompletions(t *testing.T) {
+	out := ConvertErrorToChatCompletions([]byte(`{"type":"error","error":{"type":"rate_limit_error","message":"slow down"}}`))
+	require.JSONEq(t, `{"error":{"type":"rate_limit_error","message":"slow down"}}`, string(out))
+
+	out = ConvertErrorToChatCompletions([]byte(`{"error":{"code":429,"message":"quota","status":"RESOURCE_EXHAUSTED"}}`))
+	require.JSONEq(t, `{"error":{"type":"resource_exhausted","message":"quota","code":429}}`, string(out))
+
+	require.Equal(t, "plain text", string(ConvertErrorToChatCompletions([]byte("plain text"))))
+}
+
+func collectChatChunks(t *testing.T, out string) ([]map[string]any, bool) {
+	t.Helper()
+	var chunks []map[string]any
+	done := false
+	for _, event := range strings.Split(out, "\n\n") {
+		event = strings.TrimSpace(event)
+		if !strings.HasPrefix(event, "data: ") {
+			continue
+		}
+		data := strings.TrimPrefix(event, "data: ")
+		if data == "[DONE]" {
+			done = true
+			continue
+		}
+		var chunk map[string]any
+		require.NoError(t, json.Unmarshal([]byte(data), &chunk))
+		chunks = append(chunks, chunk)
+	}
+	return chunks, done
+}
+
+func TestClaudeChatStreamConverter(t *testing.T) {
+	conv := NewClaudeChatStreamConverter("claude-sonnet-4-5", true)
+	events := []string{
+		"event: message_start\n" + `data: {"type":"message_start","message":{"id":"msg_9","model":"x","usage":{"input_tokens":4,"output_tokens":0}}}`,
+		`data: {"type": "ping"}`,
+		"event: content_block_delta\n" + `data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hel"}}`,
+		"event: content_block_delta\n" + `data: {"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"lo"}}`,
+		"event: content_block_start\n" + `data: {"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"toolu_1","name":"f"}}`,
+		"event: content_block_delta\n" + `data: {"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"a\":1}"}}`,
+		"event: message_delta\n" + `data: {"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":6}}`,
+		"event: message_stop\n" + `data: {"type":"message_stop"}`,
+	}
+	var sb strings.Builder
+	for _, e := range events {
+		sb.Write(conv.ConvertEvent(e))
+	}
+	sb.Write(conv.Finish())
+	out := sb.String()
+	require.Contains(t, out, ": ping\n\n")
+
+	chunks, done := collectChatChunks(t, out)
+	require.True(t, done)
+	require.Equal(t, 1, strings.Count(out, "[DONE]"))
+	require.Len(t, chunks, 7)
+
+	first := chunks[0]["choices"].([]any)[0].(map[string]any)["delta"].(map[string]any)
+	require.Equal(t, "assistant", first["role"])
+	require.Equal(t, "chatcmpl-msg_9", chunks[0]["id"])
+	require.Equal(t, "claude-sonnet-4-5", chunks[0]["model"])
+
+	text := chunks[1]["choices"].([]any)[0].(map[string]any)["delta"].(map[string]any)["content"].(string) +
+		chunks[2]["choices"].([]any)[0].(map[string]any)["delta"].(map[string]any)["content"].(string)
+	require.Equal(t, "Hello", text)
+
+	toolStart := chunks[3]["choices"].([]any)[0].(map[string]any)["delta"].(map[string]any)["tool_calls"].([]any)[0].(map[string]any)
+	require.Equal(t, "toolu_1", toolStart["id"])
+	require.Equal(t, float64(0), toolStart["index"])
+
+	finish := chunks[5]["choices"].([]any)[0].(map[string]any)
+	require.Equal(t, "tool_calls", finish["finish_reason"])
+
+	usage := chunks[6]["usage"].(map[string]any)
+	require.Equal(t, float64(4), usage["prompt_tokens"])
+	require.Equal(t, float64(6), usage["completion_tokens"])
+}
+
+func TestClaudeChatStreamConverter_Error(t *testing.T) {
+	conv := NewClaudeChatStreamConverter("m", false)
+	out := string(conv.ConvertEvent(`data: {"type":"error","error":{"type":"overloaded_error","message":"busy"}}`))
+	require.Contains(t, out, `"overloaded_error"`)
+	require.Contains(t, out, "data: [DONE]")
+	require.Empty(t, conv.Finish())
+}
+
+func TestResponsesChatStreamConverter(t *testing.T) {
+	conv := NewResponsesChatStreamConverter("gpt-5", false)
+	events := []string{
+		"event: response.created\n" + `data: {"type":"response.created","response":{"id":"resp_2"}}`,
+		"event: response.output_text.delta\n" + `data: {"type":"response.output_text.delta","delta":"Hi"}`,
+		"event: response.output_item.added\n" + `data: {"type":"response.output_item.added","item":{"id":"fc_1","type":"function_call","call_id":"call_1","name":"f"}}`,
+		"event: response.function_call_arguments.delta\n" + `data: {"type":"response.function_call_arguments.delta","item_id":"fc_1","delta":"{}"}`,
+		"event: response.completed\n" + `data: {"type":"response.completed","response":{"id":"resp_2","status":"completed","usage":{"input_tokens":3,"output_tokens":1}}}`,
+	}
+	var sb strings.Builder
+	for _, e := range events {
+		sb.Write(conv.ConvertEvent(e))
+	}
+	sb.Write(conv.Finish())
+
+	chunks, done := collectChatChunks(t, sb.String())
+	require.True(t, done)
+	require.Len(t, chunks, 5)
+	require.Equal(t, "chatcmpl-2", chunks[0]["id"])
+	args := chunks[3]["choices"].([]any)[0].(map[string]any)["delta"].(map[string]any)["tool_calls"].([]any)[0].(map[string]any)["function"].(map[string]any)["arguments"]
+	require.Equal(t, "{}", args)
+	require.Equal(t, "tool_calls", chunks[4]["choices"].([]any)[0].(map[string]any)["finish_reason"])
+}
@@ -0,0 +1,473 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// Anthropic Messages <-> Responses API 转换（OpenAI 兜底）
+//
+// anthropic 分组配置了 openai_fallback_model_mapping 时，Claude 账号全部不可用的 /v1/messages 请求
+// 在此转换为 Responses API 请求交给分组内的 OpenAI 账号处理，上游 Responses 响应 / SSE 再转换回
+// Anthropic 消息与 SSE 事件。工具名称沿用 openai_tool_corrector.go 的映射表修正回客户端声明的名称。
+
+// ConvertClaudeMessagesToResponses 将 Anthropic Messages 请求体转换为 Responses API 请求体。
+//   - system 转为首条 developer 消息（OAuth 账号会改写 instructions，developer 消息不受影响）；
+//   - tool_use / tool_result 转为 function_call / function_call_output；
+//   - thinking.budget_tokens 映射为 reasoning.effort，并请求推理摘要以输出 thinking 块；
+//   - temperature / top_p / stop_sequences 在推理模型上不受支持，直接丢弃；历史 thinking 块同样丢弃。
+func ConvertClaudeMessagesToResponses(body []byte, model string) ([]byte, error) {
+	var req claudeMessagesRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	if len(req.Messages) == 0 {
+		return nil, errors.New("messages is required")
+	}
+
+	input := make([]map[string]any, 0, len(req.Messages)+1)
+	if system := claudeContentText(req.System); system != "" {
+		input = append(input, map[string]any{
+			"type":    "message",
+			"role":    "developer",
+			"content": []map[string]any{{"type": "input_text", "text": system}},
+		})
+	}
+
+	for _, msg := range req.Messages {
+		blocks, err := parseClaudeContent(msg.Content)
+		if err != nil {
+			return nil, err
+		}
+		switch msg.Role {
+		case "user":
+			// function_call_output 必须紧跟在对应的 function_call 之后，其余内容合并为一条用户消息
+			parts := make([]map[string]any, 0, len(blocks))
+			for _, b := range blocks {
+				switch b.Type {
+				case "tool_result":
+					output := claudeContentText(b.Content)
+					if output == "" {
+						var s string
+						if json.Unmarshal(b.Content, &s) == nil {
+							output = s
+						}
+					}
+					input = append(input, map[string]any{
+						"type":    "function_call_output",
+						"call_id": b.ToolUseID,
+						"output":  output,
+					})
+					// function_call_output 只支持文本，工具结果中的图片随后以用户消息补充
+					nested, _ := parseClaudeContent(b.Content)
+					for _, nb := range nested {
+						if url := claudeImageURL(nb); url != "" {
+							parts = append(parts, map[string]any{"type": "input_image", "image_url": url})
+						}
+					}
+				case "text":
+					if b.Text != "" {
+						parts = append(parts, map[string]any{"type": "input_text", "text": b.Text})
+					}
+				case "image":
+					if url := claudeImageURL(b); url != "" {
+						parts = append(parts, map[string]any{"type": "input_image", "image_url": url})
+					}
+				}
+			}
+			if len(parts) > 0 {
+				input = append(input, map[string]any{"type": "message", "role": "user", "content": parts})
+			}
+		case "assistant":
+			// 按块顺序输出：文本先合并为 assistant 消息，遇到 tool_use 时落盘并追加 function_call
+			var text strings.Builder
+			flushText := func() {
+				if text.Len() == 0 {
+					return
+				}
+				input = append(input, map[string]any{
+					"type":    "message",
+					"role":    "assistant",
+					"content": []map[string]any{{"type": "output_text", "text": text.String()}},
+				})
+				text.Reset()
+			}
+			for _, b := range blocks {
+				switch b.Type {
+				case "text":
+					text.WriteString(b.Text)
+				case "tool_use":
+					flushText()
+					args := string(b.Input)
+					if args == "" || args == "null" {
+						args = "{}"
+					}
+					input = append(input, map[string]any{
+						"type":      "function_call",
+						"call_id":   b.ID,
+						"name":      b.Name,
+						"arguments": args,
+					})
+				}
+			}
+			flushText()
+		default:
+			return nil, fmt.Errorf("unsupported message role: %s", msg.Role)
+		}
+	}
+	if len(input) == 0 {
+		return nil, errors.New("messages must contain at least one user or assistant message")
+	}
+
+	if model == "" {
+		model = req.Model
+	}
+	out := map[string]any{
+		"model":  model,
+		"input":  input,
+		"stream": req.Stream,
+	}
+	if req.MaxTokens != nil && *req.MaxTokens > 0 {
+		out["max_output_tokens"] = *req.MaxTokens
+	}
+	if req.Thinking != nil && req.Thinking.Type == "enabled" {
+		out["reasoning"] = map[string]any{
+			"effort":  thinkingBudgetToReasoningEffort(req.Thinking.BudgetTokens),
+			"summary": "auto",
+		}
+	}
+	if len(req.Tools) > 0 {
+		tools := make([]map[string]any, 0, len(req.Tools))
+		for _, t := range req.Tools {
+			// 服务端工具（web_search_20250305 等）带有 type，上游无法执行
+			if t.Type != "" && t.Type != "custom" {
+				continue
+			}
+			tool := map[string]any{"type": "function", "name": t.Name, "strict": false}
+			if t.Description != "" {
+				tool["description"] = t.Description
+			}
+			if len(t.InputSchema) > 0 && string(t.InputSchema) != "null" {
+				tool["parameters"] = t.InputSchema
+			}
+			tools = append(tools, tool)
+		}
+		if len(tools) > 0 {
+			out["tools"] = tools
+		}
+	}
+	if req.ToolChoice != nil && out["tools"] != nil {
+		switch req.ToolChoice.Type {
+		case "auto":
+			out["tool_choice"] = "auto"
+		case "any":
+			out["tool_choice"] = "required"
+		case "none":
+			out["tool_choice"] = "none"
+		case "tool":
+			out["tool_choice"] = map[string]any{"type": "function", "name": req.ToolChoice.Name}
+		}
+	}
+	return json.Marshal(out)
+}
+
+// claudeImageURL 将 image 块转换为 URL（base64 图片转为 data URL），非图片块返回空字符串
+func claudeImageURL(b claudeContentBlock) string {
+	if b.Type != "image" || b.Source == nil {
+		return ""
+	}
+	if b.Source.Type == "base64" {
+		return "data:" + b.Source.MediaType + ";base64," + b.Source.Data
+	}
+	return b.Source.URL
+}
+
+// thinkingBudgetToReasoningEffort 按 thinking.budget_tokens 估算 reasoning.effort
+func thinkingBudgetToReasoningEffort(budget int) string {
+	switch {
+	case budget <= 0:
+		return "medium"
+	case budget < 4096:
+		return "low"
+	case budget < 16384:
+		return "medium"
+	default:
+		return "high"
+	}
+}
+
+// ClaudeRequestToolNames 返回 Anthropic Messages 请求中声明的工具名称，用于修正上游返回的工具名
+func ClaudeRequestToolNames(body []byte) []string {
+	var req struct {
+		Tools []struct {
+			Name string `json:"name"`
+		} `json:"tools"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(req.Tools))
+	for _, t := range req.Tools {
+		if t.Name != "" {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// claudeToolNameResolver 将上游返回的工具名修正为客户端声明的名称。
+// GPT/Codex 模型常输出 apply_patch、read_file 等原生工具名：先按 codexToolNameMapping 修正，
+// 再不区分大小写匹配客户端声明的工具（如 Claude Code 的 Edit、Read）。无法匹配时保留原名。
+type claudeToolNameResolver map[string]string
+
+func newClaudeToolNameResolver(toolNames []string) claudeToolNameResolver {
+	r := make(claudeToolNameResolver, len(toolNames))
+	for _, name := range toolNames {
+		r[strings.ToLower(name)] = name
+	}
+	return r
+}
+
+func (r claudeToolNameResolver) resolve(name string) string {
+	if len(r) == 0 || name == "" {
+		return name
+	}
+	if declared, ok := r[strings.ToLower(name)]; ok {
+		return declared
+	}
+	if corrected, ok := CorrectToolName(name); ok {
+		if declared, ok := r[strings.ToLower(corrected)]; ok {
+			return declared
+		}
+	}
+	return name
+}
+
+// claudeUsage 转换为网关统一的 ClaudeUsage：input_tokens 不含缓存命中部分
+func (r *responsesObject) claudeUsage() ClaudeUsage {
+	if r == nil || r.Usage == nil {
+		return ClaudeUsage{}
+	}
+	cached := min(r.Usage.InputTokensDetails.CachedTokens, r.Usage.InputTokens)
+	return ClaudeUsage{
+		InputTokens:          r.Usage.InputTokens - cached,
+		OutputTokens:         r.Usage.OutputTokens,
+		CacheReadInputTokens: cached,
+	}
+}
+
+// ConvertResponsesToClaudeMessage 将 Responses API 非流式响应转换为 Anthropic 消息。
+// 兼容 OAuth 账号返回的 SSE 文本（取 response.completed 事件中的最终响应）。
+func ConvertResponsesToClaudeMessage(body []byte, model string, toolNames []string) ([]byte, error) {
+	if final, ok := extractCodexFinalResponse(string(body)); ok {
+		body = final
+	}
+	var resp responsesObject
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	tools := newClaudeToolNameResolver(toolNames)
+	content := make([]map[string]any, 0, len(resp.Output))
+	hasToolUse := false
+	for _, item := range resp.Output {
+		switch item.Type {
+		case "reasoning":
+			var thinking strings.Builder
+			for _, s := range item.Summary {
+				thinking.WriteString(s.Text)
+			}
+			if thinking.Len() > 0 {
+				content = append(content, map[string]any{"type": "thinking", "thinking": thinking.String(), "signature": ""})
+			}
+		case "message":
+			for _, c := range item.Content {
+				if c.Type == "output_text" && c.Text != "" {
+					content = append(content, map[string]any{"type": "text", "text": c.Text})
+				}
+			}
+		case "function_call":
+			hasToolUse = true
+			content = append(content, map[string]any{
+				"type":  "tool_use",
+				"id":    item.CallID,
+				"name":  tools.resolve(item.Name),
+				"input": parseToolArguments(item.Arguments),
+			})
+		}
+	}
+
+	if model == "" {
+		model = resp.Model
+	}
+	return json.Marshal(map[string]any{
+		"id":            claudeMessageID(strings.TrimPrefix(resp.ID, "resp_")),
+		"type":          "message",
+		"role":          "assistant",
+		"model":         model,
+		"content":       content,
+		"stop_reason":   finishReasonToClaudeStopReason(resp.finishReason(hasToolUse)),
+		"stop_sequence": nil,
+		"usage":         resp.claudeUsage(),
+	})
+}
+
+// ConvertErrorToClaudeMessages 将 OpenAI 风格错误体转换为 Anthropic 错误格式。
+// 已是 Anthropic 格式或无法识别的内容原样返回。
+func ConvertErrorToClaudeMessages(body []byte) []byte {
+	var m map[string]any
+	if err := json.Unmarshal(body, &m); err != nil {
+		return body
+	}
+	if t, _ := m["type"].(string); t == "error" {
+		return body
+	}
+	errObj, ok := m["error"].(map[string]any)
+	if !ok {
+		return body
+	}
+	message, _ := errObj["message"].(string)
+	errType, _ := errObj["type"].(string)
+	if errType == "" {
+		errType = "api_error"
+	}
+	b, err := json.Marshal(map[string]any{
+		"type":  "error",
+		"error": map[string]any{"type": errType, "message": message},
+	})
+	if err != nil {
+		return body
+	}
+	return b
+}
+
+// responsesClaudeStreamConverter 将 Responses API SSE 转换为 Anthropic Messages SSE
+type responsesClaudeStreamConverter struct {
+	claudeStreamEmitter
+	tools      claudeToolNameResolver
+	toolBlocks map[string]int // 上游 function_call item id -> content block index
+	argsSent   map[string]bool
+}
+
+// NewResponsesClaudeStreamConverter 创建 Responses SSE -> Anthropic SSE 转换器。
+// 返回值复用 ChatCompletionsStreamConverter 接口（ConvertEvent / Finish），以便处理器统一包装写出。
+func NewResponsesClaudeStreamConverter(model string, toolNames []string) ChatCompletionsStreamConverter {
+	return &responsesClaudeStreamConverter{
+		claudeStreamEmitter: claudeStreamEmitter{model: model},
+		tools:               newClaudeToolNameResolver(toolNames),
+		toolBlocks:          make(map[string]int),
+		argsSent:            make(map[string]bool),
+	}
+}
+
+func (s *responsesClaudeStreamConverter) ConvertEvent(event string) []byte {
+	if s.finished {
+		return nil
+	}
+	name, data := parseSSEEvent(event)
+	if data == "" {
+		if strings.HasPrefix(strings.TrimSpace(event), ":") {
+			return []byte(strings.TrimSpace(event) + "\n\n")
+		}
+		return nil
+	}
+	if data == "[DONE]" {
+		return s.Finish()
+	}
+	var evt struct {
+		Type     string           `json:"type"`
+		Delta    string           `json:"delta"`
+		ItemID   string           `json:"item_id"`
+		Message  string           `json:"message"`
+		Response *responsesObject `json:"response"`
+		Item     *struct {
+			ID        string `json:"id"`
+			Type      string `json:"type"`
+			CallID    string `json:"call_id"`
+			Name      string `json:"name"`
+			Arguments string `json:"arguments"`
+		} `json:"item"`
+	}
+	if err := json.Unmarshal([]byte(data), &evt); err != nil {
+		return nil
+	}
+	if evt.Type == "" && name == "error" {
+		// 网关自身在流开始后写出的错误事件：event: error + {"error":{...}}
+		message := ExtractUpstreamErrorMessage([]byte(data))
+		if message == "" {
+			message = "Upstream stream error"
+		}
+		return s.errorEvent(message)
+	}
+
+	var buf bytes.Buffer
+	switch evt.Type {
+	case "response.created":
+		upstreamID := ""
+		if evt.Response != nil {
+			upstreamID = strings.TrimPrefix(evt.Response.ID, "resp_")
+		}
+		s.start(&buf, upstreamID)
+	case "response.output_text.delta":
+		if evt.Delta != "" {
+			s.start(&buf, "")
+			s.textDelta(&buf, evt.Delta)
+		}
+	case "response.reasoning_summary_text.delta":
+		if evt.Delta != "" {
+			s.start(&buf, "")
+			s.thinkingDelta(&buf, evt.Delta)
+		}
+	case "response.output_item.added":
+		if evt.Item != nil && evt.Item.Type == "function_call" {
+			s.start(&buf, "")
+			s.openContentBlock(&buf, "tool_use", map[string]any{
+				"type":  "tool_use",
+				"id":    evt.Item.CallID,
+				"name":  s.tools.resolve(evt.Item.Name),
+				"input": map[string]any{},
+			})
+			s.toolBlocks[evt.Item.ID] = s.blockIndex
+			s.stopReason = "tool_use"
+		}
+	case "response.function_call_arguments.delta":
+		if evt.Delta != "" && s.isOpenToolBlock(evt.ItemID) {
+			s.inputJSONDelta(&buf, s.blockIndex, evt.Delta)
+			s.argsSent[evt.ItemID] = true
+		}
+	case "response.output_item.done":
+		// 部分上游不输出 arguments.delta，仅在 item 完成时给出完整参数
+		if evt.Item != nil && evt.Item.Type == "function_call" && !s.argsSent[evt.Item.ID] &&
+			evt.Item.Arguments != "" && s.isOpenToolBlock(evt.Item.ID) {
+			s.inputJSONDelta(&buf, s.blockIndex, evt.Item.Arguments)
+			s.argsSent[evt.Item.ID] = true
+		}
+	case "response.completed", "response.done", "response.incomplete":
+		if evt.Response != nil {
+			s.usage = evt.Response.claudeUsage()
+			s.stopReason = finishReasonToClaudeStopReason(evt.Response.finishReason(len(s.toolBlocks) > 0))
+		}
+		buf.Write(s.Finish())
+	case "response.failed", "error":
+		message := evt.Message
+		if message == "" {
+			message = gjson.Get(data, "response.error.message").String()
+		}
+		if message == "" {
+			message = "upstream response failed"
+		}
+		buf.Write(s.errorEvent(sanitizeUpstreamErrorMessage(message)))
+	}
+	return buf.Bytes()
+}
+
+// isOpenToolBlock 判断 item 对应的 tool_use 块是否仍为当前打开的块（Anthropic 只能向当前块追加）
+func (s *responsesClaudeStreamConverter) isOpenToolBlock(itemID string) bool {
+	idx, ok := s.toolBlocks[itemID]
+	return ok && idx == s.blockIndex && s.openBlock == "tool_use"
+}
@@ -0,0 +1,200 @@
+//go:build unit
+
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertClaudeMessagesToResponses(t *testing.T) {
+	body := []byte(`{
+		"model": "claude-sonnet-4-5",
+		"stream": true,
+		"max_tokens": 2048,
+		"temperature": 1,
+		"system": [{"type": "text", "text": "you are a coding agent"}],
+		"thinking": {"type": "enabled", "budget_tokens": 10000},
+		"messages": [
+			{"role": "user", "content": [
+				{"type": "text", "text": "look at this"},
+				{"type": "image", "source": {"type": "base64", "media_type": "image/png", "data": "AAAA"}}
+			]},
+			{"role": "assistant", "content": [
+				{"type": "thinking", "thinking": "hmm", "signature": "sig"},
+				{"type": "text", "text": "reading"},
+				{"type": "tool_use", "id": "toolu_1", "name": "Read", "input": {"file_path": "/a"}}
+			]},
+			{"role": "user", "content": [
+				{"type": "tool_result", "tool_use_id": "toolu_1", "content": [{"type": "text", "text": "file body"}]},
+				{"type": "text", "text": "continue"}
+			]}
+		],
+		"tools": [
+			{"name": "Read", "description": "read a file", "input_schema": {"type": "object"}},
+			{"type": "web_search_20250305", "name": "web_search"}
+		],
+		"tool_choice": {"type": "any"}
+	}`)
+
+	out, err := ConvertClaudeMessagesToResponses(body, "gpt-5")
+	require.NoError(t, err)
+
+	var req map[string]any
+	require.NoError(t, json.Unmarshal(out, &req))
+	require.Equal(t, "gpt-5", req["model"])
+	require.Equal(t, true, req["stream"])
+	require.EqualValues(t, 2048, req["max_output_tokens"])
+	require.NotContains(t, req, "temperature")
+	require.NotContains(t, req, "instructions")
+	require.Equal(t, map[string]any{"effort": "medium", "summary": "auto"}, req["reasoning"])
+	require.Equal(t, "required", req["tool_choice"])
+
+	tools := req["tools"].([]any)
+	require.Len(t, tools, 1)
+	require.Equal(t, "Read", tools[0].(map[string]any)["name"])
+	require.Equal(t, false, tools[0].(map[string]any)["strict"])
+
+	input := req["input"].([]any)
+	require.Len(t, input, 6)
+	types := make([]string, 0, len(input))
+	for _, item := range input {
+		m := item.(map[string]any)
+		types = append(types, m["type"].(string)+":"+stringOrEmpty(m["role"]))
+	}
+	require.Equal(t, []string{
+		"message:developer",
+		"message:user",
+		"message:assistant",
+		"function_call:",
+		"function_call_output:",
+		"message:user",
+	}, types)
+
+	image := input[1].(map[string]any)["content"].([]any)[1].(map[string]any)
+	require.Equal(t, "input_image", image["type"])
+	require.Equal(t, "data:image/png;base64,AAAA", image["image_url"])
+
+	call := input[3].(map[string]any)
+	require.Equal(t, "toolu_1", call["call_id"])
+	require.Equal(t, "Read", call["name"])
+	require.JSONEq(t, `{"file_path":"/a"}`, call["arguments"].(string))
+
+	output := input[4].(map[string]any)
+	require.Equal(t, "toolu_1", output["call_id"])
+	require.Equal(t, "file body", output["output"])
+}
+
+func stringOrEmpty(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func TestClaudeToolNameResolver(t *testing.T) {
+	r := newClaudeToolNameResolver([]string{"Read", "Edit", "Bash", "mcp__github__search"})
+
+	require.Equal(t, "Read", r.resolve("Read"))
+	require.Equal(t, "Read", r.resolve("read"))
+	require.Equal(t, "Read", r.resolve("read_file"))
+	require.Equal(t, "Edit", r.resolve("apply_patch"))
+	require.Equal(t, "Bash", r.resolve("execute_bash"))
+	require.Equal(t, "mcp__github__search", r.resolve("mcp__github__search"))
+	// 未声明的工具保留原名，交由客户端报错
+	require.Equal(t, "update_plan", r.resolve("update_plan"))
+	require.Equal(t, "apply_patch", newClaudeToolNameResolver(nil).resolve("apply_patch"))
+}
+
+func TestConvertResponsesToClaudeMessage(t *testing.T) {
+	body := []byte(`{
+		"id": "resp_abc",
+		"model": "gpt-5",
+		"status": "completed",
+		"output": [
+			{"type": "reasoning", "summary": [{"text": "thinking..."}]},
+			{"type": "message", "content": [{"type": "output_text", "text": "Let me edit."}]},
+			{"type": "function_call", "call_id": "call_1", "name": "apply_patch", "arguments": "{\"file_path\":\"/a\"}"}
+		],
+		"usage": {"input_tokens": 100, "output_tokens": 20, "input_tokens_details": {"cached_tokens": 40}}
+	}`)
+
+	out, err := ConvertResponsesToClaudeMessage(body, "claude-sonnet-4-5", []string{"Edit"})
+	require.NoError(t, err)
+
+	var msg struct {
+		ID         string           `json:"id"`
+		Model      string           `json:"model"`
+		StopReason string           `json:"stop_reason"`
+		Content    []map[string]any `json:"content"`
+		Usage      ClaudeUsage      `json:"usage"`
+	}
+	require.NoError(t, json.Unmarshal(out, &msg))
+	require.Equal(t, "msg_abc", msg.ID)
+	require.Equal(t, "claude-sonnet-4-5", msg.Model)
+	require.Equal(t, "tool_use", msg.StopReason)
+	require.Len(t, msg.Content, 3)
+	require.Equal(t, "thinking", msg.Content[0]["type"])
+	require.Equal(t, "Let me edit.", msg.Content[1]["text"])
+	require.Equal(t, "Edit", msg.Content[2]["name"])
+	require.Equal(t, map[string]any{"file_path": "/a"}, msg.Content[2]["input"])
+	require.Equal(t, ClaudeUsage{InputTokens: 60, OutputTokens: 20, CacheReadInputTokens: 40}, msg.Usage)
+}
+
+func TestConvertResponsesToClaudeMessage_IncompleteMaxTokens(t *testing.T) {
+	body := []byte(`{"id":"resp_x","status":"incomplete","incomplete_details":{"reason":"max_output_tokens"},"output":[{"type":"message","content":[{"type":"output_text","text":"partial"}]}]}`)
+
+	out, err := ConvertResponsesToClaudeMessage(body, "claude-sonnet-4-5", nil)
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"stop_reason":"max_tokens"`)
+}
+
+func TestConvertErrorToClaudeMessages(t *testing.T) {
+	out := ConvertErrorToClaudeMessages([]byte(`{"error":{"type":"rate_limit_error","message":"slow down"}}`))
+	require.JSONEq(t, `{"type":"error","error":{"type":"rate_limit_error","message":"slow down"}}`, string(out))
+
+	claudeErr := `{"type":"error","error":{"type":"api_error","message":"x"}}`
+	require.Equal(t, claudeErr, string(ConvertErrorToClaudeMessages([]byte(claudeErr))))
+	require.Equal(t, "not json", string(ConvertErrorToClaudeMessages([]byte("not json"))))
+}
+
+func TestResponsesClaudeStreamConverter(t *testing.T) {
+	conv := NewResponsesClaudeStreamConverter("claude-sonnet-4-5", []string{"Bash"})
+
+	var out strings.Builder
+	for _, event := range []string{
+		`event: response.created` + "\n" + `data: {"type":"response.created","response":{"id":"resp_1"}}`,
+		`data: {"type":"response.reasoning_summary_text.delta","delta":"plan"}`,
+		`data: {"type":"response.output_text.delta","delta":"Running"}`,
+		`data: {"type":"response.output_item.added","item":{"id":"fc_1","type":"function_call","call_id":"call_1","name":"execute_bash"}}`,
+		`data: {"type":"response.function_call_arguments.delta","item_id":"fc_1","delta":"{\"command\":"}`,
+		`data: {"type":"response.function_call_arguments.delta","item_id":"fc_1","delta":"\"ls\"}"}`,
+		`data: {"type":"response.output_item.done","item":{"id":"fc_1","type":"function_call","arguments":"{\"command\":\"ls\"}"}}`,
+		`data: {"type":"response.completed","response":{"id":"resp_1","status":"completed","usage":{"input_tokens":10,"output_tokens":5,"input_tokens_details":{"cached_tokens":4}}}}`,
+	} {
+		out.Write(conv.ConvertEvent(event))
+	}
+	require.Nil(t, conv.Finish())
+
+	s := out.String()
+	require.Contains(t, s, `"id":"msg_1"`)
+	require.Contains(t, s, `"delta":{"thinking":"plan","type":"thinking_delta"}`)
+	require.Contains(t, s, `"delta":{"text":"Running","type":"text_delta"}`)
+	require.Contains(t, s, `"content_block":{"id":"call_1","input":{},"name":"Bash","type":"tool_use"}`)
+	// output_item.done 不会重复输出已流式发送的参数
+	require.Equal(t, 2, strings.Count(s, "input_json_delta"))
+	require.Contains(t, s, `"stop_reason":"tool_use"`)
+	require.Contains(t, s, `"usage":{"input_tokens":6,"output_tokens":5,"cache_creation_input_tokens":0,"cache_read_input_tokens":4}`)
+	require.True(t, strings.HasSuffix(s, "event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n"))
+	require.Equal(t, 3, strings.Count(s, "event: content_block_stop"))
+}
+
+func TestResponsesClaudeStreamConverter_Failed(t *testing.T) {
+	conv := NewResponsesClaudeStreamConverter("claude-sonnet-4-5", nil)
+
+	out := string(conv.ConvertEvent(`data: {"type":"response.failed","response":{"error":{"message":"quota exceeded"}}}`))
+	require.Contains(t, out, "event: error")
+	require.Contains(t, out, "quota exceeded")
+	require.Nil(t, conv.Finish())
+}
@@ -0,0 +1,470 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/util/responseheaders"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// compatibleResponseFormat 客户端请求的协议格式，决定响应转换方式与错误格式
+type compatibleResponseFormat int
+
+const (
+	// compatibleFormatChat 客户端使用 /v1/chat/completions，响应原样透传
+	compatibleFormatChat compatibleResponseFormat = iota
+	// compatibleFormatMessages 客户端使用 /v1/messages，请求与响应在 Anthropic 格式之间转换
+	compatibleFormatMessages
+)
+
+// CompatibleGatewayService 处理 compatible 平台（任意 OpenAI Chat Completions 兼容上游）的请求转发。
+//
+// 账号凭证为 base_url + api_key；/v1/chat/completions 直接透传，/v1/messages 转换为 Chat Completions。
+// 调度、粘性会话、限流标记与使用记录复用 GatewayService / RateLimitService，与其他平台一致。
+type CompatibleGatewayService struct {
+	gatewayService   *GatewayService
+	rateLimitService *RateLimitService
+	httpUpstream     HTTPUpstream
+	cfg              *config.Config
+}
+
+// NewCompatibleGatewayService creates a new CompatibleGatewayService
+func NewCompatibleGatewayService(
+	gatewayService *GatewayService,
+	rateLimitService *RateLimitService,
+	httpUpstream HTTPUpstream,
+	cfg *config.Config,
+) *CompatibleGatewayService {
+	return &CompatibleGatewayService{
+		gatewayService:   gatewayService,
+		rateLimitService: rateLimitService,
+		httpUpstream:     httpUpstream,
+		cfg:              cfg,
+	}
+}
+
+// SelectAccountWithLoadAwareness 按分组平台（compatible）负载感知选择账号
+func (s *CompatibleGatewayService) SelectAccountWithLoadAwareness(ctx context.Context, groupID *int64, sessionHash, model string, excludedIDs map[int64]struct{}) (*AccountSelectionResult, error) {
+	return s.gatewayService.SelectAccountWithLoadAwareness(ctx, groupID, sessionHash, model, excludedIDs, "")
+}
+
+// BindStickySession 绑定粘性会话
+func (s *CompatibleGatewayService) BindStickySession(ctx context.Context, groupID *int64, sessionHash string, accountID int64) error {
+	return s.gatewayService.BindStickySession(ctx, groupID, sessionHash, accountID)
+}
+
+// RecordUsage 记录使用量并扣费
+func (s *CompatibleGatewayService) RecordUsage(ctx context.Context, input *RecordUsageInput) error {
+	return s.gatewayService.RecordUsage(ctx, input)
+}
+
+// ForwardChatCompletions 透传 Chat Completions 请求。
+// 流式请求强制开启 stream_options.include_usage 用于计费；客户端未要求时丢弃仅含 usage 的末尾 chunk。
+func (s *CompatibleGatewayService) ForwardChatCompletions(ctx context.Context, c *gin.Context, account *Account, body []byte, model string, stream, includeUsage bool) (*ForwardResult, error) {
+	startTime := time.Now()
+
+	mappedModel := account.GetMappedModel(model)
+	if mappedModel != model {
+		log.Printf("[Compatible] Model mapping applied: %s -> %s (account: %s)", model, mappedModel, account.Name)
+		if newBody, err := sjson.SetBytes(body, "model", mappedModel); err == nil {
+			body = newBody
+		}
+	}
+	if stream {
+		if newBody, err := sjson.SetBytes(body, "stream_options.include_usage", true); err == nil {
+			body = newBody
+		}
+	}
+
+	resp, err := s.doUpstream(ctx, c, account, body, stream, compatibleFormatChat)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		return s.handleErrorResponse(ctx, c, account, resp, compatibleFormatChat)
+	}
+
+	result := &ForwardResult{
+		RequestID: resp.Header.Get("x-request-id"),
+		Model:     model,
+		Stream:    stream,
+	}
+	if stream {
+		usage, firstTokenMs, clientDisconnect, err := s.streamChatCompletions(c, resp, startTime, mappedModel, model, includeUsage)
+		if err != nil {
+			return nil, err
+		}
+		result.Usage, result.FirstTokenMs, result.ClientDisconnect = usage, firstTokenMs, clientDisconnect
+	} else {
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		var parsed struct {
+			Usage *chatCompletionUsageIn `json:"usage"`
+		}
+		if err := json.Unmarshal(respBody, &parsed); err != nil {
+			writeCompatibleError(c, compatibleFormatChat, http.StatusBadGateway, "upstream_error", "Failed to parse upstream response")
+			return nil, err
+		}
+		result.Usage = parsed.Usage.toClaudeUsage()
+		if mappedModel != model {
+			respBody = s.gatewayService.replaceModelInResponseBody(respBody, mappedModel, model)
+		}
+		s.writeResponse(c, resp, respBody, "application/json")
+	}
+	result.Duration = time.Since(startTime)
+	return result, nil
+}
+
+// ForwardMessages 将 Anthropic Messages 请求转换为 Chat Completions 转发，并将响应转换回 Anthropic 格式
+func (s *CompatibleGatewayService) ForwardMessages(ctx context.Context, c *gin.Context, account *Account, body []byte, model string, stream bool) (*ForwardResult, error) {
+	startTime := time.Now()
+
+	mappedModel := account.GetMappedModel(model)
+	if mappedModel != model {
+		log.Printf("[Compatible] Model mapping applied: %s -> %s (account: %s)", model, mappedModel, account.Name)
+	}
+	payload, err := ConvertClaudeMessagesToChatCompletions(body, mappedModel)
+	if err != nil {
+		writeCompatibleError(c, compatibleFormatMessages, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return nil, err
+	}
+
+	resp, err := s.doUpstream(ctx, c, account, payload, stream, compatibleFormatMessages)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		return s.handleErrorResponse(ctx, c, account, resp, compatibleFormatMessages)
+	}
+
+	result := &ForwardResult{
+		RequestID: resp.Header.Get("x-request-id"),
+		Model:     model,
+		Stream:    stream,
+	}
+	if stream {
+		usage, firstTokenMs, clientDisconnect, err := s.streamMessages(c, resp, startTime, model)
+		if err != nil {
+			return nil, err
+		}
+		result.Usage, result.FirstTokenMs, result.ClientDisconnect = usage, firstTokenMs, clientDisconnect
+	} else {
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		converted, usage, err := ConvertChatCompletionToClaudeMessage(respBody, model)
+		if err != nil {
+			writeCompatibleError(c, compatibleFormatMessages, http.StatusBadGateway, "upstream_error", "Failed to parse upstream response")
+			return nil, err
+		}
+		result.Usage = usage
+		s.writeResponse(c, resp, converted, "application/json")
+	}
+	result.Duration = time.Since(startTime)
+	return result, nil
+}
+
+func (s *CompatibleGatewayService) buildRequest(ctx context.Context, account *Account, payload []byte, stream bool) (*http.Request, error) {
+	targetURL := account.GetCompatibleChatCompletionsURL()
+	if targetURL == "" {
+		return nil, errors.New("base_url not found in credentials")
+	}
+	targetURL, err := s.gatewayService.validateUpstreamBaseURL(targetURL)
+	if err != nil {
+		return nil, err
+	}
+	apiKey := strings.TrimSpace(account.GetCredential("api_key"))
+	if apiKey == "" {
+		return nil, errors.New("api_key not found in credentials")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("authorization", "Bearer "+apiKey)
+	req.Header.Set("content-type", "application/json")
+	if stream {
+		req.Header.Set("accept", "text/event-stream")
+	}
+	return req, nil
+}
+
+func (s *CompatibleGatewayService) doUpstream(ctx context.Context, c *gin.Context, account *Account, payload []byte, stream bool, format compatibleResponseFormat) (*http.Response, error) {
+	req, err := s.buildRequest(ctx, account, payload, stream)
+	if err != nil {
+		writeCompatibleError(c, format, http.StatusBadGateway, "api_error", "Invalid upstream account configuration")
+		return nil, err
+	}
+	if c != nil {
+		c.Set(OpsUpstreamRequestBodyKey, string(payload))
+	}
+
+	proxyURL := ""
+	if account.ProxyID != nil && account.Proxy != nil {
+		proxyURL = account.Proxy.URL()
+	}
+	resp, err := s.httpUpstream.Do(req, proxyURL, account.ID, account.Concurrency)
+	if err != nil {
+		safeErr := sanitizeUpstreamErrorMessage(err.Error())
+		setOpsUpstreamError(c, 0, safeErr, "")
+		appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+			Platform:           account.Platform,
+			AccountID:          account.ID,
+			AccountName:        account.Name,
+			UpstreamStatusCode: 0,
+			Kind:               "request_error",
+			Message:            safeErr,
+		})
+		writeCompatibleError(c, format, http.StatusBadGateway, "upstream_error", "Upstream request failed")
+		return nil, fmt.Errorf("upstream request failed: %s", safeErr)
+	}
+	return resp, nil
+}
+
+// handleErrorResponse 处理上游错误：可切换账号的错误标记账号状态并返回 UpstreamFailoverError，
+// 其余错误按错误透传规则写出（400 类错误保留上游消息，便于调用方修正输入）。
+func (s *CompatibleGatewayService) handleErrorResponse(ctx context.Context, c *gin.Context, account *Account, resp *http.Response, format compatibleResponseFormat) (*ForwardResult, error) {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+
+	upstreamMsg := sanitizeUpstreamErrorMessage(strings.TrimSpace(extractUpstreamErrorMessage(body)))
+	upstreamDetail := ""
+	if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
+		maxBytes := s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+		if maxBytes <= 0 {
+			maxBytes = 2048
+		}
+		upstreamDetail = truncateString(string(body), maxBytes)
+	}
+	setOpsUpstreamError(c, resp.StatusCode, upstreamMsg, upstreamDetail)
+
+	kind := "http_error"
+	failover := shouldFailoverCompatibleError(resp.StatusCode)
+	if failover {
+		kind = "failover"
+	}
+	appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+		Platform:           account.Platform,
+		AccountID:          account.ID,
+		AccountName:        account.Name,
+		UpstreamStatusCode: resp.StatusCode,
+		UpstreamRequestID:  resp.Header.Get("x-request-id"),
+		Kind:               kind,
+		Message:            upstreamMsg,
+		Detail:             upstreamDetail,
+	})
+
+	if failover {
+		if s.rateLimitService != nil {
+			s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, body)
+		}
+		return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode, ResponseBody: body}
+	}
+
+	defaultStatus, defaultType, defaultMsg := http.StatusBadGateway, "upstream_error", "Upstream request failed"
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity {
+		defaultStatus, defaultType = http.StatusBadRequest, "invalid_request_error"
+		if upstreamMsg != "" {
+			defaultMsg = upstreamMsg
+		}
+	}
+	status, errType, errMsg, _ := applyErrorPassthroughRule(c, account.Platform, resp.StatusCode, body, defaultStatus, defaultType, defaultMsg)
+	writeCompatibleError(c, format, status, errType, errMsg)
+
+	if upstreamMsg == "" {
+		return nil, fmt.Errorf("upstream error: %d", resp.StatusCode)
+	}
+	return nil, fmt.Errorf("upstream error: %d message=%s", resp.StatusCode, upstreamMsg)
+}
+
+func (s *CompatibleGatewayService) writeResponse(c *gin.Context, resp *http.Response, body []byte, contentType string) {
+	if s.cfg != nil {
+		responseheaders.WriteFilteredHeaders(c.Writer.Header(), resp.Header, s.cfg.Security.ResponseHeaders)
+	}
+	c.Data(resp.StatusCode, contentType, body)
+}
+
+func (s *CompatibleGatewayService) prepareStream(c *gin.Context, resp *http.Response) (http.Flusher, error) {
+	if s.cfg != nil {
+		responseheaders.WriteFilteredHeaders(c.Writer.Header(), resp.Header, s.cfg.Security.ResponseHeaders)
+	}
+	c.Header("Content-Type", "text/event-stream; charset=utf-8")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	flusher, ok := c.Writer.(http.Flusher)
+	if !ok {
+		return nil, errors.New("streaming not supported")
+	}
+	return flusher, nil
+}
+
+// streamChatCompletions 透传 chat.completion.chunk SSE，同时提取用量。
+// 客户端断开后继续读取上游直到结束，以便拿到末尾的 usage 完成计费。
+func (s *CompatibleGatewayService) streamChatCompletions(c *gin.Context, resp *http.Response, startTime time.Time, mappedModel, model string, includeUsage bool) (ClaudeUsage, *int, bool, error) {
+	flusher, err := s.prepareStream(c, resp)
+	if err != nil {
+		return ClaudeUsage{}, nil, false, err
+	}
+
+	var usage ClaudeUsage
+	var firstTokenMs *int
+	clientDisconnect := false
+	write := func(s string) {
+		if clientDisconnect {
+			return
+		}
+		if _, err := io.WriteString(c.Writer, s); err != nil {
+			clientDisconnect = true
+			return
+		}
+		flusher.Flush()
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			trimmed := strings.TrimRight(line, "\r\n")
+			payload, isData := strings.CutPrefix(trimmed, "data:")
+			payload = strings.TrimSpace(payload)
+			if !isData || payload == "" || payload == "[DONE]" {
+				write(line)
+			} else {
+				data := []byte(payload)
+				usageOnly := false
+				if u := gjson.GetBytes(data, "usage"); u.IsObject() {
+					var parsed chatCompletionUsageIn
+					if json.Unmarshal([]byte(u.Raw), &parsed) == nil {
+						usage = parsed.toClaudeUsage()
+					}
+					usageOnly = len(gjson.GetBytes(data, "choices").Array()) == 0
+				}
+				if firstTokenMs == nil && len(gjson.GetBytes(data, "choices").Array()) > 0 {
+					ms := int(time.Since(startTime).Milliseconds())
+					firstTokenMs = &ms
+				}
+				if !usageOnly || includeUsage {
+					if mappedModel != model && gjson.GetBytes(data, "model").String() == mappedModel {
+						if replaced, err := sjson.SetBytes(data, "model", model); err == nil {
+							data = replaced
+						}
+					}
+					write("data: " + string(data) + "\n\n")
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if clientDisconnect || errors.Is(err, context.Canceled) {
+				return usage, firstTokenMs, true, nil
+			}
+			return usage, firstTokenMs, clientDisconnect, fmt.Errorf("stream read error: %w", err)
+		}
+	}
+	return usage, firstTokenMs, clientDisconnect, nil
+}
+
+// streamMessages 将 chat.completion.chunk SSE 转换为 Anthropic Messages SSE 写出
+func (s *CompatibleGatewayService) streamMessages(c *gin.Context, resp *http.Response, startTime time.Time, model string) (ClaudeUsage, *int, bool, error) {
+	flusher, err := s.prepareStream(c, resp)
+	if err != nil {
+		return ClaudeUsage{}, nil, false, err
+	}
+
+	conv := newChatClaudeStreamConverter(model)
+	var firstTokenMs *int
+	clientDisconnect := false
+	write := func(b []byte) {
+		if clientDisconnect || len(b) == 0 {
+			return
+		}
+		if _, err := c.Writer.Write(b); err != nil {
+			clientDisconnect = true
+			return
+		}
+		flusher.Flush()
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	for {
+		line, err := reader.ReadString('\n')
+		if payload, ok := strings.CutPrefix(strings.TrimRight(line, "\r\n"), "data:"); ok {
+			payload = strings.TrimSpace(payload)
+			if payload != "" {
+				if firstTokenMs == nil && payload != "[DONE]" {
+					ms := int(time.Since(startTime).Milliseconds())
+					firstTokenMs = &ms
+				}
+				write(conv.ConvertData(payload))
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if clientDisconnect || errors.Is(err, context.Canceled) {
+				return conv.usage, firstTokenMs, true, nil
+			}
+			return conv.usage, firstTokenMs, clientDisconnect, fmt.Errorf("stream read error: %w", err)
+		}
+	}
+	write(conv.Finish())
+	return conv.usage, firstTokenMs, clientDisconnect, nil
+}
+
+func shouldFailoverCompatibleError(statusCode int) bool {
+	switch statusCode {
+	case 401, 402, 403, 429, 529:
+		return true
+	default:
+		return statusCode >= 500
+	}
+}
+
+// writeCompatibleError 按客户端协议写出错误：Chat Completions 使用 OpenAI 格式，Messages 使用 Anthropic 格式
+func writeCompatibleError(c *gin.Context, format compatibleResponseFormat, status int, errType, message string) {
+	if c == nil {
+		return
+	}
+	if format == compatibleFormatMessages {
+		c.JSON(status, gin.H{
+			"type": "error",
+			"error": gin.H{
+				"type":    errType,
+				"message": message,
+			},
+		})
+		return
+	}
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
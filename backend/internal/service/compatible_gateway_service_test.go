@@ -0,0 +1,234 @@
+//go:build unit
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func newCompatibleTestService() *CompatibleGatewayService {
+	gw := newPassthroughGatewayService()
+	return &CompatibleGatewayService{gatewayService: gw, httpUpstream: gw.httpUpstream, cfg: gw.cfg}
+}
+
+func newCompatibleTestAccount(baseURL string) *Account {
+	return newUpstreamTestAccount(51, "deepseek", PlatformCompatible, AccountTypeAPIKey, map[string]any{
+		"base_url": baseURL + "/v1",
+		"api_key":  "sk-compatible",
+	})
+}
+
+func TestAccount_GetCompatibleChatCompletionsURL(t *testing.T) {
+	account := &Account{Platform: PlatformCompatible, Credentials: map[string]any{"base_url": "https://api.deepseek.com/v1/"}}
+	require.Equal(t, "https://api.deepseek.com/v1/chat/completions", account.GetCompatibleChatCompletionsURL())
+
+	account.Credentials["base_url"] = "http://vllm:8000/v1/chat/completions"
+	require.Equal(t, "http://vllm:8000/v1/chat/completions", account.GetCompatibleChatCompletionsURL())
+
+	account.Credentials["base_url"] = ""
+	require.Equal(t, "", account.GetCompatibleChatCompletionsURL())
+}
+
+func TestConvertClaudeMessagesToChatCompletions(t *testing.T) {
+	body := []byte(`{
+		"model": "claude-sonnet-4-5",
+		"system": [{"type":"text","text":"be brief"}],
+		"max_tokens": 256,
+		"stop_sequences": ["END"],
+		"stream": true,
+		"tools": [
+			{"name":"get_weather","description":"weather","input_schema":{"type":"object"}},
+			{"type":"web_search_20250305","name":"web_search"}
+		],
+		"tool_choice": {"type":"tool","name":"get_weather"},
+		"messages": [
+			{"role":"user","content":[{"type":"text","text":"weather?"},{"type":"image","source":{"type":"base64","media_type":"image/png","data":"AAAA"}}]},
+			{"role":"assistant","content":[{"type":"thinking","thinking":"hmm","signature":"x"},{"type":"tool_use","id":"toolu_1","name":"get_weather","input":{"city":"Paris"}}]},
+			{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":[{"type":"text","text":"sunny"}]},{"type":"text","text":"thanks"}]}
+		]
+	}`)
+
+	out, err := ConvertClaudeMessagesToChatCompletions(body, "deepseek-chat")
+	require.NoError(t, err)
+
+	require.Equal(t, "deepseek-chat", gjson.GetBytes(out, "model").String())
+	require.Equal(t, int64(256), gjson.GetBytes(out, "max_tokens").Int())
+	require.True(t, gjson.GetBytes(out, "stream_options.include_usage").Bool())
+	require.Equal(t, "END", gjson.GetBytes(out, "stop.0").String())
+
+	msgs := gjson.GetBytes(out, "messages").Array()
+	require.Len(t, msgs, 5)
+	require.Equal(t, "system", msgs[0].Get("role").String())
+	require.Equal(t, "be brief", msgs[0].Get("content").String())
+	require.Equal(t, "image_url", msgs[1].Get("content.1.type").String())
+	require.Equal(t, "data:image/png;base64,AAAA", msgs[1].Get("content.1.image_url.url").String())
+	require.Equal(t, "assistant", msgs[2].Get("role").String())
+	require.Equal(t, "toolu_1", msgs[2].Get("tool_calls.0.id").String())
+	require.JSONEq(t, `{"city":"Paris"}`, msgs[2].Get("tool_calls.0.function.arguments").String())
+	require.Equal(t, "tool", msgs[3].Get("role").String())
+	require.Equal(t, "toolu_1", msgs[3].Get("tool_call_id").String())
+	require.Equal(t, "sunny", msgs[3].Get("content").String())
+	require.Equal(t, "thanks", msgs[4].Get("content").String())
+
+	tools := gjson.GetBytes(out, "tools").Array()
+	require.Len(t, tools, 1)
+	require.Equal(t, "get_weather", tools[0].Get("function.name").String())
+	require.Equal(t, "get_weather", gjson.GetBytes(out, "tool_choice.function.name").String())
+}
+
+func TestConvertChatCompletionToClaudeMessage(t *testing.T) {
+	body := []byte(`{
+		"id":"chatcmpl-abc","model":"deepseek-chat",
+		"choices":[{"index":0,"message":{"role":"assistant","content":"checking","tool_calls":[{"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}}]},"finish_reason":"tool_calls"}],
+		"usage":{"prompt_tokens":100,"completion_tokens":20,"prompt_tokens_details":{"cached_tokens":60}}
+	}`)
+
+	out, usage, err := ConvertChatCompletionToClaudeMessage(body, "claude-sonnet-4-5")
+	require.NoError(t, err)
+	require.Equal(t, ClaudeUsage{InputTokens: 40, OutputTokens: 20, CacheReadInputTokens: 60}, usage)
+
+	require.Equal(t, "msg_abc", gjson.GetBytes(out, "id").String())
+	require.Equal(t, "claude-sonnet-4-5", gjson.GetBytes(out, "model").String())
+	require.Equal(t, "tool_use", gjson.GetBytes(out, "stop_reason").String())
+	require.Equal(t, "checking", gjson.GetBytes(out, "content.0.text").String())
+	require.Equal(t, "call_1", gjson.GetBytes(out, "content.1.id").String())
+	require.Equal(t, "Paris", gjson.GetBytes(out, "content.1.input.city").String())
+	require.Equal(t, int64(60), gjson.GetBytes(out, "usage.cache_read_input_tokens").Int())
+}
+
+func TestChatClaudeStreamConverter(t *testing.T) {
+	conv := newChatClaudeStreamConverter("claude-sonnet-4-5")
+	var out bytes.Buffer
+	for _, data := range []string{
+		`{"id":"chatcmpl-1","choices":[{"index":0,"delta":{"reasoning_content":"think"}}]}`,
+		`{"id":"chatcmpl-1","choices":[{"index":0,"delta":{"content":"Hi"}}]}`,
+		`{"id":"chatcmpl-1","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","function":{"name":"f","arguments":""}}]}}]}`,
+		`{"id":"chatcmpl-1","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"{\"a\":1}"}}]},"finish_reason":"tool_calls"}]}`,
+		`{"id":"chatcmpl-1","choices":[],"usage":{"prompt_tokens":10,"completion_tokens":5,"prompt_cache_hit_tokens":4}}`,
+		`[DONE]`,
+	} {
+		out.Write(conv.ConvertData(data))
+	}
+	require.Nil(t, conv.Finish())
+
+	stream := out.String()
+	require.Equal(t, 1, strings.Count(stream, "event: message_start\n"))
+	require.Equal(t, 3, strings.Count(stream, "event: content_block_start\n"))
+	require.Equal(t, 3, strings.Count(stream, "event: content_block_stop\n"))
+	require.Contains(t, stream, `"thinking_delta"`)
+	require.Contains(t, stream, `{"text":"Hi","type":"text_delta"}`)
+	require.Contains(t, stream, `"partial_json":"{\"a\":1}"`)
+	require.Contains(t, stream, `"stop_reason":"tool_use"`)
+	require.True(t, strings.HasSuffix(stream, "event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n"))
+	require.Equal(t, ClaudeUsage{InputTokens: 6, OutputTokens: 5, CacheReadInputTokens: 4}, conv.usage)
+}
+
+func TestCompatibleGatewayService_ForwardChatCompletions_Streaming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "/v1/chat/completions", r.URL.Path)
+		require.Equal(t, "Bearer sk-compatible", r.Header.Get("Authorization"))
+		require.Equal(t, "deepseek-chat", gjson.GetBytes(body, "model").String())
+		require.True(t, gjson.GetBytes(body, "stream_options.include_usage").Bool())
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"id\":\"c1\",\"model\":\"deepseek-chat\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"ok\"}}]}\n\n" +
+			"data: {\"id\":\"c1\",\"model\":\"deepseek-chat\",\"choices\":[],\"usage\":{\"prompt_tokens\":12,\"completion_tokens\":3}}\n\n" +
+			"data: [DONE]\n\n"))
+	}))
+	defer srv.Close()
+
+	account := newCompatibleTestAccount(srv.URL)
+	account.Credentials["model_mapping"] = map[string]any{"ds": "deepseek-chat"}
+	svc := newCompatibleTestService()
+
+	body := []byte(`{"model":"ds","stream":true,"messages":[{"role":"user","content":"hi"}]}`)
+	c, rec := newUpstreamTestContext("/v1/chat/completions", body)
+
+	result, err := svc.ForwardChatCompletions(context.Background(), c, account, body, "ds", true, false)
+	require.NoError(t, err)
+	require.Equal(t, 12, result.Usage.InputTokens)
+	require.Equal(t, 3, result.Usage.OutputTokens)
+	require.NotNil(t, result.FirstTokenMs)
+
+	// 客户端未要求 include_usage：仅含 usage 的 chunk 被丢弃；模型名还原为请求模型
+	out := rec.Body.String()
+	require.Contains(t, out, `"model":"ds"`)
+	require.NotContains(t, out, `"usage"`)
+	require.Contains(t, out, "data: [DONE]")
+}
+
+func TestCompatibleGatewayService_ForwardMessages_NonStreaming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "user", gjson.GetBytes(body, "messages.0.role").String())
+		require.False(t, gjson.GetBytes(body, "stream").Bool())
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"chatcmpl-9","model":"deepseek-chat","choices":[{"index":0,"message":{"role":"assistant","content":"hello"},"finish_reason":"stop"}],"usage":{"prompt_tokens":7,"completion_tokens":2}}`))
+	}))
+	defer srv.Close()
+
+	account := newCompatibleTestAccount(srv.URL)
+	svc := newCompatibleTestService()
+
+	body := []byte(`{"model":"deepseek-chat","max_tokens":32,"messages":[{"role":"user","content":"hi"}]}`)
+	c, rec := newUpstreamTestContext("/v1/messages", body)
+
+	result, err := svc.ForwardMessages(context.Background(), c, account, body, "deepseek-chat", false)
+	require.NoError(t, err)
+	require.Equal(t, 7, result.Usage.InputTokens)
+	require.Equal(t, 2, result.Usage.OutputTokens)
+	require.Equal(t, "message", gjson.Get(rec.Body.String(), "type").String())
+	require.Equal(t, "hello", gjson.Get(rec.Body.String(), "content.0.text").String())
+	require.Equal(t, "end_turn", gjson.Get(rec.Body.String(), "stop_reason").String())
+}
+
+func TestCompatibleGatewayService_UpstreamErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	status := http.StatusTooManyRequests
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"error":{"message":"bad things","type":"invalid_request_error"}}`))
+	}))
+	defer srv.Close()
+
+	account := newCompatibleTestAccount(srv.URL)
+	svc := newCompatibleTestService()
+	body := []byte(`{"model":"deepseek-chat","messages":[{"role":"user","content":"hi"}]}`)
+
+	// 429：返回 failover 错误，由 handler 切换账号
+	c, _ := newUpstreamTestContext("/v1/chat/completions", body)
+	_, err := svc.ForwardChatCompletions(context.Background(), c, account, body, "deepseek-chat", false, false)
+	var failoverErr *UpstreamFailoverError
+	require.True(t, errors.As(err, &failoverErr))
+	require.Equal(t, http.StatusTooManyRequests, failoverErr.StatusCode)
+
+	// 400：直接以 Anthropic 格式返回上游消息
+	status = http.StatusBadRequest
+	c, rec := newUpstreamTestContext("/v1/messages", body)
+	_, err = svc.ForwardMessages(context.Background(), c, account, body, "deepseek-chat", false)
+	require.Error(t, err)
+	require.False(t, errors.As(err, &failoverErr))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Equal(t, "error", gjson.Get(rec.Body.String(), "type").String())
+	require.Equal(t, "bad things", gjson.Get(rec.Body.String(), "error.message").String())
+}
@@ -0,0 +1,580 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Anthropic Messages <-> Chat Completions 转换（compatible 平台）
+//
+// compatible 平台账号只提供 Chat Completions 接口，/v1/messages 请求在此转换为 Chat Completions，
+// 上游 chat.completion / chat.completion.chunk 再转换回 Anthropic 消息与 SSE 事件。
+// 与 chat_completions_compat.go 中的方向相反：那里是 Chat Completions 客户端 -> Anthropic 上游。
+
+// claudeMessagesRequest 是 Anthropic Messages 请求中转换所需的字段子集
+type claudeMessagesRequest struct {
+	Model         string          `json:"model"`
+	System        json.RawMessage `json:"system,omitempty"`
+	Messages      []claudeMessage `json:"messages"`
+	MaxTokens     *int            `json:"max_tokens,omitempty"`
+	Temperature   *float64        `json:"temperature,omitempty"`
+	TopP          *float64        `json:"top_p,omitempty"`
+	StopSequences []string        `json:"stop_sequences,omitempty"`
+	Stream        bool            `json:"stream"`
+	Tools         []struct {
+		Type        string          `json:"type,omitempty"`
+		Name        string          `json:"name"`
+		Description string          `json:"description,omitempty"`
+		InputSchema json.RawMessage `json:"input_schema,omitempty"`
+	} `json:"tools,omitempty"`
+	ToolChoice *struct {
+		Type string `json:"type"`
+		Name string `json:"name,omitempty"`
+	} `json:"tool_choice,omitempty"`
+	Metadata *struct {
+		UserID string `json:"user_id,omitempty"`
+	} `json:"metadata,omitempty"`
+	Thinking *struct {
+		Type         string `json:"type"`
+		BudgetTokens int    `json:"budget_tokens,omitempty"`
+	} `json:"thinking,omitempty"`
+}
+
+type claudeMessage struct {
+	Role    string          `json:"role"`
+	Content json.RawMessage `json:"content"`
+}
+
+type claudeContentBlock struct {
+	Type   string `json:"type"`
+	Text   string `json:"text,omitempty"`
+	Source *struct {
+		Type      string `json:"type"`
+		MediaType string `json:"media_type,omitempty"`
+		Data      string `json:"data,omitempty"`
+		URL       string `json:"url,omitempty"`
+	} `json:"source,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   json.RawMessage `json:"content,omitempty"`
+	IsError   bool            `json:"is_error,omitempty"`
+}
+
+// parseClaudeContent 将 content（字符串或 block 数组）统一为 block 列表
+func parseClaudeContent(raw json.RawMessage) ([]claudeContentBlock, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		if text == "" {
+			return nil, nil
+		}
+		return []claudeContentBlock{{Type: "text", Text: text}}, nil
+	}
+	var blocks []claudeContentBlock
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return nil, fmt.Errorf("invalid message content: %w", err)
+	}
+	return blocks, nil
+}
+
+// claudeContentText 拼接 content 中的文本块（system、tool_result 等仅需要文本的场景）
+func claudeContentText(raw json.RawMessage) string {
+	blocks, _ := parseClaudeContent(raw)
+	var sb strings.Builder
+	for _, b := range blocks {
+		if b.Type == "text" && b.Text != "" {
+			if sb.Len() > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(b.Text)
+		}
+	}
+	return sb.String()
+}
+
+// ConvertClaudeMessagesToChatCompletions 将 Anthropic Messages 请求体转换为 Chat Completions 请求体。
+// thinking 块与服务端工具（web_search 等）在 Chat Completions 中没有对应概念，直接丢弃。
+// 流式请求总是开启 stream_options.include_usage，以便计费。
+func ConvertClaudeMessagesToChatCompletions(body []byte, model string) ([]byte, error) {
+	var req claudeMessagesRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	if len(req.Messages) == 0 {
+		return nil, errors.New("messages is required")
+	}
+
+	messages := make([]map[string]any, 0, len(req.Messages)+1)
+	if system := claudeContentText(req.System); system != "" {
+		messages = append(messages, map[string]any{"role": "system", "content": system})
+	}
+
+	for _, msg := range req.Messages {
+		blocks, err := parseClaudeContent(msg.Content)
+		if err != nil {
+			return nil, err
+		}
+		switch msg.Role {
+		case "user":
+			// tool_result 需要作为独立的 tool 消息，且必须紧跟在 assistant 的 tool_calls 之后
+			parts := make([]map[string]any, 0, len(blocks))
+			for _, b := range blocks {
+				switch b.Type {
+				case "tool_result":
+					content := claudeContentText(b.Content)
+					if content == "" {
+						var s string
+						if json.Unmarshal(b.Content, &s) == nil {
+							content = s
+						}
+					}
+					messages = append(messages, map[string]any{
+						"role":         "tool",
+						"tool_call_id": b.ToolUseID,
+						"content":      content,
+					})
+				case "text":
+					if b.Text != "" {
+						parts = append(parts, map[string]any{"type": "text", "text": b.Text})
+					}
+				case "image":
+					if b.Source == nil {
+						continue
+					}
+					url := b.Source.URL
+					if b.Source.Type == "base64" {
+						url = "data:" + b.Source.MediaType + ";base64," + b.Source.Data
+					}
+					if url != "" {
+						parts = append(parts, map[string]any{"type": "image_url", "image_url": map[string]any{"url": url}})
+					}
+				}
+			}
+			if len(parts) == 0 {
+				continue
+			}
+			if len(parts) == 1 && parts[0]["type"] == "text" {
+				messages = append(messages, map[string]any{"role": "user", "content": parts[0]["text"]})
+			} else {
+				messages = append(messages, map[string]any{"role": "user", "content": parts})
+			}
+		case "assistant":
+			var text strings.Builder
+			toolCalls := make([]map[string]any, 0)
+			for _, b := range blocks {
+				switch b.Type {
+				case "text":
+					text.WriteString(b.Text)
+				case "tool_use":
+					args := string(b.Input)
+					if args == "" || args == "null" {
+						args = "{}"
+					}
+					toolCalls = append(toolCalls, map[string]any{
+						"id":       b.ID,
+						"type":     "function",
+						"function": map[string]any{"name": b.Name, "arguments": args},
+					})
+				}
+			}
+			out := map[string]any{"role": "assistant", "content": text.String()}
+			if len(toolCalls) > 0 {
+				out["tool_calls"] = toolCalls
+				if text.Len() == 0 {
+					out["content"] = nil
+				}
+			} else if text.Len() == 0 {
+				continue
+			}
+			messages = append(messages, out)
+		default:
+			return nil, fmt.Errorf("unsupported message role: %s", msg.Role)
+		}
+	}
+
+	if model == "" {
+		model = req.Model
+	}
+	out := map[string]any{
+		"model":    model,
+		"messages": messages,
+		"stream":   req.Stream,
+	}
+	if req.Stream {
+		out["stream_options"] = map[string]any{"include_usage": true}
+	}
+	if req.MaxTokens != nil && *req.MaxTokens > 0 {
+		out["max_tokens"] = *req.MaxTokens
+	}
+	if req.Temperature != nil {
+		out["temperature"] = *req.Temperature
+	}
+	if req.TopP != nil {
+		out["top_p"] = *req.TopP
+	}
+	if len(req.StopSequences) > 0 {
+		out["stop"] = req.StopSequences
+	}
+	if req.Metadata != nil && req.Metadata.UserID != "" {
+		out["user"] = req.Metadata.UserID
+	}
+	if len(req.Tools) > 0 {
+		tools := make([]map[string]any, 0, len(req.Tools))
+		for _, t := range req.Tools {
+			// 服务端工具（web_search_20250305 等）带有 type，上游无法执行
+			if t.Type != "" && t.Type != "custom" {
+				continue
+			}
+			fn := map[string]any{"name": t.Name}
+			if t.Description != "" {
+				fn["description"] = t.Description
+			}
+			if len(t.InputSchema) > 0 && string(t.InputSchema) != "null" {
+				fn["parameters"] = t.InputSchema
+			}
+			tools = append(tools, map[string]any{"type": "function", "function": fn})
+		}
+		if len(tools) > 0 {
+			out["tools"] = tools
+		}
+	}
+	if req.ToolChoice != nil && out["tools"] != nil {
+		switch req.ToolChoice.Type {
+		case "auto":
+			out["tool_choice"] = "auto"
+		case "any":
+			out["tool_choice"] = "required"
+		case "none":
+			out["tool_choice"] = "none"
+		case "tool":
+			out["tool_choice"] = map[string]any{"type": "function", "function": map[string]any{"name": req.ToolChoice.Name}}
+		}
+	}
+	return json.Marshal(out)
+}
+
+// chatCompletionUsageIn 解析上游 usage；DeepSeek 使用 prompt_cache_hit_tokens 报告缓存命中
+type chatCompletionUsageIn struct {
+	PromptTokens        int `json:"prompt_tokens"`
+	CompletionTokens    int `json:"completion_tokens"`
+	PromptTokensDetails *struct {
+		CachedTokens int `json:"cached_tokens"`
+	} `json:"prompt_tokens_details,omitempty"`
+	PromptCacheHitTokens int `json:"prompt_cache_hit_tokens,omitempty"`
+}
+
+// toClaudeUsage 转换为网关统一的 ClaudeUsage：input_tokens 不含缓存命中部分
+func (u *chatCompletionUsageIn) toClaudeUsage() ClaudeUsage {
+	if u == nil {
+		return ClaudeUsage{}
+	}
+	cached := u.PromptCacheHitTokens
+	if u.PromptTokensDetails != nil && u.PromptTokensDetails.CachedTokens > cached {
+		cached = u.PromptTokensDetails.CachedTokens
+	}
+	cached = min(cached, u.PromptTokens)
+	return ClaudeUsage{
+		InputTokens:          u.PromptTokens - cached,
+		OutputTokens:         u.CompletionTokens,
+		CacheReadInputTokens: cached,
+	}
+}
+
+// chatCompletionChunk 是 chat.completion / chat.completion.chunk 中转换所需的字段子集
+type chatCompletionChunk struct {
+	ID      string `json:"id"`
+	Model   string `json:"model"`
+	Choices []struct {
+		Index   int `json:"index"`
+		Message *struct {
+			Content          *string        `json:"content"`
+			ReasoningContent string         `json:"reasoning_content"`
+			ToolCalls        []chatToolCall `json:"tool_calls"`
+		} `json:"message,omitempty"`
+		Delta *struct {
+			Content          string `json:"content"`
+			ReasoningContent string `json:"reasoning_content"`
+			ToolCalls        []struct {
+				Index    int    `json:"index"`
+				ID       string `json:"id"`
+				Function struct {
+					Name      string `json:"name"`
+					Arguments string `json:"arguments"`
+				} `json:"function"`
+			} `json:"tool_calls"`
+		} `json:"delta,omitempty"`
+		FinishReason *string `json:"finish_reason"`
+	} `json:"choices"`
+	Usage *chatCompletionUsageIn `json:"usage,omitempty"`
+	Error json.RawMessage        `json:"error,omitempty"`
+}
+
+func finishReasonToClaudeStopReason(reason string) string {
+	switch reason {
+	case "length":
+		return "max_tokens"
+	case "tool_calls", "function_call":
+		return "tool_use"
+	case "content_filter":
+		return "refusal"
+	default:
+		return "end_turn"
+	}
+}
+
+func claudeMessageID(upstreamID string) string {
+	if upstreamID == "" {
+		return "msg_" + randomHex(12)
+	}
+	return "msg_" + strings.TrimPrefix(upstreamID, "chatcmpl-")
+}
+
+// ConvertChatCompletionToClaudeMessage 将 chat.completion 非流式响应转换为 Anthropic 消息，同时返回用量
+func ConvertChatCompletionToClaudeMessage(body []byte, model string) ([]byte, ClaudeUsage, error) {
+	var resp chatCompletionChunk
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, ClaudeUsage{}, err
+	}
+	if len(resp.Choices) == 0 || resp.Choices[0].Message == nil {
+		return nil, ClaudeUsage{}, errors.New("upstream response has no choices")
+	}
+	choice := resp.Choices[0]
+	msg := choice.Message
+
+	content := make([]map[string]any, 0, 2+len(msg.ToolCalls))
+	if msg.ReasoningContent != "" {
+		content = append(content, map[string]any{"type": "thinking", "thinking": msg.ReasoningContent, "signature": ""})
+	}
+	if msg.Content != nil && *msg.Content != "" {
+		content = append(content, map[string]any{"type": "text", "text": *msg.Content})
+	}
+	for _, tc := range msg.ToolCalls {
+		content = append(content, map[string]any{
+			"type":  "tool_use",
+			"id":    tc.ID,
+			"name":  tc.Function.Name,
+			"input": parseToolArguments(tc.Function.Arguments),
+		})
+	}
+
+	finishReason := ""
+	if choice.FinishReason != nil {
+		finishReason = *choice.FinishReason
+	}
+	if model == "" {
+		model = resp.Model
+	}
+	usage := resp.Usage.toClaudeUsage()
+	out, err := json.Marshal(map[string]any{
+		"id":            claudeMessageID(resp.ID),
+		"type":          "message",
+		"role":          "assistant",
+		"model":         model,
+		"content":       content,
+		"stop_reason":   finishReasonToClaudeStopReason(finishReason),
+		"stop_sequence": nil,
+		"usage":         usage,
+	})
+	return out, usage, err
+}
+
+// parseToolArguments 解析工具调用参数；上游返回非法 JSON 时保留原文，避免丢失信息
+func parseToolArguments(arguments string) any {
+	if strings.TrimSpace(arguments) == "" {
+		return map[string]any{}
+	}
+	var input map[string]any
+	if err := json.Unmarshal([]byte(arguments), &input); err != nil {
+		return map[string]any{"raw_arguments": arguments}
+	}
+	return input
+}
+
+// claudeStreamEmitter 负责按 Anthropic Messages SSE 协议输出事件（message_start、content block 开关、结尾事件），
+// 供各上游格式的流式转换器复用
+type claudeStreamEmitter struct {
+	model      string
+	started    bool
+	finished   bool
+	blockIndex int
+	openBlock  string // 当前打开的 content block 类型（text / thinking / tool_use），空表示无
+	stopReason string
+	usage      ClaudeUsage
+}
+
+func writeClaudeSSE(buf *bytes.Buffer, event string, payload any) {
+	b, _ := json.Marshal(payload)
+	buf.WriteString("event: " + event + "\ndata: ")
+	buf.Write(b)
+	buf.WriteString("\n\n")
+}
+
+func (s *claudeStreamEmitter) start(buf *bytes.Buffer, upstreamID string) {
+	if s.started {
+		return
+	}
+	s.started = true
+	writeClaudeSSE(buf, "message_start", map[string]any{
+		"type": "message_start",
+		"message": map[string]any{
+			"id":            claudeMessageID(upstreamID),
+			"type":          "message",
+			"role":          "assistant",
+			"model":         s.model,
+			"content":       []any{},
+			"stop_reason":   nil,
+			"stop_sequence": nil,
+			"usage":         map[string]any{"input_tokens": 0, "output_tokens": 0},
+		},
+	})
+}
+
+func (s *claudeStreamEmitter) closeBlock(buf *bytes.Buffer) {
+	if s.openBlock == "" {
+		return
+	}
+	writeClaudeSSE(buf, "content_block_stop", map[string]any{"type": "content_block_stop", "index": s.blockIndex})
+	s.openBlock = ""
+	s.blockIndex++
+}
+
+func (s *claudeStreamEmitter) openContentBlock(buf *bytes.Buffer, blockType string, block map[string]any) {
+	s.closeBlock(buf)
+	s.openBlock = blockType
+	writeClaudeSSE(buf, "content_block_start", map[string]any{"type": "content_block_start", "index": s.blockIndex, "content_block": block})
+}
+
+// textDelta / thinkingDelta 向当前块追加内容，必要时先打开对应类型的块
+func (s *claudeStreamEmitter) textDelta(buf *bytes.Buffer, text string) {
+	if s.openBlock != "text" {
+		s.openContentBlock(buf, "text", map[string]any{"type": "text", "text": ""})
+	}
+	writeClaudeSSE(buf, "content_block_delta", map[string]any{"type": "content_block_delta", "index": s.blockIndex,
+		"delta": map[string]any{"type": "text_delta", "text": text}})
+}
+
+func (s *claudeStreamEmitter) thinkingDelta(buf *bytes.Buffer, thinking string) {
+	if s.openBlock != "thinking" {
+		s.openContentBlock(buf, "thinking", map[string]any{"type": "thinking", "thinking": ""})
+	}
+	writeClaudeSSE(buf, "content_block_delta", map[string]any{"type": "content_block_delta", "index": s.blockIndex,
+		"delta": map[string]any{"type": "thinking_delta", "thinking": thinking}})
+}
+
+func (s *claudeStreamEmitter) inputJSONDelta(buf *bytes.Buffer, index int, partial string) {
+	writeClaudeSSE(buf, "content_block_delta", map[string]any{"type": "content_block_delta", "index": index,
+		"delta": map[string]any{"type": "input_json_delta", "partial_json": partial}})
+}
+
+// errorEvent 输出 Anthropic error 事件并结束流
+func (s *claudeStreamEmitter) errorEvent(message string) []byte {
+	s.finished = true
+	var buf bytes.Buffer
+	writeClaudeSSE(&buf, "error", map[string]any{"type": "error", "error": map[string]any{"type": "api_error", "message": message}})
+	return buf.Bytes()
+}
+
+// Abort 在上游流未输出结束事件即中断时调用，输出 error 事件而非 message_stop
+func (s *claudeStreamEmitter) Abort() []byte {
+	if s.finished {
+		return nil
+	}
+	return s.errorEvent(upstreamStreamTruncatedMessage)
+}
+
+// Finish 在上游流结束后调用，补齐 content_block_stop / message_delta / message_stop（若尚未输出）
+func (s *claudeStreamEmitter) Finish() []byte {
+	if s.finished {
+		return nil
+	}
+	s.finished = true
+	var buf bytes.Buffer
+	s.start(&buf, "")
+	s.closeBlock(&buf)
+	stopReason := s.stopReason
+	if stopReason == "" {
+		stopReason = "end_turn"
+	}
+	writeClaudeSSE(&buf, "message_delta", map[string]any{
+		"type":  "message_delta",
+		"delta": map[string]any{"stop_reason": stopReason, "stop_sequence": nil},
+		"usage": s.usage,
+	})
+	writeClaudeSSE(&buf, "message_stop", map[string]any{"type": "message_stop"})
+	return buf.Bytes()
+}
+
+// chatClaudeStreamConverter 将 chat.completion.chunk SSE 转换为 Anthropic Messages SSE
+type chatClaudeStreamConverter struct {
+	claudeStreamEmitter
+	toolBlocks map[int]int // 上游 tool_calls index -> content block index
+}
+
+func newChatClaudeStreamConverter(model string) *chatClaudeStreamConverter {
+	return &chatClaudeStreamConverter{claudeStreamEmitter: claudeStreamEmitter{model: model}, toolBlocks: make(map[int]int)}
+}
+
+// ConvertData 转换一个 SSE data 负载（不含 "data:" 前缀），返回需写给客户端的 Anthropic SSE 文本
+func (s *chatClaudeStreamConverter) ConvertData(data string) []byte {
+	if s.finished {
+		return nil
+	}
+	if data == "[DONE]" {
+		return s.Finish()
+	}
+	var chunk chatCompletionChunk
+	if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+		return nil
+	}
+	if len(chunk.Error) > 0 && string(chunk.Error) != "null" {
+		message := sanitizeUpstreamErrorMessage(ExtractUpstreamErrorMessage([]byte(data)))
+		if message == "" {
+			message = "Upstream stream error"
+		}
+		return s.errorEvent(message)
+	}
+	var buf bytes.Buffer
+	s.start(&buf, chunk.ID)
+	if chunk.Usage != nil {
+		s.usage = chunk.Usage.toClaudeUsage()
+	}
+
+	for _, choice := range chunk.Choices {
+		if choice.Index != 0 || choice.Delta == nil {
+			if choice.FinishReason != nil && *choice.FinishReason != "" {
+				s.stopReason = finishReasonToClaudeStopReason(*choice.FinishReason)
+			}
+			continue
+		}
+		delta := choice.Delta
+		if delta.ReasoningContent != "" {
+			s.thinkingDelta(&buf, delta.ReasoningContent)
+		}
+		if delta.Content != "" {
+			s.textDelta(&buf, delta.Content)
+		}
+		for _, tc := range delta.ToolCalls {
+			blockIdx, seen := s.toolBlocks[tc.Index]
+			if !seen {
+				s.openContentBlock(&buf, "tool_use", map[string]any{"type": "tool_use", "id": tc.ID, "name": tc.Function.Name, "input": map[string]any{}})
+				blockIdx = s.blockIndex
+				s.toolBlocks[tc.Index] = blockIdx
+			}
+			// 上游交错输出多个工具调用参数时，只能向当前打开的块追加
+			if tc.Function.Arguments != "" && blockIdx == s.blockIndex && s.openBlock == "tool_use" {
+				s.inputJSONDelta(&buf, blockIdx, tc.Function.Arguments)
+			}
+		}
+		if choice.FinishReason != nil && *choice.FinishReason != "" {
+			s.stopReason = finishReasonToClaudeStopReason(*choice.FinishReason)
+		}
+	}
+	return buf.Bytes()
+}
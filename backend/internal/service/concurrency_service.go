@@ -59,7 +59,8 @@ const (
 
 // ConcurrencyService manages concurrent request limiting for accounts and users
 type ConcurrencyService struct {
-	cache ConcurrencyCache
+	cache     ConcurrencyCache
+	fairQueue *FairQueueService
 }
 
 // NewConcurrencyService creates a new ConcurrencyService
@@ -112,21 +113,22 @@ func (s *ConcurrencyService) AcquireAccountSlot(ctx context.Context, accountID i
 	// Generate unique request ID for this slot
 	requestID := generateRequestID()
 
-	acquired, err := s.cache.AcquireAccountSlot(ctx, accountID, maxConcurrency, requestID)
+	var acquired bool
+	var err error
+	if s.fairQueue.Enabled() {
+		// 公平排队启用时，新请求只能使用排队者之外的空闲槽位
+		acquired, err = s.fairQueue.cache.AcquireAccountSlotYielding(ctx, accountID, maxConcurrency, requestID)
+	} else {
+		acquired, err = s.cache.AcquireAccountSlot(ctx, accountID, maxConcurrency, requestID)
+	}
 	if err != nil {
 		return nil, err
 	}
 
 	if acquired {
 		return &AcquireResult{
-			Acquired: true,
-			ReleaseFunc: func() {
-				bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-				defer cancel()
-				if err := s.cache.ReleaseAccountSlot(bgCtx, accountID, requestID); err != nil {
-					log.Printf("Warning: failed to release account slot for %d (req=%s): %v", accountID, requestID, err)
-				}
-			},
+			Acquired:    true,
+			ReleaseFunc: s.accountSlotReleaseFunc(accountID, requestID),
 		}, nil
 	}
 
@@ -136,6 +138,48 @@ func (s *ConcurrencyService) AcquireAccountSlot(ctx context.Context, accountID i
 	}, nil
 }
 
+// SetFairQueue 设置账号槽位公平排队（nil 或未启用时使用退避轮询）
+func (s *ConcurrencyService) SetFairQueue(fairQueue *FairQueueService) {
+	s.fairQueue = fairQueue
+}
+
+// FairQueue 返回公平排队服务，未启用时返回 nil
+func (s *ConcurrencyService) FairQueue() *FairQueueService {
+	if s == nil || !s.fairQueue.Enabled() {
+		return nil
+	}
+	return s.fairQueue
+}
+
+// AcquireAccountSlotFair 以排队 ticket 尝试获取账号槽位，只有排名在空闲槽位数之内时成功
+func (s *ConcurrencyService) AcquireAccountSlotFair(ctx context.Context, ticket *FairQueueTicket, maxConcurrency int) (*AcquireResult, *FairQueueAcquireResult, error) {
+	if maxConcurrency <= 0 {
+		return &AcquireResult{Acquired: true, ReleaseFunc: func() {}}, &FairQueueAcquireResult{Acquired: true}, nil
+	}
+	requestID := generateRequestID()
+	result, err := s.fairQueue.cache.TryAcquire(ctx, ticket, maxConcurrency, requestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !result.Acquired {
+		return &AcquireResult{Acquired: false}, result, nil
+	}
+	return &AcquireResult{
+		Acquired:    true,
+		ReleaseFunc: s.accountSlotReleaseFunc(ticket.AccountID, requestID),
+	}, result, nil
+}
+
+func (s *ConcurrencyService) accountSlotReleaseFunc(accountID int64, requestID string) func() {
+	return func() {
+		bgCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := s.cache.ReleaseAccountSlot(bgCtx, accountID, requestID); err != nil {
+			log.Printf("Warning: failed to release account slot for %d (req=%s): %v", accountID, requestID, err)
+		}
+	}
+}
+
 // AcquireUserSlot attempts to acquire a concurrency slot for a user.
 // If the user is at max concurrency, it waits until a slot is available or timeout.
 // Returns a release function that MUST be called when the request completes.
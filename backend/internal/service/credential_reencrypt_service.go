@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+const credentialReencryptRunTimeout = 5 * time.Minute
+
+// CredentialReencryptResult 一批重新加密的结果
+type CredentialReencryptResult struct {
+	Scanned int   // 本批检查的账号数
+	Updated int   // 成功改用当前主密钥加密的账号数
+	Failed  int   // 解密/加密失败的账号数（例如旧主密钥已从配置中移除）
+	LastID  int64 // 本批最后一个账号 ID，用作下一批的游标
+}
+
+// AccountCredentialRepository 账号凭证加密存储的维护操作
+type AccountCredentialRepository interface {
+	// ReencryptStale 从 afterID 之后扫描最多 limit 个仍含明文敏感字段或使用旧主密钥的账号，
+	// 改用当前主密钥加密；未配置当前主密钥时不做任何事。
+	ReencryptStale(ctx context.Context, afterID int64, limit int) (CredentialReencryptResult, error)
+}
+
+// CredentialReencryptService 后台重新加密账号凭证。
+//
+// 用于两种场景：首次启用凭证加密时把已有明文凭证加密；轮换主密钥后把旧主密钥包裹的数据密钥重新包裹。
+// 全部完成后即可从配置中移除旧主密钥。
+type CredentialReencryptService struct {
+	repo      AccountCredentialRepository
+	interval  time.Duration
+	batchSize int
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewCredentialReencryptService creates a CredentialReencryptService
+func NewCredentialReencryptService(repo AccountCredentialRepository, cfg *config.Config) *CredentialReencryptService {
+	s := &CredentialReencryptService{
+		repo:      repo,
+		batchSize: 100,
+		stopCh:    make(chan struct{}),
+	}
+	if cfg != nil {
+		s.interval = time.Duration(cfg.Credential.ReencryptIntervalMinutes) * time.Minute
+		if cfg.Credential.ReencryptBatchSize > 0 {
+			s.batchSize = cfg.Credential.ReencryptBatchSize
+		}
+	}
+	return s
+}
+
+// Start 启动定时重新加密；interval <= 0 时不启动
+func (s *CredentialReencryptService) Start() {
+	if s == nil || s.repo == nil || s.interval <= 0 {
+		return
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.runOnce()
+		for {
+			select {
+			case <-ticker.C:
+				s.runOnce()
+			case <-s.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+// Stop 停止定时重新加密
+func (s *CredentialReencryptService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+}
+
+// RunOnce 按批次扫描全部账号一遍，返回累计结果
+func (s *CredentialReencryptService) RunOnce(ctx context.Context) (CredentialReencryptResult, error) {
+	var total CredentialReencryptResult
+	var afterID int64
+	for {
+		select {
+		case <-s.stopCh:
+			return total, nil
+		default:
+		}
+
+		res, err := s.repo.ReencryptStale(ctx, afterID, s.batchSize)
+		if err != nil {
+			return total, err
+		}
+		total.Scanned += res.Scanned
+		total.Updated += res.Updated
+		total.Failed += res.Failed
+		if res.LastID > total.LastID {
+			total.LastID = res.LastID
+		}
+		if res.Scanned < s.batchSize || res.LastID <= afterID {
+			return total, nil
+		}
+		afterID = res.LastID
+	}
+}
+
+func (s *CredentialReencryptService) runOnce() {
+	ctx, cancel := context.WithTimeout(context.Background(), credentialReencryptRunTimeout)
+	defer cancel()
+
+	res, err := s.RunOnce(ctx)
+	if err != nil {
+		log.Printf("[CredentialReencrypt] run failed: %v", err)
+		return
+	}
+	if res.Updated > 0 || res.Failed > 0 {
+		log.Printf("[CredentialReencrypt] re-encrypted %d account(s), %d failed", res.Updated, res.Failed)
+	}
+}
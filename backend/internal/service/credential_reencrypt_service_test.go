@@ -0,0 +1,43 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type credentialRepoStub struct {
+	batches []CredentialReencryptResult
+	cursors []int64
+}
+
+func (s *credentialRepoStub) ReencryptStale(ctx context.Context, afterID int64, limit int) (CredentialReencryptResult, error) {
+	s.cursors = append(s.cursors, afterID)
+	if len(s.batches) == 0 {
+		return CredentialReencryptResult{LastID: afterID}, nil
+	}
+	res := s.batches[0]
+	s.batches = s.batches[1:]
+	return res, nil
+}
+
+func TestCredentialReencryptService_RunOnceWalksAllBatches(t *testing.T) {
+	repo := &credentialRepoStub{batches: []CredentialReencryptResult{
+		{Scanned: 2, Updated: 2, LastID: 10},
+		{Scanned: 2, Updated: 1, Failed: 1, LastID: 25},
+		{Scanned: 1, Updated: 1, LastID: 30},
+	}}
+	svc := NewCredentialReencryptService(repo, &config.Config{Credential: config.CredentialEncryptionConfig{ReencryptBatchSize: 2}})
+
+	res, err := svc.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []int64{0, 10, 25}, repo.cursors)
+	require.Equal(t, 5, res.Scanned)
+	require.Equal(t, 4, res.Updated)
+	require.Equal(t, 1, res.Failed)
+	require.Equal(t, int64(30), res.LastID)
+}
@@ -1,63 +1,126 @@
 package service
 
 import (
+	"context"
+	"log"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Wei-Shaw/sub2api/internal/config"
 	gocache "github.com/patrickmn/go-cache"
 )
 
 // digestSessionTTL 摘要会话默认 TTL
 const digestSessionTTL = 5 * time.Minute
 
+// 摘要会话存储后端
+const (
+	DigestSessionBackendMemory = "memory"
+	DigestSessionBackendRedis  = "redis"
+)
+
+// DigestSessionStore 摘要会话存储（Gemini/Anthropic 基于内容摘要链的会话粘性）
+//
+// 匹配语义：key 为 "{groupID}:{prefixHash}|{digestChain}"，Find 从完整 chain 逐段（按 "-"）截断，
+// 返回最长匹配及对应 matchedChain；Save 写入新 chain 并删除旧 matchedChain，条目按 TTL 过期。
+type DigestSessionStore interface {
+	// Save 保存摘要会话。oldDigestChain 为 Find 返回的 matchedChain，用于删旧 key。
+	Save(ctx context.Context, groupID int64, prefixHash, digestChain, uuid string, accountID int64, oldDigestChain string) error
+	// Find 查找摘要会话，返回最长匹配；存储异常时视为未命中。
+	Find(ctx context.Context, groupID int64, prefixHash, digestChain string) (uuid string, accountID int64, matchedChain string, found bool)
+}
+
+// DigestSessionCache Redis 摘要会话存储（多实例共享），由 repository 层实现
+type DigestSessionCache interface {
+	DigestSessionStore
+}
+
+// ProvideDigestSessionStore 按配置选择摘要会话存储后端。
+// simple 模式（单节点）始终使用内存存储；多副本部署可配置 redis 以在实例间共享会话粘性。
+func ProvideDigestSessionStore(cfg *config.Config, cache DigestSessionCache) DigestSessionStore {
+	ttl := digestSessionTTL
+	backend := DigestSessionBackendMemory
+	if cfg != nil {
+		if cfg.Gateway.DigestSession.TTLSeconds > 0 {
+			ttl = time.Duration(cfg.Gateway.DigestSession.TTLSeconds) * time.Second
+		}
+		backend = strings.ToLower(strings.TrimSpace(cfg.Gateway.DigestSession.Backend))
+		if backend == DigestSessionBackendRedis && cfg.RunMode == config.RunModeSimple {
+			log.Printf("[DigestSession] run_mode=simple, ignoring backend=redis and using in-memory store")
+			backend = DigestSessionBackendMemory
+		}
+	}
+	if backend == DigestSessionBackendRedis && cache != nil {
+		return cache
+	}
+	return newMemoryDigestSessionStore(ttl)
+}
+
 // sessionEntry flat cache 条目
 type sessionEntry struct {
 	uuid      string
 	accountID int64
 }
 
-// DigestSessionStore 内存摘要会话存储（flat cache 实现）
+// MemoryDigestSessionStore 内存摘要会话存储（flat cache 实现）
 // key: "{groupID}:{prefixHash}|{digestChain}" → *sessionEntry
-type DigestSessionStore struct {
+type MemoryDigestSessionStore struct {
 	cache *gocache.Cache
 }
 
-// NewDigestSessionStore 创建内存摘要会话存储
-func NewDigestSessionStore() *DigestSessionStore {
-	return &DigestSessionStore{
-		cache: gocache.New(digestSessionTTL, time.Minute),
+// NewMemoryDigestSessionStore 创建内存摘要会话存储
+func NewMemoryDigestSessionStore() *MemoryDigestSessionStore {
+	return newMemoryDigestSessionStore(digestSessionTTL)
+}
+
+func newMemoryDigestSessionStore(ttl time.Duration) *MemoryDigestSessionStore {
+	return &MemoryDigestSessionStore{
+		cache: gocache.New(ttl, time.Minute),
 	}
 }
 
 // Save 保存摘要会话。oldDigestChain 为 Find 返回的 matchedChain，用于删旧 key。
-func (s *DigestSessionStore) Save(groupID int64, prefixHash, digestChain, uuid string, accountID int64, oldDigestChain string) {
+func (s *MemoryDigestSessionStore) Save(_ context.Context, groupID int64, prefixHash, digestChain, uuid string, accountID int64, oldDigestChain string) error {
 	if digestChain == "" {
-		return
+		return nil
 	}
 	ns := buildNS(groupID, prefixHash)
 	s.cache.Set(ns+digestChain, &sessionEntry{uuid: uuid, accountID: accountID}, gocache.DefaultExpiration)
 	if oldDigestChain != "" && oldDigestChain != digestChain {
 		s.cache.Delete(ns + oldDigestChain)
 	}
+	return nil
 }
 
 // Find 查找摘要会话，从完整 chain 逐段截断，返回最长匹配及对应 matchedChain。
-func (s *DigestSessionStore) Find(groupID int64, prefixHash, digestChain string) (uuid string, accountID int64, matchedChain string, found bool) {
+func (s *MemoryDigestSessionStore) Find(_ context.Context, groupID int64, prefixHash, digestChain string) (uuid string, accountID int64, matchedChain string, found bool) {
 	if digestChain == "" {
 		return "", 0, "", false
 	}
 	ns := buildNS(groupID, prefixHash)
-	chain := digestChain
-	for {
+	for _, chain := range DigestChainCandidates(digestChain) {
 		if val, ok := s.cache.Get(ns + chain); ok {
 			if e, ok := val.(*sessionEntry); ok {
 				return e.uuid, e.accountID, chain, true
 			}
 		}
+	}
+	return "", 0, "", false
+}
+
+// DigestChainCandidates 返回 chain 的所有前缀（按 "-" 分段），从最长到最短排列
+func DigestChainCandidates(digestChain string) []string {
+	if digestChain == "" {
+		return nil
+	}
+	candidates := make([]string, 0, strings.Count(digestChain, "-")+1)
+	chain := digestChain
+	for {
+		candidates = append(candidates, chain)
 		i := strings.LastIndex(chain, "-")
 		if i < 0 {
-			return "", 0, "", false
+			return candidates
 		}
 		chain = chain[:i]
 	}
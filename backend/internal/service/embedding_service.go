@@ -0,0 +1,529 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/geminicli"
+	"github.com/Wei-Shaw/sub2api/internal/util/responseheaders"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/sjson"
+)
+
+var (
+	// ErrEmbeddingsPlatformUnsupported 分组平台不支持 embeddings（仅 OpenAI / Gemini 分组可用）
+	ErrEmbeddingsPlatformUnsupported = errors.New("embeddings are only supported for openai and gemini groups")
+	// ErrEmbeddingsScopeNotAllowed 分组 supported_model_scopes 未包含 embeddings 对应的模型系列
+	ErrEmbeddingsScopeNotAllowed = errors.New("embedding models are not enabled for this group")
+)
+
+// EmbeddingService 处理 OpenAI 兼容的 /v1/embeddings 请求。
+//
+// 只调度具备 embeddings 能力的账号：OpenAI API Key 账号（ChatGPT OAuth 不支持），
+// 以及 Gemini API Key / AI Studio OAuth 账号（Code Assist 不支持 embedContent）。
+// 计费与使用记录复用 GatewayService.RecordUsage，按输入 token 与 LiteLLM embedding 价格计费。
+type EmbeddingService struct {
+	accountRepo          AccountRepository
+	schedulerSnapshot    *SchedulerSnapshotService
+	concurrencyService   *ConcurrencyService
+	gatewayService       *GatewayService
+	openAIGatewayService *OpenAIGatewayService
+	geminiCompatService  *GeminiMessagesCompatService
+	rateLimitService     *RateLimitService
+	httpUpstream         HTTPUpstream
+	cfg                  *config.Config
+}
+
+// NewEmbeddingService creates a new EmbeddingService
+func NewEmbeddingService(
+	accountRepo AccountRepository,
+	schedulerSnapshot *SchedulerSnapshotService,
+	concurrencyService *ConcurrencyService,
+	gatewayService *GatewayService,
+	openAIGatewayService *OpenAIGatewayService,
+	geminiCompatService *GeminiMessagesCompatService,
+	rateLimitService *RateLimitService,
+	httpUpstream HTTPUpstream,
+	cfg *config.Config,
+) *EmbeddingService {
+	return &EmbeddingService{
+		accountRepo:          accountRepo,
+		schedulerSnapshot:    schedulerSnapshot,
+		concurrencyService:   concurrencyService,
+		gatewayService:       gatewayService,
+		openAIGatewayService: openAIGatewayService,
+		geminiCompatService:  geminiCompatService,
+		rateLimitService:     rateLimitService,
+		httpUpstream:         httpUpstream,
+		cfg:                  cfg,
+	}
+}
+
+// ResolvePlatform 确定 embeddings 请求的上游平台，并校验分组的 supported_model_scopes。
+// 无分组（简易模式）时按模型名推断。
+func (s *EmbeddingService) ResolvePlatform(group *Group, model string) (string, error) {
+	if group == nil {
+		return InferEmbeddingPlatform(model), nil
+	}
+	switch group.Platform {
+	case PlatformOpenAI, PlatformGemini:
+	default:
+		return "", ErrEmbeddingsPlatformUnsupported
+	}
+	if scope := EmbeddingModelScope(group.Platform); scope != "" && !group.SupportsModelScope(scope) {
+		return "", ErrEmbeddingsScopeNotAllowed
+	}
+	return group.Platform, nil
+}
+
+// SelectAccount 选择可处理 embeddings 的账号并尝试获取并发槽位。
+//
+// 分组启用模型路由且命中规则时，优先在路由账号中选择；路由账号均不可用时回退到全部候选账号。
+// 候选账号按优先级、最近使用时间排序，依次尝试获取槽位；全部已满时返回首个候选账号的等待计划。
+func (s *EmbeddingService) SelectAccount(ctx context.Context, group *Group, groupID *int64, platform, model string, excludedIDs map[int64]struct{}) (*AccountSelectionResult, error) {
+	accounts, err := s.listSchedulableAccounts(ctx, groupID, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]*Account, 0, len(accounts))
+	for i := range accounts {
+		acc := &accounts[i]
+		if _, excluded := excludedIDs[acc.ID]; excluded {
+			continue
+		}
+		if !acc.IsEligibleForNewSession() {
+			continue
+		}
+		if !isEmbeddingCapableAccount(acc, platform) || !acc.IsSchedulableForModelWithContext(ctx, model) {
+			continue
+		}
+		if !isEmbeddingModelSupportedByAccount(acc, model) {
+			continue
+		}
+		candidates = append(candidates, acc)
+	}
+
+	if group != nil {
+		if routed := filterEmbeddingRoutedAccounts(candidates, group.GetRoutingAccountIDs(model)); len(routed) > 0 {
+			candidates = routed
+		} else if len(group.GetRoutingAccountIDs(model)) > 0 {
+			log.Printf("[ModelRouting] No routed accounts available for embeddings model=%s, falling back to normal selection", model)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, errors.New("no available embedding accounts")
+	}
+	sortAccountsByPriorityAndLastUsed(candidates, false)
+
+	for _, acc := range candidates {
+		result, err := s.tryAcquireAccountSlot(ctx, acc.ID, acc.Concurrency)
+		if err == nil && result.Acquired {
+			return &AccountSelectionResult{
+				Account:     acc,
+				Acquired:    true,
+				ReleaseFunc: result.ReleaseFunc,
+			}, nil
+		}
+	}
+
+	cfg := s.schedulingConfig()
+	return &AccountSelectionResult{
+		Account: candidates[0],
+		WaitPlan: &AccountWaitPlan{
+			AccountID:      candidates[0].ID,
+			MaxConcurrency: candidates[0].Concurrency,
+			Timeout:        cfg.FallbackWaitTimeout,
+			MaxWaiting:     cfg.FallbackMaxWaiting,
+		},
+	}, nil
+}
+
+// Forward 转发 embeddings 请求。成功或非 failover 错误时已写出响应；
+// 返回 *UpstreamFailoverError 时由 handler 切换账号重试。
+func (s *EmbeddingService) Forward(ctx context.Context, c *gin.Context, account *Account, req *EmbeddingsRequest, body []byte) (*ForwardResult, error) {
+	switch account.Platform {
+	case PlatformOpenAI:
+		return s.forwardOpenAI(ctx, c, account, req, body)
+	case PlatformGemini:
+		return s.forwardGemini(ctx, c, account, req)
+	default:
+		writeEmbeddingsError(c, http.StatusBadGateway, "api_error", "Unsupported account platform: "+account.Platform)
+		return nil, fmt.Errorf("unsupported embeddings platform: %s", account.Platform)
+	}
+}
+
+// RecordUsage 记录 embeddings 使用量并扣费（仅输入 token）
+func (s *EmbeddingService) RecordUsage(ctx context.Context, input *RecordUsageInput) error {
+	return s.gatewayService.RecordUsage(ctx, input)
+}
+
+func (s *EmbeddingService) forwardOpenAI(ctx context.Context, c *gin.Context, account *Account, req *EmbeddingsRequest, body []byte) (*ForwardResult, error) {
+	startTime := time.Now()
+
+	mappedModel := account.GetMappedModel(req.Model)
+	if mappedModel != req.Model {
+		log.Printf("[Embeddings] Model mapping applied: %s -> %s (account: %s)", req.Model, mappedModel, account.Name)
+		if newBody, err := sjson.SetBytes(body, "model", mappedModel); err == nil {
+			body = newBody
+		}
+	}
+
+	token, _, err := s.openAIGatewayService.GetAccessToken(ctx, account)
+	if err != nil {
+		writeEmbeddingsError(c, http.StatusBadGateway, "api_error", "Failed to get upstream credentials")
+		return nil, err
+	}
+	baseURL, err := s.openAIGatewayService.validateUpstreamBaseURL(account.GetOpenAIBaseURL())
+	if err != nil {
+		writeEmbeddingsError(c, http.StatusBadGateway, "api_error", "Invalid upstream base_url")
+		return nil, err
+	}
+
+	upstreamReq, err := http.NewRequestWithContext(ctx, http.MethodPost, buildOpenAIEmbeddingsURL(baseURL), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	upstreamReq.Header.Set("authorization", "Bearer "+token)
+	upstreamReq.Header.Set("content-type", "application/json")
+	if customUA := account.GetOpenAIUserAgent(); customUA != "" {
+		upstreamReq.Header.Set("user-agent", customUA)
+	}
+
+	if c != nil {
+		c.Set(OpsUpstreamRequestBodyKey, string(body))
+	}
+
+	resp, err := s.doUpstream(c, account, upstreamReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		return s.handleErrorResponse(ctx, c, account, resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	promptTokens, err := parseOpenAIEmbeddingsUsage(respBody)
+	if err != nil {
+		writeEmbeddingsError(c, http.StatusBadGateway, "upstream_error", "Failed to parse upstream response")
+		return nil, err
+	}
+	if mappedModel != req.Model {
+		respBody = s.openAIGatewayService.replaceModelInResponseBody(respBody, mappedModel, req.Model)
+	}
+
+	s.writeResponse(c, resp, respBody)
+
+	return &ForwardResult{
+		RequestID: resp.Header.Get("x-request-id"),
+		Usage:     ClaudeUsage{InputTokens: promptTokens},
+		Model:     req.Model,
+		Duration:  time.Since(startTime),
+	}, nil
+}
+
+func (s *EmbeddingService) forwardGemini(ctx context.Context, c *gin.Context, account *Account, req *EmbeddingsRequest) (*ForwardResult, error) {
+	startTime := time.Now()
+
+	inputs, err := req.TextInputs()
+	if err != nil {
+		writeEmbeddingsError(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return nil, err
+	}
+	mappedModel := strings.TrimPrefix(account.GetMappedModel(req.Model), "models/")
+	payload, err := buildGeminiBatchEmbedRequest(mappedModel, inputs, req.Dimensions)
+	if err != nil {
+		writeEmbeddingsError(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+		return nil, err
+	}
+
+	baseURL, err := s.geminiCompatService.validateUpstreamBaseURL(account.GetGeminiBaseURL(geminicli.AIStudioBaseURL))
+	if err != nil {
+		writeEmbeddingsError(c, http.StatusBadGateway, "api_error", "Invalid upstream base_url")
+		return nil, err
+	}
+	fullURL := fmt.Sprintf("%s/v1beta/models/%s:batchEmbedContents", strings.TrimRight(baseURL, "/"), mappedModel)
+
+	upstreamReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	upstreamReq.Header.Set("Content-Type", "application/json")
+	switch account.Type {
+	case AccountTypeAPIKey:
+		apiKey := strings.TrimSpace(account.GetCredential("api_key"))
+		if apiKey == "" {
+			writeEmbeddingsError(c, http.StatusBadGateway, "api_error", "Failed to get upstream credentials")
+			return nil, errors.New("gemini api_key not configured")
+		}
+		upstreamReq.Header.Set("x-goog-api-key", apiKey)
+	case AccountTypeOAuth:
+		tokenProvider := s.geminiCompatService.GetTokenProvider()
+		if tokenProvider == nil {
+			writeEmbeddingsError(c, http.StatusBadGateway, "api_error", "Failed to get upstream credentials")
+			return nil, errors.New("gemini token provider not configured")
+		}
+		accessToken, err := tokenProvider.GetAccessToken(ctx, account)
+		if err != nil {
+			writeEmbeddingsError(c, http.StatusBadGateway, "api_error", "Failed to get upstream credentials")
+			return nil, err
+		}
+		upstreamReq.Header.Set("Authorization", "Bearer "+accessToken)
+	default:
+		writeEmbeddingsError(c, http.StatusBadGateway, "api_error", "Unsupported account type: "+account.Type)
+		return nil, fmt.Errorf("unsupported account type: %s", account.Type)
+	}
+
+	if c != nil {
+		c.Set(OpsUpstreamRequestBodyKey, string(payload))
+	}
+
+	resp, err := s.doUpstream(c, account, upstreamReq)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		return s.handleErrorResponse(ctx, c, account, resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	// 优先使用上游返回的 token 数；上游未返回时按输入文本估算计费
+	promptTokens := parseGeminiEmbedUsage(respBody)
+	if promptTokens <= 0 {
+		promptTokens = estimateEmbeddingTokens(inputs)
+	}
+	converted, err := convertGeminiBatchEmbedResponse(respBody, req.Model, req.EncodingFormat, promptTokens)
+	if err != nil {
+		writeEmbeddingsError(c, http.StatusBadGateway, "upstream_error", "Failed to parse upstream response")
+		return nil, err
+	}
+
+	if s.cfg != nil {
+		responseheaders.WriteFilteredHeaders(c.Writer.Header(), resp.Header, s.cfg.Security.ResponseHeaders)
+	}
+	c.Data(http.StatusOK, "application/json", converted)
+
+	return &ForwardResult{
+		RequestID: resp.Header.Get("x-request-id"),
+		Usage:     ClaudeUsage{InputTokens: promptTokens},
+		Model:     req.Model,
+		Duration:  time.Since(startTime),
+	}, nil
+}
+
+func (s *EmbeddingService) doUpstream(c *gin.Context, account *Account, req *http.Request) (*http.Response, error) {
+	proxyURL := ""
+	if account.ProxyID != nil && account.Proxy != nil {
+		proxyURL = account.Proxy.URL()
+	}
+	resp, err := s.httpUpstream.Do(req, proxyURL, account.ID, account.Concurrency)
+	if err != nil {
+		safeErr := sanitizeUpstreamErrorMessage(err.Error())
+		setOpsUpstreamError(c, 0, safeErr, "")
+		appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+			Platform:           account.Platform,
+			AccountID:          account.ID,
+			AccountName:        account.Name,
+			UpstreamStatusCode: 0,
+			Kind:               "request_error",
+			Message:            safeErr,
+		})
+		writeEmbeddingsError(c, http.StatusBadGateway, "upstream_error", "Upstream request failed")
+		return nil, fmt.Errorf("upstream request failed: %s", safeErr)
+	}
+	return resp, nil
+}
+
+// handleErrorResponse 处理上游错误：可切换账号的错误返回 UpstreamFailoverError，
+// 其余错误按错误透传规则写出 OpenAI 格式错误（400 类错误保留上游消息，便于调用方修正输入）。
+func (s *EmbeddingService) handleErrorResponse(ctx context.Context, c *gin.Context, account *Account, resp *http.Response) (*ForwardResult, error) {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+
+	upstreamMsg := sanitizeUpstreamErrorMessage(strings.TrimSpace(extractUpstreamErrorMessage(body)))
+	upstreamDetail := ""
+	if s.cfg != nil && s.cfg.Gateway.LogUpstreamErrorBody {
+		maxBytes := s.cfg.Gateway.LogUpstreamErrorBodyMaxBytes
+		if maxBytes <= 0 {
+			maxBytes = 2048
+		}
+		upstreamDetail = truncateString(string(body), maxBytes)
+	}
+	setOpsUpstreamError(c, resp.StatusCode, upstreamMsg, upstreamDetail)
+
+	kind := "http_error"
+	failover := shouldFailoverEmbeddingsError(resp.StatusCode)
+	if failover {
+		kind = "failover"
+	}
+	appendOpsUpstreamError(c, OpsUpstreamErrorEvent{
+		Platform:           account.Platform,
+		AccountID:          account.ID,
+		AccountName:        account.Name,
+		UpstreamStatusCode: resp.StatusCode,
+		UpstreamRequestID:  resp.Header.Get("x-request-id"),
+		Kind:               kind,
+		Message:            upstreamMsg,
+		Detail:             upstreamDetail,
+	})
+
+	if failover {
+		switch account.Platform {
+		case PlatformGemini:
+			s.geminiCompatService.handleGeminiUpstreamError(ctx, account, resp.StatusCode, resp.Header, body)
+		default:
+			if s.rateLimitService != nil {
+				s.rateLimitService.HandleUpstreamError(ctx, account, resp.StatusCode, resp.Header, body)
+			}
+		}
+		return nil, &UpstreamFailoverError{StatusCode: resp.StatusCode, ResponseBody: body}
+	}
+
+	defaultStatus, defaultType, defaultMsg := http.StatusBadGateway, "upstream_error", "Upstream request failed"
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusUnprocessableEntity {
+		defaultStatus, defaultType = http.StatusBadRequest, "invalid_request_error"
+		if upstreamMsg != "" {
+			defaultMsg = upstreamMsg
+		}
+	}
+	status, errType, errMsg, _ := applyErrorPassthroughRule(c, account.Platform, resp.StatusCode, body, defaultStatus, defaultType, defaultMsg)
+	writeEmbeddingsError(c, status, errType, errMsg)
+
+	if upstreamMsg == "" {
+		return nil, fmt.Errorf("upstream error: %d", resp.StatusCode)
+	}
+	return nil, fmt.Errorf("upstream error: %d message=%s", resp.StatusCode, upstreamMsg)
+}
+
+func (s *EmbeddingService) writeResponse(c *gin.Context, resp *http.Response, body []byte) {
+	contentType := "application/json"
+	if s.cfg != nil {
+		responseheaders.WriteFilteredHeaders(c.Writer.Header(), resp.Header, s.cfg.Security.ResponseHeaders)
+		if !s.cfg.Security.ResponseHeaders.Enabled {
+			if upstreamType := resp.Header.Get("Content-Type"); upstreamType != "" {
+				contentType = upstreamType
+			}
+		}
+	}
+	c.Data(resp.StatusCode, contentType, body)
+}
+
+func (s *EmbeddingService) listSchedulableAccounts(ctx context.Context, groupID *int64, platform string) ([]Account, error) {
+	if s.schedulerSnapshot != nil {
+		accounts, _, err := s.schedulerSnapshot.ListSchedulableAccounts(ctx, groupID, platform, true)
+		return accounts, err
+	}
+	var accounts []Account
+	var err error
+	if s.cfg != nil && s.cfg.RunMode == config.RunModeSimple {
+		accounts, err = s.accountRepo.ListSchedulableByPlatform(ctx, platform)
+	} else if groupID != nil {
+		accounts, err = s.accountRepo.ListSchedulableByGroupIDAndPlatform(ctx, *groupID, platform)
+	} else {
+		accounts, err = s.accountRepo.ListSchedulableByPlatform(ctx, platform)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("query accounts failed: %w", err)
+	}
+	return accounts, nil
+}
+
+func (s *EmbeddingService) tryAcquireAccountSlot(ctx context.Context, accountID int64, maxConcurrency int) (*AcquireResult, error) {
+	if s.concurrencyService == nil {
+		return &AcquireResult{Acquired: true, ReleaseFunc: func() {}}, nil
+	}
+	return s.concurrencyService.AcquireAccountSlot(ctx, accountID, maxConcurrency)
+}
+
+func (s *EmbeddingService) schedulingConfig() config.GatewaySchedulingConfig {
+	if s.cfg != nil {
+		return s.cfg.Gateway.Scheduling
+	}
+	return config.GatewaySchedulingConfig{
+		FallbackWaitTimeout: 30 * time.Second,
+		FallbackMaxWaiting:  100,
+	}
+}
+
+// isEmbeddingCapableAccount 判断账号是否能调用 embeddings 上游
+func isEmbeddingCapableAccount(account *Account, platform string) bool {
+	if account == nil || account.Platform != platform || !account.IsSchedulable() {
+		return false
+	}
+	switch platform {
+	case PlatformOpenAI:
+		return account.Type == AccountTypeAPIKey
+	case PlatformGemini:
+		if account.Type == AccountTypeAPIKey {
+			return true
+		}
+		return account.Type == AccountTypeOAuth && !account.IsGeminiCodeAssist()
+	default:
+		return false
+	}
+}
+
+// isEmbeddingModelSupportedByAccount 与文本网关一致：Gemini API Key 账号透传模型，其余按账号模型映射校验
+func isEmbeddingModelSupportedByAccount(account *Account, model string) bool {
+	if account.Platform == PlatformGemini && account.Type == AccountTypeAPIKey {
+		return true
+	}
+	return account.IsModelSupported(model)
+}
+
+// filterEmbeddingRoutedAccounts 按路由配置的顺序返回候选账号中命中路由的账号
+func filterEmbeddingRoutedAccounts(candidates []*Account, routedIDs []int64) []*Account {
+	if len(routedIDs) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*Account, len(candidates))
+	for _, acc := range candidates {
+		byID[acc.ID] = acc
+	}
+	routed := make([]*Account, 0, len(routedIDs))
+	for _, id := range routedIDs {
+		if acc, ok := byID[id]; ok {
+			routed = append(routed, acc)
+		}
+	}
+	return routed
+}
+
+func shouldFailoverEmbeddingsError(statusCode int) bool {
+	switch statusCode {
+	case 401, 402, 403, 429, 529:
+		return true
+	default:
+		return statusCode >= 500
+	}
+}
+
+func writeEmbeddingsError(c *gin.Context, status int, errType, message string) {
+	if c == nil {
+		return
+	}
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"type":    errType,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// OpenAI Embeddings 兼容层
+//
+// /v1/embeddings 请求按分组平台调度：
+//   - OpenAI 分组：仅调度 API Key 账号，请求体原样透传到上游 /v1/embeddings；
+//   - Gemini 分组：调度 AI Studio 账号（API Key / AI Studio OAuth），转换为 batchEmbedContents，
+//     响应再转换回 OpenAI embedding list 格式。
+
+const (
+	embeddingsListObject       = "list"
+	embeddingsObject           = "embedding"
+	embeddingsEncodingFloat    = "float"
+	embeddingsEncodingBase64   = "base64"
+	embeddingsMaxGeminiBatch   = 100
+	embeddingsGeminiModelScope = "gemini_text"
+)
+
+// EmbeddingsRequest 是 Embeddings 请求中网关关心的字段子集
+type EmbeddingsRequest struct {
+	Model          string          `json:"model"`
+	Input          json.RawMessage `json:"input"`
+	EncodingFormat string          `json:"encoding_format,omitempty"`
+	Dimensions     *int            `json:"dimensions,omitempty"`
+	User           string          `json:"user,omitempty"`
+}
+
+// ParseEmbeddingsRequest 解析 Embeddings 请求体
+func ParseEmbeddingsRequest(body []byte) (*EmbeddingsRequest, error) {
+	var req EmbeddingsRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	req.Model = strings.TrimSpace(req.Model)
+	if req.Model == "" {
+		return nil, errors.New("model is required")
+	}
+	trimmed := bytes.TrimSpace(req.Input)
+	if len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")) {
+		return nil, errors.New("input is required")
+	}
+	switch req.EncodingFormat {
+	case "", embeddingsEncodingFloat, embeddingsEncodingBase64:
+	default:
+		return nil, fmt.Errorf("unsupported encoding_format: %s", req.EncodingFormat)
+	}
+	if req.Dimensions != nil && *req.Dimensions <= 0 {
+		return nil, errors.New("dimensions must be a positive integer")
+	}
+	return &req, nil
+}
+
+// TextInputs 返回文本形式的输入列表（string 或 string 数组）。
+// OpenAI 允许传入 token 数组，但 Gemini 只接受文本，此时返回错误。
+func (r *EmbeddingsRequest) TextInputs() ([]string, error) {
+	var single string
+	if err := json.Unmarshal(r.Input, &single); err == nil {
+		return []string{single}, nil
+	}
+	var list []string
+	if err := json.Unmarshal(r.Input, &list); err == nil {
+		if len(list) == 0 {
+			return nil, errors.New("input must not be empty")
+		}
+		return list, nil
+	}
+	return nil, errors.New("input must be a string or an array of strings for this model")
+}
+
+// EmbeddingModelScope 返回 embeddings 请求在分组 supported_model_scopes 中对应的模型系列。
+// 目前仅 Gemini 平台存在对应系列（gemini_text）；OpenAI 平台没有模型系列概念，返回空表示不限制。
+func EmbeddingModelScope(platform string) string {
+	if platform == PlatformGemini {
+		return embeddingsGeminiModelScope
+	}
+	return ""
+}
+
+// InferEmbeddingPlatform 在没有分组（简易模式）时根据模型名推断上游平台
+func InferEmbeddingPlatform(model string) string {
+	m := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(model), "models/"))
+	if strings.HasPrefix(m, "gemini-") || strings.HasPrefix(m, "text-embedding-00") || strings.HasPrefix(m, "embedding-") {
+		return PlatformGemini
+	}
+	return PlatformOpenAI
+}
+
+type geminiEmbedRequest struct {
+	Model                string             `json:"model"`
+	Content              geminiEmbedContent `json:"content"`
+	OutputDimensionality *int               `json:"outputDimensionality,omitempty"`
+}
+
+type geminiEmbedContent struct {
+	Parts []geminiEmbedPart `json:"parts"`
+}
+
+type geminiEmbedPart struct {
+	Text string `json:"text"`
+}
+
+// buildGeminiBatchEmbedRequest 将 OpenAI embeddings 输入转换为 Gemini batchEmbedContents 请求体
+func buildGeminiBatchEmbedRequest(model string, inputs []string, dimensions *int) ([]byte, error) {
+	if len(inputs) > embeddingsMaxGeminiBatch {
+		return nil, fmt.Errorf("too many inputs: %d (max %d)", len(inputs), embeddingsMaxGeminiBatch)
+	}
+	modelName := "models/" + strings.TrimPrefix(model, "models/")
+	requests := make([]geminiEmbedRequest, 0, len(inputs))
+	for _, text := range inputs {
+		requests = append(requests, geminiEmbedRequest{
+			Model:                modelName,
+			Content:              geminiEmbedContent{Parts: []geminiEmbedPart{{Text: text}}},
+			OutputDimensionality: dimensions,
+		})
+	}
+	return json.Marshal(map[string]any{"requests": requests})
+}
+
+// convertGeminiBatchEmbedResponse 将 Gemini batchEmbedContents 响应转换为 OpenAI embedding list
+func convertGeminiBatchEmbedResponse(body []byte, model, encodingFormat string, promptTokens int) ([]byte, error) {
+	var resp struct {
+		Embeddings []struct {
+			Values []float64 `json:"values"`
+		} `json:"embeddings"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse gemini embeddings response: %w", err)
+	}
+
+	data := make([]map[string]any, 0, len(resp.Embeddings))
+	for i, e := range resp.Embeddings {
+		var embedding any = e.Values
+		if encodingFormat == embeddingsEncodingBase64 {
+			embedding = encodeEmbeddingBase64(e.Values)
+		} else if e.Values == nil {
+			embedding = []float64{}
+		}
+		data = append(data, map[string]any{
+			"object":    embeddingsObject,
+			"index":     i,
+			"embedding": embedding,
+		})
+	}
+
+	return json.Marshal(map[string]any{
+		"object": embeddingsListObject,
+		"data":   data,
+		"model":  model,
+		"usage": map[string]int{
+			"prompt_tokens": promptTokens,
+			"total_tokens":  promptTokens,
+		},
+	})
+}
+
+// encodeEmbeddingBase64 按 OpenAI 约定将向量编码为 little-endian float32 的 base64
+func encodeEmbeddingBase64(values []float64) string {
+	buf := make([]byte, 4*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(buf[i*4:], math.Float32bits(float32(v)))
+	}
+	return base64.StdEncoding.EncodeToString(buf)
+}
+
+// parseGeminiEmbedUsage 提取 Gemini embeddings 响应中上游返回的输入 token 数（usageMetadata.promptTokenCount），
+// 未返回时为 0
+func parseGeminiEmbedUsage(body []byte) int {
+	var resp struct {
+		UsageMetadata *struct {
+			PromptTokenCount int `json:"promptTokenCount"`
+		} `json:"usageMetadata"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.UsageMetadata == nil {
+		return 0
+	}
+	return resp.UsageMetadata.PromptTokenCount
+}
+
+// estimateEmbeddingTokens 估算 embeddings 输入 token 数，仅在上游响应未返回 usage 时用于计费
+// （AI Studio batchEmbedContents 目前不返回 usage）
+func estimateEmbeddingTokens(inputs []string) int {
+	total := 0
+	for _, text := range inputs {
+		total += estimateTokensForText(text)
+	}
+	return total
+}
+
+// parseOpenAIEmbeddingsUsage 提取 OpenAI embeddings 响应中的 prompt_tokens
+func parseOpenAIEmbeddingsUsage(body []byte) (int, error) {
+	var resp struct {
+		Usage struct {
+			PromptTokens int `json:"prompt_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return 0, fmt.Errorf("parse response: %w", err)
+	}
+	return resp.Usage.PromptTokens, nil
+}
+
+// buildOpenAIEmbeddingsURL 基于账号 base_url 构建 embeddings 地址（兼容是否带 /v1 后缀）
+func buildOpenAIEmbeddingsURL(baseURL string) string {
+	base := strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if strings.HasSuffix(base, "/v1") {
+		return base + "/embeddings"
+	}
+	return base + "/v1/embeddings"
+}
@@ -0,0 +1,226 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEmbeddingsRequest(t *testing.T) {
+	req, err := ParseEmbeddingsRequest([]byte(`{"model":" text-embedding-3-small ","input":["a","b"],"encoding_format":"base64","dimensions":256}`))
+	require.NoError(t, err)
+	require.Equal(t, "text-embedding-3-small", req.Model)
+	require.Equal(t, "base64", req.EncodingFormat)
+	require.Equal(t, 256, *req.Dimensions)
+
+	inputs, err := req.TextInputs()
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, inputs)
+
+	_, err = ParseEmbeddingsRequest([]byte(`{"input":"x"}`))
+	require.ErrorContains(t, err, "model is required")
+	_, err = ParseEmbeddingsRequest([]byte(`{"model":"m"}`))
+	require.ErrorContains(t, err, "input is required")
+	_, err = ParseEmbeddingsRequest([]byte(`{"model":"m","input":"x","encoding_format":"int8"}`))
+	require.ErrorContains(t, err, "encoding_format")
+	_, err = ParseEmbeddingsRequest([]byte(`{"model":"m","input":"x","dimensions":0}`))
+	require.ErrorContains(t, err, "dimensions")
+}
+
+func TestEmbeddingsRequestTextInputs(t *testing.T) {
+	req := &EmbeddingsRequest{Input: json.RawMessage(`"hello"`)}
+	inputs, err := req.TextInputs()
+	require.NoError(t, err)
+	require.Equal(t, []string{"hello"}, inputs)
+
+	req = &EmbeddingsRequest{Input: json.RawMessage(`[1,2,3]`)}
+	_, err = req.TextInputs()
+	require.Error(t, err)
+
+	req = &EmbeddingsRequest{Input: json.RawMessage(`[]`)}
+	_, err = req.TextInputs()
+	require.Error(t, err)
+}
+
+func TestBuildGeminiBatchEmbedRequest(t *testing.T) {
+	dims := 128
+	body, err := buildGeminiBatchEmbedRequest("text-embedding-004", []string{"a", "b"}, &dims)
+	require.NoError(t, err)
+
+	var got struct {
+		Requests []struct {
+			Model   string `json:"model"`
+			Content struct {
+				Parts []struct {
+					Text string `json:"text"`
+				} `json:"parts"`
+			} `json:"content"`
+			OutputDimensionality int `json:"outputDimensionality"`
+		} `json:"requests"`
+	}
+	require.NoError(t, json.Unmarshal(body, &got))
+	require.Len(t, got.Requests, 2)
+	require.Equal(t, "models/text-embedding-004", got.Requests[0].Model)
+	require.Equal(t, "b", got.Requests[1].Content.Parts[0].Text)
+	require.Equal(t, 128, got.Requests[1].OutputDimensionality)
+
+	_, err = buildGeminiBatchEmbedRequest("m", make([]string, embeddingsMaxGeminiBatch+1), nil)
+	require.Error(t, err)
+}
+
+func TestConvertGeminiBatchEmbedResponse(t *testing.T) {
+	upstream := []byte(`{"embeddings":[{"values":[0.5,-1]},{"values":[0.25]}]}`)
+
+	out, err := convertGeminiBatchEmbedResponse(upstream, "gemini-embedding-001", "", 7)
+	require.NoError(t, err)
+	var resp struct {
+		Object string `json:"object"`
+		Model  string `json:"model"`
+		Data   []struct {
+			Object    string    `json:"object"`
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+		Usage struct {
+			PromptTokens int `json:"prompt_tokens"`
+			TotalTokens  int `json:"total_tokens"`
+		} `json:"usage"`
+	}
+	require.NoError(t, json.Unmarshal(out, &resp))
+	require.Equal(t, "list", resp.Object)
+	require.Equal(t, "gemini-embedding-001", resp.Model)
+	require.Len(t, resp.Data, 2)
+	require.Equal(t, 1, resp.Data[1].Index)
+	require.Equal(t, []float64{0.5, -1}, resp.Data[0].Embedding)
+	require.Equal(t, 7, resp.Usage.PromptTokens)
+	require.Equal(t, 7, resp.Usage.TotalTokens)
+
+	out, err = convertGeminiBatchEmbedResponse(upstream, "gemini-embedding-001", "base64", 7)
+	require.NoError(t, err)
+	var b64 struct {
+		Data []struct {
+			Embedding string `json:"embedding"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(out, &b64))
+	raw, err := base64.StdEncoding.DecodeString(b64.Data[0].Embedding)
+	require.NoError(t, err)
+	require.Len(t, raw, 8)
+	require.Equal(t, float32(0.5), math.Float32frombits(binary.LittleEndian.Uint32(raw[0:4])))
+	require.Equal(t, float32(-1), math.Float32frombits(binary.LittleEndian.Uint32(raw[4:8])))
+}
+
+func TestParseGeminiEmbedUsage(t *testing.T) {
+	require.Equal(t, 0, parseGeminiEmbedUsage([]byte(`{"embeddings":[{"values":[0.5]}]}`)))
+	require.Equal(t, 12, parseGeminiEmbedUsage([]byte(`{"embeddings":[{"values":[0.5]}],"usageMetadata":{"promptTokenCount":12}}`)))
+	require.Equal(t, 0, parseGeminiEmbedUsage([]byte(`not json`)))
+}
+
+func TestBuildOpenAIEmbeddingsURL(t *testing.T) {
+	require.Equal(t, "https://api.openai.com/v1/embeddings", buildOpenAIEmbeddingsURL("https://api.openai.com"))
+	require.Equal(t, "https://proxy.example.com/v1/embeddings", buildOpenAIEmbeddingsURL("https://proxy.example.com/v1/"))
+}
+
+func TestEmbeddingServiceResolvePlatform(t *testing.T) {
+	svc := &EmbeddingService{}
+
+	platform, err := svc.ResolvePlatform(nil, "text-embedding-004")
+	require.NoError(t, err)
+	require.Equal(t, PlatformGemini, platform)
+	platform, err = svc.ResolvePlatform(nil, "text-embedding-3-small")
+	require.NoError(t, err)
+	require.Equal(t, PlatformOpenAI, platform)
+
+	_, err = svc.ResolvePlatform(&Group{Platform: PlatformAnthropic}, "text-embedding-3-small")
+	require.ErrorIs(t, err, ErrEmbeddingsPlatformUnsupported)
+
+	_, err = svc.ResolvePlatform(&Group{Platform: PlatformGemini, SupportedModelScopes: []string{"claude"}}, "gemini-embedding-001")
+	require.ErrorIs(t, err, ErrEmbeddingsScopeNotAllowed)
+
+	platform, err = svc.ResolvePlatform(&Group{Platform: PlatformGemini, SupportedModelScopes: []string{"gemini_text"}}, "gemini-embedding-001")
+	require.NoError(t, err)
+	require.Equal(t, PlatformGemini, platform)
+
+	// OpenAI 分组没有对应的模型系列，不受 supported_model_scopes 限制
+	platform, err = svc.ResolvePlatform(&Group{Platform: PlatformOpenAI, SupportedModelScopes: []string{"claude"}}, "text-embedding-3-small")
+	require.NoError(t, err)
+	require.Equal(t, PlatformOpenAI, platform)
+}
+
+func TestEmbeddingServiceSelectAccount(t *testing.T) {
+	ctx := context.Background()
+	groupID := int64(1)
+	repo := &mockAccountRepoForPlatform{
+		accounts: []Account{
+			{ID: 1, Platform: PlatformOpenAI, Type: AccountTypeOAuth, Status: StatusActive, Schedulable: true, Priority: 0},
+			{ID: 2, Platform: PlatformOpenAI, Type: AccountTypeAPIKey, Status: StatusActive, Schedulable: true, Priority: 2},
+			{ID: 3, Platform: PlatformOpenAI, Type: AccountTypeAPIKey, Status: StatusActive, Schedulable: true, Priority: 1},
+			{ID: 4, Platform: PlatformOpenAI, Type: AccountTypeAPIKey, Status: StatusActive, Schedulable: true, Priority: 0,
+				Credentials: map[string]any{"model_mapping": map[string]any{"gpt-4o": "gpt-4o"}}},
+		},
+	}
+	svc := &EmbeddingService{accountRepo: repo}
+
+	// OAuth 账号与不支持该模型的账号被过滤，按优先级选择
+	selection, err := svc.SelectAccount(ctx, nil, &groupID, PlatformOpenAI, "text-embedding-3-small", nil)
+	require.NoError(t, err)
+	require.True(t, selection.Acquired)
+	require.Equal(t, int64(3), selection.Account.ID)
+
+	// 排除列表生效
+	selection, err = svc.SelectAccount(ctx, nil, &groupID, PlatformOpenAI, "text-embedding-3-small", map[int64]struct{}{3: {}})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), selection.Account.ID)
+
+	// 模型路由优先于优先级
+	group := &Group{
+		Platform:            PlatformOpenAI,
+		ModelRoutingEnabled: true,
+		ModelRouting:        map[string][]int64{"text-embedding-*": {2}},
+	}
+	selection, err = svc.SelectAccount(ctx, group, &groupID, PlatformOpenAI, "text-embedding-3-small", nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), selection.Account.ID)
+
+	// 路由账号不可用时回退到普通选择
+	selection, err = svc.SelectAccount(ctx, group, &groupID, PlatformOpenAI, "text-embedding-3-small", map[int64]struct{}{2: {}})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), selection.Account.ID)
+
+	_, err = svc.SelectAccount(ctx, nil, &groupID, PlatformOpenAI, "text-embedding-3-small", map[int64]struct{}{2: {}, 3: {}})
+	require.Error(t, err)
+}
+
+func TestIsEmbeddingCapableAccount(t *testing.T) {
+	active := func(a Account) *Account {
+		a.Status = StatusActive
+		a.Schedulable = true
+		return &a
+	}
+	require.True(t, isEmbeddingCapableAccount(active(Account{Platform: PlatformGemini, Type: AccountTypeAPIKey}), PlatformGemini))
+	require.True(t, isEmbeddingCapableAccount(active(Account{Platform: PlatformGemini, Type: AccountTypeOAuth,
+		Credentials: map[string]any{"oauth_type": "ai_studio"}}), PlatformGemini))
+	require.False(t, isEmbeddingCapableAccount(active(Account{Platform: PlatformGemini, Type: AccountTypeOAuth,
+		Credentials: map[string]any{"project_id": "p"}}), PlatformGemini))
+	require.False(t, isEmbeddingCapableAccount(active(Account{Platform: PlatformOpenAI, Type: AccountTypeOAuth}), PlatformOpenAI))
+	require.False(t, isEmbeddingCapableAccount(active(Account{Platform: PlatformAntigravity, Type: AccountTypeOAuth}), PlatformGemini))
+}
+
+func TestBillingServiceEmbeddingFallbackPricing(t *testing.T) {
+	svc := NewBillingService(nil, nil)
+
+	cost, err := svc.CalculateCost("text-embedding-3-small", UsageTokens{InputTokens: 1_000_000}, 1)
+	require.NoError(t, err)
+	require.InDelta(t, 0.02, cost.TotalCost, 1e-9)
+
+	cost, err = svc.CalculateCost("gemini-embedding-001", UsageTokens{InputTokens: 1_000_000}, 1)
+	require.NoError(t, err)
+	require.InDelta(t, 0.15, cost.TotalCost, 1e-9)
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+)
+
+// 账号槽位公平排队（Weighted Fair Queueing）
+//
+// 分组内账号全部满载时，请求原本通过退避轮询争抢账号槽位，谁重试得巧谁先拿到，
+// 单个用户的大量并发请求会饿死其他用户。启用后：
+//   - 等待中的请求在目标账号的 Redis 队列中登记 ticket，按“流”（用户或 API Key）计算
+//     SFQ 起始标签：start = max(账号虚拟时间, 该流上一个 ticket 的结束标签)，finish = start + 1000/weight
+//   - 队列按 start 排序，只有排名 < 空闲槽位数的 ticket 才能获取槽位（原子 Lua 脚本），
+//     新到达的请求在队列非空时也必须把空闲槽位让给排队者
+//   - 获取成功后账号虚拟时间推进到该 ticket 的 start；超时或断开时撤销 ticket 并退还流的标签
+//
+// 队列按账号而非分组划分：
+//   - 槽位是账号维度的，且账号可以同时属于多个分组，分组队列无法对来自不同分组、争抢同一账号槽位的请求排序；
+//   - 等待请求在调度时已绑定到具体账号（WaitPlan），只会等待该账号的槽位；
+//   - 同一分组内的每个账号队列都按流公平分配，单个流在分组内的排队请求无论落到哪个账号都只占自己的份额，
+//     分组整体仍然不会被单个用户饿死。
+//
+// 队列状态全部存储在 Redis，多实例共享；ticket 元数据记录 groupID，
+// 分组维度的排队统计由运维实时并发接口按分组内账号汇总。
+
+const (
+	FairQueueKeyUser   = "user"
+	FairQueueKeyAPIKey = "api_key"
+
+	FairQueueWeightNone            = "none"
+	FairQueueWeightUserConcurrency = "user_concurrency"
+)
+
+// FairQueueTicket 表示一个在账号队列中等待的请求
+type FairQueueTicket struct {
+	ID        string
+	AccountID int64
+	GroupID   int64
+	Flow      string
+	Weight    int
+
+	// SFQ 标签（由 Redis 脚本计算，撤销时用于退还流的标签）
+	StartTag  string
+	FinishTag string
+}
+
+// FairQueueAcquireResult 一次公平队列获取尝试的结果
+type FairQueueAcquireResult struct {
+	Acquired bool
+	// Position 在账号队列中的位置（0 表示队首）
+	Position int
+	// Lost 表示 ticket 已不在队列中（如长时间未心跳被清理），调用方应重新入队
+	Lost bool
+}
+
+// FairQueueEntry 队列快照中的单个等待请求（运维展示）
+type FairQueueEntry struct {
+	AccountID int64  `json:"account_id"`
+	GroupID   int64  `json:"group_id"`
+	Flow      string `json:"flow"`
+	Weight    int    `json:"weight"`
+	Position  int    `json:"position"`
+	WaitMs    int64  `json:"wait_ms"`
+}
+
+// FairQueueAccountStats 账号队列统计
+type FairQueueAccountStats struct {
+	AccountID int64 `json:"account_id"`
+	Waiting   int   `json:"waiting"`
+	// MaxWaitMs 当前排队请求中最长的已等待时间
+	MaxWaitMs int64 `json:"max_wait_ms"`
+	// 最近窗口内通过队列获取槽位的请求数与等待耗时
+	Granted       int64 `json:"granted"`
+	AvgGrantWait  int64 `json:"avg_grant_wait_ms"`
+	MaxGrantWait  int64 `json:"max_grant_wait_ms"`
+	FlowsQueueing int   `json:"flows_queueing"`
+}
+
+// FairQueueCache 公平队列的 Redis 存储
+type FairQueueCache interface {
+	// Enqueue 登记 ticket，填充 StartTag / FinishTag
+	Enqueue(ctx context.Context, ticket *FairQueueTicket) error
+	// TryAcquire 在 ticket 排名小于空闲槽位数时原子获取账号槽位（与 ConcurrencyCache 共用槽位键）
+	TryAcquire(ctx context.Context, ticket *FairQueueTicket, maxConcurrency int, requestID string) (*FairQueueAcquireResult, error)
+	// Cancel 撤销 ticket 并退还流的标签
+	Cancel(ctx context.Context, ticket *FairQueueTicket) error
+	// AcquireAccountSlotYielding 非排队请求获取账号槽位：仅当空闲槽位多于排队请求数时成功
+	AcquireAccountSlotYielding(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error)
+
+	GetAccountStatsBatch(ctx context.Context, accountIDs []int64) (map[int64]*FairQueueAccountStats, error)
+	ListAccountEntries(ctx context.Context, accountID int64) ([]*FairQueueEntry, error)
+}
+
+// FairQueueService 管理账号槽位的公平排队
+type FairQueueService struct {
+	cache FairQueueCache
+	cfg   config.GatewayFairQueueConfig
+}
+
+// NewFairQueueService creates a new FairQueueService
+func NewFairQueueService(cache FairQueueCache, cfg *config.Config) *FairQueueService {
+	s := &FairQueueService{cache: cache}
+	if cfg != nil {
+		s.cfg = cfg.Gateway.Scheduling.FairQueue
+	}
+	return s
+}
+
+// Enabled 判断是否启用公平排队
+func (s *FairQueueService) Enabled() bool {
+	return s != nil && s.cache != nil && s.cfg.Enabled
+}
+
+// FlowFor 返回请求所属的流与权重
+func (s *FairQueueService) FlowFor(apiKey *APIKey) (string, int) {
+	if apiKey == nil {
+		return "anonymous", 1
+	}
+	flow := "user:" + strconv.FormatInt(apiKey.UserID, 10)
+	if strings.EqualFold(s.cfg.Key, FairQueueKeyAPIKey) {
+		flow = "key:" + strconv.FormatInt(apiKey.ID, 10)
+	}
+
+	weight := 1
+	if strings.EqualFold(s.cfg.WeightSource, FairQueueWeightUserConcurrency) && apiKey.User != nil {
+		weight = apiKey.User.Concurrency
+	}
+	if weight < 1 {
+		weight = 1
+	}
+	if s.cfg.MaxWeight > 0 && weight > s.cfg.MaxWeight {
+		weight = s.cfg.MaxWeight
+	}
+	return flow, weight
+}
+
+// Enqueue 为等待账号槽位的请求登记 ticket
+func (s *FairQueueService) Enqueue(ctx context.Context, accountID int64, apiKey *APIKey) (*FairQueueTicket, error) {
+	flow, weight := s.FlowFor(apiKey)
+	ticket := &FairQueueTicket{
+		ID:        generateRequestID(),
+		AccountID: accountID,
+		Flow:      flow,
+		Weight:    weight,
+	}
+	if apiKey != nil && apiKey.GroupID != nil {
+		ticket.GroupID = *apiKey.GroupID
+	}
+	if err := s.cache.Enqueue(ctx, ticket); err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// Cancel 撤销 ticket（best-effort，使用独立上下文，避免请求取消后无法清理）
+func (s *FairQueueService) Cancel(ticket *FairQueueTicket) {
+	if s == nil || ticket == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.cache.Cancel(ctx, ticket); err != nil {
+		log.Printf("Warning: failed to cancel fair queue ticket for account %d: %v", ticket.AccountID, err)
+	}
+}
+
+// GetAccountStatsBatch 批量查询账号队列统计
+func (s *FairQueueService) GetAccountStatsBatch(ctx context.Context, accountIDs []int64) (map[int64]*FairQueueAccountStats, error) {
+	if !s.Enabled() || len(accountIDs) == 0 {
+		return map[int64]*FairQueueAccountStats{}, nil
+	}
+	return s.cache.GetAccountStatsBatch(ctx, accountIDs)
+}
+
+// ListAccountEntries 返回账号队列中的等待请求（按位置排序）
+func (s *FairQueueService) ListAccountEntries(ctx context.Context, accountID int64) ([]*FairQueueEntry, error) {
+	if !s.Enabled() {
+		return []*FairQueueEntry{}, nil
+	}
+	return s.cache.ListAccountEntries(ctx, accountID)
+}
@@ -0,0 +1,152 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type fairQueueCacheStub struct {
+	FairQueueCache
+	yielding    bool
+	tryResults  []*FairQueueAcquireResult
+	enqueued    []*FairQueueTicket
+	canceled    []*FairQueueTicket
+	yieldCalls  int
+	tryRequests []string
+}
+
+func (c *fairQueueCacheStub) Enqueue(ctx context.Context, ticket *FairQueueTicket) error {
+	ticket.StartTag, ticket.FinishTag = "0", "1000"
+	c.enqueued = append(c.enqueued, ticket)
+	return nil
+}
+
+func (c *fairQueueCacheStub) TryAcquire(ctx context.Context, ticket *FairQueueTicket, maxConcurrency int, requestID string) (*FairQueueAcquireResult, error) {
+	c.tryRequests = append(c.tryRequests, requestID)
+	if len(c.tryResults) == 0 {
+		return &FairQueueAcquireResult{}, nil
+	}
+	result := c.tryResults[0]
+	c.tryResults = c.tryResults[1:]
+	return result, nil
+}
+
+func (c *fairQueueCacheStub) Cancel(ctx context.Context, ticket *FairQueueTicket) error {
+	c.canceled = append(c.canceled, ticket)
+	return nil
+}
+
+func (c *fairQueueCacheStub) AcquireAccountSlotYielding(ctx context.Context, accountID int64, maxConcurrency int, requestID string) (bool, error) {
+	c.yieldCalls++
+	return c.yielding, nil
+}
+
+func newFairQueueTestConfig(key, weightSource string, maxWeight int) *config.Config {
+	cfg := &config.Config{}
+	cfg.Gateway.Scheduling.FairQueue = config.GatewayFairQueueConfig{
+		Enabled:      true,
+		Key:          key,
+		WeightSource: weightSource,
+		MaxWeight:    maxWeight,
+	}
+	return cfg
+}
+
+func TestFairQueueService_FlowFor(t *testing.T) {
+	groupID := int64(9)
+	apiKey := &APIKey{ID: 7, UserID: 3, GroupID: &groupID, User: &User{ID: 3, Concurrency: 40}}
+
+	svc := NewFairQueueService(&fairQueueCacheStub{}, newFairQueueTestConfig(FairQueueKeyUser, FairQueueWeightNone, 16))
+	flow, weight := svc.FlowFor(apiKey)
+	require.Equal(t, "user:3", flow)
+	require.Equal(t, 1, weight)
+
+	svc = NewFairQueueService(&fairQueueCacheStub{}, newFairQueueTestConfig(FairQueueKeyAPIKey, FairQueueWeightUserConcurrency, 16))
+	flow, weight = svc.FlowFor(apiKey)
+	require.Equal(t, "key:7", flow)
+	require.Equal(t, 16, weight, "weight is clamped to max_weight")
+
+	apiKey.User.Concurrency = 0
+	_, weight = svc.FlowFor(apiKey)
+	require.Equal(t, 1, weight)
+
+	flow, weight = svc.FlowFor(nil)
+	require.Equal(t, "anonymous", flow)
+	require.Equal(t, 1, weight)
+}
+
+func TestFairQueueService_EnqueueAndCancel(t *testing.T) {
+	cache := &fairQueueCacheStub{}
+	svc := NewFairQueueService(cache, newFairQueueTestConfig(FairQueueKeyUser, FairQueueWeightNone, 0))
+	groupID := int64(5)
+
+	ticket, err := svc.Enqueue(context.Background(), 11, &APIKey{ID: 1, UserID: 2, GroupID: &groupID})
+	require.NoError(t, err)
+	require.Equal(t, int64(11), ticket.AccountID)
+	require.Equal(t, int64(5), ticket.GroupID)
+	require.Equal(t, "user:2", ticket.Flow)
+	require.NotEmpty(t, ticket.ID)
+	require.Equal(t, "1000", ticket.FinishTag)
+
+	svc.Cancel(ticket)
+	require.Len(t, cache.canceled, 1)
+}
+
+func TestFairQueueService_Disabled(t *testing.T) {
+	cfg := newFairQueueTestConfig(FairQueueKeyUser, FairQueueWeightNone, 0)
+	cfg.Gateway.Scheduling.FairQueue.Enabled = false
+	svc := NewFairQueueService(&fairQueueCacheStub{}, cfg)
+	require.False(t, svc.Enabled())
+
+	concurrency := NewConcurrencyService(stubConcurrencyCache{})
+	concurrency.SetFairQueue(svc)
+	require.Nil(t, concurrency.FairQueue())
+
+	var nilSvc *FairQueueService
+	require.False(t, nilSvc.Enabled())
+}
+
+func TestConcurrencyService_AcquireAccountSlotYieldsToQueue(t *testing.T) {
+	cache := &fairQueueCacheStub{yielding: false}
+	concurrency := NewConcurrencyService(stubConcurrencyCache{})
+	concurrency.SetFairQueue(NewFairQueueService(cache, newFairQueueTestConfig(FairQueueKeyUser, FairQueueWeightNone, 0)))
+
+	result, err := concurrency.AcquireAccountSlot(context.Background(), 1, 2)
+	require.NoError(t, err)
+	require.False(t, result.Acquired, "free slots are reserved for queued requests")
+	require.Equal(t, 1, cache.yieldCalls)
+
+	cache.yielding = true
+	result, err = concurrency.AcquireAccountSlot(context.Background(), 1, 2)
+	require.NoError(t, err)
+	require.True(t, result.Acquired)
+	require.NotNil(t, result.ReleaseFunc)
+}
+
+func TestConcurrencyService_AcquireAccountSlotFair(t *testing.T) {
+	cache := &fairQueueCacheStub{tryResults: []*FairQueueAcquireResult{
+		{Position: 3},
+		{Acquired: true},
+	}}
+	concurrency := NewConcurrencyService(stubConcurrencyCache{})
+	concurrency.SetFairQueue(NewFairQueueService(cache, newFairQueueTestConfig(FairQueueKeyUser, FairQueueWeightNone, 0)))
+	ticket := &FairQueueTicket{ID: "t1", AccountID: 1}
+
+	result, queued, err := concurrency.AcquireAccountSlotFair(context.Background(), ticket, 2)
+	require.NoError(t, err)
+	require.False(t, result.Acquired)
+	require.Equal(t, 3, queued.Position)
+
+	result, queued, err = concurrency.AcquireAccountSlotFair(context.Background(), ticket, 2)
+	require.NoError(t, err)
+	require.True(t, result.Acquired)
+	require.True(t, queued.Acquired)
+	require.NotNil(t, result.ReleaseFunc)
+	require.Len(t, cache.tryRequests, 2)
+	require.NotEqual(t, cache.tryRequests[0], cache.tryRequests[1])
+}
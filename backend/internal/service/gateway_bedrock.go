@@ -0,0 +1,169 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/bedrock"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// Bedrock 账号凭证字段：
+//   - aws_access_key_id / aws_secret_access_key：IAM 访问密钥（必填）
+//   - aws_session_token：STS 临时凭证（可选）
+//   - aws_region：Bedrock 所在区域，如 us-east-1（必填）
+//   - base_url：可选，覆盖默认的 https://bedrock-runtime.<region>.amazonaws.com（VPC Endpoint / 本地桩服务）
+//   - model_mapping：可选，将请求模型映射为 Bedrock 模型 ID 或推理配置文件 ID（如 us.anthropic.claude-...）；
+//     仅提供按需吞吐以外访问方式的模型（需推理配置文件）必须显式映射
+const (
+	bedrockAnthropicVersion = "bedrock-2023-05-31"
+	bedrockModelIDPrefix    = "anthropic."
+	bedrockModelIDSuffix    = "-v1:0"
+)
+
+// bedrockModelVersionSuffixes Bedrock 模型版本号不是 v1:0 的 Anthropic 模型
+var bedrockModelVersionSuffixes = map[string]string{
+	"claude-3-5-sonnet-20241022": "-v2:0",
+}
+
+// bedrockCredentials 读取账号中的 AWS 凭证与区域
+func bedrockCredentials(account *Account) (bedrock.Credentials, string, error) {
+	creds := bedrock.Credentials{
+		AccessKeyID:     strings.TrimSpace(account.GetCredential("aws_access_key_id")),
+		SecretAccessKey: strings.TrimSpace(account.GetCredential("aws_secret_access_key")),
+		SessionToken:    strings.TrimSpace(account.GetCredential("aws_session_token")),
+	}
+	region := strings.TrimSpace(account.GetCredential("aws_region"))
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return creds, "", errors.New("aws_access_key_id / aws_secret_access_key not found in credentials")
+	}
+	if region == "" {
+		return creds, "", errors.New("aws_region not found in credentials")
+	}
+	return creds, region, nil
+}
+
+// bedrockModelID 将 Anthropic 模型 ID 转换为 Bedrock 模型 ID。
+// 已是 Bedrock 格式（含 "anthropic." 或推理配置文件前缀 / ARN）时原样返回；
+// 否则先按 Anthropic 标准映射补全短 ID，再拼接为 anthropic.<model>-<version>。
+// 不带日期版本号的 ID（如 -latest 别名）无法确定 Bedrock 模型，需在账号 model_mapping 中显式配置。
+func bedrockModelID(model string) (string, error) {
+	if strings.Contains(model, bedrockModelIDPrefix) || strings.HasPrefix(model, "arn:") {
+		return model, nil
+	}
+	model = claude.NormalizeModelID(model)
+	if _, _, ok := splitClaudeModelDate(model); !ok {
+		return "", fmt.Errorf("model %q has no Bedrock model ID, configure it in the account model_mapping", model)
+	}
+	suffix, ok := bedrockModelVersionSuffixes[model]
+	if !ok {
+		suffix = bedrockModelIDSuffix
+	}
+	return bedrockModelIDPrefix + model + suffix, nil
+}
+
+// buildBedrockRequestBody 将 /v1/messages 请求体转换为 Bedrock InvokeModel 请求体：
+// 模型与流式标志由 URL 决定，anthropic-beta 头改为 body 中的 anthropic_beta 数组。
+func buildBedrockRequestBody(body []byte, betaHeader string) ([]byte, error) {
+	var err error
+	for _, field := range []string{"model", "stream"} {
+		if body, err = sjson.DeleteBytes(body, field); err != nil {
+			return nil, err
+		}
+	}
+	if !gjson.GetBytes(body, "anthropic_version").Exists() {
+		if body, err = sjson.SetBytes(body, "anthropic_version", bedrockAnthropicVersion); err != nil {
+			return nil, err
+		}
+	}
+	var betas []string
+	for _, b := range strings.Split(betaHeader, ",") {
+		if b = strings.TrimSpace(b); b != "" {
+			betas = append(betas, b)
+		}
+	}
+	if len(betas) > 0 && !gjson.GetBytes(body, "anthropic_beta").Exists() {
+		if body, err = sjson.SetBytes(body, "anthropic_beta", betas); err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+// buildBedrockRequest 构建 SigV4 签名的 Bedrock invoke / invoke-with-response-stream 请求
+func (s *GatewayService) buildBedrockRequest(ctx context.Context, c *gin.Context, account *Account, body []byte, modelID string, reqStream bool) (*http.Request, error) {
+	betaHeader := ""
+	if c != nil && c.Request != nil {
+		betaHeader = c.Request.Header.Get("anthropic-beta")
+	}
+	return newBedrockRequest(ctx, account, s.validateUpstreamBaseURL, body, modelID, betaHeader, reqStream)
+}
+
+// newBedrockRequest 将 /v1/messages 请求体转换为 Bedrock 请求并签名。
+// validateBaseURL 用于校验账号自定义的 base_url（与各服务的上游 URL 白名单保持一致）。
+func newBedrockRequest(ctx context.Context, account *Account, validateBaseURL func(string) (string, error), body []byte, modelID, betaHeader string, reqStream bool) (*http.Request, error) {
+	creds, region, err := bedrockCredentials(account)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := "https://bedrock-runtime." + region + ".amazonaws.com"
+	if custom := strings.TrimSpace(account.GetCredential("base_url")); custom != "" {
+		validatedURL, err := validateBaseURL(custom)
+		if err != nil {
+			return nil, err
+		}
+		baseURL = validatedURL
+	}
+
+	action := "invoke"
+	accept := "application/json"
+	if reqStream {
+		action = "invoke-with-response-stream"
+		accept = bedrock.ContentTypeEventStream
+	}
+
+	payload, err := buildBedrockRequestBody(body, betaHeader)
+	if err != nil {
+		return nil, fmt.Errorf("build bedrock request body: %w", err)
+	}
+
+	bedrockModel, err := bedrockModelID(modelID)
+	if err != nil {
+		return nil, err
+	}
+	targetURL := strings.TrimRight(baseURL, "/") + "/model/" + bedrock.EscapePathSegment(bedrockModel) + "/" + action
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("accept", accept)
+
+	if err := bedrock.SignRequest(req, payload, creds, region, bedrock.ServiceName, time.Now()); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// adaptBedrockResponse 将 Bedrock 成功响应适配为 Anthropic 响应：
+// 流式响应的 event-stream 分帧转换为 SSE，请求 ID 头统一为 x-request-id。
+func adaptBedrockResponse(resp *http.Response, reqStream bool) {
+	if resp.Header.Get("x-request-id") == "" {
+		if requestID := resp.Header.Get("x-amzn-requestid"); requestID != "" {
+			resp.Header.Set("x-request-id", requestID)
+		}
+	}
+	if reqStream && strings.HasPrefix(resp.Header.Get("content-type"), bedrock.ContentTypeEventStream) {
+		resp.Body = bedrock.NewSSEReader(resp.Body)
+		resp.Header.Set("content-type", "text/event-stream")
+	}
+}
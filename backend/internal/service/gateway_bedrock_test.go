@@ -0,0 +1,175 @@
+//go:build unit
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/bedrock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+// newBedrockStubServer 模拟 Bedrock Runtime：校验 SigV4 签名与请求体转换，返回 handler 生成的响应
+func newBedrockStubServer(t *testing.T, handle func(w http.ResponseWriter, r *http.Request, body []byte)) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		// 以相同的时间戳重新签名，校验 Authorization 一致
+		signedAt, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+		require.NoError(t, err)
+		verify, err := http.NewRequest(r.Method, "http://"+r.Host+r.URL.RequestURI(), bytes.NewReader(body))
+		require.NoError(t, err)
+		verify.Header.Set("Content-Type", r.Header.Get("Content-Type"))
+		creds := bedrock.Credentials{AccessKeyID: "AKIDTEST", SecretAccessKey: "secret-test"}
+		require.NoError(t, bedrock.SignRequest(verify, body, creds, "us-west-2", bedrock.ServiceName, signedAt))
+		require.Equal(t, verify.Header.Get("Authorization"), r.Header.Get("Authorization"))
+
+		handle(w, r, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newBedrockTestAccount(baseURL string) *Account {
+	return newUpstreamTestAccount(42, "bedrock", PlatformAnthropic, AccountTypeBedrock, map[string]any{
+		"aws_access_key_id":     "AKIDTEST",
+		"aws_secret_access_key": "secret-test",
+		"aws_region":            "us-west-2",
+		"base_url":              baseURL,
+	})
+}
+
+func newBedrockTestContext(body []byte) (*gin.Context, *httptest.ResponseRecorder) {
+	c, rec := newUpstreamTestContext("/v1/messages", body)
+	c.Request.Header.Set("anthropic-beta", "context-1m-2025-08-07")
+	return c, rec
+}
+
+func TestGatewayService_Forward_BedrockStreaming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	srv := newBedrockStubServer(t, func(w http.ResponseWriter, r *http.Request, body []byte) {
+		require.Equal(t, "/model/anthropic.claude-sonnet-4-5-20250929-v1:0/invoke-with-response-stream", r.URL.Path)
+		require.Equal(t, bedrock.ContentTypeEventStream, r.Header.Get("Accept"))
+		require.False(t, gjson.GetBytes(body, "model").Exists())
+		require.False(t, gjson.GetBytes(body, "stream").Exists())
+		require.Equal(t, "bedrock-2023-05-31", gjson.GetBytes(body, "anthropic_version").String())
+		require.Equal(t, `["context-1m-2025-08-07"]`, gjson.GetBytes(body, "anthropic_beta").Raw)
+
+		w.Header().Set("Content-Type", bedrock.ContentTypeEventStream)
+		w.Header().Set("X-Amzn-Requestid", "req-bedrock-1")
+		for _, event := range []string{
+			`{"type":"message_start","message":{"id":"msg_1","model":"claude-sonnet-4-5-20250929","usage":{"input_tokens":11,"cache_read_input_tokens":2}}}`,
+			`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"hi"}}`,
+			`{"type":"message_delta","delta":{"stop_reason":"end_turn"},"usage":{"output_tokens":7}}`,
+			`{"type":"message_stop","amazon-bedrock-invocationMetrics":{"inputTokenCount":11,"outputTokenCount":7}}`,
+		} {
+			_, _ = w.Write(bedrock.EncodeChunk([]byte(event)))
+		}
+	})
+
+	body := []byte(`{"model":"claude-sonnet-4-5","stream":true,"max_tokens":16,"messages":[{"role":"user","content":"hi"}]}`)
+	c, rec := newBedrockTestContext(body)
+
+	svc := newPassthroughGatewayService()
+	result, err := svc.Forward(context.Background(), c, newBedrockTestAccount(srv.URL), &ParsedRequest{Body: body, Model: "claude-sonnet-4-5", Stream: true})
+	require.NoError(t, err)
+	require.Equal(t, "req-bedrock-1", result.RequestID)
+	require.Equal(t, "claude-sonnet-4-5", result.Model)
+	require.Equal(t, 11, result.Usage.InputTokens)
+	require.Equal(t, 7, result.Usage.OutputTokens)
+	require.Equal(t, 2, result.Usage.CacheReadInputTokens)
+
+	out := rec.Body.String()
+	require.Contains(t, out, "event: message_start\n")
+	require.Contains(t, out, `"text":"hi"`)
+	require.Contains(t, out, "event: message_stop\n")
+}
+
+func TestGatewayService_Forward_BedrockNonStreamingWithModelMapping(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	srv := newBedrockStubServer(t, func(w http.ResponseWriter, r *http.Request, body []byte) {
+		require.Equal(t, "/model/us.anthropic.claude-3-5-haiku-20241022-v1:0/invoke", r.URL.Path)
+		require.Equal(t, "/model/us.anthropic.claude-3-5-haiku-20241022-v1%3A0/invoke", r.URL.EscapedPath())
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"msg_1","type":"message","model":"claude-3-5-haiku-20241022","content":[{"type":"text","text":"ok"}],"usage":{"input_tokens":5,"output_tokens":3}}`))
+	})
+
+	account := newBedrockTestAccount(srv.URL)
+	account.Credentials["model_mapping"] = map[string]any{"claude-3-5-haiku*": "us.anthropic.claude-3-5-haiku-20241022-v1:0"}
+
+	body := []byte(`{"model":"claude-3-5-haiku-latest","max_tokens":16,"messages":[{"role":"user","content":"hi"}]}`)
+	c, rec := newBedrockTestContext(body)
+
+	svc := newPassthroughGatewayService()
+	result, err := svc.Forward(context.Background(), c, account, &ParsedRequest{Body: body, Model: "claude-3-5-haiku-latest"})
+	require.NoError(t, err)
+	require.Equal(t, 5, result.Usage.InputTokens)
+	require.Equal(t, 3, result.Usage.OutputTokens)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "ok", gjson.Get(rec.Body.String(), "content.0.text").String())
+}
+
+func TestGatewayService_Forward_BedrockStreamException(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	srv := newBedrockStubServer(t, func(w http.ResponseWriter, r *http.Request, body []byte) {
+		w.Header().Set("Content-Type", bedrock.ContentTypeEventStream)
+		_, _ = w.Write(bedrock.EncodeMessage(map[string]string{
+			":message-type":   "exception",
+			":exception-type": "throttlingException",
+		}, []byte(`{"message":"Too many tokens"}`)))
+	})
+
+	body := []byte(`{"model":"claude-sonnet-4-5","stream":true,"max_tokens":16,"messages":[{"role":"user","content":"hi"}]}`)
+	c, _ := newBedrockTestContext(body)
+
+	svc := newPassthroughGatewayService()
+	_, err := svc.Forward(context.Background(), c, newBedrockTestAccount(srv.URL), &ParsedRequest{Body: body, Model: "claude-sonnet-4-5", Stream: true})
+	var failoverErr *UpstreamFailoverError
+	require.ErrorAs(t, err, &failoverErr)
+}
+
+func TestGatewayService_GetAccessToken_BedrockRequiresCredentials(t *testing.T) {
+	svc := newPassthroughGatewayService()
+
+	account := newBedrockTestAccount("")
+	token, tokenType, err := svc.GetAccessToken(context.Background(), account)
+	require.NoError(t, err)
+	require.Equal(t, "AKIDTEST", token)
+	require.Equal(t, "bedrock", tokenType)
+
+	delete(account.Credentials, "aws_region")
+	_, _, err = svc.GetAccessToken(context.Background(), account)
+	require.ErrorContains(t, err, "aws_region")
+}
+
+func TestBedrockModelID(t *testing.T) {
+	for input, want := range map[string]string{
+		"claude-sonnet-4-5-20250929":                        "anthropic.claude-sonnet-4-5-20250929-v1:0",
+		"claude-sonnet-4-5":                                 "anthropic.claude-sonnet-4-5-20250929-v1:0",
+		"claude-3-5-sonnet-20241022":                        "anthropic.claude-3-5-sonnet-20241022-v2:0",
+		"anthropic.claude-3-5-sonnet-20241022-v2:0":         "anthropic.claude-3-5-sonnet-20241022-v2:0",
+		"eu.anthropic.claude-sonnet-4-20250514-v1:0":        "eu.anthropic.claude-sonnet-4-20250514-v1:0",
+		"arn:aws:bedrock:us-east-1:123:inference-profile/x": "arn:aws:bedrock:us-east-1:123:inference-profile/x",
+	} {
+		got, err := bedrockModelID(input)
+		require.NoError(t, err, input)
+		require.Equal(t, want, got, input)
+	}
+
+	// 无日期版本号的别名无法推导 Bedrock 模型 ID，需显式映射
+	_, err := bedrockModelID("claude-3-7-sonnet-latest")
+	require.ErrorContains(t, err, "model_mapping")
+}
@@ -402,7 +402,7 @@ type GatewayService struct {
 	userSubRepo         UserSubscriptionRepository
 	userGroupRateRepo   UserGroupRateRepository
 	cache               GatewayCache
-	digestStore         *DigestSessionStore
+	digestStore         DigestSessionStore
 	cfg                 *config.Config
 	schedulerSnapshot   *SchedulerSnapshotService
 	billingService      *BillingService
@@ -413,7 +413,10 @@ type GatewayService struct {
 	deferredService     *DeferredService
 	concurrencyService  *ConcurrencyService
 	claudeTokenProvider *ClaudeTokenProvider
+	vertexTokenProvider *VertexTokenProvider
 	sessionLimitCache   SessionLimitCache // 会话数量限制缓存（仅 Anthropic OAuth/SetupToken）
+	webhookService      *WebhookService
+	apiKeyRateLimit     *APIKeyRateLimitService
 }
 
 // NewGatewayService creates a new GatewayService
@@ -435,8 +438,11 @@ func NewGatewayService(
 	httpUpstream HTTPUpstream,
 	deferredService *DeferredService,
 	claudeTokenProvider *ClaudeTokenProvider,
+	vertexTokenProvider *VertexTokenProvider,
 	sessionLimitCache SessionLimitCache,
-	digestStore *DigestSessionStore,
+	digestStore DigestSessionStore,
+	webhookService *WebhookService,
+	apiKeyRateLimit *APIKeyRateLimitService,
 ) *GatewayService {
 	return &GatewayService{
 		accountRepo:         accountRepo,
@@ -457,7 +463,10 @@ func NewGatewayService(
 		httpUpstream:        httpUpstream,
 		deferredService:     deferredService,
 		claudeTokenProvider: claudeTokenProvider,
+		vertexTokenProvider: vertexTokenProvider,
 		sessionLimitCache:   sessionLimitCache,
+		webhookService:      webhookService,
+		apiKeyRateLimit:     apiKeyRateLimit,
 	}
 }
 
@@ -546,37 +555,35 @@ func (s *GatewayService) GetCachedSessionAccountID(ctx context.Context, groupID
 
 // FindGeminiSession 查找 Gemini 会话（基于内容摘要链的 Fallback 匹配）
 // 返回最长匹配的会话信息（uuid, accountID）
-func (s *GatewayService) FindGeminiSession(_ context.Context, groupID int64, prefixHash, digestChain string) (uuid string, accountID int64, matchedChain string, found bool) {
+func (s *GatewayService) FindGeminiSession(ctx context.Context, groupID int64, prefixHash, digestChain string) (uuid string, accountID int64, matchedChain string, found bool) {
 	if digestChain == "" || s.digestStore == nil {
 		return "", 0, "", false
 	}
-	return s.digestStore.Find(groupID, prefixHash, digestChain)
+	return s.digestStore.Find(ctx, groupID, prefixHash, digestChain)
 }
 
 // SaveGeminiSession 保存 Gemini 会话。oldDigestChain 为 Find 返回的 matchedChain，用于删旧 key。
-func (s *GatewayService) SaveGeminiSession(_ context.Context, groupID int64, prefixHash, digestChain, uuid string, accountID int64, oldDigestChain string) error {
+func (s *GatewayService) SaveGeminiSession(ctx context.Context, groupID int64, prefixHash, digestChain, uuid string, accountID int64, oldDigestChain string) error {
 	if digestChain == "" || s.digestStore == nil {
 		return nil
 	}
-	s.digestStore.Save(groupID, prefixHash, digestChain, uuid, accountID, oldDigestChain)
-	return nil
+	return s.digestStore.Save(ctx, groupID, prefixHash, digestChain, uuid, accountID, oldDigestChain)
 }
 
 // FindAnthropicSession 查找 Anthropic 会话（基于内容摘要链的 Fallback 匹配）
-func (s *GatewayService) FindAnthropicSession(_ context.Context, groupID int64, prefixHash, digestChain string) (uuid string, accountID int64, matchedChain string, found bool) {
+func (s *GatewayService) FindAnthropicSession(ctx context.Context, groupID int64, prefixHash, digestChain string) (uuid string, accountID int64, matchedChain string, found bool) {
 	if digestChain == "" || s.digestStore == nil {
 		return "", 0, "", false
 	}
-	return s.digestStore.Find(groupID, prefixHash, digestChain)
+	return s.digestStore.Find(ctx, groupID, prefixHash, digestChain)
 }
 
 // SaveAnthropicSession 保存 Anthropic 会话
-func (s *GatewayService) SaveAnthropicSession(_ context.Context, groupID int64, prefixHash, digestChain, uuid string, accountID int64, oldDigestChain string) error {
+func (s *GatewayService) SaveAnthropicSession(ctx context.Context, groupID int64, prefixHash, digestChain, uuid string, accountID int64, oldDigestChain string) error {
 	if digestChain == "" || s.digestStore == nil {
 		return nil
 	}
-	s.digestStore.Save(groupID, prefixHash, digestChain, uuid, accountID, oldDigestChain)
-	return nil
+	return s.digestStore.Save(ctx, groupID, prefixHash, digestChain, uuid, accountID, oldDigestChain)
 }
 
 func (s *GatewayService) extractCacheableContent(parsed *ParsedRequest) string {
@@ -1188,6 +1195,9 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 				if loadInfo == nil {
 					loadInfo = &AccountLoadInfo{AccountID: acc.ID}
 				}
+				if !acc.IsEligibleForNewSession() {
+					continue
+				}
 				if loadInfo.LoadRate < 100 {
 					routingAvailable = append(routingAvailable, accountWithLoad{account: acc, loadInfo: loadInfo})
 				}
@@ -1215,6 +1225,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 					}
 				})
 				shuffleWithinSortGroups(routingAvailable)
+				orderByWeightWithinPriority(routingAvailable)
 
 				// 4. 尝试获取槽位
 				for _, item := range routingAvailable {
@@ -1331,7 +1342,7 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 		// Scheduler snapshots can be temporarily stale (bucket rebuild is throttled);
 		// re-check schedulability here so recently rate-limited/overloaded accounts
 		// are not selected again before the bucket is rebuilt.
-		if !acc.IsSchedulable() {
+		if !acc.IsEligibleForNewSession() {
 			continue
 		}
 		if !s.isAccountAllowedForPlatform(acc, platform, useMixed) {
@@ -1382,14 +1393,12 @@ func (s *GatewayService) SelectAccountWithLoadAwareness(ctx context.Context, gro
 			}
 		}
 
-		// 分层过滤选择：优先级 → 负载率 → LRU
+		// 分层过滤选择：优先级 → 权重（已配置时）或 负载率 → LRU
 		for len(available) > 0 {
 			// 1. 取优先级最小的集合
 			candidates := filterByMinPriority(available)
-			// 2. 取负载率最低的集合
-			candidates = filterByMinLoadRate(candidates)
-			// 3. LRU 选择最久未用的账号
-			selected := selectByLRU(candidates, preferOAuth)
+			// 2. 同优先级内按权重随机，未配置权重时取负载率最低的集合并按 LRU 选择
+			selected := selectFromPriorityTier(candidates, preferOAuth)
 			if selected == nil {
 				break
 			}
@@ -2150,6 +2159,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 		}
 
 		var selected *Account
+		var eligible []*Account
 		for i := range accounts {
 			acc := &accounts[i]
 			if _, ok := routingSet[acc.ID]; !ok {
@@ -2160,7 +2170,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 			}
 			// Scheduler snapshots can be temporarily stale; re-check schedulability here to
 			// avoid selecting accounts that were recently rate-limited/overloaded.
-			if !acc.IsSchedulable() {
+			if !acc.IsEligibleForNewSession() {
 				continue
 			}
 			if requestedModel != "" && !s.isModelSupportedByAccountWithContext(ctx, acc, requestedModel) {
@@ -2169,6 +2179,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 			if !acc.IsSchedulableForModelWithContext(ctx, requestedModel) {
 				continue
 			}
+			eligible = append(eligible, acc)
 			if selected == nil {
 				selected = acc
 				continue
@@ -2193,6 +2204,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 			}
 		}
 
+		selected = preferWeightedInTier(selected, eligible)
 		if selected != nil {
 			if sessionHash != "" && s.cache != nil {
 				if err := s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.ID, stickySessionTTL); err != nil {
@@ -2242,6 +2254,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 
 	// 3. 按优先级+最久未用选择（考虑模型支持）
 	var selected *Account
+	var eligible []*Account
 	for i := range accounts {
 		acc := &accounts[i]
 		if _, excluded := excludedIDs[acc.ID]; excluded {
@@ -2249,7 +2262,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 		}
 		// Scheduler snapshots can be temporarily stale; re-check schedulability here to
 		// avoid selecting accounts that were recently rate-limited/overloaded.
-		if !acc.IsSchedulable() {
+		if !acc.IsEligibleForNewSession() {
 			continue
 		}
 		if requestedModel != "" && !s.isModelSupportedByAccountWithContext(ctx, acc, requestedModel) {
@@ -2258,6 +2271,7 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 		if !acc.IsSchedulableForModelWithContext(ctx, requestedModel) {
 			continue
 		}
+		eligible = append(eligible, acc)
 		if selected == nil {
 			selected = acc
 			continue
@@ -2282,6 +2296,8 @@ func (s *GatewayService) selectAccountForModelWithPlatform(ctx context.Context,
 		}
 	}
 
+	// 同优先级内配置了权重时按权重随机选择
+	selected = preferWeightedInTier(selected, eligible)
 	if selected == nil {
 		if requestedModel != "" {
 			return nil, fmt.Errorf("no available accounts supporting model: %s", requestedModel)
@@ -2355,6 +2371,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 		}
 
 		var selected *Account
+		var eligible []*Account
 		for i := range accounts {
 			acc := &accounts[i]
 			if _, ok := routingSet[acc.ID]; !ok {
@@ -2365,7 +2382,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 			}
 			// Scheduler snapshots can be temporarily stale; re-check schedulability here to
 			// avoid selecting accounts that were recently rate-limited/overloaded.
-			if !acc.IsSchedulable() {
+			if !acc.IsEligibleForNewSession() {
 				continue
 			}
 			// 过滤：原生平台直接通过，antigravity 需要启用混合调度
@@ -2378,6 +2395,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 			if !acc.IsSchedulableForModelWithContext(ctx, requestedModel) {
 				continue
 			}
+			eligible = append(eligible, acc)
 			if selected == nil {
 				selected = acc
 				continue
@@ -2402,6 +2420,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 			}
 		}
 
+		selected = preferWeightedInTier(selected, eligible)
 		if selected != nil {
 			if sessionHash != "" && s.cache != nil {
 				if err := s.cache.SetSessionAccountID(ctx, derefGroupID(groupID), sessionHash, selected.ID, stickySessionTTL); err != nil {
@@ -2449,6 +2468,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 
 	// 3. 按优先级+最久未用选择（考虑模型支持和混合调度）
 	var selected *Account
+	var eligible []*Account
 	for i := range accounts {
 		acc := &accounts[i]
 		if _, excluded := excludedIDs[acc.ID]; excluded {
@@ -2456,7 +2476,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 		}
 		// Scheduler snapshots can be temporarily stale; re-check schedulability here to
 		// avoid selecting accounts that were recently rate-limited/overloaded.
-		if !acc.IsSchedulable() {
+		if !acc.IsEligibleForNewSession() {
 			continue
 		}
 		// 过滤：原生平台直接通过，antigravity 需要启用混合调度
@@ -2469,6 +2489,7 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 		if !acc.IsSchedulableForModelWithContext(ctx, requestedModel) {
 			continue
 		}
+		eligible = append(eligible, acc)
 		if selected == nil {
 			selected = acc
 			continue
@@ -2493,6 +2514,8 @@ func (s *GatewayService) selectAccountWithMixedScheduling(ctx context.Context, g
 		}
 	}
 
+	// 同优先级内配置了权重时按权重随机选择
+	selected = preferWeightedInTier(selected, eligible)
 	if selected == nil {
 		if requestedModel != "" {
 			return nil, fmt.Errorf("no available accounts supporting model: %s", requestedModel)
@@ -2544,7 +2567,8 @@ func (s *GatewayService) isModelSupportedByAccount(account *Account, requestedMo
 		return mapAntigravityModel(account, requestedModel) != ""
 	}
 	// OAuth/SetupToken 账号使用 Anthropic 标准映射（短ID → 长ID）
-	if account.Platform == PlatformAnthropic && account.Type != AccountTypeAPIKey {
+	// Bedrock / Vertex 账号与 APIKey 一致，按原始模型名匹配账号级映射
+	if account.Platform == PlatformAnthropic && account.Type != AccountTypeAPIKey && !account.IsCloudProvider() {
 		requestedModel = claude.NormalizeModelID(requestedModel)
 	}
 	// Gemini API Key 账户直接透传，由上游判断模型是否支持
@@ -2567,6 +2591,22 @@ func (s *GatewayService) GetAccessToken(ctx context.Context, account *Account) (
 			return "", "", errors.New("api_key not found in credentials")
 		}
 		return apiKey, "apikey", nil
+	case AccountTypeBedrock:
+		// Bedrock 请求在 buildUpstreamRequest 中用 SigV4 签名，这里仅校验凭证完整性
+		creds, _, err := bedrockCredentials(account)
+		if err != nil {
+			return "", "", err
+		}
+		return creds.AccessKeyID, "bedrock", nil
+	case AccountTypeVertex:
+		if s.vertexTokenProvider == nil {
+			return "", "", errors.New("vertex token provider not configured")
+		}
+		accessToken, err := s.vertexTokenProvider.GetAccessToken(ctx, account)
+		if err != nil {
+			return "", "", err
+		}
+		return accessToken, "vertex", nil
 	default:
 		return "", "", fmt.Errorf("unsupported account type: %s", account.Type)
 	}
@@ -3009,11 +3049,24 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 		return nil, fmt.Errorf("parse request: empty request")
 	}
 
-	body := parsed.Body
 	reqModel := parsed.Model
 	reqStream := parsed.Stream
 	originalModel := reqModel
 
+	// 分组请求策略（在所有请求体改写之前执行）
+	body, err := enforceRequestPolicy(ctx, c, applyClaudeRequestPolicy, parsed.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"type":  "error",
+			"error": gin.H{"type": "invalid_request_error", "message": err.Error()},
+		})
+		return nil, err
+	}
+	system := parsed.System
+	if policy := requestPolicyFromContext(ctx); policy != nil && (policy.SystemPrompt != "" || !policy.Redaction.IsEmpty()) {
+		system = gjson.GetBytes(body, "system").Value()
+	}
+
 	isClaudeCode := isClaudeCodeRequest(ctx, c, parsed)
 	shouldMimicClaudeCode := account.IsOAuth() && !isClaudeCode
 
@@ -3021,8 +3074,8 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 		// 智能注入 Claude Code 系统提示词（仅 OAuth/SetupToken 账号需要）
 		// 条件：1) OAuth/SetupToken 账号  2) 不是 Claude Code 客户端  3) 不是 Haiku 模型  4) system 中还没有 Claude Code 提示词
 		if !strings.Contains(strings.ToLower(reqModel), "haiku") &&
-			!systemIncludesClaudeCodePrompt(parsed.System) {
-			body = injectClaudeCodePrompt(body, parsed.System)
+			!systemIncludesClaudeCodePrompt(system) {
+			body = injectClaudeCodePrompt(body, system)
 		}
 
 		normalizeOpts := claudeOAuthNormalizeOptions{stripSystemCacheControl: true}
@@ -3051,15 +3104,16 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 	// 应用模型映射：
 	// - APIKey 账号：使用账号级别的显式映射（如果配置），否则透传原始模型名
 	// - OAuth/SetupToken 账号：使用 Anthropic 标准映射（短ID → 长ID）
+	// - Bedrock / Vertex 账号：优先账号级映射，未命中时使用 Anthropic 标准映射（构建请求时再转换为云厂商模型 ID）
 	mappedModel := reqModel
 	mappingSource := ""
-	if account.Type == AccountTypeAPIKey {
+	if account.Type == AccountTypeAPIKey || account.IsCloudProvider() {
 		mappedModel = account.GetMappedModel(reqModel)
 		if mappedModel != reqModel {
 			mappingSource = "account"
 		}
 	}
-	if mappingSource == "" && account.Platform == PlatformAnthropic && account.Type != AccountTypeAPIKey {
+	if mappingSource == "" && (account.IsCloudProvider() || (account.Platform == PlatformAnthropic && account.Type != AccountTypeAPIKey)) {
 		normalized := claude.NormalizeModelID(reqModel)
 		if normalized != reqModel {
 			mappedModel = normalized
@@ -3425,6 +3479,9 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 	}
 
 	// 处理正常响应
+	if account.IsBedrock() {
+		adaptBedrockResponse(resp, reqStream)
+	}
 	var usage *ClaudeUsage
 	var firstTokenMs *int
 	var clientDisconnect bool
@@ -3460,6 +3517,13 @@ func (s *GatewayService) Forward(ctx context.Context, c *gin.Context, account *A
 }
 
 func (s *GatewayService) buildUpstreamRequest(ctx context.Context, c *gin.Context, account *Account, body []byte, token, tokenType, modelID string, reqStream bool, mimicClaudeCode bool) (*http.Request, error) {
+	if account.IsBedrock() {
+		return s.buildBedrockRequest(ctx, c, account, body, modelID, reqStream)
+	}
+	if account.IsVertex() {
+		return s.buildVertexRequest(ctx, c, account, body, token, modelID, reqStream)
+	}
+
 	// 确定目标URL
 	targetURL := claudeAPIURL
 	if account.Type == AccountTypeAPIKey {
@@ -4511,6 +4575,8 @@ type RecordUsageInput struct {
 	UserAgent         string             // 请求的 User-Agent
 	IPAddress         string             // 请求的客户端 IP 地址
 	ForceCacheBilling bool               // 强制缓存计费：将 input_tokens 转为 cache_read 计费（用于粘性会话切换）
+	ResponseCacheHit  bool               // 响应缓存命中：按缓存折扣计费，不计入账号用量
+	MessageBatch      bool               // 批处理请求（Message Batches API）：按批处理折扣计费
 	APIKeyService     APIKeyQuotaUpdater // 可选：用于更新API Key配额
 }
 
@@ -4527,6 +4593,8 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 	account := input.Account
 	subscription := input.Subscription
 
+	observeFirstTokenMetric(account, apiKey, result.FirstTokenMs)
+
 	// 强制缓存计费：将 input_tokens 转为 cache_read_input_tokens
 	// 用于粘性会话切换时的特殊计费处理
 	if input.ForceCacheBilling && result.Usage.InputTokens > 0 {
@@ -4585,6 +4653,15 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 	if isSubscriptionBilling {
 		billingType = BillingTypeSubscription
 	}
+	accountRateMultiplier := account.BillingRateMultiplier()
+	if input.ResponseCacheHit {
+		// 缓存命中未消耗上游账号额度：按折扣计费，账号倍率记为 0
+		applyResponseCacheDiscount(cost, s.cfg)
+		accountRateMultiplier = 0
+	}
+	if input.MessageBatch {
+		applyMessageBatchDiscount(cost, s.cfg)
+	}
 
 	// 创建使用日志
 	durationMs := int(result.Duration.Milliseconds())
@@ -4592,7 +4669,6 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 	if result.ImageSize != "" {
 		imageSize = &result.ImageSize
 	}
-	accountRateMultiplier := account.BillingRateMultiplier()
 	usageLog := &UsageLog{
 		UserID:                user.ID,
 		APIKeyID:              apiKey.ID,
@@ -4612,6 +4688,7 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 		RateMultiplier:        multiplier,
 		AccountRateMultiplier: &accountRateMultiplier,
 		BillingType:           billingType,
+		ResponseCacheHit:      input.ResponseCacheHit,
 		Stream:                result.Stream,
 		DurationMs:            &durationMs,
 		FirstTokenMs:          result.FirstTokenMs,
@@ -4643,9 +4720,14 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 		log.Printf("Create usage log failed: %v", err)
 	}
 
+	// 记入 API Key TPM 窗口（简易模式同样限流）
+	s.apiKeyRateLimit.RecordTokens(ctx, apiKey, usageLog.TotalTokens())
+
 	if s.cfg != nil && s.cfg.RunMode == config.RunModeSimple {
 		log.Printf("[SIMPLE MODE] Usage recorded (not billed): user=%d, tokens=%d", usageLog.UserID, usageLog.TotalTokens())
-		s.deferredService.ScheduleLastUsedUpdate(account.ID)
+		if !input.ResponseCacheHit {
+			s.deferredService.ScheduleLastUsedUpdate(account.ID)
+		}
 		return nil
 	}
 
@@ -4664,11 +4746,14 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 	} else {
 		// 余额模式：扣除用户余额（使用 ActualCost 考虑倍率后的费用）
 		if shouldBill && cost.ActualCost > 0 {
-			if err := s.userRepo.DeductBalance(ctx, user.ID, cost.ActualCost); err != nil {
+			// 余额与流水写入失败时不扣减缓存、不发送通知，避免缓存与数据库/流水不一致
+			if err := s.userRepo.ApplyBalanceChange(ctx, usageBalanceLedgerEntry(usageLog, cost.ActualCost)); err != nil {
 				log.Printf("Deduct balance failed: %v", err)
+			} else {
+				// 异步更新余额缓存
+				s.billingCacheService.QueueDeductBalance(user.ID, cost.ActualCost)
+				s.webhookService.NotifyBalanceDeducted(user.ID, cost.ActualCost)
 			}
-			// 异步更新余额缓存
-			s.billingCacheService.QueueDeductBalance(user.ID, cost.ActualCost)
 		}
 	}
 
@@ -4679,8 +4764,10 @@ func (s *GatewayService) RecordUsage(ctx context.Context, input *RecordUsageInpu
 		}
 	}
 
-	// Schedule batch update for account last_used_at
-	s.deferredService.ScheduleLastUsedUpdate(account.ID)
+	// Schedule batch update for account last_used_at（缓存命中未使用账号）
+	if !input.ResponseCacheHit {
+		s.deferredService.ScheduleLastUsedUpdate(account.ID)
+	}
 
 	return nil
 }
@@ -4708,6 +4795,8 @@ func (s *GatewayService) RecordUsageWithLongContext(ctx context.Context, input *
 	account := input.Account
 	subscription := input.Subscription
 
+	observeFirstTokenMetric(account, apiKey, result.FirstTokenMs)
+
 	// 强制缓存计费：将 input_tokens 转为 cache_read_input_tokens
 	// 用于粘性会话切换时的特殊计费处理
 	if input.ForceCacheBilling && result.Usage.InputTokens > 0 {
@@ -4824,6 +4913,9 @@ func (s *GatewayService) RecordUsageWithLongContext(ctx context.Context, input *
 		log.Printf("Create usage log failed: %v", err)
 	}
 
+	// 记入 API Key TPM 窗口（简易模式同样限流）
+	s.apiKeyRateLimit.RecordTokens(ctx, apiKey, usageLog.TotalTokens())
+
 	if s.cfg != nil && s.cfg.RunMode == config.RunModeSimple {
 		log.Printf("[SIMPLE MODE] Usage recorded (not billed): user=%d, tokens=%d", usageLog.UserID, usageLog.TotalTokens())
 		s.deferredService.ScheduleLastUsedUpdate(account.ID)
@@ -4845,11 +4937,14 @@ func (s *GatewayService) RecordUsageWithLongContext(ctx context.Context, input *
 	} else {
 		// 余额模式：扣除用户余额（使用 ActualCost 考虑倍率后的费用）
 		if shouldBill && cost.ActualCost > 0 {
-			if err := s.userRepo.DeductBalance(ctx, user.ID, cost.ActualCost); err != nil {
+			// 余额与流水写入失败时不扣减缓存、不发送通知，避免缓存与数据库/流水不一致
+			if err := s.userRepo.ApplyBalanceChange(ctx, usageBalanceLedgerEntry(usageLog, cost.ActualCost)); err != nil {
 				log.Printf("Deduct balance failed: %v", err)
+			} else {
+				// 异步更新余额缓存
+				s.billingCacheService.QueueDeductBalance(user.ID, cost.ActualCost)
+				s.webhookService.NotifyBalanceDeducted(user.ID, cost.ActualCost)
 			}
-			// 异步更新余额缓存
-			s.billingCacheService.QueueDeductBalance(user.ID, cost.ActualCost)
 			// API Key 独立配额扣费
 			if input.APIKeyService != nil && apiKey.Quota > 0 {
 				if err := input.APIKeyService.UpdateQuotaUsed(ctx, apiKey.ID, cost.ActualCost); err != nil {
@@ -4884,8 +4979,8 @@ func (s *GatewayService) ForwardCountTokens(ctx context.Context, c *gin.Context,
 		body, reqModel = normalizeClaudeOAuthRequestBody(body, reqModel, normalizeOpts)
 	}
 
-	// Antigravity 账户不支持 count_tokens 转发，直接返回空值
-	if account.Platform == PlatformAntigravity {
+	// Antigravity / Bedrock / Vertex / OpenAI 兼容上游账户不支持 count_tokens 转发，直接返回空值
+	if account.Platform == PlatformAntigravity || account.IsCloudProvider() || account.IsCompatible() {
 		c.JSON(http.StatusOK, gin.H{"input_tokens": 0})
 		return nil
 	}
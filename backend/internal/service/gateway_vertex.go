@@ -0,0 +1,57 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// buildVertexAnthropicRequestBody 将 /v1/messages 请求体转换为 Vertex rawPredict 请求体：
+// 模型由 URL 决定，anthropic_version 固定为 Vertex 版本；stream 字段保留（streamRawPredict 需要）。
+func buildVertexAnthropicRequestBody(body []byte) ([]byte, error) {
+	body, err := sjson.DeleteBytes(body, "model")
+	if err != nil {
+		return nil, err
+	}
+	if !gjson.GetBytes(body, "anthropic_version").Exists() {
+		if body, err = sjson.SetBytes(body, "anthropic_version", vertexAnthropicVersion); err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+// buildVertexRequest 构建 Anthropic-on-Vertex 的 rawPredict / streamRawPredict 请求。
+// Vertex 返回标准的 Anthropic 响应与 SSE，后续响应处理与直连账号一致。
+func (s *GatewayService) buildVertexRequest(ctx context.Context, c *gin.Context, account *Account, body []byte, token, modelID string, reqStream bool) (*http.Request, error) {
+	method := "rawPredict"
+	if reqStream {
+		method = "streamRawPredict"
+	}
+	targetURL, err := buildVertexModelURL(account, s.validateUpstreamBaseURL, vertexPublisherAnthropic, vertexAnthropicModelID(modelID), method)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := buildVertexAnthropicRequestBody(body)
+	if err != nil {
+		return nil, fmt.Errorf("build vertex request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, targetURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("authorization", "Bearer "+token)
+	if c != nil && c.Request != nil {
+		if beta := c.Request.Header.Get("anthropic-beta"); beta != "" {
+			req.Header.Set("anthropic-beta", beta)
+		}
+	}
+	return req, nil
+}
@@ -0,0 +1,121 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+// newVertexTestAccount 构造指向本地桩服务的 Vertex 账号；token 预先写入缓存，避免真实签发
+func newVertexTestAccount(platform, baseURL string) (*Account, *VertexTokenProvider) {
+	account := newUpstreamTestAccount(43, "vertex", platform, AccountTypeVertex, map[string]any{
+		"service_account_json": `{"project_id":"sa-project","client_email":"svc@sa-project.iam.gserviceaccount.com","private_key":"unused"}`,
+		"vertex_project_id":    "p",
+		"vertex_location":      "us-east5",
+		"base_url":             baseURL,
+	})
+	cache := newVertexTokenCacheStub()
+	cache.tokens[VertexTokenCacheKey(account)] = "ya29.cached"
+	return account, NewVertexTokenProvider(cache, passthroughUpstream{})
+}
+
+func TestGatewayService_Forward_VertexStreaming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, "/v1/projects/p/locations/us-east5/publishers/anthropic/models/claude-sonnet-4-5@20250929:streamRawPredict", r.URL.Path)
+		require.Equal(t, "Bearer ya29.cached", r.Header.Get("Authorization"))
+		require.Equal(t, "context-1m-2025-08-07", r.Header.Get("anthropic-beta"))
+		require.False(t, gjson.GetBytes(body, "model").Exists())
+		require.True(t, gjson.GetBytes(body, "stream").Bool())
+		require.Equal(t, vertexAnthropicVersion, gjson.GetBytes(body, "anthropic_version").String())
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("event: message_start\ndata: {\"type\":\"message_start\",\"message\":{\"id\":\"msg_1\",\"usage\":{\"input_tokens\":9}}}\n\n" +
+			"event: message_delta\ndata: {\"type\":\"message_delta\",\"delta\":{\"stop_reason\":\"end_turn\"},\"usage\":{\"output_tokens\":4}}\n\n" +
+			"event: message_stop\ndata: {\"type\":\"message_stop\"}\n\n"))
+	}))
+	defer srv.Close()
+
+	account, provider := newVertexTestAccount(PlatformAnthropic, srv.URL)
+	svc := newPassthroughGatewayService()
+	svc.vertexTokenProvider = provider
+
+	body := []byte(`{"model":"claude-sonnet-4-5-20250929","stream":true,"max_tokens":16,"messages":[{"role":"user","content":"hi"}]}`)
+	c, rec := newBedrockTestContext(body)
+
+	result, err := svc.Forward(context.Background(), c, account, &ParsedRequest{Body: body, Model: "claude-sonnet-4-5-20250929", Stream: true})
+	require.NoError(t, err)
+	require.Equal(t, 9, result.Usage.InputTokens)
+	require.Equal(t, 4, result.Usage.OutputTokens)
+	require.Contains(t, rec.Body.String(), "event: message_stop\n")
+}
+
+func TestGatewayService_GetAccessToken_Vertex(t *testing.T) {
+	account, provider := newVertexTestAccount(PlatformAnthropic, "")
+	svc := newPassthroughGatewayService()
+	svc.vertexTokenProvider = provider
+
+	token, tokenType, err := svc.GetAccessToken(context.Background(), account)
+	require.NoError(t, err)
+	require.Equal(t, "ya29.cached", token)
+	require.Equal(t, "vertex", tokenType)
+}
+
+func TestGeminiMessagesCompatService_ForwardNative_Vertex(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/projects/p/locations/us-east5/publishers/google/models/gemini-2.5-pro:generateContent", r.URL.Path)
+		require.Equal(t, "Bearer ya29.cached", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"candidates":[{"content":{"parts":[{"text":"ok"}]}}],"usageMetadata":{"promptTokenCount":6,"candidatesTokenCount":2}}`))
+	}))
+	defer srv.Close()
+
+	account, provider := newVertexTestAccount(PlatformGemini, srv.URL)
+	account.Credentials["model_mapping"] = map[string]any{"gemini-pro-latest": "gemini-2.5-pro"}
+
+	gw := newPassthroughGatewayService()
+	svc := &GeminiMessagesCompatService{cfg: gw.cfg, httpUpstream: gw.httpUpstream, vertexTokenProvider: provider}
+
+	body := []byte(`{"contents":[{"role":"user","parts":[{"text":"hi"}]}]}`)
+	c, rec := newUpstreamTestContext("/v1beta/models/gemini-pro-latest:generateContent", body)
+
+	result, err := svc.ForwardNative(context.Background(), c, account, "gemini-pro-latest", "generateContent", false, body)
+	require.NoError(t, err)
+	require.Equal(t, 6, result.Usage.InputTokens)
+	require.Equal(t, 2, result.Usage.OutputTokens)
+	require.Equal(t, "ok", gjson.Get(rec.Body.String(), "candidates.0.content.parts.0.text").String())
+}
+
+func TestVertexModelURL(t *testing.T) {
+	account := &Account{Credentials: map[string]any{"vertex_project_id": "p"}}
+	noCustom := func(string) (string, error) { t.Fatal("unexpected base_url validation"); return "", nil }
+
+	got, err := buildVertexModelURL(account, noCustom, vertexPublisherGoogle, "gemini-2.5-flash", "generateContent")
+	require.NoError(t, err)
+	require.Equal(t, "https://aiplatform.googleapis.com/v1/projects/p/locations/global/publishers/google/models/gemini-2.5-flash:generateContent", got)
+
+	account.Credentials["vertex_location"] = "europe-west1"
+	got, err = buildVertexModelURL(account, noCustom, vertexPublisherAnthropic, "claude-opus-4-1@20250805", "rawPredict")
+	require.NoError(t, err)
+	require.Equal(t, "https://europe-west1-aiplatform.googleapis.com/v1/projects/p/locations/europe-west1/publishers/anthropic/models/claude-opus-4-1@20250805:rawPredict", got)
+}
+
+func TestVertexAnthropicModelID(t *testing.T) {
+	require.Equal(t, "claude-sonnet-4-5@20250929", vertexAnthropicModelID("claude-sonnet-4-5-20250929"))
+	require.Equal(t, "claude-sonnet-4-5@20250929", vertexAnthropicModelID("claude-sonnet-4-5@20250929"))
+	require.Equal(t, "claude-sonnet-4-5@20250929", vertexAnthropicModelID("claude-sonnet-4-5"), "短 ID 先按 Anthropic 标准映射补全")
+	require.Equal(t, "claude-3-7-sonnet-latest", vertexAnthropicModelID("claude-3-7-sonnet-latest"))
+}
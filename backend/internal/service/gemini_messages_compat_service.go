@@ -47,6 +47,7 @@ type GeminiMessagesCompatService struct {
 	cache                     GatewayCache
 	schedulerSnapshot         *SchedulerSnapshotService
 	tokenProvider             *GeminiTokenProvider
+	vertexTokenProvider       *VertexTokenProvider
 	rateLimitService          *RateLimitService
 	httpUpstream              HTTPUpstream
 	antigravityGatewayService *AntigravityGatewayService
@@ -59,6 +60,7 @@ func NewGeminiMessagesCompatService(
 	cache GatewayCache,
 	schedulerSnapshot *SchedulerSnapshotService,
 	tokenProvider *GeminiTokenProvider,
+	vertexTokenProvider *VertexTokenProvider,
 	rateLimitService *RateLimitService,
 	httpUpstream HTTPUpstream,
 	antigravityGatewayService *AntigravityGatewayService,
@@ -70,6 +72,7 @@ func NewGeminiMessagesCompatService(
 		cache:                     cache,
 		schedulerSnapshot:         schedulerSnapshot,
 		tokenProvider:             tokenProvider,
+		vertexTokenProvider:       vertexTokenProvider,
 		rateLimitService:          rateLimitService,
 		httpUpstream:              httpUpstream,
 		antigravityGatewayService: antigravityGatewayService,
@@ -300,6 +303,7 @@ func (s *GeminiMessagesCompatService) selectBestGeminiAccount(
 	useMixedScheduling bool,
 ) *Account {
 	var selected *Account
+	var eligible []*Account
 
 	for i := range accounts {
 		acc := &accounts[i]
@@ -313,8 +317,12 @@ func (s *GeminiMessagesCompatService) selectBestGeminiAccount(
 		if !s.isAccountUsableForRequest(ctx, acc, requestedModel, platform, useMixedScheduling) {
 			continue
 		}
+		if !acc.IsEligibleForNewSession() {
+			continue
+		}
 
 		// 选择最佳账号
+		eligible = append(eligible, acc)
 		if selected == nil {
 			selected = acc
 			continue
@@ -325,7 +333,8 @@ func (s *GeminiMessagesCompatService) selectBestGeminiAccount(
 		}
 	}
 
-	return selected
+	// 同优先级内配置了权重时按权重随机选择
+	return preferWeightedInTier(selected, eligible)
 }
 
 // isBetterGeminiAccount 判断 candidate 是否比 current 更优。
@@ -515,6 +524,12 @@ func (s *GeminiMessagesCompatService) SelectAccountForAIStudioEndpoints(ctx cont
 func (s *GeminiMessagesCompatService) Forward(ctx context.Context, c *gin.Context, account *Account, body []byte) (*ForwardResult, error) {
 	startTime := time.Now()
 
+	// 分组请求策略（Claude 请求体，转换为 Gemini 之前执行）
+	body, err := enforceRequestPolicy(ctx, c, applyClaudeRequestPolicy, body)
+	if err != nil {
+		return nil, s.writeClaudeError(c, http.StatusBadRequest, "invalid_request_error", err.Error())
+	}
+
 	var req struct {
 		Model  string `json:"model"`
 		Stream bool   `json:"stream"`
@@ -528,7 +543,7 @@ func (s *GeminiMessagesCompatService) Forward(ctx context.Context, c *gin.Contex
 
 	originalModel := req.Model
 	mappedModel := req.Model
-	if account.Type == AccountTypeAPIKey {
+	if account.Type == AccountTypeAPIKey || account.IsVertex() {
 		mappedModel = account.GetMappedModel(req.Model)
 	}
 
@@ -659,6 +674,17 @@ func (s *GeminiMessagesCompatService) Forward(ctx context.Context, c *gin.Contex
 		}
 		requestIDHeader = "x-request-id"
 
+	case AccountTypeVertex:
+		buildReq = func(ctx context.Context) (*http.Request, string, error) {
+			action := "generateContent"
+			if req.Stream {
+				action = "streamGenerateContent"
+			}
+			upstreamReq, err := s.buildVertexGeminiRequest(ctx, account, mappedModel, action, req.Stream, geminiReq)
+			return upstreamReq, "x-request-id", err
+		}
+		requestIDHeader = "x-request-id"
+
 	default:
 		return nil, fmt.Errorf("unsupported account type: %s", account.Type)
 	}
@@ -1034,12 +1060,21 @@ func (s *GeminiMessagesCompatService) ForwardNative(ctx context.Context, c *gin.
 		return nil, s.writeGoogleError(c, http.StatusNotFound, "Unsupported action: "+action)
 	}
 
+	// 分组请求策略（countTokens 不生成内容，跳过）
+	if action != "countTokens" {
+		policyBody, err := enforceRequestPolicy(ctx, c, applyGeminiRequestPolicy, body)
+		if err != nil {
+			return nil, s.writeGoogleError(c, http.StatusBadRequest, err.Error())
+		}
+		body = policyBody
+	}
+
 	// Some Gemini upstreams validate tool call parts strictly; ensure any `functionCall` part includes a
 	// `thoughtSignature` to avoid frequent INVALID_ARGUMENT 400s.
 	body = ensureGeminiFunctionCallThoughtSignatures(body)
 
 	mappedModel := originalModel
-	if account.Type == AccountTypeAPIKey {
+	if account.Type == AccountTypeAPIKey || account.IsVertex() {
 		mappedModel = account.GetMappedModel(originalModel)
 	}
 
@@ -1158,6 +1193,13 @@ func (s *GeminiMessagesCompatService) ForwardNative(ctx context.Context, c *gin.
 		}
 		requestIDHeader = "x-request-id"
 
+	case AccountTypeVertex:
+		buildReq = func(ctx context.Context) (*http.Request, string, error) {
+			upstreamReq, err := s.buildVertexGeminiRequest(ctx, account, mappedModel, upstreamAction, useUpstreamStream, body)
+			return upstreamReq, "x-request-id", err
+		}
+		requestIDHeader = "x-request-id"
+
 	default:
 		return nil, s.writeGoogleError(c, http.StatusBadGateway, "Unsupported account type: "+account.Type)
 	}
@@ -2722,6 +2764,10 @@ func (s *GeminiMessagesCompatService) handleGeminiUpstreamError(ctx context.Cont
 			}
 			ra = time.Now().Add(cooldown)
 			log.Printf("[Gemini 429] Account %d (Code Assist, tier=%s, project=%s) rate limited, cooldown=%v", account.ID, tierID, projectID, time.Until(ra).Truncate(time.Second))
+		} else if account.IsVertex() {
+			// Vertex 配额按分钟计算，短暂冷却即可
+			ra = time.Now().Add(time.Minute)
+			log.Printf("[Gemini 429] Account %d (Vertex) rate limited, cooldown=1m", account.ID)
 		} else {
 			// API Key / AI Studio OAuth: PST 午夜
 			if ts := nextGeminiDailyResetUnix(); ts != nil {
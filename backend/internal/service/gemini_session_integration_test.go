@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"testing"
 
 	"github.com/Wei-Shaw/sub2api/internal/pkg/antigravity"
@@ -8,7 +9,8 @@ import (
 
 // TestGeminiSessionContinuousConversation 测试连续会话的摘要链匹配
 func TestGeminiSessionContinuousConversation(t *testing.T) {
-	store := NewDigestSessionStore()
+	ctx := context.Background()
+	store := NewMemoryDigestSessionStore()
 	groupID := int64(1)
 	prefixHash := "test_prefix_hash"
 	sessionUUID := "session-uuid-12345"
@@ -27,13 +29,13 @@ func TestGeminiSessionContinuousConversation(t *testing.T) {
 	t.Logf("Round 1 chain: %s", chain1)
 
 	// 第一轮：没有找到会话，创建新会话
-	_, _, _, found := store.Find(groupID, prefixHash, chain1)
+	_, _, _, found := store.Find(ctx, groupID, prefixHash, chain1)
 	if found {
 		t.Error("Round 1: should not find existing session")
 	}
 
 	// 保存第一轮会话（首轮无旧 chain）
-	store.Save(groupID, prefixHash, chain1, sessionUUID, accountID, "")
+	store.Save(ctx, groupID, prefixHash, chain1, sessionUUID, accountID, "")
 
 	// 模拟第二轮对话（用户继续对话）
 	req2 := &antigravity.GeminiRequest{
@@ -50,7 +52,7 @@ func TestGeminiSessionContinuousConversation(t *testing.T) {
 	t.Logf("Round 2 chain: %s", chain2)
 
 	// 第二轮：应该能找到会话（通过前缀匹配）
-	foundUUID, foundAccID, matchedChain, found := store.Find(groupID, prefixHash, chain2)
+	foundUUID, foundAccID, matchedChain, found := store.Find(ctx, groupID, prefixHash, chain2)
 	if !found {
 		t.Error("Round 2: should find session via prefix matching")
 	}
@@ -62,7 +64,7 @@ func TestGeminiSessionContinuousConversation(t *testing.T) {
 	}
 
 	// 保存第二轮会话，传入 Find 返回的 matchedChain 以删旧 key
-	store.Save(groupID, prefixHash, chain2, sessionUUID, accountID, matchedChain)
+	store.Save(ctx, groupID, prefixHash, chain2, sessionUUID, accountID, matchedChain)
 
 	// 模拟第三轮对话
 	req3 := &antigravity.GeminiRequest{
@@ -81,7 +83,7 @@ func TestGeminiSessionContinuousConversation(t *testing.T) {
 	t.Logf("Round 3 chain: %s", chain3)
 
 	// 第三轮：应该能找到会话（通过第二轮的前缀匹配）
-	foundUUID, foundAccID, _, found = store.Find(groupID, prefixHash, chain3)
+	foundUUID, foundAccID, _, found = store.Find(ctx, groupID, prefixHash, chain3)
 	if !found {
 		t.Error("Round 3: should find session via prefix matching")
 	}
@@ -95,7 +97,8 @@ func TestGeminiSessionContinuousConversation(t *testing.T) {
 
 // TestGeminiSessionDifferentConversations 测试不同会话不会错误匹配
 func TestGeminiSessionDifferentConversations(t *testing.T) {
-	store := NewDigestSessionStore()
+	ctx := context.Background()
+	store := NewMemoryDigestSessionStore()
 	groupID := int64(1)
 	prefixHash := "test_prefix_hash"
 
@@ -106,7 +109,7 @@ func TestGeminiSessionDifferentConversations(t *testing.T) {
 		},
 	}
 	chain1 := BuildGeminiDigestChain(req1)
-	store.Save(groupID, prefixHash, chain1, "session-1", 100, "")
+	store.Save(ctx, groupID, prefixHash, chain1, "session-1", 100, "")
 
 	// 第二个完全不同的会话
 	req2 := &antigravity.GeminiRequest{
@@ -117,7 +120,7 @@ func TestGeminiSessionDifferentConversations(t *testing.T) {
 	chain2 := BuildGeminiDigestChain(req2)
 
 	// 不同会话不应该匹配
-	_, _, _, found := store.Find(groupID, prefixHash, chain2)
+	_, _, _, found := store.Find(ctx, groupID, prefixHash, chain2)
 	if found {
 		t.Error("Different conversations should not match")
 	}
@@ -125,17 +128,18 @@ func TestGeminiSessionDifferentConversations(t *testing.T) {
 
 // TestGeminiSessionPrefixMatchingOrder 测试前缀匹配的优先级（最长匹配优先）
 func TestGeminiSessionPrefixMatchingOrder(t *testing.T) {
-	store := NewDigestSessionStore()
+	ctx := context.Background()
+	store := NewMemoryDigestSessionStore()
 	groupID := int64(1)
 	prefixHash := "test_prefix_hash"
 
 	// 保存不同轮次的会话到不同账号
-	store.Save(groupID, prefixHash, "s:sys-u:q1", "session-round1", 1, "")
-	store.Save(groupID, prefixHash, "s:sys-u:q1-m:a1", "session-round2", 2, "")
-	store.Save(groupID, prefixHash, "s:sys-u:q1-m:a1-u:q2", "session-round3", 3, "")
+	store.Save(ctx, groupID, prefixHash, "s:sys-u:q1", "session-round1", 1, "")
+	store.Save(ctx, groupID, prefixHash, "s:sys-u:q1-m:a1", "session-round2", 2, "")
+	store.Save(ctx, groupID, prefixHash, "s:sys-u:q1-m:a1-u:q2", "session-round3", 3, "")
 
 	// 查找更长的链，应该返回最长匹配（账号 3）
-	_, accID, _, found := store.Find(groupID, prefixHash, "s:sys-u:q1-m:a1-u:q2-m:a2")
+	_, accID, _, found := store.Find(ctx, groupID, prefixHash, "s:sys-u:q1-m:a1-u:q2-m:a2")
 	if !found {
 		t.Error("Should find session")
 	}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+)
+
+// buildVertexGeminiRequest 构建 Gemini-on-Vertex 请求：
+// {base}/v1/projects/{project}/locations/{location}/publishers/google/models/{model}:{action}
+// Vertex 的请求体与响应格式与 AI Studio v1beta 一致，因此无需 Code Assist 式的包装/解包。
+func (s *GeminiMessagesCompatService) buildVertexGeminiRequest(ctx context.Context, account *Account, model, action string, stream bool, body []byte) (*http.Request, error) {
+	if s.vertexTokenProvider == nil {
+		return nil, errors.New("vertex token provider not configured")
+	}
+	accessToken, err := s.vertexTokenProvider.GetAccessToken(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	fullURL, err := buildVertexModelURL(account, s.validateUpstreamBaseURL, vertexPublisherGoogle, model, action)
+	if err != nil {
+		return nil, err
+	}
+	if stream {
+		fullURL += "?alt=sse"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fullURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	return req, nil
+}
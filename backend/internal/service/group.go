@@ -41,13 +41,30 @@ type Group struct {
 	// MCP XML 协议注入开关（仅 antigravity 平台使用）
 	MCPXMLInject bool
 
-	// 支持的模型系列（仅 antigravity 平台使用）
+	// 支持的模型系列（antigravity 平台；gemini 分组的 embeddings 请求按 gemini_text 校验）
 	// 可选值: claude, gemini_text, gemini_image
 	SupportedModelScopes []string
 
 	// 分组排序
 	SortOrder int
 
+	// API Key 默认限流（Key 未单独配置时生效，0 = 不限制）
+	DefaultRPMLimit          int
+	DefaultTPMLimit          int
+	DefaultDailyRequestLimit int
+
+	// 响应缓存：缓存 temperature=0 的确定性请求响应（TTL 为 0 时使用全局默认值）
+	ResponseCacheEnabled    bool
+	ResponseCacheTTLSeconds int
+
+	// Claude → OpenAI 协议转换兜底（仅 anthropic 平台使用）
+	// key: Claude 模型匹配模式（支持末尾 * 通配符，如 "claude-sonnet-*"）
+	// value: OpenAI 模型（如 "gpt-5"）；为空表示不启用
+	OpenAIFallbackModelMapping map[string]string
+
+	// 分组请求策略（转发到上游前执行，nil 表示不启用）
+	RequestPolicy *RequestPolicy
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
@@ -134,6 +151,43 @@ func (g *Group) GetRoutingAccountIDs(requestedModel string) []int64 {
 	return nil
 }
 
+// GetOpenAIFallbackModel 返回 Claude 模型对应的 OpenAI 兜底模型，未配置时返回空字符串
+// 精确匹配优先，其次选择前缀最长的通配符规则，保证结果稳定
+func (g *Group) GetOpenAIFallbackModel(requestedModel string) string {
+	if len(g.OpenAIFallbackModelMapping) == 0 || requestedModel == "" {
+		return ""
+	}
+
+	if target := strings.TrimSpace(g.OpenAIFallbackModelMapping[requestedModel]); target != "" {
+		return target
+	}
+
+	matched, bestLen := "", -1
+	for pattern, target := range g.OpenAIFallbackModelMapping {
+		target = strings.TrimSpace(target)
+		if target == "" || !matchModelPattern(pattern, requestedModel) {
+			continue
+		}
+		if len(pattern) > bestLen {
+			matched, bestLen = target, len(pattern)
+		}
+	}
+	return matched
+}
+
+// SupportsModelScope 检查分组是否允许指定模型系列（未配置 supported_model_scopes 时不限制）
+func (g *Group) SupportsModelScope(scope string) bool {
+	if len(g.SupportedModelScopes) == 0 {
+		return true
+	}
+	for _, s := range g.SupportedModelScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
 // matchModelPattern 检查模型是否匹配模式
 // 支持 * 通配符，如 "claude-opus-*" 匹配 "claude-opus-4-20250514"
 func matchModelPattern(pattern, model string) bool {
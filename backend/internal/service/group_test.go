@@ -90,3 +90,21 @@ func TestGroup_GetImagePrice_PartialConfig(t *testing.T) {
 	require.Nil(t, group.GetImagePrice("2K"))
 	require.Nil(t, group.GetImagePrice("4K"))
 }
+
+// TestGroup_GetOpenAIFallbackModel 测试 Claude → OpenAI 兜底模型映射：精确匹配优先，通配符取最长前缀
+func TestGroup_GetOpenAIFallbackModel(t *testing.T) {
+	group := &Group{
+		OpenAIFallbackModelMapping: map[string]string{
+			"claude-*":                 "gpt-5-mini",
+			"claude-sonnet-*":          "gpt-5",
+			"claude-opus-4-1-20250805": "gpt-5-codex",
+			"claude-haiku-*":           " ", // 空目标视为未配置
+		},
+	}
+
+	require.Equal(t, "gpt-5-codex", group.GetOpenAIFallbackModel("claude-opus-4-1-20250805"))
+	require.Equal(t, "gpt-5", group.GetOpenAIFallbackModel("claude-sonnet-4-5-20250929"))
+	require.Equal(t, "gpt-5-mini", group.GetOpenAIFallbackModel("claude-haiku-4-5-20251001"))
+	require.Equal(t, "", group.GetOpenAIFallbackModel("gemini-2.5-pro"))
+	require.Equal(t, "", (&Group{}).GetOpenAIFallbackModel("claude-sonnet-4-5"))
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// OpenAI Images 兼容层
+//
+// /v1/images/generations 请求转换为 Gemini 原生 generateContent 请求，
+// 交给 Gemini/Antigravity 网关流程（GatewayHandler.GeminiV1BetaModels）处理，
+// 因此调度、故障切换、运维日志与按图片尺寸计费（CalculateImageCost）均与原生端点一致。
+// 每次上游调用生成并计费一张图片，n > 1 时由 handler 依次调用。
+
+const (
+	// DefaultImagesModel 请求未指定模型时使用的图片生成模型
+	DefaultImagesModel = "gemini-2.5-flash-image"
+	// MaxImagesPerRequest 单次请求允许生成的最大图片数（n）
+	MaxImagesPerRequest = 4
+
+	ImagesResponseFormatB64JSON = "b64_json"
+	ImagesResponseFormatURL     = "url"
+)
+
+// geminiImageAspectRatios 是 Gemini imageConfig.aspectRatio 支持的取值
+var geminiImageAspectRatios = []string{"1:1", "2:3", "3:2", "3:4", "4:3", "4:5", "5:4", "9:16", "16:9", "21:9"}
+
+// ImagesGenerationRequest 是 OpenAI Images 生成请求中网关关心的字段子集。
+// AspectRatio / ImageSize 为扩展字段，可直接指定 Gemini 的宽高比与输出尺寸（1K/2K/4K）。
+type ImagesGenerationRequest struct {
+	Model          string `json:"model"`
+	Prompt         string `json:"prompt"`
+	N              int    `json:"n,omitempty"`
+	Size           string `json:"size,omitempty"`
+	Quality        string `json:"quality,omitempty"`
+	ResponseFormat string `json:"response_format,omitempty"`
+	User           string `json:"user,omitempty"`
+	AspectRatio    string `json:"aspect_ratio,omitempty"`
+	ImageSize      string `json:"image_size,omitempty"`
+}
+
+// ImagesResponse 是 OpenAI Images 响应体
+type ImagesResponse struct {
+	Created int64        `json:"created"`
+	Data    []ImagesData `json:"data"`
+}
+
+// ImagesData 表示一张生成的图片
+type ImagesData struct {
+	B64JSON       string `json:"b64_json,omitempty"`
+	URL           string `json:"url,omitempty"`
+	RevisedPrompt string `json:"revised_prompt,omitempty"`
+}
+
+// ParseImagesGenerationRequest 解析并校验 Images 生成请求，填充默认值
+func ParseImagesGenerationRequest(body []byte) (*ImagesGenerationRequest, error) {
+	var req ImagesGenerationRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(req.Prompt) == "" {
+		return nil, errors.New("prompt is required")
+	}
+	req.Model = strings.TrimSpace(req.Model)
+	if req.Model == "" {
+		req.Model = DefaultImagesModel
+	}
+	if !isImageGenerationModel(req.Model) {
+		return nil, fmt.Errorf("model %s does not support image generation", req.Model)
+	}
+	if req.N == 0 {
+		req.N = 1
+	}
+	if req.N < 1 || req.N > MaxImagesPerRequest {
+		return nil, fmt.Errorf("n must be between 1 and %d", MaxImagesPerRequest)
+	}
+	switch req.ResponseFormat {
+	case "":
+		req.ResponseFormat = ImagesResponseFormatB64JSON
+	case ImagesResponseFormatB64JSON, ImagesResponseFormatURL:
+	default:
+		return nil, fmt.Errorf("unsupported response_format: %s", req.ResponseFormat)
+	}
+	if req.ImageSize != "" {
+		size := strings.ToUpper(strings.TrimSpace(req.ImageSize))
+		if size != "1K" && size != "2K" && size != "4K" {
+			return nil, fmt.Errorf("unsupported image_size: %s", req.ImageSize)
+		}
+		req.ImageSize = size
+	}
+	return &req, nil
+}
+
+// ConvertImagesRequestToGemini 将 Images 生成请求转换为 Gemini generateContent 请求体。
+//
+// size（如 1536x1024）映射为最接近的 aspectRatio，并按长边映射为 imageSize；
+// imageSize 仅 Gemini 3 Pro Image 支持，其他模型固定输出 1K 并省略该字段（计费沿用原生端点的默认尺寸）。
+// quality 对 Gemini 无对应参数，忽略。
+func ConvertImagesRequestToGemini(req *ImagesGenerationRequest) ([]byte, error) {
+	aspectRatio := strings.TrimSpace(req.AspectRatio)
+	imageSize := req.ImageSize
+	if width, height, ok := parseImagesSize(req.Size); ok {
+		if aspectRatio == "" {
+			aspectRatio = nearestGeminiAspectRatio(width, height)
+		}
+		if imageSize == "" {
+			imageSize = imagesSizeTier(max(width, height))
+		}
+	}
+	if !supportsGeminiImageSize(req.Model) {
+		imageSize = ""
+	}
+
+	imageConfig := map[string]any{}
+	if aspectRatio != "" {
+		imageConfig["aspectRatio"] = aspectRatio
+	}
+	if imageSize != "" {
+		imageConfig["imageSize"] = imageSize
+	}
+	generationConfig := map[string]any{
+		"responseModalities": []string{"TEXT", "IMAGE"},
+	}
+	if len(imageConfig) > 0 {
+		generationConfig["imageConfig"] = imageConfig
+	}
+	return json.Marshal(map[string]any{
+		"contents": []any{
+			map[string]any{
+				"role":  "user",
+				"parts": []any{map[string]any{"text": req.Prompt}},
+			},
+		},
+		"generationConfig": generationConfig,
+	})
+}
+
+// ConvertGeminiToImagesData 从 Gemini generateContent 响应中提取第一张图片。
+// 每次上游调用按一张图片计费，因此只返回第一张；同一响应中的文本作为 revised_prompt。
+func ConvertGeminiToImagesData(body []byte, responseFormat string) (*ImagesData, error) {
+	var resp struct {
+		Response *json.RawMessage `json:"response"`
+	}
+	if err := json.Unmarshal(body, &resp); err == nil && resp.Response != nil {
+		body = *resp.Response
+	}
+
+	var gemini struct {
+		Candidates []struct {
+			Content struct {
+				Parts []struct {
+					Text       string `json:"text"`
+					Thought    bool   `json:"thought"`
+					InlineData *struct {
+						MimeType string `json:"mimeType"`
+						Data     string `json:"data"`
+					} `json:"inlineData"`
+				} `json:"parts"`
+			} `json:"content"`
+			FinishReason string `json:"finishReason"`
+		} `json:"candidates"`
+		PromptFeedback *struct {
+			BlockReason string `json:"blockReason"`
+		} `json:"promptFeedback"`
+	}
+	if err := json.Unmarshal(body, &gemini); err != nil {
+		return nil, err
+	}
+
+	var text strings.Builder
+	finishReason := ""
+	for _, cand := range gemini.Candidates {
+		if finishReason == "" {
+			finishReason = cand.FinishReason
+		}
+		for _, part := range cand.Content.Parts {
+			if part.Thought {
+				continue
+			}
+			if part.InlineData != nil && part.InlineData.Data != "" {
+				out := &ImagesData{RevisedPrompt: strings.TrimSpace(text.String())}
+				if responseFormat == ImagesResponseFormatURL {
+					mimeType := part.InlineData.MimeType
+					if mimeType == "" {
+						mimeType = "image/png"
+					}
+					out.URL = "data:" + mimeType + ";base64," + part.InlineData.Data
+				} else {
+					out.B64JSON = part.InlineData.Data
+				}
+				return out, nil
+			}
+			text.WriteString(part.Text)
+		}
+	}
+
+	reason := finishReason
+	if gemini.PromptFeedback != nil && gemini.PromptFeedback.BlockReason != "" {
+		reason = gemini.PromptFeedback.BlockReason
+	}
+	msg := "upstream returned no image"
+	if reason != "" {
+		msg += " (reason: " + reason + ")"
+	}
+	if t := strings.TrimSpace(text.String()); t != "" {
+		msg += ": " + t
+	}
+	return nil, errors.New(msg)
+}
+
+// parseImagesSize 解析 "WIDTHxHEIGHT" 格式的尺寸，"auto" 或空值返回 false
+func parseImagesSize(size string) (int, int, bool) {
+	w, h, ok := strings.Cut(strings.ToLower(strings.TrimSpace(size)), "x")
+	if !ok {
+		return 0, 0, false
+	}
+	width, err := strconv.Atoi(w)
+	if err != nil || width <= 0 {
+		return 0, 0, false
+	}
+	height, err := strconv.Atoi(h)
+	if err != nil || height <= 0 {
+		return 0, 0, false
+	}
+	return width, height, true
+}
+
+// nearestGeminiAspectRatio 选择与 width:height 最接近的 Gemini 宽高比
+func nearestGeminiAspectRatio(width, height int) string {
+	target := math.Log(float64(width) / float64(height))
+	best := geminiImageAspectRatios[0]
+	bestDiff := math.MaxFloat64
+	for _, ratio := range geminiImageAspectRatios {
+		a, b, _ := strings.Cut(ratio, ":")
+		aw, _ := strconv.Atoi(a)
+		bh, _ := strconv.Atoi(b)
+		diff := math.Abs(math.Log(float64(aw)/float64(bh)) - target)
+		if diff < bestDiff {
+			best, bestDiff = ratio, diff
+		}
+	}
+	return best
+}
+
+// imagesSizeTier 按图片长边映射为 Gemini imageSize 档位
+func imagesSizeTier(longSide int) string {
+	switch {
+	case longSide <= 1024:
+		return "1K"
+	case longSide <= 2048:
+		return "2K"
+	default:
+		return "4K"
+	}
+}
+
+// supportsGeminiImageSize 判断模型是否支持 imageConfig.imageSize
+func supportsGeminiImageSize(model string) bool {
+	return strings.HasPrefix(strings.TrimPrefix(strings.ToLower(model), "models/"), "gemini-3-pro-image")
+}
@@ -0,0 +1,83 @@
+//go:build unit
+
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImagesGenerationRequest(t *testing.T) {
+	req, err := ParseImagesGenerationRequest([]byte(`{"prompt":"a cat"}`))
+	require.NoError(t, err)
+	require.Equal(t, DefaultImagesModel, req.Model)
+	require.Equal(t, 1, req.N)
+	require.Equal(t, ImagesResponseFormatB64JSON, req.ResponseFormat)
+
+	req, err = ParseImagesGenerationRequest([]byte(`{"model":"gemini-3-pro-image-preview","prompt":"a cat","n":2,"response_format":"url","image_size":"4k"}`))
+	require.NoError(t, err)
+	require.Equal(t, 2, req.N)
+	require.Equal(t, "4K", req.ImageSize)
+
+	for _, body := range []string{
+		`{"prompt":""}`,
+		`{"prompt":"a cat","model":"gemini-2.5-pro"}`,
+		`{"prompt":"a cat","n":5}`,
+		`{"prompt":"a cat","response_format":"png"}`,
+		`{"prompt":"a cat","image_size":"8K"}`,
+	} {
+		_, err := ParseImagesGenerationRequest([]byte(body))
+		require.Error(t, err, body)
+	}
+}
+
+func TestConvertImagesRequestToGemini(t *testing.T) {
+	out, err := ConvertImagesRequestToGemini(&ImagesGenerationRequest{
+		Model:  "gemini-3-pro-image-preview",
+		Prompt: "a cat",
+		Size:   "1536x1024",
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"contents":[{"role":"user","parts":[{"text":"a cat"}]}],
+		"generationConfig":{"responseModalities":["TEXT","IMAGE"],"imageConfig":{"aspectRatio":"3:2","imageSize":"2K"}}
+	}`, string(out))
+
+	// 不支持 imageSize 的模型只保留宽高比
+	out, err = ConvertImagesRequestToGemini(&ImagesGenerationRequest{
+		Model:  "gemini-2.5-flash-image",
+		Prompt: "a cat",
+		Size:   "1024x1792",
+	})
+	require.NoError(t, err)
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(out, &payload))
+	require.Equal(t, map[string]any{"aspectRatio": "9:16"}, payload["generationConfig"].(map[string]any)["imageConfig"])
+
+	// auto 尺寸不设置 imageConfig
+	out, err = ConvertImagesRequestToGemini(&ImagesGenerationRequest{Model: "gemini-2.5-flash-image", Prompt: "a cat", Size: "auto"})
+	require.NoError(t, err)
+	require.NotContains(t, string(out), "imageConfig")
+}
+
+func TestConvertGeminiToImagesData(t *testing.T) {
+	body := []byte(`{"candidates":[{"content":{"parts":[{"text":"A cat"},{"inlineData":{"mimeType":"image/jpeg","data":"AAAA"}},{"inlineData":{"mimeType":"image/png","data":"BBBB"}}]},"finishReason":"STOP"}]}`)
+
+	img, err := ConvertGeminiToImagesData(body, ImagesResponseFormatB64JSON)
+	require.NoError(t, err)
+	require.Equal(t, &ImagesData{B64JSON: "AAAA", RevisedPrompt: "A cat"}, img)
+
+	img, err = ConvertGeminiToImagesData(body, ImagesResponseFormatURL)
+	require.NoError(t, err)
+	require.Equal(t, "data:image/jpeg;base64,AAAA", img.URL)
+
+	// v1internal 包装格式
+	img, err = ConvertGeminiToImagesData([]byte(`{"response":{"candidates":[{"content":{"parts":[{"inlineData":{"data":"CCCC"}}]}}]}}`), ImagesResponseFormatURL)
+	require.NoError(t, err)
+	require.Equal(t, "data:image/png;base64,CCCC", img.URL)
+
+	_, err = ConvertGeminiToImagesData([]byte(`{"candidates":[{"content":{"parts":[{"text":"I can't draw that"}]},"finishReason":"IMAGE_SAFETY"}]}`), ImagesResponseFormatB64JSON)
+	require.EqualError(t, err, "upstream returned no image (reason: IMAGE_SAFETY): I can't draw that")
+}
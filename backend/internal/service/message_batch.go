@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// Message Batch 批次处理状态（与 Anthropic processing_status 一致）
+const (
+	MessageBatchStatusInProgress = "in_progress"
+	MessageBatchStatusCanceling  = "canceling"
+	MessageBatchStatusEnded      = "ended"
+)
+
+// Message Batch 单条请求状态；succeeded/errored/canceled/expired 为结束状态，与结果文件中的 result.type 一致
+const (
+	MessageBatchItemStatusPending    = "pending"
+	MessageBatchItemStatusProcessing = "processing"
+	MessageBatchItemStatusSucceeded  = "succeeded"
+	MessageBatchItemStatusErrored    = "errored"
+	MessageBatchItemStatusCanceled   = "canceled"
+	MessageBatchItemStatusExpired    = "expired"
+)
+
+var (
+	ErrMessageBatchNotFound         = infraerrors.NotFound("MESSAGE_BATCH_NOT_FOUND", "message batch not found")
+	ErrMessageBatchDisabled         = infraerrors.Forbidden("MESSAGE_BATCH_DISABLED", "message batches are disabled")
+	ErrMessageBatchNotEnded         = infraerrors.Conflict("MESSAGE_BATCH_NOT_ENDED", "message batch results are not available until processing has ended")
+	ErrMessageBatchUnsupportedGroup = infraerrors.BadRequest("MESSAGE_BATCH_UNSUPPORTED_GROUP", "message batches are only supported for Claude-compatible groups")
+)
+
+// MessageBatch 一个 Message Batches API 批次
+type MessageBatch struct {
+	ID                int64
+	BatchID           string // 对外批次 ID（msgbatch_ 前缀）
+	UserID            int64
+	APIKeyID          int64
+	GroupID           *int64
+	ProcessingStatus  string
+	RequestCount      int
+	ExpiresAt         time.Time
+	CancelInitiatedAt *time.Time
+	EndedAt           *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+
+	// RequestCounts 各状态请求数（查询时聚合填充）
+	RequestCounts MessageBatchRequestCounts
+}
+
+// MessageBatchRequestCounts 批次内各状态请求数；processing 计入 Processing（对外与 pending 合并展示）
+type MessageBatchRequestCounts struct {
+	Processing int64
+	Succeeded  int64
+	Errored    int64
+	Canceled   int64
+	Expired    int64
+}
+
+// MessageBatchItem 批次内的单条请求
+type MessageBatchItem struct {
+	ID          int64
+	BatchID     int64 // message_batches.id
+	CustomID    string
+	Params      string // Messages API 请求体（JSON）
+	Status      string
+	Result      *string // 结束后的 result 对象（JSON）
+	Attempts    int
+	AccountID   *int64
+	LockedUntil *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// MessageBatchListParams 批次列表分页参数（按创建时间倒序，before_id/after_id 为对外批次 ID）
+type MessageBatchListParams struct {
+	Limit    int
+	BeforeID string
+	AfterID  string
+}
+
+// MessageBatchRepository 批次与批次请求的持久化接口
+type MessageBatchRepository interface {
+	// CreateBatch 在同一事务内写入批次及其全部请求，回填 ID 与时间字段
+	CreateBatch(ctx context.Context, batch *MessageBatch, items []*MessageBatchItem) error
+	GetBatch(ctx context.Context, id int64) (*MessageBatch, error)
+	// GetBatchByBatchID 按对外批次 ID 查询，仅返回属于 userID 的批次
+	GetBatchByBatchID(ctx context.Context, userID int64, batchID string) (*MessageBatch, error)
+	// ListBatches 按创建时间倒序列出用户批次，返回是否还有更多
+	ListBatches(ctx context.Context, userID int64, params MessageBatchListParams) ([]MessageBatch, bool, error)
+	// CountItemsByBatch 聚合各批次的请求状态计数
+	CountItemsByBatch(ctx context.Context, batchIDs []int64) (map[int64]MessageBatchRequestCounts, error)
+	// CancelBatch 将批次置为 canceling，并把尚未执行的请求标记为 canceled
+	CancelBatch(ctx context.Context, id int64) error
+
+	// ClaimItems 领取可执行的请求（pending 且未锁定，或 processing 但租约已过期），置为 processing 并加租约
+	ClaimItems(ctx context.Context, limit int, lease time.Duration) ([]MessageBatchItem, error)
+	// UpdateItem 写回请求执行结果（status/result/attempts/account_id/locked_until）
+	UpdateItem(ctx context.Context, item *MessageBatchItem) error
+	// ListItems 按 ID 顺序分页读取批次请求（用于导出结果）
+	ListItems(ctx context.Context, batchID int64, afterID int64, limit int) ([]MessageBatchItem, error)
+
+	// ExpireItems 将已过期批次中仍未执行的请求标记为 expired
+	ExpireItems(ctx context.Context) (int64, error)
+	// FinalizeBatches 将不再有 pending/processing 请求的批次置为 ended
+	FinalizeBatches(ctx context.Context) (int64, error)
+	// DeleteEndedBefore 删除在 cutoff 之前结束的批次（请求随外键级联删除）
+	DeleteEndedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
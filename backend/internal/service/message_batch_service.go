@@ -0,0 +1,810 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/domain"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const (
+	messageBatchIDPrefix          = "msgbatch_"
+	messageBatchDefaultListLimit  = 20
+	messageBatchMaxListLimit      = 1000
+	messageBatchResultsPageSize   = 500
+	messageBatchResponseLimit     = 16 << 20
+	messageBatchBusyRetryDelay    = 10 * time.Second
+	messageBatchRetryBackoff      = 30 * time.Second
+	messageBatchCleanupInterval   = time.Hour
+	messageBatchMaxAccountSwitch  = 3
+	messageBatchAnthropicVersion  = "2023-06-01"
+	messageBatchRequestUserAgent  = "sub2api-message-batch/1.0"
+	messageBatchErrorMessageLimit = 2048
+)
+
+var messageBatchCustomIDPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// MessageBatchRequest 创建批次时的单条请求
+type MessageBatchRequest struct {
+	CustomID string          `json:"custom_id"`
+	Params   json.RawMessage `json:"params"`
+}
+
+// MessageBatchService Message Batches API 服务
+//
+// 批次及其请求持久化到数据库，后台 worker 按租约领取请求，经正常的网关账号调度（含故障切换）
+// 以非流式方式转发，结果写回请求记录；每条成功请求按批处理折扣通过 RecordUsage 计费。
+// worker 只在本实例并发槽位空闲时领取，单实例同时执行的请求数不超过 message_batch.concurrency。
+type MessageBatchService struct {
+	repo                      MessageBatchRepository
+	apiKeyRepo                APIKeyRepository
+	gatewayService            *GatewayService
+	antigravityGatewayService *AntigravityGatewayService
+	geminiCompatService       *GeminiMessagesCompatService
+	subscriptionService       *SubscriptionService
+	billingCacheService       *BillingCacheService
+	apiKeyService             *APIKeyService
+	cfg                       *config.Config
+
+	sem    chan struct{}
+	kickCh chan struct{}
+	stopCh chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+
+	lastCleanupAt time.Time
+}
+
+// NewMessageBatchService 创建 Message Batches 服务
+func NewMessageBatchService(
+	repo MessageBatchRepository,
+	apiKeyRepo APIKeyRepository,
+	gatewayService *GatewayService,
+	antigravityGatewayService *AntigravityGatewayService,
+	geminiCompatService *GeminiMessagesCompatService,
+	subscriptionService *SubscriptionService,
+	billingCacheService *BillingCacheService,
+	apiKeyService *APIKeyService,
+	cfg *config.Config,
+) *MessageBatchService {
+	concurrency := 8
+	if cfg != nil && cfg.MessageBatch.Concurrency > 0 {
+		concurrency = cfg.MessageBatch.Concurrency
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &MessageBatchService{
+		repo:                      repo,
+		apiKeyRepo:                apiKeyRepo,
+		gatewayService:            gatewayService,
+		antigravityGatewayService: antigravityGatewayService,
+		geminiCompatService:       geminiCompatService,
+		subscriptionService:       subscriptionService,
+		billingCacheService:       billingCacheService,
+		apiKeyService:             apiKeyService,
+		cfg:                       cfg,
+		sem:                       make(chan struct{}, concurrency),
+		kickCh:                    make(chan struct{}, 1),
+		stopCh:                    make(chan struct{}),
+		ctx:                       ctx,
+		cancel:                    cancel,
+	}
+}
+
+// Enabled 是否启用批处理接口
+func (s *MessageBatchService) Enabled() bool {
+	return s != nil && s.repo != nil && s.cfg != nil && s.cfg.MessageBatch.Enabled
+}
+
+// Start 启动批处理执行 worker
+func (s *MessageBatchService) Start() {
+	if s == nil {
+		return
+	}
+	if !s.Enabled() || s.gatewayService == nil {
+		log.Printf("[MessageBatch] not started (disabled)")
+		return
+	}
+	s.startOnce.Do(func() {
+		s.wg.Add(1)
+		go s.runWorker()
+		log.Printf("[MessageBatch] started (interval=%s concurrency=%d)", s.workerInterval(), cap(s.sem))
+	})
+}
+
+// Stop 停止 worker 并中断执行中的请求（中断的请求重新置为 pending，由下次启动后继续执行）
+func (s *MessageBatchService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+		s.cancel()
+	})
+	s.wg.Wait()
+}
+
+func (s *MessageBatchService) workerInterval() time.Duration {
+	if s.cfg == nil || s.cfg.MessageBatch.WorkerIntervalSeconds <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(s.cfg.MessageBatch.WorkerIntervalSeconds) * time.Second
+}
+
+func (s *MessageBatchService) requestTimeout() time.Duration {
+	if s.cfg == nil || s.cfg.MessageBatch.RequestTimeoutSeconds <= 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(s.cfg.MessageBatch.RequestTimeoutSeconds) * time.Second
+}
+
+func (s *MessageBatchService) maxAttempts() int {
+	if s.cfg == nil || s.cfg.MessageBatch.MaxAttempts <= 0 {
+		return 3
+	}
+	return s.cfg.MessageBatch.MaxAttempts
+}
+
+func (s *MessageBatchService) maxRequestsPerBatch() int {
+	if s.cfg == nil || s.cfg.MessageBatch.MaxRequestsPerBatch <= 0 {
+		return 10000
+	}
+	return s.cfg.MessageBatch.MaxRequestsPerBatch
+}
+
+func (s *MessageBatchService) expiration() time.Duration {
+	if s.cfg == nil || s.cfg.MessageBatch.ExpirationHours <= 0 {
+		return 24 * time.Hour
+	}
+	return time.Duration(s.cfg.MessageBatch.ExpirationHours) * time.Hour
+}
+
+func (s *MessageBatchService) maxAccountSwitches() int {
+	if s.cfg != nil && s.cfg.Gateway.MaxAccountSwitches > 0 {
+		return s.cfg.Gateway.MaxAccountSwitches
+	}
+	return messageBatchMaxAccountSwitch
+}
+
+// applyMessageBatchDiscount 按批处理折扣调整各项费用
+func applyMessageBatchDiscount(cost *CostBreakdown, cfg *config.Config) {
+	if cost == nil || cfg == nil {
+		return
+	}
+	cost.Scale(1 - cfg.MessageBatch.Discount)
+}
+
+// ===== 批次管理 =====
+
+// CreateBatch 校验并创建批次；请求在 API Key 当前分组下执行
+func (s *MessageBatchService) CreateBatch(ctx context.Context, apiKey *APIKey, requests []MessageBatchRequest) (*MessageBatch, error) {
+	if !s.Enabled() {
+		return nil, ErrMessageBatchDisabled
+	}
+	if apiKey == nil {
+		return nil, infraerrors.Unauthorized("INVALID_API_KEY", "invalid api key")
+	}
+	if apiKey.Group != nil && apiKey.Group.Platform == PlatformOpenAI {
+		return nil, ErrMessageBatchUnsupportedGroup
+	}
+	if len(requests) == 0 {
+		return nil, messageBatchInvalidRequest("requests: at least one request is required")
+	}
+	if limit := s.maxRequestsPerBatch(); len(requests) > limit {
+		return nil, messageBatchInvalidRequest(fmt.Sprintf("requests: a batch may contain at most %d requests", limit))
+	}
+
+	seen := make(map[string]struct{}, len(requests))
+	items := make([]*MessageBatchItem, 0, len(requests))
+	for i := range requests {
+		req := &requests[i]
+		if !messageBatchCustomIDPattern.MatchString(req.CustomID) {
+			return nil, messageBatchInvalidRequest(fmt.Sprintf("requests.%d.custom_id: must be 1-64 characters of letters, digits, underscores or hyphens", i))
+		}
+		if _, ok := seen[req.CustomID]; ok {
+			return nil, messageBatchInvalidRequest(fmt.Sprintf("requests.%d.custom_id: duplicate custom_id %q", i, req.CustomID))
+		}
+		seen[req.CustomID] = struct{}{}
+
+		if err := validateMessageBatchParams(req.Params); err != nil {
+			return nil, messageBatchInvalidRequest(fmt.Sprintf("requests.%d.params: %s", i, err.Error()))
+		}
+		items = append(items, &MessageBatchItem{
+			CustomID: req.CustomID,
+			Params:   string(req.Params),
+			Status:   MessageBatchItemStatusPending,
+		})
+	}
+
+	batch := &MessageBatch{
+		BatchID:          messageBatchIDPrefix + strings.ReplaceAll(uuid.NewString(), "-", ""),
+		UserID:           apiKey.UserID,
+		APIKeyID:         apiKey.ID,
+		GroupID:          apiKey.GroupID,
+		ProcessingStatus: MessageBatchStatusInProgress,
+		RequestCount:     len(items),
+		ExpiresAt:        time.Now().Add(s.expiration()),
+	}
+	if err := s.repo.CreateBatch(ctx, batch, items); err != nil {
+		return nil, err
+	}
+	batch.RequestCounts = MessageBatchRequestCounts{Processing: int64(len(items))}
+	s.kick()
+	return batch, nil
+}
+
+// validateMessageBatchParams 校验单条 Messages 请求：必须包含 model，且不支持流式
+func validateMessageBatchParams(params json.RawMessage) error {
+	if len(bytes.TrimSpace(params)) == 0 {
+		return errors.New("params is required")
+	}
+	parsed, err := ParseGatewayRequest(params, domain.PlatformAnthropic)
+	if err != nil {
+		return errors.New("params must be a valid Messages API request object")
+	}
+	if parsed.Model == "" {
+		return errors.New("model is required")
+	}
+	if parsed.Stream {
+		return errors.New("streaming is not supported in message batches")
+	}
+	return nil
+}
+
+func messageBatchInvalidRequest(message string) error {
+	return infraerrors.BadRequest("MESSAGE_BATCH_INVALID_REQUEST", message)
+}
+
+// GetBatch 获取用户的批次（含请求计数）
+func (s *MessageBatchService) GetBatch(ctx context.Context, userID int64, batchID string) (*MessageBatch, error) {
+	if !s.Enabled() {
+		return nil, ErrMessageBatchDisabled
+	}
+	batch, err := s.repo.GetBatchByBatchID(ctx, userID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fillRequestCounts(ctx, []*MessageBatch{batch}); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// ListBatches 按创建时间倒序列出用户批次（含请求计数）
+func (s *MessageBatchService) ListBatches(ctx context.Context, userID int64, params MessageBatchListParams) ([]MessageBatch, bool, error) {
+	if !s.Enabled() {
+		return nil, false, ErrMessageBatchDisabled
+	}
+	if params.Limit <= 0 {
+		params.Limit = messageBatchDefaultListLimit
+	}
+	if params.Limit > messageBatchMaxListLimit {
+		params.Limit = messageBatchMaxListLimit
+	}
+	batches, hasMore, err := s.repo.ListBatches(ctx, userID, params)
+	if err != nil {
+		return nil, false, err
+	}
+	refs := make([]*MessageBatch, 0, len(batches))
+	for i := range batches {
+		refs = append(refs, &batches[i])
+	}
+	if err := s.fillRequestCounts(ctx, refs); err != nil {
+		return nil, false, err
+	}
+	return batches, hasMore, nil
+}
+
+func (s *MessageBatchService) fillRequestCounts(ctx context.Context, batches []*MessageBatch) error {
+	if len(batches) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(batches))
+	for _, batch := range batches {
+		ids = append(ids, batch.ID)
+	}
+	counts, err := s.repo.CountItemsByBatch(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, batch := range batches {
+		batch.RequestCounts = counts[batch.ID]
+	}
+	return nil
+}
+
+// CancelBatch 取消批次：尚未执行的请求标记为 canceled，执行中的请求完成后批次结束；已结束的批次原样返回
+func (s *MessageBatchService) CancelBatch(ctx context.Context, userID int64, batchID string) (*MessageBatch, error) {
+	if !s.Enabled() {
+		return nil, ErrMessageBatchDisabled
+	}
+	batch, err := s.repo.GetBatchByBatchID(ctx, userID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.ProcessingStatus == MessageBatchStatusInProgress {
+		if err := s.repo.CancelBatch(ctx, batch.ID); err != nil {
+			return nil, err
+		}
+		s.kick()
+	}
+	return s.GetBatch(ctx, userID, batchID)
+}
+
+// GetEndedBatch 获取已结束的批次（下载结果前调用）
+func (s *MessageBatchService) GetEndedBatch(ctx context.Context, userID int64, batchID string) (*MessageBatch, error) {
+	batch, err := s.GetBatch(ctx, userID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if batch.ProcessingStatus != MessageBatchStatusEnded {
+		return nil, ErrMessageBatchNotEnded
+	}
+	return batch, nil
+}
+
+// StreamResults 按请求提交顺序逐行输出 JSONL 结果：{"custom_id":...,"result":{...}}
+func (s *MessageBatchService) StreamResults(ctx context.Context, batch *MessageBatch, write func(line []byte) error) error {
+	var afterID int64
+	for {
+		items, err := s.repo.ListItems(ctx, batch.ID, afterID, messageBatchResultsPageSize)
+		if err != nil {
+			return err
+		}
+		for i := range items {
+			line, err := messageBatchResultLine(&items[i])
+			if err != nil {
+				return err
+			}
+			if err := write(line); err != nil {
+				return err
+			}
+			afterID = items[i].ID
+		}
+		if len(items) < messageBatchResultsPageSize {
+			return nil
+		}
+	}
+}
+
+func messageBatchResultLine(item *MessageBatchItem) ([]byte, error) {
+	result := json.RawMessage(`{"type":"` + item.Status + `"}`)
+	if item.Result != nil && json.Valid([]byte(*item.Result)) {
+		result = json.RawMessage(*item.Result)
+	}
+	line, err := json.Marshal(struct {
+		CustomID string          `json:"custom_id"`
+		Result   json.RawMessage `json:"result"`
+	}{CustomID: item.CustomID, Result: result})
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
+
+// ===== 执行 worker =====
+
+func (s *MessageBatchService) kick() {
+	select {
+	case s.kickCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *MessageBatchService) runWorker() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.workerInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.kickCh:
+		case <-s.stopCh:
+			return
+		}
+		s.runOnce()
+	}
+}
+
+func (s *MessageBatchService) runOnce() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if _, err := s.repo.ExpireItems(ctx); err != nil {
+		log.Printf("[MessageBatch] expire items failed: %v", err)
+	}
+	if _, err := s.repo.FinalizeBatches(ctx); err != nil {
+		log.Printf("[MessageBatch] finalize batches failed: %v", err)
+	}
+	cancel()
+
+	s.claimAndExecute()
+	s.cleanupBatches()
+}
+
+// claimAndExecute 按空闲槽位数领取请求并异步执行；每条请求结束后唤醒 worker 继续领取
+func (s *MessageBatchService) claimAndExecute() {
+	free := cap(s.sem) - len(s.sem)
+	if free <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	items, err := s.repo.ClaimItems(ctx, free, s.requestTimeout()+time.Minute)
+	cancel()
+	if err != nil {
+		log.Printf("[MessageBatch] claim items failed: %v", err)
+		return
+	}
+	for i := range items {
+		item := items[i]
+		s.sem <- struct{}{}
+		s.wg.Add(1)
+		go func() {
+			defer func() {
+				<-s.sem
+				s.wg.Done()
+				s.kick()
+			}()
+			s.executeItem(&item)
+		}()
+	}
+}
+
+func (s *MessageBatchService) executeItem(item *MessageBatchItem) {
+	ctx, cancel := context.WithTimeout(s.ctx, s.requestTimeout())
+	defer cancel()
+
+	batch, err := s.repo.GetBatch(ctx, item.BatchID)
+	if err != nil {
+		log.Printf("[MessageBatch] load batch %d for item %d failed: %v", item.BatchID, item.ID, err)
+		return
+	}
+	if batch.ProcessingStatus != MessageBatchStatusInProgress {
+		s.finishItem(item, MessageBatchItemStatusCanceled, nil)
+		return
+	}
+	if !time.Now().Before(batch.ExpiresAt) {
+		s.finishItem(item, MessageBatchItemStatusExpired, nil)
+		return
+	}
+
+	apiKey, subscription, callerErr := s.resolveCaller(ctx, batch)
+	if callerErr != nil {
+		s.finishItem(item, MessageBatchItemStatusErrored, callerErr)
+		return
+	}
+
+	body := []byte(item.Params)
+	parsed, err := ParseGatewayRequest(body, domain.PlatformAnthropic)
+	if err != nil {
+		s.finishItem(item, MessageBatchItemStatusErrored, newMessageBatchError(http.StatusBadRequest, "Failed to parse request body"))
+		return
+	}
+
+	item.Attempts++
+	outcome := s.forward(ctx, apiKey, body, parsed)
+	if s.ctx.Err() != nil {
+		// 服务停止：中断的请求放回队列
+		s.requeueItem(item, 0)
+		return
+	}
+
+	switch {
+	case outcome.busy:
+		// 分组内账号并发已满：不计入尝试次数，稍后重新领取
+		item.Attempts--
+		s.requeueItem(item, messageBatchBusyRetryDelay)
+	case outcome.message != nil:
+		item.AccountID = &outcome.account.ID
+		s.finishItem(item, MessageBatchItemStatusSucceeded, outcome.message)
+	case outcome.retryable && item.Attempts < s.maxAttempts():
+		s.requeueItem(item, time.Duration(item.Attempts)*messageBatchRetryBackoff)
+	default:
+		if outcome.account != nil {
+			item.AccountID = &outcome.account.ID
+		}
+		s.finishItem(item, MessageBatchItemStatusErrored, outcome.errBody)
+	}
+	// 上游已返回用量（包括结果过大/无法解析而标记为 errored 的请求）时照常计费
+	s.recordUsage(apiKey, subscription, outcome)
+}
+
+// resolveCaller 按批次创建时的 API Key 重新校验调用方（Key/用户状态、订阅与余额），返回 errored 结果体
+func (s *MessageBatchService) resolveCaller(ctx context.Context, batch *MessageBatch) (*APIKey, *UserSubscription, json.RawMessage) {
+	apiKey, err := s.apiKeyRepo.GetByID(ctx, batch.APIKeyID)
+	if err != nil {
+		if errors.Is(err, ErrAPIKeyNotFound) {
+			return nil, nil, newMessageBatchError(http.StatusUnauthorized, "API key no longer exists")
+		}
+		return nil, nil, newMessageBatchError(http.StatusInternalServerError, "Failed to load API key")
+	}
+	if !apiKey.IsActive() || apiKey.IsExpired() || apiKey.IsQuotaExhausted() {
+		return nil, nil, newMessageBatchError(http.StatusUnauthorized, "API key is disabled, expired or out of quota")
+	}
+	if apiKey.User == nil || !apiKey.User.IsActive() {
+		return nil, nil, newMessageBatchError(http.StatusUnauthorized, "User account is not active")
+	}
+	if s.cfg != nil && s.cfg.RunMode == config.RunModeSimple {
+		return apiKey, nil, nil
+	}
+
+	var subscription *UserSubscription
+	if apiKey.Group != nil && apiKey.Group.IsSubscriptionType() && s.subscriptionService != nil {
+		subscription, err = s.subscriptionService.GetActiveSubscription(ctx, apiKey.User.ID, apiKey.Group.ID)
+		if err != nil {
+			return nil, nil, newMessageBatchError(http.StatusForbidden, "No active subscription found for this group")
+		}
+		if err := s.subscriptionService.ValidateSubscription(ctx, subscription); err != nil {
+			return nil, nil, newMessageBatchError(http.StatusForbidden, err.Error())
+		}
+	}
+	if s.billingCacheService != nil {
+		if err := s.billingCacheService.CheckBillingEligibility(ctx, apiKey.User, apiKey, apiKey.Group, subscription); err != nil {
+			msg := infraerrors.Message(err)
+			if msg == "" {
+				msg = err.Error()
+			}
+			return nil, nil, newMessageBatchError(http.StatusForbidden, msg)
+		}
+	}
+	return apiKey, subscription, nil
+}
+
+// messageBatchOutcome 一次请求执行的结果
+type messageBatchOutcome struct {
+	account   *Account
+	result    *ForwardResult
+	message   json.RawMessage // 成功时的 result 对象
+	errBody   json.RawMessage // 失败时的 result 对象
+	retryable bool            // 无可用账号或故障切换用尽，可稍后重试
+	busy      bool            // 所有可用账号并发已满
+}
+
+// forward 经正常账号调度转发单条请求，遇可切换的上游错误时换号重试
+func (s *MessageBatchService) forward(ctx context.Context, apiKey *APIKey, body []byte, parsed *ParsedRequest) *messageBatchOutcome {
+	ctx = context.WithValue(ctx, ctxkey.ThinkingEnabled, parsed.ThinkingEnabled)
+	// 分组上下文供 Forward 读取分组请求策略
+	if apiKey.Group != nil {
+		ctx = context.WithValue(ctx, ctxkey.Group, apiKey.Group)
+	}
+	platform := ""
+	if apiKey.Group != nil {
+		platform = apiKey.Group.Platform
+	}
+
+	excluded := make(map[int64]struct{})
+	switches := 0
+	sawBusy := false
+	var last *messageBatchOutcome
+	for {
+		selection, err := s.gatewayService.SelectAccountWithLoadAwareness(ctx, apiKey.GroupID, "", parsed.Model, excluded, "")
+		if err != nil {
+			if errors.Is(err, ErrClaudeCodeOnly) {
+				return &messageBatchOutcome{errBody: newMessageBatchError(http.StatusForbidden, err.Error())}
+			}
+			if last != nil {
+				return last
+			}
+			if sawBusy {
+				return &messageBatchOutcome{busy: true}
+			}
+			return &messageBatchOutcome{retryable: true, errBody: newMessageBatchError(http.StatusServiceUnavailable, "No available accounts: "+err.Error())}
+		}
+		account := selection.Account
+		if !selection.Acquired || selection.ReleaseFunc == nil {
+			// 批处理不参与账号排队，换下一个账号
+			excluded[account.ID] = struct{}{}
+			sawBusy = true
+			continue
+		}
+
+		attemptCtx := ctx
+		if switches > 0 {
+			attemptCtx = context.WithValue(attemptCtx, ctxkey.AccountSwitchCount, switches)
+		}
+		c, w := newMessageBatchContext(attemptCtx)
+		var result *ForwardResult
+		switch {
+		case platform == PlatformGemini:
+			result, err = s.geminiCompatService.Forward(attemptCtx, c, account, body)
+		case account.Platform == PlatformAntigravity && account.Type != AccountTypeAPIKey:
+			result, err = s.antigravityGatewayService.Forward(attemptCtx, c, account, body, false)
+		default:
+			result, err = s.gatewayService.Forward(attemptCtx, c, account, parsed)
+		}
+		selection.ReleaseFunc()
+
+		statusCode := c.Writer.Status()
+		if err == nil && statusCode < http.StatusBadRequest {
+			if w.truncated() || !json.Valid(w.bodyBytes()) {
+				return &messageBatchOutcome{account: account, result: result, errBody: newMessageBatchError(http.StatusInternalServerError, "Upstream response is too large or malformed")}
+			}
+			message, _ := json.Marshal(map[string]json.RawMessage{
+				"type":    json.RawMessage(`"succeeded"`),
+				"message": json.RawMessage(w.bodyBytes()),
+			})
+			return &messageBatchOutcome{account: account, result: result, message: message}
+		}
+
+		var failoverErr *UpstreamFailoverError
+		if errors.As(err, &failoverErr) {
+			excluded[account.ID] = struct{}{}
+			last = &messageBatchOutcome{
+				account:   account,
+				retryable: true,
+				errBody:   messageBatchErrorFromBody(failoverErr.StatusCode, failoverErr.ResponseBody),
+			}
+			if switches >= s.maxAccountSwitches() {
+				return last
+			}
+			switches++
+			continue
+		}
+		if ctx.Err() != nil {
+			return &messageBatchOutcome{account: account, retryable: true, errBody: newMessageBatchError(http.StatusGatewayTimeout, "Request timed out")}
+		}
+		if statusCode < http.StatusBadRequest {
+			statusCode = http.StatusBadGateway
+		}
+		return &messageBatchOutcome{account: account, result: result, errBody: messageBatchErrorFromBody(statusCode, w.bodyBytes())}
+	}
+}
+
+// newMessageBatchContext 构造转发用的 gin.Context；响应写入内存供解析结果
+func newMessageBatchContext(ctx context.Context) (*gin.Context, *limitedResponseWriter) {
+	w := newLimitedResponseWriter(messageBatchResponseLimit)
+	c, _ := gin.CreateTestContext(w)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "http://localhost/v1/messages", bytes.NewReader(nil))
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("anthropic-version", messageBatchAnthropicVersion)
+	req.Header.Set("user-agent", messageBatchRequestUserAgent)
+	c.Request = req
+	return c, w
+}
+
+func (s *MessageBatchService) recordUsage(apiKey *APIKey, subscription *UserSubscription, outcome *messageBatchOutcome) {
+	if outcome.result == nil || outcome.account == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.gatewayService.RecordUsage(ctx, &RecordUsageInput{
+		Result:        outcome.result,
+		APIKey:        apiKey,
+		User:          apiKey.User,
+		Account:       outcome.account,
+		Subscription:  subscription,
+		UserAgent:     messageBatchRequestUserAgent,
+		MessageBatch:  true,
+		APIKeyService: s.apiKeyService,
+	}); err != nil {
+		log.Printf("[MessageBatch] record usage failed: %v", err)
+	}
+}
+
+func (s *MessageBatchService) finishItem(item *MessageBatchItem, status string, result json.RawMessage) {
+	item.Status = status
+	item.LockedUntil = nil
+	item.Result = nil
+	if len(result) > 0 {
+		raw := string(result)
+		item.Result = &raw
+	}
+	s.saveItem(item)
+}
+
+func (s *MessageBatchService) requeueItem(item *MessageBatchItem, delay time.Duration) {
+	item.Status = MessageBatchItemStatusPending
+	item.LockedUntil = nil
+	if delay > 0 {
+		next := time.Now().Add(delay)
+		item.LockedUntil = &next
+	}
+	s.saveItem(item)
+}
+
+func (s *MessageBatchService) saveItem(item *MessageBatchItem) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.repo.UpdateItem(ctx, item); err != nil {
+		log.Printf("[MessageBatch] update item %d failed: %v", item.ID, err)
+	}
+}
+
+func (s *MessageBatchService) cleanupBatches() {
+	if s.cfg == nil || s.cfg.MessageBatch.RetentionDays <= 0 {
+		return
+	}
+	now := time.Now()
+	if now.Sub(s.lastCleanupAt) < messageBatchCleanupInterval {
+		return
+	}
+	s.lastCleanupAt = now
+	cutoff := now.AddDate(0, 0, -s.cfg.MessageBatch.RetentionDays)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	deleted, err := s.repo.DeleteEndedBefore(ctx, cutoff)
+	if err != nil {
+		log.Printf("[MessageBatch] cleanup failed: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("[MessageBatch] cleaned up %d ended batches", deleted)
+	}
+}
+
+// ===== 结果格式 =====
+
+// newMessageBatchError 构造 errored 结果：{"type":"errored","error":{"type":"error","error":{...}}}
+func newMessageBatchError(statusCode int, message string) json.RawMessage {
+	return buildMessageBatchError(messageBatchErrorType(statusCode), message)
+}
+
+func buildMessageBatchError(errType, message string) json.RawMessage {
+	raw, _ := json.Marshal(map[string]any{
+		"type": MessageBatchItemStatusErrored,
+		"error": map[string]any{
+			"type": "error",
+			"error": map[string]string{
+				"type":    errType,
+				"message": truncateString(message, messageBatchErrorMessageLimit),
+			},
+		},
+	})
+	return raw
+}
+
+// messageBatchErrorFromBody 优先沿用上游返回的 Anthropic 错误对象，否则按状态码构造
+func messageBatchErrorFromBody(statusCode int, body []byte) json.RawMessage {
+	var upstream struct {
+		Type  string `json:"type"`
+		Error struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &upstream); err == nil && upstream.Type == "error" && upstream.Error.Type != "" {
+		return buildMessageBatchError(upstream.Error.Type, upstream.Error.Message)
+	}
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = fmt.Sprintf("Upstream request failed with status %d", statusCode)
+	}
+	return newMessageBatchError(statusCode, message)
+}
+
+func messageBatchErrorType(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return "invalid_request_error"
+	case http.StatusUnauthorized:
+		return "authentication_error"
+	case http.StatusForbidden:
+		return "permission_error"
+	case http.StatusNotFound:
+		return "not_found_error"
+	case http.StatusRequestEntityTooLarge:
+		return "request_too_large"
+	case http.StatusTooManyRequests:
+		return "rate_limit_error"
+	case 529, http.StatusServiceUnavailable:
+		return "overloaded_error"
+	default:
+		return "api_error"
+	}
+}
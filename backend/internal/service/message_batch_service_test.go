@@ -0,0 +1,305 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type messageBatchRepoStub struct {
+	batches map[int64]*MessageBatch
+	items   []*MessageBatchItem
+	counts  map[int64]MessageBatchRequestCounts
+	updated []MessageBatchItem
+
+	canceled   []int64
+	listParams MessageBatchListParams
+}
+
+func newMessageBatchRepoStub() *messageBatchRepoStub {
+	return &messageBatchRepoStub{
+		batches: map[int64]*MessageBatch{},
+		counts:  map[int64]MessageBatchRequestCounts{},
+	}
+}
+
+func (r *messageBatchRepoStub) CreateBatch(ctx context.Context, batch *MessageBatch, items []*MessageBatchItem) error {
+	batch.ID = int64(len(r.batches) + 1)
+	for _, item := range items {
+		item.ID = int64(len(r.items) + 1)
+		item.BatchID = batch.ID
+		r.items = append(r.items, item)
+	}
+	clone := *batch
+	r.batches[batch.ID] = &clone
+	return nil
+}
+
+func (r *messageBatchRepoStub) GetBatch(ctx context.Context, id int64) (*MessageBatch, error) {
+	if batch, ok := r.batches[id]; ok {
+		clone := *batch
+		return &clone, nil
+	}
+	return nil, ErrMessageBatchNotFound
+}
+
+func (r *messageBatchRepoStub) GetBatchByBatchID(ctx context.Context, userID int64, batchID string) (*MessageBatch, error) {
+	for _, batch := range r.batches {
+		if batch.BatchID == batchID && batch.UserID == userID {
+			clone := *batch
+			return &clone, nil
+		}
+	}
+	return nil, ErrMessageBatchNotFound
+}
+
+func (r *messageBatchRepoStub) ListBatches(ctx context.Context, userID int64, params MessageBatchListParams) ([]MessageBatch, bool, error) {
+	r.listParams = params
+	out := make([]MessageBatch, 0)
+	for _, batch := range r.batches {
+		if batch.UserID == userID {
+			out = append(out, *batch)
+		}
+	}
+	return out, false, nil
+}
+
+func (r *messageBatchRepoStub) CountItemsByBatch(ctx context.Context, batchIDs []int64) (map[int64]MessageBatchRequestCounts, error) {
+	return r.counts, nil
+}
+
+func (r *messageBatchRepoStub) CancelBatch(ctx context.Context, id int64) error {
+	r.canceled = append(r.canceled, id)
+	r.batches[id].ProcessingStatus = MessageBatchStatusCanceling
+	return nil
+}
+
+func (r *messageBatchRepoStub) ClaimItems(ctx context.Context, limit int, lease time.Duration) ([]MessageBatchItem, error) {
+	return nil, nil
+}
+
+func (r *messageBatchRepoStub) UpdateItem(ctx context.Context, item *MessageBatchItem) error {
+	r.updated = append(r.updated, *item)
+	return nil
+}
+
+func (r *messageBatchRepoStub) ListItems(ctx context.Context, batchID int64, afterID int64, limit int) ([]MessageBatchItem, error) {
+	out := make([]MessageBatchItem, 0)
+	for _, item := range r.items {
+		if item.BatchID == batchID && item.ID > afterID && len(out) < limit {
+			out = append(out, *item)
+		}
+	}
+	return out, nil
+}
+
+func (r *messageBatchRepoStub) ExpireItems(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (r *messageBatchRepoStub) FinalizeBatches(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (r *messageBatchRepoStub) DeleteEndedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+func newTestMessageBatchService(repo MessageBatchRepository, apiKeyRepo APIKeyRepository) *MessageBatchService {
+	cfg := &config.Config{}
+	cfg.MessageBatch.Enabled = true
+	cfg.MessageBatch.MaxRequestsPerBatch = 3
+	return NewMessageBatchService(repo, apiKeyRepo, nil, nil, nil, nil, nil, nil, cfg)
+}
+
+func messageBatchParams(extra string) json.RawMessage {
+	return json.RawMessage(`{"model":"claude-sonnet-4-5","max_tokens":16,"messages":[{"role":"user","content":"hi"}]` + extra + `}`)
+}
+
+func TestMessageBatchServiceCreateBatch(t *testing.T) {
+	repo := newMessageBatchRepoStub()
+	svc := newTestMessageBatchService(repo, nil)
+	groupID := int64(5)
+	apiKey := &APIKey{ID: 9, UserID: 7, GroupID: &groupID, Group: &Group{ID: groupID, Platform: PlatformAnthropic}}
+
+	batch, err := svc.CreateBatch(context.Background(), apiKey, []MessageBatchRequest{
+		{CustomID: "a-1", Params: messageBatchParams("")},
+		{CustomID: "b_2", Params: messageBatchParams(`,"temperature":0`)},
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(batch.BatchID, messageBatchIDPrefix))
+	require.Equal(t, MessageBatchStatusInProgress, batch.ProcessingStatus)
+	require.Equal(t, int64(7), batch.UserID)
+	require.Equal(t, int64(9), batch.APIKeyID)
+	require.Equal(t, &groupID, batch.GroupID)
+	require.Equal(t, 2, batch.RequestCount)
+	require.Equal(t, int64(2), batch.RequestCounts.Processing)
+	require.WithinDuration(t, time.Now().Add(24*time.Hour), batch.ExpiresAt, time.Minute)
+	require.Len(t, repo.items, 2)
+	require.Equal(t, MessageBatchItemStatusPending, repo.items[0].Status)
+	require.Equal(t, "b_2", repo.items[1].CustomID)
+}
+
+func TestMessageBatchServiceCreateBatchValidation(t *testing.T) {
+	svc := newTestMessageBatchService(newMessageBatchRepoStub(), nil)
+	apiKey := &APIKey{ID: 1, UserID: 1}
+
+	cases := map[string][]MessageBatchRequest{
+		"empty":             {},
+		"too many":          {{CustomID: "a", Params: messageBatchParams("")}, {CustomID: "b", Params: messageBatchParams("")}, {CustomID: "c", Params: messageBatchParams("")}, {CustomID: "d", Params: messageBatchParams("")}},
+		"bad custom_id":     {{CustomID: "has space", Params: messageBatchParams("")}},
+		"long custom_id":    {{CustomID: strings.Repeat("x", 65), Params: messageBatchParams("")}},
+		"duplicate":         {{CustomID: "a", Params: messageBatchParams("")}, {CustomID: "a", Params: messageBatchParams("")}},
+		"missing params":    {{CustomID: "a"}},
+		"missing model":     {{CustomID: "a", Params: json.RawMessage(`{"max_tokens":1,"messages":[]}`)}},
+		"stream":            {{CustomID: "a", Params: messageBatchParams(`,"stream":true`)}},
+		"params not object": {{CustomID: "a", Params: json.RawMessage(`"text"`)}},
+	}
+	for name, requests := range cases {
+		_, err := svc.CreateBatch(context.Background(), apiKey, requests)
+		require.Error(t, err, name)
+	}
+
+	// OpenAI 分组不支持 Messages 批处理
+	_, err := svc.CreateBatch(context.Background(), &APIKey{ID: 1, UserID: 1, Group: &Group{Platform: PlatformOpenAI}}, []MessageBatchRequest{{CustomID: "a", Params: messageBatchParams("")}})
+	require.ErrorIs(t, err, ErrMessageBatchUnsupportedGroup)
+
+	// 未启用
+	svc.cfg.MessageBatch.Enabled = false
+	_, err = svc.CreateBatch(context.Background(), apiKey, []MessageBatchRequest{{CustomID: "a", Params: messageBatchParams("")}})
+	require.ErrorIs(t, err, ErrMessageBatchDisabled)
+}
+
+func TestMessageBatchServiceGetListAndCancel(t *testing.T) {
+	ctx := context.Background()
+	repo := newMessageBatchRepoStub()
+	repo.batches[1] = &MessageBatch{ID: 1, BatchID: "msgbatch_a", UserID: 7, ProcessingStatus: MessageBatchStatusInProgress}
+	repo.batches[2] = &MessageBatch{ID: 2, BatchID: "msgbatch_b", UserID: 7, ProcessingStatus: MessageBatchStatusEnded}
+	repo.counts[1] = MessageBatchRequestCounts{Processing: 2, Succeeded: 1}
+	svc := newTestMessageBatchService(repo, nil)
+
+	// 其他用户不可见
+	_, err := svc.GetBatch(ctx, 8, "msgbatch_a")
+	require.ErrorIs(t, err, ErrMessageBatchNotFound)
+
+	batch, err := svc.GetBatch(ctx, 7, "msgbatch_a")
+	require.NoError(t, err)
+	require.Equal(t, MessageBatchRequestCounts{Processing: 2, Succeeded: 1}, batch.RequestCounts)
+
+	batches, _, err := svc.ListBatches(ctx, 7, MessageBatchListParams{Limit: 5000})
+	require.NoError(t, err)
+	require.Len(t, batches, 2)
+	require.Equal(t, messageBatchMaxListLimit, repo.listParams.Limit)
+	_, _, err = svc.ListBatches(ctx, 7, MessageBatchListParams{})
+	require.NoError(t, err)
+	require.Equal(t, messageBatchDefaultListLimit, repo.listParams.Limit)
+
+	batch, err = svc.CancelBatch(ctx, 7, "msgbatch_a")
+	require.NoError(t, err)
+	require.Equal(t, MessageBatchStatusCanceling, batch.ProcessingStatus)
+	require.Equal(t, []int64{1}, repo.canceled)
+
+	// 已结束/取消中的批次不重复取消
+	_, err = svc.CancelBatch(ctx, 7, "msgbatch_a")
+	require.NoError(t, err)
+	_, err = svc.CancelBatch(ctx, 7, "msgbatch_b")
+	require.NoError(t, err)
+	require.Equal(t, []int64{1}, repo.canceled)
+
+	// 结果仅在批次结束后可下载
+	_, err = svc.GetEndedBatch(ctx, 7, "msgbatch_a")
+	require.ErrorIs(t, err, ErrMessageBatchNotEnded)
+	_, err = svc.GetEndedBatch(ctx, 7, "msgbatch_b")
+	require.NoError(t, err)
+}
+
+func TestMessageBatchServiceStreamResults(t *testing.T) {
+	repo := newMessageBatchRepoStub()
+	succeeded := `{"type":"succeeded","message":{"id":"msg_1"}}`
+	repo.items = []*MessageBatchItem{
+		{ID: 1, BatchID: 1, CustomID: "a", Status: MessageBatchItemStatusSucceeded, Result: &succeeded},
+		{ID: 2, BatchID: 1, CustomID: "b", Status: MessageBatchItemStatusCanceled},
+		{ID: 3, BatchID: 2, CustomID: "other", Status: MessageBatchItemStatusExpired},
+		{ID: 4, BatchID: 1, CustomID: "c", Status: MessageBatchItemStatusExpired},
+	}
+	svc := newTestMessageBatchService(repo, nil)
+
+	var out strings.Builder
+	err := svc.StreamResults(context.Background(), &MessageBatch{ID: 1}, func(line []byte) error {
+		out.Write(line)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t,
+		`{"custom_id":"a","result":{"type":"succeeded","message":{"id":"msg_1"}}}`+"\n"+
+			`{"custom_id":"b","result":{"type":"canceled"}}`+"\n"+
+			`{"custom_id":"c","result":{"type":"expired"}}`+"\n",
+		out.String())
+}
+
+func TestMessageBatchServiceExecuteItemWithoutForwarding(t *testing.T) {
+	repo := newMessageBatchRepoStub()
+	repo.batches[1] = &MessageBatch{ID: 1, APIKeyID: 9, ProcessingStatus: MessageBatchStatusCanceling, ExpiresAt: time.Now().Add(time.Hour)}
+	repo.batches[2] = &MessageBatch{ID: 2, APIKeyID: 9, ProcessingStatus: MessageBatchStatusInProgress, ExpiresAt: time.Now().Add(-time.Minute)}
+	repo.batches[3] = &MessageBatch{ID: 3, APIKeyID: 9, ProcessingStatus: MessageBatchStatusInProgress, ExpiresAt: time.Now().Add(time.Hour)}
+	apiKeyRepo := &apiKeyRepoStub{apiKey: &APIKey{ID: 9, Status: StatusAPIKeyDisabled, User: &User{ID: 7, Status: StatusActive}}}
+	svc := newTestMessageBatchService(repo, apiKeyRepo)
+
+	lockedUntil := time.Now().Add(time.Minute)
+	for batchID := int64(1); batchID <= 3; batchID++ {
+		svc.executeItem(&MessageBatchItem{ID: batchID, BatchID: batchID, Params: string(messageBatchParams("")), Status: MessageBatchItemStatusProcessing, LockedUntil: &lockedUntil})
+	}
+
+	require.Len(t, repo.updated, 3)
+	require.Equal(t, MessageBatchItemStatusCanceled, repo.updated[0].Status)
+	require.Equal(t, MessageBatchItemStatusExpired, repo.updated[1].Status)
+	require.Equal(t, MessageBatchItemStatusErrored, repo.updated[2].Status)
+	require.Nil(t, repo.updated[2].LockedUntil)
+	require.Zero(t, repo.updated[2].Attempts)
+
+	var result struct {
+		Type  string `json:"type"`
+		Error struct {
+			Type  string `json:"type"`
+			Error struct {
+				Type string `json:"type"`
+			} `json:"error"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(*repo.updated[2].Result), &result))
+	require.Equal(t, MessageBatchItemStatusErrored, result.Type)
+	require.Equal(t, "error", result.Error.Type)
+	require.Equal(t, "authentication_error", result.Error.Error.Type)
+}
+
+func TestMessageBatchErrorFromBody(t *testing.T) {
+	raw := messageBatchErrorFromBody(400, []byte(`{"type":"error","error":{"type":"invalid_request_error","message":"max_tokens: required"}}`))
+	require.JSONEq(t, `{"type":"errored","error":{"type":"error","error":{"type":"invalid_request_error","message":"max_tokens: required"}}}`, string(raw))
+
+	raw = messageBatchErrorFromBody(529, []byte("upstream overloaded"))
+	require.JSONEq(t, `{"type":"errored","error":{"type":"error","error":{"type":"overloaded_error","message":"upstream overloaded"}}}`, string(raw))
+
+	raw = messageBatchErrorFromBody(502, nil)
+	require.Contains(t, string(raw), `"type":"api_error"`)
+	require.Contains(t, string(raw), "status 502")
+}
+
+func TestApplyMessageBatchDiscount(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.MessageBatch.Discount = 0.5
+	cost := &CostBreakdown{InputCost: 1, OutputCost: 2, CacheCreationCost: 0.5, CacheReadCost: 0.25, TotalCost: 3.75, ActualCost: 7.5}
+	applyMessageBatchDiscount(cost, cfg)
+	require.InDelta(t, 0.5, cost.InputCost, 1e-9)
+	require.InDelta(t, 1, cost.OutputCost, 1e-9)
+	require.InDelta(t, 0.25, cost.CacheCreationCost, 1e-9)
+	require.InDelta(t, 0.125, cost.CacheReadCost, 1e-9)
+	require.InDelta(t, 1.875, cost.TotalCost, 1e-9)
+	require.InDelta(t, 3.75, cost.ActualCost, 1e-9)
+}
@@ -184,6 +184,8 @@ type OpenAIGatewayService struct {
 	deferredService     *DeferredService
 	openAITokenProvider *OpenAITokenProvider
 	toolCorrector       *CodexToolCorrector
+	webhookService      *WebhookService
+	apiKeyRateLimit     *APIKeyRateLimitService
 }
 
 // NewOpenAIGatewayService creates a new OpenAIGatewayService
@@ -202,6 +204,8 @@ func NewOpenAIGatewayService(
 	httpUpstream HTTPUpstream,
 	deferredService *DeferredService,
 	openAITokenProvider *OpenAITokenProvider,
+	webhookService *WebhookService,
+	apiKeyRateLimit *APIKeyRateLimitService,
 ) *OpenAIGatewayService {
 	return &OpenAIGatewayService{
 		accountRepo:         accountRepo,
@@ -219,6 +223,8 @@ func NewOpenAIGatewayService(
 		deferredService:     deferredService,
 		openAITokenProvider: openAITokenProvider,
 		toolCorrector:       NewCodexToolCorrector(),
+		webhookService:      webhookService,
+		apiKeyRateLimit:     apiKeyRateLimit,
 	}
 }
 
@@ -359,6 +365,7 @@ func (s *OpenAIGatewayService) tryStickySessionHit(ctx context.Context, groupID
 // Returns nil if no available account.
 func (s *OpenAIGatewayService) selectBestAccount(accounts []Account, requestedModel string, excludedIDs map[int64]struct{}) *Account {
 	var selected *Account
+	var eligible []*Account
 
 	for i := range accounts {
 		acc := &accounts[i]
@@ -371,7 +378,7 @@ func (s *OpenAIGatewayService) selectBestAccount(accounts []Account, requestedMo
 
 		// 调度器快照可能暂时过时，这里重新检查可调度性和平台
 		// Scheduler snapshots can be temporarily stale; re-check schedulability and platform
-		if !acc.IsSchedulable() || !acc.IsOpenAI() {
+		if !acc.IsEligibleForNewSession() || !acc.IsOpenAI() {
 			continue
 		}
 
@@ -383,6 +390,7 @@ func (s *OpenAIGatewayService) selectBestAccount(accounts []Account, requestedMo
 
 		// 选择优先级最高且最久未使用的账号
 		// Select highest priority and least recently used
+		eligible = append(eligible, acc)
 		if selected == nil {
 			selected = acc
 			continue
@@ -393,7 +401,9 @@ func (s *OpenAIGatewayService) selectBestAccount(accounts []Account, requestedMo
 		}
 	}
 
-	return selected
+	// 同优先级内配置了权重时按权重随机选择
+	// Within the selected priority tier, pick by weight when weights are configured
+	return preferWeightedInTier(selected, eligible)
 }
 
 // isBetterAccount 判断 candidate 是否比 current 更优。
@@ -541,7 +551,7 @@ func (s *OpenAIGatewayService) SelectAccountWithLoadAwareness(ctx context.Contex
 		// Scheduler snapshots can be temporarily stale (bucket rebuild is throttled);
 		// re-check schedulability here so recently rate-limited/overloaded accounts
 		// are not selected again before the bucket is rebuilt.
-		if !acc.IsSchedulable() {
+		if !acc.IsEligibleForNewSession() {
 			continue
 		}
 		if requestedModel != "" && !acc.IsModelSupported(requestedModel) {
@@ -615,6 +625,7 @@ func (s *OpenAIGatewayService) SelectAccountWithLoadAwareness(ctx context.Contex
 				}
 			})
 			shuffleWithinSortGroups(available)
+			orderByWeightWithinPriority(available)
 
 			for _, item := range available {
 				result, err := s.tryAcquireAccountSlot(ctx, item.account.ID, item.account.Concurrency)
@@ -744,6 +755,18 @@ func (s *OpenAIGatewayService) handleFailoverSideEffects(ctx context.Context, re
 func (s *OpenAIGatewayService) Forward(ctx context.Context, c *gin.Context, account *Account, body []byte) (*OpenAIForwardResult, error) {
 	startTime := time.Now()
 
+	// 分组请求策略（在所有请求体改写之前执行）
+	body, err := enforceRequestPolicy(ctx, c, applyResponsesRequestPolicy, body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": gin.H{
+				"type":    "invalid_request_error",
+				"message": err.Error(),
+			},
+		})
+		return nil, err
+	}
+
 	// Parse request body once (avoid multiple parse/serialize cycles)
 	var reqBody map[string]any
 	if err := json.Unmarshal(body, &reqBody); err != nil {
@@ -1712,6 +1735,8 @@ type OpenAIRecordUsageInput struct {
 	UserAgent     string // 请求的 User-Agent
 	IPAddress     string // 请求的客户端 IP 地址
 	APIKeyService APIKeyQuotaUpdater
+
+	ResponseCacheHit bool // 响应缓存命中：按缓存折扣计费，不计入账号用量
 }
 
 // RecordUsage records usage and deducts balance
@@ -1722,6 +1747,8 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 	account := input.Account
 	subscription := input.Subscription
 
+	observeFirstTokenMetric(account, apiKey, result.FirstTokenMs)
+
 	// 计算实际的新输入token（减去缓存读取的token）
 	// 因为 input_tokens 包含了 cache_read_tokens，而缓存读取的token不应按输入价格计费
 	actualInputTokens := result.Usage.InputTokens - result.Usage.CacheReadInputTokens
@@ -1754,10 +1781,15 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 	if isSubscriptionBilling {
 		billingType = BillingTypeSubscription
 	}
+	accountRateMultiplier := account.BillingRateMultiplier()
+	if input.ResponseCacheHit {
+		// 缓存命中未消耗上游账号额度：按折扣计费，账号倍率记为 0
+		applyResponseCacheDiscount(cost, s.cfg)
+		accountRateMultiplier = 0
+	}
 
 	// Create usage log
 	durationMs := int(result.Duration.Milliseconds())
-	accountRateMultiplier := account.BillingRateMultiplier()
 	usageLog := &UsageLog{
 		UserID:                user.ID,
 		APIKeyID:              apiKey.ID,
@@ -1778,6 +1810,7 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 		RateMultiplier:        multiplier,
 		AccountRateMultiplier: &accountRateMultiplier,
 		BillingType:           billingType,
+		ResponseCacheHit:      input.ResponseCacheHit,
 		Stream:                result.Stream,
 		DurationMs:            &durationMs,
 		FirstTokenMs:          result.FirstTokenMs,
@@ -1802,9 +1835,15 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 	}
 
 	inserted, err := s.usageLogRepo.Create(ctx, usageLog)
+
+	// 记入 API Key TPM 窗口（简易模式同样限流）
+	s.apiKeyRateLimit.RecordTokens(ctx, apiKey, usageLog.TotalTokens())
+
 	if s.cfg != nil && s.cfg.RunMode == config.RunModeSimple {
 		log.Printf("[SIMPLE MODE] Usage recorded (not billed): user=%d, tokens=%d", usageLog.UserID, usageLog.TotalTokens())
-		s.deferredService.ScheduleLastUsedUpdate(account.ID)
+		if !input.ResponseCacheHit {
+			s.deferredService.ScheduleLastUsedUpdate(account.ID)
+		}
 		return nil
 	}
 
@@ -1818,8 +1857,13 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 		}
 	} else {
 		if shouldBill && cost.ActualCost > 0 {
-			_ = s.userRepo.DeductBalance(ctx, user.ID, cost.ActualCost)
-			s.billingCacheService.QueueDeductBalance(user.ID, cost.ActualCost)
+			// 余额与流水写入失败时不扣减缓存、不发送通知，避免缓存与数据库/流水不一致
+			if err := s.userRepo.ApplyBalanceChange(ctx, usageBalanceLedgerEntry(usageLog, cost.ActualCost)); err != nil {
+				log.Printf("Deduct balance failed: %v", err)
+			} else {
+				s.billingCacheService.QueueDeductBalance(user.ID, cost.ActualCost)
+				s.webhookService.NotifyBalanceDeducted(user.ID, cost.ActualCost)
+			}
 		}
 	}
 
@@ -1830,8 +1874,10 @@ func (s *OpenAIGatewayService) RecordUsage(ctx context.Context, input *OpenAIRec
 		}
 	}
 
-	// Schedule batch update for account last_used_at
-	s.deferredService.ScheduleLastUsedUpdate(account.ID)
+	// Schedule batch update for account last_used_at（缓存命中未使用账号）
+	if !input.ResponseCacheHit {
+		s.deferredService.ScheduleLastUsedUpdate(account.ID)
+	}
 
 	return nil
 }
@@ -65,7 +65,12 @@ func (s *OpsService) GetAccountAvailabilityStats(ctx context.Context, platformFi
 			isOverloaded = false
 		}
 
-		isAvailable := acc.Status == StatusActive && acc.Schedulable && !isRateLimited && !isOverloaded && !isTempUnsched
+		scheduleState := acc.ScheduleState(now)
+		isOffSchedule := !scheduleState.Allowed
+		drainProgress := acc.DrainProgress(now)
+		isDraining := drainProgress != nil
+
+		isAvailable := acc.Status == StatusActive && acc.Schedulable && !isRateLimited && !isOverloaded && !isTempUnsched && !isOffSchedule && !isDraining
 
 		if acc.Platform != "" {
 			if _, ok := platform[acc.Platform]; !ok {
@@ -84,6 +89,9 @@ func (s *OpsService) GetAccountAvailabilityStats(ctx context.Context, platformFi
 			if hasError {
 				p.ErrorCount++
 			}
+			if isDraining {
+				p.DrainingCount++
+			}
 		}
 
 		for _, grp := range acc.Groups {
@@ -108,6 +116,9 @@ func (s *OpsService) GetAccountAvailabilityStats(ctx context.Context, platformFi
 			if hasError {
 				g.ErrorCount++
 			}
+			if isDraining {
+				g.DrainingCount++
+			}
 		}
 
 		displayGroupID := int64(0)
@@ -150,6 +161,19 @@ func (s *OpsService) GetAccountAvailabilityStats(ctx context.Context, platformFi
 		if isTempUnsched && acc.TempUnschedulableUntil != nil {
 			item.TempUnschedulableUntil = acc.TempUnschedulableUntil
 		}
+		if isOffSchedule {
+			item.IsOffSchedule = true
+			item.InMaintenance = scheduleState.InMaintenance
+			item.ScheduleReason = scheduleState.Reason
+			if !scheduleState.NextTransition.IsZero() {
+				next := scheduleState.NextTransition
+				item.ScheduleNextChangeAt = &next
+			}
+		}
+		if isDraining {
+			item.IsDraining = true
+			item.Drain = drainProgress
+		}
 
 		account[acc.ID] = item
 	}
@@ -36,6 +36,8 @@ type OpsAlertEvaluatorService struct {
 	opsRepo      OpsRepository
 	emailService *EmailService
 
+	webhookService *WebhookService
+
 	redisClient *redis.Client
 	cfg         *config.Config
 	instanceID  string
@@ -80,6 +82,11 @@ func NewOpsAlertEvaluatorService(
 	}
 }
 
+// SetWebhookService 设置 Webhook 服务（可选依赖），告警触发/恢复时发布事件
+func (s *OpsAlertEvaluatorService) SetWebhookService(webhookService *WebhookService) {
+	s.webhookService = webhookService
+}
+
 func (s *OpsAlertEvaluatorService) Start() {
 	if s == nil {
 		return
@@ -292,6 +299,7 @@ func (s *OpsAlertEvaluatorService) evaluateOnce(interval time.Duration) {
 				if s.maybeSendAlertEmail(ctx, runtimeCfg, rule, created) {
 					emailsSent++
 				}
+				s.emitAlertWebhook(runtimeCfg, WebhookEventOpsAlertFiring, rule, created)
 			}
 			continue
 		}
@@ -303,6 +311,9 @@ func (s *OpsAlertEvaluatorService) evaluateOnce(interval time.Duration) {
 				log.Printf("[OpsAlertEvaluator] resolve event failed (event=%d): %v", activeEvent.ID, err)
 			} else {
 				eventsResolved++
+				activeEvent.Status = OpsAlertStatusResolved
+				activeEvent.ResolvedAt = &resolvedAt
+				s.emitAlertWebhook(runtimeCfg, WebhookEventOpsAlertResolved, rule, activeEvent)
 			}
 		}
 	}
@@ -657,6 +668,44 @@ func (s *OpsAlertEvaluatorService) maybeSendAlertEmail(ctx context.Context, runt
 	return anySent
 }
 
+// emitAlertWebhook 发布告警触发/恢复事件，与邮件通知一致地遵循运行时静默配置
+func (s *OpsAlertEvaluatorService) emitAlertWebhook(runtimeCfg *OpsAlertRuntimeSettings, eventType string, rule *OpsAlertRule, event *OpsAlertEvent) {
+	if s == nil || s.webhookService == nil || rule == nil || event == nil {
+		return
+	}
+	if runtimeCfg != nil && runtimeCfg.Silencing.Enabled {
+		if isOpsAlertSilenced(time.Now().UTC(), rule, event, runtimeCfg.Silencing) {
+			return
+		}
+	}
+	data := map[string]any{
+		"alert_event_id": event.ID,
+		"rule_id":        rule.ID,
+		"rule_name":      strings.TrimSpace(rule.Name),
+		"severity":       strings.TrimSpace(rule.Severity),
+		"status":         event.Status,
+		"metric_type":    strings.TrimSpace(rule.MetricType),
+		"operator":       rule.Operator,
+		"threshold":      rule.Threshold,
+		"description":    event.Description,
+		"fired_at":       event.FiredAt,
+	}
+	if event.MetricValue != nil {
+		data["metric_value"] = *event.MetricValue
+	}
+	if event.ResolvedAt != nil {
+		data["resolved_at"] = *event.ResolvedAt
+	}
+	summary := event.Title
+	if summary == "" {
+		summary = fmt.Sprintf("%s: %s", strings.TrimSpace(rule.Severity), strings.TrimSpace(rule.Name))
+	}
+	if eventType == WebhookEventOpsAlertResolved {
+		summary = "[Resolved] " + summary
+	}
+	s.webhookService.Emit(eventType, summary, data)
+}
+
 func buildOpsAlertEmailBody(rule *OpsAlertRule, event *OpsAlertEvent) string {
 	if rule == nil || event == nil {
 		return ""
@@ -97,6 +97,47 @@ func (s *OpsService) getAccountsLoadMapBestEffort(ctx context.Context, accounts
 	return out
 }
 
+func (s *OpsService) getFairQueueStatsBestEffort(ctx context.Context, accounts []Account) map[int64]*FairQueueAccountStats {
+	if s == nil || s.concurrencyService == nil {
+		return map[int64]*FairQueueAccountStats{}
+	}
+	fairQueue := s.concurrencyService.FairQueue()
+	if fairQueue == nil || len(accounts) == 0 {
+		return map[int64]*FairQueueAccountStats{}
+	}
+
+	seen := make(map[int64]struct{}, len(accounts))
+	ids := make([]int64, 0, len(accounts))
+	for _, acc := range accounts {
+		if acc.ID <= 0 {
+			continue
+		}
+		if _, ok := seen[acc.ID]; ok {
+			continue
+		}
+		seen[acc.ID] = struct{}{}
+		ids = append(ids, acc.ID)
+	}
+
+	out := make(map[int64]*FairQueueAccountStats, len(ids))
+	for i := 0; i < len(ids); i += opsConcurrencyBatchChunkSize {
+		end := i + opsConcurrencyBatchChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		part, err := fairQueue.GetAccountStatsBatch(ctx, ids[i:end])
+		if err != nil {
+			// Best-effort: queue stats are informational only.
+			log.Printf("[Ops] fair queue GetAccountStatsBatch failed: %v", err)
+			continue
+		}
+		for k, v := range part {
+			out[k] = v
+		}
+	}
+	return out
+}
+
 // GetConcurrencyStats returns real-time concurrency usage aggregated by platform/group/account.
 //
 // Optional filters:
@@ -118,6 +159,7 @@ func (s *OpsService) GetConcurrencyStats(
 
 	collectedAt := time.Now()
 	loadMap := s.getAccountsLoadMapBestEffort(ctx, accounts)
+	fairQueueMap := s.getFairQueueStatsBestEffort(ctx, accounts)
 
 	platform := make(map[string]*PlatformConcurrencyInfo)
 	group := make(map[int64]*GroupConcurrencyInfo)
@@ -152,6 +194,16 @@ func (s *OpsService) GetConcurrencyStats(
 			currentInUse = int64(load.CurrentConcurrency)
 			waiting = int64(load.WaitingCount)
 		}
+		queued := fairQueueMap[acc.ID]
+		addFairQueue := func(g *GroupConcurrencyInfo) {
+			if queued == nil {
+				return
+			}
+			g.FairQueueWaiting += int64(queued.Waiting)
+			if queued.MaxWaitMs > g.FairQueueMaxWaitMs {
+				g.FairQueueMaxWaitMs = queued.MaxWaitMs
+			}
+		}
 
 		// Account-level view picks one display group (the first group).
 		displayGroupID := int64(0)
@@ -178,6 +230,11 @@ func (s *OpsService) GetConcurrencyStats(
 			if info.MaxCapacity > 0 {
 				info.LoadPercentage = float64(info.CurrentInUse) / float64(info.MaxCapacity) * 100
 			}
+			if queued != nil {
+				info.FairQueueWaiting = int64(queued.Waiting)
+				info.FairQueueMaxWaitMs = queued.MaxWaitMs
+				info.FairQueueAvgGrantWait = queued.AvgGrantWait
+			}
 			account[acc.ID] = info
 		}
 
@@ -215,6 +272,7 @@ func (s *OpsService) GetConcurrencyStats(
 			g.MaxCapacity += int64(acc.Concurrency)
 			g.CurrentInUse += currentInUse
 			g.WaitingInQueue += waiting
+			addFairQueue(g)
 		} else {
 			for _, grp := range acc.Groups {
 				if grp == nil || grp.ID <= 0 {
@@ -238,6 +296,7 @@ func (s *OpsService) GetConcurrencyStats(
 				g.MaxCapacity += int64(acc.Concurrency)
 				g.CurrentInUse += currentInUse
 				g.WaitingInQueue += waiting
+				addFairQueue(g)
 			}
 		}
 	}
@@ -394,3 +453,19 @@ func (s *OpsService) GetUserConcurrencyStats(ctx context.Context) (map[int64]*Us
 
 	return result, &collectedAt, nil
 }
+
+// GetFairQueueEntries returns the requests currently queued for an account slot, ordered by queue position.
+func (s *OpsService) GetFairQueueEntries(ctx context.Context, accountID int64) ([]*FairQueueEntry, *time.Time, error) {
+	if err := s.RequireMonitoringEnabled(ctx); err != nil {
+		return nil, nil, err
+	}
+	collectedAt := time.Now()
+	if s.concurrencyService == nil || s.concurrencyService.FairQueue() == nil {
+		return []*FairQueueEntry{}, &collectedAt, nil
+	}
+	entries, err := s.concurrencyService.FairQueue().ListAccountEntries(ctx, accountID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return entries, &collectedAt, nil
+}
@@ -167,3 +167,45 @@ type OpsRetryResult struct {
 	FinishedAt time.Time `json:"finished_at"`
 	DurationMs int64     `json:"duration_ms"`
 }
+
+type OpsRedactionLog struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	RequestID       string `json:"request_id"`
+	ClientRequestID string `json:"client_request_id"`
+
+	UserID    *int64 `json:"user_id"`
+	UserEmail string `json:"user_email"`
+	APIKeyID  *int64 `json:"api_key_id"`
+	GroupID   *int64 `json:"group_id"`
+	GroupName string `json:"group_name"`
+
+	Platform    string `json:"platform"`
+	Model       string `json:"model"`
+	RequestPath string `json:"request_path"`
+
+	Action     string         `json:"action"`
+	Counts     map[string]int `json:"counts"`
+	TotalCount int            `json:"total_count"`
+}
+
+type OpsRedactionLogFilter struct {
+	StartTime *time.Time
+	EndTime   *time.Time
+
+	Platform string
+	GroupID  *int64
+	UserID   *int64
+	Action   string
+
+	Page     int
+	PageSize int
+}
+
+type OpsRedactionLogList struct {
+	Logs     []*OpsRedactionLog `json:"logs"`
+	Total    int                `json:"total"`
+	Page     int                `json:"page"`
+	PageSize int                `json:"page_size"`
+}
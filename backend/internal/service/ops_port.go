@@ -11,6 +11,9 @@ type OpsRepository interface {
 	GetErrorLogByID(ctx context.Context, id int64) (*OpsErrorLogDetail, error)
 	ListRequestDetails(ctx context.Context, filter *OpsRequestDetailFilter) ([]*OpsRequestDetail, int64, error)
 
+	InsertRedactionLog(ctx context.Context, input *OpsInsertRedactionLogInput) (int64, error)
+	ListRedactionLogs(ctx context.Context, filter *OpsRedactionLogFilter) (*OpsRedactionLogList, error)
+
 	InsertRetryAttempt(ctx context.Context, input *OpsInsertRetryAttemptInput) (int64, error)
 	UpdateRetryAttempt(ctx context.Context, input *OpsUpdateRetryAttemptInput) error
 	GetLatestRetryAttemptForError(ctx context.Context, sourceErrorID int64) (*OpsRetryAttempt, error)
@@ -21,6 +24,8 @@ type OpsRepository interface {
 	GetWindowStats(ctx context.Context, filter *OpsDashboardFilter) (*OpsWindowStats, error)
 	// Lightweight realtime traffic summary (for the Ops dashboard header card).
 	GetRealtimeTrafficSummary(ctx context.Context, filter *OpsDashboardFilter) (*OpsRealtimeTrafficSummary, error)
+	// Successful request counts per account (for weighted traffic share comparison).
+	GetAccountRequestCounts(ctx context.Context, filter *OpsDashboardFilter) (map[int64]int64, error)
 
 	GetDashboardOverview(ctx context.Context, filter *OpsDashboardFilter) (*OpsDashboardOverview, error)
 	GetThroughputTrend(ctx context.Context, filter *OpsDashboardFilter, bucketSeconds int) (*OpsThroughputTrendResponse, error)
@@ -59,6 +64,27 @@ type OpsRepository interface {
 	GetLatestDailyBucketDate(ctx context.Context) (time.Time, bool, error)
 }
 
+// OpsInsertRedactionLogInput 单次请求的敏感信息检测记录（仅命中次数，不含原文）
+type OpsInsertRedactionLogInput struct {
+	RequestID       string
+	ClientRequestID string
+
+	UserID   *int64
+	APIKeyID *int64
+	GroupID  *int64
+
+	Platform    string
+	Model       string
+	RequestPath string
+
+	// mask | block
+	Action     string
+	Counts     map[string]int
+	TotalCount int
+
+	CreatedAt time.Time
+}
+
 type OpsInsertErrorLogInput struct {
 	RequestID       string
 	ClientRequestID string
@@ -22,6 +22,9 @@ type GroupConcurrencyInfo struct {
 	MaxCapacity    int64   `json:"max_capacity"`
 	LoadPercentage float64 `json:"load_percentage"`
 	WaitingInQueue int64   `json:"waiting_in_queue"`
+	// 公平排队（gateway.scheduling.fair_queue）中等待账号槽位的请求数与最长已等待时间
+	FairQueueWaiting   int64 `json:"fair_queue_waiting"`
+	FairQueueMaxWaitMs int64 `json:"fair_queue_max_wait_ms"`
 }
 
 // AccountConcurrencyInfo represents real-time concurrency usage for a single account.
@@ -35,6 +38,10 @@ type AccountConcurrencyInfo struct {
 	MaxCapacity    int64   `json:"max_capacity"`
 	LoadPercentage float64 `json:"load_percentage"`
 	WaitingInQueue int64   `json:"waiting_in_queue"`
+	// 公平排队统计：当前排队数、最长已等待时间，以及最近窗口内经排队获取槽位的平均等待时间
+	FairQueueWaiting      int64 `json:"fair_queue_waiting"`
+	FairQueueMaxWaitMs    int64 `json:"fair_queue_max_wait_ms"`
+	FairQueueAvgGrantWait int64 `json:"fair_queue_avg_grant_wait_ms"`
 }
 
 // UserConcurrencyInfo represents real-time concurrency usage for a single user.
@@ -55,6 +62,7 @@ type PlatformAvailability struct {
 	AvailableCount int64  `json:"available_count"`
 	RateLimitCount int64  `json:"rate_limit_count"`
 	ErrorCount     int64  `json:"error_count"`
+	DrainingCount  int64  `json:"draining_count"`
 }
 
 // GroupAvailability aggregates account availability by group.
@@ -66,6 +74,7 @@ type GroupAvailability struct {
 	AvailableCount int64  `json:"available_count"`
 	RateLimitCount int64  `json:"rate_limit_count"`
 	ErrorCount     int64  `json:"error_count"`
+	DrainingCount  int64  `json:"draining_count"`
 }
 
 // AccountAvailability represents current availability for a single account.
@@ -89,4 +98,14 @@ type AccountAvailability struct {
 	OverloadRemainingSec   *int64     `json:"overload_remaining_sec"`
 	ErrorMessage           string     `json:"error_message"`
 	TempUnschedulableUntil *time.Time `json:"temp_unschedulable_until,omitempty"`
+
+	// 时间段调度（extra.schedule）：不在调度时间段内或处于维护窗口
+	IsOffSchedule        bool       `json:"is_off_schedule"`
+	InMaintenance        bool       `json:"in_maintenance"`
+	ScheduleReason       string     `json:"schedule_reason,omitempty"`
+	ScheduleNextChangeAt *time.Time `json:"schedule_next_change_at,omitempty"`
+
+	// drain：只承接已有粘性会话，完成后自动关闭调度
+	IsDraining bool                  `json:"is_draining"`
+	Drain      *AccountDrainProgress `json:"drain,omitempty"`
 }
@@ -251,6 +251,46 @@ func (s *OpsService) RecordError(ctx context.Context, entry *OpsInsertErrorLogIn
 	return nil
 }
 
+// RecordRedaction 记录单次请求的敏感信息检测统计（best-effort，不影响网关请求）
+func (s *OpsService) RecordRedaction(ctx context.Context, entry *OpsInsertRedactionLogInput) error {
+	if entry == nil || entry.TotalCount <= 0 {
+		return nil
+	}
+	if !s.IsMonitoringEnabled(ctx) {
+		return nil
+	}
+	if s.opsRepo == nil {
+		return nil
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	entry.Action = strings.TrimSpace(entry.Action)
+	if entry.Action == "" {
+		entry.Action = RedactionActionMask
+	}
+	if _, err := s.opsRepo.InsertRedactionLog(ctx, entry); err != nil {
+		log.Printf("[Ops] RecordRedaction failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *OpsService) GetRedactionLogs(ctx context.Context, filter *OpsRedactionLogFilter) (*OpsRedactionLogList, error) {
+	if err := s.RequireMonitoringEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if s.opsRepo == nil {
+		return &OpsRedactionLogList{Logs: []*OpsRedactionLog{}, Total: 0, Page: 1, PageSize: 20}, nil
+	}
+	result, err := s.opsRepo.ListRedactionLogs(ctx, filter)
+	if err != nil {
+		log.Printf("[Ops] GetRedactionLogs failed: %v", err)
+		return nil, err
+	}
+	return result, nil
+}
+
 func (s *OpsService) GetErrorLogs(ctx context.Context, filter *OpsErrorLogFilter) (*OpsErrorLogList, error) {
 	if err := s.RequireMonitoringEnabled(ctx); err != nil {
 		return nil, err
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// OpsAccountTrafficShare compares an account's configured weight share with the traffic it actually served.
+type OpsAccountTrafficShare struct {
+	AccountID   int64  `json:"account_id"`
+	AccountName string `json:"account_name"`
+	Platform    string `json:"platform"`
+	Priority    int    `json:"priority"`
+	Schedulable bool   `json:"schedulable"`
+
+	// Weight is the configured weight (0 = not configured); EffectiveWeight is what the scheduler uses.
+	Weight          int `json:"weight"`
+	EffectiveWeight int `json:"effective_weight"`
+
+	// ConfiguredShare is EffectiveWeight / sum of effective weights of schedulable accounts in the same priority tier.
+	ConfiguredShare float64 `json:"configured_share"`
+	// ObservedShare is RequestCount / total requests served by the same priority tier in the window.
+	ObservedShare float64 `json:"observed_share"`
+	RequestCount  int64   `json:"request_count"`
+}
+
+// OpsPriorityTierTrafficShare groups accounts of the same priority.
+type OpsPriorityTierTrafficShare struct {
+	Priority      int                       `json:"priority"`
+	Weighted      bool                      `json:"weighted"`
+	TotalRequests int64                     `json:"total_requests"`
+	Accounts      []*OpsAccountTrafficShare `json:"accounts"`
+}
+
+// OpsTrafficShareReport is the traffic share distribution of a group within a time window.
+type OpsTrafficShareReport struct {
+	GroupID   int64     `json:"group_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+
+	Tiers []*OpsPriorityTierTrafficShare `json:"tiers"`
+}
+
+// GetAccountTrafficShares returns, per priority tier of the group, each account's configured
+// weight share next to the share of successful requests it served in the window.
+func (s *OpsService) GetAccountTrafficShares(ctx context.Context, groupID int64, startTime, endTime time.Time) (*OpsTrafficShareReport, error) {
+	if err := s.RequireMonitoringEnabled(ctx); err != nil {
+		return nil, err
+	}
+	if s.opsRepo == nil {
+		return nil, infraerrors.ServiceUnavailable("OPS_REPO_UNAVAILABLE", "Ops repository not available")
+	}
+	if s.accountRepo == nil {
+		return nil, infraerrors.ServiceUnavailable("ACCOUNT_REPO_UNAVAILABLE", "Account repository not available")
+	}
+	if groupID <= 0 {
+		return nil, infraerrors.BadRequest("OPS_GROUP_REQUIRED", "group_id is required")
+	}
+	if startTime.After(endTime) {
+		return nil, infraerrors.BadRequest("OPS_TIME_RANGE_INVALID", "start_time must be <= end_time")
+	}
+	if endTime.Sub(startTime) > 24*time.Hour {
+		return nil, infraerrors.BadRequest("OPS_TIME_RANGE_TOO_LARGE", "invalid time range: max window is 24 hours")
+	}
+
+	accounts, err := s.accountRepo.ListByGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.opsRepo.GetAccountRequestCounts(ctx, &OpsDashboardFilter{
+		StartTime: startTime,
+		EndTime:   endTime,
+		GroupID:   &groupID,
+		QueryMode: OpsQueryModeRaw,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTrafficShareReport(groupID, startTime, endTime, accounts, counts), nil
+}
+
+func buildTrafficShareReport(groupID int64, startTime, endTime time.Time, accounts []Account, counts map[int64]int64) *OpsTrafficShareReport {
+	report := &OpsTrafficShareReport{
+		GroupID:   groupID,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Tiers:     []*OpsPriorityTierTrafficShare{},
+	}
+
+	tiers := make(map[int]*OpsPriorityTierTrafficShare)
+	weightSums := make(map[int]int)
+	for i := range accounts {
+		acc := &accounts[i]
+		tier := tiers[acc.Priority]
+		if tier == nil {
+			tier = &OpsPriorityTierTrafficShare{Priority: acc.Priority}
+			tiers[acc.Priority] = tier
+			report.Tiers = append(report.Tiers, tier)
+		}
+		item := &OpsAccountTrafficShare{
+			AccountID:       acc.ID,
+			AccountName:     acc.Name,
+			Platform:        acc.Platform,
+			Priority:        acc.Priority,
+			Schedulable:     acc.IsSchedulable(),
+			Weight:          acc.GetWeight(),
+			EffectiveWeight: acc.EffectiveWeight(),
+			RequestCount:    counts[acc.ID],
+		}
+		if item.Weight > 0 {
+			tier.Weighted = true
+		}
+		if item.Schedulable {
+			weightSums[acc.Priority] += item.EffectiveWeight
+		}
+		tier.TotalRequests += item.RequestCount
+		tier.Accounts = append(tier.Accounts, item)
+	}
+
+	for _, tier := range report.Tiers {
+		sum := weightSums[tier.Priority]
+		for _, item := range tier.Accounts {
+			if item.Schedulable && sum > 0 {
+				item.ConfiguredShare = float64(item.EffectiveWeight) / float64(sum)
+			}
+			if tier.TotalRequests > 0 {
+				item.ObservedShare = float64(item.RequestCount) / float64(tier.TotalRequests)
+			}
+		}
+		sort.SliceStable(tier.Accounts, func(i, j int) bool {
+			return tier.Accounts[i].AccountID < tier.Accounts[j].AccountID
+		})
+	}
+	sort.SliceStable(report.Tiers, func(i, j int) bool {
+		return report.Tiers[i].Priority < report.Tiers[j].Priority
+	})
+	return report
+}
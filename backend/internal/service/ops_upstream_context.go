@@ -24,6 +24,10 @@ const (
 	// OpsSkipPassthroughKey 由 applyErrorPassthroughRule 在命中 skip_monitoring=true 的规则时设置。
 	// ops_error_logger 中间件检查此 key，为 true 时跳过错误记录。
 	OpsSkipPassthroughKey = "ops_skip_passthrough"
+
+	// OpsRedactionKey 由分组请求策略在检测到敏感信息时设置（*RedactionResult）。
+	// ops_error_logger 中间件据此写入 ops_redaction_logs，并对持久化的请求体做同样的掩码。
+	OpsRedactionKey = "ops_redaction"
 )
 
 func setOpsUpstreamError(c *gin.Context, upstreamStatusCode int, upstreamMessage, upstreamDetail string) {
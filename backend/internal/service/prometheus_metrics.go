@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const accountConcurrencyCollectTimeout = 5 * time.Second
+
+// observeFirstTokenMetric 记录流式请求的首 token 延迟（非流式或未采集到时跳过）
+func observeFirstTokenMetric(account *Account, apiKey *APIKey, firstTokenMs *int) {
+	if firstTokenMs == nil || account == nil {
+		return
+	}
+	var groupID *int64
+	if apiKey != nil {
+		groupID = apiKey.GroupID
+	}
+	metrics.ObserveFirstToken(account.Platform, metrics.GroupLabel(groupID), *firstTokenMs)
+}
+
+var (
+	accountConcurrencyInUseDesc = prometheus.NewDesc(
+		"sub2api_account_concurrency_in_use",
+		"Concurrency slots currently held on each schedulable account.",
+		[]string{"account_id", "platform"}, nil,
+	)
+	accountConcurrencyMaxDesc = prometheus.NewDesc(
+		"sub2api_account_concurrency_max",
+		"Configured max concurrency of each schedulable account.",
+		[]string{"account_id", "platform"}, nil,
+	)
+	accountConcurrencyWaitingDesc = prometheus.NewDesc(
+		"sub2api_account_concurrency_waiting",
+		"Requests waiting for a concurrency slot on each schedulable account.",
+		[]string{"account_id", "platform"}, nil,
+	)
+)
+
+// accountConcurrencyCollector 在每次抓取时读取可调度账号的并发槽位占用。
+// 数据源与运维面板一致（ConcurrencyService.GetAccountsLoadBatch），不做额外缓存。
+type accountConcurrencyCollector struct {
+	accountRepo        AccountRepository
+	concurrencyService *ConcurrencyService
+}
+
+func (c *accountConcurrencyCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- accountConcurrencyInUseDesc
+	ch <- accountConcurrencyMaxDesc
+	ch <- accountConcurrencyWaitingDesc
+}
+
+func (c *accountConcurrencyCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), accountConcurrencyCollectTimeout)
+	defer cancel()
+
+	accounts, err := c.accountRepo.ListSchedulable(ctx)
+	if err != nil {
+		log.Printf("[Metrics] list schedulable accounts failed: %v", err)
+		return
+	}
+	if len(accounts) == 0 {
+		return
+	}
+
+	batch := make([]AccountWithConcurrency, 0, len(accounts))
+	for _, acc := range accounts {
+		batch = append(batch, AccountWithConcurrency{ID: acc.ID, MaxConcurrency: acc.Concurrency})
+	}
+	loadMap := make(map[int64]*AccountLoadInfo, len(batch))
+	for i := 0; i < len(batch); i += opsConcurrencyBatchChunkSize {
+		end := i + opsConcurrencyBatchChunkSize
+		if end > len(batch) {
+			end = len(batch)
+		}
+		part, err := c.concurrencyService.GetAccountsLoadBatch(ctx, batch[i:end])
+		if err != nil {
+			log.Printf("[Metrics] get accounts load failed: %v", err)
+			continue
+		}
+		for k, v := range part {
+			loadMap[k] = v
+		}
+	}
+
+	for _, acc := range accounts {
+		id := strconv.FormatInt(acc.ID, 10)
+		ch <- prometheus.MustNewConstMetric(accountConcurrencyMaxDesc, prometheus.GaugeValue, float64(acc.Concurrency), id, acc.Platform)
+		load := loadMap[acc.ID]
+		if load == nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(accountConcurrencyInUseDesc, prometheus.GaugeValue, float64(load.CurrentConcurrency), id, acc.Platform)
+		ch <- prometheus.MustNewConstMetric(accountConcurrencyWaitingDesc, prometheus.GaugeValue, float64(load.WaitingCount), id, acc.Platform)
+	}
+}
+
+// registerAccountConcurrencyMetrics 注册账号并发采集器（metrics.enabled 且 metrics.account_concurrency 时）
+func registerAccountConcurrencyMetrics(accountRepo AccountRepository, concurrencyService *ConcurrencyService) {
+	if accountRepo == nil || concurrencyService == nil {
+		return
+	}
+	if err := metrics.Register(&accountConcurrencyCollector{accountRepo: accountRepo, concurrencyService: concurrencyService}); err != nil {
+		log.Printf("[Metrics] register account concurrency collector failed: %v", err)
+	}
+}
@@ -124,7 +124,14 @@ func (s *PromoService) ApplyPromoCode(ctx context.Context, userID int64, code st
 	}
 
 	// 增加用户余额
-	if err := s.userRepo.UpdateBalance(txCtx, userID, promoCode.BonusAmount); err != nil {
+	promoCodeID := promoCode.ID
+	if err := s.userRepo.ApplyBalanceChange(txCtx, &BalanceLedgerEntry{
+		UserID:      userID,
+		Type:        BalanceLedgerTypePromo,
+		Amount:      promoCode.BonusAmount,
+		ReferenceID: &promoCodeID,
+		Note:        promoCode.Code,
+	}); err != nil {
 		return fmt.Errorf("update user balance: %w", err)
 	}
 
@@ -23,6 +23,7 @@ type RateLimitService struct {
 	timeoutCounterCache   TimeoutCounterCache
 	settingService        *SettingService
 	tokenCacheInvalidator TokenCacheInvalidator
+	webhookService        *WebhookService
 	usageCacheMu          sync.RWMutex
 	usageCache            map[int64]*geminiUsageCacheEntry
 }
@@ -62,6 +63,11 @@ func (s *RateLimitService) SetTokenCacheInvalidator(invalidator TokenCacheInvali
 	s.tokenCacheInvalidator = invalidator
 }
 
+// SetWebhookService 设置 Webhook 服务（可选依赖），账号进入错误/限流/临时不可调度时发布事件
+func (s *RateLimitService) SetWebhookService(webhookService *WebhookService) {
+	s.webhookService = webhookService
+}
+
 // ErrorPolicyResult 表示错误策略检查的结果
 type ErrorPolicyResult int
 
@@ -141,6 +147,11 @@ func (s *RateLimitService) HandleUpstreamError(ctx context.Context, account *Acc
 			} else {
 				slog.Info("oauth_401_force_refresh_set", "account_id", account.ID, "platform", account.Platform)
 			}
+		} else if account.Type == AccountTypeVertex && s.tokenCacheInvalidator != nil {
+			// Vertex 服务账号 token 不落库，仅失效缓存，下次请求重新签发
+			if err := s.tokenCacheInvalidator.InvalidateToken(ctx, account); err != nil {
+				slog.Warn("vertex_401_invalidate_cache_failed", "account_id", account.ID, "error", err)
+			}
 		}
 		msg := "Authentication failed (401): invalid or expired credentials"
 		if upstreamMsg != "" {
@@ -354,6 +365,7 @@ func (s *RateLimitService) handleAuthError(ctx context.Context, account *Account
 		return
 	}
 	slog.Warn("account_disabled_auth_error", "account_id", account.ID, "error", errorMsg)
+	s.webhookService.EmitAccountEvent(WebhookEventAccountError, account, errorMsg, nil)
 }
 
 // handleCustomErrorCode 处理自定义错误码，停止账号调度
@@ -364,6 +376,7 @@ func (s *RateLimitService) handleCustomErrorCode(ctx context.Context, account *A
 		return
 	}
 	slog.Warn("account_disabled_custom_error", "account_id", account.ID, "status_code", statusCode, "error", errorMsg)
+	s.webhookService.EmitAccountEvent(WebhookEventAccountError, account, msg, map[string]any{"status_code": statusCode})
 }
 
 // handle429 处理429限流错误
@@ -372,7 +385,7 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 	// 1. OpenAI 平台：优先尝试解析 x-codex-* 响应头（用于 rate_limit_exceeded）
 	if account.Platform == PlatformOpenAI {
 		if resetAt := s.calculateOpenAI429ResetTime(headers); resetAt != nil {
-			if err := s.accountRepo.SetRateLimited(ctx, account.ID, *resetAt); err != nil {
+			if err := s.markRateLimited(ctx, account, *resetAt); err != nil {
 				slog.Warn("rate_limit_set_failed", "account_id", account.ID, "error", err)
 				return
 			}
@@ -391,7 +404,7 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 			// 尝试解析 OpenAI 的 usage_limit_reached 错误
 			if resetAt := parseOpenAIRateLimitResetTime(responseBody); resetAt != nil {
 				resetTime := time.Unix(*resetAt, 0)
-				if err := s.accountRepo.SetRateLimited(ctx, account.ID, resetTime); err != nil {
+				if err := s.markRateLimited(ctx, account, resetTime); err != nil {
 					slog.Warn("rate_limit_set_failed", "account_id", account.ID, "error", err)
 					return
 				}
@@ -402,7 +415,7 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 			// 尝试解析 Gemini 格式（用于其他平台）
 			if resetAt := ParseGeminiRateLimitResetTime(responseBody); resetAt != nil {
 				resetTime := time.Unix(*resetAt, 0)
-				if err := s.accountRepo.SetRateLimited(ctx, account.ID, resetTime); err != nil {
+				if err := s.markRateLimited(ctx, account, resetTime); err != nil {
 					slog.Warn("rate_limit_set_failed", "account_id", account.ID, "error", err)
 					return
 				}
@@ -414,7 +427,7 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 		// 没有重置时间，使用默认5分钟
 		resetAt := time.Now().Add(5 * time.Minute)
 		slog.Warn("rate_limit_no_reset_time", "account_id", account.ID, "platform", account.Platform, "using_default", "5m")
-		if err := s.accountRepo.SetRateLimited(ctx, account.ID, resetAt); err != nil {
+		if err := s.markRateLimited(ctx, account, resetAt); err != nil {
 			slog.Warn("rate_limit_set_failed", "account_id", account.ID, "error", err)
 		}
 		return
@@ -425,7 +438,7 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 	if err != nil {
 		slog.Warn("rate_limit_reset_parse_failed", "reset_timestamp", resetTimestamp, "error", err)
 		resetAt := time.Now().Add(5 * time.Minute)
-		if err := s.accountRepo.SetRateLimited(ctx, account.ID, resetAt); err != nil {
+		if err := s.markRateLimited(ctx, account, resetAt); err != nil {
 			slog.Warn("rate_limit_set_failed", "account_id", account.ID, "error", err)
 		}
 		return
@@ -434,7 +447,7 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 	resetAt := time.Unix(ts, 0)
 
 	// 标记限流状态
-	if err := s.accountRepo.SetRateLimited(ctx, account.ID, resetAt); err != nil {
+	if err := s.markRateLimited(ctx, account, resetAt); err != nil {
 		slog.Warn("rate_limit_set_failed", "account_id", account.ID, "error", err)
 		return
 	}
@@ -449,6 +462,17 @@ func (s *RateLimitService) handle429(ctx context.Context, account *Account, head
 	slog.Info("account_rate_limited", "account_id", account.ID, "reset_at", resetAt)
 }
 
+// markRateLimited 标记账号限流直到 resetAt，并发布 account.rate_limited 事件
+func (s *RateLimitService) markRateLimited(ctx context.Context, account *Account, resetAt time.Time) error {
+	if err := s.accountRepo.SetRateLimited(ctx, account.ID, resetAt); err != nil {
+		return err
+	}
+	s.webhookService.EmitAccountEvent(WebhookEventAccountRateLimited, account, "upstream returned 429", map[string]any{
+		"reset_at": resetAt.UTC(),
+	})
+	return nil
+}
+
 // calculateOpenAI429ResetTime 从 OpenAI 429 响应头计算正确的重置时间
 // 返回 nil 表示无法从响应头中确定重置时间
 func (s *RateLimitService) calculateOpenAI429ResetTime(headers http.Header) *time.Time {
@@ -785,6 +809,12 @@ func (s *RateLimitService) triggerTempUnschedulable(ctx context.Context, account
 	}
 
 	slog.Info("account_temp_unschedulable", "account_id", account.ID, "until", until, "rule_index", ruleIndex, "status_code", statusCode)
+	s.webhookService.EmitAccountEvent(WebhookEventAccountTempUnschedulable, account, truncateString(state.ErrorMessage, 512), map[string]any{
+		"until":           until.UTC(),
+		"status_code":     statusCode,
+		"matched_keyword": matchedKeyword,
+		"rule_index":      ruleIndex,
+	})
 	return true
 }
 
@@ -896,6 +926,10 @@ func (s *RateLimitService) triggerStreamTimeoutTempUnsched(ctx context.Context,
 	}
 
 	slog.Info("stream_timeout_temp_unschedulable", "account_id", account.ID, "until", until, "model", model)
+	s.webhookService.EmitAccountEvent(WebhookEventAccountTempUnschedulable, account, state.ErrorMessage, map[string]any{
+		"until": until.UTC(),
+		"model": model,
+	})
 	return true
 }
 
@@ -916,5 +950,6 @@ func (s *RateLimitService) triggerStreamTimeoutError(ctx context.Context, accoun
 	}
 
 	slog.Warn("stream_timeout_account_error", "account_id", account.ID, "model", model)
+	s.webhookService.EmitAccountEvent(WebhookEventAccountError, account, errorMsg, map[string]any{"model": model})
 	return true
 }
@@ -290,7 +290,13 @@ func (s *RedeemService) Redeem(ctx context.Context, userID int64, code string) (
 	switch redeemCode.Type {
 	case RedeemTypeBalance:
 		// 增加用户余额
-		if err := s.userRepo.UpdateBalance(txCtx, userID, redeemCode.Value); err != nil {
+		codeID := redeemCode.ID
+		if err := s.userRepo.ApplyBalanceChange(txCtx, &BalanceLedgerEntry{
+			UserID:      userID,
+			Type:        BalanceLedgerTypeRedeem,
+			Amount:      redeemCode.Value,
+			ReferenceID: &codeID,
+		}); err != nil {
 			return nil, fmt.Errorf("update user balance: %w", err)
 		}
 
@@ -0,0 +1,433 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Wei-Shaw/sub2api/internal/domain"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// 分组请求策略
+//
+// 网关 handler 在读取请求体后、响应缓存查找与账号调度之前执行（ApplyClaudeRequestPolicy 等），
+// 缓存键基于策略改写后的请求体计算，违反策略的请求不会命中缓存回放。
+// 各平台 Forward（GatewayService.Forward、OpenAIGatewayService.Forward、Gemini / Antigravity 的
+// Claude 与原生转发）仍会执行，handler 已执行过时跳过，避免重复注入提示词。
+// 按请求体协议分别实现：
+//   - Claude Messages：max_tokens、thinking.budget_tokens、tools[].name、temperature、system
+//   - OpenAI Responses：max_output_tokens、tools[].name/type、temperature、input（developer 消息）
+//   - Gemini generateContent：generationConfig.*、tools[].functionDeclarations、systemInstruction
+//
+// 违反策略（禁用工具、命中拦截正则、block 模式下检测到敏感信息）时返回 *RequestPolicyViolationError，
+// 由调用方按平台错误格式写出 400 响应（运维错误日志按 invalid_request_error 记录）。
+// 敏感信息检测见 request_policy_redaction.go。
+
+// RequestPolicy 分组级请求策略，定义见 domain.RequestPolicy
+type RequestPolicy = domain.RequestPolicy
+
+// RequestPolicyViolationError 表示请求被分组策略拒绝
+type RequestPolicyViolationError struct {
+	Reason string
+}
+
+func (e *RequestPolicyViolationError) Error() string {
+	return "Request blocked by group policy: " + e.Reason
+}
+
+// policyTextKeys 拦截正则检查的文本字段（仅取字符串值，跳过图片 data 等二进制内容）
+var policyTextKeys = map[string]bool{
+	"text":         true,
+	"content":      true,
+	"system":       true,
+	"input":        true,
+	"instructions": true,
+	"output":       true,
+}
+
+// 各协议中参与拦截正则与敏感信息检测的顶层字段
+var (
+	claudePolicyTextRoots    = []string{"system", "messages"}
+	responsesPolicyTextRoots = []string{"instructions", "input"}
+	geminiPolicyTextRoots    = []string{"systemInstruction", "system_instruction", "contents"}
+)
+
+// policyTextRef 请求体中的一段文本及其 gjson/sjson 路径
+type policyTextRef struct {
+	path string
+	text string
+}
+
+// requestPolicyAppliedKey 标记本次请求已由 handler 执行过分组策略（gin context）
+const requestPolicyAppliedKey = "request_policy_applied"
+
+// requestPolicyPatternCache 缓存已编译的拦截正则（pattern -> *regexp.Regexp，编译失败为 nil）
+var requestPolicyPatternCache sync.Map
+
+// requestPolicyFromContext 从认证中间件写入的分组上下文中读取请求策略
+func requestPolicyFromContext(ctx context.Context) *RequestPolicy {
+	group, ok := ctx.Value(ctxkey.Group).(*Group)
+	if !ok || group == nil || group.RequestPolicy.IsEmpty() {
+		return nil
+	}
+	return group.RequestPolicy
+}
+
+// ApplyClaudeRequestPolicy 在 handler 中对 Claude Messages 请求体执行分组策略，返回改写后的请求体
+func ApplyClaudeRequestPolicy(c *gin.Context, body []byte) ([]byte, error) {
+	return applyRequestPolicyForHandler(c, applyClaudeRequestPolicy, body)
+}
+
+// ApplyResponsesRequestPolicy 在 handler 中对 OpenAI Responses 请求体执行分组策略，返回改写后的请求体
+func ApplyResponsesRequestPolicy(c *gin.Context, body []byte) ([]byte, error) {
+	return applyRequestPolicyForHandler(c, applyResponsesRequestPolicy, body)
+}
+
+// ApplyGeminiRequestPolicy 在 handler 中对 Gemini generateContent 请求体执行分组策略，返回改写后的请求体
+func ApplyGeminiRequestPolicy(c *gin.Context, body []byte) ([]byte, error) {
+	return applyRequestPolicyForHandler(c, applyGeminiRequestPolicy, body)
+}
+
+// applyRequestPolicyForHandler 执行策略并标记已执行，后续 Forward（含故障切换重试）不再重复执行
+func applyRequestPolicyForHandler(
+	c *gin.Context,
+	apply func(*RequestPolicy, []byte) ([]byte, *RedactionResult, error),
+	body []byte,
+) ([]byte, error) {
+	out, err := enforceRequestPolicy(c.Request.Context(), c, apply, body)
+	if err != nil {
+		return nil, err
+	}
+	c.Set(requestPolicyAppliedKey, true)
+	return out, nil
+}
+
+// enforceRequestPolicy 读取上下文中的分组策略并执行 apply；handler 已执行过时直接返回原请求体。
+// 检测到敏感信息时（无论 mask 还是 block）将命中统计写入 gin context，由运维日志中间件记录。
+func enforceRequestPolicy(
+	ctx context.Context,
+	c *gin.Context,
+	apply func(*RequestPolicy, []byte) ([]byte, *RedactionResult, error),
+	body []byte,
+) ([]byte, error) {
+	if c != nil && c.GetBool(requestPolicyAppliedKey) {
+		return body, nil
+	}
+	policy := requestPolicyFromContext(ctx)
+	if policy == nil {
+		return body, nil
+	}
+	out, redaction, err := apply(policy, body)
+	setOpsRedaction(c, redaction)
+	return out, err
+}
+
+// applyClaudeRequestPolicy 对 Claude Messages 请求体执行分组策略
+func applyClaudeRequestPolicy(policy *RequestPolicy, body []byte) ([]byte, *RedactionResult, error) {
+	if policy.IsEmpty() {
+		return body, nil, nil
+	}
+	var toolNames []string
+	gjson.GetBytes(body, "tools").ForEach(func(_, tool gjson.Result) bool {
+		toolNames = append(toolNames, tool.Get("name").String())
+		return true
+	})
+	if err := checkPolicyTools(policy, toolNames); err != nil {
+		return nil, nil, err
+	}
+	body, redaction, err := applyRequestRedaction(policy.Redaction, body, claudePolicyTextRoots...)
+	if err != nil {
+		return nil, redaction, err
+	}
+	if err := checkPolicyPatterns(policy, body, claudePolicyTextRoots...); err != nil {
+		return nil, redaction, err
+	}
+
+	maxTokens := gjson.GetBytes(body, "max_tokens")
+	maxTokensValue := maxTokens.Int()
+	if policy.MaxTokens > 0 && (!maxTokens.Exists() || maxTokensValue > int64(policy.MaxTokens)) {
+		maxTokensValue = int64(policy.MaxTokens)
+		if body, err = sjson.SetBytes(body, "max_tokens", maxTokensValue); err != nil {
+			return nil, redaction, err
+		}
+	}
+
+	thinkingType := gjson.GetBytes(body, "thinking.type").String()
+	thinkingEnabled := thinkingType == "enabled" || thinkingType == "adaptive"
+	if budget := gjson.GetBytes(body, "thinking.budget_tokens"); thinkingEnabled && budget.Exists() {
+		newBudget := budget.Int()
+		if policy.MaxThinkingBudgetTokens > 0 && newBudget > int64(policy.MaxThinkingBudgetTokens) {
+			newBudget = int64(policy.MaxThinkingBudgetTokens)
+		}
+		// 上游要求 budget_tokens < max_tokens
+		if policy.MaxTokens > 0 && newBudget >= maxTokensValue {
+			newBudget = maxTokensValue - 1
+		}
+		if newBudget != budget.Int() {
+			if body, err = sjson.SetBytes(body, "thinking.budget_tokens", newBudget); err != nil {
+				return nil, redaction, err
+			}
+		}
+	}
+
+	// 开启 thinking 时上游只接受 temperature=1，不覆盖
+	if policy.ForceTemperature != nil && !thinkingEnabled {
+		if body, err = sjson.SetBytes(body, "temperature", *policy.ForceTemperature); err != nil {
+			return nil, redaction, err
+		}
+	}
+
+	if policy.SystemPrompt != "" {
+		if body, err = prependClaudeSystemPrompt(body, policy.SystemPrompt); err != nil {
+			return nil, redaction, err
+		}
+	}
+	return body, redaction, nil
+}
+
+// prependClaudeSystemPrompt 将策略提示词插入 system 开头；
+// 若首个 system 块是 Claude Code 身份提示词，则插入其后，避免影响 OAuth 账号的客户端校验。
+func prependClaudeSystemPrompt(body []byte, prompt string) ([]byte, error) {
+	block := map[string]any{"type": "text", "text": prompt}
+	system := gjson.GetBytes(body, "system")
+	var blocks []any
+	switch {
+	case system.Type == gjson.String:
+		blocks = []any{block}
+		if strings.TrimSpace(system.String()) != "" {
+			blocks = append(blocks, map[string]any{"type": "text", "text": system.String()})
+		}
+	case system.IsArray():
+		existing, _ := system.Value().([]any)
+		insertAt := 0
+		if len(existing) > 0 {
+			if first, ok := existing[0].(map[string]any); ok {
+				if text, _ := first["text"].(string); strings.HasPrefix(strings.TrimSpace(text), strings.TrimSpace(claudeCodeSystemPrompt)) {
+					insertAt = 1
+				}
+			}
+		}
+		blocks = make([]any, 0, len(existing)+1)
+		blocks = append(blocks, existing[:insertAt]...)
+		blocks = append(blocks, block)
+		blocks = append(blocks, existing[insertAt:]...)
+	default:
+		blocks = []any{block}
+	}
+	return sjson.SetBytes(body, "system", blocks)
+}
+
+// applyResponsesRequestPolicy 对 OpenAI Responses 请求体执行分组策略。
+// thinking 预算对 Responses 无对应参数（reasoning.effort），不处理。
+func applyResponsesRequestPolicy(policy *RequestPolicy, body []byte) ([]byte, *RedactionResult, error) {
+	if policy.IsEmpty() {
+		return body, nil, nil
+	}
+	var toolNames []string
+	gjson.GetBytes(body, "tools").ForEach(func(_, tool gjson.Result) bool {
+		if name := tool.Get("name").String(); name != "" {
+			toolNames = append(toolNames, name)
+		} else {
+			// 内置工具（web_search、code_interpreter 等）按 type 匹配
+			toolNames = append(toolNames, tool.Get("type").String())
+		}
+		return true
+	})
+	if err := checkPolicyTools(policy, toolNames); err != nil {
+		return nil, nil, err
+	}
+	body, redaction, err := applyRequestRedaction(policy.Redaction, body, responsesPolicyTextRoots...)
+	if err != nil {
+		return nil, redaction, err
+	}
+	if err := checkPolicyPatterns(policy, body, responsesPolicyTextRoots...); err != nil {
+		return nil, redaction, err
+	}
+
+	if policy.MaxTokens > 0 {
+		if current := gjson.GetBytes(body, "max_output_tokens"); !current.Exists() || current.Int() > int64(policy.MaxTokens) {
+			if body, err = sjson.SetBytes(body, "max_output_tokens", policy.MaxTokens); err != nil {
+				return nil, redaction, err
+			}
+		}
+	}
+	if policy.ForceTemperature != nil {
+		if body, err = sjson.SetBytes(body, "temperature", *policy.ForceTemperature); err != nil {
+			return nil, redaction, err
+		}
+	}
+	if policy.SystemPrompt != "" {
+		developer := map[string]any{"type": "message", "role": "developer", "content": policy.SystemPrompt}
+		input := gjson.GetBytes(body, "input")
+		var items []any
+		switch {
+		case input.Type == gjson.String:
+			items = []any{developer, map[string]any{"type": "message", "role": "user", "content": input.String()}}
+		case input.IsArray():
+			existing, _ := input.Value().([]any)
+			items = append([]any{developer}, existing...)
+		default:
+			items = []any{developer}
+		}
+		if body, err = sjson.SetBytes(body, "input", items); err != nil {
+			return nil, redaction, err
+		}
+	}
+	return body, redaction, nil
+}
+
+// applyGeminiRequestPolicy 对 Gemini generateContent 请求体执行分组策略。
+// thinkingBudget 仅在请求显式设置时下调（部分模型不支持 thinkingConfig）。
+func applyGeminiRequestPolicy(policy *RequestPolicy, body []byte) ([]byte, *RedactionResult, error) {
+	if policy.IsEmpty() {
+		return body, nil, nil
+	}
+	var toolNames []string
+	gjson.GetBytes(body, "tools").ForEach(func(_, tool gjson.Result) bool {
+		tool.ForEach(func(key, value gjson.Result) bool {
+			switch key.String() {
+			case "functionDeclarations", "function_declarations":
+				value.ForEach(func(_, decl gjson.Result) bool {
+					toolNames = append(toolNames, decl.Get("name").String())
+					return true
+				})
+			default:
+				// 内置工具（googleSearch、codeExecution 等）按字段名匹配
+				toolNames = append(toolNames, key.String())
+			}
+			return true
+		})
+		return true
+	})
+	if err := checkPolicyTools(policy, toolNames); err != nil {
+		return nil, nil, err
+	}
+	body, redaction, err := applyRequestRedaction(policy.Redaction, body, geminiPolicyTextRoots...)
+	if err != nil {
+		return nil, redaction, err
+	}
+	if err := checkPolicyPatterns(policy, body, geminiPolicyTextRoots...); err != nil {
+		return nil, redaction, err
+	}
+
+	if policy.MaxTokens > 0 {
+		if current := gjson.GetBytes(body, "generationConfig.maxOutputTokens"); !current.Exists() || current.Int() > int64(policy.MaxTokens) {
+			if body, err = sjson.SetBytes(body, "generationConfig.maxOutputTokens", policy.MaxTokens); err != nil {
+				return nil, redaction, err
+			}
+		}
+	}
+	if policy.MaxThinkingBudgetTokens > 0 {
+		// -1 表示动态预算，同样视为超出上限
+		if budget := gjson.GetBytes(body, "generationConfig.thinkingConfig.thinkingBudget"); budget.Exists() &&
+			(budget.Int() < 0 || budget.Int() > int64(policy.MaxThinkingBudgetTokens)) {
+			if body, err = sjson.SetBytes(body, "generationConfig.thinkingConfig.thinkingBudget", policy.MaxThinkingBudgetTokens); err != nil {
+				return nil, redaction, err
+			}
+		}
+	}
+	if policy.ForceTemperature != nil {
+		if body, err = sjson.SetBytes(body, "generationConfig.temperature", *policy.ForceTemperature); err != nil {
+			return nil, redaction, err
+		}
+	}
+	if policy.SystemPrompt != "" {
+		key := "systemInstruction"
+		if !gjson.GetBytes(body, key).Exists() && gjson.GetBytes(body, "system_instruction").Exists() {
+			key = "system_instruction"
+		}
+		parts := []any{map[string]any{"text": policy.SystemPrompt}}
+		if existing, ok := gjson.GetBytes(body, key+".parts").Value().([]any); ok {
+			parts = append(parts, existing...)
+		}
+		if body, err = sjson.SetBytes(body, key+".parts", parts); err != nil {
+			return nil, redaction, err
+		}
+	}
+	return body, redaction, nil
+}
+
+func checkPolicyTools(policy *RequestPolicy, toolNames []string) error {
+	for _, name := range toolNames {
+		if policy.IsToolBlocked(name) {
+			return &RequestPolicyViolationError{Reason: fmt.Sprintf("tool %q is not allowed", name)}
+		}
+	}
+	return nil
+}
+
+// checkPolicyPatterns 检查请求体中指定顶层字段下的文本是否命中拦截正则
+func checkPolicyPatterns(policy *RequestPolicy, body []byte, roots ...string) error {
+	if len(policy.BlockedPatterns) == 0 {
+		return nil
+	}
+	texts := collectPolicyTextRefs(body, roots...)
+	if len(texts) == 0 {
+		return nil
+	}
+	for _, pattern := range policy.BlockedPatterns {
+		re := compilePolicyPattern(pattern)
+		if re == nil {
+			continue
+		}
+		for _, ref := range texts {
+			if re.MatchString(ref.text) {
+				return &RequestPolicyViolationError{Reason: "content matches a blocked pattern"}
+			}
+		}
+	}
+	return nil
+}
+
+// collectPolicyTextRefs 收集请求体中指定顶层字段下的文本
+func collectPolicyTextRefs(body []byte, roots ...string) []policyTextRef {
+	var refs []policyTextRef
+	for _, root := range roots {
+		collectPolicyText(gjson.GetBytes(body, root), root, gjson.Escape(root), &refs)
+	}
+	return refs
+}
+
+// collectPolicyText 递归收集 policyTextKeys 字段下的字符串值；数组元素沿用父字段名
+func collectPolicyText(value gjson.Result, key, path string, out *[]policyTextRef) {
+	switch {
+	case value.IsObject():
+		value.ForEach(func(k, v gjson.Result) bool {
+			collectPolicyText(v, k.String(), path+"."+gjson.Escape(k.String()), out)
+			return true
+		})
+	case value.IsArray():
+		i := 0
+		value.ForEach(func(_, v gjson.Result) bool {
+			collectPolicyText(v, key, path+"."+strconv.Itoa(i), out)
+			i++
+			return true
+		})
+	case value.Type == gjson.String:
+		if policyTextKeys[key] {
+			*out = append(*out, policyTextRef{path: path, text: value.String()})
+		}
+	}
+}
+
+func compilePolicyPattern(pattern string) *regexp.Regexp {
+	if cached, ok := requestPolicyPatternCache.Load(pattern); ok {
+		re, _ := cached.(*regexp.Regexp)
+		return re
+	}
+	// 策略保存时已校验，编译失败（如历史数据）时忽略该规则
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		re = nil
+	}
+	requestPolicyPatternCache.Store(pattern, re)
+	return re
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// 分组敏感信息检测（RequestPolicy.Redaction）
+//
+// 在分组请求策略中执行，扫描与拦截正则相同的消息文本字段（Claude system/messages、
+// Responses instructions/input、Gemini systemInstruction/contents）：
+//   - mask：命中内容替换为 [REDACTED:<detector>] 后继续转发
+//   - block：拒绝请求（*RequestPolicyViolationError）
+//
+// 命中统计（每个检测器的次数）通过 OpsRedactionKey 写入 gin context，
+// 由 ops_error_logger 中间件异步写入 ops_redaction_logs；原文不会被记录。
+
+// RedactionPolicy 敏感信息检测配置，定义见 domain.RedactionPolicy
+type RedactionPolicy = domain.RedactionPolicy
+
+// RedactionPattern 自定义检测器，定义见 domain.RedactionPattern
+type RedactionPattern = domain.RedactionPattern
+
+const (
+	RedactionActionMask  = domain.RedactionActionMask
+	RedactionActionBlock = domain.RedactionActionBlock
+)
+
+// builtinRedactionPatterns 内置检测器正则
+var builtinRedactionPatterns = map[string]*regexp.Regexp{
+	domain.RedactionDetectorPrivateKey: regexp.MustCompile(`(?s)-----BEGIN [A-Z0-9 ]*PRIVATE KEY-----.*?(?:-----END [A-Z0-9 ]*PRIVATE KEY-----|$)`),
+	domain.RedactionDetectorAWSKey: regexp.MustCompile(
+		`\b(?:AKIA|ASIA|ABIA|ACCA)[0-9A-Z]{16}\b` +
+			`|(?i:aws_?secret_?access_?key)["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}`),
+	domain.RedactionDetectorAPIKey: regexp.MustCompile(
+		`\bsk-(?:ant-|proj-)?[A-Za-z0-9_-]{20,}` +
+			`|\bgh[pousr]_[A-Za-z0-9]{36,}\b` +
+			`|\bgithub_pat_[A-Za-z0-9_]{22,}\b` +
+			`|\bglpat-[A-Za-z0-9_-]{20,}` +
+			`|\bxox[abprs]-[A-Za-z0-9-]{10,}` +
+			`|\bAIza[0-9A-Za-z_-]{35}\b`),
+	domain.RedactionDetectorEmail: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`),
+	// 北美格式（要求分隔符，避免误伤普通数字）、E.164 与中国大陆手机号
+	domain.RedactionDetectorPhone: regexp.MustCompile(
+		`(?:\+?1[-. ]?)?(?:\([2-9]\d{2}\)\s?|\b[2-9]\d{2}[-. ])\d{3}[-. ]\d{4}\b` +
+			`|\+[1-9]\d{7,14}\b` +
+			`|(?:\+86[- ]?1[3-9]\d{9}|\b1[3-9]\d{9})\b`),
+}
+
+// builtinRedactionOrder 内置检测器执行顺序：先处理私钥等大段内容，避免其中的片段被其他检测器重复计数
+var builtinRedactionOrder = []string{
+	domain.RedactionDetectorPrivateKey,
+	domain.RedactionDetectorAWSKey,
+	domain.RedactionDetectorAPIKey,
+	domain.RedactionDetectorEmail,
+	domain.RedactionDetectorPhone,
+}
+
+// RedactionResult 单次请求的敏感信息检测结果，只包含各检测器的命中次数，不含原文
+type RedactionResult struct {
+	Action string         `json:"action"`
+	Counts map[string]int `json:"counts"`
+	Total  int            `json:"total"`
+
+	policy *RedactionPolicy
+}
+
+// Detectors 返回命中的检测器名称（按名称排序）
+func (r *RedactionResult) Detectors() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, 0, len(r.Counts))
+	for name := range r.Counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MaskRequestBody 对请求体中的全部字符串值执行同一组检测器并掩码，
+// 用于持久化错误日志的请求体（block 模式下请求体未被改写）。
+func (r *RedactionResult) MaskRequestBody(body []byte) []byte {
+	if r == nil || r.policy.IsEmpty() || len(body) == 0 || !gjson.ValidBytes(body) {
+		return body
+	}
+	detectors := compileRedactionDetectors(r.policy)
+	var refs []policyTextRef
+	collectRedactionStrings(gjson.ParseBytes(body), "", &refs)
+	out := body
+	for _, ref := range refs {
+		masked, n := redactText(detectors, ref.text, nil)
+		if n == 0 {
+			continue
+		}
+		if updated, err := sjson.SetBytes(out, ref.path, masked); err == nil {
+			out = updated
+		}
+	}
+	return out
+}
+
+type redactionDetector struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// applyRequestRedaction 检测 roots 字段下的消息文本；mask 模式返回改写后的请求体，
+// block 模式命中时返回 *RequestPolicyViolationError。未命中时返回的结果为 nil。
+func applyRequestRedaction(policy *RedactionPolicy, body []byte, roots ...string) ([]byte, *RedactionResult, error) {
+	if policy.IsEmpty() {
+		return body, nil, nil
+	}
+	detectors := compileRedactionDetectors(policy)
+	if len(detectors) == 0 {
+		return body, nil, nil
+	}
+
+	action := policy.Action
+	if action == "" {
+		action = RedactionActionMask
+	}
+	result := &RedactionResult{Action: action, Counts: map[string]int{}, policy: policy}
+	type maskedRef struct {
+		path string
+		text string
+	}
+	var masked []maskedRef
+	for _, ref := range collectPolicyTextRefs(body, roots...) {
+		text, n := redactText(detectors, ref.text, result.Counts)
+		if n > 0 {
+			result.Total += n
+			masked = append(masked, maskedRef{path: ref.path, text: text})
+		}
+	}
+	if result.Total == 0 {
+		return body, nil, nil
+	}
+
+	if action == RedactionActionBlock {
+		return nil, result, &RequestPolicyViolationError{
+			Reason: "sensitive content detected (" + strings.Join(result.Detectors(), ", ") + ")",
+		}
+	}
+	var err error
+	for _, ref := range masked {
+		if body, err = sjson.SetBytes(body, ref.path, ref.text); err != nil {
+			return nil, result, err
+		}
+	}
+	return body, result, nil
+}
+
+// redactText 依次执行检测器并掩码，返回掩码后的文本与命中次数；counts 非 nil 时按检测器累计
+func redactText(detectors []redactionDetector, text string, counts map[string]int) (string, int) {
+	total := 0
+	for _, d := range detectors {
+		n := 0
+		text = d.re.ReplaceAllStringFunc(text, func(string) string {
+			n++
+			return "[REDACTED:" + d.name + "]"
+		})
+		if n > 0 {
+			total += n
+			if counts != nil {
+				counts[d.name] += n
+			}
+		}
+	}
+	return text, total
+}
+
+func compileRedactionDetectors(policy *RedactionPolicy) []redactionDetector {
+	detectors := make([]redactionDetector, 0, len(policy.Detectors)+len(policy.CustomPatterns))
+	for _, name := range builtinRedactionOrder {
+		for _, enabled := range policy.Detectors {
+			if strings.EqualFold(strings.TrimSpace(enabled), name) {
+				detectors = append(detectors, redactionDetector{name: name, re: builtinRedactionPatterns[name]})
+				break
+			}
+		}
+	}
+	for _, custom := range policy.CustomPatterns {
+		// 空正则会匹配任意位置，保存时已拒绝，这里同样跳过
+		if strings.TrimSpace(custom.Pattern) == "" {
+			continue
+		}
+		if re := compilePolicyPattern(custom.Pattern); re != nil {
+			detectors = append(detectors, redactionDetector{name: custom.Name, re: re})
+		}
+	}
+	return detectors
+}
+
+// collectRedactionStrings 收集 JSON 中的全部字符串值（不区分字段名）
+func collectRedactionStrings(value gjson.Result, path string, out *[]policyTextRef) {
+	join := func(key string) string {
+		if path == "" {
+			return key
+		}
+		return path + "." + key
+	}
+	switch {
+	case value.IsObject():
+		value.ForEach(func(k, v gjson.Result) bool {
+			collectRedactionStrings(v, join(gjson.Escape(k.String())), out)
+			return true
+		})
+	case value.IsArray():
+		i := 0
+		value.ForEach(func(_, v gjson.Result) bool {
+			collectRedactionStrings(v, join(strconv.Itoa(i)), out)
+			i++
+			return true
+		})
+	case value.Type == gjson.String:
+		if path != "" {
+			*out = append(*out, policyTextRef{path: path, text: value.String()})
+		}
+	}
+}
+
+// setOpsRedaction 记录本次请求的敏感信息检测结果，供运维日志中间件写入 ops_redaction_logs
+func setOpsRedaction(c *gin.Context, result *RedactionResult) {
+	if c == nil || result == nil || result.Total == 0 {
+		return
+	}
+	c.Set(OpsRedactionKey, result)
+}
@@ -0,0 +1,178 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+)
+
+func TestRequestPolicy_NormalizeAndValidate(t *testing.T) {
+	policy, err := normalizeRequestPolicy(&RequestPolicy{
+		BlockedTools:    []string{" Bash ", ""},
+		BlockedPatterns: []string{"(?i)secret", " "},
+		SystemPrompt:    "  be nice  ",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Bash"}, policy.BlockedTools)
+	require.Equal(t, []string{"(?i)secret"}, policy.BlockedPatterns)
+	require.Equal(t, "be nice", policy.SystemPrompt)
+
+	policy, err = normalizeRequestPolicy(&RequestPolicy{BlockedTools: []string{" "}})
+	require.NoError(t, err)
+	require.Nil(t, policy, "empty policy is stored as nil")
+
+	for _, invalid := range []*RequestPolicy{
+		{MaxTokens: -1},
+		{MaxThinkingBudgetTokens: -1},
+		{ForceTemperature: float64Ptr(2.5)},
+		{BlockedPatterns: []string{"("}},
+	} {
+		_, err := normalizeRequestPolicy(invalid)
+		require.Error(t, err)
+	}
+}
+
+func TestRequestPolicy_IsToolBlocked(t *testing.T) {
+	policy := &RequestPolicy{BlockedTools: []string{"bash", "mcp__github__*"}}
+	require.True(t, policy.IsToolBlocked("Bash"))
+	require.True(t, policy.IsToolBlocked("mcp__github__create_issue"))
+	require.False(t, policy.IsToolBlocked("bash_exec"))
+	require.False(t, policy.IsToolBlocked("Read"))
+}
+
+func TestRequestPolicyFromContext(t *testing.T) {
+	require.Nil(t, requestPolicyFromContext(context.Background()))
+
+	policy := &RequestPolicy{MaxTokens: 100}
+	ctx := context.WithValue(context.Background(), ctxkey.Group, &Group{ID: 1, RequestPolicy: policy})
+	require.Same(t, policy, requestPolicyFromContext(ctx))
+
+	ctx = context.WithValue(context.Background(), ctxkey.Group, &Group{ID: 1, RequestPolicy: &RequestPolicy{}})
+	require.Nil(t, requestPolicyFromContext(ctx))
+}
+
+// handler 执行过策略后，Forward（含故障切换重试）不再重复注入提示词
+func TestApplyClaudeRequestPolicy_HandlerAppliesOnce(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	req := httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+	ctx := context.WithValue(req.Context(), ctxkey.Group, &Group{ID: 1, RequestPolicy: &RequestPolicy{SystemPrompt: "Follow company policy."}})
+	c.Request = req.WithContext(ctx)
+
+	body := []byte(`{"model":"claude-sonnet-4-5","messages":[{"role":"user","content":"hi"}]}`)
+	out, err := ApplyClaudeRequestPolicy(c, body)
+	require.NoError(t, err)
+	require.Equal(t, "Follow company policy.", gjson.GetBytes(out, "system.0.text").String())
+
+	again, err := enforceRequestPolicy(ctx, c, applyClaudeRequestPolicy, out)
+	require.NoError(t, err)
+	require.Equal(t, string(out), string(again))
+	require.Equal(t, 1, strings.Count(string(again), "Follow company policy."))
+}
+
+func TestApplyClaudeRequestPolicy(t *testing.T) {
+	policy := &RequestPolicy{
+		MaxTokens:               4096,
+		MaxThinkingBudgetTokens: 8000,
+		ForceTemperature:        float64Ptr(0.2),
+		SystemPrompt:            "Follow company policy.",
+	}
+
+	body := []byte(`{"model":"claude-sonnet-4-5","max_tokens":32000,"thinking":{"type":"enabled","budget_tokens":16000},"temperature":1,"system":"You are helpful.","messages":[{"role":"user","content":"hi"}]}`)
+	out, _, err := applyClaudeRequestPolicy(policy, body)
+	require.NoError(t, err)
+	require.EqualValues(t, 4096, gjson.GetBytes(out, "max_tokens").Int())
+	require.EqualValues(t, 4095, gjson.GetBytes(out, "thinking.budget_tokens").Int(), "budget must stay below max_tokens")
+	require.EqualValues(t, 1, gjson.GetBytes(out, "temperature").Float(), "temperature is not forced when thinking is enabled")
+	require.Equal(t, "Follow company policy.", gjson.GetBytes(out, "system.0.text").String())
+	require.Equal(t, "You are helpful.", gjson.GetBytes(out, "system.1.text").String())
+
+	// 未开启 thinking：强制 temperature；Claude Code 身份提示词保持在首位
+	body = []byte(`{"model":"claude-sonnet-4-5","max_tokens":100,"temperature":0.9,"system":[{"type":"text","text":"` + claudeCodeSystemPrompt + `"},{"type":"text","text":"more"}],"messages":[]}`)
+	out, _, err = applyClaudeRequestPolicy(policy, body)
+	require.NoError(t, err)
+	require.EqualValues(t, 100, gjson.GetBytes(out, "max_tokens").Int())
+	require.InDelta(t, 0.2, gjson.GetBytes(out, "temperature").Float(), 1e-9)
+	require.Equal(t, claudeCodeSystemPrompt, gjson.GetBytes(out, "system.0.text").String())
+	require.Equal(t, "Follow company policy.", gjson.GetBytes(out, "system.1.text").String())
+	require.Equal(t, "more", gjson.GetBytes(out, "system.2.text").String())
+
+	// 空策略不修改请求体
+	out, _, err = applyClaudeRequestPolicy(nil, body)
+	require.NoError(t, err)
+	require.Equal(t, string(body), string(out))
+}
+
+func TestApplyClaudeRequestPolicy_Violations(t *testing.T) {
+	policy := &RequestPolicy{BlockedTools: []string{"bash"}, BlockedPatterns: []string{`sk-[A-Za-z0-9]{8,}`}}
+
+	_, _, err := applyClaudeRequestPolicy(policy, []byte(`{"tools":[{"name":"Read"},{"name":"Bash"}],"messages":[]}`))
+	var violation *RequestPolicyViolationError
+	require.True(t, errors.As(err, &violation))
+	require.Contains(t, err.Error(), `tool "Bash" is not allowed`)
+
+	_, _, err = applyClaudeRequestPolicy(policy, []byte(`{"messages":[{"role":"user","content":[{"type":"tool_result","tool_use_id":"t","content":[{"type":"text","text":"key=sk-abcdefgh1234"}]}]}]}`))
+	require.True(t, errors.As(err, &violation))
+	require.Contains(t, err.Error(), "blocked pattern")
+
+	// 图片 base64 数据不参与匹配
+	_, _, err = applyClaudeRequestPolicy(policy, []byte(`{"messages":[{"role":"user","content":[{"type":"image","source":{"type":"base64","data":"sk-abcdefgh1234"}}]}]}`))
+	require.NoError(t, err)
+}
+
+func TestApplyResponsesRequestPolicy(t *testing.T) {
+	policy := &RequestPolicy{
+		MaxTokens:        1000,
+		BlockedTools:     []string{"web_search"},
+		ForceTemperature: float64Ptr(0),
+		SystemPrompt:     "Be concise.",
+	}
+
+	out, _, err := applyResponsesRequestPolicy(policy, []byte(`{"model":"gpt-5","input":"hello","max_output_tokens":5000}`))
+	require.NoError(t, err)
+	require.EqualValues(t, 1000, gjson.GetBytes(out, "max_output_tokens").Int())
+	require.True(t, gjson.GetBytes(out, "temperature").Exists())
+	require.Equal(t, "developer", gjson.GetBytes(out, "input.0.role").String())
+	require.Equal(t, "Be concise.", gjson.GetBytes(out, "input.0.content").String())
+	require.Equal(t, "hello", gjson.GetBytes(out, "input.1.content").String())
+
+	_, _, err = applyResponsesRequestPolicy(policy, []byte(`{"model":"gpt-5","input":[],"tools":[{"type":"web_search"}]}`))
+	require.ErrorContains(t, err, `tool "web_search" is not allowed`)
+}
+
+func TestApplyGeminiRequestPolicy(t *testing.T) {
+	policy := &RequestPolicy{
+		MaxTokens:               2048,
+		MaxThinkingBudgetTokens: 1024,
+		ForceTemperature:        float64Ptr(0.5),
+		SystemPrompt:            "Policy.",
+		BlockedPatterns:         []string{"(?i)forbidden"},
+	}
+
+	body := []byte(`{"contents":[{"role":"user","parts":[{"text":"hi"}]}],"systemInstruction":{"parts":[{"text":"orig"}]},"generationConfig":{"maxOutputTokens":8192,"thinkingConfig":{"thinkingBudget":-1}}}`)
+	out, _, err := applyGeminiRequestPolicy(policy, body)
+	require.NoError(t, err)
+	require.EqualValues(t, 2048, gjson.GetBytes(out, "generationConfig.maxOutputTokens").Int())
+	require.EqualValues(t, 1024, gjson.GetBytes(out, "generationConfig.thinkingConfig.thinkingBudget").Int())
+	require.InDelta(t, 0.5, gjson.GetBytes(out, "generationConfig.temperature").Float(), 1e-9)
+	require.Equal(t, "Policy.", gjson.GetBytes(out, "systemInstruction.parts.0.text").String())
+	require.Equal(t, "orig", gjson.GetBytes(out, "systemInstruction.parts.1.text").String())
+
+	_, _, err = applyGeminiRequestPolicy(policy, []byte(`{"contents":[{"role":"user","parts":[{"text":"something FORBIDDEN"}]}]}`))
+	require.ErrorContains(t, err, "blocked pattern")
+
+	_, _, err = applyGeminiRequestPolicy(&RequestPolicy{BlockedTools: []string{"googleSearch", "run_*"}}, []byte(`{"contents":[],"tools":[{"functionDeclarations":[{"name":"run_shell"}]}]}`))
+	require.ErrorContains(t, err, `tool "run_shell" is not allowed`)
+	_, _, err = applyGeminiRequestPolicy(&RequestPolicy{BlockedTools: []string{"googleSearch"}}, []byte(`{"contents":[],"tools":[{"googleSearch":{}}]}`))
+	require.ErrorContains(t, err, `tool "googleSearch" is not allowed`)
+}
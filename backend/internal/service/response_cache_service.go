@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/timezone"
+	"github.com/google/uuid"
+)
+
+// 响应缓存端点（不同端点的响应格式不同，不可共用缓存）
+const (
+	ResponseCacheEndpointMessages  = "messages"
+	ResponseCacheEndpointResponses = "responses"
+)
+
+const (
+	responseCacheDefaultTTL      = time.Hour
+	responseCacheDefaultMaxBytes = 1024 * 1024
+	// responseCacheMaxStatsDays 命中率统计最多查询的天数（与统计数据保留时间一致）
+	responseCacheMaxStatsDays = 31
+)
+
+// responseCacheIgnoredFields 计算请求哈希时忽略的字段：
+// 流式开关单独计入缓存键；metadata/user/prompt_cache_key 等标识字段不影响模型输出
+var responseCacheIgnoredFields = []string{"stream", "stream_options", "metadata", "user", "prompt_cache_key"}
+
+// ResponseCacheEntry 缓存的完整客户端响应（非流式为 JSON 响应体，流式为完整 SSE 事件序列）
+type ResponseCacheEntry struct {
+	ContentType     string      `json:"content_type"`
+	Body            []byte      `json:"body"`
+	Model           string      `json:"model"`
+	ReasoningEffort *string     `json:"reasoning_effort,omitempty"`
+	AccountID       int64       `json:"account_id"` // 生成该响应的上游账号（用量日志关联）
+	Usage           ClaudeUsage `json:"usage"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+// ResponseCacheDailyStats 单日缓存统计
+type ResponseCacheDailyStats struct {
+	Date    string  `json:"date"`
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	Stores  int64   `json:"stores"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// ResponseCacheStats 时间范围内的缓存命中率统计
+type ResponseCacheStats struct {
+	Hits    int64                     `json:"hits"`
+	Misses  int64                     `json:"misses"`
+	Stores  int64                     `json:"stores"`
+	HitRate float64                   `json:"hit_rate"`
+	Daily   []ResponseCacheDailyStats `json:"daily"`
+}
+
+// ResponseCache 响应缓存存储
+type ResponseCache interface {
+	// GetResponse 读取缓存响应，未命中时返回 nil, nil
+	GetResponse(ctx context.Context, key string) (*ResponseCacheEntry, error)
+	SetResponse(ctx context.Context, key string, entry *ResponseCacheEntry, ttl time.Duration) error
+	// IncrStats 累加指定日期（yyyy-mm-dd）的命中/未命中/写入次数
+	IncrStats(ctx context.Context, date string, hits, misses, stores int64) error
+	// GetStats 批量读取指定日期的统计，缺失日期不出现在结果中
+	GetStats(ctx context.Context, dates []string) (map[string]ResponseCacheDailyStats, error)
+}
+
+// ResponseCacheRequest 一次可缓存请求的缓存键与过期时间
+type ResponseCacheRequest struct {
+	Key    string
+	TTL    time.Duration
+	Stream bool
+}
+
+// ResponseCacheService 确定性请求（temperature=0）响应缓存
+type ResponseCacheService struct {
+	cache ResponseCache
+	cfg   *config.Config
+}
+
+// NewResponseCacheService 创建响应缓存服务
+func NewResponseCacheService(cache ResponseCache, cfg *config.Config) *ResponseCacheService {
+	return &ResponseCacheService{cache: cache, cfg: cfg}
+}
+
+// Prepare 判断请求是否可缓存并计算缓存键；分组未开启缓存或请求非确定性时返回 nil
+func (s *ResponseCacheService) Prepare(group *Group, endpoint, model string, stream bool, body []byte) *ResponseCacheRequest {
+	if s == nil || s.cache == nil || group == nil || !group.ResponseCacheEnabled {
+		return nil
+	}
+	hash, ok := responseCacheRequestHash(body)
+	if !ok {
+		return nil
+	}
+	ttl := responseCacheDefaultTTL
+	if s.cfg != nil && s.cfg.Gateway.ResponseCache.DefaultTTLSeconds > 0 {
+		ttl = time.Duration(s.cfg.Gateway.ResponseCache.DefaultTTLSeconds) * time.Second
+	}
+	if group.ResponseCacheTTLSeconds > 0 {
+		ttl = time.Duration(group.ResponseCacheTTLSeconds) * time.Second
+	}
+	return &ResponseCacheRequest{
+		Key:    fmt.Sprintf("%d:%s:%s:%t:%s", group.ID, endpoint, model, stream, hash),
+		TTL:    ttl,
+		Stream: stream,
+	}
+}
+
+// Lookup 查询缓存并记录命中/未命中；Redis 故障时视为未命中
+func (s *ResponseCacheService) Lookup(ctx context.Context, req *ResponseCacheRequest) *ResponseCacheEntry {
+	if s == nil || req == nil {
+		return nil
+	}
+	entry, err := s.cache.GetResponse(ctx, req.Key)
+	if err != nil {
+		log.Printf("[ResponseCache] lookup failed: %v", err)
+		return nil
+	}
+	if entry != nil {
+		s.incrStats(ctx, 1, 0, 0)
+	} else {
+		s.incrStats(ctx, 0, 1, 0)
+	}
+	return entry
+}
+
+// Store 写入缓存；空响应或超出大小上限时跳过
+func (s *ResponseCacheService) Store(ctx context.Context, req *ResponseCacheRequest, entry *ResponseCacheEntry) {
+	if s == nil || req == nil || entry == nil || len(entry.Body) == 0 || len(entry.Body) > s.MaxEntryBytes() {
+		return
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	if err := s.cache.SetResponse(ctx, req.Key, entry, req.TTL); err != nil {
+		log.Printf("[ResponseCache] store failed: %v", err)
+		return
+	}
+	s.incrStats(ctx, 0, 0, 1)
+}
+
+// MaxEntryBytes 单条缓存响应的最大字节数
+func (s *ResponseCacheService) MaxEntryBytes() int {
+	if s != nil && s.cfg != nil && s.cfg.Gateway.ResponseCache.MaxEntryBytes > 0 {
+		return s.cfg.Gateway.ResponseCache.MaxEntryBytes
+	}
+	return responseCacheDefaultMaxBytes
+}
+
+// GetStats 获取 [startTime, endTime) 范围内的命中率统计（按天）
+func (s *ResponseCacheService) GetStats(ctx context.Context, startTime, endTime time.Time) (*ResponseCacheStats, error) {
+	var dates []string
+	for day := startTime; day.Before(endTime) && len(dates) < responseCacheMaxStatsDays; day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day.Format("2006-01-02"))
+	}
+	byDate, err := s.cache.GetStats(ctx, dates)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ResponseCacheStats{Daily: make([]ResponseCacheDailyStats, 0, len(dates))}
+	for _, date := range dates {
+		daily := byDate[date]
+		daily.Date = date
+		daily.HitRate = responseCacheHitRate(daily.Hits, daily.Misses)
+		stats.Hits += daily.Hits
+		stats.Misses += daily.Misses
+		stats.Stores += daily.Stores
+		stats.Daily = append(stats.Daily, daily)
+	}
+	stats.HitRate = responseCacheHitRate(stats.Hits, stats.Misses)
+	return stats, nil
+}
+
+func (s *ResponseCacheService) incrStats(ctx context.Context, hits, misses, stores int64) {
+	if err := s.cache.IncrStats(ctx, timezone.Now().Format("2006-01-02"), hits, misses, stores); err != nil {
+		log.Printf("[ResponseCache] update stats failed: %v", err)
+	}
+}
+
+func responseCacheHitRate(hits, misses int64) float64 {
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
+// responseCacheRequestHash 计算规范化请求体的哈希。
+// 仅显式设置 temperature=0 的请求可缓存；规范化会按键名排序并忽略不影响输出的字段。
+func responseCacheRequestHash(body []byte) (string, bool) {
+	var req map[string]any
+	if err := json.Unmarshal(body, &req); err != nil {
+		return "", false
+	}
+	temperature, ok := req["temperature"].(float64)
+	if !ok || temperature != 0 {
+		return "", false
+	}
+	for _, field := range responseCacheIgnoredFields {
+		delete(req, field)
+	}
+	normalized, err := json.Marshal(req)
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(normalized)
+	return hex.EncodeToString(sum[:]), true
+}
+
+// NewResponseCacheEntry 基于 Anthropic/Gemini 转发结果构建缓存条目
+func NewResponseCacheEntry(contentType string, body []byte, accountID int64, result *ForwardResult) *ResponseCacheEntry {
+	return &ResponseCacheEntry{
+		ContentType: contentType,
+		Body:        body,
+		Model:       result.Model,
+		AccountID:   accountID,
+		Usage:       result.Usage,
+	}
+}
+
+// NewOpenAIResponseCacheEntry 基于 OpenAI 转发结果构建缓存条目
+func NewOpenAIResponseCacheEntry(contentType string, body []byte, accountID int64, result *OpenAIForwardResult) *ResponseCacheEntry {
+	return &ResponseCacheEntry{
+		ContentType:     contentType,
+		Body:            body,
+		Model:           result.Model,
+		ReasoningEffort: result.ReasoningEffort,
+		AccountID:       accountID,
+		Usage: ClaudeUsage{
+			InputTokens:              result.Usage.InputTokens,
+			OutputTokens:             result.Usage.OutputTokens,
+			CacheCreationInputTokens: result.Usage.CacheCreationInputTokens,
+			CacheReadInputTokens:     result.Usage.CacheReadInputTokens,
+		},
+	}
+}
+
+// ForwardResult 将缓存条目转换为用于计费的转发结果（每次命中使用新的请求 ID）
+func (e *ResponseCacheEntry) ForwardResult(stream bool, duration time.Duration) *ForwardResult {
+	return &ForwardResult{
+		RequestID: responseCacheRequestID(),
+		Usage:     e.Usage,
+		Model:     e.Model,
+		Stream:    stream,
+		Duration:  duration,
+	}
+}
+
+// OpenAIForwardResult 将缓存条目转换为用于计费的 OpenAI 转发结果
+func (e *ResponseCacheEntry) OpenAIForwardResult(stream bool, duration time.Duration) *OpenAIForwardResult {
+	return &OpenAIForwardResult{
+		RequestID: responseCacheRequestID(),
+		Usage: OpenAIUsage{
+			InputTokens:              e.Usage.InputTokens,
+			OutputTokens:             e.Usage.OutputTokens,
+			CacheCreationInputTokens: e.Usage.CacheCreationInputTokens,
+			CacheReadInputTokens:     e.Usage.CacheReadInputTokens,
+		},
+		Model:           e.Model,
+		ReasoningEffort: e.ReasoningEffort,
+		Stream:          stream,
+		Duration:        duration,
+	}
+}
+
+// OriginAccount 生成该响应的上游账号（仅含 ID，用于用量日志关联）
+func (e *ResponseCacheEntry) OriginAccount() *Account {
+	return &Account{ID: e.AccountID}
+}
+
+func responseCacheRequestID() string {
+	return "cache_" + uuid.NewString()
+}
+
+// applyResponseCacheDiscount 按缓存命中折扣调整各项费用
+func applyResponseCacheDiscount(cost *CostBreakdown, cfg *config.Config) {
+	if cost == nil || cfg == nil {
+		return
+	}
+	cost.Scale(1 - cfg.Gateway.ResponseCache.Discount)
+}
@@ -0,0 +1,204 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+type responseCacheStub struct {
+	entries map[string]*ResponseCacheEntry
+	ttls    map[string]time.Duration
+	stats   map[string]ResponseCacheDailyStats
+	getErr  error
+}
+
+func newResponseCacheStub() *responseCacheStub {
+	return &responseCacheStub{
+		entries: map[string]*ResponseCacheEntry{},
+		ttls:    map[string]time.Duration{},
+		stats:   map[string]ResponseCacheDailyStats{},
+	}
+}
+
+func (s *responseCacheStub) GetResponse(ctx context.Context, key string) (*ResponseCacheEntry, error) {
+	if s.getErr != nil {
+		return nil, s.getErr
+	}
+	return s.entries[key], nil
+}
+
+func (s *responseCacheStub) SetResponse(ctx context.Context, key string, entry *ResponseCacheEntry, ttl time.Duration) error {
+	s.entries[key] = entry
+	s.ttls[key] = ttl
+	return nil
+}
+
+func (s *responseCacheStub) IncrStats(ctx context.Context, date string, hits, misses, stores int64) error {
+	stats := s.stats[date]
+	stats.Hits += hits
+	stats.Misses += misses
+	stats.Stores += stores
+	s.stats[date] = stats
+	return nil
+}
+
+func (s *responseCacheStub) GetStats(ctx context.Context, dates []string) (map[string]ResponseCacheDailyStats, error) {
+	return s.stats, nil
+}
+
+func (s *responseCacheStub) totals() (hits, misses, stores int64) {
+	for _, stats := range s.stats {
+		hits += stats.Hits
+		misses += stats.Misses
+		stores += stats.Stores
+	}
+	return
+}
+
+func TestResponseCacheRequestHash(t *testing.T) {
+	base, ok := responseCacheRequestHash([]byte(`{"model":"m","temperature":0,"messages":[{"role":"user","content":"hi"}]}`))
+	require.True(t, ok)
+
+	// 键顺序、流式开关与标识字段不影响哈希
+	same, ok := responseCacheRequestHash([]byte(`{"messages":[{"role":"user","content":"hi"}],"temperature":0.0,"model":"m","stream":true,"metadata":{"user_id":"u1"}}`))
+	require.True(t, ok)
+	require.Equal(t, base, same)
+
+	// 内容变化
+	other, ok := responseCacheRequestHash([]byte(`{"model":"m","temperature":0,"messages":[{"role":"user","content":"hello"}]}`))
+	require.True(t, ok)
+	require.NotEqual(t, base, other)
+
+	// 非确定性请求不可缓存
+	for _, body := range []string{
+		`{"model":"m","messages":[]}`,
+		`{"model":"m","temperature":0.7,"messages":[]}`,
+		`{"model":"m","temperature":"0","messages":[]}`,
+		`not json`,
+	} {
+		_, ok := responseCacheRequestHash([]byte(body))
+		require.False(t, ok, body)
+	}
+}
+
+func TestResponseCacheServicePrepare(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Gateway.ResponseCache.DefaultTTLSeconds = 600
+	svc := NewResponseCacheService(newResponseCacheStub(), cfg)
+	body := []byte(`{"model":"m","temperature":0}`)
+
+	require.Nil(t, svc.Prepare(nil, ResponseCacheEndpointMessages, "m", false, body))
+	require.Nil(t, svc.Prepare(&Group{ID: 1}, ResponseCacheEndpointMessages, "m", false, body))
+	require.Nil(t, svc.Prepare(&Group{ID: 1, ResponseCacheEnabled: true}, ResponseCacheEndpointMessages, "m", false, []byte(`{"model":"m"}`)))
+
+	group := &Group{ID: 1, ResponseCacheEnabled: true}
+	req := svc.Prepare(group, ResponseCacheEndpointMessages, "m", false, body)
+	require.NotNil(t, req)
+	require.Equal(t, 10*time.Minute, req.TTL)
+
+	// 流式/非流式、端点、分组分别缓存
+	require.NotEqual(t, req.Key, svc.Prepare(group, ResponseCacheEndpointMessages, "m", true, body).Key)
+	require.NotEqual(t, req.Key, svc.Prepare(group, ResponseCacheEndpointResponses, "m", false, body).Key)
+	require.NotEqual(t, req.Key, svc.Prepare(&Group{ID: 2, ResponseCacheEnabled: true}, ResponseCacheEndpointMessages, "m", false, body).Key)
+
+	// 分组 TTL 优先
+	group.ResponseCacheTTLSeconds = 30
+	require.Equal(t, 30*time.Second, svc.Prepare(group, ResponseCacheEndpointMessages, "m", false, body).TTL)
+
+	var nilSvc *ResponseCacheService
+	require.Nil(t, nilSvc.Prepare(group, ResponseCacheEndpointMessages, "m", false, body))
+	require.Nil(t, nilSvc.Lookup(context.Background(), nil))
+}
+
+func TestResponseCacheServiceLookupAndStore(t *testing.T) {
+	ctx := context.Background()
+	cache := newResponseCacheStub()
+	cfg := &config.Config{}
+	cfg.Gateway.ResponseCache.MaxEntryBytes = 16
+	svc := NewResponseCacheService(cache, cfg)
+	req := &ResponseCacheRequest{Key: "k", TTL: time.Minute}
+
+	require.Nil(t, svc.Lookup(ctx, req))
+
+	// 超出大小上限不缓存
+	svc.Store(ctx, req, &ResponseCacheEntry{Body: []byte("this body is far too large")})
+	require.Nil(t, svc.Lookup(ctx, req))
+
+	svc.Store(ctx, req, &ResponseCacheEntry{Body: []byte(`{"ok":true}`), Model: "m", AccountID: 7})
+	entry := svc.Lookup(ctx, req)
+	require.NotNil(t, entry)
+	require.Equal(t, int64(7), entry.AccountID)
+	require.False(t, entry.CreatedAt.IsZero())
+	require.Equal(t, time.Minute, cache.ttls["k"])
+
+	hits, misses, stores := cache.totals()
+	require.Equal(t, int64(1), hits)
+	require.Equal(t, int64(2), misses)
+	require.Equal(t, int64(1), stores)
+
+	// Redis 故障视为未命中
+	cache.getErr = errors.New("redis down")
+	require.Nil(t, svc.Lookup(ctx, req))
+}
+
+func TestResponseCacheServiceGetStats(t *testing.T) {
+	cache := newResponseCacheStub()
+	cache.stats["2026-01-01"] = ResponseCacheDailyStats{Hits: 3, Misses: 1, Stores: 1}
+	cache.stats["2026-01-03"] = ResponseCacheDailyStats{Hits: 1, Misses: 3}
+	svc := NewResponseCacheService(cache, nil)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	stats, err := svc.GetStats(context.Background(), start, start.AddDate(0, 0, 3))
+	require.NoError(t, err)
+	require.Len(t, stats.Daily, 3)
+	require.Equal(t, "2026-01-02", stats.Daily[1].Date)
+	require.Zero(t, stats.Daily[1].HitRate)
+	require.InDelta(t, 0.75, stats.Daily[0].HitRate, 1e-9)
+	require.Equal(t, int64(4), stats.Hits)
+	require.Equal(t, int64(4), stats.Misses)
+	require.InDelta(t, 0.5, stats.HitRate, 1e-9)
+
+	// 查询范围上限
+	stats, err = svc.GetStats(context.Background(), start, start.AddDate(1, 0, 0))
+	require.NoError(t, err)
+	require.Len(t, stats.Daily, responseCacheMaxStatsDays)
+}
+
+func TestResponseCacheEntryForwardResults(t *testing.T) {
+	effort := "high"
+	openaiEntry := NewOpenAIResponseCacheEntry("application/json", []byte("{}"), 9, &OpenAIForwardResult{
+		Model:           "gpt-5",
+		ReasoningEffort: &effort,
+		Usage:           OpenAIUsage{InputTokens: 10, OutputTokens: 5, CacheReadInputTokens: 2},
+	})
+	result := openaiEntry.OpenAIForwardResult(true, time.Millisecond)
+	require.Equal(t, "gpt-5", result.Model)
+	require.Equal(t, OpenAIUsage{InputTokens: 10, OutputTokens: 5, CacheReadInputTokens: 2}, result.Usage)
+	require.Equal(t, &effort, result.ReasoningEffort)
+	require.True(t, result.Stream)
+	require.Contains(t, result.RequestID, "cache_")
+	require.Equal(t, int64(9), openaiEntry.OriginAccount().ID)
+
+	entry := NewResponseCacheEntry("text/event-stream", []byte("data: {}\n\n"), 3, &ForwardResult{Model: "claude", Usage: ClaudeUsage{InputTokens: 1}})
+	first, second := entry.ForwardResult(false, 0), entry.ForwardResult(false, 0)
+	require.Equal(t, ClaudeUsage{InputTokens: 1}, first.Usage)
+	require.NotEqual(t, first.RequestID, second.RequestID)
+}
+
+func TestApplyResponseCacheDiscount(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Gateway.ResponseCache.Discount = 0.75
+	cost := &CostBreakdown{InputCost: 1, OutputCost: 2, TotalCost: 3, ActualCost: 6}
+	applyResponseCacheDiscount(cost, cfg)
+	require.InDelta(t, 0.25, cost.InputCost, 1e-9)
+	require.InDelta(t, 0.5, cost.OutputCost, 1e-9)
+	require.InDelta(t, 0.75, cost.TotalCost, 1e-9)
+	require.InDelta(t, 1.5, cost.ActualCost, 1e-9)
+}
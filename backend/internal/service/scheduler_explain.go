@@ -0,0 +1,417 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/ctxkey"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+)
+
+// 调度决策解释（dry-run）
+//
+// 按 SelectAccountWithLoadAwareness 的分层逻辑（模型路由 → 粘性会话 → 负载感知 → 兜底排队）
+// 重放一次账号选择，但不获取并发槽位、不注册会话、不写粘性绑定，
+// 返回分组内每个账号的过滤结论、实时负载以及最终会选中的账号。
+// 负载感知层在同优先级、同负载率、同 LastUsedAt 的账号之间会随机打散，因此结果表示“最可能”的选择。
+// OpenAI 分组由 OpenAIGatewayService 独立选择（粘性键、过滤顺序均不同），不在重放范围内。
+
+// ErrSchedulerExplainPlatformUnsupported 请求或分组平台为 OpenAI
+var ErrSchedulerExplainPlatformUnsupported = infraerrors.BadRequest("SCHEDULER_EXPLAIN_PLATFORM_UNSUPPORTED", "scheduler explain does not support openai groups")
+
+// 账号过滤结论
+const (
+	SchedulerVerdictEligible          = "eligible"
+	SchedulerVerdictExcluded          = "excluded"
+	SchedulerVerdictInactive          = "inactive"
+	SchedulerVerdictExpired           = "expired"
+	SchedulerVerdictRateLimited       = "rate_limited"
+	SchedulerVerdictOverloaded        = "overloaded"
+	SchedulerVerdictTempUnschedulable = "temp_unschedulable"
+	SchedulerVerdictMaintenance       = "maintenance"
+	SchedulerVerdictOutsideSchedule   = "outside_schedule"
+	SchedulerVerdictDraining          = "draining"
+	SchedulerVerdictPlatformMismatch  = "platform_mismatch"
+	SchedulerVerdictModelUnsupported  = "model_unsupported"
+	SchedulerVerdictModelRateLimited  = "model_rate_limited"
+	SchedulerVerdictWindowCost        = "window_cost"
+	SchedulerVerdictMaxSessions       = "max_sessions"
+	// SchedulerVerdictNotInSnapshot 账号本身可用，但尚未进入调度快照（快照重建有节流）
+	SchedulerVerdictNotInSnapshot = "not_in_snapshot"
+)
+
+// 最终选择所在的调度层
+const (
+	SchedulerLayerModelRouting = "model_routing"
+	SchedulerLayerSticky       = "sticky_session"
+	SchedulerLayerStickyWait   = "sticky_session_wait"
+	SchedulerLayerLoadBalance  = "load_balance"
+	SchedulerLayerFallbackWait = "fallback_wait"
+	SchedulerLayerNone         = "none"
+)
+
+// SchedulerExplainInput dry-run 调度参数
+type SchedulerExplainInput struct {
+	GroupID *int64
+	// Platform 为空时使用分组平台；与分组平台不同时等同于强制平台路由（如 /antigravity）
+	Platform           string
+	Model              string
+	SessionHash        string
+	ExcludedAccountIDs []int64
+}
+
+// SchedulerCandidate 单个账号的过滤结论与负载
+type SchedulerCandidate struct {
+	AccountID   int64      `json:"account_id"`
+	Name        string     `json:"name"`
+	Platform    string     `json:"platform"`
+	Type        string     `json:"type"`
+	Priority    int        `json:"priority"`
+	Concurrency int        `json:"concurrency"`
+	Weight      int        `json:"weight,omitempty"`
+	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
+
+	Verdict string `json:"verdict"`
+	Detail  string `json:"detail,omitempty"`
+	Sticky  bool   `json:"sticky"`
+	Routed  bool   `json:"routed"`
+
+	CurrentConcurrency int `json:"current_concurrency"`
+	WaitingCount       int `json:"waiting_count"`
+	LoadRate           int `json:"load_rate"`
+}
+
+// SchedulerExplanation dry-run 调度结果
+type SchedulerExplanation struct {
+	GroupID           *int64  `json:"group_id,omitempty"`
+	GroupName         string  `json:"group_name,omitempty"`
+	Platform          string  `json:"platform"`
+	Model             string  `json:"model,omitempty"`
+	StickyAccountID   int64   `json:"sticky_account_id,omitempty"`
+	RoutingAccountIDs []int64 `json:"routing_account_ids,omitempty"`
+
+	Layer             string `json:"layer"`
+	SelectedAccountID int64  `json:"selected_account_id,omitempty"`
+	// WaitPlan 为 true 表示选中账号槽位已满，请求会进入等待
+	WaitPlan bool   `json:"wait_plan"`
+	Reason   string `json:"reason"`
+
+	Candidates []*SchedulerCandidate `json:"candidates"`
+}
+
+// ExplainAccountSelection 重放一次账号选择并解释每个账号的过滤结论（不产生副作用）
+func (s *GatewayService) ExplainAccountSelection(ctx context.Context, input *SchedulerExplainInput) (*SchedulerExplanation, error) {
+	if input == nil {
+		input = &SchedulerExplainInput{}
+	}
+	groupID := input.GroupID
+	model := strings.TrimSpace(input.Model)
+	sessionHash := strings.TrimSpace(input.SessionHash)
+
+	var group *Group
+	if groupID != nil {
+		g, err := s.resolveGroupByID(ctx, *groupID)
+		if err != nil {
+			return nil, err
+		}
+		group = g
+		ctx = s.withGroupContext(ctx, group)
+	}
+
+	platform := strings.TrimSpace(input.Platform)
+	if platform != "" && (group == nil || platform != group.Platform) {
+		ctx = context.WithValue(ctx, ctxkey.ForcePlatform, platform)
+	}
+	platform, hasForcePlatform, err := s.resolvePlatform(ctx, groupID, group)
+	if err != nil {
+		return nil, err
+	}
+	if platform == PlatformOpenAI {
+		return nil, ErrSchedulerExplainPlatformUnsupported
+	}
+	useMixed := (platform == PlatformAnthropic || platform == PlatformGemini) && !hasForcePlatform
+	preferOAuth := platform == PlatformGemini
+	cfg := s.schedulingConfig()
+
+	out := &SchedulerExplanation{
+		GroupID:  groupID,
+		Platform: platform,
+		Model:    model,
+		Layer:    SchedulerLayerNone,
+	}
+	if group != nil {
+		out.GroupName = group.Name
+		if model != "" && group.Platform == PlatformAnthropic {
+			out.RoutingAccountIDs = group.GetRoutingAccountIDs(model)
+		}
+	}
+	if sessionHash != "" && s.cache != nil {
+		if accountID, err := s.cache.GetSessionAccountID(ctx, derefGroupID(groupID), sessionHash); err == nil {
+			out.StickyAccountID = accountID
+		}
+	}
+
+	accounts, err := s.listAccountsForExplain(ctx, groupID, platform, useMixed)
+	if err != nil {
+		return nil, err
+	}
+	inSnapshot := map[int64]struct{}{}
+	if schedulable, _, err := s.listSchedulableAccounts(ctx, groupID, platform, hasForcePlatform); err == nil {
+		for _, acc := range schedulable {
+			inSnapshot[acc.ID] = struct{}{}
+		}
+	}
+	excluded := make(map[int64]struct{}, len(input.ExcludedAccountIDs))
+	for _, id := range input.ExcludedAccountIDs {
+		excluded[id] = struct{}{}
+	}
+
+	loads := make([]AccountWithConcurrency, 0, len(accounts))
+	for i := range accounts {
+		loads = append(loads, AccountWithConcurrency{ID: accounts[i].ID, MaxConcurrency: accounts[i].Concurrency})
+	}
+	loadMap := map[int64]*AccountLoadInfo{}
+	if s.concurrencyService != nil && len(loads) > 0 {
+		if m, err := s.concurrencyService.GetAccountsLoadBatch(ctx, loads); err == nil {
+			loadMap = m
+		}
+	}
+
+	accountByID := make(map[int64]*Account, len(accounts))
+	candidateByID := make(map[int64]*SchedulerCandidate, len(accounts))
+	out.Candidates = make([]*SchedulerCandidate, 0, len(accounts))
+	for i := range accounts {
+		acc := &accounts[i]
+		accountByID[acc.ID] = acc
+		isSticky := out.StickyAccountID > 0 && out.StickyAccountID == acc.ID
+		candidate := &SchedulerCandidate{
+			AccountID:   acc.ID,
+			Name:        acc.Name,
+			Platform:    acc.Platform,
+			Type:        acc.Type,
+			Priority:    acc.Priority,
+			Concurrency: acc.Concurrency,
+			Weight:      acc.GetWeight(),
+			LastUsedAt:  acc.LastUsedAt,
+			Sticky:      isSticky,
+			Routed:      containsInt64(out.RoutingAccountIDs, acc.ID),
+		}
+		if load := loadMap[acc.ID]; load != nil {
+			candidate.CurrentConcurrency = load.CurrentConcurrency
+			candidate.WaitingCount = load.WaitingCount
+			candidate.LoadRate = load.LoadRate
+		}
+		if _, ok := excluded[acc.ID]; ok {
+			candidate.Verdict = SchedulerVerdictExcluded
+		} else {
+			candidate.Verdict, candidate.Detail = s.explainAccountVerdict(ctx, acc, platform, useMixed, model, sessionHash, isSticky)
+		}
+		if candidate.Verdict == SchedulerVerdictEligible {
+			if _, ok := inSnapshot[acc.ID]; !ok {
+				candidate.Verdict = SchedulerVerdictNotInSnapshot
+			}
+		}
+		candidateByID[acc.ID] = candidate
+		out.Candidates = append(out.Candidates, candidate)
+	}
+	sort.SliceStable(out.Candidates, func(i, j int) bool {
+		a, b := out.Candidates[i], out.Candidates[j]
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.AccountID < b.AccountID
+	})
+
+	eligible := func(id int64) bool {
+		c := candidateByID[id]
+		return c != nil && c.Verdict == SchedulerVerdictEligible
+	}
+	withLoad := func(acc *Account) accountWithLoad {
+		c := candidateByID[acc.ID]
+		return accountWithLoad{account: acc, loadInfo: &AccountLoadInfo{
+			AccountID:          acc.ID,
+			CurrentConcurrency: c.CurrentConcurrency,
+			WaitingCount:       c.WaitingCount,
+			LoadRate:           c.LoadRate,
+		}}
+	}
+
+	// Layer 1: 模型路由
+	if len(out.RoutingAccountIDs) > 0 {
+		var routed []accountWithLoad
+		for _, id := range out.RoutingAccountIDs {
+			if acc, ok := accountByID[id]; ok && eligible(id) {
+				routed = append(routed, withLoad(acc))
+			}
+		}
+		if len(routed) > 0 {
+			if sticky := candidateByID[out.StickyAccountID]; sticky != nil && sticky.Routed && eligible(sticky.AccountID) {
+				if sticky.LoadRate < 100 {
+					return out.pick(SchedulerLayerModelRouting, sticky.AccountID, false, "sticky session account within the model routing list"), nil
+				}
+				if sticky.WaitingCount < cfg.StickySessionMaxWaiting {
+					return out.pick(SchedulerLayerModelRouting, sticky.AccountID, true, "sticky routed account is full, request waits for its slot"), nil
+				}
+			}
+			if selected := pickLeastLoaded(routed, preferOAuth); selected != nil {
+				return out.pick(SchedulerLayerModelRouting, selected.account.ID, false,
+					fmt.Sprintf("model routing for %s: lowest priority/load among %d routed accounts", model, len(routed))), nil
+			}
+		}
+	}
+
+	// Layer 1.5: 粘性会话（仅在无模型路由配置时生效）
+	if len(out.RoutingAccountIDs) == 0 && out.StickyAccountID > 0 && eligible(out.StickyAccountID) {
+		sticky := candidateByID[out.StickyAccountID]
+		if s.isAccountInGroup(accountByID[sticky.AccountID], groupID) {
+			if sticky.LoadRate < 100 {
+				return out.pick(SchedulerLayerSticky, sticky.AccountID, false, "session is bound to this account"), nil
+			}
+			if sticky.WaitingCount < cfg.StickySessionMaxWaiting {
+				return out.pick(SchedulerLayerStickyWait, sticky.AccountID, true,
+					fmt.Sprintf("sticky account is full, waiting queue %d < %d", sticky.WaitingCount, cfg.StickySessionMaxWaiting)), nil
+			}
+		}
+	}
+
+	// Layer 2: 负载感知
+	var candidates []*Account
+	var available []accountWithLoad
+	for i := range accounts {
+		acc := &accounts[i]
+		if !eligible(acc.ID) {
+			continue
+		}
+		candidates = append(candidates, acc)
+		if candidateByID[acc.ID].LoadRate < 100 {
+			available = append(available, withLoad(acc))
+		}
+	}
+	if len(candidates) == 0 {
+		out.Reason = "no available accounts"
+		return out, nil
+	}
+	if selected := pickLeastLoaded(available, preferOAuth); selected != nil {
+		if tierHasWeights(filterByMinPriority(available)) {
+			return out.pick(SchedulerLayerLoadBalance, selected.account.ID, false,
+				fmt.Sprintf("priority %d, weighted random pick (weight %d, load %d%%) among %d accounts with free slots",
+					selected.account.Priority, selected.account.EffectiveWeight(), selected.loadInfo.LoadRate, len(available))), nil
+		}
+		return out.pick(SchedulerLayerLoadBalance, selected.account.ID, false,
+			fmt.Sprintf("priority %d, load %d%%, least recently used among %d accounts with free slots",
+				selected.account.Priority, selected.loadInfo.LoadRate, len(available))), nil
+	}
+
+	// Layer 3: 兜底排队
+	s.sortCandidatesForFallback(candidates, preferOAuth, cfg.FallbackSelectionMode)
+	return out.pick(SchedulerLayerFallbackWait, candidates[0].ID, true,
+		fmt.Sprintf("all %d eligible accounts are at capacity, request waits for a slot", len(candidates))), nil
+}
+
+func (e *SchedulerExplanation) pick(layer string, accountID int64, wait bool, reason string) *SchedulerExplanation {
+	e.Layer = layer
+	e.SelectedAccountID = accountID
+	e.WaitPlan = wait
+	e.Reason = reason
+	return e
+}
+
+// pickLeastLoaded 与负载感知层一致：优先级 → 权重（已配置时）或 负载率 → LRU
+func pickLeastLoaded(available []accountWithLoad, preferOAuth bool) *accountWithLoad {
+	if len(available) == 0 {
+		return nil
+	}
+	return selectFromPriorityTier(filterByMinPriority(available), preferOAuth)
+}
+
+// listAccountsForExplain 列出分组（或平台）内的全部账号，包括当前不可调度的账号
+func (s *GatewayService) listAccountsForExplain(ctx context.Context, groupID *int64, platform string, useMixed bool) ([]Account, error) {
+	if s.accountRepo == nil {
+		return nil, errors.New("account repository not available")
+	}
+	if groupID != nil {
+		return s.accountRepo.ListByGroup(ctx, *groupID)
+	}
+	accounts, err := s.accountRepo.ListByPlatform(ctx, platform)
+	if err != nil {
+		return nil, err
+	}
+	if useMixed {
+		mixed, err := s.accountRepo.ListByPlatform(ctx, PlatformAntigravity)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, mixed...)
+	}
+	return accounts, nil
+}
+
+// explainAccountVerdict 按调度层的过滤顺序给出账号结论
+func (s *GatewayService) explainAccountVerdict(ctx context.Context, acc *Account, platform string, useMixed bool, model, sessionHash string, isSticky bool) (string, string) {
+	now := time.Now()
+	switch {
+	case !acc.IsActive() || !acc.Schedulable:
+		return SchedulerVerdictInactive, fmt.Sprintf("status=%s schedulable=%v", acc.Status, acc.Schedulable)
+	case acc.AutoPauseOnExpired && acc.ExpiresAt != nil && !now.Before(*acc.ExpiresAt):
+		return SchedulerVerdictExpired, "expired at " + acc.ExpiresAt.UTC().Format(time.RFC3339)
+	case acc.OverloadUntil != nil && now.Before(*acc.OverloadUntil):
+		return SchedulerVerdictOverloaded, "until " + acc.OverloadUntil.UTC().Format(time.RFC3339)
+	case acc.RateLimitResetAt != nil && now.Before(*acc.RateLimitResetAt):
+		return SchedulerVerdictRateLimited, "until " + acc.RateLimitResetAt.UTC().Format(time.RFC3339)
+	case acc.TempUnschedulableUntil != nil && now.Before(*acc.TempUnschedulableUntil):
+		detail := "until " + acc.TempUnschedulableUntil.UTC().Format(time.RFC3339)
+		if acc.TempUnschedulableReason != "" {
+			detail += ": " + acc.TempUnschedulableReason
+		}
+		return SchedulerVerdictTempUnschedulable, detail
+	}
+	if state := acc.ScheduleState(now); !state.Allowed {
+		if state.InMaintenance {
+			return SchedulerVerdictMaintenance, state.Reason
+		}
+		return SchedulerVerdictOutsideSchedule, state.Reason
+	}
+	if progress := acc.DrainProgress(now); progress != nil && !isSticky {
+		return SchedulerVerdictDraining, "draining since " + progress.StartedAt.UTC().Format(time.RFC3339) + ", only existing sticky sessions are served"
+	}
+	if !s.isAccountAllowedForPlatform(acc, platform, useMixed) {
+		return SchedulerVerdictPlatformMismatch, "account platform " + acc.Platform + ", request platform " + platform
+	}
+	if model != "" && !s.isModelSupportedByAccountWithContext(ctx, acc, model) {
+		return SchedulerVerdictModelUnsupported, "model " + model + " is not in the account model mapping"
+	}
+	if !acc.IsSchedulableForModelWithContext(ctx, model) {
+		remaining := acc.GetRateLimitRemainingTimeWithContext(ctx, model)
+		return SchedulerVerdictModelRateLimited, "remaining " + remaining.Round(time.Second).String()
+	}
+	if !s.isAccountSchedulableForWindowCost(ctx, acc, isSticky) {
+		return SchedulerVerdictWindowCost, fmt.Sprintf("window cost limit $%.2f reached", acc.GetWindowCostLimit())
+	}
+	if ok, detail := s.explainSessionLimit(ctx, acc, sessionHash); !ok {
+		return SchedulerVerdictMaxSessions, detail
+	}
+	return SchedulerVerdictEligible, ""
+}
+
+// explainSessionLimit 只读版本的 checkAndRegisterSession
+func (s *GatewayService) explainSessionLimit(ctx context.Context, acc *Account, sessionHash string) (bool, string) {
+	if !acc.IsAnthropicOAuthOrSetupToken() || s.sessionLimitCache == nil {
+		return true, ""
+	}
+	maxSessions := acc.GetMaxSessions()
+	if maxSessions <= 0 || sessionHash == "" {
+		return true, ""
+	}
+	if active, err := s.sessionLimitCache.IsSessionActive(ctx, acc.ID, sessionHash); err != nil || active {
+		return true, ""
+	}
+	count, err := s.sessionLimitCache.GetActiveSessionCount(ctx, acc.ID)
+	if err != nil || count < maxSessions {
+		return true, ""
+	}
+	return false, fmt.Sprintf("%d/%d active sessions", count, maxSessions)
+}
@@ -0,0 +1,153 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// explainAccountRepo 在单平台 mock 基础上返回分组内的全部账号（含不可调度账号）
+type explainAccountRepo struct {
+	mockAccountRepoForPlatform
+}
+
+func (m *explainAccountRepo) ListByGroup(ctx context.Context, groupID int64) ([]Account, error) {
+	return m.accounts, nil
+}
+
+func (m *explainAccountRepo) ListByPlatform(ctx context.Context, platform string) ([]Account, error) {
+	var result []Account
+	for _, acc := range m.accounts {
+		if acc.Platform == platform {
+			result = append(result, acc)
+		}
+	}
+	return result, nil
+}
+
+func newExplainTestService(accounts []Account, group *Group, cache *mockGatewayCacheForPlatform, concurrency *mockConcurrencyCache) *GatewayService {
+	repo := &explainAccountRepo{mockAccountRepoForPlatform{accounts: accounts, accountsByID: map[int64]*Account{}}}
+	for i := range repo.accounts {
+		repo.accountsByID[repo.accounts[i].ID] = &repo.accounts[i]
+	}
+	groupRepo := &mockGroupRepoForGateway{groups: map[int64]*Group{}}
+	if group != nil {
+		groupRepo.groups[group.ID] = group
+	}
+	return &GatewayService{
+		accountRepo:        repo,
+		groupRepo:          groupRepo,
+		cache:              cache,
+		cfg:                testConfig(),
+		concurrencyService: NewConcurrencyService(concurrency),
+	}
+}
+
+func explainCandidate(t *testing.T, out *SchedulerExplanation, accountID int64) *SchedulerCandidate {
+	t.Helper()
+	for _, c := range out.Candidates {
+		if c.AccountID == accountID {
+			return c
+		}
+	}
+	t.Fatalf("candidate %d not found", accountID)
+	return nil
+}
+
+func TestExplainAccountSelection_Verdicts(t *testing.T) {
+	groupID := int64(1)
+	future := time.Now().Add(time.Hour)
+	accounts := []Account{
+		{ID: 1, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true, Concurrency: 5},
+		{ID: 2, Platform: PlatformAnthropic, Priority: 0, Status: StatusActive, Schedulable: true, Concurrency: 5, RateLimitResetAt: &future},
+		{ID: 3, Platform: PlatformAnthropic, Priority: 0, Status: StatusActive, Schedulable: true, Concurrency: 5, TempUnschedulableUntil: &future, TempUnschedulableReason: "overloaded 529"},
+		{ID: 4, Platform: PlatformAnthropic, Priority: 0, Status: StatusDisabled, Schedulable: true, Concurrency: 5},
+		{ID: 5, Platform: PlatformOpenAI, Priority: 0, Status: StatusActive, Schedulable: true, Concurrency: 5},
+		{ID: 6, Platform: PlatformAnthropic, Priority: 0, Status: StatusActive, Schedulable: true, Concurrency: 5},
+		{ID: 7, Platform: PlatformAnthropic, Priority: 0, Status: StatusActive, Schedulable: true, Concurrency: 5,
+			Credentials: map[string]any{"model_mapping": map[string]any{"claude-other": "claude-other"}}},
+		{ID: 8, Platform: PlatformAnthropic, Priority: 0, Status: StatusActive, Schedulable: true, Concurrency: 5,
+			Extra: map[string]any{"schedule": map[string]any{"maintenance": []any{map[string]any{
+				"start": time.Now().Add(-time.Hour).Format(time.RFC3339), "end": future.Format(time.RFC3339), "reason": "upgrade",
+			}}}}},
+		drainingAccount(9, 0, time.Now()),
+	}
+	group := &Group{ID: groupID, Name: "g", Platform: PlatformAnthropic, Status: StatusActive, Hydrated: true}
+	svc := newExplainTestService(accounts, group, &mockGatewayCacheForPlatform{}, &mockConcurrencyCache{})
+
+	out, err := svc.ExplainAccountSelection(context.Background(), &SchedulerExplainInput{
+		GroupID:            &groupID,
+		Model:              "claude-3-5-sonnet-20241022",
+		ExcludedAccountIDs: []int64{6},
+	})
+	require.NoError(t, err)
+	require.Len(t, out.Candidates, len(accounts))
+
+	require.Equal(t, SchedulerVerdictEligible, explainCandidate(t, out, 1).Verdict)
+	require.Equal(t, SchedulerVerdictRateLimited, explainCandidate(t, out, 2).Verdict)
+	require.Equal(t, SchedulerVerdictTempUnschedulable, explainCandidate(t, out, 3).Verdict)
+	require.Contains(t, explainCandidate(t, out, 3).Detail, "overloaded 529")
+	require.Equal(t, SchedulerVerdictInactive, explainCandidate(t, out, 4).Verdict)
+	require.Equal(t, SchedulerVerdictPlatformMismatch, explainCandidate(t, out, 5).Verdict)
+	require.Equal(t, SchedulerVerdictExcluded, explainCandidate(t, out, 6).Verdict)
+	require.Equal(t, SchedulerVerdictModelUnsupported, explainCandidate(t, out, 7).Verdict)
+	require.Equal(t, SchedulerVerdictMaintenance, explainCandidate(t, out, 8).Verdict)
+	require.Contains(t, explainCandidate(t, out, 8).Detail, "upgrade")
+	require.Equal(t, SchedulerVerdictDraining, explainCandidate(t, out, 9).Verdict)
+
+	require.Equal(t, SchedulerLayerLoadBalance, out.Layer)
+	require.Equal(t, int64(1), out.SelectedAccountID)
+	require.False(t, out.WaitPlan)
+}
+
+func TestExplainAccountSelection_StickyAndFallback(t *testing.T) {
+	accounts := []Account{
+		{ID: 1, Platform: PlatformAnthropic, Priority: 1, Status: StatusActive, Schedulable: true, Concurrency: 1},
+		{ID: 2, Platform: PlatformAnthropic, Priority: 2, Status: StatusActive, Schedulable: true, Concurrency: 1},
+	}
+	cache := &mockGatewayCacheForPlatform{sessionBindings: map[string]int64{"sess": 2}}
+	concurrency := &mockConcurrencyCache{loadMap: map[int64]*AccountLoadInfo{
+		1: {AccountID: 1, CurrentConcurrency: 1, LoadRate: 100},
+		2: {AccountID: 2, CurrentConcurrency: 0, LoadRate: 0},
+	}}
+	svc := newExplainTestService(accounts, nil, cache, concurrency)
+
+	out, err := svc.ExplainAccountSelection(context.Background(), &SchedulerExplainInput{SessionHash: "sess"})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), out.StickyAccountID)
+	require.True(t, explainCandidate(t, out, 2).Sticky)
+	require.Equal(t, SchedulerLayerSticky, out.Layer)
+	require.Equal(t, int64(2), out.SelectedAccountID)
+	require.Equal(t, 100, explainCandidate(t, out, 1).LoadRate)
+
+	// 全部满载且无粘性会话：兜底排队
+	concurrency.loadMap[2] = &AccountLoadInfo{AccountID: 2, CurrentConcurrency: 1, LoadRate: 100}
+	out, err = svc.ExplainAccountSelection(context.Background(), &SchedulerExplainInput{})
+	require.NoError(t, err)
+	require.Equal(t, SchedulerLayerFallbackWait, out.Layer)
+	require.Equal(t, int64(1), out.SelectedAccountID)
+	require.True(t, out.WaitPlan)
+
+	// 无可用账号
+	out, err = svc.ExplainAccountSelection(context.Background(), &SchedulerExplainInput{ExcludedAccountIDs: []int64{1, 2}})
+	require.NoError(t, err)
+	require.Equal(t, SchedulerLayerNone, out.Layer)
+	require.Equal(t, "no available accounts", out.Reason)
+	require.Zero(t, concurrency.acquireAccountCalls, "dry-run must not acquire slots")
+}
+
+func TestExplainAccountSelection_RejectsOpenAI(t *testing.T) {
+	groupID := int64(1)
+	group := &Group{ID: groupID, Name: "openai", Platform: PlatformOpenAI, Status: StatusActive, Hydrated: true}
+	svc := newExplainTestService(nil, group, &mockGatewayCacheForPlatform{}, &mockConcurrencyCache{})
+
+	_, err := svc.ExplainAccountSelection(context.Background(), &SchedulerExplainInput{GroupID: &groupID})
+	require.ErrorIs(t, err, ErrSchedulerExplainPlatformUnsupported)
+
+	_, err = svc.ExplainAccountSelection(context.Background(), &SchedulerExplainInput{Platform: PlatformOpenAI})
+	require.ErrorIs(t, err, ErrSchedulerExplainPlatformUnsupported)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
 )
 
 var (
@@ -19,6 +20,9 @@ var (
 
 const outboxEventTimeout = 2 * time.Minute
 
+// accountScheduleRefreshInterval 时间段调度边界检查的最长间隔（用于感知调度配置的变更）
+const accountScheduleRefreshInterval = time.Minute
+
 type SchedulerSnapshotService struct {
 	cache         SchedulerCache
 	outboxRepo    SchedulerOutboxRepository
@@ -83,6 +87,14 @@ func (s *SchedulerSnapshotService) Start() {
 			s.runFullRebuildWorker(fullInterval)
 		}()
 	}
+
+	if s.accountRepo != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.runScheduleBoundaryWorker()
+		}()
+	}
 }
 
 func (s *SchedulerSnapshotService) Stop() {
@@ -212,6 +224,74 @@ func (s *SchedulerSnapshotService) runFullRebuildWorker(interval time.Duration)
 	}
 }
 
+// runScheduleBoundaryWorker 在账号时间段调度 / 维护窗口的边界处重建相关快照，
+// 使进入调度时间段的账号及时回到快照中（离开时间段的账号由 IsSchedulable 实时过滤）。
+func (s *SchedulerSnapshotService) runScheduleBoundaryWorker() {
+	states := make(map[int64]bool)
+	for {
+		wait := accountScheduleRefreshInterval
+		if next := s.syncAccountSchedules(states, time.Now()); !next.IsZero() {
+			if d := time.Until(next); d < wait {
+				wait = d
+			}
+		}
+		if wait < time.Second {
+			wait = time.Second
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-s.stopCh:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// syncAccountSchedules 检查配置了时间段调度的账号，判定结果翻转时重建其所在分组的快照。
+// states 记录上一次的判定结果；返回最近的边界时间（零值表示没有）。
+func (s *SchedulerSnapshotService) syncAccountSchedules(states map[int64]bool, now time.Time) time.Time {
+	if s.accountRepo == nil || s.cache == nil {
+		return time.Time{}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	accounts, err := s.accountRepo.ListActiveWithSchedule(ctx)
+	if err != nil {
+		log.Printf("[Scheduler] list accounts for schedule check failed: %v", err)
+		return time.Time{}
+	}
+
+	var next time.Time
+	seen := make(map[int64]struct{}, len(states))
+	for i := range accounts {
+		acc := &accounts[i]
+		if acc.GetSchedule() == nil {
+			continue
+		}
+		seen[acc.ID] = struct{}{}
+		state := acc.ScheduleState(now)
+		// 首次观察到的账号无需重建：启动重建或配置变更的 outbox 事件已按当前状态构建快照
+		if prev, ok := states[acc.ID]; ok && prev != state.Allowed {
+			if err := s.rebuildByAccount(ctx, acc, acc.GroupIDs, "schedule"); err != nil {
+				log.Printf("[Scheduler] schedule rebuild failed: account=%d err=%v", acc.ID, err)
+			}
+		}
+		states[acc.ID] = state.Allowed
+		if !state.NextTransition.IsZero() && (next.IsZero() || state.NextTransition.Before(next)) {
+			next = state.NextTransition
+		}
+	}
+	for id := range states {
+		if _, ok := seen[id]; !ok {
+			delete(states, id)
+		}
+	}
+	return next
+}
+
 func (s *SchedulerSnapshotService) pollOutbox() {
 	if s.outboxRepo == nil || s.cache == nil {
 		return
@@ -231,6 +311,8 @@ func (s *SchedulerSnapshotService) pollOutbox() {
 		return
 	}
 	if len(events) == 0 {
+		metrics.SetSchedulerOutboxLag(0)
+		metrics.SetSchedulerOutboxBacklog(0)
 		return
 	}
 
@@ -386,7 +468,7 @@ func (s *SchedulerSnapshotService) rebuildByGroupIDs(ctx context.Context, groupI
 	if len(groupIDs) == 0 {
 		return nil
 	}
-	platforms := []string{PlatformAnthropic, PlatformGemini, PlatformOpenAI, PlatformAntigravity}
+	platforms := []string{PlatformAnthropic, PlatformGemini, PlatformOpenAI, PlatformAntigravity, PlatformCompatible}
 	var firstErr error
 	for _, platform := range platforms {
 		if err := s.rebuildBucketsForPlatform(ctx, platform, groupIDs, reason); err != nil && firstErr == nil {
@@ -459,6 +541,7 @@ func (s *SchedulerSnapshotService) triggerFullRebuild(reason string) error {
 	if s.cache == nil {
 		return ErrSchedulerCacheNotReady
 	}
+	metrics.IncSchedulerRebuild(reason)
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
@@ -483,6 +566,7 @@ func (s *SchedulerSnapshotService) checkOutboxLag(ctx context.Context, oldest Sc
 	}
 
 	lag := time.Since(oldest.CreatedAt)
+	metrics.SetSchedulerOutboxLag(lag)
 	if lagSeconds := int(lag.Seconds()); lagSeconds >= s.cfg.Gateway.Scheduling.OutboxLagWarnSeconds && s.cfg.Gateway.Scheduling.OutboxLagWarnSeconds > 0 {
 		log.Printf("[Scheduler] outbox lag warning: %ds", lagSeconds)
 	}
@@ -508,14 +592,19 @@ func (s *SchedulerSnapshotService) checkOutboxLag(ctx context.Context, oldest Sc
 		s.lagMu.Unlock()
 	}
 
-	threshold := s.cfg.Gateway.Scheduling.OutboxBacklogRebuildRows
-	if threshold <= 0 || s.outboxRepo == nil {
+	if s.outboxRepo == nil {
 		return
 	}
 	maxID, err := s.outboxRepo.MaxID(ctx)
 	if err != nil {
 		return
 	}
+	metrics.SetSchedulerOutboxBacklog(maxID - watermark)
+
+	threshold := s.cfg.Gateway.Scheduling.OutboxBacklogRebuildRows
+	if threshold <= 0 {
+		return
+	}
 	if maxID-watermark >= int64(threshold) {
 		log.Printf("[Scheduler] outbox backlog rebuild triggered: backlog=%d", maxID-watermark)
 		if err := s.triggerFullRebuild("outbox_backlog"); err != nil {
@@ -552,13 +641,32 @@ func (s *SchedulerSnapshotService) loadAccountsFromDB(ctx context.Context, bucke
 			}
 			filtered = append(filtered, acc)
 		}
-		return filtered, nil
+		return filterAccountsWithinSchedule(filtered, time.Now()), nil
 	}
 
+	var accounts []Account
+	var err error
 	if groupID > 0 {
-		return s.accountRepo.ListSchedulableByGroupIDAndPlatform(ctx, groupID, bucket.Platform)
+		accounts, err = s.accountRepo.ListSchedulableByGroupIDAndPlatform(ctx, groupID, bucket.Platform)
+	} else {
+		accounts, err = s.accountRepo.ListSchedulableByPlatform(ctx, bucket.Platform)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return filterAccountsWithinSchedule(accounts, time.Now()), nil
+}
+
+// filterAccountsWithinSchedule 过滤掉当前不在调度时间段内或处于维护窗口的账号（extra.schedule 无法在 SQL 中过滤）
+func filterAccountsWithinSchedule(accounts []Account, now time.Time) []Account {
+	filtered := accounts[:0]
+	for _, acc := range accounts {
+		if !acc.IsWithinSchedule(now) {
+			continue
+		}
+		filtered = append(filtered, acc)
 	}
-	return s.accountRepo.ListSchedulableByPlatform(ctx, bucket.Platform)
+	return filtered
 }
 
 func (s *SchedulerSnapshotService) bucketFor(groupID *int64, platform string, mode string) SchedulerBucket {
@@ -669,7 +777,7 @@ func (s *SchedulerSnapshotService) fullRebuildInterval() time.Duration {
 
 func (s *SchedulerSnapshotService) defaultBuckets(ctx context.Context) ([]SchedulerBucket, error) {
 	buckets := make([]SchedulerBucket, 0)
-	platforms := []string{PlatformAnthropic, PlatformGemini, PlatformOpenAI, PlatformAntigravity}
+	platforms := []string{PlatformAnthropic, PlatformGemini, PlatformOpenAI, PlatformAntigravity, PlatformCompatible}
 	for _, platform := range platforms {
 		buckets = append(buckets, SchedulerBucket{GroupID: 0, Platform: platform, Mode: SchedulerModeSingle})
 		buckets = append(buckets, SchedulerBucket{GroupID: 0, Platform: platform, Mode: SchedulerModeForced})
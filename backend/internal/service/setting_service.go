@@ -185,6 +185,13 @@ func (s *SettingService) GetPublicSettingsForInjection(ctx context.Context) (any
 
 // UpdateSettings 更新系统设置
 func (s *SettingService) UpdateSettings(ctx context.Context, settings *SystemSettings) error {
+	var auditBefore map[string]any
+	if adminAuditRecorderFromContext(ctx) != nil {
+		if current, err := s.GetAllSettings(ctx); err == nil {
+			auditBefore = adminAuditSnapshot(ctx, current)
+		}
+	}
+
 	updates := make(map[string]string)
 
 	// 注册设置
@@ -260,6 +267,11 @@ func (s *SettingService) UpdateSettings(ctx context.Context, settings *SystemSet
 	if err == nil && s.onUpdate != nil {
 		s.onUpdate() // Invalidate cache after settings update
 	}
+	if err == nil && auditBefore != nil {
+		if updated, getErr := s.GetAllSettings(ctx); getErr == nil {
+			recordAdminAuditChange(ctx, "settings", 0, auditBefore, updated)
+		}
+	}
 	return err
 }
 
@@ -24,6 +24,12 @@ func (c *CompositeTokenCacheInvalidator) InvalidateToken(ctx context.Context, ac
 	if c == nil || c.cache == nil || account == nil {
 		return nil
 	}
+	if account.Type == AccountTypeVertex {
+		if err := c.cache.DeleteAccessToken(ctx, VertexTokenCacheKey(account)); err != nil {
+			slog.Warn("token_cache_delete_failed", "key", VertexTokenCacheKey(account), "account_id", account.ID, "error", err)
+		}
+		return nil
+	}
 	if account.Type != AccountTypeOAuth {
 		return nil
 	}
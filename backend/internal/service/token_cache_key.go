@@ -8,6 +8,12 @@ func OpenAITokenCacheKey(account *Account) string {
 	return "openai:account:" + strconv.FormatInt(account.ID, 10)
 }
 
+// VertexTokenCacheKey 生成 Vertex 服务账号的缓存键（Anthropic / Gemini 平台共用）
+// 格式: "vertex:account:{account_id}"
+func VertexTokenCacheKey(account *Account) string {
+	return "vertex:account:" + strconv.FormatInt(account.ID, 10)
+}
+
 // ClaudeTokenCacheKey 生成 Claude (Anthropic) OAuth 账号的缓存键
 // 格式: "claude:account:{account_id}"
 func ClaudeTokenCacheKey(account *Account) string {
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/metrics"
 )
 
 // TokenRefreshService OAuth token自动刷新服务
@@ -19,6 +20,7 @@ type TokenRefreshService struct {
 	cfg              *config.TokenRefreshConfig
 	cacheInvalidator TokenCacheInvalidator
 	schedulerCache   SchedulerCache // 用于同步更新调度器缓存，解决 token 刷新后缓存不一致问题
+	webhookService   *WebhookService
 
 	stopCh chan struct{}
 	wg     sync.WaitGroup
@@ -54,6 +56,11 @@ func NewTokenRefreshService(
 	return s
 }
 
+// SetWebhookService 设置 Webhook 服务（可选依赖），刷新最终失败时发布事件
+func (s *TokenRefreshService) SetWebhookService(webhookService *WebhookService) {
+	s.webhookService = webhookService
+}
+
 // Start 启动后台刷新服务
 func (s *TokenRefreshService) Start() {
 	if !s.cfg.Enabled {
@@ -141,9 +148,12 @@ func (s *TokenRefreshService) processRefresh() {
 			// 执行刷新
 			if err := s.refreshWithRetry(ctx, account, refresher); err != nil {
 				log.Printf("[TokenRefresh] Account %d (%s) failed: %v", account.ID, account.Name, err)
+				metrics.IncTokenRefresh(account.Platform, metrics.TokenRefreshFailure)
+				s.webhookService.EmitAccountEvent(WebhookEventAccountTokenRefreshFailed, account, truncateString(err.Error(), 512), nil)
 				failed++
 			} else {
 				log.Printf("[TokenRefresh] Account %d (%s) refreshed successfully", account.ID, account.Name)
+				metrics.IncTokenRefresh(account.Platform, metrics.TokenRefreshSuccess)
 				refreshed++
 			}
 
@@ -228,6 +238,7 @@ func (s *TokenRefreshService) refreshWithRetry(ctx context.Context, account *Acc
 
 		// 如果还有重试机会，等待后重试
 		if attempt < s.cfg.MaxRetries {
+			metrics.IncTokenRefresh(account.Platform, metrics.TokenRefreshRetry)
 			// 指数退避：2^(attempt-1) * baseSeconds
 			backoff := time.Duration(s.cfg.RetryBackoffSeconds) * time.Second * time.Duration(1<<(attempt-1))
 			time.Sleep(backoff)
@@ -0,0 +1,50 @@
+//go:build unit
+
+package service
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gin-gonic/gin"
+)
+
+// passthroughUpstream 直接用标准 http.Client 发送请求（指向本地桩服务），供需要真实 HTTP 往返的上游转发测试共用
+type passthroughUpstream struct{}
+
+func (passthroughUpstream) Do(req *http.Request, _ string, _ int64, _ int) (*http.Response, error) {
+	return http.DefaultClient.Do(req)
+}
+
+func (u passthroughUpstream) DoWithTLS(req *http.Request, proxyURL string, accountID int64, accountConcurrency int, _ bool) (*http.Response, error) {
+	return u.Do(req, proxyURL, accountID, accountConcurrency)
+}
+
+// newPassthroughGatewayService 构造经 passthroughUpstream 转发、允许 http 桩服务地址的 GatewayService
+func newPassthroughGatewayService() *GatewayService {
+	cfg := testConfig()
+	cfg.Security.URLAllowlist.AllowInsecureHTTP = true
+	return &GatewayService{cfg: cfg, httpUpstream: passthroughUpstream{}}
+}
+
+// newUpstreamTestAccount 构造可调度的测试账号，平台相关字段由调用方通过 credentials 指定
+func newUpstreamTestAccount(id int64, name, platform, accountType string, credentials map[string]any) *Account {
+	return &Account{
+		ID:          id,
+		Name:        name,
+		Platform:    platform,
+		Type:        accountType,
+		Status:      StatusActive,
+		Schedulable: true,
+		Credentials: credentials,
+	}
+}
+
+// newUpstreamTestContext 构造携带请求体的 POST 请求上下文
+func newUpstreamTestContext(path string, body []byte) (*gin.Context, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	c.Request = httptest.NewRequest(http.MethodPost, path, bytes.NewReader(body))
+	return c, rec
+}
@@ -39,12 +39,14 @@ type UsageLog struct {
 	// AccountRateMultiplier 账号计费倍率快照（nil 表示历史数据，按 1.0 处理）
 	AccountRateMultiplier *float64
 
-	BillingType  int8
-	Stream       bool
-	DurationMs   *int
-	FirstTokenMs *int
-	UserAgent    *string
-	IPAddress    *string
+	BillingType int8
+	// ResponseCacheHit 响应缓存命中（按缓存折扣计费，未消耗上游账号）；与 BillingType 相互独立
+	ResponseCacheHit bool
+	Stream           bool
+	DurationMs       *int
+	FirstTokenMs     *int
+	UserAgent        *string
+	IPAddress        *string
 
 	// 图片生成字段
 	ImageCount int
@@ -122,7 +122,7 @@ func (s *UsageService) Create(ctx context.Context, req CreateUsageLogRequest) (*
 	// 扣除用户余额
 	balanceUpdated := false
 	if inserted && req.ActualCost > 0 {
-		if err := s.userRepo.UpdateBalance(txCtx, req.UserID, -req.ActualCost); err != nil {
+		if err := s.userRepo.ApplyBalanceChange(txCtx, usageBalanceLedgerEntry(usageLog, req.ActualCost)); err != nil {
 			return nil, fmt.Errorf("update user balance: %w", err)
 		}
 		balanceUpdated = true
@@ -333,6 +333,14 @@ func (s *UsageService) ListWithFilters(ctx context.Context, params pagination.Pa
 	return logs, result, nil
 }
 
+// ExportWithFilters streams usage logs matching filters to fn in id order.
+func (s *UsageService) ExportWithFilters(ctx context.Context, filters usagestats.UsageLogFilters, fn func(*UsageLog) error) error {
+	if err := s.usageRepo.StreamWithFilters(ctx, filters, fn); err != nil {
+		return fmt.Errorf("export usage logs with filters: %w", err)
+	}
+	return nil
+}
+
 // GetGlobalStats returns global usage stats for a time range.
 func (s *UsageService) GetGlobalStats(ctx context.Context, startTime, endTime time.Time) (*usagestats.UsageStats, error) {
 	stats, err := s.usageRepo.GetGlobalStats(ctx, startTime, endTime)
@@ -33,8 +33,13 @@ type UserRepository interface {
 	List(ctx context.Context, params pagination.PaginationParams) ([]User, *pagination.PaginationResult, error)
 	ListWithFilters(ctx context.Context, params pagination.PaginationParams, filters UserListFilters) ([]User, *pagination.PaginationResult, error)
 
+	// Update 不修改余额；余额只能经由下列方法变更，每次变更都会原子地追加一条余额流水
 	UpdateBalance(ctx context.Context, id int64, amount float64) error
 	DeductBalance(ctx context.Context, id int64, amount float64) error
+	// ApplyBalanceChange 将 entry.Amount 计入余额并追加流水，成功后回填 ID、BalanceBefore、BalanceAfter、CreatedAt
+	ApplyBalanceChange(ctx context.Context, entry *BalanceLedgerEntry) error
+	// SetBalance 将余额设为 balance 并追加流水，entry.Amount 按变更前余额回填
+	SetBalance(ctx context.Context, entry *BalanceLedgerEntry, balance float64) error
 	UpdateConcurrency(ctx context.Context, id int64, amount int) error
 	ExistsByEmail(ctx context.Context, email string) (bool, error)
 	RemoveGroupFromAllowedGroups(ctx context.Context, groupID int64) (int64, error)
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Wei-Shaw/sub2api/internal/pkg/claude"
+)
+
+// Vertex 账号凭证字段：
+//   - service_account_json：GCP 服务账号密钥 JSON（必填，需具备 Vertex AI User 权限；以字符串存储时随凭证加密）
+//   - vertex_project_id：可选，默认取服务账号 JSON 中的 project_id
+//   - vertex_location：可选，区域（如 us-east5 / europe-west1），默认 global
+//   - base_url：可选，覆盖默认的 Vertex AI 端点（Private Service Connect / 本地桩服务）
+//   - model_mapping：可选，与 APIKey 账号一致的账号级模型映射
+const (
+	vertexDefaultLocation    = "global"
+	vertexAnthropicVersion   = "vertex-2023-10-16"
+	vertexPublisherAnthropic = "anthropic"
+	vertexPublisherGoogle    = "google"
+)
+
+// vertexServiceAccount 服务账号 JSON 中用到的字段
+type vertexServiceAccount struct {
+	Type         string `json:"type"`
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// parseVertexServiceAccount 解析账号中的服务账号 JSON（支持字符串或已解析的对象两种存储形式）
+func parseVertexServiceAccount(account *Account) (*vertexServiceAccount, error) {
+	var raw []byte
+	switch v := account.Credentials["service_account_json"].(type) {
+	case string:
+		raw = []byte(strings.TrimSpace(v))
+	case map[string]any:
+		raw, _ = json.Marshal(v)
+	}
+	if len(raw) == 0 {
+		return nil, errors.New("service_account_json not found in credentials")
+	}
+	var sa vertexServiceAccount
+	if err := json.Unmarshal(raw, &sa); err != nil {
+		return nil, fmt.Errorf("invalid service_account_json: %w", err)
+	}
+	if sa.ClientEmail == "" || sa.PrivateKey == "" {
+		return nil, errors.New("service_account_json missing client_email or private_key")
+	}
+	if sa.TokenURI == "" {
+		sa.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+	return &sa, nil
+}
+
+// vertexProjectAndLocation 返回 Vertex 请求使用的项目与区域
+func vertexProjectAndLocation(account *Account) (project, location string, err error) {
+	project = strings.TrimSpace(account.GetCredential("vertex_project_id"))
+	if project == "" {
+		sa, err := parseVertexServiceAccount(account)
+		if err != nil {
+			return "", "", err
+		}
+		project = strings.TrimSpace(sa.ProjectID)
+	}
+	if project == "" {
+		return "", "", errors.New("vertex project id not configured")
+	}
+	location = strings.TrimSpace(account.GetCredential("vertex_location"))
+	if location == "" {
+		location = vertexDefaultLocation
+	}
+	return project, location, nil
+}
+
+// buildVertexModelURL 构建 Vertex 模型调用 URL：
+// {base}/v1/projects/{project}/locations/{location}/publishers/{publisher}/models/{model}:{method}
+// validateBaseURL 用于校验账号自定义的 base_url（与各服务的上游 URL 白名单保持一致）。
+func buildVertexModelURL(account *Account, validateBaseURL func(string) (string, error), publisher, model, method string) (string, error) {
+	project, location, err := vertexProjectAndLocation(account)
+	if err != nil {
+		return "", err
+	}
+
+	baseURL := "https://" + location + "-aiplatform.googleapis.com"
+	if location == vertexDefaultLocation {
+		baseURL = "https://aiplatform.googleapis.com"
+	}
+	if custom := strings.TrimSpace(account.GetCredential("base_url")); custom != "" {
+		validatedURL, err := validateBaseURL(custom)
+		if err != nil {
+			return "", err
+		}
+		baseURL = validatedURL
+	}
+
+	return fmt.Sprintf("%s/v1/projects/%s/locations/%s/publishers/%s/models/%s:%s",
+		strings.TrimRight(baseURL, "/"),
+		url.PathEscape(project), url.PathEscape(location), publisher, url.PathEscape(model), method), nil
+}
+
+// vertexAnthropicModelID 将 Anthropic 模型 ID 转换为 Vertex 格式：先按 Anthropic 标准映射补全短 ID，
+// 再将日期版本号以 '@' 分隔，如 claude-sonnet-4-5 → claude-sonnet-4-5@20250929；
+// 无日期后缀（如 -latest 别名）或已是 Vertex 格式时原样返回。
+func vertexAnthropicModelID(model string) string {
+	if strings.Contains(model, "@") {
+		return model
+	}
+	model = claude.NormalizeModelID(model)
+	base, date, ok := splitClaudeModelDate(model)
+	if !ok {
+		return model
+	}
+	return base + "@" + date
+}
+
+// splitClaudeModelDate 拆分 Anthropic 模型 ID 末尾的 8 位日期版本号（如 claude-sonnet-4-5-20250929）
+func splitClaudeModelDate(model string) (base, date string, ok bool) {
+	idx := strings.LastIndex(model, "-")
+	if idx <= 0 || len(model)-idx-1 != 8 {
+		return model, "", false
+	}
+	for _, ch := range model[idx+1:] {
+		if ch < '0' || ch > '9' {
+			return model, "", false
+		}
+	}
+	return model[:idx], model[idx+1:], true
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	vertexTokenScope     = "https://www.googleapis.com/auth/cloud-platform"
+	vertexTokenCacheSkew = 5 * time.Minute
+	vertexLockWaitTime   = 200 * time.Millisecond
+	vertexAssertionTTL   = time.Hour
+)
+
+// VertexTokenCache Token 缓存接口（复用 GeminiTokenCache 接口定义）
+type VertexTokenCache = GeminiTokenCache
+
+// VertexTokenProvider 使用服务账号 JSON 为 Vertex 账号签发并缓存 access_token。
+// 服务账号 token 无需持久化：每次缓存失效时用 JWT Bearer 授权（RFC 7523）重新换取。
+type VertexTokenProvider struct {
+	tokenCache   VertexTokenCache
+	httpUpstream HTTPUpstream
+	now          func() time.Time
+}
+
+func NewVertexTokenProvider(tokenCache VertexTokenCache, httpUpstream HTTPUpstream) *VertexTokenProvider {
+	return &VertexTokenProvider{
+		tokenCache:   tokenCache,
+		httpUpstream: httpUpstream,
+		now:          time.Now,
+	}
+}
+
+// GetAccessToken 获取有效的 access_token
+func (p *VertexTokenProvider) GetAccessToken(ctx context.Context, account *Account) (string, error) {
+	if account == nil {
+		return "", errors.New("account is nil")
+	}
+	if account.Type != AccountTypeVertex {
+		return "", errors.New("not a vertex account")
+	}
+
+	cacheKey := VertexTokenCacheKey(account)
+
+	// 1. 先尝试缓存
+	if p.tokenCache != nil {
+		if token, err := p.tokenCache.GetAccessToken(ctx, cacheKey); err == nil && strings.TrimSpace(token) != "" {
+			return token, nil
+		} else if err != nil {
+			slog.Warn("vertex_token_cache_get_failed", "account_id", account.ID, "error", err)
+		}
+
+		// 2. 加锁签发，避免并发请求同时换取 token
+		locked, lockErr := p.tokenCache.AcquireRefreshLock(ctx, cacheKey, 30*time.Second)
+		if lockErr == nil && locked {
+			defer func() { _ = p.tokenCache.ReleaseRefreshLock(ctx, cacheKey) }()
+			// 拿到锁后再次检查缓存（另一个 worker 可能已签发）
+			if token, err := p.tokenCache.GetAccessToken(ctx, cacheKey); err == nil && strings.TrimSpace(token) != "" {
+				return token, nil
+			}
+		} else if lockErr == nil {
+			// 锁被其他 worker 持有，等待后重试读取缓存；仍未命中则自行签发
+			time.Sleep(vertexLockWaitTime)
+			if token, err := p.tokenCache.GetAccessToken(ctx, cacheKey); err == nil && strings.TrimSpace(token) != "" {
+				return token, nil
+			}
+		} else {
+			slog.Warn("vertex_token_lock_failed_degraded", "account_id", account.ID, "error", lockErr)
+		}
+	}
+
+	token, expiresIn, err := p.mintAccessToken(ctx, account)
+	if err != nil {
+		return "", err
+	}
+
+	// 3. 存入缓存
+	if p.tokenCache != nil {
+		ttl := expiresIn - vertexTokenCacheSkew
+		if ttl <= 0 {
+			ttl = time.Minute
+		}
+		if err := p.tokenCache.SetAccessToken(ctx, cacheKey, token, ttl); err != nil {
+			slog.Warn("vertex_token_cache_set_failed", "account_id", account.ID, "error", err)
+		}
+	}
+	return token, nil
+}
+
+// mintAccessToken 用服务账号私钥签名 JWT 断言并向 token_uri 换取 access_token
+func (p *VertexTokenProvider) mintAccessToken(ctx context.Context, account *Account) (string, time.Duration, error) {
+	sa, err := parseVertexServiceAccount(account)
+	if err != nil {
+		return "", 0, err
+	}
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(sa.PrivateKey))
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid service account private key: %w", err)
+	}
+
+	now := p.now()
+	assertion := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   sa.ClientEmail,
+		"scope": vertexTokenScope,
+		"aud":   sa.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(vertexAssertionTTL).Unix(),
+	})
+	if sa.PrivateKeyID != "" {
+		assertion.Header["kid"] = sa.PrivateKeyID
+	}
+	signed, err := assertion.SignedString(privateKey)
+	if err != nil {
+		return "", 0, fmt.Errorf("sign service account assertion: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {signed},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sa.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", 0, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	proxyURL := ""
+	if account.ProxyID != nil && account.Proxy != nil {
+		proxyURL = account.Proxy.URL()
+	}
+	resp, err := p.httpUpstream.Do(req, proxyURL, account.ID, account.Concurrency)
+	if err != nil {
+		return "", 0, fmt.Errorf("vertex token request failed: %s", sanitizeUpstreamErrorMessage(err.Error()))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("vertex token request returned %d: %s", resp.StatusCode, truncateString(string(body), 512))
+	}
+	var tokenResp struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", 0, fmt.Errorf("parse vertex token response: %w", err)
+	}
+	if strings.TrimSpace(tokenResp.AccessToken) == "" {
+		return "", 0, errors.New("vertex token response missing access_token")
+	}
+	return tokenResp.AccessToken, time.Duration(tokenResp.ExpiresIn) * time.Second, nil
+}
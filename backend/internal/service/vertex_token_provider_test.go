@@ -0,0 +1,156 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// vertexTokenCacheStub 基于内存 map 的 token 缓存
+type vertexTokenCacheStub struct {
+	mu     sync.Mutex
+	tokens map[string]string
+	ttls   map[string]time.Duration
+}
+
+func newVertexTokenCacheStub() *vertexTokenCacheStub {
+	return &vertexTokenCacheStub{tokens: map[string]string{}, ttls: map[string]time.Duration{}}
+}
+
+func (s *vertexTokenCacheStub) GetAccessToken(ctx context.Context, cacheKey string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[cacheKey], nil
+}
+
+func (s *vertexTokenCacheStub) SetAccessToken(ctx context.Context, cacheKey string, token string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[cacheKey] = token
+	s.ttls[cacheKey] = ttl
+	return nil
+}
+
+func (s *vertexTokenCacheStub) DeleteAccessToken(ctx context.Context, cacheKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, cacheKey)
+	return nil
+}
+
+func (s *vertexTokenCacheStub) AcquireRefreshLock(ctx context.Context, cacheKey string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (s *vertexTokenCacheStub) ReleaseRefreshLock(ctx context.Context, cacheKey string) error {
+	return nil
+}
+
+// newVertexTestServiceAccount 生成测试用服务账号 JSON 及其公钥
+func newVertexTestServiceAccount(t *testing.T, tokenURI string) (string, *rsa.PublicKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	raw, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "test-project",
+		"private_key_id": "kid-1",
+		"private_key":    string(keyPEM),
+		"client_email":   "svc@test-project.iam.gserviceaccount.com",
+		"token_uri":      tokenURI,
+	})
+	require.NoError(t, err)
+	return string(raw), &key.PublicKey
+}
+
+func TestVertexTokenProvider_MintsAndCachesToken(t *testing.T) {
+	var calls int
+	var publicKey *rsa.PublicKey
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.PostForm.Get("grant_type"))
+
+		token, err := jwt.Parse(r.PostForm.Get("assertion"), func(tok *jwt.Token) (any, error) {
+			require.Equal(t, "kid-1", tok.Header["kid"])
+			return publicKey, nil
+		}, jwt.WithValidMethods([]string{"RS256"}))
+		require.NoError(t, err)
+		claims := token.Claims.(jwt.MapClaims)
+		require.Equal(t, "svc@test-project.iam.gserviceaccount.com", claims["iss"])
+		require.Equal(t, vertexTokenScope, claims["scope"])
+		require.Equal(t, "http://"+r.Host+"/token", claims["aud"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"ya29.vertex","expires_in":3600,"token_type":"Bearer"}`))
+	}))
+	defer srv.Close()
+
+	saJSON, pub := newVertexTestServiceAccount(t, srv.URL+"/token")
+	publicKey = pub
+
+	cache := newVertexTokenCacheStub()
+	provider := NewVertexTokenProvider(cache, passthroughUpstream{})
+	account := &Account{ID: 7, Type: AccountTypeVertex, Credentials: map[string]any{"service_account_json": saJSON}}
+
+	token, err := provider.GetAccessToken(context.Background(), account)
+	require.NoError(t, err)
+	require.Equal(t, "ya29.vertex", token)
+	require.Equal(t, "ya29.vertex", cache.tokens["vertex:account:7"])
+	require.Equal(t, 55*time.Minute, cache.ttls["vertex:account:7"])
+
+	token, err = provider.GetAccessToken(context.Background(), account)
+	require.NoError(t, err)
+	require.Equal(t, "ya29.vertex", token)
+	require.Equal(t, 1, calls)
+}
+
+func TestVertexTokenProvider_TokenEndpointError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	defer srv.Close()
+
+	saJSON, _ := newVertexTestServiceAccount(t, srv.URL)
+	provider := NewVertexTokenProvider(newVertexTokenCacheStub(), passthroughUpstream{})
+	account := &Account{ID: 7, Type: AccountTypeVertex, Credentials: map[string]any{"service_account_json": saJSON}}
+
+	_, err := provider.GetAccessToken(context.Background(), account)
+	require.ErrorContains(t, err, "invalid_grant")
+}
+
+func TestVertexTokenProvider_RejectsNonVertexAccount(t *testing.T) {
+	provider := NewVertexTokenProvider(nil, nil)
+	_, err := provider.GetAccessToken(context.Background(), &Account{Type: AccountTypeAPIKey})
+	require.Error(t, err)
+}
+
+func TestParseVertexServiceAccount(t *testing.T) {
+	account := &Account{Credentials: map[string]any{
+		"service_account_json": map[string]any{"client_email": "a@b", "private_key": "k", "project_id": "p"},
+	}}
+	sa, err := parseVertexServiceAccount(account)
+	require.NoError(t, err)
+	require.Equal(t, "https://oauth2.googleapis.com/token", sa.TokenURI)
+
+	_, err = parseVertexServiceAccount(&Account{Credentials: map[string]any{"service_account_json": `{"project_id":"p"}`}})
+	require.ErrorContains(t, err, "client_email")
+
+	_, err = parseVertexServiceAccount(&Account{Credentials: map[string]any{}})
+	require.ErrorContains(t, err, "service_account_json")
+}
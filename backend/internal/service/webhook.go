@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+)
+
+// Webhook 事件类型
+const (
+	WebhookEventOpsAlertFiring            = "ops.alert.firing"
+	WebhookEventOpsAlertResolved          = "ops.alert.resolved"
+	WebhookEventAccountError              = "account.error"
+	WebhookEventAccountRateLimited        = "account.rate_limited"
+	WebhookEventAccountTempUnschedulable  = "account.temp_unschedulable"
+	WebhookEventAccountTokenRefreshFailed = "account.token_refresh_failed"
+	WebhookEventUserBalanceLow            = "user.balance_low"
+)
+
+// WebhookEventTypes 所有可订阅的事件类型
+var WebhookEventTypes = []string{
+	WebhookEventOpsAlertFiring,
+	WebhookEventOpsAlertResolved,
+	WebhookEventAccountError,
+	WebhookEventAccountRateLimited,
+	WebhookEventAccountTempUnschedulable,
+	WebhookEventAccountTokenRefreshFailed,
+	WebhookEventUserBalanceLow,
+}
+
+// Webhook 请求体格式
+const (
+	WebhookFormatGeneric  = "generic"
+	WebhookFormatSlack    = "slack"
+	WebhookFormatFeishu   = "feishu"
+	WebhookFormatDingTalk = "dingtalk"
+	// WebhookFormatPagerDuty PagerDuty Events API v2，端点的 Secret 作为 routing_key（Integration Key）
+	WebhookFormatPagerDuty = "pagerduty"
+)
+
+// Webhook 投递状态
+const (
+	WebhookDeliveryStatusPending = "pending"
+	WebhookDeliveryStatusSuccess = "success"
+	WebhookDeliveryStatusFailed  = "failed"
+)
+
+var (
+	ErrWebhookEndpointNotFound = infraerrors.NotFound("WEBHOOK_ENDPOINT_NOT_FOUND", "webhook endpoint not found")
+	ErrWebhookDeliveryNotFound = infraerrors.NotFound("WEBHOOK_DELIVERY_NOT_FOUND", "webhook delivery not found")
+)
+
+// WebhookEndpoint 出站 Webhook 端点
+type WebhookEndpoint struct {
+	ID          int64
+	Name        string
+	URL         string
+	Secret      string
+	Format      string
+	Events      []string // 空列表表示订阅全部事件
+	Enabled     bool
+	Description *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Subscribes 判断端点是否订阅了指定事件类型
+func (e *WebhookEndpoint) Subscribes(eventType string) bool {
+	if e == nil {
+		return false
+	}
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, t := range e.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEvent 一次待投递的事件
+type WebhookEvent struct {
+	ID        string         `json:"id"`
+	Type      string         `json:"type"`
+	CreatedAt time.Time      `json:"created_at"`
+	Summary   string         `json:"summary"`
+	Data      map[string]any `json:"data"`
+}
+
+// WebhookDelivery 事件到某个端点的投递记录
+type WebhookDelivery struct {
+	ID             int64
+	EndpointID     int64
+	EventID        string
+	EventType      string
+	Payload        string
+	Status         string
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// WebhookDeliveryFilters 投递记录查询条件
+type WebhookDeliveryFilters struct {
+	EndpointID *int64
+	Status     string
+	EventType  string
+}
+
+// WebhookRepository Webhook 端点与投递记录的持久化接口
+type WebhookRepository interface {
+	ListEndpoints(ctx context.Context) ([]WebhookEndpoint, error)
+	GetEndpoint(ctx context.Context, id int64) (*WebhookEndpoint, error)
+	CreateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error
+	DeleteEndpoint(ctx context.Context, id int64) error
+
+	CreateDeliveries(ctx context.Context, deliveries []*WebhookDelivery) error
+	GetDelivery(ctx context.Context, id int64) (*WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, params pagination.PaginationParams, filters WebhookDeliveryFilters) ([]WebhookDelivery, *pagination.PaginationResult, error)
+	// ClaimDueDeliveries 领取到期的 pending 投递，并将其 next_attempt_at 推后 lease 以避免多实例重复发送
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)
+	// UpdateDeliveryResult 写回一次投递尝试的结果（status/attempts/next_attempt_at/last_*/delivered_at）
+	UpdateDeliveryResult(ctx context.Context, delivery *WebhookDelivery) error
+	// ResetDelivery 将投递重置为 pending 并立即可领取（用于手动重试）
+	ResetDelivery(ctx context.Context, id int64) error
+	DeleteDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// WebhookSender 发送 Webhook HTTP 请求
+type WebhookSender interface {
+	// Send POST body 到 url，返回 HTTP 状态码与截断后的响应体
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (statusCode int, respBody string, err error)
+}
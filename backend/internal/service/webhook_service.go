@@ -0,0 +1,987 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	infraerrors "github.com/Wei-Shaw/sub2api/internal/pkg/errors"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/Wei-Shaw/sub2api/internal/util/urlvalidator"
+	"github.com/google/uuid"
+)
+
+const (
+	webhookEventBufferSize     = 1024
+	webhookClaimBatchSize      = 50
+	webhookSendParallelism     = 8
+	webhookEndpointCacheTTL    = 30 * time.Second
+	webhookMaxBackoff          = 6 * time.Hour
+	webhookBalanceLowCooldown  = 10 * time.Minute
+	webhookCleanupInterval     = time.Hour
+	webhookLastErrorMaxLen     = 2048
+	webhookEventTest           = "webhook.test"
+	webhookSignatureHeader     = "X-Sub2API-Signature"
+	webhookTimestampHeader     = "X-Sub2API-Timestamp"
+	webhookEventHeader         = "X-Sub2API-Event"
+	webhookEventIDHeader       = "X-Sub2API-Event-ID"
+	webhookDeliveryIDHeader    = "X-Sub2API-Delivery"
+	webhookGeneratedSecretSize = 24
+)
+
+// CreateWebhookEndpointInput 创建 Webhook 端点的参数
+type CreateWebhookEndpointInput struct {
+	Name        string
+	URL         string
+	Secret      string
+	Format      string
+	Events      []string
+	Enabled     *bool
+	Description *string
+}
+
+// UpdateWebhookEndpointInput 更新 Webhook 端点的参数（nil 表示不修改）
+type UpdateWebhookEndpointInput struct {
+	Name        *string
+	URL         *string
+	Secret      *string
+	Format      *string
+	Events      []string
+	Enabled     *bool
+	Description *string
+}
+
+// WebhookTestResult 测试投递结果
+type WebhookTestResult struct {
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"status_code"`
+	Response   string `json:"response,omitempty"`
+	Error      string `json:"error,omitempty"`
+	LatencyMs  int64  `json:"latency_ms"`
+}
+
+// WebhookService 出站 Webhook 服务
+//
+// 事件通过 Emit 异步入队（不阻塞调用方），由 dispatcher 为每个订阅端点写入一条投递记录；
+// 投递 worker 定时领取到期记录发送，失败按指数退避重试，最终状态与错误写回投递记录供后台查看。
+type WebhookService struct {
+	repo     WebhookRepository
+	sender   WebhookSender
+	userRepo UserRepository
+	cfg      *config.Config
+
+	taskCh chan func(ctx context.Context)
+	kickCh chan struct{}
+	stopCh chan struct{}
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	wg        sync.WaitGroup
+
+	endpointsMu       sync.RWMutex
+	endpoints         []WebhookEndpoint
+	endpointsLoadedAt time.Time
+
+	balanceMu       sync.Mutex
+	balanceNotified map[int64]time.Time
+
+	lastCleanupAt time.Time
+}
+
+// NewWebhookService 创建 Webhook 服务
+func NewWebhookService(repo WebhookRepository, sender WebhookSender, userRepo UserRepository, cfg *config.Config) *WebhookService {
+	return &WebhookService{
+		repo:            repo,
+		sender:          sender,
+		userRepo:        userRepo,
+		cfg:             cfg,
+		taskCh:          make(chan func(ctx context.Context), webhookEventBufferSize),
+		kickCh:          make(chan struct{}, 1),
+		stopCh:          make(chan struct{}),
+		balanceNotified: make(map[int64]time.Time),
+	}
+}
+
+func (s *WebhookService) enabled() bool {
+	return s != nil && s.repo != nil && s.cfg != nil && s.cfg.Webhook.Enabled
+}
+
+// Start 启动事件分发与投递 worker
+func (s *WebhookService) Start() {
+	if s == nil {
+		return
+	}
+	if !s.enabled() || s.sender == nil {
+		log.Printf("[Webhook] not started (disabled)")
+		return
+	}
+	s.startOnce.Do(func() {
+		s.refreshEndpoints(context.Background())
+		s.wg.Add(2)
+		go s.runDispatcher()
+		go s.runWorker()
+		log.Printf("[Webhook] started (interval=%s max_attempts=%d)", s.workerInterval(), s.cfg.Webhook.MaxAttempts)
+	})
+}
+
+// Stop 停止 worker，已入队但未写库的事件会被丢弃
+func (s *WebhookService) Stop() {
+	if s == nil {
+		return
+	}
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+}
+
+func (s *WebhookService) workerInterval() time.Duration {
+	if s.cfg == nil || s.cfg.Webhook.WorkerIntervalSeconds <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(s.cfg.Webhook.WorkerIntervalSeconds) * time.Second
+}
+
+func (s *WebhookService) sendTimeout() time.Duration {
+	if s.cfg == nil || s.cfg.Webhook.TimeoutSeconds <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(s.cfg.Webhook.TimeoutSeconds) * time.Second
+}
+
+func (s *WebhookService) maxAttempts() int {
+	if s.cfg == nil || s.cfg.Webhook.MaxAttempts <= 0 {
+		return 6
+	}
+	return s.cfg.Webhook.MaxAttempts
+}
+
+// retryBackoff 计算第 attempts 次失败后的等待时间：base * 2^(attempts-1)，上限 webhookMaxBackoff
+func (s *WebhookService) retryBackoff(attempts int) time.Duration {
+	base := 30 * time.Second
+	if s.cfg != nil && s.cfg.Webhook.RetryBackoffSeconds > 0 {
+		base = time.Duration(s.cfg.Webhook.RetryBackoffSeconds) * time.Second
+	}
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := base
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= webhookMaxBackoff {
+			return webhookMaxBackoff
+		}
+	}
+	return backoff
+}
+
+// ===== 端点管理 =====
+
+// ListEndpoints 列出所有端点
+func (s *WebhookService) ListEndpoints(ctx context.Context) ([]WebhookEndpoint, error) {
+	return s.repo.ListEndpoints(ctx)
+}
+
+// GetEndpoint 获取端点
+func (s *WebhookService) GetEndpoint(ctx context.Context, id int64) (*WebhookEndpoint, error) {
+	return s.repo.GetEndpoint(ctx, id)
+}
+
+// CreateEndpoint 创建端点
+func (s *WebhookService) CreateEndpoint(ctx context.Context, input *CreateWebhookEndpointInput) (*WebhookEndpoint, error) {
+	endpoint := &WebhookEndpoint{
+		Name:        strings.TrimSpace(input.Name),
+		URL:         input.URL,
+		Secret:      strings.TrimSpace(input.Secret),
+		Format:      strings.ToLower(strings.TrimSpace(input.Format)),
+		Events:      input.Events,
+		Enabled:     true,
+		Description: input.Description,
+	}
+	if input.Enabled != nil {
+		endpoint.Enabled = *input.Enabled
+	}
+	if endpoint.Format == "" {
+		endpoint.Format = WebhookFormatGeneric
+	}
+	// 通用格式未提供密钥时自动生成，保证请求始终带签名
+	if endpoint.Secret == "" && endpoint.Format == WebhookFormatGeneric {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return nil, fmt.Errorf("generate webhook secret: %w", err)
+		}
+		endpoint.Secret = secret
+	}
+	if err := s.normalizeEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	s.refreshEndpoints(ctx)
+	return endpoint, nil
+}
+
+// UpdateEndpoint 更新端点（部分更新）
+func (s *WebhookService) UpdateEndpoint(ctx context.Context, id int64, input *UpdateWebhookEndpointInput) (*WebhookEndpoint, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		endpoint.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.URL != nil {
+		endpoint.URL = *input.URL
+	}
+	if input.Secret != nil {
+		endpoint.Secret = strings.TrimSpace(*input.Secret)
+	}
+	if input.Format != nil {
+		endpoint.Format = strings.ToLower(strings.TrimSpace(*input.Format))
+	}
+	if input.Events != nil {
+		endpoint.Events = input.Events
+	}
+	if input.Enabled != nil {
+		endpoint.Enabled = *input.Enabled
+	}
+	if input.Description != nil {
+		endpoint.Description = input.Description
+	}
+	if err := s.normalizeEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateEndpoint(ctx, endpoint); err != nil {
+		return nil, err
+	}
+	s.refreshEndpoints(ctx)
+	return endpoint, nil
+}
+
+// DeleteEndpoint 删除端点（投递记录级联删除）
+func (s *WebhookService) DeleteEndpoint(ctx context.Context, id int64) error {
+	if err := s.repo.DeleteEndpoint(ctx, id); err != nil {
+		return err
+	}
+	s.refreshEndpoints(ctx)
+	return nil
+}
+
+func (s *WebhookService) normalizeEndpoint(endpoint *WebhookEndpoint) error {
+	if endpoint.Name == "" {
+		return infraerrors.BadRequest("WEBHOOK_NAME_REQUIRED", "name is required")
+	}
+	if len([]rune(endpoint.Name)) > 100 {
+		return infraerrors.BadRequest("WEBHOOK_NAME_TOO_LONG", "name must be at most 100 characters")
+	}
+	normalizedURL, err := s.validateEndpointURL(endpoint.URL)
+	if err != nil {
+		return infraerrors.BadRequest("WEBHOOK_INVALID_URL", err.Error())
+	}
+	endpoint.URL = normalizedURL
+
+	switch endpoint.Format {
+	case WebhookFormatGeneric, WebhookFormatSlack, WebhookFormatFeishu, WebhookFormatDingTalk:
+	case WebhookFormatPagerDuty:
+		if endpoint.Secret == "" {
+			return infraerrors.BadRequest("WEBHOOK_ROUTING_KEY_REQUIRED", "secret (PagerDuty routing key) is required for pagerduty format")
+		}
+	default:
+		return infraerrors.BadRequest("WEBHOOK_INVALID_FORMAT", "format must be one of generic, slack, feishu, dingtalk, pagerduty")
+	}
+
+	events := make([]string, 0, len(endpoint.Events))
+	seen := make(map[string]struct{}, len(endpoint.Events))
+	for _, raw := range endpoint.Events {
+		eventType := strings.TrimSpace(raw)
+		if eventType == "" {
+			continue
+		}
+		if !isKnownWebhookEventType(eventType) {
+			return infraerrors.BadRequest("WEBHOOK_INVALID_EVENT", "unknown event type: "+eventType)
+		}
+		if _, ok := seen[eventType]; ok {
+			continue
+		}
+		seen[eventType] = struct{}{}
+		events = append(events, eventType)
+	}
+	endpoint.Events = events
+	return nil
+}
+
+// validateEndpointURL 校验 Webhook 目标地址。
+// 目标由管理员配置（Slack/飞书/PagerDuty 等），不要求命中上游白名单；
+// 但无论白名单是否开启都禁止回环、私网与链路本地地址（发送时还会校验 DNS 解析结果），防止 SSRF。
+func (s *WebhookService) validateEndpointURL(raw string) (string, error) {
+	if s.cfg == nil || !s.cfg.Security.URLAllowlist.Enabled {
+		allowInsecure := s.cfg != nil && s.cfg.Security.URLAllowlist.AllowInsecureHTTP
+		normalized, err := urlvalidator.ValidateURLFormat(raw, allowInsecure)
+		if err != nil {
+			return "", err
+		}
+		parsed, err := url.Parse(normalized)
+		if err != nil {
+			return "", err
+		}
+		if urlvalidator.IsBlockedHost(parsed.Hostname()) {
+			return "", fmt.Errorf("host is not allowed: %s", parsed.Hostname())
+		}
+		return normalized, nil
+	}
+	return urlvalidator.ValidateHTTPSURL(raw, urlvalidator.ValidationOptions{})
+}
+
+func isKnownWebhookEventType(eventType string) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, webhookGeneratedSecretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+// ===== 投递记录 =====
+
+// ListDeliveries 分页查询投递记录
+func (s *WebhookService) ListDeliveries(ctx context.Context, params pagination.PaginationParams, filters WebhookDeliveryFilters) ([]WebhookDelivery, *pagination.PaginationResult, error) {
+	return s.repo.ListDeliveries(ctx, params, filters)
+}
+
+// RetryDelivery 手动重试一条投递（重置尝试次数并立即排队）
+func (s *WebhookService) RetryDelivery(ctx context.Context, id int64) error {
+	if _, err := s.repo.GetDelivery(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.ResetDelivery(ctx, id); err != nil {
+		return err
+	}
+	s.kick()
+	return nil
+}
+
+// TestEndpoint 同步发送一条 webhook.test 事件到指定端点（不落库、不重试）
+func (s *WebhookService) TestEndpoint(ctx context.Context, id int64) (*WebhookTestResult, error) {
+	endpoint, err := s.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	event := newWebhookEvent(webhookEventTest, "Sub2API webhook test", map[string]any{
+		"endpoint_id":   endpoint.ID,
+		"endpoint_name": endpoint.Name,
+	})
+	payload, err := buildWebhookPayload(endpoint.Format, event)
+	if err != nil {
+		return nil, err
+	}
+	delivery := &WebhookDelivery{EventID: event.ID, EventType: event.Type, Payload: string(payload)}
+
+	start := time.Now()
+	statusCode, respBody, sendErr := s.send(ctx, endpoint, delivery)
+	result := &WebhookTestResult{
+		StatusCode: statusCode,
+		Response:   respBody,
+		LatencyMs:  time.Since(start).Milliseconds(),
+	}
+	if sendErr != nil {
+		result.Error = sendErr.Error()
+	} else {
+		result.Success = statusCode >= 200 && statusCode < 300
+	}
+	return result, nil
+}
+
+// ===== 事件入口 =====
+
+// Emit 异步发布事件，不阻塞调用方；无订阅端点时直接忽略
+func (s *WebhookService) Emit(eventType, summary string, data map[string]any) {
+	if !s.enabled() || !s.hasSubscriber(eventType) {
+		return
+	}
+	event := newWebhookEvent(eventType, summary, data)
+	s.enqueue(func(ctx context.Context) {
+		s.dispatch(ctx, event)
+	})
+}
+
+// EmitAccountEvent 发布账号状态变化事件
+func (s *WebhookService) EmitAccountEvent(eventType string, account *Account, reason string, extra map[string]any) {
+	if !s.enabled() || account == nil {
+		return
+	}
+	data := map[string]any{
+		"account_id":   account.ID,
+		"account_name": account.Name,
+		"platform":     account.Platform,
+		"type":         account.Type,
+		"reason":       reason,
+	}
+	for k, v := range extra {
+		data[k] = v
+	}
+	summary := fmt.Sprintf("Account #%d (%s, %s) %s", account.ID, account.Name, account.Platform, webhookEventVerb(eventType))
+	if reason != "" {
+		summary += ": " + reason
+	}
+	s.Emit(eventType, summary, data)
+}
+
+// NotifyBalanceDeducted 在余额扣费后检查是否跌破低余额阈值（由高于阈值降至阈值及以下时触发）
+func (s *WebhookService) NotifyBalanceDeducted(userID int64, amount float64) {
+	if !s.enabled() || s.userRepo == nil || userID <= 0 || amount <= 0 {
+		return
+	}
+	threshold := s.cfg.Webhook.LowBalanceThreshold
+	if threshold <= 0 || !s.hasSubscriber(WebhookEventUserBalanceLow) {
+		return
+	}
+	s.enqueue(func(ctx context.Context) {
+		user, err := s.userRepo.GetByID(ctx, userID)
+		if err != nil || user == nil {
+			return
+		}
+		if !(user.Balance <= threshold && user.Balance+amount > threshold) {
+			return
+		}
+		if !s.markBalanceNotified(userID, time.Now()) {
+			return
+		}
+		summary := fmt.Sprintf("User #%d (%s) balance dropped to %.4f (threshold %.2f)", user.ID, user.Email, user.Balance, threshold)
+		s.dispatch(ctx, newWebhookEvent(WebhookEventUserBalanceLow, summary, map[string]any{
+			"user_id":   user.ID,
+			"email":     user.Email,
+			"username":  user.Username,
+			"balance":   user.Balance,
+			"threshold": threshold,
+		}))
+	})
+}
+
+// markBalanceNotified 同一用户在冷却期内只通知一次（并发扣费可能同时判定跨越阈值）
+func (s *WebhookService) markBalanceNotified(userID int64, now time.Time) bool {
+	s.balanceMu.Lock()
+	defer s.balanceMu.Unlock()
+	if last, ok := s.balanceNotified[userID]; ok && now.Sub(last) < webhookBalanceLowCooldown {
+		return false
+	}
+	for id, at := range s.balanceNotified {
+		if now.Sub(at) >= webhookBalanceLowCooldown {
+			delete(s.balanceNotified, id)
+		}
+	}
+	s.balanceNotified[userID] = now
+	return true
+}
+
+func (s *WebhookService) enqueue(task func(ctx context.Context)) {
+	select {
+	case s.taskCh <- task:
+	default:
+		log.Printf("[Webhook] event queue full, dropping event")
+	}
+}
+
+func (s *WebhookService) kick() {
+	select {
+	case s.kickCh <- struct{}{}:
+	default:
+	}
+}
+
+func newWebhookEvent(eventType, summary string, data map[string]any) *WebhookEvent {
+	if data == nil {
+		data = map[string]any{}
+	}
+	return &WebhookEvent{
+		ID:        "evt_" + strings.ReplaceAll(uuid.NewString(), "-", ""),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Summary:   summary,
+		Data:      data,
+	}
+}
+
+func webhookEventVerb(eventType string) string {
+	switch eventType {
+	case WebhookEventAccountError:
+		return "entered error state"
+	case WebhookEventAccountRateLimited:
+		return "is rate limited"
+	case WebhookEventAccountTempUnschedulable:
+		return "is temporarily unschedulable"
+	case WebhookEventAccountTokenRefreshFailed:
+		return "token refresh failed"
+	default:
+		return eventType
+	}
+}
+
+// ===== 分发与投递 =====
+
+func (s *WebhookService) cachedEndpoints() []WebhookEndpoint {
+	s.endpointsMu.RLock()
+	defer s.endpointsMu.RUnlock()
+	return s.endpoints
+}
+
+func (s *WebhookService) hasSubscriber(eventType string) bool {
+	endpoints := s.cachedEndpoints()
+	for i := range endpoints {
+		if endpoints[i].Enabled && endpoints[i].Subscribes(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *WebhookService) refreshEndpoints(ctx context.Context) {
+	if s == nil || s.repo == nil {
+		return
+	}
+	endpoints, err := s.repo.ListEndpoints(ctx)
+	if err != nil {
+		log.Printf("[Webhook] load endpoints failed: %v", err)
+		return
+	}
+	s.endpointsMu.Lock()
+	s.endpoints = endpoints
+	s.endpointsLoadedAt = time.Now()
+	s.endpointsMu.Unlock()
+}
+
+func (s *WebhookService) endpointByID(id int64) *WebhookEndpoint {
+	endpoints := s.cachedEndpoints()
+	for i := range endpoints {
+		if endpoints[i].ID == id {
+			ep := endpoints[i]
+			return &ep
+		}
+	}
+	return nil
+}
+
+// dispatch 为每个订阅端点写入一条 pending 投递记录，并唤醒 worker 立即发送
+func (s *WebhookService) dispatch(ctx context.Context, event *WebhookEvent) {
+	endpoints := s.cachedEndpoints()
+	now := time.Now()
+	deliveries := make([]*WebhookDelivery, 0, len(endpoints))
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+		if !endpoint.Enabled || !endpoint.Subscribes(event.Type) {
+			continue
+		}
+		payload, err := buildWebhookPayload(endpoint.Format, event)
+		if err != nil {
+			log.Printf("[Webhook] build payload failed (endpoint=%d event=%s): %v", endpoint.ID, event.Type, err)
+			continue
+		}
+		next := now
+		deliveries = append(deliveries, &WebhookDelivery{
+			EndpointID:    endpoint.ID,
+			EventID:       event.ID,
+			EventType:     event.Type,
+			Payload:       string(payload),
+			Status:        WebhookDeliveryStatusPending,
+			NextAttemptAt: &next,
+		})
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+	if err := s.repo.CreateDeliveries(ctx, deliveries); err != nil {
+		log.Printf("[Webhook] create deliveries failed (event=%s): %v", event.Type, err)
+		return
+	}
+	s.kick()
+}
+
+func (s *WebhookService) runDispatcher() {
+	defer s.wg.Done()
+	for {
+		select {
+		case task := <-s.taskCh:
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			task(ctx)
+			cancel()
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+func (s *WebhookService) runWorker() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(s.workerInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.kickCh:
+		case <-s.stopCh:
+			return
+		}
+		s.runOnce()
+	}
+}
+
+func (s *WebhookService) runOnce() {
+	s.endpointsMu.RLock()
+	stale := time.Since(s.endpointsLoadedAt) > webhookEndpointCacheTTL
+	s.endpointsMu.RUnlock()
+	if stale {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		s.refreshEndpoints(ctx)
+		cancel()
+	}
+
+	s.processDueDeliveries()
+	s.cleanupDeliveries()
+}
+
+func (s *WebhookService) processDueDeliveries() {
+	lease := s.sendTimeout() + time.Minute
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	deliveries, err := s.repo.ClaimDueDeliveries(ctx, webhookClaimBatchSize, lease)
+	cancel()
+	if err != nil {
+		log.Printf("[Webhook] claim deliveries failed: %v", err)
+		return
+	}
+	if len(deliveries) == 0 {
+		return
+	}
+
+	sem := make(chan struct{}, webhookSendParallelism)
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			s.attemptDelivery(delivery)
+		}()
+	}
+	wg.Wait()
+
+	// 本批次已满，可能还有积压，立即继续
+	if len(deliveries) >= webhookClaimBatchSize {
+		s.kick()
+	}
+}
+
+func (s *WebhookService) attemptDelivery(delivery *WebhookDelivery) {
+	now := time.Now()
+	delivery.Attempts++
+
+	endpoint := s.endpointByID(delivery.EndpointID)
+	if endpoint == nil || !endpoint.Enabled {
+		msg := "endpoint disabled or deleted"
+		delivery.Status = WebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+		delivery.LastError = &msg
+		s.saveDeliveryResult(delivery)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.sendTimeout())
+	statusCode, respBody, err := s.send(ctx, endpoint, delivery)
+	cancel()
+
+	if statusCode > 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+	if err == nil && statusCode >= 200 && statusCode < 300 {
+		delivery.Status = WebhookDeliveryStatusSuccess
+		delivery.NextAttemptAt = nil
+		delivery.LastError = nil
+		delivery.DeliveredAt = &now
+		s.saveDeliveryResult(delivery)
+		return
+	}
+
+	var errMsg string
+	if err != nil {
+		errMsg = err.Error()
+	} else {
+		errMsg = fmt.Sprintf("HTTP %d", statusCode)
+		if respBody != "" {
+			errMsg += ": " + respBody
+		}
+	}
+	errMsg = truncateString(errMsg, webhookLastErrorMaxLen)
+	delivery.LastError = &errMsg
+
+	if isRetryableWebhookFailure(statusCode, err) && delivery.Attempts < s.maxAttempts() {
+		next := now.Add(s.retryBackoff(delivery.Attempts))
+		delivery.Status = WebhookDeliveryStatusPending
+		delivery.NextAttemptAt = &next
+	} else {
+		delivery.Status = WebhookDeliveryStatusFailed
+		delivery.NextAttemptAt = nil
+		log.Printf("[Webhook] delivery %d to endpoint %d failed after %d attempts: %s", delivery.ID, delivery.EndpointID, delivery.Attempts, errMsg)
+	}
+	s.saveDeliveryResult(delivery)
+}
+
+func (s *WebhookService) saveDeliveryResult(delivery *WebhookDelivery) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.repo.UpdateDeliveryResult(ctx, delivery); err != nil {
+		log.Printf("[Webhook] update delivery %d failed: %v", delivery.ID, err)
+	}
+}
+
+// isRetryableWebhookFailure 网络错误、5xx、408、429 可重试；其他 4xx 视为配置问题不再重试
+func isRetryableWebhookFailure(statusCode int, err error) bool {
+	if err != nil || statusCode == 0 {
+		return true
+	}
+	if statusCode >= 500 || statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return false
+}
+
+func (s *WebhookService) send(ctx context.Context, endpoint *WebhookEndpoint, delivery *WebhookDelivery) (int, string, error) {
+	timestamp := time.Now().Unix()
+	body := []byte(delivery.Payload)
+	targetURL := endpoint.URL
+
+	switch endpoint.Format {
+	case WebhookFormatFeishu:
+		if endpoint.Secret != "" {
+			signed, err := signFeishuPayload(body, endpoint.Secret, timestamp)
+			if err != nil {
+				return 0, "", err
+			}
+			body = signed
+		}
+	case WebhookFormatDingTalk:
+		if endpoint.Secret != "" {
+			signedURL, err := signDingTalkURL(targetURL, endpoint.Secret, timestamp*1000)
+			if err != nil {
+				return 0, "", err
+			}
+			targetURL = signedURL
+		}
+	case WebhookFormatPagerDuty:
+		// routing_key 只在发送时注入，不落库到投递记录
+		withKey, err := injectPagerDutyRoutingKey(body, endpoint.Secret)
+		if err != nil {
+			return 0, "", err
+		}
+		body = withKey
+	}
+
+	headers := map[string]string{
+		"Content-Type":         "application/json",
+		"User-Agent":           "Sub2API-Webhook/1.0",
+		webhookEventHeader:     delivery.EventType,
+		webhookEventIDHeader:   delivery.EventID,
+		webhookTimestampHeader: strconv.FormatInt(timestamp, 10),
+	}
+	if delivery.ID > 0 {
+		headers[webhookDeliveryIDHeader] = strconv.FormatInt(delivery.ID, 10)
+	}
+	if endpoint.Secret != "" && endpoint.Format != WebhookFormatPagerDuty {
+		headers[webhookSignatureHeader] = "sha256=" + SignWebhookPayload(endpoint.Secret, timestamp, body)
+	}
+	return s.sender.Send(ctx, targetURL, headers, body)
+}
+
+func (s *WebhookService) cleanupDeliveries() {
+	if s.cfg == nil || s.cfg.Webhook.DeliveryRetentionDays <= 0 {
+		return
+	}
+	now := time.Now()
+	if now.Sub(s.lastCleanupAt) < webhookCleanupInterval {
+		return
+	}
+	s.lastCleanupAt = now
+
+	cutoff := now.AddDate(0, 0, -s.cfg.Webhook.DeliveryRetentionDays)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	deleted, err := s.repo.DeleteDeliveriesBefore(ctx, cutoff)
+	if err != nil {
+		log.Printf("[Webhook] cleanup deliveries failed: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Printf("[Webhook] cleaned up %d deliveries older than %s", deleted, cutoff.Format(time.RFC3339))
+	}
+}
+
+// ===== 签名与请求体 =====
+
+// SignWebhookPayload 计算 X-Sub2API-Signature：hex(HMAC-SHA256(secret, "<timestamp>.<body>"))
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// signFeishuPayload 飞书自定义机器人签名：base64(HMAC-SHA256(key=timestamp+"\n"+secret, msg=""))
+func signFeishuPayload(body []byte, secret string, timestamp int64) ([]byte, error) {
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, []byte(strconv.FormatInt(timestamp, 10)+"\n"+secret))
+	payload["timestamp"] = strconv.FormatInt(timestamp, 10)
+	payload["sign"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return json.Marshal(payload)
+}
+
+// signDingTalkURL 钉钉自定义机器人签名：URL 追加 timestamp(ms) 与 sign=base64(HMAC-SHA256(secret, timestamp+"\n"+secret))
+func signDingTalkURL(rawURL, secret string, timestampMs int64) (string, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	ts := strconv.FormatInt(timestampMs, 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts + "\n" + secret))
+	query := parsed.Query()
+	query.Set("timestamp", ts)
+	query.Set("sign", base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	parsed.RawQuery = query.Encode()
+	return parsed.String(), nil
+}
+
+// buildWebhookPayload 按端点格式组装请求体
+func buildWebhookPayload(format string, event *WebhookEvent) ([]byte, error) {
+	switch format {
+	case WebhookFormatSlack:
+		return json.Marshal(map[string]any{"text": buildWebhookText(event)})
+	case WebhookFormatFeishu:
+		return json.Marshal(map[string]any{
+			"msg_type": "text",
+			"content":  map[string]any{"text": buildWebhookText(event)},
+		})
+	case WebhookFormatDingTalk:
+		return json.Marshal(map[string]any{
+			"msgtype": "text",
+			"text":    map[string]any{"content": buildWebhookText(event)},
+		})
+	case WebhookFormatPagerDuty:
+		return json.Marshal(buildPagerDutyEvent(event))
+	default:
+		return json.Marshal(event)
+	}
+}
+
+// buildPagerDutyEvent 组装 PagerDuty Events API v2 请求体（routing_key 在发送时注入）。
+// 运维告警的 firing/resolved 使用同一 dedup_key，PagerDuty 会自动关闭对应 incident。
+func buildPagerDutyEvent(event *WebhookEvent) map[string]any {
+	action := "trigger"
+	if event.Type == WebhookEventOpsAlertResolved {
+		action = "resolve"
+	}
+	dedupKey := event.ID
+	if alertID, ok := event.Data["alert_event_id"]; ok && alertID != nil {
+		dedupKey = fmt.Sprintf("sub2api-ops-alert-%v", alertID)
+	}
+	summary := event.Summary
+	if summary == "" {
+		summary = event.Type
+	}
+	// PagerDuty 限制 summary 最长 1024 个字符
+	if runes := []rune(summary); len(runes) > 1024 {
+		summary = string(runes[:1024])
+	}
+	return map[string]any{
+		"event_action": action,
+		"dedup_key":    dedupKey,
+		"payload": map[string]any{
+			"summary":        summary,
+			"severity":       pagerDutySeverity(event),
+			"source":         "sub2api",
+			"timestamp":      event.CreatedAt.Format(time.RFC3339),
+			"class":          event.Type,
+			"custom_details": event.Data,
+		},
+	}
+}
+
+// pagerDutySeverity 映射事件严重程度，取值为 critical/error/warning/info
+func pagerDutySeverity(event *WebhookEvent) string {
+	switch event.Type {
+	case WebhookEventOpsAlertFiring, WebhookEventOpsAlertResolved:
+		severity, _ := event.Data["severity"].(string)
+		return opsEmailSeverityForOps(severity)
+	case WebhookEventAccountError, WebhookEventAccountTokenRefreshFailed:
+		return "error"
+	case WebhookEventAccountRateLimited, WebhookEventAccountTempUnschedulable:
+		return "warning"
+	default:
+		return "info"
+	}
+}
+
+func injectPagerDutyRoutingKey(body []byte, routingKey string) ([]byte, error) {
+	var payload map[string]any
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decode pagerduty payload: %w", err)
+	}
+	payload["routing_key"] = routingKey
+	return json.Marshal(payload)
+}
+
+// buildWebhookText 生成 IM 机器人使用的纯文本消息
+func buildWebhookText(event *WebhookEvent) string {
+	var b strings.Builder
+	b.WriteString("[Sub2API] ")
+	b.WriteString(event.Type)
+	if event.Summary != "" {
+		b.WriteString("\n")
+		b.WriteString(event.Summary)
+	}
+	keys := make([]string, 0, len(event.Data))
+	for k := range event.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := event.Data[k]
+		if v == nil {
+			continue
+		}
+		if str, ok := v.(string); ok && str == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s: %v", k, v)
+	}
+	b.WriteString("\ntime: ")
+	b.WriteString(event.CreatedAt.Format(time.RFC3339))
+	return b.String()
+}
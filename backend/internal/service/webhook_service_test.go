@@ -0,0 +1,338 @@
+//go:build unit
+
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/Wei-Shaw/sub2api/internal/config"
+	"github.com/Wei-Shaw/sub2api/internal/pkg/pagination"
+	"github.com/stretchr/testify/require"
+)
+
+type webhookRepoStub struct {
+	endpoints []WebhookEndpoint
+	created   []*WebhookDelivery
+	updated   []WebhookDelivery
+}
+
+func (r *webhookRepoStub) ListEndpoints(ctx context.Context) ([]WebhookEndpoint, error) {
+	return r.endpoints, nil
+}
+
+func (r *webhookRepoStub) GetEndpoint(ctx context.Context, id int64) (*WebhookEndpoint, error) {
+	for i := range r.endpoints {
+		if r.endpoints[i].ID == id {
+			ep := r.endpoints[i]
+			return &ep, nil
+		}
+	}
+	return nil, ErrWebhookEndpointNotFound
+}
+
+func (r *webhookRepoStub) CreateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error {
+	endpoint.ID = int64(len(r.endpoints) + 1)
+	r.endpoints = append(r.endpoints, *endpoint)
+	return nil
+}
+
+func (r *webhookRepoStub) UpdateEndpoint(ctx context.Context, endpoint *WebhookEndpoint) error {
+	return nil
+}
+
+func (r *webhookRepoStub) DeleteEndpoint(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (r *webhookRepoStub) CreateDeliveries(ctx context.Context, deliveries []*WebhookDelivery) error {
+	r.created = append(r.created, deliveries...)
+	return nil
+}
+
+func (r *webhookRepoStub) GetDelivery(ctx context.Context, id int64) (*WebhookDelivery, error) {
+	return nil, ErrWebhookDeliveryNotFound
+}
+
+func (r *webhookRepoStub) ListDeliveries(ctx context.Context, params pagination.PaginationParams, filters WebhookDeliveryFilters) ([]WebhookDelivery, *pagination.PaginationResult, error) {
+	return nil, &pagination.PaginationResult{}, nil
+}
+
+func (r *webhookRepoStub) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (r *webhookRepoStub) UpdateDeliveryResult(ctx context.Context, delivery *WebhookDelivery) error {
+	r.updated = append(r.updated, *delivery)
+	return nil
+}
+
+func (r *webhookRepoStub) ResetDelivery(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (r *webhookRepoStub) DeleteDeliveriesBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	return 0, nil
+}
+
+type webhookSenderStub struct {
+	status  int
+	body    string
+	err     error
+	url     string
+	headers map[string]string
+	payload []byte
+}
+
+func (s *webhookSenderStub) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, string, error) {
+	s.url = url
+	s.headers = headers
+	s.payload = body
+	return s.status, s.body, s.err
+}
+
+func newWebhookServiceForTest(endpoints []WebhookEndpoint, sender *webhookSenderStub) (*WebhookService, *webhookRepoStub) {
+	repo := &webhookRepoStub{endpoints: endpoints}
+	cfg := &config.Config{}
+	cfg.Webhook.Enabled = true
+	cfg.Webhook.TimeoutSeconds = 5
+	cfg.Webhook.MaxAttempts = 3
+	cfg.Webhook.RetryBackoffSeconds = 10
+	svc := NewWebhookService(repo, sender, nil, cfg)
+	svc.refreshEndpoints(context.Background())
+	return svc, repo
+}
+
+func TestWebhookEndpoint_Subscribes(t *testing.T) {
+	all := &WebhookEndpoint{}
+	require.True(t, all.Subscribes(WebhookEventAccountError))
+
+	some := &WebhookEndpoint{Events: []string{WebhookEventOpsAlertFiring}}
+	require.True(t, some.Subscribes(WebhookEventOpsAlertFiring))
+	require.False(t, some.Subscribes(WebhookEventAccountError))
+
+	var nilEndpoint *WebhookEndpoint
+	require.False(t, nilEndpoint.Subscribes(WebhookEventAccountError))
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"id":"evt"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(body)))
+	require.Equal(t, hex.EncodeToString(mac.Sum(nil)), SignWebhookPayload("secret", 1700000000, body))
+}
+
+func TestWebhookService_RetryBackoff(t *testing.T) {
+	svc, _ := newWebhookServiceForTest(nil, &webhookSenderStub{})
+	require.Equal(t, 10*time.Second, svc.retryBackoff(1))
+	require.Equal(t, 20*time.Second, svc.retryBackoff(2))
+	require.Equal(t, 40*time.Second, svc.retryBackoff(3))
+	require.Equal(t, webhookMaxBackoff, svc.retryBackoff(30))
+}
+
+func TestBuildWebhookPayload_Formats(t *testing.T) {
+	event := &WebhookEvent{
+		ID:      "evt-1",
+		Type:    WebhookEventAccountError,
+		Summary: "account 1 error",
+		Data:    map[string]any{"account_id": 1, "reason": "401"},
+	}
+
+	generic, err := buildWebhookPayload(WebhookFormatGeneric, event)
+	require.NoError(t, err)
+	var decoded WebhookEvent
+	require.NoError(t, json.Unmarshal(generic, &decoded))
+	require.Equal(t, "evt-1", decoded.ID)
+	require.Equal(t, WebhookEventAccountError, decoded.Type)
+
+	slack, err := buildWebhookPayload(WebhookFormatSlack, event)
+	require.NoError(t, err)
+	var slackBody map[string]any
+	require.NoError(t, json.Unmarshal(slack, &slackBody))
+	require.Contains(t, slackBody["text"], "account 1 error")
+
+	feishu, err := buildWebhookPayload(WebhookFormatFeishu, event)
+	require.NoError(t, err)
+	require.Contains(t, string(feishu), `"msg_type":"text"`)
+
+	dingtalk, err := buildWebhookPayload(WebhookFormatDingTalk, event)
+	require.NoError(t, err)
+	require.Contains(t, string(dingtalk), `"msgtype":"text"`)
+}
+
+func TestWebhookService_DispatchOnlySubscribedEndpoints(t *testing.T) {
+	svc, repo := newWebhookServiceForTest([]WebhookEndpoint{
+		{ID: 1, Format: WebhookFormatGeneric, Enabled: true},
+		{ID: 2, Format: WebhookFormatSlack, Enabled: true, Events: []string{WebhookEventOpsAlertFiring}},
+		{ID: 3, Format: WebhookFormatGeneric, Enabled: false},
+	}, &webhookSenderStub{})
+
+	svc.dispatch(context.Background(), newWebhookEvent(WebhookEventAccountError, "x", nil))
+	require.Len(t, repo.created, 1)
+	require.Equal(t, int64(1), repo.created[0].EndpointID)
+	require.Equal(t, WebhookDeliveryStatusPending, repo.created[0].Status)
+	require.NotNil(t, repo.created[0].NextAttemptAt)
+}
+
+func TestWebhookService_AttemptDelivery_SuccessSignsRequest(t *testing.T) {
+	sender := &webhookSenderStub{status: 204}
+	svc, repo := newWebhookServiceForTest([]WebhookEndpoint{
+		{ID: 1, URL: "https://example.com/hook", Secret: "s3cret", Format: WebhookFormatGeneric, Enabled: true},
+	}, sender)
+
+	svc.attemptDelivery(&WebhookDelivery{ID: 7, EndpointID: 1, EventID: "evt", EventType: WebhookEventAccountError, Payload: `{"a":1}`})
+
+	require.Len(t, repo.updated, 1)
+	require.Equal(t, WebhookDeliveryStatusSuccess, repo.updated[0].Status)
+	require.Equal(t, 1, repo.updated[0].Attempts)
+	require.NotNil(t, repo.updated[0].DeliveredAt)
+
+	ts := sender.headers[webhookTimestampHeader]
+	require.NotEmpty(t, ts)
+	var tsInt int64
+	require.NoError(t, json.Unmarshal([]byte(ts), &tsInt))
+	require.Equal(t, "sha256="+SignWebhookPayload("s3cret", tsInt, []byte(`{"a":1}`)), sender.headers[webhookSignatureHeader])
+	require.Equal(t, "7", sender.headers[webhookDeliveryIDHeader])
+}
+
+func TestWebhookService_AttemptDelivery_RetryThenFail(t *testing.T) {
+	sender := &webhookSenderStub{status: 503, body: "unavailable"}
+	svc, repo := newWebhookServiceForTest([]WebhookEndpoint{
+		{ID: 1, URL: "https://example.com/hook", Format: WebhookFormatGeneric, Enabled: true},
+	}, sender)
+
+	delivery := &WebhookDelivery{ID: 1, EndpointID: 1, Payload: `{}`}
+	svc.attemptDelivery(delivery)
+	require.Equal(t, WebhookDeliveryStatusPending, repo.updated[0].Status)
+	require.NotNil(t, repo.updated[0].NextAttemptAt)
+	require.Contains(t, *repo.updated[0].LastError, "HTTP 503")
+
+	svc.attemptDelivery(delivery)
+	svc.attemptDelivery(delivery)
+	last := repo.updated[len(repo.updated)-1]
+	require.Equal(t, 3, last.Attempts)
+	require.Equal(t, WebhookDeliveryStatusFailed, last.Status)
+	require.Nil(t, last.NextAttemptAt)
+}
+
+func TestWebhookService_AttemptDelivery_ClientErrorNotRetried(t *testing.T) {
+	sender := &webhookSenderStub{status: 400}
+	svc, repo := newWebhookServiceForTest([]WebhookEndpoint{
+		{ID: 1, URL: "https://example.com/hook", Format: WebhookFormatGeneric, Enabled: true},
+	}, sender)
+
+	svc.attemptDelivery(&WebhookDelivery{ID: 1, EndpointID: 1, Payload: `{}`})
+	require.Equal(t, WebhookDeliveryStatusFailed, repo.updated[0].Status)
+
+	sender.status = 0
+	sender.err = errors.New("dial tcp: timeout")
+	svc.attemptDelivery(&WebhookDelivery{ID: 2, EndpointID: 1, Payload: `{}`})
+	require.Equal(t, WebhookDeliveryStatusPending, repo.updated[1].Status)
+}
+
+func TestWebhookService_AttemptDelivery_DingTalkSignsURL(t *testing.T) {
+	sender := &webhookSenderStub{status: 200}
+	svc, _ := newWebhookServiceForTest([]WebhookEndpoint{
+		{ID: 1, URL: "https://oapi.dingtalk.com/robot/send?access_token=abc", Secret: "SEC", Format: WebhookFormatDingTalk, Enabled: true},
+	}, sender)
+
+	svc.attemptDelivery(&WebhookDelivery{ID: 1, EndpointID: 1, Payload: `{"msgtype":"text"}`})
+	parsed, err := url.Parse(sender.url)
+	require.NoError(t, err)
+	require.Equal(t, "abc", parsed.Query().Get("access_token"))
+	require.NotEmpty(t, parsed.Query().Get("timestamp"))
+	require.NotEmpty(t, parsed.Query().Get("sign"))
+}
+
+func TestBuildWebhookPayload_PagerDuty(t *testing.T) {
+	firing := &WebhookEvent{
+		ID:        "evt-1",
+		Type:      WebhookEventOpsAlertFiring,
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Summary:   "P0: error rate",
+		Data:      map[string]any{"alert_event_id": 42, "severity": "P0"},
+	}
+	body, err := buildWebhookPayload(WebhookFormatPagerDuty, firing)
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	require.Equal(t, "trigger", decoded["event_action"])
+	require.Equal(t, "sub2api-ops-alert-42", decoded["dedup_key"])
+	require.NotContains(t, decoded, "routing_key", "routing_key 不写入投递记录")
+	payload := decoded["payload"].(map[string]any)
+	require.Equal(t, "P0: error rate", payload["summary"])
+	require.Equal(t, "critical", payload["severity"])
+	require.Equal(t, "sub2api", payload["source"])
+	require.Equal(t, "2026-01-02T03:04:05Z", payload["timestamp"])
+
+	resolved := &WebhookEvent{ID: "evt-2", Type: WebhookEventOpsAlertResolved, Data: map[string]any{"alert_event_id": 42, "severity": "P1"}}
+	body, err = buildWebhookPayload(WebhookFormatPagerDuty, resolved)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	require.Equal(t, "resolve", decoded["event_action"])
+	require.Equal(t, "sub2api-ops-alert-42", decoded["dedup_key"], "resolve 与 trigger 使用同一 dedup_key")
+
+	accountErr := &WebhookEvent{ID: "evt-3", Type: WebhookEventAccountError, Data: map[string]any{"account_id": 1}}
+	body, err = buildWebhookPayload(WebhookFormatPagerDuty, accountErr)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	require.Equal(t, "evt-3", decoded["dedup_key"])
+	require.Equal(t, "error", decoded["payload"].(map[string]any)["severity"])
+	require.Equal(t, WebhookEventAccountError, decoded["payload"].(map[string]any)["summary"])
+}
+
+func TestWebhookService_AttemptDelivery_PagerDutyInjectsRoutingKey(t *testing.T) {
+	sender := &webhookSenderStub{status: 202}
+	svc, _ := newWebhookServiceForTest([]WebhookEndpoint{
+		{ID: 1, URL: "https://events.pagerduty.com/v2/enqueue", Secret: "R0UT1NG", Format: WebhookFormatPagerDuty, Enabled: true},
+	}, sender)
+
+	svc.attemptDelivery(&WebhookDelivery{ID: 1, EndpointID: 1, Payload: `{"event_action":"trigger"}`})
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(sender.payload, &decoded))
+	require.Equal(t, "R0UT1NG", decoded["routing_key"])
+	require.Equal(t, "trigger", decoded["event_action"])
+	require.NotContains(t, sender.headers, webhookSignatureHeader)
+}
+
+func TestWebhookService_CreateEndpointValidation(t *testing.T) {
+	svc, _ := newWebhookServiceForTest(nil, &webhookSenderStub{})
+	ctx := context.Background()
+
+	// 白名单关闭时同样拒绝回环/私网/链路本地地址
+	for _, target := range []string{
+		"https://127.0.0.1/hook",
+		"https://localhost/hook",
+		"https://10.0.0.1/hook",
+		"https://192.168.1.10:8443/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/hook",
+	} {
+		_, err := svc.CreateEndpoint(ctx, &CreateWebhookEndpointInput{Name: "x", URL: target})
+		require.Error(t, err, target)
+	}
+
+	_, err := svc.CreateEndpoint(ctx, &CreateWebhookEndpointInput{Name: "pd", URL: "https://events.pagerduty.com/v2/enqueue", Format: WebhookFormatPagerDuty})
+	require.Error(t, err, "pagerduty 格式必须提供 routing key")
+
+	endpoint, err := svc.CreateEndpoint(ctx, &CreateWebhookEndpointInput{Name: "pd", URL: "https://events.pagerduty.com/v2/enqueue", Format: WebhookFormatPagerDuty, Secret: "R0UT1NG"})
+	require.NoError(t, err)
+	require.Equal(t, "R0UT1NG", endpoint.Secret)
+}
+
+func TestWebhookService_NilSafe(t *testing.T) {
+	var svc *WebhookService
+	require.NotPanics(t, func() {
+		svc.Emit(WebhookEventAccountError, "x", nil)
+		svc.EmitAccountEvent(WebhookEventAccountError, &Account{ID: 1}, "x", nil)
+		svc.NotifyBalanceDeducted(1, 1)
+		svc.Stop()
+	})
+}
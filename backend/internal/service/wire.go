@@ -46,8 +46,10 @@ func ProvideTokenRefreshService(
 	cacheInvalidator TokenCacheInvalidator,
 	schedulerCache SchedulerCache,
 	cfg *config.Config,
+	webhookService *WebhookService,
 ) *TokenRefreshService {
 	svc := NewTokenRefreshService(accountRepo, oauthService, openaiOAuthService, geminiOAuthService, antigravityOAuthService, cacheInvalidator, schedulerCache, cfg)
+	svc.SetWebhookService(webhookService)
 	svc.Start()
 	return svc
 }
@@ -73,6 +75,13 @@ func ProvideAccountExpiryService(accountRepo AccountRepository) *AccountExpirySe
 	return svc
 }
 
+// ProvideAccountDrainService creates and starts AccountDrainService.
+func ProvideAccountDrainService(accountRepo AccountRepository) *AccountDrainService {
+	svc := NewAccountDrainService(accountRepo, time.Minute)
+	svc.Start()
+	return svc
+}
+
 // ProvideSubscriptionExpiryService creates and starts SubscriptionExpiryService.
 func ProvideSubscriptionExpiryService(userSubRepo UserSubscriptionRepository) *SubscriptionExpiryService {
 	svc := NewSubscriptionExpiryService(userSubRepo, time.Minute)
@@ -98,10 +107,14 @@ func ProvideDeferredService(accountRepo AccountRepository, timingWheel *TimingWh
 }
 
 // ProvideConcurrencyService creates ConcurrencyService and starts slot cleanup worker.
-func ProvideConcurrencyService(cache ConcurrencyCache, accountRepo AccountRepository, cfg *config.Config) *ConcurrencyService {
+func ProvideConcurrencyService(cache ConcurrencyCache, fairQueue *FairQueueService, accountRepo AccountRepository, cfg *config.Config) *ConcurrencyService {
 	svc := NewConcurrencyService(cache)
+	svc.SetFairQueue(fairQueue)
 	if cfg != nil {
 		svc.StartSlotCleanupWorker(accountRepo, cfg.Gateway.Scheduling.SlotCleanupInterval)
+		if cfg.Metrics.Enabled && cfg.Metrics.AccountConcurrency {
+			registerAccountConcurrencyMetrics(accountRepo, svc)
+		}
 	}
 	return svc
 }
@@ -129,11 +142,13 @@ func ProvideRateLimitService(
 	timeoutCounterCache TimeoutCounterCache,
 	settingService *SettingService,
 	tokenCacheInvalidator TokenCacheInvalidator,
+	webhookService *WebhookService,
 ) *RateLimitService {
 	svc := NewRateLimitService(accountRepo, usageRepo, cfg, geminiQuotaService, tempUnschedCache)
 	svc.SetTimeoutCounterCache(timeoutCounterCache)
 	svc.SetSettingService(settingService)
 	svc.SetTokenCacheInvalidator(tokenCacheInvalidator)
+	svc.SetWebhookService(webhookService)
 	return svc
 }
 
@@ -172,8 +187,10 @@ func ProvideOpsAlertEvaluatorService(
 	emailService *EmailService,
 	redisClient *redis.Client,
 	cfg *config.Config,
+	webhookService *WebhookService,
 ) *OpsAlertEvaluatorService {
 	svc := NewOpsAlertEvaluatorService(opsService, opsRepo, emailService, redisClient, cfg)
+	svc.SetWebhookService(webhookService)
 	svc.Start()
 	return svc
 }
@@ -203,6 +220,44 @@ func ProvideOpsScheduledReportService(
 	return svc
 }
 
+// ProvideWebhookService 创建并启动出站 Webhook 服务
+func ProvideWebhookService(repo WebhookRepository, sender WebhookSender, userRepo UserRepository, cfg *config.Config) *WebhookService {
+	svc := NewWebhookService(repo, sender, userRepo, cfg)
+	svc.Start()
+	return svc
+}
+
+// ProvideMessageBatchService 创建并启动 Message Batches 执行 worker
+func ProvideMessageBatchService(
+	repo MessageBatchRepository,
+	apiKeyRepo APIKeyRepository,
+	gatewayService *GatewayService,
+	antigravityGatewayService *AntigravityGatewayService,
+	geminiCompatService *GeminiMessagesCompatService,
+	subscriptionService *SubscriptionService,
+	billingCacheService *BillingCacheService,
+	apiKeyService *APIKeyService,
+	cfg *config.Config,
+) *MessageBatchService {
+	svc := NewMessageBatchService(repo, apiKeyRepo, gatewayService, antigravityGatewayService, geminiCompatService, subscriptionService, billingCacheService, apiKeyService, cfg)
+	svc.Start()
+	return svc
+}
+
+// ProvideBalanceLedgerService 创建余额流水服务并启动定时对账
+func ProvideBalanceLedgerService(repo BalanceLedgerRepository, cfg *config.Config) *BalanceLedgerService {
+	svc := NewBalanceLedgerService(repo, cfg)
+	svc.Start()
+	return svc
+}
+
+// ProvideCredentialReencryptService 创建并启动账号凭证后台重新加密任务
+func ProvideCredentialReencryptService(repo AccountCredentialRepository, cfg *config.Config) *CredentialReencryptService {
+	svc := NewCredentialReencryptService(repo, cfg)
+	svc.Start()
+	return svc
+}
+
 // ProvideAPIKeyAuthCacheInvalidator 提供 API Key 认证缓存失效能力
 func ProvideAPIKeyAuthCacheInvalidator(apiKeyService *APIKeyService) APIKeyAuthCacheInvalidator {
 	// Start Pub/Sub subscriber for L1 cache invalidation across instances
@@ -217,6 +272,8 @@ var ProviderSet = wire.NewSet(
 	NewUserService,
 	NewAPIKeyService,
 	ProvideAPIKeyAuthCacheInvalidator,
+	NewAPIKeyRateLimitService,
+	NewResponseCacheService,
 	NewGroupService,
 	NewAccountService,
 	NewProxyService,
@@ -231,6 +288,8 @@ var ProviderSet = wire.NewSet(
 	NewAdminService,
 	NewGatewayService,
 	NewOpenAIGatewayService,
+	NewEmbeddingService,
+	NewCompatibleGatewayService,
 	NewOAuthService,
 	NewOpenAIOAuthService,
 	NewGeminiOAuthService,
@@ -243,6 +302,7 @@ var ProviderSet = wire.NewSet(
 	NewAntigravityTokenProvider,
 	NewOpenAITokenProvider,
 	NewClaudeTokenProvider,
+	NewVertexTokenProvider,
 	NewAntigravityGatewayService,
 	ProvideRateLimitService,
 	NewAccountUsageService,
@@ -259,12 +319,14 @@ var ProviderSet = wire.NewSet(
 	NewTurnstileService,
 	NewSubscriptionService,
 	ProvideConcurrencyService,
+	NewFairQueueService,
 	ProvideSchedulerSnapshotService,
 	NewIdentityService,
 	NewCRSSyncService,
 	ProvideUpdateService,
 	ProvideTokenRefreshService,
 	ProvideAccountExpiryService,
+	ProvideAccountDrainService,
 	ProvideSubscriptionExpiryService,
 	ProvideTimingWheelService,
 	ProvideDashboardAggregationService,
@@ -275,5 +337,10 @@ var ProviderSet = wire.NewSet(
 	NewUsageCache,
 	NewTotpService,
 	NewErrorPassthroughService,
-	NewDigestSessionStore,
+	ProvideDigestSessionStore,
+	ProvideWebhookService,
+	ProvideMessageBatchService,
+	NewAdminAuditService,
+	ProvideBalanceLedgerService,
+	ProvideCredentialReencryptService,
 )
@@ -89,7 +89,9 @@ func (s *FrontendServer) Middleware() gin.HandlerFunc {
 			strings.HasPrefix(path, "/antigravity/") ||
 			strings.HasPrefix(path, "/setup/") ||
 			path == "/health" ||
-			path == "/responses" {
+			path == "/responses" ||
+			path == "/chat/completions" ||
+			path == "/embeddings" {
 			c.Next()
 			return
 		}
@@ -212,7 +214,9 @@ func ServeEmbeddedFrontend() gin.HandlerFunc {
 			strings.HasPrefix(path, "/antigravity/") ||
 			strings.HasPrefix(path, "/setup/") ||
 			path == "/health" ||
-			path == "/responses" {
+			path == "/responses" ||
+			path == "/chat/completions" ||
+			path == "/embeddings" {
 			c.Next()
 			return
 		}
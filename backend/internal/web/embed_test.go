@@ -366,6 +366,8 @@ func TestFrontendServer_Middleware(t *testing.T) {
 			"/setup/init",
 			"/health",
 			"/responses",
+			"/chat/completions",
+			"/embeddings",
 		}
 
 		for _, path := range apiPaths {
@@ -541,6 +543,8 @@ func TestServeEmbeddedFrontend(t *testing.T) {
 			"/setup/init",
 			"/health",
 			"/responses",
+			"/chat/completions",
+			"/embeddings",
 		}
 
 		for _, path := range apiPaths {
@@ -0,0 +1,17 @@
+-- Per-API-key rate limits
+-- api_keys: 0 = inherit the group default; groups: 0 = unlimited.
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS rpm_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS tpm_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS daily_request_limit INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS default_rpm_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS default_tpm_limit INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS default_daily_request_limit INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN api_keys.rpm_limit IS 'Requests per minute limit (0 = inherit group default)';
+COMMENT ON COLUMN api_keys.tpm_limit IS 'Tokens per minute limit (0 = inherit group default)';
+COMMENT ON COLUMN api_keys.daily_request_limit IS 'Daily request cap (0 = inherit group default)';
+COMMENT ON COLUMN groups.default_rpm_limit IS '分组内 API Key 默认每分钟请求数上限（0 = 不限制）';
+COMMENT ON COLUMN groups.default_tpm_limit IS '分组内 API Key 默认每分钟 token 数上限（0 = 不限制）';
+COMMENT ON COLUMN groups.default_daily_request_limit IS '分组内 API Key 默认每日请求数上限（0 = 不限制）';
@@ -0,0 +1,8 @@
+-- Per-group response cache for deterministic (temperature=0) requests
+-- usage_logs.billing_type: 0 = balance, 1 = subscription, 2 = response cache hit
+
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS response_cache_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS response_cache_ttl_seconds INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN groups.response_cache_enabled IS '是否缓存 temperature=0 的确定性请求响应';
+COMMENT ON COLUMN groups.response_cache_ttl_seconds IS '响应缓存过期时间（秒，0 = 使用全局默认值）';
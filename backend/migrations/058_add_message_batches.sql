@@ -0,0 +1,39 @@
+-- Message Batches API
+-- Batches submitted via /v1/messages/batches are executed asynchronously by the batch worker;
+-- each request is stored as an item and claimed with a lease (locked_until) for multi-instance safety.
+
+CREATE TABLE IF NOT EXISTS message_batches (
+    id BIGSERIAL PRIMARY KEY,
+    batch_id VARCHAR(64) NOT NULL UNIQUE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    api_key_id BIGINT NOT NULL,
+    group_id BIGINT,
+    processing_status VARCHAR(20) NOT NULL DEFAULT 'in_progress',
+    request_count INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    cancel_initiated_at TIMESTAMPTZ,
+    ended_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_batches_user_created ON message_batches (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_message_batches_processing_status ON message_batches (processing_status);
+CREATE INDEX IF NOT EXISTS idx_message_batches_ended_at ON message_batches (ended_at);
+
+CREATE TABLE IF NOT EXISTS message_batch_items (
+    id BIGSERIAL PRIMARY KEY,
+    batch_id BIGINT NOT NULL REFERENCES message_batches(id) ON DELETE CASCADE,
+    custom_id VARCHAR(64) NOT NULL,
+    params TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    result TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    account_id BIGINT,
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_batch_items_batch_status ON message_batch_items (batch_id, status);
+CREATE INDEX IF NOT EXISTS idx_message_batch_items_status_locked_until ON message_batch_items (status, locked_until);
@@ -0,0 +1,38 @@
+-- Balance ledger
+-- Append-only trail of every users.balance mutation (usage deductions, redeem codes, promo bonuses,
+-- admin adjustments). For each user SUM(amount) must equal users.balance; a reconciliation job flags drift.
+
+CREATE TABLE IF NOT EXISTS balance_ledger_entries (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    entry_type VARCHAR(32) NOT NULL,
+    amount DECIMAL(20,8) NOT NULL,
+    balance_before DECIMAL(20,8) NOT NULL,
+    balance_after DECIMAL(20,8) NOT NULL,
+    reference_id BIGINT,
+    api_key_id BIGINT,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_balance_ledger_entries_user_created ON balance_ledger_entries (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_balance_ledger_entries_type_ref ON balance_ledger_entries (entry_type, reference_id);
+
+-- 只追加：禁止修改或删除已有流水
+CREATE OR REPLACE FUNCTION balance_ledger_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'balance_ledger_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_balance_ledger_entries_append_only ON balance_ledger_entries;
+CREATE TRIGGER trg_balance_ledger_entries_append_only
+    BEFORE UPDATE OR DELETE ON balance_ledger_entries
+    FOR EACH ROW EXECUTE FUNCTION balance_ledger_entries_append_only();
+
+-- 期初余额：为已有余额的用户写入一条 initial 流水，使流水合计与当前余额一致
+INSERT INTO balance_ledger_entries (user_id, entry_type, amount, balance_before, balance_after, note)
+SELECT id, 'initial', balance, 0, balance, 'opening balance'
+FROM users
+WHERE balance <> 0
+  AND NOT EXISTS (SELECT 1 FROM balance_ledger_entries l WHERE l.user_id = users.id);
@@ -0,0 +1,24 @@
+-- Hashed API keys
+-- API keys are stored as HMAC-SHA256(key_salt, key) plus a visible prefix used for lookup and display.
+-- Generated keys (configured prefix + 64 hex chars) keep the configured prefix plus the first 8 random chars,
+-- so lookup buckets stay small whatever default.api_key_prefix is; custom keys keep their first 8 chars.
+-- Custom (non-generated) keys additionally wrap the HMAC digest in bcrypt, see service.HashAPIKey.
+-- Existing plaintext keys keep working: the prefix is backfilled here, and the plaintext is hashed and
+-- cleared the first time the key prefix is looked up during authentication.
+
+ALTER TABLE api_keys ALTER COLUMN key DROP NOT NULL;
+
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS key_prefix VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS key_salt VARCHAR(64);
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS key_hash VARCHAR(64);
+
+-- 与 service.APIKeyPrefix 保持一致：
+-- 生成的 Key 取「前缀 + 随机部分前 8 位」（最长 64 位，超出时保留末尾），自定义 Key 取前 8 个字符
+UPDATE api_keys
+SET key_prefix = CASE
+    WHEN key ~ '[0-9a-f]{64}$' THEN RIGHT(LEFT(key, LENGTH(key) - 56), 64)
+    ELSE LEFT(key, 8)
+END
+WHERE key IS NOT NULL AND key_prefix = '';
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_key_prefix ON api_keys (key_prefix);
@@ -0,0 +1,6 @@
+-- Per-group Claude -> OpenAI model mapping used when no Claude account is available.
+-- Matching /v1/messages requests are translated to the Responses API and served by OpenAI accounts bound to the group.
+
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS openai_fallback_model_mapping JSONB DEFAULT '{}';
+
+COMMENT ON COLUMN groups.openai_fallback_model_mapping IS 'Claude 模型 → OpenAI 模型映射：{"claude-sonnet-*": "gpt-5"}，支持末尾通配符，为空表示不启用';
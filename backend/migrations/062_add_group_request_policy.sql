@@ -0,0 +1,6 @@
+-- Per-group request policy enforced before upstream dispatch (Claude / OpenAI Responses / Gemini forwarders).
+-- NULL means no policy.
+
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS request_policy JSONB;
+
+COMMENT ON COLUMN groups.request_policy IS '分组请求策略：{"max_tokens":4096,"max_thinking_budget_tokens":2048,"blocked_tools":["Bash"],"force_temperature":0.2,"system_prompt":"...","blocked_patterns":["(?i)password"]}';
@@ -0,0 +1,36 @@
+-- Per-request sensitive content (PII / secret) detections from group request policy redaction.
+-- Only detector names and hit counts are stored; matched text is never persisted.
+-- ops_* tables intentionally avoid strict foreign keys (see 033_ops_monitoring_vnext.sql).
+
+CREATE TABLE IF NOT EXISTS ops_redaction_logs (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    request_id VARCHAR(64),
+    client_request_id VARCHAR(64),
+
+    user_id BIGINT,
+    api_key_id BIGINT,
+    group_id BIGINT,
+
+    platform VARCHAR(32),
+    model VARCHAR(100),
+    request_path VARCHAR(256),
+
+    -- mask|block
+    action VARCHAR(16) NOT NULL,
+    -- {"<detector>": <count>}
+    detections JSONB NOT NULL DEFAULT '{}'::jsonb,
+    total_count INT NOT NULL DEFAULT 0
+);
+
+COMMENT ON TABLE ops_redaction_logs IS 'Sensitive content detections per request (counts only, never the raw secret).';
+
+CREATE INDEX IF NOT EXISTS idx_ops_redaction_logs_created_at
+    ON ops_redaction_logs (created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_ops_redaction_logs_group_created_at
+    ON ops_redaction_logs (group_id, created_at DESC);
+
+-- Keep the group policy column comment in sync with the new redaction option.
+COMMENT ON COLUMN groups.request_policy IS '分组请求策略：{"max_tokens":4096,"max_thinking_budget_tokens":2048,"blocked_tools":["Bash"],"force_temperature":0.2,"system_prompt":"...","blocked_patterns":["(?i)password"],"redaction":{"detectors":["aws_key","email"],"custom_patterns":[{"name":"ticket","pattern":"TKT-\\d+"}],"action":"mask"}}';
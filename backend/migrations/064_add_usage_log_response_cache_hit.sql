@@ -0,0 +1,12 @@
+-- Record response cache hits in a dedicated flag instead of billing_type.
+-- billing_type keeps meaning balance (0) / subscription (1) so existing filters stay correct;
+-- rows written with the interim billing_type = 2 are mapped back using subscription_id.
+
+ALTER TABLE usage_logs ADD COLUMN IF NOT EXISTS response_cache_hit BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE usage_logs
+SET response_cache_hit = TRUE,
+    billing_type = CASE WHEN subscription_id IS NOT NULL THEN 1 ELSE 0 END
+WHERE billing_type = 2;
+
+COMMENT ON COLUMN usage_logs.response_cache_hit IS '是否为响应缓存命中（按缓存折扣计费，未消耗上游账号）';
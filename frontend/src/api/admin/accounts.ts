@@ -393,6 +393,7 @@ export async function exportData(options?: {
     search?: string
   }
   includeProxies?: boolean
+  includeCredentials?: boolean
 }): Promise<AdminDataPayload> {
   const params: Record<string, string> = {}
   if (options?.ids && options.ids.length > 0) {
@@ -407,6 +408,9 @@ export async function exportData(options?: {
   if (options?.includeProxies === false) {
     params.include_proxies = 'false'
   }
+  if (options?.includeCredentials) {
+    params.include_credentials = 'true'
+  }
   const { data } = await apiClient.get<AdminDataPayload>('/admin/accounts/data', { params })
   return data
 }
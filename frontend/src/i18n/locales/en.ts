@@ -461,7 +461,7 @@ export default {
     saving: 'Saving...',
     noKeysYet: 'No API keys yet',
     createFirstKey: 'Create your first API key to get started with the API.',
-    keyCreatedSuccess: 'API key created successfully',
+    keyCreatedSuccess: 'API key created. Copy it now — the full key is only shown once',
     keyUpdatedSuccess: 'API key updated successfully',
     keyDeletedSuccess: 'API key deleted successfully',
     keyEnabledSuccess: 'API key enabled successfully',
@@ -1275,6 +1275,7 @@ export default {
       dataExport: 'Export',
       dataExportSelected: 'Export Selected',
       dataExportIncludeProxies: 'Include proxies linked to the exported accounts',
+      dataExportIncludeCredentials: 'Include plaintext credentials (audited; otherwise secrets are redacted and the file cannot be imported)',
       dataImport: 'Import',
       dataExportConfirmMessage: 'The exported data contains sensitive account and proxy information. Store it securely.',
       dataExportConfirm: 'Confirm Export',
@@ -462,7 +462,7 @@ export default {
     saving: '保存中...',
     noKeysYet: '暂无 API 密钥',
     createFirstKey: '创建您的第一个 API 密钥以开始使用 API。',
-    keyCreatedSuccess: 'API 密钥创建成功',
+    keyCreatedSuccess: 'API 密钥创建成功，请立即复制，完整密钥仅显示一次',
     keyUpdatedSuccess: 'API 密钥更新成功',
     keyDeletedSuccess: 'API 密钥删除成功',
     keyEnabledSuccess: 'API 密钥已启用',
@@ -1363,6 +1363,7 @@ export default {
       dataExport: '导出',
       dataExportSelected: '导出选中',
       dataExportIncludeProxies: '导出代理（导出账号关联的代理）',
+      dataExportIncludeCredentials: '导出明文凭证（会记录审计日志；不勾选时敏感凭证以占位值导出，无法直接导入）',
       dataImport: '导入',
       dataExportConfirmMessage: '导出的数据包含账号与代理的敏感信息，请妥善保存。',
       dataExportConfirm: '确认导出',